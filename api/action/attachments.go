@@ -0,0 +1,34 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+)
+
+// DownloadAttachment returns a reader for the content of the named
+// file attached to the results of the identified action. It is the
+// caller's responsibility to close the returned reader.
+func (c *Client) DownloadAttachment(tag names.ActionTag, name string) (io.ReadCloser, error) {
+	httpClient, err := c.facade.RawAPICaller().HTTPClient()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	endpoint := fmt.Sprintf("/actions/%s/attachments/%s", tag.Id(), url.QueryEscape(name))
+	req, err := http.NewRequest("GET", endpoint, nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot create HTTP request")
+	}
+	var resp *http.Response
+	if err := httpClient.Do(req, nil, &resp); err != nil {
+		return nil, errors.Annotatef(err, "cannot download attachment %q", name)
+	}
+	return resp.Body, nil
+}
@@ -0,0 +1,47 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"io/ioutil"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+)
+
+type attachmentsSuite struct {
+	baseSuite
+	actionTag names.ActionTag
+}
+
+var _ = gc.Suite(&attachmentsSuite{})
+
+func (s *attachmentsSuite) SetUpTest(c *gc.C) {
+	s.baseSuite.SetUpTest(c)
+	unit := s.Factory.MakeUnit(c, nil)
+	action, err := s.State.EnqueueAction(unit.Tag(), "snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = action.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	s.actionTag = action.ActionTag()
+}
+
+func (s *attachmentsSuite) TestDownloadAttachment(c *gc.C) {
+	_, err := s.State.AddActionAttachment(s.actionTag, "dump.sql", strings.NewReader("some data"), 9, "")
+	c.Assert(err, jc.ErrorIsNil)
+
+	reader, err := s.client.DownloadAttachment(s.actionTag, "dump.sql")
+	c.Assert(err, jc.ErrorIsNil)
+	defer reader.Close()
+	data, err := ioutil.ReadAll(reader)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "some data")
+}
+
+func (s *attachmentsSuite) TestDownloadAttachmentNotFound(c *gc.C) {
+	_, err := s.client.DownloadAttachment(s.actionTag, "missing")
+	c.Assert(err, gc.ErrorMatches, `cannot download attachment "missing": attachment "missing" for action ".*" not found`)
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/charmstore"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/storage"
 )
 
@@ -217,6 +218,117 @@ func (c *Client) Expose(application string) error {
 	return c.facade.FacadeCall("Expose", params, nil)
 }
 
+// ExposeTo changes the juju-managed firewall to expose any ports that
+// were also explicitly marked by units as open, to the given source
+// address ranges, in CIDR notation, and to the subnets of the named
+// spaces only. Restricted exposure requires version 2 or later of the
+// Application facade; older controllers would expose the application to
+// any address.
+func (c *Client) ExposeTo(application string, cidrs, spaces []string) error {
+	if c.facade.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("exposing applications to specific CIDRs or spaces by this controller")
+	}
+	params := params.ApplicationExpose{
+		ApplicationName: application,
+		ToCIDRs:         cidrs,
+		ToSpaces:        spaces,
+	}
+	return c.facade.FacadeCall("Expose", params, nil)
+}
+
+// Offer makes the given endpoints of the application available to be
+// related to by applications in other models on the controller, under
+// the given offer name. The endpoints map the names of the offered
+// endpoints to the names of the application's endpoints.
+func (c *Client) Offer(application, offerName string, endpoints map[string]string) error {
+	args := params.ApplicationOffers{
+		Offers: []params.ApplicationOffer{{
+			OfferName:       offerName,
+			ApplicationName: application,
+			Endpoints:       endpoints,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("Offer", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// Bind changes the spaces the named application's endpoints are bound
+// to. Endpoints not in bindings keep their current bindings.
+func (c *Client) Bind(application string, bindings map[string]string) error {
+	args := params.ApplicationBind{
+		ApplicationName:  application,
+		EndpointBindings: bindings,
+	}
+	return c.facade.FacadeCall("Bind", args, nil)
+}
+
+// RemoveOffer removes the named application offer. Relations already
+// made through the offer are not affected.
+func (c *Client) RemoveOffer(offerName string) error {
+	args := params.RemoveApplicationOffers{
+		OfferNames: []string{offerName},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("RemoveOffers", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// EgressRules returns the egress rules of the named application or,
+// if application is empty, of the model.
+func (c *Client) EgressRules(application string) ([]network.EgressRule, error) {
+	var result params.EgressRulesResult
+	args := params.ApplicationEgressRules{ApplicationName: application}
+	if err := c.facade.FacadeCall("EgressRules", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	rules := make([]network.EgressRule, len(result.Rules))
+	for i, rule := range result.Rules {
+		rules[i] = rule.NetworkEgressRule()
+	}
+	return rules, nil
+}
+
+// SetEgressRules replaces the egress rules of the named application
+// or, if application is empty, of the model.
+func (c *Client) SetEgressRules(application string, rules []network.EgressRule) error {
+	args := params.ApplicationEgressRules{
+		ApplicationName: application,
+		Rules:           make([]params.EgressRule, len(rules)),
+	}
+	for i, rule := range rules {
+		args.Rules[i] = params.FromNetworkEgressRule(rule)
+	}
+	return c.facade.FacadeCall("SetEgressRules", args, nil)
+}
+
+// AllocateVirtualIPAddress allocates a virtual IP address to the named
+// application from the subnet with the given CIDR, and returns it. If
+// address is empty, any free address of the subnet is allocated.
+func (c *Client) AllocateVirtualIPAddress(application, subnetCIDR, address string) (string, error) {
+	var result params.StringResult
+	args := params.ApplicationVirtualIPAddress{
+		ApplicationName: application,
+		SubnetCIDR:      subnetCIDR,
+		Address:         address,
+	}
+	if err := c.facade.FacadeCall("AllocateVirtualIPAddress", args, &result); err != nil {
+		return "", errors.Trace(err)
+	}
+	return result.Result, nil
+}
+
+// ReleaseVirtualIPAddress releases the virtual IP address of the named
+// application.
+func (c *Client) ReleaseVirtualIPAddress(application string) error {
+	args := params.ApplicationVirtualIPAddress{ApplicationName: application}
+	return c.facade.FacadeCall("ReleaseVirtualIPAddress", args, nil)
+}
+
 // Unexpose changes the juju-managed firewall to unexpose any ports that
 // were also explicitly marked by units as open.
 func (c *Client) Unexpose(application string) error {
@@ -271,3 +383,14 @@ func (c *Client) DestroyRelation(endpoints ...string) error {
 	params := params.DestroyRelation{Endpoints: endpoints}
 	return c.facade.FacadeCall("DestroyRelation", params, nil)
 }
+
+// RelationData returns the settings of every unit participating in the
+// relation between the specified endpoints.
+func (c *Client) RelationData(endpoints ...string) (*params.RelationDataResult, error) {
+	var result params.RelationDataResult
+	args := params.RelationDataArgs{Endpoints: endpoints}
+	if err := c.facade.FacadeCall("RelationData", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &result, nil
+}
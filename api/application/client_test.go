@@ -4,6 +4,7 @@
 package application_test
 
 import (
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable"
@@ -15,6 +16,7 @@ import (
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/instance"
 	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/storage"
 )
 
@@ -163,3 +165,193 @@ func (s *serviceSuite) TestServiceSetCharm(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(called, jc.IsTrue)
 }
+
+func (s *serviceSuite) TestExposeTo(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "Expose")
+		c.Assert(a, jc.DeepEquals, params.ApplicationExpose{
+			ApplicationName: "wordpress",
+			ToCIDRs:         []string{"10.0.0.0/8"},
+			ToSpaces:        []string{"internal"},
+		})
+		return nil
+	})
+	err := s.client.ExposeTo("wordpress", []string{"10.0.0.0/8"}, []string{"internal"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestEgressRules(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "EgressRules")
+		c.Assert(a, jc.DeepEquals, params.ApplicationEgressRules{
+			ApplicationName: "wordpress",
+		})
+		result := response.(*params.EgressRulesResult)
+		result.Rules = []params.EgressRule{{
+			PortRange:        params.PortRange{443, 443, "tcp"},
+			DestinationCIDRs: []string{"10.0.0.0/8"},
+		}}
+		return nil
+	})
+	rules, err := s.client.EgressRules("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(rules, jc.DeepEquals, []network.EgressRule{
+		network.NewEgressRule(network.PortRange{443, 443, "tcp"}, "10.0.0.0/8"),
+	})
+}
+
+func (s *serviceSuite) TestSetEgressRules(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "SetEgressRules")
+		c.Assert(a, jc.DeepEquals, params.ApplicationEgressRules{
+			Rules: []params.EgressRule{{
+				PortRange:        params.PortRange{53, 53, "udp"},
+				DestinationCIDRs: []string{"0.0.0.0/0"},
+			}},
+		})
+		return nil
+	})
+	err := s.client.SetEgressRules("", []network.EgressRule{
+		network.NewEgressRule(network.PortRange{53, 53, "udp"}),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestAllocateVirtualIPAddress(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "AllocateVirtualIPAddress")
+		c.Assert(a, jc.DeepEquals, params.ApplicationVirtualIPAddress{
+			ApplicationName: "mysql",
+			SubnetCIDR:      "10.0.0.0/24",
+		})
+		result, ok := response.(*params.StringResult)
+		c.Assert(ok, jc.IsTrue)
+		result.Result = "10.0.0.254"
+		return nil
+	})
+	address, err := s.client.AllocateVirtualIPAddress("mysql", "10.0.0.0/24", "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(address, gc.Equals, "10.0.0.254")
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestReleaseVirtualIPAddress(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "ReleaseVirtualIPAddress")
+		c.Assert(a, jc.DeepEquals, params.ApplicationVirtualIPAddress{
+			ApplicationName: "mysql",
+		})
+		return nil
+	})
+	err := s.client.ReleaseVirtualIPAddress("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestBind(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "Bind")
+		c.Assert(a, jc.DeepEquals, params.ApplicationBind{
+			ApplicationName:  "mysql",
+			EndpointBindings: map[string]string{"server": "db"},
+		})
+		return nil
+	})
+	err := s.client.Bind("mysql", map[string]string{"server": "db"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestOffer(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "Offer")
+		c.Assert(a, jc.DeepEquals, params.ApplicationOffers{
+			Offers: []params.ApplicationOffer{{
+				OfferName:       "hosted-mysql",
+				ApplicationName: "mysql",
+				Endpoints:       map[string]string{"database": "server"},
+			}},
+		})
+		result := response.(*params.ErrorResults)
+		result.Results = []params.ErrorResult{{Error: &params.Error{Message: "boom"}}}
+		return nil
+	})
+	err := s.client.Offer("mysql", "hosted-mysql", map[string]string{"database": "server"})
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestRemoveOffer(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "RemoveOffers")
+		c.Assert(a, jc.DeepEquals, params.RemoveApplicationOffers{
+			OfferNames: []string{"hosted-mysql"},
+		})
+		result := response.(*params.ErrorResults)
+		result.Results = []params.ErrorResult{{Error: &params.Error{Message: "boom"}}}
+		return nil
+	})
+	err := s.client.RemoveOffer("hosted-mysql")
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestExposeToOldController(c *gc.C) {
+	application.PatchBestAPIVersion(s, s.client, 1)
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+	err := s.client.ExposeTo("wordpress", []string{"10.0.0.0/8"}, nil)
+	c.Assert(err, gc.ErrorMatches, "exposing applications to specific CIDRs or spaces by this controller not supported")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *serviceSuite) TestRelationData(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "RelationData")
+		c.Assert(a, jc.DeepEquals, params.RelationDataArgs{
+			Endpoints: []string{"wordpress", "mysql:server"},
+		})
+		result := response.(*params.RelationDataResult)
+		result.RelationId = 2
+		result.Key = "wordpress:db mysql:server"
+		return nil
+	})
+	result, err := s.client.RelationData("wordpress", "mysql:server")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(result, jc.DeepEquals, &params.RelationDataResult{
+		RelationId: 2,
+		Key:        "wordpress:db mysql:server",
+	})
+}
+
+func (s *serviceSuite) TestRelationDataError(c *gc.C) {
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		return errors.New("boom")
+	})
+	_, err := s.client.RelationData("wordpress", "mysql")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
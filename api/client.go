@@ -96,6 +96,27 @@ func (c *Client) StatusHistory(kind status.HistoryKind, tag names.Tag, filter st
 	return history, nil
 }
 
+// HookHistory returns the most recent hook executions recorded for
+// the given unit, oldest first. Hook history requires version 2 or
+// later of the Client facade.
+func (c *Client) HookHistory(tag names.UnitTag) ([]params.HookExecution, error) {
+	if c.facade.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("hook history by this controller")
+	}
+	var results params.HookHistoryResults
+	args := params.Entities{Entities: []params.Entity{{Tag: tag.String()}}}
+	if err := c.facade.FacadeCall("HookHistory", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, err
+	}
+	return results.Results[0].Executions, nil
+}
+
 // Resolved clears errors on a unit.
 func (c *Client) Resolved(unit string, retry bool) error {
 	p := params.Resolved{
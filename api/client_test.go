@@ -330,6 +330,57 @@ func (s *clientSuite) TestClientEnvironmentUsers(c *gc.C) {
 	})
 }
 
+func (s *clientSuite) TestHookHistory(c *gc.C) {
+	client := s.APIState.Client()
+	execution := params.HookExecution{Hook: "install", RelationId: -1}
+	cleanup := api.PatchClientFacadeCall(client,
+		func(request string, paramsIn interface{}, response interface{}) error {
+			c.Assert(request, gc.Equals, "HookHistory")
+			c.Assert(paramsIn, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "unit-mysql-0"}},
+			})
+			result, ok := response.(*params.HookHistoryResults)
+			c.Assert(ok, jc.IsTrue)
+			result.Results = []params.HookHistoryResult{{
+				Executions: []params.HookExecution{execution},
+			}}
+			return nil
+		},
+	)
+	defer cleanup()
+
+	obtained, err := client.HookHistory(names.NewUnitTag("mysql/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(obtained, jc.DeepEquals, []params.HookExecution{execution})
+}
+
+func (s *clientSuite) TestHookHistoryError(c *gc.C) {
+	client := s.APIState.Client()
+	cleanup := api.PatchClientFacadeCall(client,
+		func(request string, paramsIn interface{}, response interface{}) error {
+			result := response.(*params.HookHistoryResults)
+			result.Results = []params.HookHistoryResult{{
+				Error: &params.Error{Message: "boom"},
+			}}
+			return nil
+		},
+	)
+	defer cleanup()
+
+	_, err := client.HookHistory(names.NewUnitTag("mysql/0"))
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *clientSuite) TestHookHistoryOldController(c *gc.C) {
+	client := s.APIState.Client()
+	cleanup := api.PatchClientBestAPIVersion(client, 1)
+	defer cleanup()
+
+	_, err := client.HookHistory(names.NewUnitTag("mysql/0"))
+	c.Assert(err, gc.ErrorMatches, "hook history by this controller not supported")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *clientSuite) TestDestroyEnvironment(c *gc.C) {
 	client := s.APIState.Client()
 	var called bool
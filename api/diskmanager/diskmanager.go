@@ -4,6 +4,7 @@
 package diskmanager
 
 import (
+	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/base"
@@ -43,3 +44,66 @@ func (st *State) SetMachineBlockDevices(devices []storage.BlockDevice) error {
 	}
 	return results.OneError()
 }
+
+// MachineFilesystemAttachments returns the mounted filesystem attachments
+// of the machine identified by the authenticated machine tag.
+func (st *State) MachineFilesystemAttachments() ([]storage.FilesystemAttachment, error) {
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: st.tag.String()}},
+	}
+	var results params.FilesystemAttachmentsResults
+	err := st.facade.FacadeCall("MachineFilesystemAttachments", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	attachments := make([]storage.FilesystemAttachment, len(result.Result))
+	for i, attachment := range result.Result {
+		filesystemTag, err := names.ParseFilesystemTag(attachment.FilesystemTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		attachments[i] = storage.FilesystemAttachment{
+			Filesystem: filesystemTag,
+			Machine:    st.tag,
+			FilesystemAttachmentInfo: storage.FilesystemAttachmentInfo{
+				Path:     attachment.Info.MountPoint,
+				ReadOnly: attachment.Info.ReadOnly,
+			},
+		}
+	}
+	return attachments, nil
+}
+
+// SetFilesystemAttachmentUsage records the space and inode usage of
+// filesystems attached to the machine identified by the authenticated
+// machine tag.
+func (st *State) SetFilesystemAttachmentUsage(usage []storage.FilesystemUsage) error {
+	args := params.SetFilesystemAttachmentUsage{
+		Usage: make([]params.FilesystemAttachmentUsage, len(usage)),
+	}
+	for i, u := range usage {
+		args.Usage[i] = params.FilesystemAttachmentUsage{
+			FilesystemTag: u.Filesystem.String(),
+			MachineTag:    st.tag.String(),
+			Usage: params.FilesystemUsage{
+				UsedBytes:  u.UsedBytes,
+				FreeBytes:  u.FreeBytes,
+				UsedInodes: u.UsedInodes,
+				FreeInodes: u.FreeInodes,
+			},
+		}
+	}
+	var results params.ErrorResults
+	err := st.facade.FacadeCall("SetFilesystemAttachmentUsage", args, &results)
+	if err != nil {
+		return err
+	}
+	return results.Combine()
+}
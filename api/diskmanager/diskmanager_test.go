@@ -124,3 +124,94 @@ func (s *DiskManagerSuite) TestSetMachineBlockDevicesResultCountInvalid(c *gc.C)
 		c.Check(err, gc.ErrorMatches, fmt.Sprintf("expected 1 result, got %d", n))
 	}
 }
+
+func (s *DiskManagerSuite) TestMachineFilesystemAttachments(c *gc.C) {
+	var callCount int
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "DiskManager")
+		c.Check(request, gc.Equals, "MachineFilesystemAttachments")
+		c.Check(arg, gc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "machine-123"}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.FilesystemAttachmentsResults{})
+		*(result.(*params.FilesystemAttachmentsResults)) = params.FilesystemAttachmentsResults{
+			Results: []params.FilesystemAttachmentsResult{{
+				Result: []params.FilesystemAttachment{{
+					FilesystemTag: "filesystem-0",
+					MachineTag:    "machine-123",
+					Info: params.FilesystemAttachmentInfo{
+						MountPoint: "/srv",
+						ReadOnly:   true,
+					},
+				}},
+			}},
+		}
+		callCount++
+		return nil
+	})
+	st := diskmanager.NewState(apiCaller, names.NewMachineTag("123"))
+	attachments, err := st.MachineFilesystemAttachments()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(callCount, gc.Equals, 1)
+	c.Assert(attachments, jc.DeepEquals, []storage.FilesystemAttachment{{
+		Filesystem: names.NewFilesystemTag("0"),
+		Machine:    names.NewMachineTag("123"),
+		FilesystemAttachmentInfo: storage.FilesystemAttachmentInfo{
+			Path:     "/srv",
+			ReadOnly: true,
+		},
+	}})
+}
+
+func (s *DiskManagerSuite) TestMachineFilesystemAttachmentsServerError(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.FilesystemAttachmentsResults)) = params.FilesystemAttachmentsResults{
+			Results: []params.FilesystemAttachmentsResult{{
+				Error: &params.Error{Message: "MSG", Code: "621"},
+			}},
+		}
+		return nil
+	})
+	st := diskmanager.NewState(apiCaller, names.NewMachineTag("123"))
+	_, err := st.MachineFilesystemAttachments()
+	c.Check(err, gc.ErrorMatches, "MSG")
+}
+
+func (s *DiskManagerSuite) TestSetFilesystemAttachmentUsage(c *gc.C) {
+	var callCount int
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "DiskManager")
+		c.Check(request, gc.Equals, "SetFilesystemAttachmentUsage")
+		c.Check(arg, gc.DeepEquals, params.SetFilesystemAttachmentUsage{
+			Usage: []params.FilesystemAttachmentUsage{{
+				FilesystemTag: "filesystem-0",
+				MachineTag:    "machine-123",
+				Usage: params.FilesystemUsage{
+					UsedBytes:  1,
+					FreeBytes:  2,
+					UsedInodes: 3,
+					FreeInodes: 4,
+				},
+			}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{
+				Error: &params.Error{Message: "MSG", Code: "621"},
+			}},
+		}
+		callCount++
+		return nil
+	})
+	st := diskmanager.NewState(apiCaller, names.NewMachineTag("123"))
+	err := st.SetFilesystemAttachmentUsage([]storage.FilesystemUsage{{
+		Filesystem: names.NewFilesystemTag("0"),
+		Machine:    names.NewMachineTag("123"),
+		UsedBytes:  1,
+		FreeBytes:  2,
+		UsedInodes: 3,
+		FreeInodes: 4,
+	}})
+	c.Check(err, gc.ErrorMatches, "MSG")
+	c.Check(callCount, gc.Equals, 1)
+}
@@ -64,14 +64,15 @@ func NewTestingState(params TestingStateParams) Connection {
 // original state.
 func PatchClientFacadeCall(c *Client, mockCall func(request string, params interface{}, response interface{}) error) func() {
 	orig := c.facade
-	c.facade = &resultCaller{mockCall}
+	c.facade = &resultCaller{mockCall, orig.BestAPIVersion()}
 	return func() {
 		c.facade = orig
 	}
 }
 
 type resultCaller struct {
-	mockCall func(request string, params interface{}, response interface{}) error
+	mockCall    func(request string, params interface{}, response interface{}) error
+	bestVersion int
 }
 
 func (f *resultCaller) FacadeCall(request string, params, response interface{}) error {
@@ -83,13 +84,24 @@ func (f *resultCaller) Name() string {
 }
 
 func (f *resultCaller) BestAPIVersion() int {
-	return 0
+	return f.bestVersion
 }
 
 func (f *resultCaller) RawAPICaller() base.APICaller {
 	return nil
 }
 
+// PatchClientBestAPIVersion changes the internal FacadeCaller to one
+// whose BestAPIVersion returns the given version. The function returned
+// is a cleanup function that returns the client to its original state.
+func PatchClientBestAPIVersion(c *Client, version int) func() {
+	orig := c.facade
+	c.facade = &resultCaller{orig.FacadeCall, version}
+	return func() {
+		c.facade = orig
+	}
+}
+
 // IsMinVersionError returns true if the given error was caused by the charm
 // having a minjujuversion higher than the juju environment's version.
 func IsMinVersionError(err error) bool {
@@ -21,14 +21,14 @@ var facadeVersions = map[string]int{
 	"AllModelWatcher":              2,
 	"AllWatcher":                   1,
 	"Annotations":                  2,
-	"Application":                  1,
+	"Application":                  2,
 	"ApplicationScaler":            1,
 	"Backups":                      1,
 	"Block":                        2,
 	"CharmRevisionUpdater":         2,
 	"Charms":                       2,
 	"Cleaner":                      2,
-	"Client":                       1,
+	"Client":                       2,
 	"Cloud":                        1,
 	"Controller":                   3,
 	"Deployer":                     1,
@@ -36,7 +36,7 @@ var facadeVersions = map[string]int{
 	"DiskManager":                  2,
 	"EntityWatcher":                2,
 	"FilesystemAttachmentsWatcher": 2,
-	"Firewaller":                   3,
+	"Firewaller":                   4,
 	"HighAvailability":             2,
 	"HostKeyReporter":              1,
 	"ImageManager":                 2,
@@ -66,6 +66,7 @@ var facadeVersions = map[string]int{
 	"ProxyUpdater":                 1,
 	"Reboot":                       2,
 	"RelationUnitsWatcher":         1,
+	"RemoteRelations":              1,
 	"Resumer":                      2,
 	"RetryStrategy":                1,
 	"Singular":                     1,
@@ -78,7 +79,7 @@ var facadeVersions = map[string]int{
 	"Subnets":                      2,
 	"Undertaker":                   1,
 	"UnitAssigner":                 1,
-	"Uniter":                       4,
+	"Uniter":                       5,
 	"Upgrader":                     1,
 	"UserManager":                  1,
 	"VolumeAttachmentsWatcher":     2,
@@ -115,3 +115,21 @@ func (st *State) WatchOpenedPorts() (watcher.StringsWatcher, error) {
 	w := apiwatcher.NewStringsWatcher(st.facade.RawAPICaller(), result)
 	return w, nil
 }
+
+// WatchSubnets returns a NotifyWatcher that notifies of changes to the
+// model's subnets and spaces.
+func (st *State) WatchSubnets() (watcher.NotifyWatcher, error) {
+	if st.facade.BestAPIVersion() < 4 {
+		return nil, errors.NotImplementedf("WatchSubnets() (need V4+)")
+	}
+	var result params.NotifyWatchResult
+	err := st.facade.FacadeCall("WatchSubnets", nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	if err := result.Error; err != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewNotifyWatcher(st.facade.RawAPICaller(), result)
+	return w, nil
+}
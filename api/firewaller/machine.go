@@ -6,6 +6,7 @@ package firewaller
 import (
 	"fmt"
 
+	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
 	apiwatcher "github.com/juju/juju/api/watcher"
@@ -108,6 +109,44 @@ func (m *Machine) ActiveSubnets() ([]names.SubnetTag, error) {
 	return tags, nil
 }
 
+// SetFirewallDrift records how the ingress rules applied to the
+// machine's instance differ from those expected. Passing no rules
+// clears any previously recorded drift.
+func (m *Machine) SetFirewallDrift(missing, unexpected []network.IngressRule) error {
+	if m.st.facade.BestAPIVersion() < 4 {
+		return errors.NotImplementedf("SetFirewallDrift() (need V4+)")
+	}
+	var results params.ErrorResults
+	args := params.MachinesFirewallDrift{
+		Machines: []params.MachineFirewallDrift{{
+			Tag: m.tag.String(),
+			Drift: params.FirewallDrift{
+				Missing:    ingressRuleStrings(missing),
+				Unexpected: ingressRuleStrings(unexpected),
+			},
+		}},
+	}
+	err := m.st.facade.FacadeCall("SetFirewallDrift", args, &results)
+	if err != nil {
+		return err
+	}
+	return results.OneError()
+}
+
+func ingressRuleStrings(rules []network.IngressRule) []string {
+	if len(rules) == 0 {
+		return nil
+	}
+	sorted := make([]network.IngressRule, len(rules))
+	copy(sorted, rules)
+	network.SortIngressRules(sorted)
+	result := make([]string, len(sorted))
+	for i, rule := range sorted {
+		result[i] = rule.String()
+	}
+	return result
+}
+
 // OpenedPorts returns a map of network.PortRange to unit tag for all opened
 // port ranges on the machine for the subnet matching given subnetTag.
 func (m *Machine) OpenedPorts(subnetTag names.SubnetTag) (map[network.PortRange]names.UnitTag, error) {
@@ -94,6 +94,32 @@ func (s *machineSuite) TestWatchUnits(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *machineSuite) TestSetFirewallDrift(c *gc.C) {
+	err := s.apiMachine.SetFirewallDrift(
+		[]network.IngressRule{
+			network.NewIngressRule(network.PortRange{8080, 8080, "tcp"}),
+			network.NewIngressRule(network.PortRange{80, 80, "tcp"}),
+		},
+		[]network.IngressRule{
+			network.NewIngressRule(network.PortRange{22, 22, "tcp"}, "10.0.0.0/8"),
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.machines[0].Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.machines[0].FirewallDrift(), jc.DeepEquals, state.FirewallDrift{
+		Missing:    []string{"80/tcp", "8080/tcp"},
+		Unexpected: []string{"22/tcp from 10.0.0.0/8"},
+	})
+
+	err = s.apiMachine.SetFirewallDrift(nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machines[0].Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.machines[0].FirewallDrift().IsEmpty(), jc.IsTrue)
+}
+
 func (s *machineSuite) TestActiveSubnets(c *gc.C) {
 	// No ports opened at first, no active subnets.
 	subnets, err := s.apiMachine.ActiveSubnets()
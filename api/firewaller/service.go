@@ -6,10 +6,12 @@ package firewaller
 import (
 	"fmt"
 
+	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/watcher"
 )
 
@@ -75,3 +77,81 @@ func (s *Application) IsExposed() (bool, error) {
 	}
 	return result.Result, nil
 }
+
+// ExposedSourceCIDRs returns the source address ranges, in CIDR
+// notation, from which the explicitly open ports of the service may
+// be accessed when it is exposed.
+func (s *Application) ExposedSourceCIDRs() ([]string, error) {
+	if s.st.facade.BestAPIVersion() < 4 {
+		return nil, errors.NotImplementedf("ExposedSourceCIDRs() (need V4+)")
+	}
+	var results params.StringsResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: s.tag.String()}},
+	}
+	err := s.st.facade.FacadeCall("GetExposedSourceCIDRs", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Result, nil
+}
+
+// EgressRules returns the egress rules defining the egress traffic
+// permitted from machines hosting the service's units.
+func (s *Application) EgressRules() ([]network.EgressRule, error) {
+	if s.st.facade.BestAPIVersion() < 4 {
+		return nil, errors.NotImplementedf("EgressRules() (need V4+)")
+	}
+	var results params.EgressRulesResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: s.tag.String()}},
+	}
+	err := s.st.facade.FacadeCall("GetEgressRules", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	rules := make([]network.EgressRule, len(result.Rules))
+	for i, rule := range result.Rules {
+		rules[i] = rule.NetworkEgressRule()
+	}
+	return rules, nil
+}
+
+// RelationIngressCIDRs returns the address ranges, in CIDR notation,
+// of the units of remote applications related to the service. These
+// may access the service's open ports whether or not it is exposed.
+func (s *Application) RelationIngressCIDRs() ([]string, error) {
+	if s.st.facade.BestAPIVersion() < 4 {
+		return nil, errors.NotImplementedf("RelationIngressCIDRs() (need V4+)")
+	}
+	var results params.StringsResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: s.tag.String()}},
+	}
+	err := s.st.facade.FacadeCall("GetRelationIngressCIDRs", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Result, nil
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/juju/api/firewaller"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/watcher/watchertest"
 )
 
@@ -91,3 +92,51 @@ func (s *serviceSuite) TestIsExposed(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(isExposed, jc.IsFalse)
 }
+
+func (s *serviceSuite) TestExposedSourceCIDRs(c *gc.C) {
+	err := s.application.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+
+	cidrs, err := s.apiApplication.ExposedSourceCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, jc.DeepEquals, []string{"0.0.0.0/0"})
+
+	err = s.application.SetExposedTo([]string{"10.0.0.0/8", "192.168.1.0/24"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cidrs, err = s.apiApplication.ExposedSourceCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, jc.DeepEquals, []string{"10.0.0.0/8", "192.168.1.0/24"})
+}
+
+func (s *serviceSuite) TestEgressRules(c *gc.C) {
+	rules, err := s.apiApplication.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.HasLen, 0)
+
+	err = s.application.SetEgressRules([]network.EgressRule{
+		network.NewEgressRule(network.PortRange{53, 53, "udp"}),
+		network.NewEgressRule(network.PortRange{443, 443, "tcp"}, "10.0.0.0/8"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	rules, err = s.apiApplication.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.EgressRule{
+		network.NewEgressRule(network.PortRange{443, 443, "tcp"}, "10.0.0.0/8"),
+		network.NewEgressRule(network.PortRange{53, 53, "udp"}),
+	})
+}
+
+func (s *serviceSuite) TestRelationIngressCIDRs(c *gc.C) {
+	cidrs, err := s.apiApplication.RelationIngressCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, gc.HasLen, 0)
+
+	err = s.application.SetRelationIngressCIDR("remote-wordpress/0", "8.8.8.8/32")
+	c.Assert(err, jc.ErrorIsNil)
+
+	cidrs, err = s.apiApplication.RelationIngressCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, jc.DeepEquals, []string{"8.8.8.8/32"})
+}
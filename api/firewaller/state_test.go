@@ -100,3 +100,17 @@ func (s *stateSuite) TestWatchOpenedPorts(c *gc.C) {
 	wc.AssertChange("1:")
 	wc.AssertNoChange()
 }
+
+func (s *stateSuite) TestWatchSubnets(c *gc.C) {
+	w, err := s.firewaller.WatchSubnets()
+	c.Assert(err, jc.ErrorIsNil)
+	wc := watchertest.NewNotifyWatcherC(c, w, s.BackingState.StartSync)
+	defer wc.AssertStops()
+
+	// Initial event.
+	wc.AssertOneChange()
+
+	_, err = s.State.AddSubnet(state.SubnetInfo{CIDR: "10.0.0.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
@@ -0,0 +1,150 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remoterelations
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
+)
+
+const remoteRelationsFacade = "RemoteRelations"
+
+// State provides access to the RemoteRelations API facade.
+type State struct {
+	facade base.FacadeCaller
+}
+
+// NewState creates a new client-side RemoteRelations facade.
+func NewState(caller base.APICaller) *State {
+	facadeCaller := base.NewFacadeCaller(caller, remoteRelationsFacade)
+	return &State{facadeCaller}
+}
+
+// WatchRemoteApplications returns a strings watcher that notifies of
+// the addition, removal, and lifecycle changes of remote applications
+// in the model.
+func (st *State) WatchRemoteApplications() (watcher.StringsWatcher, error) {
+	var result params.StringsWatchResult
+	err := st.facade.FacadeCall("WatchRemoteApplications", nil, &result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewStringsWatcher(st.facade.RawAPICaller(), result)
+	return w, nil
+}
+
+// RemoteApplications returns the details of the remote applications
+// with the given names.
+func (st *State) RemoteApplications(applications []string) ([]params.RemoteApplicationResult, error) {
+	args := params.Entities{Entities: make([]params.Entity, len(applications))}
+	for i, application := range applications {
+		if !names.IsValidApplication(application) {
+			return nil, errors.NotValidf("application name %q", application)
+		}
+		args.Entities[i].Tag = names.NewApplicationTag(application).String()
+	}
+	var results params.RemoteApplicationResults
+	err := st.facade.FacadeCall("RemoteApplications", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(applications) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(applications), len(results.Results))
+	}
+	return results.Results, nil
+}
+
+// WatchRemoteApplicationRelations returns a strings watcher that
+// notifies of changes to the keys of the relations in which the
+// named remote application takes part.
+func (st *State) WatchRemoteApplicationRelations(application string) (watcher.StringsWatcher, error) {
+	if !names.IsValidApplication(application) {
+		return nil, errors.NotValidf("application name %q", application)
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag(application).String()}},
+	}
+	var results params.StringsWatchResults
+	err := st.facade.FacadeCall("WatchRemoteApplicationRelations", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewStringsWatcher(st.facade.RawAPICaller(), result)
+	return w, nil
+}
+
+// Relations returns the details of the relations with the given keys.
+func (st *State) Relations(keys []string) ([]params.RemoteRelationResult, error) {
+	args := params.Entities{Entities: make([]params.Entity, len(keys))}
+	for i, key := range keys {
+		if !names.IsValidRelation(key) {
+			return nil, errors.NotValidf("relation key %q", key)
+		}
+		args.Entities[i].Tag = names.NewRelationTag(key).String()
+	}
+	var results params.RemoteRelationResults
+	err := st.facade.FacadeCall("Relations", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(keys) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(keys), len(results.Results))
+	}
+	return results.Results, nil
+}
+
+// WatchLocalRelationUnits returns a watcher that notifies of changes
+// to the local units taking part in the relation with the given key.
+func (st *State) WatchLocalRelationUnits(relationKey string) (watcher.RelationUnitsWatcher, error) {
+	if !names.IsValidRelation(relationKey) {
+		return nil, errors.NotValidf("relation key %q", relationKey)
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewRelationTag(relationKey).String()}},
+	}
+	var results params.RelationUnitsWatchResults
+	err := st.facade.FacadeCall("WatchLocalRelationUnits", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewRelationUnitsWatcher(st.facade.RawAPICaller(), result)
+	return w, nil
+}
+
+// PublishLocalRelationChange publishes the given change, made to the
+// local side of a relation with a remote application, to the model
+// hosting the remote application.
+func (st *State) PublishLocalRelationChange(change params.RemoteRelationChange) error {
+	args := params.RemoteRelationChanges{
+		Changes: []params.RemoteRelationChange{change},
+	}
+	var results params.ErrorResults
+	err := st.facade.FacadeCall("PublishLocalRelationChanges", args, &results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
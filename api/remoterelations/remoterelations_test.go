@@ -0,0 +1,118 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remoterelations_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base"
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/remoterelations"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type remoteRelationsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&remoteRelationsSuite{})
+
+func (s *remoteRelationsSuite) TestRemoteApplications(c *gc.C) {
+	var called bool
+	caller := apiCaller(c, func(request string, arg, result interface{}) error {
+		called = true
+		c.Check(request, gc.Equals, "RemoteApplications")
+		c.Check(arg, gc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "application-hosted-mysql"}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.RemoteApplicationResults{})
+		*(result.(*params.RemoteApplicationResults)) = params.RemoteApplicationResults{
+			Results: []params.RemoteApplicationResult{{
+				Result: &params.RemoteApplication{Name: "hosted-mysql"},
+			}},
+		}
+		return nil
+	})
+	st := remoterelations.NewState(caller)
+	results, err := st.RemoteApplications([]string{"hosted-mysql"})
+	c.Check(called, jc.IsTrue)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.RemoteApplicationResult{{
+		Result: &params.RemoteApplication{Name: "hosted-mysql"},
+	}})
+}
+
+func (s *remoteRelationsSuite) TestRemoteApplicationsBadName(c *gc.C) {
+	caller := apiCaller(c, func(_ string, _, _ interface{}) error {
+		panic("should not be called")
+	})
+	st := remoterelations.NewState(caller)
+	_, err := st.RemoteApplications([]string{"bad/name"})
+	c.Assert(err, gc.ErrorMatches, `application name "bad/name" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *remoteRelationsSuite) TestRelations(c *gc.C) {
+	var called bool
+	caller := apiCaller(c, func(request string, arg, result interface{}) error {
+		called = true
+		c.Check(request, gc.Equals, "Relations")
+		c.Check(arg, gc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "relation-wordpress.db#hosted-mysql.server"}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.RemoteRelationResults{})
+		*(result.(*params.RemoteRelationResults)) = params.RemoteRelationResults{
+			Results: []params.RemoteRelationResult{{
+				Error: &params.Error{Message: "FAIL"},
+			}},
+		}
+		return nil
+	})
+	st := remoterelations.NewState(caller)
+	results, err := st.Relations([]string{"wordpress:db hosted-mysql:server"})
+	c.Check(called, jc.IsTrue)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, "FAIL")
+}
+
+func (s *remoteRelationsSuite) TestPublishLocalRelationChange(c *gc.C) {
+	var called bool
+	change := params.RemoteRelationChange{
+		Relation: params.RemoteRelation{
+			Key: "wordpress:db hosted-mysql:server",
+		},
+		ChangedUnits: []string{"wordpress/0"},
+	}
+	caller := apiCaller(c, func(request string, arg, result interface{}) error {
+		called = true
+		c.Check(request, gc.Equals, "PublishLocalRelationChanges")
+		c.Check(arg, jc.DeepEquals, params.RemoteRelationChanges{
+			Changes: []params.RemoteRelationChange{change},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{
+				Error: &params.Error{Message: "FAIL"},
+			}},
+		}
+		return nil
+	})
+	st := remoterelations.NewState(caller)
+	err := st.PublishLocalRelationChange(change)
+	c.Check(called, jc.IsTrue)
+	c.Assert(err, gc.ErrorMatches, "FAIL")
+}
+
+func apiCaller(c *gc.C, check func(request string, arg, result interface{}) error) base.APICaller {
+	return apitesting.APICallerFunc(func(facade string, version int, id, request string, arg, result interface{}) error {
+		c.Check(facade, gc.Equals, "RemoteRelations")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		return check(request, arg, result)
+	})
+}
@@ -59,11 +59,13 @@ func (c *Client) ListStorageDetails() ([]params.StorageDetails, error) {
 
 // ListPools returns a list of pools that matches given filter.
 // If no filter was provided, a list of all pools is returned.
-func (c *Client) ListPools(providers, names []string) ([]params.StoragePool, error) {
+// If usage is true, each pool's usage is also reported.
+func (c *Client) ListPools(providers, names []string, usage bool) ([]params.StoragePool, error) {
 	args := params.StoragePoolFilters{
 		Filters: []params.StoragePoolFilter{{
 			Names:     names,
 			Providers: providers,
+			Usage:     usage,
 		}},
 	}
 	var results params.StoragePoolsResults
@@ -89,6 +91,16 @@ func (c *Client) CreatePool(pname, provider string, attrs map[string]interface{}
 	return c.facade.FacadeCall("CreatePool", args, nil)
 }
 
+// UpdatePool updates the attributes of an existing pool, merging
+// the specified attributes with the existing ones.
+func (c *Client) UpdatePool(pname string, attrs map[string]interface{}) error {
+	args := params.StoragePool{
+		Name:  pname,
+		Attrs: attrs,
+	}
+	return c.facade.FacadeCall("UpdatePool", args, nil)
+}
+
 // ListVolumes lists volumes for desired machines.
 // If no machines provided, a list of all volumes is returned.
 func (c *Client) ListVolumes(machines []string) ([]params.VolumeDetailsListResult, error) {
@@ -147,3 +159,181 @@ func (c *Client) AddToUnit(storages []params.StorageAddParams) ([]params.ErrorRe
 	}
 	return out.Results, nil
 }
+
+// CreateVolumeSnapshots requests snapshots of the volumes backing the
+// specified storage instances.
+func (c *Client) CreateVolumeSnapshots(tags []names.StorageTag) ([]params.VolumeSnapshotDetailsResult, error) {
+	entities := make([]params.Entity, len(tags))
+	for i, tag := range tags {
+		entities[i] = params.Entity{Tag: tag.String()}
+	}
+	var results params.VolumeSnapshotDetailsResults
+	if err := c.facade.FacadeCall("CreateVolumeSnapshots", params.Entities{Entities: entities}, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(tags) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(tags), len(results.Results))
+	}
+	return results.Results, nil
+}
+
+// ListVolumeSnapshots returns the details of all volume snapshots in
+// the model.
+func (c *Client) ListVolumeSnapshots() ([]params.VolumeSnapshotDetailsResult, error) {
+	var results params.VolumeSnapshotDetailsResults
+	if err := c.facade.FacadeCall("ListVolumeSnapshots", nil, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Results, nil
+}
+
+// DestroyVolumeSnapshots requests that the specified volume snapshots
+// be deleted.
+func (c *Client) DestroyVolumeSnapshots(ids []string) ([]params.ErrorResult, error) {
+	var results params.ErrorResults
+	args := params.VolumeSnapshotIds{Ids: ids}
+	if err := c.facade.FacadeCall("DestroyVolumeSnapshots", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(ids) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(ids), len(results.Results))
+	}
+	return results.Results, nil
+}
+
+// ResizeStorage requests that the specified storage instance be grown
+// to the specified size, in MiB.
+func (c *Client) ResizeStorage(tag names.StorageTag, size uint64) error {
+	args := params.StoragesResizeParams{
+		Storages: []params.StorageResizeParams{{
+			StorageTag: tag.String(),
+			Size:       size,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("ResizeStorage", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// Attach attaches the specified existing storage instance to the
+// specified unit.
+func (c *Client) Attach(storage names.StorageTag, unit names.UnitTag) error {
+	args := params.StorageAttachmentIds{
+		Ids: []params.StorageAttachmentId{{
+			StorageTag: storage.String(),
+			UnitTag:    unit.String(),
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("Attach", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// Detach detaches the specified storage instances from the units
+// to which they are attached.
+func (c *Client) Detach(tags []names.StorageTag) ([]params.ErrorResult, error) {
+	entities := make([]params.Entity, len(tags))
+	for i, tag := range tags {
+		entities[i] = params.Entity{Tag: tag.String()}
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("Detach", params.Entities{Entities: entities}, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(tags) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(tags), len(results.Results))
+	}
+	return results.Results, nil
+}
+
+// Remove removes the specified storage instances from the model. If
+// release is true, the cloud storage is released from the model and
+// left intact; otherwise it is destroyed.
+func (c *Client) Remove(tags []names.StorageTag, release bool) ([]params.ErrorResult, error) {
+	args := params.RemoveStorage{
+		Storage: make([]params.RemoveStorageInstance, len(tags)),
+	}
+	for i, tag := range tags {
+		args.Storage[i] = params.RemoveStorageInstance{
+			Tag:     tag.String(),
+			Release: release,
+		}
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("Remove", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(tags) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(tags), len(results.Results))
+	}
+	return results.Results, nil
+}
+
+// ImportFilesystem imports the filesystem, or the volume backing it,
+// with the specified provider ID from the specified storage pool into
+// the model, creating a storage instance with the specified storage
+// name. The tag of the new storage instance is returned.
+func (c *Client) ImportFilesystem(pool, providerId, storageName string) (names.StorageTag, error) {
+	args := params.ImportFilesystemsParams{
+		Filesystems: []params.ImportFilesystemParams{{
+			Pool:        pool,
+			ProviderId:  providerId,
+			StorageName: storageName,
+		}},
+	}
+	var results params.ImportStorageResults
+	if err := c.facade.FacadeCall("ImportFilesystem", args, &results); err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return names.StorageTag{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return names.StorageTag{}, err
+	}
+	return names.ParseStorageTag(results.Results[0].Result.StorageTag)
+}
+
+// StorageQuota returns the storage quota of the specified application,
+// or of the model if application is empty, along with the usage of each
+// storage pool that is accounted against the quota.
+func (c *Client) StorageQuota(application string) (params.StorageQuotaDetails, error) {
+	var filter params.StorageQuotaFilter
+	if application != "" {
+		filter.ApplicationTag = names.NewApplicationTag(application).String()
+	}
+	args := params.StorageQuotaFilters{
+		Filters: []params.StorageQuotaFilter{filter},
+	}
+	var results params.StorageQuotaResults
+	if err := c.facade.FacadeCall("StorageQuotas", args, &results); err != nil {
+		return params.StorageQuotaDetails{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.StorageQuotaDetails{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return params.StorageQuotaDetails{}, err
+	}
+	return *results.Results[0].Result, nil
+}
+
+// SetApplicationStorageQuota sets the storage quota of the specified
+// application.
+func (c *Client) SetApplicationStorageQuota(application string, quota params.StorageQuota) error {
+	args := params.ApplicationStorageQuotas{
+		Quotas: []params.ApplicationStorageQuota{{
+			ApplicationTag: names.NewApplicationTag(application).String(),
+			Quota:          quota,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetApplicationStorageQuotas", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
@@ -191,6 +191,7 @@ func (s *storageMockSuite) TestListPools(c *gc.C) {
 			c.Assert(args.Filters, gc.HasLen, 1)
 			c.Assert(args.Filters[0].Names, gc.HasLen, 2)
 			c.Assert(args.Filters[0].Providers, gc.HasLen, 1)
+			c.Assert(args.Filters[0].Usage, jc.IsTrue)
 
 			results := result.(*params.StoragePoolsResults)
 			pools := make([]params.StoragePool, want)
@@ -209,7 +210,7 @@ func (s *storageMockSuite) TestListPools(c *gc.C) {
 	storageClient := storage.NewClient(apiCaller)
 	names := []string{"a", "b"}
 	types := []string{"1"}
-	found, err := storageClient.ListPools(types, names)
+	found, err := storageClient.ListPools(types, names, true)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(found, gc.HasLen, want)
 	c.Assert(found, gc.DeepEquals, expected)
@@ -230,7 +231,7 @@ func (s *storageMockSuite) TestListPoolsFacadeCallError(c *gc.C) {
 			return errors.New(msg)
 		})
 	storageClient := storage.NewClient(apiCaller)
-	found, err := storageClient.ListPools(nil, nil)
+	found, err := storageClient.ListPools(nil, nil, false)
 	c.Assert(errors.Cause(err), gc.ErrorMatches, msg)
 	c.Assert(found, gc.HasLen, 0)
 }
@@ -269,6 +270,35 @@ func (s *storageMockSuite) TestCreatePool(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *storageMockSuite) TestUpdatePool(c *gc.C) {
+	var called bool
+	poolName := "poolName"
+	poolConfig := map[string]interface{}{
+		"test": "one",
+	}
+
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "UpdatePool")
+			c.Check(a, jc.DeepEquals, params.StoragePool{
+				Name:  poolName,
+				Attrs: poolConfig,
+			})
+			return nil
+		})
+	storageClient := storage.NewClient(apiCaller)
+	err := storageClient.UpdatePool(poolName, poolConfig)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
 func (s *storageMockSuite) TestCreatePoolFacadeCallError(c *gc.C) {
 	msg := "facade failure"
 	apiCaller := basetesting.APICallerFunc(
@@ -581,3 +611,393 @@ func (s *storageMockSuite) TestAddToUnitFacadeCallError(c *gc.C) {
 	c.Assert(errors.Cause(err), gc.ErrorMatches, msg)
 	c.Assert(found, gc.HasLen, 0)
 }
+
+func (s *storageMockSuite) TestCreateVolumeSnapshots(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "CreateVolumeSnapshots")
+			c.Check(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "storage-data-0"}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.VolumeSnapshotDetailsResults{})
+			*(result.(*params.VolumeSnapshotDetailsResults)) = params.VolumeSnapshotDetailsResults{
+				Results: []params.VolumeSnapshotDetailsResult{{
+					Result: &params.VolumeSnapshotDetails{
+						Id:         "0:1",
+						VolumeTag:  "volume-0",
+						StorageTag: "storage-data-0",
+						Pool:       "loop",
+						Life:       params.Alive,
+					},
+				}},
+			}
+			return nil
+		})
+	storageClient := storage.NewClient(apiCaller)
+	results, err := storageClient.CreateVolumeSnapshots([]names.StorageTag{
+		names.NewStorageTag("data/0"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.VolumeSnapshotDetailsResult{{
+		Result: &params.VolumeSnapshotDetails{
+			Id:         "0:1",
+			VolumeTag:  "volume-0",
+			StorageTag: "storage-data-0",
+			Pool:       "loop",
+			Life:       params.Alive,
+		},
+	}})
+}
+
+func (s *storageMockSuite) TestListVolumeSnapshots(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ListVolumeSnapshots")
+			c.Check(a, gc.IsNil)
+			c.Assert(result, gc.FitsTypeOf, &params.VolumeSnapshotDetailsResults{})
+			*(result.(*params.VolumeSnapshotDetailsResults)) = params.VolumeSnapshotDetailsResults{
+				Results: []params.VolumeSnapshotDetailsResult{{
+					Result: &params.VolumeSnapshotDetails{
+						Id:        "0:1",
+						VolumeTag: "volume-0",
+						Pool:      "loop",
+						Life:      params.Dying,
+					},
+				}},
+			}
+			return nil
+		})
+	storageClient := storage.NewClient(apiCaller)
+	results, err := storageClient.ListVolumeSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.VolumeSnapshotDetailsResult{{
+		Result: &params.VolumeSnapshotDetails{
+			Id:        "0:1",
+			VolumeTag: "volume-0",
+			Pool:      "loop",
+			Life:      params.Dying,
+		},
+	}})
+}
+
+func (s *storageMockSuite) TestDestroyVolumeSnapshots(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "DestroyVolumeSnapshots")
+			c.Check(a, jc.DeepEquals, params.VolumeSnapshotIds{
+				Ids: []string{"0:1", "0:2"},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{
+					{},
+					{Error: &params.Error{Message: "foo"}},
+				},
+			}
+			return nil
+		})
+	storageClient := storage.NewClient(apiCaller)
+	results, err := storageClient.DestroyVolumeSnapshots([]string{"0:1", "0:2"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: "foo"}},
+	})
+}
+
+func (s *storageMockSuite) TestDestroyVolumeSnapshotsArity(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			return nil
+		})
+	storageClient := storage.NewClient(apiCaller)
+	_, err := storageClient.DestroyVolumeSnapshots([]string{"0:1"})
+	c.Assert(err, gc.ErrorMatches, `expected 1 result\(s\), got 0`)
+}
+
+func (s *storageMockSuite) TestResizeStorage(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ResizeStorage")
+			c.Check(a, jc.DeepEquals, params.StoragesResizeParams{
+				Storages: []params.StorageResizeParams{{
+					StorageTag: "storage-data-0",
+					Size:       2048,
+				}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{
+					Error: &params.Error{Message: "boom"},
+				}},
+			}
+			return nil
+		})
+	storageClient := storage.NewClient(apiCaller)
+	err := storageClient.ResizeStorage(names.NewStorageTag("data/0"), 2048)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *storageMockSuite) TestAttach(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Attach")
+			c.Check(a, jc.DeepEquals, params.StorageAttachmentIds{
+				Ids: []params.StorageAttachmentId{{
+					StorageTag: "storage-data-0",
+					UnitTag:    "unit-mysql-1",
+				}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{
+					Error: &params.Error{Message: "boom"},
+				}},
+			}
+			return nil
+		})
+	storageClient := storage.NewClient(apiCaller)
+	err := storageClient.Attach(names.NewStorageTag("data/0"), names.NewUnitTag("mysql/1"))
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *storageMockSuite) TestDetach(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Detach")
+			c.Check(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{
+					{Tag: "storage-data-0"},
+					{Tag: "storage-data-1"},
+				},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{
+					{},
+					{Error: &params.Error{Message: "boom"}},
+				},
+			}
+			return nil
+		})
+	storageClient := storage.NewClient(apiCaller)
+	results, err := storageClient.Detach([]names.StorageTag{
+		names.NewStorageTag("data/0"),
+		names.NewStorageTag("data/1"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: "boom"}},
+	})
+}
+
+func (s *storageMockSuite) TestRemove(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Remove")
+			c.Check(a, jc.DeepEquals, params.RemoveStorage{
+				Storage: []params.RemoveStorageInstance{
+					{Tag: "storage-data-0", Release: true},
+				},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{}},
+			}
+			return nil
+		})
+	storageClient := storage.NewClient(apiCaller)
+	results, err := storageClient.Remove([]names.StorageTag{names.NewStorageTag("data/0")}, true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.ErrorResult{{}})
+}
+
+func (s *storageMockSuite) TestRemoveArity(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			*(result.(*params.ErrorResults)) = params.ErrorResults{}
+			return nil
+		})
+	storageClient := storage.NewClient(apiCaller)
+	_, err := storageClient.Remove([]names.StorageTag{names.NewStorageTag("data/0")}, false)
+	c.Assert(err, gc.ErrorMatches, `expected 1 result\(s\), got 0`)
+}
+
+func (s *storageMockSuite) TestImportFilesystem(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ImportFilesystem")
+			c.Check(a, jc.DeepEquals, params.ImportFilesystemsParams{
+				Filesystems: []params.ImportFilesystemParams{{
+					Pool:        "ebs",
+					ProviderId:  "vol-123",
+					StorageName: "data",
+				}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.ImportStorageResults{})
+			*(result.(*params.ImportStorageResults)) = params.ImportStorageResults{
+				Results: []params.ImportStorageResult{{
+					Result: &params.ImportStorageDetails{StorageTag: "storage-data-0"},
+				}},
+			}
+			return nil
+		})
+	storageClient := storage.NewClient(apiCaller)
+	storageTag, err := storageClient.ImportFilesystem("ebs", "vol-123", "data")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storageTag, gc.Equals, names.NewStorageTag("data/0"))
+}
+
+func (s *storageMockSuite) TestImportFilesystemError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			*(result.(*params.ImportStorageResults)) = params.ImportStorageResults{
+				Results: []params.ImportStorageResult{{
+					Error: &params.Error{Message: "boom"},
+				}},
+			}
+			return nil
+		})
+	storageClient := storage.NewClient(apiCaller)
+	_, err := storageClient.ImportFilesystem("ebs", "vol-123", "data")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *storageMockSuite) TestStorageQuota(c *gc.C) {
+	details := params.StorageQuotaDetails{
+		Quota: params.StorageQuota{Size: 1024},
+		Usage: []params.StorageQuotaUsage{{Pool: "ebs", Size: 512, Count: 1}},
+	}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "StorageQuotas")
+			c.Check(a, jc.DeepEquals, params.StorageQuotaFilters{
+				Filters: []params.StorageQuotaFilter{{ApplicationTag: "application-mysql"}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.StorageQuotaResults{})
+			*(result.(*params.StorageQuotaResults)) = params.StorageQuotaResults{
+				Results: []params.StorageQuotaResult{{Result: &details}},
+			}
+			return nil
+		})
+	storageClient := storage.NewClient(apiCaller)
+	found, err := storageClient.StorageQuota("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.DeepEquals, details)
+}
+
+func (s *storageMockSuite) TestStorageQuotaModel(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(a, jc.DeepEquals, params.StorageQuotaFilters{
+				Filters: []params.StorageQuotaFilter{{}},
+			})
+			*(result.(*params.StorageQuotaResults)) = params.StorageQuotaResults{
+				Results: []params.StorageQuotaResult{{
+					Error: &params.Error{Message: "boom"},
+				}},
+			}
+			return nil
+		})
+	storageClient := storage.NewClient(apiCaller)
+	_, err := storageClient.StorageQuota("")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *storageMockSuite) TestSetApplicationStorageQuota(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "SetApplicationStorageQuotas")
+			c.Check(a, jc.DeepEquals, params.ApplicationStorageQuotas{
+				Quotas: []params.ApplicationStorageQuota{{
+					ApplicationTag: "application-mysql",
+					Quota:          params.StorageQuota{Count: 3},
+				}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{}},
+			}
+			return nil
+		})
+	storageClient := storage.NewClient(apiCaller)
+	err := storageClient.SetApplicationStorageQuota("mysql", params.StorageQuota{Count: 3})
+	c.Assert(err, jc.ErrorIsNil)
+}
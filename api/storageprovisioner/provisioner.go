@@ -94,6 +94,24 @@ func (st *State) WatchFilesystems() (watcher.StringsWatcher, error) {
 	return st.watchStorageEntities("WatchFilesystems")
 }
 
+// WatchVolumeSnapshots watches for lifecycle changes to snapshots of
+// volumes scoped to the entity with the tag passed to NewState.
+func (st *State) WatchVolumeSnapshots() (watcher.StringsWatcher, error) {
+	return st.watchStorageEntities("WatchVolumeSnapshots")
+}
+
+// WatchVolumeResizes watches for changes to the requested sizes of
+// volumes scoped to the entity with the tag passed to NewState.
+func (st *State) WatchVolumeResizes() (watcher.StringsWatcher, error) {
+	return st.watchStorageEntities("WatchVolumeResizes")
+}
+
+// WatchFilesystemResizes watches for changes to the requested sizes of
+// filesystems scoped to the entity with the tag passed to NewState.
+func (st *State) WatchFilesystemResizes() (watcher.StringsWatcher, error) {
+	return st.watchStorageEntities("WatchFilesystemResizes")
+}
+
 func (st *State) watchStorageEntities(method string) (watcher.StringsWatcher, error) {
 	var results params.StringsWatchResults
 	args := params.Entities{
@@ -437,6 +455,90 @@ func (st *State) RemoveAttachments(ids []params.MachineStorageId) ([]params.Erro
 	return results.Results, nil
 }
 
+// VolumeSnapshotParams returns the parameters for creating or deleting
+// the volume snapshots with the specified IDs.
+func (st *State) VolumeSnapshotParams(ids []string) ([]params.VolumeSnapshotParamsResult, error) {
+	args := params.VolumeSnapshotIds{Ids: ids}
+	var results params.VolumeSnapshotParamsResults
+	err := st.facade.FacadeCall("VolumeSnapshotParams", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(ids) {
+		panic(errors.Errorf("expected %d result(s), got %d", len(ids), len(results.Results)))
+	}
+	return results.Results, nil
+}
+
+// VolumeResizeParams returns the parameters for growing the volumes
+// with the specified tags.
+func (st *State) VolumeResizeParams(tags []names.VolumeTag) ([]params.VolumeResizeParamsResult, error) {
+	args := params.Entities{
+		Entities: make([]params.Entity, len(tags)),
+	}
+	for i, tag := range tags {
+		args.Entities[i].Tag = tag.String()
+	}
+	var results params.VolumeResizeParamsResults
+	err := st.facade.FacadeCall("VolumeResizeParams", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(tags) {
+		panic(errors.Errorf("expected %d result(s), got %d", len(tags), len(results.Results)))
+	}
+	return results.Results, nil
+}
+
+// FilesystemResizeParams returns the parameters for growing the
+// filesystems with the specified tags.
+func (st *State) FilesystemResizeParams(tags []names.FilesystemTag) ([]params.FilesystemResizeParamsResult, error) {
+	args := params.Entities{
+		Entities: make([]params.Entity, len(tags)),
+	}
+	for i, tag := range tags {
+		args.Entities[i].Tag = tag.String()
+	}
+	var results params.FilesystemResizeParamsResults
+	err := st.facade.FacadeCall("FilesystemResizeParams", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(tags) {
+		panic(errors.Errorf("expected %d result(s), got %d", len(tags), len(results.Results)))
+	}
+	return results.Results, nil
+}
+
+// SetVolumeSnapshotInfo records the details of newly taken volume
+// snapshots.
+func (st *State) SetVolumeSnapshotInfo(snapshots []params.VolumeSnapshot) ([]params.ErrorResult, error) {
+	args := params.VolumeSnapshots{VolumeSnapshots: snapshots}
+	var results params.ErrorResults
+	err := st.facade.FacadeCall("SetVolumeSnapshotInfo", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(snapshots) {
+		panic(errors.Errorf("expected %d result(s), got %d", len(snapshots), len(results.Results)))
+	}
+	return results.Results, nil
+}
+
+// RemoveVolumeSnapshots removes the volume snapshots with the specified
+// IDs from state.
+func (st *State) RemoveVolumeSnapshots(ids []string) ([]params.ErrorResult, error) {
+	var results params.ErrorResults
+	args := params.VolumeSnapshotIds{Ids: ids}
+	if err := st.facade.FacadeCall("RemoveVolumeSnapshots", args, &results); err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(ids) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(ids), len(results.Results))
+	}
+	return results.Results, nil
+}
+
 // InstanceIds returns the provider specific instance ID for each machine,
 // or an CodeNotProvisioned error if not set.
 func (st *State) InstanceIds(tags []names.MachineTag) ([]params.StringResult, error) {
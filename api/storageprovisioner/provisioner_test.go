@@ -952,3 +952,176 @@ func (s *provisionerSuite) TestModelConfig(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(outputCfg.AllAttrs(), jc.DeepEquals, inputCfg.AllAttrs())
 }
+
+func (s *provisionerSuite) TestVolumeSnapshotParams(c *gc.C) {
+	var callCount int
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "StorageProvisioner")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "VolumeSnapshotParams")
+		c.Check(arg, gc.DeepEquals, params.VolumeSnapshotIds{Ids: []string{"100:0"}})
+		c.Assert(result, gc.FitsTypeOf, &params.VolumeSnapshotParamsResults{})
+		*(result.(*params.VolumeSnapshotParamsResults)) = params.VolumeSnapshotParamsResults{
+			Results: []params.VolumeSnapshotParamsResult{{
+				Result: params.VolumeSnapshotParams{
+					Id:        "100:0",
+					VolumeTag: "volume-100",
+					VolumeId:  "vol-100",
+					Provider:  "loop",
+					Life:      params.Alive,
+				},
+			}},
+		}
+		callCount++
+		return nil
+	})
+
+	st, err := storageprovisioner.NewState(apiCaller, names.NewMachineTag("123"))
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := st.VolumeSnapshotParams([]string{"100:0"})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(callCount, gc.Equals, 1)
+	c.Assert(results, jc.DeepEquals, []params.VolumeSnapshotParamsResult{{
+		Result: params.VolumeSnapshotParams{
+			Id:        "100:0",
+			VolumeTag: "volume-100",
+			VolumeId:  "vol-100",
+			Provider:  "loop",
+			Life:      params.Alive,
+		},
+	}})
+}
+
+func (s *provisionerSuite) TestSetVolumeSnapshotInfo(c *gc.C) {
+	snapshots := []params.VolumeSnapshot{{
+		Id:        "100:0",
+		VolumeTag: "volume-100",
+		Info:      params.VolumeSnapshotInfo{SnapshotId: "snap-100", Size: 1024},
+	}}
+	var callCount int
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "SetVolumeSnapshotInfo")
+		c.Check(arg, gc.DeepEquals, params.VolumeSnapshots{VolumeSnapshots: snapshots})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{Error: &params.Error{Message: "FAIL"}}},
+		}
+		callCount++
+		return nil
+	})
+
+	st, err := storageprovisioner.NewState(apiCaller, names.NewMachineTag("123"))
+	c.Assert(err, jc.ErrorIsNil)
+	errorResults, err := st.SetVolumeSnapshotInfo(snapshots)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(callCount, gc.Equals, 1)
+	c.Assert(errorResults, gc.HasLen, 1)
+	c.Assert(errorResults[0].Error, gc.ErrorMatches, "FAIL")
+}
+
+func (s *provisionerSuite) TestRemoveVolumeSnapshotsClientError(c *gc.C) {
+	s.testClientError(c, func(st *storageprovisioner.State) error {
+		_, err := st.RemoveVolumeSnapshots(nil)
+		return err
+	})
+}
+
+func (s *provisionerSuite) TestWatchVolumeResizes(c *gc.C) {
+	var callCount int
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "StorageProvisioner")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "WatchVolumeResizes")
+		c.Assert(result, gc.FitsTypeOf, &params.StringsWatchResults{})
+		*(result.(*params.StringsWatchResults)) = params.StringsWatchResults{
+			Results: []params.StringsWatchResult{{
+				Error: &params.Error{Message: "FAIL"},
+			}},
+		}
+		callCount++
+		return nil
+	})
+
+	st, err := storageprovisioner.NewState(apiCaller, names.NewMachineTag("123"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = st.WatchVolumeResizes()
+	c.Check(err, gc.ErrorMatches, "FAIL")
+	c.Check(callCount, gc.Equals, 1)
+}
+
+func (s *provisionerSuite) TestVolumeResizeParams(c *gc.C) {
+	var callCount int
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "StorageProvisioner")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "VolumeResizeParams")
+		c.Check(arg, gc.DeepEquals, params.Entities{Entities: []params.Entity{{"volume-100"}}})
+		c.Assert(result, gc.FitsTypeOf, &params.VolumeResizeParamsResults{})
+		*(result.(*params.VolumeResizeParamsResults)) = params.VolumeResizeParamsResults{
+			Results: []params.VolumeResizeParamsResult{{
+				Result: params.VolumeResizeParams{
+					VolumeTag: "volume-100",
+					VolumeId:  "vol-ume",
+					Provider:  "loop",
+					Size:      2048,
+				},
+			}},
+		}
+		callCount++
+		return nil
+	})
+
+	st, err := storageprovisioner.NewState(apiCaller, names.NewMachineTag("123"))
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := st.VolumeResizeParams([]names.VolumeTag{names.NewVolumeTag("100")})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(callCount, gc.Equals, 1)
+	c.Assert(results, jc.DeepEquals, []params.VolumeResizeParamsResult{{
+		Result: params.VolumeResizeParams{
+			VolumeTag: "volume-100", VolumeId: "vol-ume", Provider: "loop", Size: 2048,
+		},
+	}})
+}
+
+func (s *provisionerSuite) TestFilesystemResizeParams(c *gc.C) {
+	var callCount int
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "StorageProvisioner")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "FilesystemResizeParams")
+		c.Check(arg, gc.DeepEquals, params.Entities{Entities: []params.Entity{{"filesystem-100"}}})
+		c.Assert(result, gc.FitsTypeOf, &params.FilesystemResizeParamsResults{})
+		*(result.(*params.FilesystemResizeParamsResults)) = params.FilesystemResizeParamsResults{
+			Results: []params.FilesystemResizeParamsResult{{
+				Result: params.FilesystemResizeParams{
+					FilesystemTag: "filesystem-100",
+					VolumeTag:     "volume-100",
+					FilesystemId:  "fs-id",
+					Provider:      "loop",
+					Size:          2048,
+				},
+			}},
+		}
+		callCount++
+		return nil
+	})
+
+	st, err := storageprovisioner.NewState(apiCaller, names.NewMachineTag("123"))
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := st.FilesystemResizeParams([]names.FilesystemTag{names.NewFilesystemTag("100")})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(callCount, gc.Equals, 1)
+	c.Assert(results, jc.DeepEquals, []params.FilesystemResizeParamsResult{{
+		Result: params.FilesystemResizeParams{
+			FilesystemTag: "filesystem-100",
+			VolumeTag:     "volume-100",
+			FilesystemId:  "fs-id",
+			Provider:      "loop",
+			Size:          2048,
+		},
+	}})
+}
@@ -4,6 +4,11 @@
 package uniter_test
 
 import (
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -122,3 +127,35 @@ func (s *actionSuite) TestActionFail(c *gc.C) {
 	c.Assert(res, gc.DeepEquals, map[string]interface{}{})
 	c.Assert(completed[0].Name(), gc.Equals, "fakeaction")
 }
+
+func (s *actionSuite) TestActionAttach(c *gc.C) {
+	action, err := s.uniterSuite.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.uniter.ActionBegin(action.ActionTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	content := "report contents"
+	hash := fmt.Sprintf("%x", sha256.Sum256([]byte(content)))
+	err = s.uniter.ActionAttach(action.ActionTag(), "report.txt", strings.NewReader(content), int64(len(content)), hash)
+	c.Assert(err, jc.ErrorIsNil)
+
+	attachment, reader, err := s.State.ActionAttachment(action.ActionTag(), "report.txt")
+	c.Assert(err, jc.ErrorIsNil)
+	defer reader.Close()
+	c.Assert(attachment, jc.DeepEquals, state.ActionAttachment{
+		Name:   "report.txt",
+		Size:   int64(len(content)),
+		SHA256: hash,
+	})
+	data, err := ioutil.ReadAll(reader)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, content)
+}
+
+func (s *actionSuite) TestActionAttachNotRunning(c *gc.C) {
+	action, err := s.uniterSuite.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.uniter.ActionAttach(action.ActionTag(), "report.txt", strings.NewReader("x"), 1, "")
+	c.Assert(err, gc.ErrorMatches, `.*cannot attach "report.txt" to action ".*": action is pending`)
+}
@@ -69,6 +69,31 @@ func (s *Application) WatchRelations() (watcher.StringsWatcher, error) {
 	return w, nil
 }
 
+// WatchEndpointBindings returns a NotifyWatcher that notifies of
+// changes to the spaces the application's endpoints are bound to.
+func (s *Application) WatchEndpointBindings() (watcher.NotifyWatcher, error) {
+	if s.st.facade.BestAPIVersion() < 5 {
+		return nil, errors.NotImplementedf("WatchEndpointBindings() (need V5+)")
+	}
+	var results params.NotifyWatchResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: s.tag.String()}},
+	}
+	err := s.st.facade.FacadeCall("WatchEndpointBindings", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewNotifyWatcher(s.st.facade.RawAPICaller(), result)
+	return w, nil
+}
+
 // Life returns the application's current life state.
 func (s *Application) Life() params.Life {
 	return s.life
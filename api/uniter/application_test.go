@@ -90,6 +90,26 @@ func (s *serviceSuite) TestWatchRelations(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *serviceSuite) TestWatchEndpointBindings(c *gc.C) {
+	w, err := s.apiService.WatchEndpointBindings()
+	c.Assert(err, jc.ErrorIsNil)
+	wc := watchertest.NewNotifyWatcherC(c, w, s.BackingState.StartSync)
+	defer wc.AssertStops()
+
+	// Initial event.
+	wc.AssertOneChange()
+
+	// Change something other than the bindings and make sure
+	// it's not detected.
+	err = s.wordpressService.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	err = s.wordpressService.SetEndpointBindings(map[string]string{"admin-api": ""})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
 func (s *serviceSuite) TestRefresh(c *gc.C) {
 	c.Assert(s.apiService.Life(), gc.Equals, params.Alive)
 
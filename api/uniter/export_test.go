@@ -8,6 +8,7 @@ import (
 
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/apiserver/params"
 )
@@ -42,3 +43,18 @@ func PatchUnitFacadeCall(p testing.Patcher, u *Unit, respFunc func(request strin
 func CreateUnit(st *State, tag names.UnitTag) *Unit {
 	return &Unit{st, tag, params.Alive}
 }
+
+// PatchBestAPIVersion patches the unit's facade such that
+// BestAPIVersion returns the specified version.
+func PatchBestAPIVersion(p testing.Patcher, u *Unit, version int) {
+	p.PatchValue(&u.st.facade, base.FacadeCaller(&versionedFacade{u.st.facade, version}))
+}
+
+type versionedFacade struct {
+	base.FacadeCaller
+	version int
+}
+
+func (f *versionedFacade) BestAPIVersion() int {
+	return f.version
+}
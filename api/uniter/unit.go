@@ -376,6 +376,33 @@ func (u *Unit) PublicAddress() (string, error) {
 	return result.Result, nil
 }
 
+// ClaimVirtualIPAddress moves the virtual IP address of the unit's
+// application to the unit's machine, and returns it. Only the leader
+// of the application may claim its virtual IP address. If the
+// application has no virtual IP address, the empty string is
+// returned.
+func (u *Unit) ClaimVirtualIPAddress() (string, error) {
+	if u.st.facade.BestAPIVersion() < 5 {
+		return "", errors.NotImplementedf("ClaimVirtualIPAddress() (need V5+)")
+	}
+	var results params.StringResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("ClaimVirtualIPAddress", args, &results)
+	if err != nil {
+		return "", err
+	}
+	if len(results.Results) != 1 {
+		return "", fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return "", result.Error
+	}
+	return result.Result, nil
+}
+
 // PrivateAddress returns the private address of the unit and whether
 // it is valid.
 //
@@ -511,6 +538,41 @@ func (u *Unit) SetCharmURL(curl *charm.URL) error {
 	return result.OneError()
 }
 
+// AddHookExecution records a run of a charm hook in the unit's
+// hook history.
+func (u *Unit) AddHookExecution(execution params.HookExecution) error {
+	if u.st.facade.BestAPIVersion() < 5 {
+		return errors.NotImplementedf("AddHookExecution() (need V5+)")
+	}
+	var result params.ErrorResults
+	args := params.HookExecutionArgs{
+		Args: []params.HookExecutionArg{
+			{Tag: u.tag.String(), Execution: execution},
+		},
+	}
+	err := u.st.facade.FacadeCall("AddHookExecutions", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
+// SetWorkloadVersion sets the version of the workload that the unit
+// is running.
+func (u *Unit) SetWorkloadVersion(version string) error {
+	var result params.ErrorResults
+	args := params.EntityWorkloadVersions{
+		Entities: []params.EntityWorkloadVersion{
+			{Tag: u.tag.String(), WorkloadVersion: version},
+		},
+	}
+	err := u.st.facade.FacadeCall("SetWorkloadVersion", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
 // ClearResolved removes any resolved setting on the unit.
 func (u *Unit) ClearResolved() error {
 	var result params.ErrorResults
@@ -738,3 +800,24 @@ func (u *Unit) NetworkConfig(bindingName string) ([]params.NetworkConfig, error)
 
 	return result.Config, nil
 }
+
+// NetworkInfo requests the network info of the unit for each of the
+// given binding names: the devices and addresses bound to the endpoint,
+// the ingress addresses other units should use, and the egress subnets.
+// Results are keyed by binding name, each possibly holding an error.
+func (u *Unit) NetworkInfo(bindingNames []string) (map[string]params.NetworkInfoResult, error) {
+	if u.st.facade.BestAPIVersion() < 5 {
+		return nil, errors.NotImplementedf("NetworkInfo() (need V5+)")
+	}
+	var results params.NetworkInfoResults
+	args := params.NetworkInfoParams{
+		Unit:     u.tag.String(),
+		Bindings: bindingNames,
+	}
+
+	err := u.st.facade.FacadeCall("NetworkInfo", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Results, nil
+}
@@ -295,6 +295,22 @@ func (s *unitSuite) TestPublicAddress(c *gc.C) {
 	c.Assert(address, gc.Equals, "1.2.3.4")
 }
 
+func (s *unitSuite) TestClaimVirtualIPAddressNone(c *gc.C) {
+	address, err := s.apiUnit.ClaimVirtualIPAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(address, gc.Equals, "")
+}
+
+func (s *unitSuite) TestClaimVirtualIPAddressNotLeader(c *gc.C) {
+	_, err := s.State.AddSubnet(state.SubnetInfo{CIDR: "10.20.0.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.wordpressService.AllocateVirtualIPAddress("10.20.0.0/24", "")
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.apiUnit.ClaimVirtualIPAddress()
+	c.Assert(err, gc.ErrorMatches, ".* is not leader of .*")
+}
+
 func (s *unitSuite) TestPrivateAddress(c *gc.C) {
 	address, err := s.apiUnit.PrivateAddress()
 	c.Assert(err, gc.ErrorMatches, `"unit-wordpress-0" has no private address set`)
@@ -347,6 +363,22 @@ func (s *unitSuite) TestNetworkConfig(c *gc.C) {
 	c.Assert(netConfig, gc.IsNil)
 }
 
+func (s *unitSuite) TestNetworkInfo(c *gc.C) {
+	err := s.wordpressMachine.SetProviderAddresses(
+		network.NewScopedAddress("1.2.3.4", network.ScopeCloudLocal),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.apiUnit.NetworkInfo([]string{"db", "unknown"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Check(results["db"], jc.DeepEquals, params.NetworkInfoResult{
+		IngressAddresses: []string{"1.2.3.4"},
+		EgressSubnets:    []string{"1.2.3.4/32"},
+	})
+	c.Check(results["unknown"].Error, gc.ErrorMatches, `binding name "unknown" not defined by the unit's charm`)
+}
+
 func (s *unitSuite) TestAvailabilityZone(c *gc.C) {
 	uniter.PatchUnitResponse(s, s.apiUnit, "AvailabilityZone",
 		func(result interface{}) error {
@@ -420,6 +452,51 @@ func (s *unitSuite) TestGetSetCharmURL(c *gc.C) {
 	c.Assert(curl.String(), gc.Equals, s.wordpressCharm.String())
 }
 
+func (s *unitSuite) TestAddHookExecution(c *gc.C) {
+	started := time.Date(2016, 10, 1, 12, 0, 0, 0, time.UTC)
+	err := s.apiUnit.AddHookExecution(params.HookExecution{
+		Hook:       "config-changed",
+		RelationId: -1,
+		Started:    started,
+		Finished:   started.Add(time.Second),
+		ToolCalls:  []string{"config-get"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.wordpressUnit.HookHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, jc.DeepEquals, []state.HookExecution{{
+		Hook:       "config-changed",
+		RelationId: -1,
+		Started:    started,
+		Finished:   started.Add(time.Second),
+		ToolCalls:  []string{"config-get"},
+	}})
+}
+
+func (s *unitSuite) TestAddHookExecutionOldController(c *gc.C) {
+	uniter.PatchBestAPIVersion(s, s.apiUnit, 4)
+	err := s.apiUnit.AddHookExecution(params.HookExecution{Hook: "install", RelationId: -1})
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
+func (s *unitSuite) TestNetworkingOldController(c *gc.C) {
+	uniter.PatchBestAPIVersion(s, s.apiUnit, 4)
+	_, err := s.apiUnit.NetworkInfo([]string{"db"})
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+	_, err = s.apiUnit.ClaimVirtualIPAddress()
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
+func (s *unitSuite) TestSetWorkloadVersion(c *gc.C) {
+	err := s.apiUnit.SetWorkloadVersion("4.6.1")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.wordpressUnit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.wordpressUnit.WorkloadVersion(), gc.Equals, "4.6.1")
+}
+
 func (s *unitSuite) TestConfigSettings(c *gc.C) {
 	// Make sure ConfigSettings returns an error when
 	// no charm URL is set, as its state counterpart does.
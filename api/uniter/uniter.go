@@ -5,6 +5,9 @@ package uniter
 
 import (
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
 
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6-unstable"
@@ -69,12 +72,12 @@ func newStateForVersionFn(version int) func(base.APICaller, names.UnitTag) *Stat
 	}
 }
 
-// newStateV4 creates a new client-side Uniter facade, version 4.
-var newStateV4 = newStateForVersionFn(4)
+// newStateV5 creates a new client-side Uniter facade, version 5.
+var newStateV5 = newStateForVersionFn(5)
 
 // NewState creates a new client-side Uniter facade.
 // Defined like this to allow patching during tests.
-var NewState = newStateV4
+var NewState = newStateV5
 
 // BestAPIVersion returns the API version that we were able to
 // determine is supported by both the client and the API Server.
@@ -275,6 +278,31 @@ func (st *State) ActionFinish(tag names.ActionTag, status string, results map[st
 	return nil
 }
 
+// ActionAttach uploads size bytes of content as a file with the given
+// name attached to the results of the identified action. The action
+// must be running.
+func (st *State) ActionAttach(tag names.ActionTag, name string, content io.ReadSeeker, size int64, sha256 string) error {
+	httpClient, err := st.facade.RawAPICaller().HTTPClient()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	query := url.Values{
+		"size":   {fmt.Sprint(size)},
+		"sha256": {sha256},
+	}
+	endpoint := fmt.Sprintf("/actions/%s/attachments/%s?%s", tag.Id(), url.QueryEscape(name), query.Encode())
+	req, err := http.NewRequest("PUT", endpoint, nil)
+	if err != nil {
+		return errors.Annotate(err, "cannot create upload request")
+	}
+	req.Header.Set("Content-Type", params.ContentTypeRaw)
+	var result params.ActionAttachment
+	if err := httpClient.Do(req, content, &result); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
 // RelationById returns the existing relation with the given id.
 func (st *State) RelationById(id int) (*Relation, error) {
 	var results params.RelationResults
@@ -0,0 +1,145 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// actionAttachmentsHandler handles the upload of files attached to the
+// results of an action by the agent running it, and their download by
+// users.
+type actionAttachmentsHandler struct {
+	ctxt httpContext
+}
+
+func (h *actionAttachmentsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	st, entity, err := h.ctxt.stateForRequestAuthenticated(r)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+
+	query := r.URL.Query()
+	if !names.IsValidAction(query.Get(":action")) {
+		sendError(w, errors.BadRequestf("invalid action id %q", query.Get(":action")))
+		return
+	}
+	actionTag := names.NewActionTag(query.Get(":action"))
+	name := query.Get(":name")
+
+	switch r.Method {
+	case "PUT":
+		attachment, err := h.processPut(r, st, entity.Tag(), actionTag, name)
+		if err != nil {
+			sendError(w, err)
+			return
+		}
+		sendStatusAndJSON(w, http.StatusOK, &params.ActionAttachment{
+			Name:   attachment.Name,
+			Size:   attachment.Size,
+			SHA256: attachment.SHA256,
+		})
+	case "GET":
+		if err := h.processGet(w, st, entity.Tag(), actionTag, name); err != nil {
+			sendError(w, err)
+			return
+		}
+	default:
+		sendError(w, errors.MethodNotAllowedf("unsupported method: %q", r.Method))
+	}
+}
+
+// processPut handles an attachment upload PUT request after
+// authentication. Only the agent running the action may attach
+// files to it.
+func (h *actionAttachmentsHandler) processPut(
+	r *http.Request, st *state.State, authTag names.Tag, actionTag names.ActionTag, name string,
+) (state.ActionAttachment, error) {
+	defer r.Body.Close()
+
+	action, err := st.ActionByTag(actionTag)
+	if err != nil {
+		return state.ActionAttachment{}, errors.Trace(err)
+	}
+	switch authTag.(type) {
+	case names.UnitTag, names.MachineTag:
+		if authTag.Id() != action.Receiver() {
+			return state.ActionAttachment{}, common.ErrPerm
+		}
+	default:
+		return state.ActionAttachment{}, common.ErrPerm
+	}
+
+	contentType := r.Header.Get("Content-Type")
+	if contentType != params.ContentTypeRaw {
+		return state.ActionAttachment{}, errors.BadRequestf("expected Content-Type: %s, got: %v", params.ContentTypeRaw, contentType)
+	}
+	// The size is passed explicitly because the request
+	// body may be sent without a Content-Length.
+	size := r.ContentLength
+	if sizeParam := r.URL.Query().Get("size"); sizeParam != "" {
+		size, err = strconv.ParseInt(sizeParam, 10, 64)
+		if err != nil {
+			return state.ActionAttachment{}, errors.NewBadRequest(err, fmt.Sprintf("invalid size %q", sizeParam))
+		}
+	}
+	if size < 0 {
+		return state.ActionAttachment{}, errors.BadRequestf("expected size argument")
+	}
+	attachment, err := st.AddActionAttachment(
+		actionTag, name, r.Body, size, r.URL.Query().Get("sha256"),
+	)
+	if err != nil {
+		return state.ActionAttachment{}, errors.Trace(err)
+	}
+	logger.Infof("attached %q (%d bytes) to action %q", name, attachment.Size, actionTag.Id())
+	return attachment, nil
+}
+
+// processGet handles an attachment download GET request after
+// authentication, streaming the attachment content to the client.
+func (h *actionAttachmentsHandler) processGet(
+	w http.ResponseWriter, st *state.State, authTag names.Tag, actionTag names.ActionTag, name string,
+) error {
+	action, err := st.ActionByTag(actionTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	switch authTag.(type) {
+	case names.UserTag:
+	case names.UnitTag, names.MachineTag:
+		if authTag.Id() != action.Receiver() {
+			return common.ErrPerm
+		}
+	default:
+		return common.ErrPerm
+	}
+
+	attachment, content, err := st.ActionAttachment(actionTag, name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer content.Close()
+
+	w.Header().Set("Content-Type", params.ContentTypeRaw)
+	w.Header().Set("Content-Length", fmt.Sprint(attachment.Size))
+	w.Header().Set("Digest", params.EncodeChecksum(attachment.SHA256))
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, content); err != nil {
+		// The headers have already been sent, so all we can do is log.
+		logger.Errorf("cannot stream attachment %q of action %q: %v", name, actionTag.Id(), err)
+	}
+	return nil
+}
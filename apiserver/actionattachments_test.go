@@ -0,0 +1,121 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+type actionAttachmentsSuite struct {
+	authHttpSuite
+	unit         *state.Unit
+	unitPassword string
+	action       state.Action
+}
+
+var _ = gc.Suite(&actionAttachmentsSuite{})
+
+func (s *actionAttachmentsSuite) SetUpTest(c *gc.C) {
+	s.authHttpSuite.SetUpTest(c)
+	s.unit, s.unitPassword = s.Factory.MakeUnitReturningPassword(c, nil)
+	action, err := s.State.EnqueueAction(s.unit.Tag(), "snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.action, err = action.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *actionAttachmentsSuite) attachmentURL(c *gc.C, name string, query url.Values) string {
+	path := fmt.Sprintf("/model/%s/actions/%s/attachments/%s", s.modelUUID, s.action.Id(), name)
+	return s.makeURL(c, "https", path, query).String()
+}
+
+func (s *actionAttachmentsSuite) upload(c *gc.C, tag, password, name, content string) *http.Response {
+	query := url.Values{"sha256": {fmt.Sprintf("%x", sha256.Sum256([]byte(content)))}}
+	return s.sendRequest(c, httpRequestParams{
+		method:      "PUT",
+		url:         s.attachmentURL(c, name, query),
+		tag:         tag,
+		password:    password,
+		contentType: params.ContentTypeRaw,
+		body:        strings.NewReader(content),
+	})
+}
+
+func (s *actionAttachmentsSuite) assertErrorResponse(c *gc.C, resp *http.Response, expCode int, expError string) {
+	body := assertResponse(c, resp, expCode, params.ContentTypeJSON)
+	var result params.ErrorResult
+	err := json.Unmarshal(body, &result)
+	c.Assert(err, jc.ErrorIsNil, gc.Commentf("body: %s", body))
+	c.Assert(result.Error, gc.NotNil)
+	c.Assert(result.Error.Message, gc.Matches, expError)
+}
+
+func (s *actionAttachmentsSuite) TestUploadAndDownload(c *gc.C) {
+	resp := s.upload(c, s.unit.Tag().String(), s.unitPassword, "dump.sql", "some data")
+	body := assertResponse(c, resp, http.StatusOK, params.ContentTypeJSON)
+	var attachment params.ActionAttachment
+	err := json.Unmarshal(body, &attachment)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachment, jc.DeepEquals, params.ActionAttachment{
+		Name:   "dump.sql",
+		Size:   9,
+		SHA256: fmt.Sprintf("%x", sha256.Sum256([]byte("some data"))),
+	})
+
+	resp = s.authRequest(c, httpRequestParams{
+		method: "GET",
+		url:    s.attachmentURL(c, "dump.sql", nil),
+	})
+	body = assertResponse(c, resp, http.StatusOK, params.ContentTypeRaw)
+	c.Assert(string(body), gc.Equals, "some data")
+}
+
+func (s *actionAttachmentsSuite) TestUploadRequiresReceiver(c *gc.C) {
+	other, password := s.Factory.MakeUnitReturningPassword(c, nil)
+	resp := s.upload(c, other.Tag().String(), password, "dump.sql", "some data")
+	s.assertErrorResponse(c, resp, http.StatusUnauthorized, "permission denied")
+}
+
+func (s *actionAttachmentsSuite) TestUploadByUserDenied(c *gc.C) {
+	resp := s.upload(c, s.userTag.String(), s.password, "dump.sql", "some data")
+	s.assertErrorResponse(c, resp, http.StatusUnauthorized, "permission denied")
+}
+
+func (s *actionAttachmentsSuite) TestUploadRequiresContentType(c *gc.C) {
+	resp := s.sendRequest(c, httpRequestParams{
+		method:   "PUT",
+		url:      s.attachmentURL(c, "dump.sql", nil),
+		tag:      s.unit.Tag().String(),
+		password: s.unitPassword,
+		body:     strings.NewReader("some data"),
+	})
+	s.assertErrorResponse(c, resp, http.StatusBadRequest, "expected Content-Type: application/octet-stream, got: .*")
+}
+
+func (s *actionAttachmentsSuite) TestDownloadNotFound(c *gc.C) {
+	resp := s.authRequest(c, httpRequestParams{
+		method: "GET",
+		url:    s.attachmentURL(c, "missing", nil),
+	})
+	s.assertErrorResponse(c, resp, http.StatusNotFound, `attachment "missing" for action ".*" not found`)
+}
+
+func (s *actionAttachmentsSuite) TestUnsupportedMethod(c *gc.C) {
+	resp := s.authRequest(c, httpRequestParams{
+		method: "POST",
+		url:    s.attachmentURL(c, "dump.sql", nil),
+	})
+	s.assertErrorResponse(c, resp, http.StatusMethodNotAllowed, `unsupported method: "POST"`)
+}
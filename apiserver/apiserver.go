@@ -408,6 +408,11 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 			ctxt: httpCtxt,
 		},
 	)
+	add("/model/:modeluuid/actions/:action/attachments/:name",
+		&actionAttachmentsHandler{
+			ctxt: httpCtxt,
+		},
+	)
 	strictCtxt := httpCtxt
 	strictCtxt.strictValidation = true
 	strictCtxt.controllerModelOnly = true
@@ -28,7 +28,7 @@ var (
 )
 
 func init() {
-	common.RegisterStandardFacade("Application", 1, NewAPI)
+	common.RegisterStandardFacade("Application", 2, NewAPI)
 }
 
 // Application defines the methods on the application API end point.
@@ -419,7 +419,10 @@ func (api *API) CharmRelations(p params.ApplicationCharmRelations) (params.Appli
 }
 
 // Expose changes the juju-managed firewall to expose any ports that
-// were also explicitly marked by units as open.
+// were also explicitly marked by units as open. If source CIDRs or
+// spaces are specified, the ports are only exposed to them; this
+// is only possible if the model's provider can restrict the source
+// addresses of ingress traffic.
 func (api *API) Expose(args params.ApplicationExpose) error {
 	if err := api.check.ChangeAllowed(); err != nil {
 		return errors.Trace(err)
@@ -428,7 +431,12 @@ func (api *API) Expose(args params.ApplicationExpose) error {
 	if err != nil {
 		return err
 	}
-	return svc.SetExposed()
+	if len(args.ToCIDRs) > 0 || len(args.ToSpaces) > 0 {
+		if err := api.checkSupportsIngressRules(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return svc.SetExposedTo(args.ToCIDRs, args.ToSpaces)
 }
 
 // Unexpose changes the juju-managed firewall to unexpose any ports that
@@ -532,11 +540,32 @@ func (api *API) SetConstraints(args params.SetConstraints) error {
 	return svc.SetConstraints(args.Constraints)
 }
 
+// Bind changes the spaces the application's endpoints are bound to.
+// Units in relations using rebound endpoints publish their addresses
+// in the new spaces, and run the config-changed hook.
+func (api *API) Bind(args params.ApplicationBind) error {
+	if err := api.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	svc, err := api.state.Application(args.ApplicationName)
+	if err != nil {
+		return err
+	}
+	return svc.SetEndpointBindings(args.EndpointBindings)
+}
+
 // AddRelation adds a relation between the specified endpoints and returns the relation info.
+// One of the endpoints may be offered by another model on the controller, in
+// which case it is specified as [<owner>/]<model>.<offer>[:<endpoint>].
 func (api *API) AddRelation(args params.AddRelation) (params.AddRelationResults, error) {
 	if err := api.check.ChangeAllowed(); err != nil {
 		return params.AddRelationResults{}, errors.Trace(err)
 	}
+	if local, remote, ok, err := api.splitOfferEndpoint(args.Endpoints); err != nil {
+		return params.AddRelationResults{}, errors.Trace(err)
+	} else if ok {
+		return api.addRemoteRelation(local, remote)
+	}
 	inEps, err := api.state.InferEndpoints(args.Endpoints...)
 	if err != nil {
 		return params.AddRelationResults{}, err
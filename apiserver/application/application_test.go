@@ -23,12 +23,16 @@ import (
 	"gopkg.in/mgo.v2"
 
 	"github.com/juju/juju/apiserver/application"
+	"github.com/juju/juju/apiserver/common"
 	commontesting "github.com/juju/juju/apiserver/common/testing"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
 	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	statestorage "github.com/juju/juju/state/storage"
 	"github.com/juju/juju/status"
@@ -1866,7 +1870,7 @@ func (s *serviceSuite) TestServiceExpose(c *gc.C) {
 	c.Assert(svcs[1].IsExposed(), jc.IsTrue)
 	for i, t := range serviceExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err = s.applicationApi.Expose(params.ApplicationExpose{t.service})
+		err = s.applicationApi.Expose(params.ApplicationExpose{ApplicationName: t.service})
 		if t.err != "" {
 			c.Assert(err, gc.ErrorMatches, t.err)
 		} else {
@@ -1878,6 +1882,251 @@ func (s *serviceSuite) TestServiceExpose(c *gc.C) {
 	}
 }
 
+func (s *serviceSuite) TestServiceExposeTo(c *gc.C) {
+	_, err := s.State.AddSpace("internal", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddTestingService(c, "dummy-service", s.AddTestingCharm(c, "dummy"))
+
+	err = s.applicationApi.Expose(params.ApplicationExpose{
+		ApplicationName: "dummy-service",
+		ToCIDRs:         []string{"10.0.0.0/8"},
+		ToSpaces:        []string{"internal"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	application, err := s.State.Application("dummy-service")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(application.IsExposed(), jc.IsTrue)
+	c.Assert(application.ExposedCIDRs(), jc.DeepEquals, []string{"10.0.0.0/8"})
+	c.Assert(application.ExposedSpaces(), jc.DeepEquals, []string{"internal"})
+}
+
+type noIngressRulesEnviron struct {
+	environs.Environ
+}
+
+func (s *serviceSuite) TestServiceExposeToNotSupported(c *gc.C) {
+	s.PatchValue(application.NewEnviron, func(cfg *config.Config) (environs.Environ, error) {
+		env, err := environs.New(cfg)
+		return noIngressRulesEnviron{env}, err
+	})
+	s.AddTestingService(c, "dummy-service", s.AddTestingCharm(c, "dummy"))
+	err := s.applicationApi.Expose(params.ApplicationExpose{
+		ApplicationName: "dummy-service",
+		ToCIDRs:         []string{"10.0.0.0/8"},
+	})
+	c.Assert(err, gc.ErrorMatches, `exposing to specific CIDRs or spaces on "dummy" provider not supported`)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	app, err := s.State.Application("dummy-service")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.IsExposed(), jc.IsFalse)
+
+	// Exposing to all addresses is always possible.
+	err = s.applicationApi.Expose(params.ApplicationExpose{ApplicationName: "dummy-service"})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *serviceSuite) TestSetEgressRules(c *gc.C) {
+	s.AddTestingService(c, "dummy-service", s.AddTestingCharm(c, "dummy"))
+	rules := []params.EgressRule{{
+		PortRange:        params.PortRange{443, 443, "tcp"},
+		DestinationCIDRs: []string{"10.0.0.0/8"},
+	}}
+
+	err := s.applicationApi.SetEgressRules(params.ApplicationEgressRules{
+		ApplicationName: "dummy-service",
+		Rules:           rules,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	application, err := s.State.Application("dummy-service")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(application.EgressRules(), jc.DeepEquals, []network.EgressRule{
+		network.NewEgressRule(network.PortRange{443, 443, "tcp"}, "10.0.0.0/8"),
+	})
+	result, err := s.applicationApi.EgressRules(params.ApplicationEgressRules{
+		ApplicationName: "dummy-service",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Rules, jc.DeepEquals, rules)
+
+	// Without an application name, the model's rules are set.
+	err = s.applicationApi.SetEgressRules(params.ApplicationEgressRules{
+		Rules: []params.EgressRule{{
+			PortRange:        params.PortRange{53, 53, "udp"},
+			DestinationCIDRs: []string{"0.0.0.0/0"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	cfg, err := s.State.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.EgressRules(), jc.DeepEquals, []network.EgressRule{
+		network.NewEgressRule(network.PortRange{53, 53, "udp"}),
+	})
+
+	err = s.applicationApi.SetEgressRules(params.ApplicationEgressRules{})
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = s.applicationApi.EgressRules(params.ApplicationEgressRules{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Rules, gc.HasLen, 0)
+}
+
+func (s *serviceSuite) TestSetEgressRulesInvalid(c *gc.C) {
+	s.AddTestingService(c, "dummy-service", s.AddTestingCharm(c, "dummy"))
+	err := s.applicationApi.SetEgressRules(params.ApplicationEgressRules{
+		ApplicationName: "dummy-service",
+		Rules: []params.EgressRule{{
+			PortRange:        params.PortRange{443, 443, "icmp"},
+			DestinationCIDRs: []string{"10.0.0.0/8"},
+		}},
+	})
+	c.Assert(err, gc.ErrorMatches, `invalid protocol "icmp", expected "tcp" or "udp"`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *serviceSuite) TestBind(c *gc.C) {
+	_, err := s.State.AddSpace("db", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+
+	err = s.applicationApi.Bind(params.ApplicationBind{
+		ApplicationName:  "mysql",
+		EndpointBindings: map[string]string{"server": "db"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	application, err := s.State.Application("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	bindings, err := application.EndpointBindings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bindings["server"], gc.Equals, "db")
+
+	err = s.applicationApi.Bind(params.ApplicationBind{
+		ApplicationName:  "mysql",
+		EndpointBindings: map[string]string{"server": "missing"},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot set endpoint bindings for application "mysql": unknown space "missing" not valid`)
+}
+
+func (s *serviceSuite) TestBlockChangesBind(c *gc.C) {
+	s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	s.BlockAllChanges(c, "TestBlockChangesBind")
+	err := s.applicationApi.Bind(params.ApplicationBind{
+		ApplicationName:  "mysql",
+		EndpointBindings: map[string]string{"server": ""},
+	})
+	s.AssertBlocked(c, err, "TestBlockChangesBind")
+}
+
+type noEgressEnviron struct {
+	environs.Environ
+}
+
+func (s *serviceSuite) TestSetEgressRulesNotSupported(c *gc.C) {
+	s.PatchValue(application.NewEnviron, func(cfg *config.Config) (environs.Environ, error) {
+		env, err := environs.New(cfg)
+		return noEgressEnviron{env}, err
+	})
+	s.AddTestingService(c, "dummy-service", s.AddTestingCharm(c, "dummy"))
+	err := s.applicationApi.SetEgressRules(params.ApplicationEgressRules{
+		ApplicationName: "dummy-service",
+		Rules: []params.EgressRule{{
+			PortRange:        params.PortRange{443, 443, "tcp"},
+			DestinationCIDRs: []string{"10.0.0.0/8"},
+		}},
+	})
+	c.Assert(err, gc.ErrorMatches, `egress rules on "dummy" provider not supported`)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+
+	// Removing egress rules is always possible.
+	err = s.applicationApi.SetEgressRules(params.ApplicationEgressRules{
+		ApplicationName: "dummy-service",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+// virtualIPInstancer is implemented by the dummy provider's environ.
+type virtualIPInstancer interface {
+	VirtualIPAddressInstance(address string) (instance.Id, bool)
+}
+
+func (s *serviceSuite) TestAllocateVirtualIPAddress(c *gc.C) {
+	_, err := s.State.AddSubnet(state.SubnetInfo{CIDR: "10.20.0.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+
+	result, err := s.applicationApi.AllocateVirtualIPAddress(params.ApplicationVirtualIPAddress{
+		ApplicationName: "mysql",
+		SubnetCIDR:      "10.20.0.0/24",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Result, gc.Equals, "10.20.0.254")
+	application, err := s.State.Application("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	address, subnetCIDR := application.VirtualIPAddress()
+	c.Assert(address, gc.Equals, "10.20.0.254")
+	c.Assert(subnetCIDR, gc.Equals, "10.20.0.0/24")
+
+	_, err = s.applicationApi.AllocateVirtualIPAddress(params.ApplicationVirtualIPAddress{
+		ApplicationName: "mysql",
+	})
+	c.Assert(err, gc.ErrorMatches, "empty subnet CIDR not valid")
+}
+
+func (s *serviceSuite) TestReleaseVirtualIPAddress(c *gc.C) {
+	_, err := s.State.AddSubnet(state.SubnetInfo{CIDR: "10.20.0.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	inst, _ := jujutesting.AssertStartInstance(c, s.Environ, s.ControllerConfig.ControllerUUID(), machine.Id())
+	err = machine.SetProvisioned(inst.Id(), "fake_nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	application := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	unit, err := application.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+	address, err := application.AllocateVirtualIPAddress("10.20.0.0/24", "")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.LeadershipClaimer().ClaimLeadership("mysql", unit.Name(), time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	token := s.State.LeadershipChecker().LeadershipCheck("mysql", unit.Name())
+	err = common.MoveVirtualIPAddress(s.State, s.Environ.(environs.VirtualIPManager), application, unit.Name(), token)
+	c.Assert(err, jc.ErrorIsNil)
+	instId, ok := s.Environ.(virtualIPInstancer).VirtualIPAddressInstance(address)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(instId, gc.Equals, inst.Id())
+
+	err = s.applicationApi.ReleaseVirtualIPAddress(params.ApplicationVirtualIPAddress{
+		ApplicationName: "mysql",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok = s.Environ.(virtualIPInstancer).VirtualIPAddressInstance(address)
+	c.Assert(ok, jc.IsFalse)
+	err = application.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	address, _ = application.VirtualIPAddress()
+	c.Assert(address, gc.Equals, "")
+}
+
+type noVirtualIPEnviron struct {
+	environs.Environ
+}
+
+func (s *serviceSuite) TestAllocateVirtualIPAddressNotSupported(c *gc.C) {
+	s.PatchValue(application.NewEnviron, func(cfg *config.Config) (environs.Environ, error) {
+		env, err := environs.New(cfg)
+		return noVirtualIPEnviron{env}, err
+	})
+	_, err := s.State.AddSubnet(state.SubnetInfo{CIDR: "10.20.0.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+
+	_, err = s.applicationApi.AllocateVirtualIPAddress(params.ApplicationVirtualIPAddress{
+		ApplicationName: "mysql",
+		SubnetCIDR:      "10.20.0.0/24",
+	})
+	c.Assert(err, gc.ErrorMatches, `virtual IP addresses on "dummy" provider not supported`)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *serviceSuite) setupServiceExpose(c *gc.C) {
 	charm := s.AddTestingCharm(c, "dummy")
 	serviceNames := []string{"dummy-service", "exposed-service"}
@@ -1918,7 +2167,7 @@ var serviceExposeTests = []struct {
 func (s *serviceSuite) assertServiceExpose(c *gc.C) {
 	for i, t := range serviceExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err := s.applicationApi.Expose(params.ApplicationExpose{t.service})
+		err := s.applicationApi.Expose(params.ApplicationExpose{ApplicationName: t.service})
 		if t.err != "" {
 			c.Assert(err, gc.ErrorMatches, t.err)
 		} else {
@@ -1933,7 +2182,7 @@ func (s *serviceSuite) assertServiceExpose(c *gc.C) {
 func (s *serviceSuite) assertServiceExposeBlocked(c *gc.C, msg string) {
 	for i, t := range serviceExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err := s.applicationApi.Expose(params.ApplicationExpose{t.service})
+		err := s.applicationApi.Expose(params.ApplicationExpose{ApplicationName: t.service})
 		s.AssertBlocked(c, err, msg)
 	}
 }
@@ -0,0 +1,339 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// Offer makes the specified endpoints of applications available to be
+// related to by applications in other models on the controller.
+func (api *API) Offer(args params.ApplicationOffers) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Offers)),
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return result, errors.Trace(err)
+	}
+	for i, offer := range args.Offers {
+		_, err := api.state.AddApplicationOffer(state.ApplicationOffer{
+			OfferName:       offer.OfferName,
+			ApplicationName: offer.ApplicationName,
+			Endpoints:       offer.Endpoints,
+		})
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// RemoveOffers removes the specified application offers. Relations
+// already made through an offer are not affected.
+func (api *API) RemoveOffers(args params.RemoveApplicationOffers) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.OfferNames)),
+	}
+	if err := api.check.RemoveAllowed(); err != nil {
+		return result, errors.Trace(err)
+	}
+	for i, offerName := range args.OfferNames {
+		if _, err := api.state.ApplicationOffer(offerName); err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		err := api.state.RemoveApplicationOffer(offerName)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// offerEndpoint identifies an endpoint of an application offered by
+// another model on the controller, in the form
+// [<owner>/]<model>.<offer>[:<endpoint>].
+type offerEndpoint struct {
+	owner     names.UserTag
+	modelName string
+	offerName string
+	endpoint  string
+}
+
+// parseOfferEndpoint returns the offer endpoint described by the given
+// string, and whether it describes one at all. Application names cannot
+// contain dots, so anything else is an endpoint of a local application.
+func parseOfferEndpoint(s string, defaultOwner names.UserTag) (offerEndpoint, bool, error) {
+	name, endpoint := s, ""
+	if i := strings.Index(s, ":"); i >= 0 {
+		name, endpoint = s[:i], s[i+1:]
+	}
+	i := strings.LastIndex(name, ".")
+	if i < 0 {
+		return offerEndpoint{}, false, nil
+	}
+	result := offerEndpoint{
+		owner:     defaultOwner,
+		modelName: name[:i],
+		offerName: name[i+1:],
+		endpoint:  endpoint,
+	}
+	if j := strings.Index(result.modelName, "/"); j >= 0 {
+		owner := result.modelName[:j]
+		if !names.IsValidUser(owner) {
+			return offerEndpoint{}, true, errors.NotValidf("user name %q", owner)
+		}
+		result.owner = names.NewUserTag(owner)
+		result.modelName = result.modelName[j+1:]
+	}
+	if !names.IsValidModelName(result.modelName) {
+		return offerEndpoint{}, true, errors.NotValidf("model name %q", result.modelName)
+	}
+	if !names.IsValidApplication(result.offerName) {
+		return offerEndpoint{}, true, errors.NotValidf("offer name %q", result.offerName)
+	}
+	return result, true, nil
+}
+
+// splitOfferEndpoint returns, if one of the two endpoints to be related
+// is offered by another model, the other endpoint and the offered one.
+// If the offering model's owner is not specified, it defaults to the
+// authenticated user.
+func (api *API) splitOfferEndpoint(endpoints []string) (string, offerEndpoint, bool, error) {
+	if len(endpoints) != 2 {
+		return "", offerEndpoint{}, false, nil
+	}
+	user, _ := api.authorizer.GetAuthTag().(names.UserTag)
+	for i, endpoint := range endpoints {
+		remote, ok, err := parseOfferEndpoint(endpoint, user)
+		if err != nil {
+			return "", offerEndpoint{}, false, errors.Trace(err)
+		}
+		if ok {
+			return endpoints[1-i], remote, true, nil
+		}
+	}
+	return "", offerEndpoint{}, false, nil
+}
+
+// consumerProxyName returns the name of the remote application that
+// represents, in a model offering an application, the named application
+// consuming the offer from the model with the given tag. The name is
+// derived from both, so that every consumer of a model's offers is
+// represented by a distinct remote application.
+func consumerProxyName(consumerModel names.ModelTag, applicationName string) string {
+	hash := sha1.Sum([]byte(consumerModel.Id() + "/" + applicationName))
+	return fmt.Sprintf("remote%x", hash)
+}
+
+// addRemoteRelation relates the local application endpoint to an
+// endpoint of an application offered by another model on the
+// controller. The offered application is represented in this model by
+// a remote application named after the offer; the local application is
+// represented in the offering model by a remote application too, and
+// the relation is mirrored there.
+func (api *API) addRemoteRelation(localEndpoint string, remote offerEndpoint) (_ params.AddRelationResults, err error) {
+	offeringState, err := api.offeringModel(remote)
+	if err != nil {
+		return params.AddRelationResults{}, errors.Trace(err)
+	}
+	defer offeringState.Close()
+
+	offer, err := offeringState.ApplicationOffer(remote.offerName)
+	if err != nil {
+		return params.AddRelationResults{}, errors.Trace(err)
+	}
+	offered, err := offeringState.Application(offer.ApplicationName)
+	if err != nil {
+		return params.AddRelationResults{}, errors.Trace(err)
+	}
+	remoteEndpointName := ""
+	if remote.endpoint != "" {
+		var ok bool
+		if remoteEndpointName, ok = offer.Endpoints[remote.endpoint]; !ok {
+			return params.AddRelationResults{}, errors.NotFoundf(
+				"endpoint %q of application offer %q", remote.endpoint, remote.offerName,
+			)
+		}
+	}
+
+	// The remote application's endpoints have the same names as
+	// those of the offered application, so that the relations in
+	// either model join the same endpoints.
+	var remoteEndpoints []charm.Relation
+	for _, name := range offer.Endpoints {
+		ep, err := offered.Endpoint(name)
+		if err != nil {
+			return params.AddRelationResults{}, errors.Trace(err)
+		}
+		remoteEndpoints = append(remoteEndpoints, ep.Relation)
+	}
+	remoteApplication, created, err := ensureRemoteApplication(api.state, state.AddRemoteApplicationArgs{
+		Name:                  remote.offerName,
+		SourceModel:           offeringState.ModelTag(),
+		SourceApplicationName: offer.ApplicationName,
+		OfferName:             offer.OfferName,
+		Endpoints:             remoteEndpoints,
+	})
+	if err != nil {
+		return params.AddRelationResults{}, errors.Trace(err)
+	}
+	defer func() {
+		if created && err != nil {
+			if err := remoteApplication.Destroy(); err != nil {
+				logger.Errorf("cleaning up after failure to relate to offer: %v", err)
+			}
+		}
+	}()
+
+	remoteName := remoteApplication.Name()
+	if remoteEndpointName != "" {
+		remoteName += ":" + remoteEndpointName
+	}
+	eps, err := api.state.InferEndpoints(localEndpoint, remoteName)
+	if err != nil {
+		return params.AddRelationResults{}, errors.Trace(err)
+	}
+	if _, err := api.state.EndpointsRelation(eps...); err == nil {
+		return params.AddRelationResults{}, errors.AlreadyExistsf("relation %q", eps[0].String()+" "+eps[1].String())
+	} else if !errors.IsNotFound(err) {
+		return params.AddRelationResults{}, errors.Trace(err)
+	}
+	local, remoteEp := eps[0], eps[1]
+	if local.ApplicationName == remoteApplication.Name() {
+		local, remoteEp = remoteEp, local
+	}
+	localApplication, err := api.state.Application(local.ApplicationName)
+	if err != nil {
+		return params.AddRelationResults{}, errors.Trace(err)
+	}
+
+	// Mirror the relation in the offering model.
+	proxyEndpoints, err := consumerProxyEndpoints(localApplication)
+	if err != nil {
+		return params.AddRelationResults{}, errors.Trace(err)
+	}
+	proxy, proxyCreated, err := ensureRemoteApplication(offeringState, state.AddRemoteApplicationArgs{
+		Name:                  consumerProxyName(api.state.ModelTag(), local.ApplicationName),
+		SourceModel:           api.state.ModelTag(),
+		SourceApplicationName: local.ApplicationName,
+		OfferName:             offer.OfferName,
+		IsConsumerProxy:       true,
+		Endpoints:             proxyEndpoints,
+	})
+	if err != nil {
+		return params.AddRelationResults{}, errors.Annotate(err, "adding consumer to offering model")
+	}
+	proxyEp, err := proxy.Endpoint(local.Name)
+	if err != nil {
+		return params.AddRelationResults{}, errors.Trace(err)
+	}
+	offeredEp, err := offered.Endpoint(remoteEp.Name)
+	if err != nil {
+		return params.AddRelationResults{}, errors.Trace(err)
+	}
+	mirror, err := offeringState.AddRelation(proxyEp, offeredEp)
+	if err != nil {
+		if proxyCreated {
+			if err := proxy.Destroy(); err != nil {
+				logger.Errorf("cleaning up after failure to relate to offer: %v", err)
+			}
+		}
+		return params.AddRelationResults{}, errors.Annotate(err, "relating in offering model")
+	}
+
+	rel, err := api.state.AddRelation(eps...)
+	if err != nil {
+		if err := mirror.Destroy(); err != nil {
+			logger.Errorf("cleaning up after failure to relate to offer: %v", err)
+		}
+		return params.AddRelationResults{}, errors.Trace(err)
+	}
+	outEps := make(map[string]charm.Relation)
+	for _, ep := range rel.Endpoints() {
+		outEps[ep.ApplicationName] = ep.Relation
+	}
+	return params.AddRelationResults{Endpoints: outEps}, nil
+}
+
+// offeringModel returns the state of the model identified by the offer
+// endpoint. Relating to an offer changes the offering model, so the
+// authenticated user must have write or admin access to it.
+func (api *API) offeringModel(remote offerEndpoint) (*state.State, error) {
+	user, ok := api.authorizer.GetAuthTag().(names.UserTag)
+	if !ok {
+		return nil, common.ErrPerm
+	}
+	models, err := api.state.ModelsForUser(user)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, model := range models {
+		if model.Name() != remote.modelName || model.Owner().Canonical() != remote.owner.Canonical() {
+			continue
+		}
+		st, err := api.state.ForModel(model.ModelTag())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		modelUser, err := st.ModelUser(user)
+		if err != nil {
+			st.Close()
+			return nil, errors.Trace(err)
+		}
+		if modelUser.IsReadOnly() {
+			st.Close()
+			return nil, common.ErrPerm
+		}
+		return st, nil
+	}
+	return nil, errors.NotFoundf("model %q", remote.owner.Canonical()+"/"+remote.modelName)
+}
+
+// consumerProxyEndpoints returns the endpoints of the remote application
+// representing the given application in a model offering applications
+// to it.
+func consumerProxyEndpoints(application *state.Application) ([]charm.Relation, error) {
+	eps, err := application.Endpoints()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result []charm.Relation
+	for _, ep := range eps {
+		if ep.Role == charm.RolePeer || ep.Scope == charm.ScopeContainer {
+			continue
+		}
+		result = append(result, ep.Relation)
+	}
+	return result, nil
+}
+
+// ensureRemoteApplication adds a remote application with the supplied
+// arguments, unless one representing the same application already
+// exists. It returns the remote application, and whether it was added.
+func ensureRemoteApplication(st *state.State, args state.AddRemoteApplicationArgs) (*state.RemoteApplication, bool, error) {
+	existing, err := st.RemoteApplication(args.Name)
+	if errors.IsNotFound(err) {
+		application, err := st.AddRemoteApplication(args)
+		if err != nil {
+			return nil, false, errors.Trace(err)
+		}
+		return application, true, nil
+	} else if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	if existing.SourceModel() != args.SourceModel ||
+		existing.SourceApplicationName() != args.SourceApplicationName {
+		return nil, false, errors.AlreadyExistsf(
+			"remote application %q for a different application", args.Name,
+		)
+	}
+	return existing, false, nil
+}
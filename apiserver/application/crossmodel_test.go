@@ -0,0 +1,212 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/application"
+	commontesting "github.com/juju/juju/apiserver/common/testing"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type crossModelSuite struct {
+	jujutesting.JujuConnSuite
+	commontesting.BlockHelper
+
+	applicationApi *application.API
+	offeringState  *state.State
+}
+
+var _ = gc.Suite(&crossModelSuite{})
+
+func (s *crossModelSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+	s.BlockHelper = commontesting.NewBlockHelper(s.APIState)
+	s.AddCleanup(func(*gc.C) { s.BlockHelper.Close() })
+	authorizer := apiservertesting.FakeAuthorizer{
+		Tag: s.AdminUserTag(c),
+	}
+	var err error
+	s.applicationApi, err = application.NewAPI(s.State, nil, authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.offeringState = s.Factory.MakeModel(c, &factory.ModelParams{Name: "prod"})
+	s.AddCleanup(func(*gc.C) { s.offeringState.Close() })
+	f := factory.NewFactory(s.offeringState)
+	f.MakeApplication(c, &factory.ApplicationParams{
+		Name:  "mysql",
+		Charm: f.MakeCharm(c, &factory.CharmParams{Name: "mysql"}),
+	})
+	_, err = s.offeringState.AddApplicationOffer(state.ApplicationOffer{
+		OfferName:       "hosted-mysql",
+		ApplicationName: "mysql",
+		Endpoints:       map[string]string{"database": "server"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+}
+
+func (s *crossModelSuite) TestOffer(c *gc.C) {
+	results, err := s.applicationApi.Offer(params.ApplicationOffers{
+		Offers: []params.ApplicationOffer{{
+			OfferName:       "blog",
+			ApplicationName: "wordpress",
+			Endpoints:       map[string]string{"website": "url"},
+		}, {
+			OfferName:       "missing",
+			ApplicationName: "missing",
+			Endpoints:       map[string]string{"db": "db"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `cannot add application offer "missing": application "missing" not found`)
+
+	offer, err := s.State.ApplicationOffer("blog")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(offer, jc.DeepEquals, &state.ApplicationOffer{
+		OfferName:       "blog",
+		ApplicationName: "wordpress",
+		Endpoints:       map[string]string{"website": "url"},
+	})
+}
+
+func (s *crossModelSuite) TestRemoveOffers(c *gc.C) {
+	_, err := s.State.AddApplicationOffer(state.ApplicationOffer{
+		OfferName:       "blog",
+		ApplicationName: "wordpress",
+		Endpoints:       map[string]string{"website": "url"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.applicationApi.RemoveOffers(params.RemoveApplicationOffers{
+		OfferNames: []string{"blog", "missing"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `application offer "missing" not found`)
+
+	_, err = s.State.ApplicationOffer("blog")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *crossModelSuite) TestBlockRemoveOffers(c *gc.C) {
+	s.BlockRemoveObject(c, "TestBlockRemoveOffers")
+	_, err := s.applicationApi.RemoveOffers(params.RemoveApplicationOffers{
+		OfferNames: []string{"blog"},
+	})
+	s.AssertBlocked(c, err, "TestBlockRemoveOffers")
+}
+
+func (s *crossModelSuite) TestAddRelationToOffer(c *gc.C) {
+	result, err := s.applicationApi.AddRelation(params.AddRelation{
+		Endpoints: []string{"wordpress", "admin/prod.hosted-mysql:database"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Endpoints, gc.HasLen, 2)
+	c.Assert(result.Endpoints["wordpress"].Name, gc.Equals, "db")
+	c.Assert(result.Endpoints["hosted-mysql"].Name, gc.Equals, "server")
+
+	// The offered application is represented in the consuming model.
+	remote, err := s.State.RemoteApplication("hosted-mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(remote.SourceModel(), gc.Equals, s.offeringState.ModelTag())
+	c.Assert(remote.SourceApplicationName(), gc.Equals, "mysql")
+	c.Assert(remote.IsConsumerProxy(), jc.IsFalse)
+	rels, err := remote.Relations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rels, gc.HasLen, 1)
+	c.Assert(rels[0].String(), gc.Equals, "wordpress:db hosted-mysql:server")
+
+	// The consuming application is represented in the offering model,
+	// where the relation is mirrored.
+	proxies, err := s.offeringState.AllRemoteApplications()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(proxies, gc.HasLen, 1)
+	proxy := proxies[0]
+	c.Assert(proxy.SourceModel(), gc.Equals, s.State.ModelTag())
+	c.Assert(proxy.SourceApplicationName(), gc.Equals, "wordpress")
+	c.Assert(proxy.OfferName(), gc.Equals, "hosted-mysql")
+	c.Assert(proxy.IsConsumerProxy(), jc.IsTrue)
+	rels, err = proxy.Relations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rels, gc.HasLen, 1)
+	c.Assert(rels[0].String(), gc.Equals, proxy.Name()+":db mysql:server")
+
+	// The relation cannot be added twice.
+	_, err = s.applicationApi.AddRelation(params.AddRelation{
+		Endpoints: []string{"wordpress", "prod.hosted-mysql"},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *crossModelSuite) TestAddRelationToOfferErrors(c *gc.C) {
+	for i, test := range []struct {
+		endpoint string
+		err      string
+	}{{
+		endpoint: "staging.hosted-mysql",
+		err:      `model "admin@local/staging" not found`,
+	}, {
+		endpoint: "prod.hosted-postgresql",
+		err:      `application offer "hosted-postgresql" not found`,
+	}, {
+		endpoint: "prod.hosted-mysql:admin",
+		err:      `endpoint "admin" of application offer "hosted-mysql" not found`,
+	}, {
+		endpoint: "prod.Hosted",
+		err:      `offer name "Hosted" not valid`,
+	}} {
+		c.Logf("test %d: %s", i, test.endpoint)
+		_, err := s.applicationApi.AddRelation(params.AddRelation{
+			Endpoints: []string{"wordpress", test.endpoint},
+		})
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	// Nothing is left behind in either model.
+	remotes, err := s.State.AllRemoteApplications()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(remotes, gc.HasLen, 0)
+	remotes, err = s.offeringState.AllRemoteApplications()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(remotes, gc.HasLen, 0)
+}
+
+func (s *crossModelSuite) TestAddRelationToOfferRequiresWriteAccess(c *gc.C) {
+	s.Factory.MakeUser(c, &factory.UserParams{Name: "bob"})
+	f := factory.NewFactory(s.offeringState)
+	modelUser := f.MakeModelUser(c, &factory.ModelUserParams{
+		User:   "bob",
+		Access: state.ReadAccess,
+	})
+	authorizer := apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("bob"),
+	}
+	api, err := application.NewAPI(s.State, nil, authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.AddRelation{
+		Endpoints: []string{"wordpress", "admin/prod.hosted-mysql:database"},
+	}
+	_, err = api.AddRelation(args)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	remotes, err := s.State.AllRemoteApplications()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(remotes, gc.HasLen, 0)
+
+	err = modelUser.SetAccess(state.WriteAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.AddRelation(args)
+	c.Assert(err, jc.ErrorIsNil)
+}
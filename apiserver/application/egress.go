@@ -0,0 +1,110 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/network"
+)
+
+var newEnviron = environs.New
+
+// EgressRules returns the egress rules of the specified application
+// or, if no application is specified, of the model.
+func (api *API) EgressRules(args params.ApplicationEgressRules) (params.EgressRulesResult, error) {
+	var rules []network.EgressRule
+	if args.ApplicationName == "" {
+		cfg, err := api.state.ModelConfig()
+		if err != nil {
+			return params.EgressRulesResult{}, errors.Trace(err)
+		}
+		rules = cfg.EgressRules()
+	} else {
+		application, err := api.state.Application(args.ApplicationName)
+		if err != nil {
+			return params.EgressRulesResult{}, errors.Trace(err)
+		}
+		rules = application.EgressRules()
+	}
+	result := params.EgressRulesResult{
+		Rules: make([]params.EgressRule, len(rules)),
+	}
+	for i, rule := range rules {
+		result.Rules[i] = params.FromNetworkEgressRule(rule)
+	}
+	return result, nil
+}
+
+// SetEgressRules replaces the egress rules of the specified application
+// or, if no application is specified, of the model. Egress rules may
+// only be set if the model's provider can restrict egress traffic.
+func (api *API) SetEgressRules(args params.ApplicationEgressRules) error {
+	if err := api.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	rules := make([]network.EgressRule, len(args.Rules))
+	for i, rule := range args.Rules {
+		rules[i] = rule.NetworkEgressRule()
+		if err := rules[i].Validate(); err != nil {
+			return errors.NewNotValid(err, "")
+		}
+	}
+	if len(rules) > 0 {
+		if err := api.checkSupportsEgressRules(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	if args.ApplicationName != "" {
+		application, err := api.state.Application(args.ApplicationName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return application.SetEgressRules(rules)
+	}
+	if len(rules) == 0 {
+		return api.state.UpdateModelConfig(nil, []string{config.EgressRulesKey}, nil)
+	}
+	values := make([]string, len(rules))
+	for i, rule := range rules {
+		values[i] = rule.String()
+	}
+	return api.state.UpdateModelConfig(map[string]interface{}{
+		config.EgressRulesKey: strings.Join(values, " "),
+	}, nil, nil)
+}
+
+// checkSupportsEgressRules returns an error satisfying
+// errors.IsNotSupported if the model's provider cannot
+// restrict egress traffic.
+func (api *API) checkSupportsEgressRules() error {
+	env, err := environs.GetEnviron(api.state, newEnviron)
+	if err != nil {
+		return errors.Annotate(err, "getting environ")
+	}
+	if _, ok := env.(environs.EgressFirewaller); !ok {
+		return errors.NotSupportedf("egress rules on %q provider", env.Config().Type())
+	}
+	return nil
+}
+
+// checkSupportsIngressRules returns an error satisfying
+// errors.IsNotSupported if the model's provider cannot
+// restrict the source addresses of ingress traffic.
+func (api *API) checkSupportsIngressRules() error {
+	env, err := environs.GetEnviron(api.state, newEnviron)
+	if err != nil {
+		return errors.Annotate(err, "getting environ")
+	}
+	if _, ok := env.(environs.IngressRuleFirewaller); !ok {
+		return errors.NotSupportedf("exposing to specific CIDRs or spaces on %q provider", env.Config().Type())
+	}
+	return nil
+}
@@ -8,6 +8,7 @@ import "github.com/juju/errors"
 var (
 	ParseSettingsCompatible = parseSettingsCompatible
 	NewStateStorage         = &newStateStorage
+	NewEnviron              = &newEnviron
 )
 
 func IsMinJujuVersionError(err error) bool {
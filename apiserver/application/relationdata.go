@@ -0,0 +1,84 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// RelationData returns the relation settings of every unit taking part
+// in the relation between the specified endpoints.
+func (api *API) RelationData(args params.RelationDataArgs) (params.RelationDataResult, error) {
+	eps, err := api.state.InferEndpoints(args.Endpoints...)
+	if err != nil {
+		return params.RelationDataResult{}, err
+	}
+	rel, err := api.state.EndpointsRelation(eps...)
+	if err != nil {
+		return params.RelationDataResult{}, err
+	}
+	result := params.RelationDataResult{
+		RelationId: rel.Id(),
+		Key:        rel.String(),
+	}
+	for _, ep := range rel.Endpoints() {
+		data, err := api.applicationRelationData(rel, ep)
+		if err != nil {
+			return params.RelationDataResult{}, errors.Annotatef(err, "reading %q settings", ep.String())
+		}
+		result.Applications = append(result.Applications, data)
+	}
+	return result, nil
+}
+
+func (api *API) applicationRelationData(rel *state.Relation, ep state.Endpoint) (params.RelationApplicationData, error) {
+	result := params.RelationApplicationData{
+		Application: ep.ApplicationName,
+		Endpoint:    ep.Name,
+		Role:        string(ep.Role),
+	}
+	application, err := api.state.Application(ep.ApplicationName)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	units, err := application.AllUnits()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	sort.Sort(unitsByName(units))
+	for _, unit := range units {
+		ru, err := rel.Unit(unit)
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		inScope, err := ru.InScope()
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		unitData := params.RelationUnitData{
+			Unit:    unit.Name(),
+			InScope: inScope,
+		}
+		if inScope {
+			settings, err := ru.Settings()
+			if err != nil {
+				return result, errors.Trace(err)
+			}
+			unitData.Settings = settings.Map()
+		}
+		result.Units = append(result.Units, unitData)
+	}
+	return result, nil
+}
+
+type unitsByName []*state.Unit
+
+func (u unitsByName) Len() int           { return len(u) }
+func (u unitsByName) Swap(i, j int)      { u[i], u[j] = u[j], u[i] }
+func (u unitsByName) Less(i, j int) bool { return u[i].Name() < u[j].Name() }
@@ -0,0 +1,136 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/application"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+)
+
+type relationDataSuite struct {
+	jujutesting.JujuConnSuite
+
+	applicationApi *application.API
+}
+
+var _ = gc.Suite(&relationDataSuite{})
+
+func (s *relationDataSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+	authorizer := apiservertesting.FakeAuthorizer{
+		Tag: s.AdminUserTag(c),
+	}
+	var err error
+	s.applicationApi, err = application.NewAPI(s.State, nil, authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *relationDataSuite) addUnit(c *gc.C, app *state.Application) *state.Unit {
+	unit, err := app.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	return unit
+}
+
+func (s *relationDataSuite) enterScope(c *gc.C, rel *state.Relation, unit *state.Unit, settings map[string]interface{}) {
+	ru, err := rel.Unit(unit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ru.EnterScope(settings)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *relationDataSuite) TestRelationData(c *gc.C) {
+	wordpress := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	mysql := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	wordpress0 := s.addUnit(c, wordpress)
+	mysql0 := s.addUnit(c, mysql)
+	s.addUnit(c, mysql)
+	s.enterScope(c, rel, wordpress0, map[string]interface{}{"private-address": "10.0.0.1"})
+	s.enterScope(c, rel, mysql0, map[string]interface{}{
+		"private-address": "10.0.0.2",
+		"password":        "sekrit",
+	})
+
+	result, err := s.applicationApi.RelationData(params.RelationDataArgs{
+		Endpoints: []string{"mysql", "wordpress"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.RelationDataResult{
+		RelationId: rel.Id(),
+		Key:        "wordpress:db mysql:server",
+		Applications: []params.RelationApplicationData{{
+			Application: "wordpress",
+			Endpoint:    "db",
+			Role:        "requirer",
+			Units: []params.RelationUnitData{{
+				Unit:     "wordpress/0",
+				InScope:  true,
+				Settings: map[string]interface{}{"private-address": "10.0.0.1"},
+			}},
+		}, {
+			Application: "mysql",
+			Endpoint:    "server",
+			Role:        "provider",
+			Units: []params.RelationUnitData{{
+				Unit:    "mysql/0",
+				InScope: true,
+				Settings: map[string]interface{}{
+					"private-address": "10.0.0.2",
+					"password":        "sekrit",
+				},
+			}, {
+				Unit: "mysql/1",
+			}},
+		}},
+	})
+}
+
+func (s *relationDataSuite) TestRelationDataPeer(c *gc.C) {
+	riak := s.AddTestingService(c, "riak", s.AddTestingCharm(c, "riak"))
+	eps, err := s.State.InferEndpoints("riak:ring")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.EndpointsRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	riak0 := s.addUnit(c, riak)
+	s.enterScope(c, rel, riak0, map[string]interface{}{"private-address": "10.0.0.1"})
+
+	result, err := s.applicationApi.RelationData(params.RelationDataArgs{
+		Endpoints: []string{"riak:ring"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.RelationDataResult{
+		RelationId: rel.Id(),
+		Key:        "riak:ring",
+		Applications: []params.RelationApplicationData{{
+			Application: "riak",
+			Endpoint:    "ring",
+			Role:        "peer",
+			Units: []params.RelationUnitData{{
+				Unit:     "riak/0",
+				InScope:  true,
+				Settings: map[string]interface{}{"private-address": "10.0.0.1"},
+			}},
+		}},
+	})
+}
+
+func (s *relationDataSuite) TestRelationDataNoRelation(c *gc.C) {
+	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	_, err := s.applicationApi.RelationData(params.RelationDataArgs{
+		Endpoints: []string{"wordpress", "mysql"},
+	})
+	c.Assert(err, gc.ErrorMatches, `relation "wordpress:db mysql:server" not found`)
+}
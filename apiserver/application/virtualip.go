@@ -0,0 +1,55 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// AllocateVirtualIPAddress allocates a virtual IP address to the
+// specified application from the specified subnet. The address is
+// assigned to the machine of the application's leader unit by the
+// unit itself. Virtual IP addresses may only be allocated if the
+// model's provider can manage them.
+func (api *API) AllocateVirtualIPAddress(args params.ApplicationVirtualIPAddress) (params.StringResult, error) {
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.StringResult{}, errors.Trace(err)
+	}
+	if args.SubnetCIDR == "" {
+		return params.StringResult{}, errors.NotValidf("empty subnet CIDR")
+	}
+	if _, err := common.VirtualIPManager(api.state, newEnviron); err != nil {
+		return params.StringResult{}, errors.Trace(err)
+	}
+	application, err := api.state.Application(args.ApplicationName)
+	if err != nil {
+		return params.StringResult{}, errors.Trace(err)
+	}
+	address, err := application.AllocateVirtualIPAddress(args.SubnetCIDR, args.Address)
+	if err != nil {
+		return params.StringResult{}, errors.Trace(err)
+	}
+	return params.StringResult{Result: address}, nil
+}
+
+// ReleaseVirtualIPAddress removes the virtual IP address of the
+// specified application from the machine it is assigned to, and
+// releases it.
+func (api *API) ReleaseVirtualIPAddress(args params.ApplicationVirtualIPAddress) error {
+	if err := api.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	application, err := api.state.Application(args.ApplicationName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	manager, err := common.VirtualIPManager(api.state, newEnviron)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return common.ReleaseVirtualIPAddress(api.state, manager, application)
+}
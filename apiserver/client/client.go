@@ -25,7 +25,7 @@ import (
 )
 
 func init() {
-	common.RegisterStandardFacade("Client", 1, NewClient)
+	common.RegisterStandardFacade("Client", 2, NewClient)
 }
 
 var logger = loggo.GetLogger("juju.apiserver.client")
@@ -205,13 +205,20 @@ func (c *Client) addOneMachine(p params.AddMachineParams) (*state.Machine, error
 	if p.ContainerType != "" && p.Placement != nil {
 		return nil, fmt.Errorf("container type and placement are mutually exclusive")
 	}
+	var staticIPAddress string
 	if p.Placement != nil {
-		// Extract container type and parent from container placement directives.
+		// Extract container type, parent and any static IP address
+		// from container placement directives.
 		containerType, err := instance.ParseContainerType(p.Placement.Scope)
 		if err == nil {
+			parentId, address, err := instance.ParseContainerDirective(p.Placement.Directive)
+			if err != nil {
+				return nil, errors.Annotatef(err, "invalid container placement %q", p.Placement)
+			}
 			p.ContainerType = containerType
-			p.ParentId = p.Placement.Directive
+			p.ParentId = parentId
 			p.Placement = nil
+			staticIPAddress = address
 		}
 	}
 
@@ -264,10 +271,12 @@ func (c *Client) addOneMachine(p params.AddMachineParams) (*state.Machine, error
 	if p.ContainerType == "" {
 		return c.api.stateAccessor.AddOneMachine(template)
 	}
+	parentTemplate := template
+	template.StaticIPAddress = staticIPAddress
 	if p.ParentId != "" {
 		return c.api.stateAccessor.AddMachineInsideMachine(template, p.ParentId, p.ContainerType)
 	}
-	return c.api.stateAccessor.AddMachineInsideNewMachine(template, template, p.ContainerType)
+	return c.api.stateAccessor.AddMachineInsideNewMachine(template, parentTemplate, p.ContainerType)
 }
 
 // ProvisioningScript returns a shell script that, when run,
@@ -421,12 +430,39 @@ func (c *Client) ModelSet(args params.ModelSet) error {
 		}
 		return nil
 	}
+	// Make sure egress rules are only set if the provider can apply them.
+	checkEgressRules := func(updateAttrs map[string]interface{}, removeAttrs []string, oldConfig *config.Config) error {
+		if _, found := updateAttrs[config.EgressRulesKey]; !found {
+			return nil
+		}
+		newConfig, err := oldConfig.Apply(updateAttrs)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if len(newConfig.EgressRules()) == 0 {
+			return nil
+		}
+		env, err := getEnvironment(oldConfig)
+		if err != nil {
+			return errors.Annotate(err, "getting environ")
+		}
+		if _, ok := env.(environs.EgressFirewaller); !ok {
+			return errors.NotSupportedf("egress rules on %q provider", oldConfig.Type())
+		}
+		return nil
+	}
+	validate := func(updateAttrs map[string]interface{}, removeAttrs []string, oldConfig *config.Config) error {
+		if err := checkAgentVersion(updateAttrs, removeAttrs, oldConfig); err != nil {
+			return err
+		}
+		return checkEgressRules(updateAttrs, removeAttrs, oldConfig)
+	}
 	// Replace any deprecated attributes with their new values.
 	attrs := config.ProcessDeprecatedAttributes(args.Config)
 	// TODO(waigani) 2014-3-11 #1167616
 	// Add a txn retry loop to ensure that the settings on disk have not
 	// changed underneath us.
-	return c.api.stateAccessor.UpdateModelConfig(attrs, nil, checkAgentVersion)
+	return c.api.stateAccessor.UpdateModelConfig(attrs, nil, validate)
 }
 
 // ModelUnset implements the server-side part of the
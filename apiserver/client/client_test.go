@@ -897,6 +897,42 @@ func (s *serverSuite) TestClientModelSetCannotChangeAgentVersion(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+type noEgressEnviron struct {
+	environs.Environ
+}
+
+func (s *serverSuite) TestClientModelSetEgressRules(c *gc.C) {
+	args := params.ModelSet{
+		map[string]interface{}{"egress-rules": "443/tcp@10.0.0.0/8"},
+	}
+	err := s.client.ModelSet(args)
+	c.Assert(err, jc.ErrorIsNil)
+	modelConfig, err := s.State.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelConfig.EgressRules(), gc.HasLen, 1)
+}
+
+func (s *serverSuite) TestClientModelSetEgressRulesNotSupported(c *gc.C) {
+	s.PatchValue(client.GetEnvironment, func(cfg *config.Config) (environs.Environ, error) {
+		env, err := environs.New(cfg)
+		return noEgressEnviron{env}, err
+	})
+	args := params.ModelSet{
+		map[string]interface{}{"egress-rules": "443/tcp@10.0.0.0/8"},
+	}
+	err := s.client.ModelSet(args)
+	c.Assert(err, gc.ErrorMatches, `egress rules on "dummy" provider not supported`)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	modelConfig, err := s.State.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelConfig.EgressRules(), gc.HasLen, 0)
+
+	// Clearing the egress rules is always possible.
+	args.Config["egress-rules"] = ""
+	err = s.client.ModelSet(args)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *serverSuite) TestClientModelUnset(c *gc.C) {
 	err := s.State.UpdateModelConfig(map[string]interface{}{"abc": 123}, nil, nil)
 	c.Assert(err, jc.ErrorIsNil)
@@ -1197,7 +1233,7 @@ func (s *clientSuite) TestInjectMachinesStillExists(c *gc.C) {
 			Nonce:      "nonce",
 		}},
 	}
-	err := s.APIState.APICall("Client", 1, "", "AddMachines", args, &results)
+	err := s.APIState.APICall("Client", 2, "", "AddMachines", args, &results)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results.Machines, gc.HasLen, 1)
 }
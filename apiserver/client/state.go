@@ -26,6 +26,7 @@ type Unit interface {
 	PrivateAddress() (network.Address, error)
 	Resolve(retryHooks bool) error
 	AgentHistory() status.StatusHistoryGetter
+	HookHistory() ([]state.HookExecution, error)
 }
 
 // stateInterface contains the state.State methods used in this package,
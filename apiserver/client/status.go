@@ -152,6 +152,53 @@ func (c *Client) StatusHistory(request params.StatusHistoryRequests) params.Stat
 	return results
 }
 
+// HookHistory returns the most recent hook executions recorded for
+// each of the given units, oldest first.
+func (c *Client) HookHistory(args params.Entities) params.HookHistoryResults {
+	results := params.HookHistoryResults{
+		Results: make([]params.HookHistoryResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		executions, err := c.unitHookHistory(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(
+				errors.Annotatef(err, "fetching hook history for %q", entity.Tag),
+			)
+			continue
+		}
+		results.Results[i].Executions = executions
+	}
+	return results
+}
+
+func (c *Client) unitHookHistory(tag string) ([]params.HookExecution, error) {
+	unitTag, err := names.ParseUnitTag(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	unit, err := c.api.stateAccessor.Unit(unitTag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	history, err := unit.HookHistory()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	executions := make([]params.HookExecution, len(history))
+	for i, exec := range history {
+		executions[i] = params.HookExecution{
+			Hook:       exec.Hook,
+			RelationId: exec.RelationId,
+			RemoteUnit: exec.RemoteUnit,
+			Started:    exec.Started,
+			Finished:   exec.Finished,
+			ExitCode:   exec.ExitCode,
+			ToolCalls:  exec.ToolCalls,
+		}
+	}
+	return executions, nil
+}
+
 // FullStatus gives the information needed for juju status over the api
 func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error) {
 	cfg, err := c.api.stateAccessor.ModelConfig()
@@ -493,6 +540,12 @@ func makeMachineStatus(machine *state.Machine) (status params.MachineStatus) {
 	} else {
 		status.Hardware = hc.String()
 	}
+	if drift := machine.FirewallDrift(); !drift.IsEmpty() {
+		status.FirewallDrift = &params.FirewallDrift{
+			Missing:    drift.Missing,
+			Unexpected: drift.Unexpected,
+		}
+	}
 	status.Containers = make(map[string]params.MachineStatus)
 	return
 }
@@ -575,10 +628,11 @@ func (context *statusContext) processApplications() map[string]params.Applicatio
 func (context *statusContext) processApplication(service *state.Application) params.ApplicationStatus {
 	serviceCharmURL, _ := service.CharmURL()
 	var processedStatus = params.ApplicationStatus{
-		Charm:   serviceCharmURL.String(),
-		Series:  service.Series(),
-		Exposed: service.IsExposed(),
-		Life:    processLife(service),
+		Charm:           serviceCharmURL.String(),
+		Series:          service.Series(),
+		Exposed:         service.IsExposed(),
+		Life:            processLife(service),
+		WorkloadVersion: workloadVersion(context.units[service.Name()]),
 	}
 
 	if latestCharm, ok := context.latestCharms[*serviceCharmURL.WithRevision(-1)]; ok && latestCharm != nil {
@@ -610,6 +664,25 @@ func (context *statusContext) processApplication(service *state.Application) par
 	return processedStatus
 }
 
+// workloadVersion returns the workload version reported by the
+// most units of an application. Ties are broken in favour of the
+// lexically greatest version, so that the result is stable.
+func workloadVersion(units map[string]*state.Unit) string {
+	counts := make(map[string]int)
+	for _, unit := range units {
+		if version := unit.WorkloadVersion(); version != "" {
+			counts[version]++
+		}
+	}
+	var result string
+	for version, count := range counts {
+		if count > counts[result] || (count == counts[result] && version > result) {
+			result = version
+		}
+	}
+	return result
+}
+
 func isColorStatus(code state.MeterStatusCode) bool {
 	return code == state.MeterGreen || code == state.MeterAmber || code == state.MeterRed
 }
@@ -660,6 +733,7 @@ func (context *statusContext) processUnit(unit *state.Unit, serviceCharm string)
 	if serviceCharm != "" && curl != nil && curl.String() != serviceCharm {
 		result.Charm = curl.String()
 	}
+	result.WorkloadVersion = unit.WorkloadVersion()
 	processUnitAndAgentStatus(unit, &result)
 
 	if subUnits := unit.SubordinateNames(); len(subUnits) > 0 {
@@ -97,6 +97,25 @@ func (s *statusUnitTestSuite) TestProcessMachinesWithEmbeddedContainers(c *gc.C)
 	c.Check(hostContainer[lxdHost.Id()].Containers, gc.HasLen, 1)
 }
 
+func (s *statusUnitTestSuite) TestMachineFirewallDrift(c *gc.C) {
+	machine := s.MakeMachine(c, &factory.MachineParams{InstanceId: instance.Id("0")})
+	status := client.MakeMachineStatus(machine)
+	c.Check(status.FirewallDrift, gc.IsNil)
+
+	err := machine.SetFirewallDrift(state.FirewallDrift{
+		Missing:    []string{"80/tcp from 0.0.0.0/0"},
+		Unexpected: []string{"22/tcp from 10.0.0.0/8"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	status = client.MakeMachineStatus(machine)
+	c.Check(status.FirewallDrift, jc.DeepEquals, &params.FirewallDrift{
+		Missing:    []string{"80/tcp from 0.0.0.0/0"},
+		Unexpected: []string{"22/tcp from 10.0.0.0/8"},
+	})
+}
+
 var testUnits = []struct {
 	unitName       string
 	setStatus      *state.MeterStatus
@@ -153,6 +172,51 @@ func (s *statusUnitTestSuite) TestMeterStatus(c *gc.C) {
 	}
 }
 
+func (s *statusUnitTestSuite) TestWorkloadVersion(c *gc.C) {
+	application := s.MakeApplication(c, nil)
+	for _, version := range []string{"1.2", "1.3", "1.2", ""} {
+		unit, err := application.AddUnit()
+		c.Assert(err, jc.ErrorIsNil)
+		if version != "" {
+			err = unit.SetWorkloadVersion(version)
+			c.Assert(err, jc.ErrorIsNil)
+		}
+	}
+
+	client := s.APIState.Client()
+	status, err := client.Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	applicationStatus, ok := status.Applications[application.Name()]
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(applicationStatus.WorkloadVersion, gc.Equals, "1.2")
+
+	versions := make(map[string]string)
+	for name, unitStatus := range applicationStatus.Units {
+		versions[name] = unitStatus.WorkloadVersion
+	}
+	name := application.Name()
+	c.Assert(versions, jc.DeepEquals, map[string]string{
+		name + "/0": "1.2",
+		name + "/1": "1.3",
+		name + "/2": "1.2",
+		name + "/3": "",
+	})
+}
+
+func (s *statusUnitTestSuite) TestWorkloadVersionTie(c *gc.C) {
+	application := s.MakeApplication(c, nil)
+	for _, version := range []string{"1.3", "1.2"} {
+		unit, err := application.AddUnit()
+		c.Assert(err, jc.ErrorIsNil)
+		err = unit.SetWorkloadVersion(version)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	status, err := s.APIState.Client().Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status.Applications[application.Name()].WorkloadVersion, gc.Equals, "1.3")
+}
+
 type statusUpgradeUnitSuite struct {
 	testing.CharmSuite
 	jujutesting.JujuConnSuite
@@ -14,6 +14,7 @@ import (
 	"github.com/juju/juju/apiserver/client"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/testing"
 )
@@ -210,10 +211,51 @@ func (s *statusHistoryTestSuite) TestStatusHistoryCombined(c *gc.C) {
 	checkStatusInfo(c, h.Results[0].History.Statuses, expected)
 }
 
+func (s *statusHistoryTestSuite) TestHookHistory(c *gc.C) {
+	started := time.Date(2016, 10, 1, 12, 0, 0, 0, time.UTC)
+	s.st.hookHistory = []state.HookExecution{{
+		Hook:       "db-relation-joined",
+		RelationId: 1,
+		RemoteUnit: "mysql/0",
+		Started:    started,
+		Finished:   started.Add(time.Second),
+		ExitCode:   1,
+		ToolCalls:  []string{"relation-get"},
+	}}
+	results := s.api.HookHistory(params.Entities{Entities: []params.Entity{
+		{Tag: "unit-unit-0"},
+		{Tag: "unit-unit-1"},
+		{Tag: "machine-0"},
+	}})
+	c.Assert(results, jc.DeepEquals, params.HookHistoryResults{
+		Results: []params.HookHistoryResult{{
+			Executions: []params.HookExecution{{
+				Hook:       "db-relation-joined",
+				RelationId: 1,
+				RemoteUnit: "mysql/0",
+				Started:    started,
+				Finished:   started.Add(time.Second),
+				ExitCode:   1,
+				ToolCalls:  []string{"relation-get"},
+			}},
+		}, {
+			Error: &params.Error{
+				Message: `fetching hook history for "unit-unit-1": unit/1 not found`,
+				Code:    params.CodeNotFound,
+			},
+		}, {
+			Error: &params.Error{
+				Message: `fetching hook history for "machine-0": "machine-0" is not a valid unit tag`,
+			},
+		}},
+	})
+}
+
 type mockState struct {
 	client.StateInterface
 	unitHistory  []status.StatusInfo
 	agentHistory []status.StatusInfo
+	hookHistory  []state.HookExecution
 }
 
 func (m *mockState) ModelUUID() string {
@@ -227,15 +269,21 @@ func (m *mockState) Unit(name string) (client.Unit, error) {
 	return &mockUnit{
 		status: m.unitHistory,
 		agent:  &mockUnitAgent{m.agentHistory},
+		hooks:  m.hookHistory,
 	}, nil
 }
 
 type mockUnit struct {
 	status statuses
 	agent  *mockUnitAgent
+	hooks  []state.HookExecution
 	client.Unit
 }
 
+func (m *mockUnit) HookHistory() ([]state.HookExecution, error) {
+	return m.hooks, nil
+}
+
 func (m *mockUnit) StatusHistory(filter status.StatusHistoryFilter) ([]status.StatusInfo, error) {
 	return m.status.StatusHistory(filter)
 }
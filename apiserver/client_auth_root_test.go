@@ -45,9 +45,9 @@ func (s *clientAuthRootSuite) AssertCallErrPerm(c *gc.C, client *clientAuthRoot,
 func (s *clientAuthRootSuite) TestNormalUser(c *gc.C) {
 	modelUser := s.Factory.MakeModelUser(c, nil)
 	client := newClientAuthRoot(&fakeFinder{}, modelUser)
-	s.AssertCallGood(c, client, "Application", 1, "Deploy")
+	s.AssertCallGood(c, client, "Application", 2, "Deploy")
 	s.AssertCallGood(c, client, "UserManager", 1, "UserInfo")
-	s.AssertCallNotImplemented(c, client, "Client", 1, "Unknown")
+	s.AssertCallNotImplemented(c, client, "Client", 2, "Unknown")
 	s.AssertCallNotImplemented(c, client, "Unknown", 1, "Method")
 }
 
@@ -55,12 +55,12 @@ func (s *clientAuthRootSuite) TestReadOnlyUser(c *gc.C) {
 	modelUser := s.Factory.MakeModelUser(c, &factory.ModelUserParams{Access: state.ReadAccess})
 	client := newClientAuthRoot(&fakeFinder{}, modelUser)
 	// deploys are bad
-	s.AssertCallErrPerm(c, client, "Application", 1, "Deploy")
+	s.AssertCallErrPerm(c, client, "Application", 2, "Deploy")
 	// read only commands are fine
-	s.AssertCallGood(c, client, "Client", 1, "FullStatus")
+	s.AssertCallGood(c, client, "Client", 2, "FullStatus")
 	// calls on the restricted root is also fine
 	s.AssertCallGood(c, client, "UserManager", 1, "AddUser")
-	s.AssertCallNotImplemented(c, client, "Client", 1, "Unknown")
+	s.AssertCallNotImplemented(c, client, "Client", 2, "Unknown")
 	s.AssertCallNotImplemented(c, client, "Unknown", 1, "Method")
 }
 
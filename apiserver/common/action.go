@@ -232,6 +232,14 @@ func ConvertActions(ar state.ActionReceiver, fn GetActionsFn) ([]params.ActionRe
 // to params.ActionResult.
 func MakeActionResult(actionReceiverTag names.Tag, action state.Action) params.ActionResult {
 	output, message := action.Results()
+	var attachments []params.ActionAttachment
+	for _, attachment := range action.Attachments() {
+		attachments = append(attachments, params.ActionAttachment{
+			Name:   attachment.Name,
+			Size:   attachment.Size,
+			SHA256: attachment.SHA256,
+		})
+	}
 	return params.ActionResult{
 		Action: &params.Action{
 			Receiver:   actionReceiverTag.String(),
@@ -239,11 +247,12 @@ func MakeActionResult(actionReceiverTag names.Tag, action state.Action) params.A
 			Name:       action.Name(),
 			Parameters: action.Parameters(),
 		},
-		Status:    string(action.Status()),
-		Message:   message,
-		Output:    output,
-		Enqueued:  action.Enqueued(),
-		Started:   action.Started(),
-		Completed: action.Completed(),
+		Status:      string(action.Status()),
+		Message:     message,
+		Output:      output,
+		Attachments: attachments,
+		Enqueued:    action.Enqueued(),
+		Started:     action.Started(),
+		Completed:   action.Completed(),
 	}
 }
@@ -159,7 +159,11 @@ func (cache *addSubnetsCache) validateZones(providerZones, givenZones []string)
 	}
 
 	// Otherwise we need the cache to validate.
-	if err := cache.cacheZones(); err != nil {
+	if err := cache.cacheZones(); errors.IsNotSupported(errors.Cause(err)) {
+		// The model has no availability zones (e.g. lxd or manual),
+		// so there is nothing to validate the given zones against.
+		return givenSet.SortedValues(), nil
+	} else if err != nil {
 		return nil, errors.Trace(err)
 	}
 
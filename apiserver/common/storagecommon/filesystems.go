@@ -101,9 +101,9 @@ func FilesystemFromState(f state.Filesystem) (params.Filesystem, error) {
 		return params.Filesystem{}, errors.Trace(err)
 	}
 	result := params.Filesystem{
-		f.FilesystemTag().String(),
-		"",
-		FilesystemInfoFromState(info),
+		FilesystemTag: f.FilesystemTag().String(),
+		Info:          FilesystemInfoFromState(info),
+		Releasing:     f.Releasing(),
 	}
 	volumeTag, err := f.Volume()
 	if err == nil {
@@ -157,8 +157,19 @@ func FilesystemAttachmentFromState(v state.FilesystemAttachment) (params.Filesys
 // to params.FilesystemAttachmentInfo.
 func FilesystemAttachmentInfoFromState(info state.FilesystemAttachmentInfo) params.FilesystemAttachmentInfo {
 	return params.FilesystemAttachmentInfo{
-		info.MountPoint,
-		info.ReadOnly,
+		MountPoint: info.MountPoint,
+		ReadOnly:   info.ReadOnly,
+	}
+}
+
+// FilesystemUsageFromState converts a state.FilesystemUsage to
+// params.FilesystemUsage.
+func FilesystemUsageFromState(usage state.FilesystemUsage) *params.FilesystemUsage {
+	return &params.FilesystemUsage{
+		UsedBytes:  usage.UsedBytes,
+		FreeBytes:  usage.FreeBytes,
+		UsedInodes: usage.UsedInodes,
+		FreeInodes: usage.FreeInodes,
 	}
 }
 
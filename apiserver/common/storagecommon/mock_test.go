@@ -21,6 +21,7 @@ type fakeStorage struct {
 	storageInstanceVolume  func(names.StorageTag) (state.Volume, error)
 	volumeAttachment       func(names.MachineTag, names.VolumeTag) (state.VolumeAttachment, error)
 	blockDevices           func(names.MachineTag) ([]state.BlockDeviceInfo, error)
+	watchVolume            func(names.VolumeTag) state.NotifyWatcher
 	watchVolumeAttachment  func(names.MachineTag, names.VolumeTag) state.NotifyWatcher
 	watchBlockDevices      func(names.MachineTag) state.NotifyWatcher
 	watchStorageAttachment func(names.StorageTag, names.UnitTag) state.NotifyWatcher
@@ -46,6 +47,11 @@ func (s *fakeStorage) BlockDevices(m names.MachineTag) ([]state.BlockDeviceInfo,
 	return s.blockDevices(m)
 }
 
+func (s *fakeStorage) WatchVolume(v names.VolumeTag) state.NotifyWatcher {
+	s.MethodCall(s, "WatchVolume", v)
+	return s.watchVolume(v)
+}
+
 func (s *fakeStorage) WatchVolumeAttachment(m names.MachineTag, v names.VolumeTag) state.NotifyWatcher {
 	s.MethodCall(s, "WatchVolumeAttachment", m, v)
 	return s.watchVolumeAttachment(m, v)
@@ -76,8 +82,8 @@ func (i *fakeStorageInstance) Tag() names.Tag {
 	return i.tag
 }
 
-func (i *fakeStorageInstance) Owner() names.Tag {
-	return i.owner
+func (i *fakeStorageInstance) Owner() (names.Tag, bool) {
+	return i.owner, i.owner != nil
 }
 
 func (i *fakeStorageInstance) Kind() state.StorageKind {
@@ -50,6 +50,12 @@ type StorageInterface interface {
 	// corresponding to the identfified machine and volume.
 	WatchVolumeAttachment(names.MachineTag, names.VolumeTag) state.NotifyWatcher
 
+	// WatchFilesystem watches for changes to the identified filesystem.
+	WatchFilesystem(names.FilesystemTag) state.NotifyWatcher
+
+	// WatchVolume watches for changes to the identified volume.
+	WatchVolume(names.VolumeTag) state.NotifyWatcher
+
 	// WatchBlockDevices watches for changes to block devices associated
 	// with the specified machine.
 	WatchBlockDevices(names.MachineTag) state.NotifyWatcher
@@ -133,6 +139,7 @@ func volumeStorageAttachmentInfo(
 	return &storage.StorageAttachmentInfo{
 		storage.StorageKindBlock,
 		devicePath,
+		volumeInfo.Size,
 	}, nil
 }
 
@@ -146,6 +153,10 @@ func filesystemStorageAttachmentInfo(
 	if err != nil {
 		return nil, errors.Annotate(err, "getting filesystem")
 	}
+	filesystemInfo, err := filesystem.Info()
+	if err != nil {
+		return nil, errors.Annotate(err, "getting filesystem info")
+	}
 	filesystemAttachment, err := st.FilesystemAttachment(machineTag, filesystem.FilesystemTag())
 	if err != nil {
 		return nil, errors.Annotate(err, "getting filesystem attachment")
@@ -157,6 +168,7 @@ func filesystemStorageAttachmentInfo(
 	return &storage.StorageAttachmentInfo{
 		storage.StorageKindFilesystem,
 		filesystemAttachmentInfo.MountPoint,
+		filesystemInfo.Size,
 	}, nil
 }
 
@@ -180,10 +192,12 @@ func WatchStorageAttachment(
 		if err != nil {
 			return nil, errors.Annotate(err, "getting storage volume")
 		}
-		// We need to watch both the volume attachment, and the
+		// We need to watch the volume attachment, and the
 		// machine's block devices. A volume attachment's block
 		// device could change (most likely, become present).
+		// We also watch the volume, to observe it being resized.
 		watchers = []state.NotifyWatcher{
+			st.WatchVolume(volume.VolumeTag()),
 			st.WatchVolumeAttachment(machineTag, volume.VolumeTag()),
 			// TODO(axw) 2015-09-30 #1501203
 			// We should filter the events to only those relevant
@@ -200,6 +214,7 @@ func WatchStorageAttachment(
 			return nil, errors.Annotate(err, "getting storage filesystem")
 		}
 		watchers = []state.NotifyWatcher{
+			st.WatchFilesystem(filesystem.FilesystemTag()),
 			st.WatchFilesystemAttachment(machineTag, filesystem.FilesystemTag()),
 		}
 	default:
@@ -263,7 +278,9 @@ func storageTags(
 	)
 	if storageInstance != nil {
 		storageTags[tags.JujuStorageInstance] = storageInstance.Tag().Id()
-		storageTags[tags.JujuStorageOwner] = storageInstance.Owner().Id()
+		if owner, ok := storageInstance.Owner(); ok {
+			storageTags[tags.JujuStorageOwner] = owner.Id()
+		}
 	}
 	return storageTags, nil
 }
@@ -66,6 +66,7 @@ func VolumeParams(
 		cfg.Attrs(),
 		volumeTags,
 		nil, // attachment params set by the caller
+		"",  // snapshot ID set by the caller
 	}, nil
 }
 
@@ -129,8 +130,9 @@ func VolumeFromState(v state.Volume) (params.Volume, error) {
 		return params.Volume{}, errors.Trace(err)
 	}
 	return params.Volume{
-		v.VolumeTag().String(),
-		VolumeInfoFromState(info),
+		VolumeTag: v.VolumeTag().String(),
+		Info:      VolumeInfoFromState(info),
+		Releasing: v.Releasing(),
 	}, nil
 }
 
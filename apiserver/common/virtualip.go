@@ -0,0 +1,123 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
+)
+
+// VirtualIPManager returns the model's environ as an
+// environs.VirtualIPManager, or an error satisfying
+// errors.IsNotSupported if the model's provider cannot
+// manage virtual IP addresses.
+func VirtualIPManager(st *state.State, newEnviron environs.NewEnvironFunc) (environs.VirtualIPManager, error) {
+	env, err := environs.GetEnviron(st, newEnviron)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting environ")
+	}
+	manager, ok := env.(environs.VirtualIPManager)
+	if !ok {
+		return nil, errors.NotSupportedf("virtual IP addresses on %q provider", env.Config().Type())
+	}
+	return manager, nil
+}
+
+// MoveVirtualIPAddress releases the application's virtual IP address
+// from the instance hosting the unit that previously held it, assigns
+// it to the instance hosting the named unit, and records the new holder
+// as long as the given leadership token remains valid. Failing to
+// release the address from the old holder, whose instance may be
+// unreachable, is logged but does not prevent the move.
+func MoveVirtualIPAddress(
+	st *state.State,
+	manager environs.VirtualIPManager,
+	application *state.Application,
+	unitName string,
+	token leadership.Token,
+) error {
+	address, subnetCIDR := application.VirtualIPAddress()
+	if address == "" {
+		return errors.NotFoundf("virtual IP address of application %q", application.Name())
+	}
+	instId, err := unitInstanceId(st, unitName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if holder := application.VirtualIPHolder(); holder != "" && holder != unitName {
+		if err := releaseVirtualIPAddress(st, manager, holder, address, subnetCIDR); err != nil {
+			logger.Warningf("cannot release virtual IP address %q from unit %q: %v", address, holder, err)
+		}
+	}
+	if err := manager.AssignVirtualIPAddress(instId, address, subnetCIDR); err != nil {
+		return errors.Trace(err)
+	}
+	return application.SetVirtualIPHolder(token, unitName, instId)
+}
+
+// ReleaseVirtualIPAddress releases the application's virtual IP address
+// from the instance hosting the unit that holds it, and removes it from
+// the application.
+func ReleaseVirtualIPAddress(
+	st *state.State,
+	manager environs.VirtualIPManager,
+	application *state.Application,
+) error {
+	address, subnetCIDR := application.VirtualIPAddress()
+	if address == "" {
+		return nil
+	}
+	if holder := application.VirtualIPHolder(); holder != "" {
+		if err := releaseVirtualIPAddress(st, manager, holder, address, subnetCIDR); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return application.ReleaseVirtualIPAddress()
+}
+
+// releaseVirtualIPAddress releases the given virtual IP address from
+// the instance hosting the named unit. Units and instances that no
+// longer exist do not hold the address, so are ignored.
+func releaseVirtualIPAddress(
+	st *state.State,
+	manager environs.VirtualIPManager,
+	unitName, address, subnetCIDR string,
+) error {
+	instId, err := unitInstanceId(st, unitName)
+	if errors.IsNotFound(err) || errors.IsNotAssigned(err) || errors.IsNotProvisioned(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	err = manager.ReleaseVirtualIPAddress(instId, address, subnetCIDR)
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// unitInstanceId returns the ID of the instance hosting the named unit.
+func unitInstanceId(st *state.State, unitName string) (instance.Id, error) {
+	unit, err := st.Unit(unitName)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	machineId, err := unit.AssignedMachineId()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	machine, err := st.Machine(machineId)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	instId, err := machine.InstanceId()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return instId, nil
+}
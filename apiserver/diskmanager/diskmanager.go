@@ -4,6 +4,7 @@
 package diskmanager
 
 import (
+	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
@@ -89,6 +90,91 @@ func (d *DiskManagerAPI) SetMachineBlockDevices(args params.SetMachineBlockDevic
 	return result, nil
 }
 
+// MachineFilesystemAttachments returns the alive, mounted filesystem
+// attachments of each of the specified machines.
+func (d *DiskManagerAPI) MachineFilesystemAttachments(args params.Entities) (params.FilesystemAttachmentsResults, error) {
+	result := params.FilesystemAttachmentsResults{
+		Results: make([]params.FilesystemAttachmentsResult, len(args.Entities)),
+	}
+	canAccess, err := d.getAuthFunc()
+	if err != nil {
+		return result, err
+	}
+	one := func(arg params.Entity) ([]params.FilesystemAttachment, error) {
+		tag, err := names.ParseMachineTag(arg.Tag)
+		if err != nil || !canAccess(tag) {
+			return nil, common.ErrPerm
+		}
+		attachments, err := d.st.MachineFilesystemAttachments(tag)
+		if err != nil {
+			return nil, err
+		}
+		var results []params.FilesystemAttachment
+		for _, attachment := range attachments {
+			if attachment.Life() != state.Alive {
+				continue
+			}
+			info, err := attachment.Info()
+			if errors.IsNotProvisioned(err) {
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+			if info.MountPoint == "" {
+				// The filesystem is not mounted yet.
+				continue
+			}
+			results = append(results, params.FilesystemAttachment{
+				FilesystemTag: attachment.Filesystem().String(),
+				MachineTag:    attachment.Machine().String(),
+				Info: params.FilesystemAttachmentInfo{
+					MountPoint: info.MountPoint,
+					ReadOnly:   info.ReadOnly,
+				},
+			})
+		}
+		return results, nil
+	}
+	for i, arg := range args.Entities {
+		attachments, err := one(arg)
+		result.Results[i].Result = attachments
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// SetFilesystemAttachmentUsage records the space and inode usage of
+// each of the specified filesystem attachments.
+func (d *DiskManagerAPI) SetFilesystemAttachmentUsage(args params.SetFilesystemAttachmentUsage) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Usage)),
+	}
+	canAccess, err := d.getAuthFunc()
+	if err != nil {
+		return result, err
+	}
+	one := func(arg params.FilesystemAttachmentUsage) error {
+		machineTag, err := names.ParseMachineTag(arg.MachineTag)
+		if err != nil || !canAccess(machineTag) {
+			return common.ErrPerm
+		}
+		filesystemTag, err := names.ParseFilesystemTag(arg.FilesystemTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return d.st.SetFilesystemAttachmentUsage(machineTag, filesystemTag, state.FilesystemUsage{
+			UsedBytes:  arg.Usage.UsedBytes,
+			FreeBytes:  arg.Usage.FreeBytes,
+			UsedInodes: arg.Usage.UsedInodes,
+			FreeInodes: arg.Usage.FreeInodes,
+		})
+	}
+	for i, arg := range args.Usage {
+		result.Results[i].Error = common.ServerError(one(arg))
+	}
+	return result, nil
+}
+
 func stateBlockDeviceInfo(devices []storage.BlockDevice) []state.BlockDeviceInfo {
 	result := make([]state.BlockDeviceInfo, len(devices))
 	for i, dev := range devices {
@@ -6,6 +6,7 @@ package diskmanager_test
 import (
 	"errors"
 
+	jujuerrors "github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -107,10 +108,99 @@ func (s *DiskManagerSuite) TestSetMachineBlockDevicesStateError(c *gc.C) {
 	})
 }
 
+func (s *DiskManagerSuite) TestMachineFilesystemAttachments(c *gc.C) {
+	s.st.attachments = []state.FilesystemAttachment{
+		&mockFilesystemAttachment{
+			filesystem: names.NewFilesystemTag("0"),
+			machine:    names.NewMachineTag("0"),
+			life:       state.Alive,
+			info:       &state.FilesystemAttachmentInfo{MountPoint: "/srv", ReadOnly: true},
+		},
+		&mockFilesystemAttachment{
+			// Not provisioned.
+			filesystem: names.NewFilesystemTag("1"),
+			machine:    names.NewMachineTag("0"),
+			life:       state.Alive,
+		},
+		&mockFilesystemAttachment{
+			// Not mounted.
+			filesystem: names.NewFilesystemTag("2"),
+			machine:    names.NewMachineTag("0"),
+			life:       state.Alive,
+			info:       &state.FilesystemAttachmentInfo{},
+		},
+		&mockFilesystemAttachment{
+			filesystem: names.NewFilesystemTag("3"),
+			machine:    names.NewMachineTag("0"),
+			life:       state.Dying,
+			info:       &state.FilesystemAttachmentInfo{MountPoint: "/var"},
+		},
+	}
+	results, err := s.api.MachineFilesystemAttachments(params.Entities{
+		Entities: []params.Entity{{"machine-0"}, {"machine-1"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.FilesystemAttachmentsResults{
+		Results: []params.FilesystemAttachmentsResult{{
+			Result: []params.FilesystemAttachment{{
+				FilesystemTag: "filesystem-0",
+				MachineTag:    "machine-0",
+				Info: params.FilesystemAttachmentInfo{
+					MountPoint: "/srv",
+					ReadOnly:   true,
+				},
+			}},
+		}, {
+			Error: &params.Error{Message: "permission denied", Code: "unauthorized access"},
+		}},
+	})
+}
+
+func (s *DiskManagerSuite) TestSetFilesystemAttachmentUsage(c *gc.C) {
+	results, err := s.api.SetFilesystemAttachmentUsage(params.SetFilesystemAttachmentUsage{
+		Usage: []params.FilesystemAttachmentUsage{{
+			FilesystemTag: "filesystem-0",
+			MachineTag:    "machine-0",
+			Usage: params.FilesystemUsage{
+				UsedBytes:  1,
+				FreeBytes:  2,
+				UsedInodes: 3,
+				FreeInodes: 4,
+			},
+		}, {
+			FilesystemTag: "filesystem-0",
+			MachineTag:    "machine-1",
+		}, {
+			FilesystemTag: "volume-0",
+			MachineTag:    "machine-0",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{
+			Error: nil,
+		}, {
+			Error: &params.Error{Message: "permission denied", Code: "unauthorized access"},
+		}, {
+			Error: &params.Error{Message: `"volume-0" is not a valid filesystem tag`},
+		}},
+	})
+	c.Assert(s.st.usage, jc.DeepEquals, map[names.FilesystemTag]state.FilesystemUsage{
+		names.NewFilesystemTag("0"): {
+			UsedBytes:  1,
+			FreeBytes:  2,
+			UsedInodes: 3,
+			FreeInodes: 4,
+		},
+	})
+}
+
 type mockState struct {
-	calls   int
-	devices map[string][]state.BlockDeviceInfo
-	err     error
+	calls       int
+	devices     map[string][]state.BlockDeviceInfo
+	attachments []state.FilesystemAttachment
+	usage       map[names.FilesystemTag]state.FilesystemUsage
+	err         error
 }
 
 func (st *mockState) SetMachineBlockDevices(machineId string, devices []state.BlockDeviceInfo) error {
@@ -121,3 +211,42 @@ func (st *mockState) SetMachineBlockDevices(machineId string, devices []state.Bl
 	st.devices[machineId] = devices
 	return st.err
 }
+
+func (st *mockState) MachineFilesystemAttachments(machine names.MachineTag) ([]state.FilesystemAttachment, error) {
+	return st.attachments, st.err
+}
+
+func (st *mockState) SetFilesystemAttachmentUsage(machine names.MachineTag, filesystem names.FilesystemTag, usage state.FilesystemUsage) error {
+	if st.usage == nil {
+		st.usage = make(map[names.FilesystemTag]state.FilesystemUsage)
+	}
+	st.usage[filesystem] = usage
+	return st.err
+}
+
+type mockFilesystemAttachment struct {
+	state.FilesystemAttachment
+	filesystem names.FilesystemTag
+	machine    names.MachineTag
+	life       state.Life
+	info       *state.FilesystemAttachmentInfo
+}
+
+func (a *mockFilesystemAttachment) Filesystem() names.FilesystemTag {
+	return a.filesystem
+}
+
+func (a *mockFilesystemAttachment) Machine() names.MachineTag {
+	return a.machine
+}
+
+func (a *mockFilesystemAttachment) Life() state.Life {
+	return a.life
+}
+
+func (a *mockFilesystemAttachment) Info() (state.FilesystemAttachmentInfo, error) {
+	if a.info == nil {
+		return state.FilesystemAttachmentInfo{}, jujuerrors.NotProvisionedf("filesystem attachment")
+	}
+	return *a.info, nil
+}
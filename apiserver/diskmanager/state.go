@@ -3,10 +3,16 @@
 
 package diskmanager
 
-import "github.com/juju/juju/state"
+import (
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+)
 
 type stateInterface interface {
 	SetMachineBlockDevices(machineId string, devices []state.BlockDeviceInfo) error
+	MachineFilesystemAttachments(machine names.MachineTag) ([]state.FilesystemAttachment, error)
+	SetFilesystemAttachmentUsage(machine names.MachineTag, filesystem names.FilesystemTag, usage state.FilesystemUsage) error
 }
 
 type stateShim struct {
@@ -5,6 +5,7 @@ package firewaller
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
@@ -16,7 +17,7 @@ import (
 
 func init() {
 	// Version 0 is no longer supported.
-	common.RegisterStandardFacade("Firewaller", 3, NewFirewallerAPI)
+	common.RegisterStandardFacade("Firewaller", 4, NewFirewallerAPI)
 }
 
 // FirewallerAPI provides access to the Firewaller API facade.
@@ -152,6 +153,21 @@ func (f *FirewallerAPI) watchOneEnvironOpenedPorts(tag names.Tag) (string, []str
 	return "", nil, watcher.EnsureErr(watch)
 }
 
+// WatchSubnets returns a NotifyWatcher that notifies of changes to the
+// model's subnets and spaces, which may change the source address
+// ranges to which applications are exposed.
+func (f *FirewallerAPI) WatchSubnets() (params.NotifyWatchResult, error) {
+	result := params.NotifyWatchResult{}
+	watch := f.st.WatchSubnets()
+	// Consume the initial event.
+	if _, ok := <-watch.Changes(); ok {
+		result.NotifyWatcherId = f.resources.Register(watch)
+	} else {
+		return result, watcher.EnsureErr(watch)
+	}
+	return result, nil
+}
+
 // GetMachinePorts returns the port ranges opened on a machine for the specified
 // subnet as a map mapping port ranges to the tags of the units that opened
 // them.
@@ -279,6 +295,148 @@ func (f *FirewallerAPI) GetExposed(args params.Entities) (params.BoolResults, er
 	return result, nil
 }
 
+// GetExposedSourceCIDRs returns, for each given application, the source
+// address ranges, in CIDR notation, to which the application is exposed.
+// Exposed spaces are resolved to the CIDRs of their subnets.
+func (f *FirewallerAPI) GetExposedSourceCIDRs(args params.Entities) (params.StringsResults, error) {
+	result := params.StringsResults{
+		Results: make([]params.StringsResult, len(args.Entities)),
+	}
+	canAccess, err := f.accessService()
+	if err != nil {
+		return params.StringsResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		service, err := f.getService(canAccess, tag)
+		if err == nil {
+			result.Results[i].Result, err = f.exposedSourceCIDRs(service)
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// GetRelationIngressCIDRs returns, for each given application, the
+// address ranges, in CIDR notation, of the units of remote applications
+// related to it.
+func (f *FirewallerAPI) GetRelationIngressCIDRs(args params.Entities) (params.StringsResults, error) {
+	result := params.StringsResults{
+		Results: make([]params.StringsResult, len(args.Entities)),
+	}
+	canAccess, err := f.accessService()
+	if err != nil {
+		return params.StringsResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		service, err := f.getService(canAccess, tag)
+		if err == nil {
+			result.Results[i].Result = service.RelationIngressCIDRs()
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// exposedSourceCIDRs returns the source address ranges to which the
+// given application is exposed. If the application's exposure is not
+// restricted, network.OpenToAllCIDR is returned.
+func (f *FirewallerAPI) exposedSourceCIDRs(service *state.Application) ([]string, error) {
+	cidrs := service.ExposedCIDRs()
+	spaces := service.ExposedSpaces()
+	if len(cidrs) == 0 && len(spaces) == 0 {
+		return []string{network.OpenToAllCIDR}, nil
+	}
+	sources := set.NewStrings(cidrs...)
+	for _, name := range spaces {
+		space, err := f.st.Space(name)
+		if errors.IsNotFound(err) {
+			// The space has been removed since the
+			// application was exposed to it.
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		subnets, err := space.Subnets()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, subnet := range subnets {
+			sources.Add(subnet.CIDR())
+		}
+	}
+	return sources.SortedValues(), nil
+}
+
+// GetEgressRules returns, for each given application, the egress rules
+// defining the egress traffic permitted from machines hosting the
+// application's units.
+func (f *FirewallerAPI) GetEgressRules(args params.Entities) (params.EgressRulesResults, error) {
+	result := params.EgressRulesResults{
+		Results: make([]params.EgressRulesResult, len(args.Entities)),
+	}
+	canAccess, err := f.accessService()
+	if err != nil {
+		return params.EgressRulesResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		service, err := f.getService(canAccess, tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		rules := service.EgressRules()
+		result.Results[i].Rules = make([]params.EgressRule, len(rules))
+		for j, rule := range rules {
+			result.Results[i].Rules[j] = params.FromNetworkEgressRule(rule)
+		}
+	}
+	return result, nil
+}
+
+// SetFirewallDrift records, for each given machine, how the ingress
+// rules applied to its instance differ from those expected. An empty
+// drift clears any previously recorded drift.
+func (f *FirewallerAPI) SetFirewallDrift(args params.MachinesFirewallDrift) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Machines)),
+	}
+	canAccess, err := f.accessMachine()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Machines {
+		tag, err := names.ParseMachineTag(arg.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		machine, err := f.getMachine(canAccess, tag)
+		if err == nil {
+			err = machine.SetFirewallDrift(state.FirewallDrift{
+				Missing:    arg.Drift.Missing,
+				Unexpected: arg.Drift.Unexpected,
+			})
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
 // GetAssignedMachine returns the assigned machine tag (if any) for
 // each given unit.
 func (f *FirewallerAPI) GetAssignedMachine(args params.Entities) (params.StringResults, error) {
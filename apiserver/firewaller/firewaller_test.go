@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/apiserver/firewaller"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
 )
@@ -77,6 +78,125 @@ func (s *firewallerSuite) TestGetExposed(c *gc.C) {
 	s.testGetExposed(c, s.firewaller)
 }
 
+func (s *firewallerSuite) TestGetExposedSourceCIDRs(c *gc.C) {
+	// The subnet is added by SetUpTest.
+	_, err := s.State.AddSpace("internal", "", []string{"10.20.30.0/24"}, false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.service.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	args := addFakeEntities(params.Entities{Entities: []params.Entity{
+		{Tag: s.service.Tag().String()},
+	}})
+	result, err := s.firewaller.GetExposedSourceCIDRs(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringsResults{
+		Results: []params.StringsResult{
+			{Result: []string{"0.0.0.0/0"}},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.NotFoundError(`application "bar"`)},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	err = s.service.SetExposedTo([]string{"192.168.1.0/24"}, []string{"internal"})
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = s.firewaller.GetExposedSourceCIDRs(params.Entities{Entities: []params.Entity{
+		{Tag: s.service.Tag().String()},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringsResults{
+		Results: []params.StringsResult{
+			{Result: []string{"10.20.30.0/24", "192.168.1.0/24"}},
+		},
+	})
+}
+
+func (s *firewallerSuite) TestGetEgressRules(c *gc.C) {
+	err := s.service.SetEgressRules([]network.EgressRule{
+		network.NewEgressRule(network.PortRange{443, 443, "tcp"}, "10.0.0.0/8"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	args := addFakeEntities(params.Entities{Entities: []params.Entity{
+		{Tag: s.service.Tag().String()},
+	}})
+	result, err := s.firewaller.GetEgressRules(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.EgressRulesResults{
+		Results: []params.EgressRulesResult{
+			{Rules: []params.EgressRule{{
+				PortRange:        params.PortRange{443, 443, "tcp"},
+				DestinationCIDRs: []string{"10.0.0.0/8"},
+			}}},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.NotFoundError(`application "bar"`)},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *firewallerSuite) TestSetFirewallDrift(c *gc.C) {
+	drift := params.FirewallDrift{
+		Missing:    []string{"80/tcp"},
+		Unexpected: []string{"22/tcp from 10.0.0.0/8"},
+	}
+	args := params.MachinesFirewallDrift{Machines: []params.MachineFirewallDrift{
+		{Tag: s.machines[0].Tag().String(), Drift: drift},
+		{Tag: s.machines[1].Tag().String()},
+		{Tag: "machine-42", Drift: drift},
+		{Tag: "unit-foo-0", Drift: drift},
+		{Tag: "foo-bar", Drift: drift},
+	}}
+	result, err := s.firewaller.SetFirewallDrift(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{Error: nil},
+			{Error: nil},
+			{Error: apiservertesting.NotFoundError("machine 42")},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	err = s.machines[0].Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.machines[0].FirewallDrift(), jc.DeepEquals, state.FirewallDrift{
+		Missing:    []string{"80/tcp"},
+		Unexpected: []string{"22/tcp from 10.0.0.0/8"},
+	})
+	err = s.machines[1].Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.machines[1].FirewallDrift().IsEmpty(), jc.IsTrue)
+}
+
+func (s *firewallerSuite) TestGetRelationIngressCIDRs(c *gc.C) {
+	err := s.service.SetRelationIngressCIDR("remote-wordpress/0", "8.8.8.8/32")
+	c.Assert(err, jc.ErrorIsNil)
+	args := addFakeEntities(params.Entities{Entities: []params.Entity{
+		{Tag: s.service.Tag().String()},
+	}})
+	result, err := s.firewaller.GetRelationIngressCIDRs(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringsResults{
+		Results: []params.StringsResult{
+			{Result: []string{"8.8.8.8/32"}},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.NotFoundError(`application "bar"`)},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
 func (s *firewallerSuite) TestGetAssignedMachine(c *gc.C) {
 	s.testGetAssignedMachine(c, s.firewaller)
 }
@@ -138,6 +258,28 @@ func (s *firewallerSuite) TestWatchOpenedPorts(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *firewallerSuite) TestWatchSubnets(c *gc.C) {
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	result, err := s.firewaller.WatchSubnets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.NotifyWatchResult{NotifyWatcherId: "1"})
+
+	// Verify the resource was registered and stop when done
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+
+	// Check that the Watch has consumed the initial event ("returned" in
+	// the Watch call)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, resource.(state.NotifyWatcher))
+	wc.AssertNoChange()
+
+	_, err = s.State.AddSubnet(state.SubnetInfo{CIDR: "10.20.31.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
 func (s *firewallerSuite) TestGetMachinePorts(c *gc.C) {
 	s.openPorts(c)
 
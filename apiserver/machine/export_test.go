@@ -0,0 +1,10 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine
+
+var (
+	AddObservedSubnets         = addObservedSubnets
+	AddObservedSubnetsIfNeeded = (*MachinerAPI).addObservedSubnetsIfNeeded
+	ObservedSubnetProviders    = &observedSubnetProviders
+)
@@ -6,19 +6,41 @@
 package machine
 
 import (
+	"net"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/common/networkingcommon"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/multiwatcher"
 )
 
 var logger = loggo.GetLogger("juju.apiserver.machine")
 
+// defaultSpaceName is the space observed subnets are added to when
+// the provider cannot discover subnets itself.
+const defaultSpaceName = "default"
+
+// hostLocalBridges holds the names of the bridges created on a machine
+// by LXD, LXC, libvirt and docker for the containers it hosts. Their
+// subnets are private to the machine, so they are never recorded as
+// observed subnets.
+var hostLocalBridges = set.NewStrings("lxdbr0", "lxcbr0", "virbr0", "docker0")
+
+// observedSubnetProviders holds the types of the providers which cannot
+// report the subnets of the machines they start, so the subnets observed
+// by the machine agents are recorded instead. "null" is an alias for the
+// manual provider. The LXD provider only knows the CIDRs of its bridges
+// when talking to LXD over the local unix socket, while the controller
+// always talks to it over the TCP remote.
+var observedSubnetProviders = set.NewStrings("manual", "null", "lxd")
+
 func init() {
 	common.RegisterStandardFacade("Machiner", 1, NewMachinerAPI)
 }
@@ -161,8 +183,12 @@ func (api *MachinerAPI) SetObservedNetworkConfig(args params.SetMachineNetworkCo
 		return errors.Trace(err)
 	}
 	if len(providerConfig) == 0 {
-		logger.Infof("not updating machine network config: no provider network config found")
-		return nil
+		logger.Infof("no provider network config found, using observed network config only")
+		if err := api.addObservedSubnetsIfNeeded(observedConfig); err != nil {
+			return errors.Trace(err)
+		}
+		sortedConfig := networkingcommon.SortNetworkConfigsByParents(observedConfig)
+		return api.setOneMachineNetworkConfig(m, sortedConfig)
 	}
 
 	mergedConfig, err := networkingcommon.MergeProviderAndObservedNetworkConfigs(providerConfig, observedConfig)
@@ -174,6 +200,71 @@ func (api *MachinerAPI) SetObservedNetworkConfig(args params.SetMachineNetworkCo
 	return api.setOneMachineNetworkConfig(m, mergedConfig)
 }
 
+// addObservedSubnetsIfNeeded records the subnets of the observed
+// network config in state on manual and LXD models, whose provider
+// cannot report them itself, so that spaces can be used on such models.
+func (api *MachinerAPI) addObservedSubnetsIfNeeded(observedConfig []params.NetworkConfig) error {
+	cfg, err := api.st.ModelConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !observedSubnetProviders.Contains(cfg.Type()) {
+		return nil
+	}
+	return addObservedSubnets(api.st, observedConfig)
+}
+
+// addObservedSubnets adds each subnet of the observed network config
+// not already known to state, in the default space. Loopback,
+// link-local and host-local bridge subnets are skipped.
+func addObservedSubnets(st *state.State, observedConfig []params.NetworkConfig) error {
+	var cidrs []string
+	seen := make(set.Strings)
+	for _, config := range observedConfig {
+		if config.CIDR == "" || config.InterfaceType == string(network.LoopbackInterface) {
+			continue
+		}
+		if hostLocalBridges.Contains(config.InterfaceName) {
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(config.CIDR)
+		if err != nil {
+			logger.Warningf("ignoring invalid observed CIDR %q: %v", config.CIDR, err)
+			continue
+		}
+		if ipNet.IP.IsLoopback() || ipNet.IP.IsLinkLocalUnicast() {
+			continue
+		}
+		cidr := ipNet.String()
+		if seen.Contains(cidr) {
+			continue
+		}
+		seen.Add(cidr)
+		cidrs = append(cidrs, cidr)
+	}
+	if len(cidrs) == 0 {
+		return nil
+	}
+
+	_, err := st.AddSpace(defaultSpaceName, "", nil, false)
+	if err != nil && !errors.IsAlreadyExists(err) {
+		return errors.Trace(err)
+	}
+	for _, cidr := range cidrs {
+		_, err := st.AddSubnet(state.SubnetInfo{
+			CIDR:      cidr,
+			SpaceName: defaultSpaceName,
+		})
+		if errors.IsAlreadyExists(err) {
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		logger.Infof("added observed subnet %q to space %q", cidr, defaultSpaceName)
+	}
+	return nil
+}
+
 func (api *MachinerAPI) getMachineForSettingNetworkConfig(machineTag string) (*state.Machine, error) {
 	canModify, err := api.getCanModify()
 	if err != nil {
@@ -6,7 +6,9 @@ package machine_test
 import (
 	"time"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/set"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
@@ -333,6 +335,117 @@ func (s *machinerSuite) TestSetObservedNetworkConfigPermissions(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "permission denied")
 }
 
+func (s *machinerSuite) TestAddObservedSubnets(c *gc.C) {
+	_, err := s.State.AddSubnet(state.SubnetInfo{CIDR: "10.20.0.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	observedConfig := []params.NetworkConfig{{
+		InterfaceName: "lo",
+		InterfaceType: "loopback",
+		CIDR:          "127.0.0.0/8",
+		Address:       "127.0.0.1",
+	}, {
+		InterfaceName: "eth0",
+		InterfaceType: "ethernet",
+		CIDR:          "10.10.0.0/24",
+		Address:       "10.10.0.2",
+	}, {
+		InterfaceName: "eth0",
+		InterfaceType: "ethernet",
+		CIDR:          "fe80::/64",
+		Address:       "fe80::1",
+	}, {
+		InterfaceName: "eth1",
+		InterfaceType: "ethernet",
+		CIDR:          "10.20.0.0/24",
+		Address:       "10.20.0.2",
+	}, {
+		InterfaceName: "br-eth1",
+		InterfaceType: "bridge",
+		CIDR:          "10.20.0.0/24",
+		Address:       "10.20.0.3",
+	}, {
+		InterfaceName: "lxdbr0",
+		InterfaceType: "bridge",
+		CIDR:          "10.157.22.0/24",
+		Address:       "10.157.22.1",
+	}, {
+		InterfaceName: "docker0",
+		InterfaceType: "bridge",
+		CIDR:          "172.17.0.0/16",
+		Address:       "172.17.0.1",
+	}}
+	err = machine.AddObservedSubnets(s.State, observedConfig)
+	c.Assert(err, jc.ErrorIsNil)
+
+	space, err := s.State.Space("default")
+	c.Assert(err, jc.ErrorIsNil)
+	subnets, err := space.Subnets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(subnets, gc.HasLen, 1)
+	c.Check(subnets[0].CIDR(), gc.Equals, "10.10.0.0/24")
+
+	// Already known subnets are left alone.
+	subnet, err := s.State.Subnet("10.20.0.0/24")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(subnet.SpaceName(), gc.Equals, "")
+
+	// Adding the same config again is a no-op.
+	err = machine.AddObservedSubnets(s.State, observedConfig)
+	c.Assert(err, jc.ErrorIsNil)
+	all, err := s.State.AllSubnets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(all, gc.HasLen, 2)
+}
+
+func (s *machinerSuite) TestAddObservedSubnetsIfNeededNotManual(c *gc.C) {
+	observedConfig := []params.NetworkConfig{{
+		InterfaceName: "eth0",
+		InterfaceType: "ethernet",
+		CIDR:          "10.10.0.0/24",
+		Address:       "10.10.0.2",
+	}}
+	err := machine.AddObservedSubnetsIfNeeded(s.machiner, observedConfig)
+	c.Assert(err, jc.ErrorIsNil)
+
+	all, err := s.State.AllSubnets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(all, gc.HasLen, 0)
+	_, err = s.State.Space("default")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *machinerSuite) TestAddObservedSubnetsIfNeededObservedProvider(c *gc.C) {
+	// The dummy provider stands in for one which cannot report the
+	// subnets of its machines, like an LXD controller talking to LXD
+	// over its TCP remote.
+	s.PatchValue(machine.ObservedSubnetProviders, set.NewStrings("dummy"))
+	observedConfig := []params.NetworkConfig{{
+		InterfaceName: "eth0",
+		InterfaceType: "ethernet",
+		CIDR:          "10.10.0.0/24",
+		Address:       "10.10.0.2",
+	}, {
+		InterfaceName: "lxdbr0",
+		InterfaceType: "bridge",
+		CIDR:          "10.0.8.0/24",
+		Address:       "10.0.8.1",
+	}}
+	err := machine.AddObservedSubnetsIfNeeded(s.machiner, observedConfig)
+	c.Assert(err, jc.ErrorIsNil)
+
+	all, err := s.State.AllSubnets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 1)
+	c.Check(all[0].CIDR(), gc.Equals, "10.10.0.0/24")
+}
+
+func (s *machinerSuite) TestObservedSubnetProviders(c *gc.C) {
+	c.Check((*machine.ObservedSubnetProviders).SortedValues(), jc.DeepEquals, []string{
+		"lxd", "manual", "null",
+	})
+}
+
 func (s *machinerSuite) TestSetProviderNetworkConfig(c *gc.C) {
 	c.Skip("dimitern: Test disabled until dummy provider is fixed properly")
 	devices, err := s.machine1.AllLinkLayerDevices()
@@ -74,13 +74,20 @@ func (mm *MachineManagerAPI) addOneMachine(p params.AddMachineParams) (*state.Ma
 	if p.ContainerType != "" && p.Placement != nil {
 		return nil, fmt.Errorf("container type and placement are mutually exclusive")
 	}
+	var staticIPAddress string
 	if p.Placement != nil {
-		// Extract container type and parent from container placement directives.
+		// Extract container type, parent and any static IP address
+		// from container placement directives.
 		containerType, err := instance.ParseContainerType(p.Placement.Scope)
 		if err == nil {
+			parentId, address, err := instance.ParseContainerDirective(p.Placement.Directive)
+			if err != nil {
+				return nil, errors.Annotatef(err, "invalid container placement %q", p.Placement)
+			}
 			p.ContainerType = containerType
-			p.ParentId = p.Placement.Directive
+			p.ParentId = parentId
 			p.Placement = nil
+			staticIPAddress = address
 		}
 	}
 
@@ -152,8 +159,10 @@ func (mm *MachineManagerAPI) addOneMachine(p params.AddMachineParams) (*state.Ma
 	if p.ContainerType == "" {
 		return mm.st.AddOneMachine(template)
 	}
+	parentTemplate := template
+	template.StaticIPAddress = staticIPAddress
 	if p.ParentId != "" {
 		return mm.st.AddMachineInsideMachine(template, p.ParentId, p.ContainerType)
 	}
-	return mm.st.AddMachineInsideNewMachine(template, template, p.ContainerType)
+	return mm.st.AddMachineInsideNewMachine(template, parentTemplate, p.ContainerType)
 }
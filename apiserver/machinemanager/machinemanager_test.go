@@ -118,10 +118,47 @@ func (s *MachineManagerSuite) TestAddMachinesStateError(c *gc.C) {
 	c.Assert(s.st.calls, gc.Equals, 1)
 }
 
+func (s *MachineManagerSuite) TestAddContainersWithStaticIPAddress(c *gc.C) {
+	results, err := s.api.AddMachines(params.AddMachines{
+		MachineParams: []params.AddMachineParams{{
+			Series:    "trusty",
+			Jobs:      []multiwatcher.MachineJob{multiwatcher.JobHostUnits},
+			Placement: instance.MustParsePlacement("lxd:0@10.0.0.5"),
+		}, {
+			Series:    "trusty",
+			Jobs:      []multiwatcher.MachineJob{multiwatcher.JobHostUnits},
+			Placement: instance.MustParsePlacement("kvm:@10.0.0.6"),
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Machines, gc.HasLen, 2)
+	for _, result := range results.Machines {
+		c.Check(result.Error, gc.IsNil)
+	}
+	c.Assert(s.st.calls, gc.Equals, 2)
+	c.Assert(s.st.parentIds, jc.DeepEquals, []string{"0", ""})
+	c.Assert(s.st.machines, jc.DeepEquals, []state.MachineTemplate{{
+		Series:          "trusty",
+		Jobs:            []state.MachineJob{state.JobHostUnits},
+		Volumes:         []state.MachineVolumeParams{},
+		StaticIPAddress: "10.0.0.5",
+	}, {
+		Series:          "trusty",
+		Jobs:            []state.MachineJob{state.JobHostUnits},
+		Volumes:         []state.MachineVolumeParams{},
+		StaticIPAddress: "10.0.0.6",
+	}})
+	// The new host machine does not get the static IP address.
+	c.Assert(s.st.parentTemplates, gc.HasLen, 1)
+	c.Assert(s.st.parentTemplates[0].StaticIPAddress, gc.Equals, "")
+}
+
 type mockState struct {
-	calls    int
-	machines []state.MachineTemplate
-	err      error
+	calls           int
+	machines        []state.MachineTemplate
+	parentIds       []string
+	parentTemplates []state.MachineTemplate
+	err             error
 }
 
 func (st *mockState) AddOneMachine(template state.MachineTemplate) (*state.Machine, error) {
@@ -144,11 +181,18 @@ func (st *mockState) Model() (*state.Model, error) {
 }
 
 func (st *mockState) AddMachineInsideNewMachine(template, parentTemplate state.MachineTemplate, containerType instance.ContainerType) (*state.Machine, error) {
-	panic("not implemented")
+	st.calls++
+	st.machines = append(st.machines, template)
+	st.parentIds = append(st.parentIds, "")
+	st.parentTemplates = append(st.parentTemplates, parentTemplate)
+	return &state.Machine{}, st.err
 }
 
 func (st *mockState) AddMachineInsideMachine(template state.MachineTemplate, parentId string, containerType instance.ContainerType) (*state.Machine, error) {
-	panic("not implemented")
+	st.calls++
+	st.machines = append(st.machines, template)
+	st.parentIds = append(st.parentIds, parentId)
+	return &state.Machine{}, st.err
 }
 
 type mockBlock struct {
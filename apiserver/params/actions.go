@@ -52,14 +52,23 @@ type ActionResults struct {
 
 // ActionResult describes an Action that will be or has been completed.
 type ActionResult struct {
-	Action    *Action                `json:"action,omitempty"`
-	Enqueued  time.Time              `json:"enqueued,omitempty"`
-	Started   time.Time              `json:"started,omitempty"`
-	Completed time.Time              `json:"completed,omitempty"`
-	Status    string                 `json:"status,omitempty"`
-	Message   string                 `json:"message,omitempty"`
-	Output    map[string]interface{} `json:"output,omitempty"`
-	Error     *Error                 `json:"error,omitempty"`
+	Action      *Action                `json:"action,omitempty"`
+	Enqueued    time.Time              `json:"enqueued,omitempty"`
+	Started     time.Time              `json:"started,omitempty"`
+	Completed   time.Time              `json:"completed,omitempty"`
+	Status      string                 `json:"status,omitempty"`
+	Message     string                 `json:"message,omitempty"`
+	Output      map[string]interface{} `json:"output,omitempty"`
+	Attachments []ActionAttachment     `json:"attachments,omitempty"`
+	Error       *Error                 `json:"error,omitempty"`
+}
+
+// ActionAttachment describes a file attached to the results of an
+// Action.
+type ActionAttachment struct {
+	Name   string `json:"name"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
 }
 
 // ActionsByReceivers wrap a slice of Actions for API calls.
@@ -0,0 +1,99 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// ApplicationOffer holds the details of an application offered to
+// other models on the same controller.
+type ApplicationOffer struct {
+	OfferName       string `json:"offer-name"`
+	ApplicationName string `json:"application-name"`
+
+	// Endpoints maps the names of the offered endpoints
+	// to the names of the application's endpoints.
+	Endpoints map[string]string `json:"endpoints"`
+}
+
+// ApplicationOffers holds the parameters for the Offer call.
+type ApplicationOffers struct {
+	Offers []ApplicationOffer `json:"offers"`
+}
+
+// RemoveApplicationOffers holds the parameters for the RemoveOffers
+// call.
+type RemoveApplicationOffers struct {
+	OfferNames []string `json:"offer-names"`
+}
+
+// RemoteApplication holds the details of an application in another
+// model to which applications in the model are related.
+type RemoteApplication struct {
+	Name                  string `json:"name"`
+	SourceModelTag        string `json:"source-model-tag"`
+	SourceApplicationName string `json:"source-application-name"`
+	IsConsumerProxy       bool   `json:"is-consumer-proxy"`
+	Life                  Life   `json:"life"`
+}
+
+// RemoteApplicationResult holds a remote application or an error.
+type RemoteApplicationResult struct {
+	Result *RemoteApplication `json:"result,omitempty"`
+	Error  *Error             `json:"error,omitempty"`
+}
+
+// RemoteApplicationResults holds the results of an API call that
+// returns remote applications.
+type RemoteApplicationResults struct {
+	Results []RemoteApplicationResult `json:"results"`
+}
+
+// RemoteRelation holds the details of a relation between a local
+// application and a remote application.
+type RemoteRelation struct {
+	Id                    int    `json:"id"`
+	Key                   string `json:"key"`
+	Life                  Life   `json:"life"`
+	ApplicationName       string `json:"application-name"`
+	Endpoint              string `json:"endpoint"`
+	RemoteApplicationName string `json:"remote-application-name"`
+	RemoteEndpoint        string `json:"remote-endpoint"`
+}
+
+// RemoteRelationResult holds a remote relation or an error.
+type RemoteRelationResult struct {
+	Result *RemoteRelation `json:"result,omitempty"`
+	Error  *Error          `json:"error,omitempty"`
+}
+
+// RemoteRelationResults holds the results of an API call that
+// returns remote relations.
+type RemoteRelationResults struct {
+	Results []RemoteRelationResult `json:"results"`
+}
+
+// RemoteRelationChange describes changes to the local side of a
+// relation with a remote application, to be published to the model
+// hosting the remote application.
+type RemoteRelationChange struct {
+	// Relation identifies the relation, and RemoteApplication
+	// the remote application taking part in it. The relation is
+	// supplied in full so that changes may be published after it
+	// has been removed from the local model; the remote application
+	// is looked up by name.
+	Relation          RemoteRelation    `json:"relation"`
+	RemoteApplication RemoteApplication `json:"remote-application"`
+
+	// ChangedUnits holds the names of the local units that have
+	// joined the relation, or whose settings have changed.
+	ChangedUnits []string `json:"changed-units,omitempty"`
+
+	// DepartedUnits holds the names of the local units that
+	// have left the relation.
+	DepartedUnits []string `json:"departed-units,omitempty"`
+}
+
+// RemoteRelationChanges holds the parameters for the
+// PublishLocalRelationChanges call.
+type RemoteRelationChanges struct {
+	Changes []RemoteRelationChange `json:"changes"`
+}
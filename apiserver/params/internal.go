@@ -587,6 +587,7 @@ type ProvisioningInfo struct {
 	ImageMetadata    []CloudImageMetadata      `json:"image-metadata,omitempty"`
 	EndpointBindings map[string]string         `json:"endpoint-bindings,omitempty"`
 	ControllerConfig map[string]interface{}    `json:"controller-config,omitempty"`
+	StaticIPAddress  string                    `json:"static-ip-address,omitempty"`
 }
 
 // ProvisioningInfoResult holds machine provisioning info or an error.
@@ -637,6 +638,52 @@ type MetricBatchParams struct {
 	Batches []MetricBatchParam `json:"batches"`
 }
 
+// HookExecution holds the details of a single run of a charm hook.
+type HookExecution struct {
+	Hook       string    `json:"hook"`
+	RelationId int       `json:"relation-id"`
+	RemoteUnit string    `json:"remote-unit,omitempty"`
+	Started    time.Time `json:"started"`
+	Finished   time.Time `json:"finished"`
+	ExitCode   int       `json:"exit-code"`
+	ToolCalls  []string  `json:"tool-calls,omitempty"`
+}
+
+// EntityWorkloadVersion holds the workload version for an entity.
+type EntityWorkloadVersion struct {
+	Tag             string `json:"tag"`
+	WorkloadVersion string `json:"workload-version"`
+}
+
+// EntityWorkloadVersions holds the parameters for setting the
+// workload version for a set of entities.
+type EntityWorkloadVersions struct {
+	Entities []EntityWorkloadVersion `json:"entities"`
+}
+
+// HookExecutionArg holds a hook execution to record for a unit.
+type HookExecutionArg struct {
+	Tag       string        `json:"tag"`
+	Execution HookExecution `json:"execution"`
+}
+
+// HookExecutionArgs holds hook executions to record.
+type HookExecutionArgs struct {
+	Args []HookExecutionArg `json:"args"`
+}
+
+// HookHistoryResult holds the recent hook executions of a unit,
+// oldest first, or an error.
+type HookHistoryResult struct {
+	Executions []HookExecution `json:"executions,omitempty"`
+	Error      *Error          `json:"error,omitempty"`
+}
+
+// HookHistoryResults holds the results of a bulk hook history request.
+type HookHistoryResults struct {
+	Results []HookHistoryResult `json:"results"`
+}
+
 // MeterStatusResult holds unit meter status or error.
 type MeterStatusResult struct {
 	Code  string `json:"code"`
@@ -193,6 +193,59 @@ func (pr PortRange) NetworkPortRange() network.PortRange {
 	}
 }
 
+// EgressRule describes a range of ports to which egress traffic
+// is permitted for a set of destination address ranges.
+type EgressRule struct {
+	PortRange        PortRange `json:"port-range"`
+	DestinationCIDRs []string  `json:"destination-cidrs"`
+}
+
+// FromNetworkEgressRule is a convenience helper to create a parameter
+// out of the network type, here for EgressRule.
+func FromNetworkEgressRule(rule network.EgressRule) EgressRule {
+	return EgressRule{
+		PortRange:        FromNetworkPortRange(rule.PortRange),
+		DestinationCIDRs: rule.DestinationCIDRs,
+	}
+}
+
+// NetworkEgressRule is a convenience helper to return the parameter
+// as network type, here for EgressRule.
+func (r EgressRule) NetworkEgressRule() network.EgressRule {
+	return network.NewEgressRule(r.PortRange.NetworkPortRange(), r.DestinationCIDRs...)
+}
+
+// EgressRulesResult holds egress rules or an error.
+type EgressRulesResult struct {
+	Rules []EgressRule `json:"rules"`
+	Error *Error       `json:"error,omitempty"`
+}
+
+// EgressRulesResults holds the bulk operation result of an API call
+// that returns egress rules or an error.
+type EgressRulesResults struct {
+	Results []EgressRulesResult `json:"results"`
+}
+
+// FirewallDrift describes how the ingress rules applied to a machine's
+// instance differ from those expected.
+type FirewallDrift struct {
+	Missing    []string `json:"missing,omitempty"`
+	Unexpected []string `json:"unexpected,omitempty"`
+}
+
+// MachineFirewallDrift holds the firewall drift detected for a machine.
+type MachineFirewallDrift struct {
+	Tag   string        `json:"tag"`
+	Drift FirewallDrift `json:"drift"`
+}
+
+// MachinesFirewallDrift holds the parameters for making a
+// SetFirewallDrift call.
+type MachinesFirewallDrift struct {
+	Machines []MachineFirewallDrift `json:"machines"`
+}
+
 // EntityPort holds an entity's tag, a protocol and a port.
 type EntityPort struct {
 	Tag      string `json:"tag"`
@@ -422,6 +475,51 @@ type UnitNetworkConfigResults struct {
 	Results []UnitNetworkConfigResult `json:"results"`
 }
 
+// NetworkInfoParams holds the parameters for calling
+// Uniter.NetworkInfo() API.
+type NetworkInfoParams struct {
+	Unit     string   `json:"unit"`
+	Bindings []string `json:"bindings"`
+}
+
+// InterfaceAddress holds a single address of a link-layer device and
+// the CIDR of its subnet.
+type InterfaceAddress struct {
+	Address string `json:"value"`
+	CIDR    string `json:"cidr"`
+}
+
+// NetworkInfo holds a link-layer device and its addresses bound to an
+// endpoint's space.
+type NetworkInfo struct {
+	MACAddress    string             `json:"mac-address"`
+	InterfaceName string             `json:"interface-name"`
+	Addresses     []InterfaceAddress `json:"addresses"`
+}
+
+// NetworkInfoResult holds the network info of a single endpoint
+// binding of a unit.
+type NetworkInfoResult struct {
+	Error *Error `json:"error,omitempty"`
+
+	// Info holds the devices and addresses bound to the endpoint.
+	Info []NetworkInfo `json:"network-info,omitempty"`
+
+	// IngressAddresses holds the addresses other units should use to
+	// reach the unit over the endpoint, most preferred first.
+	IngressAddresses []string `json:"ingress-addresses,omitempty"`
+
+	// EgressSubnets holds the CIDRs traffic from the unit over the
+	// endpoint originates from.
+	EgressSubnets []string `json:"egress-subnets,omitempty"`
+}
+
+// NetworkInfoResults holds the network info of a unit, keyed by
+// binding name.
+type NetworkInfoResults struct {
+	Results map[string]NetworkInfoResult `json:"results"`
+}
+
 // MachineNetworkConfigResult holds network configuration for a single machine.
 type MachineNetworkConfigResult struct {
 	Error *Error `json:"error,omitempty"`
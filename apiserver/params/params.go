@@ -119,6 +119,37 @@ type DestroyRelation struct {
 	Endpoints []string `json:"endpoints"`
 }
 
+// RelationDataArgs holds the parameters for making the RelationData
+// call. The endpoints specified are unordered.
+type RelationDataArgs struct {
+	Endpoints []string `json:"endpoints"`
+}
+
+// RelationDataResult holds the settings of each unit taking part in
+// a relation, grouped by application.
+type RelationDataResult struct {
+	RelationId   int                       `json:"relation-id"`
+	Key          string                    `json:"key"`
+	Applications []RelationApplicationData `json:"applications"`
+}
+
+// RelationApplicationData holds the settings of each unit of one
+// application taking part in a relation.
+type RelationApplicationData struct {
+	Application string             `json:"application"`
+	Endpoint    string             `json:"endpoint"`
+	Role        string             `json:"role"`
+	Units       []RelationUnitData `json:"units"`
+}
+
+// RelationUnitData holds the relation settings of a single unit.
+// Settings is nil if the unit has not entered the relation's scope.
+type RelationUnitData struct {
+	Unit     string                 `json:"unit"`
+	InScope  bool                   `json:"in-scope"`
+	Settings map[string]interface{} `json:"settings,omitempty"`
+}
+
 // AddCharm holds the arguments for making an AddCharm API call.
 type AddCharm struct {
 	URL     string `json:"url"`
@@ -250,6 +281,37 @@ type ApplicationSetCharm struct {
 // ApplicationExpose holds the parameters for making the application Expose call.
 type ApplicationExpose struct {
 	ApplicationName string `json:"application"`
+
+	// ToCIDRs and ToSpaces, if specified, restrict the source
+	// addresses from which the application's open ports may be
+	// accessed to the given address ranges, in CIDR notation, and
+	// to the subnets of the named spaces.
+	ToCIDRs  []string `json:"to-cidrs,omitempty"`
+	ToSpaces []string `json:"to-spaces,omitempty"`
+}
+
+// ApplicationEgressRules holds the parameters for getting or setting
+// the egress rules of an application or, if ApplicationName is empty,
+// of the model.
+type ApplicationEgressRules struct {
+	ApplicationName string       `json:"application,omitempty"`
+	Rules           []EgressRule `json:"rules,omitempty"`
+}
+
+// ApplicationVirtualIPAddress holds the parameters for allocating or
+// releasing the virtual IP address of an application. When allocating,
+// Address may be left empty to allocate any free address of the subnet.
+type ApplicationVirtualIPAddress struct {
+	ApplicationName string `json:"application"`
+	SubnetCIDR      string `json:"subnet-cidr,omitempty"`
+	Address         string `json:"address,omitempty"`
+}
+
+// ApplicationBind holds the parameters for changing the spaces an
+// application's endpoints are bound to.
+type ApplicationBind struct {
+	ApplicationName  string            `json:"application"`
+	EndpointBindings map[string]string `json:"endpoint-bindings"`
 }
 
 // ApplicationSet holds the parameters for an application Set
@@ -50,21 +50,26 @@ type MachineStatus struct {
 	Jobs       []multiwatcher.MachineJob `json:"jobs"`
 	HasVote    bool                      `json:"has-vote"`
 	WantsVote  bool                      `json:"wants-vote"`
+
+	// FirewallDrift is set when the ingress rules applied to the
+	// machine's instance differ from those expected.
+	FirewallDrift *FirewallDrift `json:"firewall-drift,omitempty"`
 }
 
 // ApplicationStatus holds status info about an application.
 type ApplicationStatus struct {
-	Err           error                  `json:"err,omitempty"`
-	Charm         string                 `json:"charm"`
-	Series        string                 `json:"series"`
-	Exposed       bool                   `json:"exposed"`
-	Life          string                 `json:"life"`
-	Relations     map[string][]string    `json:"relations"`
-	CanUpgradeTo  string                 `json:"can-upgrade-to"`
-	SubordinateTo []string               `json:"subordinate-to"`
-	Units         map[string]UnitStatus  `json:"units"`
-	MeterStatuses map[string]MeterStatus `json:"meter-statuses"`
-	Status        DetailedStatus         `json:"status"`
+	Err             error                  `json:"err,omitempty"`
+	Charm           string                 `json:"charm"`
+	Series          string                 `json:"series"`
+	Exposed         bool                   `json:"exposed"`
+	Life            string                 `json:"life"`
+	Relations       map[string][]string    `json:"relations"`
+	CanUpgradeTo    string                 `json:"can-upgrade-to"`
+	SubordinateTo   []string               `json:"subordinate-to"`
+	Units           map[string]UnitStatus  `json:"units"`
+	MeterStatuses   map[string]MeterStatus `json:"meter-statuses"`
+	Status          DetailedStatus         `json:"status"`
+	WorkloadVersion string                 `json:"workload-version"`
 }
 
 // MeterStatus represents the meter status of a unit.
@@ -81,11 +86,12 @@ type UnitStatus struct {
 	// WorkloadStatus holds the status for a unit's workload
 	WorkloadStatus DetailedStatus `json:"workload-status"`
 
-	Machine       string                `json:"machine"`
-	OpenedPorts   []string              `json:"opened-ports"`
-	PublicAddress string                `json:"public-address"`
-	Charm         string                `json:"charm"`
-	Subordinates  map[string]UnitStatus `json:"subordinates"`
+	Machine         string                `json:"machine"`
+	OpenedPorts     []string              `json:"opened-ports"`
+	PublicAddress   string                `json:"public-address"`
+	Charm           string                `json:"charm"`
+	Subordinates    map[string]UnitStatus `json:"subordinates"`
+	WorkloadVersion string                `json:"workload-version"`
 }
 
 // RelationStatus holds status info about a relation.
@@ -3,7 +3,11 @@
 
 package params
 
-import "github.com/juju/juju/storage"
+import (
+	"time"
+
+	"github.com/juju/juju/storage"
+)
 
 // MachineBlockDevices holds a machine tag and the block devices present
 // on that machine.
@@ -94,6 +98,10 @@ type StorageAttachment struct {
 	Kind     StorageKind `json:"kind"`
 	Location string      `json:"location"`
 	Life     Life        `json:"life"`
+
+	// Size is the size of the volume or filesystem backing the
+	// storage attachment, in MiB.
+	Size uint64 `json:"size,omitempty"`
 }
 
 // StorageAttachmentId identifies a storage attachment by the tags of the
@@ -166,6 +174,10 @@ type MachineStorageIds struct {
 type Volume struct {
 	VolumeTag string     `json:"volume-tag"`
 	Info      VolumeInfo `json:"info"`
+
+	// Releasing is true if the volume should be released from the
+	// model, rather than destroyed, once it is dead.
+	Releasing bool `json:"releasing,omitempty"`
 }
 
 // Volume describes a storage volume in the model.
@@ -210,6 +222,7 @@ type VolumeParams struct {
 	Attributes map[string]interface{}  `json:"attributes,omitempty"`
 	Tags       map[string]string       `json:"tags,omitempty"`
 	Attachment *VolumeAttachmentParams `json:"attachment,omitempty"`
+	SnapshotId string                  `json:"snapshot-id,omitempty"`
 }
 
 // VolumeAttachmentParams holds the parameters for creating a volume
@@ -283,11 +296,111 @@ type VolumeAttachmentParamsResults struct {
 	Results []VolumeAttachmentParamsResult `json:"results,omitempty"`
 }
 
+// VolumeSnapshot identifies and describes a volume snapshot.
+type VolumeSnapshot struct {
+	Id        string             `json:"id"`
+	VolumeTag string             `json:"volume-tag"`
+	Info      VolumeSnapshotInfo `json:"info"`
+}
+
+// VolumeSnapshotInfo describes a volume snapshot.
+type VolumeSnapshotInfo struct {
+	SnapshotId string `json:"snapshot-id"`
+	Size       uint64 `json:"size"`
+}
+
+// VolumeSnapshots describes a set of volume snapshots.
+type VolumeSnapshots struct {
+	VolumeSnapshots []VolumeSnapshot `json:"volume-snapshots"`
+}
+
+// VolumeSnapshotIds holds a set of volume snapshot IDs.
+type VolumeSnapshotIds struct {
+	Ids []string `json:"ids"`
+}
+
+// VolumeSnapshotParams holds the parameters for creating, or deleting,
+// a volume snapshot.
+type VolumeSnapshotParams struct {
+	Id         string `json:"id"`
+	VolumeTag  string `json:"volume-tag"`
+	VolumeId   string `json:"volume-id"`
+	Provider   string `json:"provider"`
+	Life       Life   `json:"life"`
+	SnapshotId string `json:"snapshot-id,omitempty"`
+}
+
+// VolumeSnapshotParamsResult holds provisioning parameters for a volume
+// snapshot, or an error.
+type VolumeSnapshotParamsResult struct {
+	Result VolumeSnapshotParams `json:"result"`
+	Error  *Error               `json:"error,omitempty"`
+}
+
+// VolumeSnapshotParamsResults holds provisioning parameters for multiple
+// volume snapshots.
+type VolumeSnapshotParamsResults struct {
+	Results []VolumeSnapshotParamsResult `json:"results,omitempty"`
+}
+
+// VolumeSnapshotDetails describes a volume snapshot, for display to
+// users.
+type VolumeSnapshotDetails struct {
+	Id         string    `json:"id"`
+	VolumeTag  string    `json:"volume-tag"`
+	StorageTag string    `json:"storage-tag,omitempty"`
+	Pool       string    `json:"pool"`
+	Life       Life      `json:"life"`
+	Created    time.Time `json:"created"`
+	SnapshotId string    `json:"snapshot-id,omitempty"`
+	Size       uint64    `json:"size,omitempty"`
+}
+
+// VolumeSnapshotDetailsResult holds the details of a volume snapshot,
+// or an error.
+type VolumeSnapshotDetailsResult struct {
+	Result *VolumeSnapshotDetails `json:"result,omitempty"`
+	Error  *Error                 `json:"error,omitempty"`
+}
+
+// VolumeSnapshotDetailsResults holds the details of multiple volume
+// snapshots.
+type VolumeSnapshotDetailsResults struct {
+	Results []VolumeSnapshotDetailsResult `json:"results,omitempty"`
+}
+
+// VolumeResizeParams holds the parameters for growing a volume.
+type VolumeResizeParams struct {
+	VolumeTag string `json:"volume-tag"`
+	VolumeId  string `json:"volume-id"`
+	Provider  string `json:"provider"`
+	// Size is the requested size of the volume in MiB,
+	// or zero if there is no outstanding resize request.
+	Size uint64 `json:"size,omitempty"`
+}
+
+// VolumeResizeParamsResult holds resize parameters for a volume,
+// or an error.
+type VolumeResizeParamsResult struct {
+	Result VolumeResizeParams `json:"result"`
+	Error  *Error             `json:"error,omitempty"`
+}
+
+// VolumeResizeParamsResults holds resize parameters for multiple
+// volumes.
+type VolumeResizeParamsResults struct {
+	Results []VolumeResizeParamsResult `json:"results,omitempty"`
+}
+
 // Filesystem identifies and describes a storage filesystem in the model.
 type Filesystem struct {
 	FilesystemTag string         `json:"filesystem-tag"`
 	VolumeTag     string         `json:"volume-tag,omitempty"`
 	Info          FilesystemInfo `json:"info"`
+
+	// Releasing is true if the filesystem should be released from
+	// the model, rather than destroyed, once it is dead.
+	Releasing bool `json:"releasing,omitempty"`
 }
 
 // Filesystem describes a storage filesystem in the model.
@@ -302,6 +415,30 @@ type Filesystems struct {
 	Filesystems []Filesystem `json:"filesystems"`
 }
 
+// FilesystemResizeParams holds the parameters for growing a filesystem.
+type FilesystemResizeParams struct {
+	FilesystemTag string `json:"filesystem-tag"`
+	VolumeTag     string `json:"volume-tag,omitempty"`
+	FilesystemId  string `json:"filesystem-id"`
+	Provider      string `json:"provider"`
+	// Size is the requested size of the filesystem in MiB,
+	// or zero if there is no outstanding resize request.
+	Size uint64 `json:"size,omitempty"`
+}
+
+// FilesystemResizeParamsResult holds resize parameters for a
+// filesystem, or an error.
+type FilesystemResizeParamsResult struct {
+	Result FilesystemResizeParams `json:"result"`
+	Error  *Error                 `json:"error,omitempty"`
+}
+
+// FilesystemResizeParamsResults holds resize parameters for multiple
+// filesystems.
+type FilesystemResizeParamsResults struct {
+	Results []FilesystemResizeParamsResult `json:"results,omitempty"`
+}
+
 // FilesystemAttachment identifies and describes a filesystem attachment.
 type FilesystemAttachment struct {
 	FilesystemTag string                   `json:"filesystem-tag"`
@@ -313,6 +450,45 @@ type FilesystemAttachment struct {
 type FilesystemAttachmentInfo struct {
 	MountPoint string `json:"mount-point,omitempty"`
 	ReadOnly   bool   `json:"read-only,omitempty"`
+
+	// Usage is the space and inode usage of the filesystem, as
+	// most recently reported by the machine, if any.
+	Usage *FilesystemUsage `json:"usage,omitempty"`
+}
+
+// FilesystemUsage describes the space and inode usage of a filesystem.
+type FilesystemUsage struct {
+	UsedBytes  uint64 `json:"used-bytes"`
+	FreeBytes  uint64 `json:"free-bytes"`
+	UsedInodes uint64 `json:"used-inodes"`
+	FreeInodes uint64 `json:"free-inodes"`
+}
+
+// FilesystemAttachmentUsage describes the space and inode usage of a
+// filesystem, as observed on a machine that it is attached to.
+type FilesystemAttachmentUsage struct {
+	FilesystemTag string          `json:"filesystem-tag"`
+	MachineTag    string          `json:"machine-tag"`
+	Usage         FilesystemUsage `json:"usage"`
+}
+
+// SetFilesystemAttachmentUsage holds the arguments for recording the
+// space and inode usage of a set of filesystem attachments.
+type SetFilesystemAttachmentUsage struct {
+	Usage []FilesystemAttachmentUsage `json:"usage"`
+}
+
+// FilesystemAttachmentsResult holds the filesystem attachments of
+// a machine, or an error.
+type FilesystemAttachmentsResult struct {
+	Result []FilesystemAttachment `json:"result,omitempty"`
+	Error  *Error                 `json:"error,omitempty"`
+}
+
+// FilesystemAttachmentsResults holds the filesystem attachments of
+// multiple machines.
+type FilesystemAttachmentsResults struct {
+	Results []FilesystemAttachmentsResult `json:"results,omitempty"`
 }
 
 // FilesystemAttachments describes a set of storage filesystem attachments.
@@ -474,6 +650,23 @@ type StoragePool struct {
 
 	// Attrs are the pool's configuration attributes.
 	Attrs map[string]interface{} `json:"attrs"`
+
+	// Usage, if non-nil, describes the storage that the pool is
+	// serving. Usage is only reported if requested.
+	Usage *StoragePoolUsage `json:"usage,omitempty"`
+}
+
+// StoragePoolUsage describes the storage that a pool is serving.
+type StoragePoolUsage struct {
+	// Volumes is the number of volumes in the pool.
+	Volumes int `json:"volumes"`
+
+	// Filesystems is the number of filesystems in the pool.
+	Filesystems int `json:"filesystems"`
+
+	// Size is the total size of the volumes, and of the filesystems
+	// not backed by volumes, in the pool, in MiB.
+	Size uint64 `json:"size"`
 }
 
 // StoragePoolFilter holds a filter for matching storage pools.
@@ -483,6 +676,10 @@ type StoragePoolFilter struct {
 
 	// Providers are pool's storage provider types to filter on.
 	Providers []string `json:"providers,omitempty"`
+
+	// Usage, if true, requests that the usage of each matching
+	// pool be reported.
+	Usage bool `json:"usage,omitempty"`
 }
 
 // StoragePoolFilters holds a collection of storage pool filters.
@@ -662,9 +859,151 @@ type StorageAddParams struct {
 
 	// Constraints are specified storage constraints.
 	Constraints StorageConstraints `json:"storage"`
+
+	// Snapshot, if non-empty, is the ID of the volume snapshot from
+	// which to create the storage.
+	Snapshot string `json:"snapshot,omitempty"`
 }
 
 // StoragesAddParams holds storage details to add to units dynamically.
 type StoragesAddParams struct {
 	Storages []StorageAddParams `json:"storages"`
 }
+
+// StorageResizeParams holds the parameters for growing a storage
+// instance.
+type StorageResizeParams struct {
+	// StorageTag is the tag of the storage instance to resize.
+	StorageTag string `json:"storage-tag"`
+
+	// Size is the requested size of the storage instance in MiB.
+	Size uint64 `json:"size"`
+}
+
+// StoragesResizeParams holds the parameters for growing multiple
+// storage instances.
+type StoragesResizeParams struct {
+	Storages []StorageResizeParams `json:"storages"`
+}
+
+// RemoveStorageInstance holds the parameters for removing a storage
+// instance from the model.
+type RemoveStorageInstance struct {
+	// Tag is the tag of the storage instance to remove.
+	Tag string `json:"tag"`
+
+	// Release, if true, causes the cloud storage backing the storage
+	// instance to be released from the model rather than destroyed.
+	Release bool `json:"release,omitempty"`
+}
+
+// RemoveStorage holds the parameters for removing multiple storage
+// instances from the model.
+type RemoveStorage struct {
+	Storage []RemoveStorageInstance `json:"storage"`
+}
+
+// ImportFilesystemParams holds the parameters for importing an
+// existing filesystem into the model.
+type ImportFilesystemParams struct {
+	// Pool is the name of the storage pool that the filesystem
+	// is to be imported into.
+	Pool string `json:"pool"`
+
+	// ProviderId is the storage provider's ID for the filesystem,
+	// or for the volume backing it.
+	ProviderId string `json:"provider-id"`
+
+	// StorageName is the name of the storage to create for the
+	// imported filesystem.
+	StorageName string `json:"storage-name"`
+}
+
+// ImportFilesystemsParams holds the parameters for importing multiple
+// existing filesystems into the model.
+type ImportFilesystemsParams struct {
+	Filesystems []ImportFilesystemParams `json:"filesystems"`
+}
+
+// ImportStorageDetails contains the details of an imported storage
+// instance.
+type ImportStorageDetails struct {
+	// StorageTag is the tag of the storage instance created for
+	// the imported storage.
+	StorageTag string `json:"storage-tag"`
+}
+
+// ImportStorageResult holds the result of importing storage.
+type ImportStorageResult struct {
+	Result *ImportStorageDetails `json:"result,omitempty"`
+	Error  *Error                `json:"error,omitempty"`
+}
+
+// ImportStorageResults holds the results of importing storage.
+type ImportStorageResults struct {
+	Results []ImportStorageResult `json:"results"`
+}
+
+// StorageQuota limits the storage that may be provisioned from each
+// storage pool. A zero value for a field imposes no limit.
+type StorageQuota struct {
+	// Size is the maximum total size of storage, in MiB.
+	Size uint64 `json:"size,omitempty"`
+
+	// Count is the maximum number of storage instances.
+	Count uint64 `json:"count,omitempty"`
+}
+
+// StorageQuotaUsage describes the storage provisioned from a
+// storage pool.
+type StorageQuotaUsage struct {
+	// Pool is the name of the storage pool.
+	Pool string `json:"pool"`
+
+	// Size is the total size of storage, in MiB.
+	Size uint64 `json:"size"`
+
+	// Count is the number of storage instances.
+	Count uint64 `json:"count"`
+}
+
+// StorageQuotaFilter selects the storage quota of the model or, if
+// ApplicationTag is set, of an application.
+type StorageQuotaFilter struct {
+	ApplicationTag string `json:"application-tag,omitempty"`
+}
+
+// StorageQuotaFilters holds a set of storage quota filters.
+type StorageQuotaFilters struct {
+	Filters []StorageQuotaFilter `json:"filters"`
+}
+
+// StorageQuotaDetails holds a storage quota, and the storage usage
+// of each pool that is accounted against it.
+type StorageQuotaDetails struct {
+	Quota StorageQuota        `json:"quota"`
+	Usage []StorageQuotaUsage `json:"usage,omitempty"`
+}
+
+// StorageQuotaResult holds the result of a storage quota query.
+type StorageQuotaResult struct {
+	Result *StorageQuotaDetails `json:"result,omitempty"`
+	Error  *Error               `json:"error,omitempty"`
+}
+
+// StorageQuotaResults holds the results of storage quota queries.
+type StorageQuotaResults struct {
+	Results []StorageQuotaResult `json:"results"`
+}
+
+// ApplicationStorageQuota holds the storage quota for an application.
+type ApplicationStorageQuota struct {
+	ApplicationTag string       `json:"application-tag"`
+	Quota          StorageQuota `json:"quota"`
+}
+
+// ApplicationStorageQuotas holds the storage quotas for multiple
+// applications.
+type ApplicationStorageQuotas struct {
+	Quotas []ApplicationStorageQuota `json:"quotas"`
+}
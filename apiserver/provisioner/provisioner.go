@@ -4,6 +4,7 @@
 package provisioner
 
 import (
+	"net"
 	"time"
 
 	"github.com/juju/errors"
@@ -630,6 +631,11 @@ func (p *ProvisionerAPI) prepareOrGetContainerInterfaceInfo(args params.Entities
 			continue
 		}
 
+		// A static IP address requested for the container is assigned
+		// to the device whose parent is in the subnet containing it.
+		staticIPAddress := container.StaticIPAddress()
+		staticDeviceName, staticGatewayAddress := "", ""
+
 		preparedInfo := make([]network.InterfaceInfo, len(containerDevices))
 		preparedOK := true
 		for j, device := range containerDevices {
@@ -680,6 +686,14 @@ func (p *ProvisionerAPI) prepareOrGetContainerInterfaceInfo(args params.Entities
 				VLANTag:             parentDeviceSubnet.VLANTag(),
 				ParentInterfaceName: parentDevice.Name(),
 			}
+			if staticIPAddress != "" && staticDeviceName == "" && subnetContains(info.CIDR, staticIPAddress) {
+				staticDeviceName = device.Name()
+				staticGatewayAddress = firstAddress.GatewayAddress()
+				info.Address = network.NewAddress(staticIPAddress)
+				if staticGatewayAddress != "" {
+					info.GatewayAddress = network.NewAddress(staticGatewayAddress)
+				}
+			}
 			logger.Tracef("prepared info for container interface %q: %+v", info.InterfaceName, info)
 			preparedOK = true
 			preparedInfo[j] = info
@@ -690,8 +704,29 @@ func (p *ProvisionerAPI) prepareOrGetContainerInterfaceInfo(args params.Entities
 			continue
 		}
 
+		if staticIPAddress != "" {
+			if staticDeviceName == "" {
+				err = errors.Errorf(
+					"cannot assign static IP address %q to %q: no host machine bridge device in a subnet containing it",
+					staticIPAddress, machineTag.Id(),
+				)
+				result.Results[i].Error = common.ServerError(err)
+				continue
+			}
+			if err := container.ReserveStaticIPAddress(staticDeviceName, staticGatewayAddress); err != nil {
+				result.Results[i].Error = common.ServerError(err)
+				continue
+			}
+		}
+
 		allocatedInfo, err := netEnviron.AllocateContainerAddresses(instId, machineTag, preparedInfo)
-		if err != nil {
+		if errors.IsNotSupported(err) && staticIPAddress != "" {
+			// The provider does not allocate container addresses, so
+			// configure the static IP address directly and leave any
+			// other devices to DHCP.
+			logger.Debugf("using static IP address %q for container %q: %v", staticIPAddress, machineTag.Id(), err)
+			allocatedInfo = staticContainerInterfaceInfo(preparedInfo)
+		} else if err != nil {
 			result.Results[i].Error = common.ServerError(err)
 			continue
 		}
@@ -705,6 +740,30 @@ func (p *ProvisionerAPI) prepareOrGetContainerInterfaceInfo(args params.Entities
 	return result, nil
 }
 
+// subnetContains reports whether the subnet with the given CIDR contains the
+// given IP address.
+func subnetContains(cidr, address string) bool {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return false
+	}
+	ip := net.ParseIP(address)
+	return ip != nil && ipNet.Contains(ip)
+}
+
+// staticContainerInterfaceInfo returns a copy of the given prepared info,
+// where devices without an address are configured using DHCP.
+func staticContainerInterfaceInfo(preparedInfo []network.InterfaceInfo) []network.InterfaceInfo {
+	result := make([]network.InterfaceInfo, len(preparedInfo))
+	for i, info := range preparedInfo {
+		if info.Address.Value == "" {
+			info.ConfigType = network.ConfigDHCP
+		}
+		result[i] = info
+	}
+	return result
+}
+
 // prepareContainerAccessEnvironment retrieves the environment, host machine, and access
 // for working with containers.
 func (p *ProvisionerAPI) prepareContainerAccessEnvironment() (environs.NetworkingEnviron, *state.Machine, common.AuthFunc, error) {
@@ -104,6 +104,7 @@ func (p *ProvisionerAPI) getProvisioningInfo(m *state.Machine) (*params.Provisio
 		EndpointBindings: endpointBindings,
 		ImageMetadata:    imageMetadata,
 		ControllerConfig: controllerCfg,
+		StaticIPAddress:  m.StaticIPAddress(),
 	}, nil
 }
 
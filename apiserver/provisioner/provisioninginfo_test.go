@@ -12,6 +12,7 @@ import (
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/provider/dummy"
 	"github.com/juju/juju/state"
@@ -360,6 +361,25 @@ func (s *withoutControllerSuite) TestStorageProviderFallbackToType(c *gc.C) {
 	})
 }
 
+func (s *withoutControllerSuite) TestProvisioningInfoWithStaticIPAddress(c *gc.C) {
+	template := state.MachineTemplate{
+		Series:          "quantal",
+		Jobs:            []state.MachineJob{state.JobHostUnits},
+		StaticIPAddress: "10.0.8.5",
+	}
+	container, err := s.State.AddMachineInsideMachine(template, s.machines[0].Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: container.Tag().String()},
+	}}
+	result, err := s.provisioner.ProvisioningInfo(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Check(result.Results[0].Result.StaticIPAddress, gc.Equals, "10.0.8.5")
+}
+
 func (s *withoutControllerSuite) TestProvisioningInfoPermissions(c *gc.C) {
 	// Login as a machine agent for machine 0.
 	anAuthorizer := s.authorizer
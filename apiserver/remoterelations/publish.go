@@ -0,0 +1,269 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remoterelations
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+)
+
+// publishLocalRelationChange applies the supplied change, made to the
+// local side of a relation with a remote application, to the
+// corresponding relation in the model hosting the remote application.
+// There, the local units are represented by units of a remote
+// application standing in for the local application.
+//
+// The remote application is looked up by name in the local model,
+// rather than trusting the details supplied with the change, so the
+// change can only affect the model hosting that application.
+func (api *RemoteRelationsAPI) publishLocalRelationChange(change params.RemoteRelationChange) error {
+	remote, err := api.st.RemoteApplication(change.RemoteApplication.Name)
+	if errors.IsNotFound(err) && change.Relation.Life != params.Alive {
+		// The remote application, and with it any means of
+		// finding the counterpart relation, has gone away.
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if change.Relation.RemoteApplicationName != remote.Name() {
+		return errors.NotValidf(
+			"relation %q with remote application %q",
+			change.Relation.Key, remote.Name(),
+		)
+	}
+	st, err := api.st.ForModel(remote.SourceModel())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer st.Close()
+
+	rel, proxy, err := counterpartRelation(st, api.st.ModelTag(), remote, change.Relation)
+	if errors.IsNotFound(err) && change.Relation.Life != params.Alive {
+		// The counterpart relation has already gone away.
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+
+	// If the remote application stands in for the local application
+	// as the consumer of an application offered by the other model,
+	// the offered application must admit traffic from the local units.
+	var offered *state.Application
+	if proxy.IsConsumerProxy() {
+		offered, err = st.Application(remote.SourceApplicationName())
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	for _, unitName := range change.ChangedUnits {
+		if err := api.publishUnitSettings(rel, proxy, offered, change.Relation.Key, unitName); err != nil {
+			return errors.Annotatef(err, "publishing settings of unit %q", unitName)
+		}
+	}
+	for _, unitName := range change.DepartedUnits {
+		remoteUnitName, err := mapUnitName(proxy.Name(), unitName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		ru, err := rel.RemoteUnit(remoteUnitName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := ru.LeaveScope(); err != nil {
+			return errors.Annotatef(err, "publishing departure of unit %q", unitName)
+		}
+		if offered != nil {
+			if err := offered.SetRelationIngressCIDR(remoteUnitName, ""); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+	if change.Relation.Life != params.Alive {
+		if err := departRemoteUnits(rel, proxy, offered); err != nil {
+			return errors.Trace(err)
+		}
+		if err := rel.Destroy(); err != nil && !errors.IsNotFound(err) {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// departRemoteUnits makes all units of the given remote application
+// leave the scope of the relation, and removes the address ranges
+// recorded for them on the offered application, if any. It is used
+// when the relation is no longer alive in the consuming model, so the
+// units remaining in scope in the other model are not known to it.
+func departRemoteUnits(rel *state.Relation, proxy *state.RemoteApplication, offered *state.Application) error {
+	unitNames, err := rel.RemoteUnitsInScope(proxy.Name())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, unitName := range unitNames {
+		ru, err := rel.RemoteUnit(unitName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := ru.LeaveScope(); err != nil {
+			return errors.Annotatef(err, "departing unit %q", unitName)
+		}
+	}
+	if offered != nil {
+		if err := offered.ClearRelationIngressCIDRs(proxy.Name()); err != nil && !errors.IsNotFound(err) {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// publishUnitSettings copies the relation settings of the named local
+// unit to the unit representing it in the counterpart relation, which
+// enters scope if it has not already done so.
+func (api *RemoteRelationsAPI) publishUnitSettings(
+	rel *state.Relation,
+	proxy *state.RemoteApplication,
+	offered *state.Application,
+	localRelationKey, unitName string,
+) error {
+	remoteUnitName, err := mapUnitName(proxy.Name(), unitName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	localRel, err := api.st.KeyRelation(localRelationKey)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	unit, err := api.st.Unit(unitName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	localRU, err := localRel.Unit(unit)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	localSettings, err := localRU.Settings()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	ru, err := rel.RemoteUnit(remoteUnitName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	inScope, err := ru.InScope()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !inScope {
+		if err := ru.EnterScope(localSettings.Map()); err != nil {
+			return errors.Trace(err)
+		}
+	} else {
+		settings, err := ru.Settings()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, key := range settings.Keys() {
+			if _, ok := localSettings.Get(key); !ok {
+				settings.Delete(key)
+			}
+		}
+		settings.Update(localSettings.Map())
+		if _, err := settings.Write(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	if offered == nil {
+		return nil
+	}
+	addr, err := unit.PublicAddress()
+	if network.IsNoAddressError(err) {
+		logger.Debugf("unit %q has no public address yet", unitName)
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if addr.Type == network.HostName {
+		logger.Warningf("cannot admit unit %q by hostname %q", unitName, addr.Value)
+		return nil
+	}
+	return offered.SetRelationIngressCIDR(remoteUnitName, hostCIDR(addr))
+}
+
+// counterpartRelation returns the relation, in the given state, that
+// corresponds to the given relation with the remote application, along
+// with the remote application standing in there for the relation's
+// local application. The relation is in the model with the given tag.
+//
+// The endpoints of a remote application have the same names as those
+// of the application it represents, so the counterpart relation joins
+// the same endpoints as the local one.
+func counterpartRelation(
+	st *state.State,
+	sourceModel names.ModelTag,
+	remote *state.RemoteApplication,
+	relation params.RemoteRelation,
+) (*state.Relation, *state.RemoteApplication, error) {
+	applications, err := st.AllRemoteApplications()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	for _, application := range applications {
+		if application.SourceModel() != sourceModel ||
+			application.SourceApplicationName() != relation.ApplicationName ||
+			application.IsConsumerProxy() == remote.IsConsumerProxy() {
+			continue
+		}
+		rels, err := application.Relations()
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		for _, rel := range rels {
+			ep, err := rel.Endpoint(application.Name())
+			if err != nil {
+				return nil, nil, errors.Trace(err)
+			}
+			if ep.Name != relation.Endpoint {
+				continue
+			}
+			related, err := rel.RelatedEndpoints(application.Name())
+			if err != nil {
+				return nil, nil, errors.Trace(err)
+			}
+			if related[0].ApplicationName == remote.SourceApplicationName() &&
+				related[0].Name == relation.RemoteEndpoint {
+				return rel, application, nil
+			}
+		}
+	}
+	return nil, nil, errors.NotFoundf(
+		"relation corresponding to %q in model %q",
+		relation.Key, st.ModelUUID(),
+	)
+}
+
+// mapUnitName returns the name of the unit of the named application
+// having the same number as the named unit.
+func mapUnitName(applicationName, unitName string) (string, error) {
+	if !names.IsValidUnit(unitName) {
+		return "", errors.NotValidf("unit name %q", unitName)
+	}
+	return applicationName + unitName[strings.Index(unitName, "/"):], nil
+}
+
+// hostCIDR returns the CIDR matching only the given address.
+func hostCIDR(addr network.Address) string {
+	if addr.Type == network.IPv6Address {
+		return addr.Value + "/128"
+	}
+	return addr.Value + "/32"
+}
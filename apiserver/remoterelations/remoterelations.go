@@ -0,0 +1,212 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package remoterelations provides the API used by the remoterelations
+// worker to publish changes to relations with applications in other
+// models on the same controller.
+package remoterelations
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.remoterelations")
+
+func init() {
+	common.RegisterStandardFacade("RemoteRelations", 1, NewRemoteRelationsAPI)
+}
+
+// RemoteRelationsAPI provides access to the RemoteRelations API facade.
+type RemoteRelationsAPI struct {
+	st        *state.State
+	resources *common.Resources
+}
+
+// NewRemoteRelationsAPI creates a new server-side RemoteRelationsAPI facade.
+func NewRemoteRelationsAPI(
+	st *state.State,
+	resources *common.Resources,
+	authorizer common.Authorizer,
+) (*RemoteRelationsAPI, error) {
+	if !authorizer.AuthModelManager() {
+		return nil, common.ErrPerm
+	}
+	return &RemoteRelationsAPI{
+		st:        st,
+		resources: resources,
+	}, nil
+}
+
+// WatchRemoteApplications starts a strings watcher that notifies of
+// the addition, removal, and lifecycle changes of remote applications
+// in the model.
+func (api *RemoteRelationsAPI) WatchRemoteApplications() (params.StringsWatchResult, error) {
+	w := api.st.WatchRemoteApplications()
+	if changes, ok := <-w.Changes(); ok {
+		return params.StringsWatchResult{
+			StringsWatcherId: api.resources.Register(w),
+			Changes:          changes,
+		}, nil
+	}
+	return params.StringsWatchResult{}, watcher.EnsureErr(w)
+}
+
+// RemoteApplications returns the details of the specified remote
+// applications.
+func (api *RemoteRelationsAPI) RemoteApplications(args params.Entities) (params.RemoteApplicationResults, error) {
+	results := params.RemoteApplicationResults{
+		Results: make([]params.RemoteApplicationResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		application, err := api.remoteApplication(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = &params.RemoteApplication{
+			Name:                  application.Name(),
+			SourceModelTag:        application.SourceModel().String(),
+			SourceApplicationName: application.SourceApplicationName(),
+			IsConsumerProxy:       application.IsConsumerProxy(),
+			Life:                  params.Life(application.Life().String()),
+		}
+	}
+	return results, nil
+}
+
+// WatchRemoteApplicationRelations starts, for each specified remote
+// application, a strings watcher that notifies of changes to the keys
+// of the relations in which the remote application takes part.
+func (api *RemoteRelationsAPI) WatchRemoteApplicationRelations(args params.Entities) (params.StringsWatchResults, error) {
+	results := params.StringsWatchResults{
+		Results: make([]params.StringsWatchResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		application, err := api.remoteApplication(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		w := application.WatchRelations()
+		if changes, ok := <-w.Changes(); ok {
+			results.Results[i].StringsWatcherId = api.resources.Register(w)
+			results.Results[i].Changes = changes
+		} else {
+			results.Results[i].Error = common.ServerError(watcher.EnsureErr(w))
+		}
+	}
+	return results, nil
+}
+
+// Relations returns the details of the specified relations with
+// remote applications.
+func (api *RemoteRelationsAPI) Relations(args params.Entities) (params.RemoteRelationResults, error) {
+	results := params.RemoteRelationResults{
+		Results: make([]params.RemoteRelationResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		rel, local, remote, err := api.remoteRelation(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = &params.RemoteRelation{
+			Id:                    rel.Id(),
+			Key:                   rel.String(),
+			Life:                  params.Life(rel.Life().String()),
+			ApplicationName:       local.ApplicationName,
+			Endpoint:              local.Name,
+			RemoteApplicationName: remote.ApplicationName,
+			RemoteEndpoint:        remote.Name,
+		}
+	}
+	return results, nil
+}
+
+// WatchLocalRelationUnits starts, for each specified relation with a
+// remote application, a RelationUnitsWatcher that notifies of changes
+// to the local units taking part in the relation.
+func (api *RemoteRelationsAPI) WatchLocalRelationUnits(args params.Entities) (params.RelationUnitsWatchResults, error) {
+	results := params.RelationUnitsWatchResults{
+		Results: make([]params.RelationUnitsWatchResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		rel, local, _, err := api.remoteRelation(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		w, err := rel.WatchUnits(local.ApplicationName)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		if changes, ok := <-w.Changes(); ok {
+			results.Results[i].RelationUnitsWatcherId = api.resources.Register(w)
+			results.Results[i].Changes = changes
+		} else {
+			results.Results[i].Error = common.ServerError(watcher.EnsureErr(w))
+		}
+	}
+	return results, nil
+}
+
+// PublishLocalRelationChanges publishes changes to the local side of
+// relations with remote applications to the models hosting the remote
+// applications.
+func (api *RemoteRelationsAPI) PublishLocalRelationChanges(args params.RemoteRelationChanges) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Changes)),
+	}
+	for i, change := range args.Changes {
+		err := api.publishLocalRelationChange(change)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// remoteApplication returns the remote application with the given tag.
+func (api *RemoteRelationsAPI) remoteApplication(tagString string) (*state.RemoteApplication, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return nil, common.ErrPerm
+	}
+	return api.st.RemoteApplication(tag.Id())
+}
+
+// remoteRelation returns the relation with the given tag, along with
+// its local and remote endpoints. It is an error if the relation is
+// not with a remote application.
+func (api *RemoteRelationsAPI) remoteRelation(tagString string) (
+	rel *state.Relation, local, remote state.Endpoint, err error,
+) {
+	tag, err := names.ParseRelationTag(tagString)
+	if err != nil {
+		return nil, local, remote, common.ErrPerm
+	}
+	rel, err = api.st.KeyRelation(tag.Id())
+	if err != nil {
+		return nil, local, remote, errors.Trace(err)
+	}
+	var foundRemote bool
+	for _, ep := range rel.Endpoints() {
+		if _, err := api.st.RemoteApplication(ep.ApplicationName); err == nil {
+			remote, foundRemote = ep, true
+		} else if errors.IsNotFound(err) {
+			local = ep
+		} else {
+			return nil, local, remote, errors.Trace(err)
+		}
+	}
+	if !foundRemote {
+		return nil, local, remote, errors.NotValidf("relation %q without remote application", rel)
+	}
+	return rel, local, remote, nil
+}
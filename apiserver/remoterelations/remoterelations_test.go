@@ -0,0 +1,347 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remoterelations_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/remoterelations"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type remoteRelationsSuite struct {
+	jujutesting.JujuConnSuite
+
+	resources  *common.Resources
+	authorizer apiservertesting.FakeAuthorizer
+	api        *remoterelations.RemoteRelationsAPI
+
+	// The wordpress application in the controller model
+	// consumes the mysql application offered by otherState.
+	otherState  *state.State
+	wordpress   *state.Application
+	mysql       *state.Application
+	relation    *state.Relation
+	counterpart *state.Relation
+}
+
+var _ = gc.Suite(&remoteRelationsSuite{})
+
+func (s *remoteRelationsSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+	s.resources = common.NewResources()
+	s.AddCleanup(func(_ *gc.C) { s.resources.StopAll() })
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:            names.NewMachineTag("0"),
+		EnvironManager: true,
+	}
+	api, err := remoterelations.NewRemoteRelationsAPI(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api = api
+
+	s.otherState = s.Factory.MakeModel(c, nil)
+	s.AddCleanup(func(*gc.C) { s.otherState.Close() })
+	f := factory.NewFactory(s.otherState)
+	s.mysql = f.MakeApplication(c, &factory.ApplicationParams{
+		Name:  "mysql",
+		Charm: f.MakeCharm(c, &factory.CharmParams{Name: "mysql"}),
+	})
+	s.wordpress = s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+
+	s.relation = s.addRemoteRelation(c, s.State, state.AddRemoteApplicationArgs{
+		Name:                  "hosted-mysql",
+		SourceModel:           s.otherState.ModelTag(),
+		SourceApplicationName: "mysql",
+		OfferName:             "hosted-mysql",
+		Endpoints: []charm.Relation{{
+			Name:      "server",
+			Role:      charm.RoleProvider,
+			Interface: "mysql",
+			Scope:     charm.ScopeGlobal,
+		}},
+	}, "wordpress")
+	s.counterpart = s.addRemoteRelation(c, s.otherState, state.AddRemoteApplicationArgs{
+		Name:                  "remote-wordpress",
+		SourceModel:           s.State.ModelTag(),
+		SourceApplicationName: "wordpress",
+		OfferName:             "hosted-mysql",
+		IsConsumerProxy:       true,
+		Endpoints: []charm.Relation{{
+			Name:      "db",
+			Role:      charm.RoleRequirer,
+			Interface: "mysql",
+			Scope:     charm.ScopeGlobal,
+		}},
+	}, "mysql")
+}
+
+func (s *remoteRelationsSuite) addRemoteRelation(
+	c *gc.C, st *state.State, args state.AddRemoteApplicationArgs, localApplication string,
+) *state.Relation {
+	_, err := st.AddRemoteApplication(args)
+	c.Assert(err, jc.ErrorIsNil)
+	eps, err := st.InferEndpoints(localApplication, args.Name)
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := st.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	return rel
+}
+
+func (s *remoteRelationsSuite) relationChange() params.RemoteRelationChange {
+	return params.RemoteRelationChange{
+		Relation: params.RemoteRelation{
+			Id:                    s.relation.Id(),
+			Key:                   s.relation.String(),
+			Life:                  params.Alive,
+			ApplicationName:       "wordpress",
+			Endpoint:              "db",
+			RemoteApplicationName: "hosted-mysql",
+			RemoteEndpoint:        "server",
+		},
+		RemoteApplication: params.RemoteApplication{
+			Name:                  "hosted-mysql",
+			SourceModelTag:        s.otherState.ModelTag().String(),
+			SourceApplicationName: "mysql",
+			Life:                  params.Alive,
+		},
+	}
+}
+
+func (s *remoteRelationsSuite) TestNewRemoteRelationsAPIRequiresModelManager(c *gc.C) {
+	s.authorizer.EnvironManager = false
+	_, err := remoterelations.NewRemoteRelationsAPI(s.State, s.resources, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *remoteRelationsSuite) TestWatchRemoteApplications(c *gc.C) {
+	result, err := s.api.WatchRemoteApplications()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.StringsWatcherId, gc.Equals, "1")
+	c.Assert(result.Changes, jc.DeepEquals, []string{"hosted-mysql"})
+
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+	wc := statetesting.NewStringsWatcherC(c, s.State, resource.(state.StringsWatcher))
+	wc.AssertNoChange()
+}
+
+func (s *remoteRelationsSuite) TestRemoteApplications(c *gc.C) {
+	result, err := s.api.RemoteApplications(params.Entities{Entities: []params.Entity{
+		{Tag: "application-hosted-mysql"},
+		{Tag: "application-wordpress"},
+		{Tag: "unit-wordpress-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.RemoteApplicationResults{
+		Results: []params.RemoteApplicationResult{{
+			Result: &params.RemoteApplication{
+				Name:                  "hosted-mysql",
+				SourceModelTag:        s.otherState.ModelTag().String(),
+				SourceApplicationName: "mysql",
+				Life:                  params.Alive,
+			},
+		}, {
+			Error: apiservertesting.NotFoundError(`remote application "wordpress"`),
+		}, {
+			Error: apiservertesting.ErrUnauthorized,
+		}},
+	})
+}
+
+func (s *remoteRelationsSuite) TestRelations(c *gc.C) {
+	result, err := s.api.Relations(params.Entities{Entities: []params.Entity{
+		{Tag: s.relation.Tag().String()},
+		{Tag: "application-wordpress"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.RemoteRelationResults{
+		Results: []params.RemoteRelationResult{{
+			Result: &params.RemoteRelation{
+				Id:                    s.relation.Id(),
+				Key:                   "wordpress:db hosted-mysql:server",
+				Life:                  params.Alive,
+				ApplicationName:       "wordpress",
+				Endpoint:              "db",
+				RemoteApplicationName: "hosted-mysql",
+				RemoteEndpoint:        "server",
+			},
+		}, {
+			Error: apiservertesting.ErrUnauthorized,
+		}},
+	})
+}
+
+// addUnitInScope adds a unit of wordpress with the given public
+// address, and enters it into the scope of the relation.
+func (s *remoteRelationsSuite) addUnitInScope(c *gc.C, address string) *state.RelationUnit {
+	unit, err := s.wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AssignUnit(unit, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	machineId, err := unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.Machine(machineId)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetProviderAddresses(network.NewScopedAddress(address, network.ScopePublic))
+	c.Assert(err, jc.ErrorIsNil)
+	ru, err := s.relation.Unit(unit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ru.EnterScope(map[string]interface{}{"database": "wordpress"})
+	c.Assert(err, jc.ErrorIsNil)
+	return ru
+}
+
+func (s *remoteRelationsSuite) TestPublishLocalRelationChanges(c *gc.C) {
+	ru := s.addUnitInScope(c, "8.8.8.8")
+
+	change := s.relationChange()
+	change.ChangedUnits = []string{"wordpress/0"}
+	result, err := s.api.PublishLocalRelationChanges(params.RemoteRelationChanges{
+		Changes: []params.RemoteRelationChange{change},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+
+	// The unit is represented by a unit of the proxy application
+	// in the other model, and the offered application admits it.
+	remoteRU, err := s.counterpart.RemoteUnit("remote-wordpress/0")
+	c.Assert(err, jc.ErrorIsNil)
+	inScope, err := remoteRU.InScope()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(inScope, jc.IsTrue)
+	settings, err := remoteRU.Settings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings.Map(), jc.DeepEquals, map[string]interface{}{"database": "wordpress"})
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.RelationIngressCIDRs(), jc.DeepEquals, []string{"8.8.8.8/32"})
+
+	// Settings changes replace the published settings.
+	localSettings, err := ru.Settings()
+	c.Assert(err, jc.ErrorIsNil)
+	localSettings.Delete("database")
+	localSettings.Set("user", "admin")
+	_, err = localSettings.Write()
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = s.api.PublishLocalRelationChanges(params.RemoteRelationChanges{
+		Changes: []params.RemoteRelationChange{change},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+	settings, err = remoteRU.Settings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings.Map(), jc.DeepEquals, map[string]interface{}{"user": "admin"})
+
+	change.ChangedUnits = nil
+	change.DepartedUnits = []string{"wordpress/0"}
+	result, err = s.api.PublishLocalRelationChanges(params.RemoteRelationChanges{
+		Changes: []params.RemoteRelationChange{change},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+	inScope, err = remoteRU.InScope()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(inScope, jc.IsFalse)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.RelationIngressCIDRs(), gc.HasLen, 0)
+}
+
+func (s *remoteRelationsSuite) TestPublishLocalRelationDying(c *gc.C) {
+	change := s.relationChange()
+	change.Relation.Life = params.Dying
+	result, err := s.api.PublishLocalRelationChanges(params.RemoteRelationChanges{
+		Changes: []params.RemoteRelationChange{change},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+	err = s.counterpart.Refresh()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// Publishing again, once the counterpart has gone, is not an error.
+	result, err = s.api.PublishLocalRelationChanges(params.RemoteRelationChanges{
+		Changes: []params.RemoteRelationChange{change},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+}
+
+func (s *remoteRelationsSuite) TestPublishLocalRelationDeadDepartsUnits(c *gc.C) {
+	s.addUnitInScope(c, "8.8.8.8")
+	s.addUnitInScope(c, "8.8.4.4")
+	change := s.relationChange()
+	change.ChangedUnits = []string{"wordpress/0", "wordpress/1"}
+	result, err := s.api.PublishLocalRelationChanges(params.RemoteRelationChanges{
+		Changes: []params.RemoteRelationChange{change},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.RelationIngressCIDRs(), jc.DeepEquals, []string{"8.8.4.4/32", "8.8.8.8/32"})
+
+	// The units published as being in scope are departed when the
+	// relation dies, without being named in the change.
+	change.ChangedUnits = nil
+	change.Relation.Life = params.Dead
+	result, err = s.api.PublishLocalRelationChanges(params.RemoteRelationChanges{
+		Changes: []params.RemoteRelationChange{change},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+	err = s.counterpart.Refresh()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.RelationIngressCIDRs(), gc.HasLen, 0)
+}
+
+func (s *remoteRelationsSuite) TestPublishLocalRelationChangeIgnoresSuppliedSource(c *gc.C) {
+	// Only the name of the remote application is trusted; the
+	// counterpart is found in the model actually hosting it.
+	change := s.relationChange()
+	change.RemoteApplication.SourceModelTag = s.State.ModelTag().String()
+	change.RemoteApplication.SourceApplicationName = "wordpress"
+	change.Relation.Life = params.Dying
+	result, err := s.api.PublishLocalRelationChanges(params.RemoteRelationChanges{
+		Changes: []params.RemoteRelationChange{change},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+	err = s.counterpart.Refresh()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	err = s.relation.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *remoteRelationsSuite) TestPublishLocalRelationChangeUnknownRemoteApplication(c *gc.C) {
+	change := s.relationChange()
+	change.RemoteApplication.Name = "hosted-postgresql"
+	change.ChangedUnits = []string{"wordpress/0"}
+	result, err := s.api.PublishLocalRelationChanges(params.RemoteRelationChanges{
+		Changes: []params.RemoteRelationChange{change},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, `remote application "hosted-postgresql" not found`)
+
+	change.RemoteApplication.Name = "hosted-mysql"
+	change.Relation.RemoteApplicationName = "hosted-postgresql"
+	result, err = s.api.PublishLocalRelationChanges(params.RemoteRelationChanges{
+		Changes: []params.RemoteRelationChange{change},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, `relation ".*" with remote application "hosted-mysql" not valid`)
+}
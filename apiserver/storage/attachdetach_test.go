@@ -0,0 +1,156 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+type attachDetachSuite struct {
+	baseStorageSuite
+}
+
+var _ = gc.Suite(&attachDetachSuite{})
+
+func (s *attachDetachSuite) TestAttach(c *gc.C) {
+	var attached []string
+	s.state.attachStorage = func(storage names.StorageTag, unit names.UnitTag) error {
+		s.calls = append(s.calls, attachStorageCall)
+		if storage != s.storageTag {
+			return errors.NotFoundf("storage %s", storage.Id())
+		}
+		attached = append(attached, storage.Id()+":"+unit.Id())
+		return nil
+	}
+	results, err := s.api.Attach(params.StorageAttachmentIds{
+		Ids: []params.StorageAttachmentId{
+			{StorageTag: s.storageTag.String(), UnitTag: "unit-mysql-1"},
+			{StorageTag: "storage-foo-0", UnitTag: "unit-mysql-1"},
+			{StorageTag: s.storageTag.String(), UnitTag: "machine-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: "storage foo/0 not found", Code: params.CodeNotFound}},
+			{Error: &params.Error{Message: `"machine-0" is not a valid unit tag`}},
+		},
+	})
+	c.Assert(attached, jc.DeepEquals, []string{"data/0:mysql/1"})
+	s.assertCalls(c, []string{
+		getBlockForTypeCall,
+		attachStorageCall,
+		attachStorageCall,
+	})
+}
+
+func (s *attachDetachSuite) TestAttachBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestAttachBlocked")
+	_, err := s.api.Attach(params.StorageAttachmentIds{
+		Ids: []params.StorageAttachmentId{{StorageTag: s.storageTag.String(), UnitTag: "unit-mysql-1"}},
+	})
+	s.assertBlocked(c, err, "TestAttachBlocked")
+}
+
+func (s *attachDetachSuite) TestDetach(c *gc.C) {
+	var detached []string
+	s.state.detachStorage = func(storage names.StorageTag, unit names.UnitTag) error {
+		s.calls = append(s.calls, detachStorageCall)
+		detached = append(detached, storage.Id()+":"+unit.Id())
+		return nil
+	}
+	results, err := s.api.Detach(params.Entities{
+		Entities: []params.Entity{
+			{Tag: s.storageTag.String()},
+			{Tag: "storage-foo-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: "storage foo/0 not found", Code: params.CodeNotFound}},
+		},
+	})
+	c.Assert(detached, jc.DeepEquals, []string{"data/0:mysql/0"})
+	s.assertCalls(c, []string{
+		getBlockForTypeCall,
+		storageInstanceCall,
+		detachStorageCall,
+		storageInstanceCall,
+	})
+}
+
+func (s *attachDetachSuite) TestDetachUnowned(c *gc.C) {
+	s.storageInstance.owner = nil
+	results, err := s.api.Detach(params.Entities{
+		Entities: []params.Entity{{Tag: s.storageTag.String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{Error: &params.Error{Message: "storage data/0 is not attached to a unit"}},
+		},
+	})
+}
+
+func (s *attachDetachSuite) TestDetachShared(c *gc.C) {
+	s.storageInstance.owner = names.NewApplicationTag("mysql")
+	results, err := s.api.Detach(params.Entities{
+		Entities: []params.Entity{{Tag: s.storageTag.String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{Error: &params.Error{
+			Message: "detaching storage data/0, which is shared by application mysql, not supported",
+			Code:    params.CodeNotSupported,
+		}}},
+	})
+}
+
+func (s *attachDetachSuite) TestDetachBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestDetachBlocked")
+	_, err := s.api.Detach(params.Entities{
+		Entities: []params.Entity{{Tag: s.storageTag.String()}},
+	})
+	s.assertBlocked(c, err, "TestDetachBlocked")
+}
+
+func (s *attachDetachSuite) TestRemove(c *gc.C) {
+	results, err := s.api.Remove(params.RemoveStorage{
+		Storage: []params.RemoveStorageInstance{
+			{Tag: s.storageTag.String()},
+			{Tag: "storage-foo-0", Release: true},
+			{Tag: "unit-mysql-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{},
+			{Error: &params.Error{Message: `"unit-mysql-0" is not a valid storage tag`}},
+		},
+	})
+	s.assertCalls(c, []string{
+		getBlockForTypeCall,
+		getBlockForTypeCall,
+		destroyStorageInstanceCall,
+		releaseStorageInstanceCall,
+	})
+}
+
+func (s *attachDetachSuite) TestRemoveBlocked(c *gc.C) {
+	s.blockRemoveObject(c, "TestRemoveBlocked")
+	_, err := s.api.Remove(params.RemoveStorage{
+		Storage: []params.RemoveStorageInstance{{Tag: s.storageTag.String()}},
+	})
+	s.assertBlocked(c, err, "TestRemoveBlocked")
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/state"
 	jujustorage "github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/poolmanager"
 	coretesting "github.com/juju/juju/testing"
 )
 
@@ -84,6 +85,16 @@ const (
 	addStorageForUnitCall                   = "addStorageForUnit"
 	getBlockForTypeCall                     = "getBlockForType"
 	volumeAttachmentCall                    = "volumeAttachment"
+	addVolumeSnapshotCall                   = "addVolumeSnapshot"
+	allVolumeSnapshotsCall                  = "allVolumeSnapshots"
+	volumeSnapshotCall                      = "volumeSnapshot"
+	destroyVolumeSnapshotCall               = "destroyVolumeSnapshot"
+	resizeStorageInstanceCall               = "resizeStorageInstance"
+	attachStorageCall                       = "attachStorage"
+	detachStorageCall                       = "detachStorage"
+	destroyStorageInstanceCall              = "destroyStorageInstance"
+	releaseStorageInstanceCall              = "releaseStorageInstance"
+	addExistingFilesystemCall               = "addExistingFilesystem"
 )
 
 func (s *baseStorageSuite) constructState() *mockState {
@@ -222,6 +233,48 @@ func (s *baseStorageSuite) constructState() *mockState {
 			s.calls = append(s.calls, addStorageForUnitCall)
 			return nil
 		},
+		addVolumeSnapshot: func(v names.VolumeTag) (state.VolumeSnapshot, error) {
+			s.calls = append(s.calls, addVolumeSnapshotCall)
+			return &mockVolumeSnapshot{id: v.Id() + ":0", volume: v}, nil
+		},
+		allVolumeSnapshots: func() ([]state.VolumeSnapshot, error) {
+			s.calls = append(s.calls, allVolumeSnapshotsCall)
+			return []state.VolumeSnapshot{
+				&mockVolumeSnapshot{id: "22:0", volume: s.volumeTag},
+				&mockVolumeSnapshot{id: "99:0", volume: names.NewVolumeTag("99")},
+			}, nil
+		},
+		volumeSnapshot: func(id string) (state.VolumeSnapshot, error) {
+			s.calls = append(s.calls, volumeSnapshotCall)
+			if id == "22:0" {
+				return &mockVolumeSnapshot{id: id, volume: s.volumeTag}, nil
+			}
+			return nil, errors.NotFoundf("volume snapshot %q", id)
+		},
+		destroyVolumeSnapshot: func(string) error {
+			s.calls = append(s.calls, destroyVolumeSnapshotCall)
+			return nil
+		},
+		resizeStorageInstance: func(tag names.StorageTag, size uint64) error {
+			s.calls = append(s.calls, resizeStorageInstanceCall)
+			return nil
+		},
+		attachStorage: func(names.StorageTag, names.UnitTag) error {
+			s.calls = append(s.calls, attachStorageCall)
+			return nil
+		},
+		detachStorage: func(names.StorageTag, names.UnitTag) error {
+			s.calls = append(s.calls, detachStorageCall)
+			return nil
+		},
+		destroyStorageInstance: func(names.StorageTag) error {
+			s.calls = append(s.calls, destroyStorageInstanceCall)
+			return nil
+		},
+		releaseStorageInstance: func(names.StorageTag) error {
+			s.calls = append(s.calls, releaseStorageInstanceCall)
+			return nil
+		},
 		getBlockForType: func(t state.BlockType) (state.Block, bool, error) {
 			s.calls = append(s.calls, getBlockForTypeCall)
 			val, found := s.blocks[t]
@@ -280,5 +333,26 @@ func (s *baseStorageSuite) constructPoolManager() *mockPoolManager {
 			}
 			return result, nil
 		},
+		updatePool: func(name string, attrs map[string]interface{}, validate poolmanager.ValidateUpdateFunc) (*jujustorage.Config, error) {
+			existing, ok := s.pools[name]
+			if !ok {
+				return nil, errors.NotFoundf("mock pool manager: update pool %v", name)
+			}
+			if validate != nil {
+				if err := validate(existing, attrs); err != nil {
+					return nil, err
+				}
+			}
+			merged := make(map[string]interface{})
+			for k, v := range existing.Attrs() {
+				merged[k] = v
+			}
+			for k, v := range attrs {
+				merged[k] = v
+			}
+			pool, err := jujustorage.NewConfig(name, existing.Provider(), merged)
+			s.pools[name] = pool
+			return pool, err
+		},
 	}
 }
@@ -136,6 +136,38 @@ func (s *filesystemSuite) TestListFilesystemsAttachmentInfo(c *gc.C) {
 	c.Assert(found.Results[0].Result[0], jc.DeepEquals, expected)
 }
 
+func (s *filesystemSuite) TestListFilesystemsAttachmentUsage(c *gc.C) {
+	s.filesystemAttachment.info = &state.FilesystemAttachmentInfo{
+		MountPoint: "/tmp",
+	}
+	s.filesystemAttachment.usage = &state.FilesystemUsage{
+		UsedBytes:  1024,
+		FreeBytes:  3072,
+		UsedInodes: 10,
+		FreeInodes: 90,
+	}
+	expected := s.expectedFilesystemDetails()
+	expected.MachineAttachments[s.machineTag.String()] = params.FilesystemAttachmentInfo{
+		MountPoint: "/tmp",
+		Usage: &params.FilesystemUsage{
+			UsedBytes:  1024,
+			FreeBytes:  3072,
+			UsedInodes: 10,
+			FreeInodes: 90,
+		},
+	}
+	expectedStorageAttachmentDetails := expected.Storage.Attachments["unit-mysql-0"]
+	expectedStorageAttachmentDetails.Location = "/tmp"
+	expected.Storage.Attachments["unit-mysql-0"] = expectedStorageAttachmentDetails
+	found, err := s.api.ListFilesystems(params.FilesystemFilters{
+		[]params.FilesystemFilter{{}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found.Results, gc.HasLen, 1)
+	c.Assert(found.Results[0].Result, gc.HasLen, 1)
+	c.Assert(found.Results[0].Result[0], jc.DeepEquals, expected)
+}
+
 func (s *filesystemSuite) TestListFilesystemsVolumeBacked(c *gc.C) {
 	s.filesystem.volume = &s.volumeTag
 	expected := s.expectedFilesystemDetails()
@@ -0,0 +1,188 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/state"
+	jujustorage "github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider/dummy"
+	"github.com/juju/juju/storage/provider/registry"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type importSuite struct {
+	baseStorageSuite
+	modelConfig  *config.Config
+	volumeSource *dummy.VolumeSource
+}
+
+var _ = gc.Suite(&importSuite{})
+
+func (s *importSuite) SetUpTest(c *gc.C) {
+	s.baseStorageSuite.SetUpTest(c)
+	s.modelConfig = coretesting.ModelConfig(c)
+	s.state.modelConfig = func() (*config.Config, error) {
+		return s.modelConfig, nil
+	}
+	s.state.controllerConfig = func() (controller.Config, error) {
+		return coretesting.FakeControllerConfig(), nil
+	}
+
+	s.volumeSource = &dummy.VolumeSource{}
+	s.registerProvider(c, "importable", &dummy.StorageProvider{
+		StorageScope: jujustorage.ScopeEnviron,
+		VolumeSourceFunc: func(*config.Config, *jujustorage.Config) (jujustorage.VolumeSource, error) {
+			return s.volumeSource, nil
+		},
+		SupportsFunc: func(kind jujustorage.StorageKind) bool {
+			return kind == jujustorage.StorageKindBlock
+		},
+	})
+	s.registerProvider(c, "machine-importable", &dummy.StorageProvider{
+		StorageScope: jujustorage.ScopeMachine,
+	})
+}
+
+func (s *importSuite) registerProvider(c *gc.C, providerType jujustorage.ProviderType, p jujustorage.Provider) {
+	registry.RegisterProvider(providerType, p)
+	s.AddCleanup(func(*gc.C) {
+		registry.RegisterProvider(providerType, nil)
+	})
+}
+
+func (s *importSuite) TestImportFilesystem(c *gc.C) {
+	s.volumeSource.ImportVolumeFunc = func(volumeId string, resourceTags map[string]string) (jujustorage.VolumeInfo, error) {
+		return jujustorage.VolumeInfo{
+			VolumeId:   volumeId,
+			Size:       1024,
+			Persistent: true,
+		}, nil
+	}
+	s.state.addExistingFilesystem = func(
+		info state.FilesystemInfo,
+		backingVolume *state.VolumeInfo,
+		storageName string,
+	) (names.StorageTag, error) {
+		s.calls = append(s.calls, addExistingFilesystemCall)
+		c.Assert(info, jc.DeepEquals, state.FilesystemInfo{Pool: "importable"})
+		c.Assert(backingVolume, jc.DeepEquals, &state.VolumeInfo{
+			VolumeId:   "vol-123",
+			Size:       1024,
+			Pool:       "importable",
+			Persistent: true,
+		})
+		c.Assert(storageName, gc.Equals, "data")
+		return names.NewStorageTag("data/0"), nil
+	}
+
+	results, err := s.api.ImportFilesystem(params.ImportFilesystemsParams{
+		Filesystems: []params.ImportFilesystemParams{{
+			Pool:        "importable",
+			ProviderId:  "vol-123",
+			StorageName: "data",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ImportStorageResults{
+		Results: []params.ImportStorageResult{{
+			Result: &params.ImportStorageDetails{StorageTag: "storage-data-0"},
+		}},
+	})
+	s.assertCalls(c, []string{getBlockForTypeCall, addExistingFilesystemCall})
+
+	// The volume is validated first, and tagged
+	// only once it has been added to the model.
+	s.volumeSource.CheckCalls(c, []testing.StubCall{
+		{"ImportVolume", []interface{}{"vol-123", map[string]string(nil)}},
+		{"ImportVolume", []interface{}{"vol-123", map[string]string{
+			tags.JujuModel:      s.modelConfig.UUID(),
+			tags.JujuController: coretesting.ModelTag.Id(),
+		}}},
+	})
+}
+
+func (s *importSuite) TestImportFilesystemAddFails(c *gc.C) {
+	s.volumeSource.ImportVolumeFunc = func(volumeId string, resourceTags map[string]string) (jujustorage.VolumeInfo, error) {
+		return jujustorage.VolumeInfo{VolumeId: volumeId, Size: 1024}, nil
+	}
+	s.state.addExistingFilesystem = func(
+		info state.FilesystemInfo,
+		backingVolume *state.VolumeInfo,
+		storageName string,
+	) (names.StorageTag, error) {
+		s.calls = append(s.calls, addExistingFilesystemCall)
+		return names.StorageTag{}, errors.AlreadyExistsf(`volume "vol-123" in the model`)
+	}
+
+	results, err := s.api.ImportFilesystem(params.ImportFilesystemsParams{
+		Filesystems: []params.ImportFilesystemParams{{
+			Pool:        "importable",
+			ProviderId:  "vol-123",
+			StorageName: "data",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `volume "vol-123" in the model already exists`)
+
+	// The volume must not be tagged for the model,
+	// as it was not added to the model.
+	s.volumeSource.CheckCalls(c, []testing.StubCall{
+		{"ImportVolume", []interface{}{"vol-123", map[string]string(nil)}},
+	})
+}
+
+func (s *importSuite) TestImportFilesystemErrors(c *gc.C) {
+	s.volumeSource.ImportVolumeFunc = func(volumeId string, resourceTags map[string]string) (jujustorage.VolumeInfo, error) {
+		return jujustorage.VolumeInfo{}, errors.NotFoundf("volume %q", volumeId)
+	}
+	results, err := s.api.ImportFilesystem(params.ImportFilesystemsParams{
+		Filesystems: []params.ImportFilesystemParams{{
+			Pool:        "importable",
+			ProviderId:  "vol-123",
+			StorageName: "0data",
+		}, {
+			Pool:        "importable",
+			ProviderId:  "vol-123",
+			StorageName: "data",
+		}, {
+			Pool:        "machine-importable",
+			ProviderId:  "vol-123",
+			StorageName: "data",
+		}, {
+			Pool:        "nonexistent",
+			ProviderId:  "vol-123",
+			StorageName: "data",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 4)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `storage name "0data" not valid`)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `importing volume: volume "vol-123" not found`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `importing storage from machine-scoped "machine-importable" provider not supported`)
+	c.Assert(results.Results[3].Error, gc.ErrorMatches, `mock pool manager: get pool nonexistent not found`)
+	s.assertCalls(c, []string{getBlockForTypeCall})
+}
+
+func (s *importSuite) TestImportFilesystemBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestImportFilesystemBlocked")
+	_, err := s.api.ImportFilesystem(params.ImportFilesystemsParams{
+		Filesystems: []params.ImportFilesystemParams{{
+			Pool:        "importable",
+			ProviderId:  "vol-123",
+			StorageName: "data",
+		}},
+	})
+	s.assertBlocked(c, err, "TestImportFilesystemBlocked")
+}
@@ -4,13 +4,18 @@
 package storage_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
 	jujustorage "github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/poolmanager"
 )
 
 type mockPoolManager struct {
@@ -18,6 +23,7 @@ type mockPoolManager struct {
 	createPool func(name string, providerType jujustorage.ProviderType, attrs map[string]interface{}) (*jujustorage.Config, error)
 	deletePool func(name string) error
 	listPools  func() ([]*jujustorage.Config, error)
+	updatePool func(name string, attrs map[string]interface{}, validate poolmanager.ValidateUpdateFunc) (*jujustorage.Config, error)
 }
 
 func (m *mockPoolManager) Get(name string) (*jujustorage.Config, error) {
@@ -36,6 +42,10 @@ func (m *mockPoolManager) List() ([]*jujustorage.Config, error) {
 	return m.listPools()
 }
 
+func (m *mockPoolManager) Update(name string, attrs map[string]interface{}, validate poolmanager.ValidateUpdateFunc) (*jujustorage.Config, error) {
+	return m.updatePool(name, attrs, validate)
+}
+
 type mockState struct {
 	storageInstance                     func(names.StorageTag) (state.StorageInstance, error)
 	allStorageInstances                 func() ([]state.StorageInstance, error)
@@ -48,6 +58,8 @@ type mockState struct {
 	watchStorageAttachment              func(names.StorageTag, names.UnitTag) state.NotifyWatcher
 	watchFilesystemAttachment           func(names.MachineTag, names.FilesystemTag) state.NotifyWatcher
 	watchVolumeAttachment               func(names.MachineTag, names.VolumeTag) state.NotifyWatcher
+	watchFilesystem                     func(names.FilesystemTag) state.NotifyWatcher
+	watchVolume                         func(names.VolumeTag) state.NotifyWatcher
 	watchBlockDevices                   func(names.MachineTag) state.NotifyWatcher
 	modelName                           string
 	volume                              func(tag names.VolumeTag) (state.Volume, error)
@@ -59,6 +71,22 @@ type mockState struct {
 	filesystemAttachments               func(filesystem names.FilesystemTag) ([]state.FilesystemAttachment, error)
 	allFilesystems                      func() ([]state.Filesystem, error)
 	addStorageForUnit                   func(u names.UnitTag, name string, cons state.StorageConstraints) error
+	addVolumeSnapshot                   func(names.VolumeTag) (state.VolumeSnapshot, error)
+	allVolumeSnapshots                  func() ([]state.VolumeSnapshot, error)
+	volumeSnapshot                      func(string) (state.VolumeSnapshot, error)
+	destroyVolumeSnapshot               func(string) error
+	resizeStorageInstance               func(names.StorageTag, uint64) error
+	attachStorage                       func(names.StorageTag, names.UnitTag) error
+	detachStorage                       func(names.StorageTag, names.UnitTag) error
+	destroyStorageInstance              func(names.StorageTag) error
+	releaseStorageInstance              func(names.StorageTag) error
+	addExistingFilesystem               func(state.FilesystemInfo, *state.VolumeInfo, string) (names.StorageTag, error)
+	modelConfig                         func() (*config.Config, error)
+	controllerConfig                    func() (controller.Config, error)
+	modelStorageQuota                   func() (state.StorageQuota, error)
+	storageUsage                        func(string) (map[string]state.StorageUsage, error)
+	applicationStorageQuota             func(string) (state.StorageQuota, error)
+	setApplicationStorageQuota          func(string, state.StorageQuota) error
 	getBlockForType                     func(t state.BlockType) (state.Block, bool, error)
 	blockDevices                        func(names.MachineTag) ([]state.BlockDeviceInfo, error)
 }
@@ -107,6 +135,14 @@ func (st *mockState) WatchVolumeAttachment(mtag names.MachineTag, v names.Volume
 	return st.watchVolumeAttachment(mtag, v)
 }
 
+func (st *mockState) WatchFilesystem(f names.FilesystemTag) state.NotifyWatcher {
+	return st.watchFilesystem(f)
+}
+
+func (st *mockState) WatchVolume(v names.VolumeTag) state.NotifyWatcher {
+	return st.watchVolume(v)
+}
+
 func (st *mockState) WatchBlockDevices(mtag names.MachineTag) state.NotifyWatcher {
 	return st.watchBlockDevices(mtag)
 }
@@ -151,6 +187,74 @@ func (st *mockState) AddStorageForUnit(u names.UnitTag, name string, cons state.
 	return st.addStorageForUnit(u, name, cons)
 }
 
+func (st *mockState) AddVolumeSnapshot(v names.VolumeTag) (state.VolumeSnapshot, error) {
+	return st.addVolumeSnapshot(v)
+}
+
+func (st *mockState) AllVolumeSnapshots() ([]state.VolumeSnapshot, error) {
+	return st.allVolumeSnapshots()
+}
+
+func (st *mockState) VolumeSnapshot(id string) (state.VolumeSnapshot, error) {
+	return st.volumeSnapshot(id)
+}
+
+func (st *mockState) DestroyVolumeSnapshot(id string) error {
+	return st.destroyVolumeSnapshot(id)
+}
+
+func (st *mockState) ResizeStorageInstance(tag names.StorageTag, size uint64) error {
+	return st.resizeStorageInstance(tag, size)
+}
+
+func (st *mockState) AttachStorage(storage names.StorageTag, unit names.UnitTag) error {
+	return st.attachStorage(storage, unit)
+}
+
+func (st *mockState) DetachStorage(storage names.StorageTag, unit names.UnitTag) error {
+	return st.detachStorage(storage, unit)
+}
+
+func (st *mockState) DestroyStorageInstance(tag names.StorageTag) error {
+	return st.destroyStorageInstance(tag)
+}
+
+func (st *mockState) ReleaseStorageInstance(tag names.StorageTag) error {
+	return st.releaseStorageInstance(tag)
+}
+
+func (st *mockState) AddExistingFilesystem(
+	info state.FilesystemInfo,
+	backingVolume *state.VolumeInfo,
+	storageName string,
+) (names.StorageTag, error) {
+	return st.addExistingFilesystem(info, backingVolume, storageName)
+}
+
+func (st *mockState) ModelConfig() (*config.Config, error) {
+	return st.modelConfig()
+}
+
+func (st *mockState) ControllerConfig() (controller.Config, error) {
+	return st.controllerConfig()
+}
+
+func (st *mockState) ModelStorageQuota() (state.StorageQuota, error) {
+	return st.modelStorageQuota()
+}
+
+func (st *mockState) StorageUsage(applicationName string) (map[string]state.StorageUsage, error) {
+	return st.storageUsage(applicationName)
+}
+
+func (st *mockState) ApplicationStorageQuota(applicationName string) (state.StorageQuota, error) {
+	return st.applicationStorageQuota(applicationName)
+}
+
+func (st *mockState) SetApplicationStorageQuota(applicationName string, quota state.StorageQuota) error {
+	return st.setApplicationStorageQuota(applicationName, quota)
+}
+
 func (st *mockState) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
 	return st.getBlockForType(t)
 }
@@ -249,6 +353,7 @@ type mockFilesystemAttachment struct {
 	filesystem names.FilesystemTag
 	machine    names.MachineTag
 	info       *state.FilesystemAttachmentInfo
+	usage      *state.FilesystemUsage
 }
 
 func (m *mockFilesystemAttachment) Filesystem() names.FilesystemTag {
@@ -266,6 +371,13 @@ func (m *mockFilesystemAttachment) Info() (state.FilesystemAttachmentInfo, error
 	return state.FilesystemAttachmentInfo{}, errors.NotProvisionedf("filesystem attachment")
 }
 
+func (m *mockFilesystemAttachment) Usage() (state.FilesystemUsage, bool) {
+	if m.usage != nil {
+		return *m.usage, true
+	}
+	return state.FilesystemUsage{}, false
+}
+
 type mockStorageInstance struct {
 	state.StorageInstance
 	kind       state.StorageKind
@@ -277,8 +389,8 @@ func (m *mockStorageInstance) Kind() state.StorageKind {
 	return m.kind
 }
 
-func (m *mockStorageInstance) Owner() names.Tag {
-	return m.owner
+func (m *mockStorageInstance) Owner() (names.Tag, bool) {
+	return m.owner, m.owner != nil
 }
 
 func (m *mockStorageInstance) Tag() names.Tag {
@@ -303,7 +415,37 @@ func (m *mockStorageAttachment) StorageInstance() names.StorageTag {
 }
 
 func (m *mockStorageAttachment) Unit() names.UnitTag {
-	return m.storage.Owner().(names.UnitTag)
+	return m.storage.owner.(names.UnitTag)
+}
+
+type mockVolumeSnapshot struct {
+	state.VolumeSnapshot
+	id     string
+	volume names.VolumeTag
+}
+
+func (m *mockVolumeSnapshot) Id() string {
+	return m.id
+}
+
+func (m *mockVolumeSnapshot) Volume() names.VolumeTag {
+	return m.volume
+}
+
+func (m *mockVolumeSnapshot) Pool() string {
+	return "loop"
+}
+
+func (m *mockVolumeSnapshot) Life() state.Life {
+	return state.Alive
+}
+
+func (m *mockVolumeSnapshot) Created() time.Time {
+	return time.Time{}
+}
+
+func (m *mockVolumeSnapshot) Info() (state.VolumeSnapshotInfo, error) {
+	return state.VolumeSnapshotInfo{}, errors.NotProvisionedf("volume snapshot %q", m.id)
 }
 
 type mockVolumeAttachment struct {
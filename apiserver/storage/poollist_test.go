@@ -12,6 +12,7 @@ import (
 
 	"github.com/juju/juju/apiserver/params"
 	apiserverstorage "github.com/juju/juju/apiserver/storage"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/storage/provider"
 	"github.com/juju/juju/storage/provider/registry"
@@ -55,7 +56,7 @@ func (s *poolSuite) TestListManyResults(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	assertPoolNames(c, results.Results[0].Result,
 		"testpool0", "testpool1",
-		"dummy", "loop",
+		"dummy", "hostdir", "loop", "lvm", "lxd",
 		"tmpfs", "rootfs")
 }
 
@@ -165,7 +166,40 @@ func (s *poolSuite) TestListNoPools(c *gc.C) {
 	results, err := s.api.ListPools(params.StoragePoolFilters{[]params.StoragePoolFilter{{}}})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results.Results, gc.HasLen, 1)
-	assertPoolNames(c, results.Results[0].Result, "dummy", "rootfs", "loop", "tmpfs")
+	assertPoolNames(c, results.Results[0].Result, "dummy", "hostdir", "rootfs", "loop", "lvm", "lxd", "tmpfs")
+}
+
+func (s *poolSuite) TestListUsage(c *gc.C) {
+	s.createPools(c, 1)
+	s.filesystem.info = &state.FilesystemInfo{Pool: "testpool0", Size: 512}
+	results, err := s.api.ListPools(params.StoragePoolFilters{[]params.StoragePoolFilter{{
+		Names: []string{"testpool0", "loop", "tmpfs"},
+		Usage: true,
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	usage := make(map[string]params.StoragePoolUsage)
+	for _, pool := range results.Results[0].Result {
+		c.Assert(pool.Usage, gc.NotNil)
+		usage[pool.Name] = *pool.Usage
+	}
+	c.Assert(usage, jc.DeepEquals, map[string]params.StoragePoolUsage{
+		"testpool0": {Filesystems: 1, Size: 512},
+		"loop":      {Volumes: 1, Size: 1024},
+		"tmpfs":     {},
+	})
+	s.assertCalls(c, []string{allVolumesCall, allFilesystemsCall})
+}
+
+func (s *poolSuite) TestListNoUsage(c *gc.C) {
+	s.createPools(c, 1)
+	results, err := s.api.ListPools(params.StoragePoolFilters{[]params.StoragePoolFilter{{}}})
+	c.Assert(err, jc.ErrorIsNil)
+	for _, pool := range results.Results[0].Result {
+		c.Assert(pool.Usage, gc.IsNil)
+	}
+	s.assertCalls(c, []string{})
 }
 
 func (s *poolSuite) TestListFilterEmpty(c *gc.C) {
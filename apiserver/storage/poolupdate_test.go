@@ -0,0 +1,128 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	jujustorage "github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/poolmanager"
+	"github.com/juju/juju/storage/provider"
+)
+
+type poolUpdateSuite struct {
+	baseStorageSuite
+}
+
+var _ = gc.Suite(&poolUpdateSuite{})
+
+func (s *poolUpdateSuite) SetUpTest(c *gc.C) {
+	s.baseStorageSuite.SetUpTest(c)
+	var err error
+	s.pools["vg-pool"], err = jujustorage.NewConfig(
+		"vg-pool", provider.LVMProviderType, map[string]interface{}{
+			provider.LVMVolumeGroup: "vg0",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	s.pools["loop-pool"], err = jujustorage.NewConfig(
+		"loop-pool", provider.LoopProviderType, map[string]interface{}{
+			"foo": "bar",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	s.filesystem.info = &state.FilesystemInfo{Pool: "rootfs"}
+}
+
+func (s *poolUpdateSuite) TestUpdatePool(c *gc.C) {
+	err := s.api.UpdatePool(params.StoragePool{
+		Name:  "loop-pool",
+		Attrs: map[string]interface{}{"baz": "qux"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.pools["loop-pool"].Attrs(), jc.DeepEquals, map[string]interface{}{
+		"foo": "bar",
+		"baz": "qux",
+	})
+}
+
+func (s *poolUpdateSuite) TestUpdatePoolNotFound(c *gc.C) {
+	err := s.api.UpdatePool(params.StoragePool{Name: "nonexistent"})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *poolUpdateSuite) TestUpdatePoolChangeProvider(c *gc.C) {
+	err := s.api.UpdatePool(params.StoragePool{
+		Name:     "loop-pool",
+		Provider: string(provider.TmpfsProviderType),
+	})
+	c.Assert(err, gc.ErrorMatches, `changing provider of pool "loop-pool" from "loop" to "tmpfs" not supported`)
+}
+
+func (s *poolUpdateSuite) TestUpdatePoolImmutableAttributeNotInUse(c *gc.C) {
+	err := s.api.UpdatePool(params.StoragePool{
+		Name:  "vg-pool",
+		Attrs: map[string]interface{}{provider.LVMVolumeGroup: "vg1"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.pools["vg-pool"].Attrs(), jc.DeepEquals, map[string]interface{}{
+		provider.LVMVolumeGroup: "vg1",
+	})
+	s.assertCalls(c, []string{getBlockForTypeCall, allVolumesCall, allFilesystemsCall})
+}
+
+func (s *poolUpdateSuite) TestUpdatePoolImmutableAttributeInUse(c *gc.C) {
+	s.volume.info = &state.VolumeInfo{Pool: "vg-pool", Size: 1024}
+	err := s.api.UpdatePool(params.StoragePool{
+		Name:  "vg-pool",
+		Attrs: map[string]interface{}{provider.LVMVolumeGroup: "vg1"},
+	})
+	c.Assert(err, gc.ErrorMatches, `changing "volume-group" attribute of pool "vg-pool" in use not supported`)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(s.pools["vg-pool"].Attrs(), jc.DeepEquals, map[string]interface{}{
+		provider.LVMVolumeGroup: "vg0",
+	})
+}
+
+func (s *poolUpdateSuite) TestUpdatePoolImmutableAttributeUnchangedInUse(c *gc.C) {
+	s.volume.info = &state.VolumeInfo{Pool: "vg-pool", Size: 1024}
+	err := s.api.UpdatePool(params.StoragePool{
+		Name:  "vg-pool",
+		Attrs: map[string]interface{}{provider.LVMVolumeGroup: "vg0"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *poolUpdateSuite) TestUpdatePoolAttributeInUseDefaultImmutable(c *gc.C) {
+	// The loop provider does not identify its immutable attributes,
+	// so none of them may be changed while the pool is in use.
+	s.volume.info = &state.VolumeInfo{Pool: "loop-pool", Size: 1024}
+	err := s.api.UpdatePool(params.StoragePool{
+		Name:  "loop-pool",
+		Attrs: map[string]interface{}{"baz": "qux", "foo": "baz"},
+	})
+	c.Assert(err, gc.ErrorMatches, `changing "baz" attribute of pool "loop-pool" in use not supported`)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(s.pools["loop-pool"].Attrs(), jc.DeepEquals, map[string]interface{}{
+		"foo": "bar",
+	})
+}
+
+func (s *poolUpdateSuite) TestUpdatePoolError(c *gc.C) {
+	s.poolManager.updatePool = func(string, map[string]interface{}, poolmanager.ValidateUpdateFunc) (*jujustorage.Config, error) {
+		return nil, errors.New("as expected")
+	}
+	err := s.api.UpdatePool(params.StoragePool{Name: "loop-pool"})
+	c.Assert(err, gc.ErrorMatches, "as expected")
+}
+
+func (s *poolUpdateSuite) TestUpdatePoolBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestUpdatePoolBlocked")
+	err := s.api.UpdatePool(params.StoragePool{Name: "loop-pool"})
+	s.assertBlocked(c, err, "TestUpdatePoolBlocked")
+}
@@ -0,0 +1,116 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+type quotaSuite struct {
+	baseStorageSuite
+	applicationQuotas map[string]state.StorageQuota
+}
+
+var _ = gc.Suite(&quotaSuite{})
+
+func (s *quotaSuite) SetUpTest(c *gc.C) {
+	s.baseStorageSuite.SetUpTest(c)
+	s.applicationQuotas = map[string]state.StorageQuota{
+		"mysql": {Count: 4},
+	}
+	s.state.modelStorageQuota = func() (state.StorageQuota, error) {
+		return state.StorageQuota{Size: 10240, Count: 10}, nil
+	}
+	s.state.storageUsage = func(applicationName string) (map[string]state.StorageUsage, error) {
+		if applicationName == "" {
+			return map[string]state.StorageUsage{
+				"loop": {Size: 1024, Count: 1},
+				"ebs":  {Size: 4096, Count: 2},
+			}, nil
+		}
+		return map[string]state.StorageUsage{
+			"ebs": {Size: 2048, Count: 1},
+		}, nil
+	}
+	s.state.applicationStorageQuota = func(applicationName string) (state.StorageQuota, error) {
+		quota, ok := s.applicationQuotas[applicationName]
+		if !ok {
+			return state.StorageQuota{}, errors.NotFoundf("application %q", applicationName)
+		}
+		return quota, nil
+	}
+	s.state.setApplicationStorageQuota = func(applicationName string, quota state.StorageQuota) error {
+		if _, ok := s.applicationQuotas[applicationName]; !ok {
+			return errors.NotFoundf("application %q", applicationName)
+		}
+		s.applicationQuotas[applicationName] = quota
+		return nil
+	}
+}
+
+func (s *quotaSuite) TestStorageQuotas(c *gc.C) {
+	results, err := s.api.StorageQuotas(params.StorageQuotaFilters{
+		Filters: []params.StorageQuotaFilter{
+			{},
+			{ApplicationTag: "application-mysql"},
+			{ApplicationTag: "application-wordpress"},
+			{ApplicationTag: "unit-mysql-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 4)
+	c.Assert(results.Results[0], jc.DeepEquals, params.StorageQuotaResult{
+		Result: &params.StorageQuotaDetails{
+			Quota: params.StorageQuota{Size: 10240, Count: 10},
+			Usage: []params.StorageQuotaUsage{
+				{Pool: "ebs", Size: 4096, Count: 2},
+				{Pool: "loop", Size: 1024, Count: 1},
+			},
+		},
+	})
+	c.Assert(results.Results[1], jc.DeepEquals, params.StorageQuotaResult{
+		Result: &params.StorageQuotaDetails{
+			Quota: params.StorageQuota{Count: 4},
+			Usage: []params.StorageQuotaUsage{
+				{Pool: "ebs", Size: 2048, Count: 1},
+			},
+		},
+	})
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `application "wordpress" not found`)
+	c.Assert(results.Results[3].Error, gc.ErrorMatches, `"unit-mysql-0" is not a valid application tag`)
+}
+
+func (s *quotaSuite) TestSetApplicationStorageQuotas(c *gc.C) {
+	results, err := s.api.SetApplicationStorageQuotas(params.ApplicationStorageQuotas{
+		Quotas: []params.ApplicationStorageQuota{{
+			ApplicationTag: "application-mysql",
+			Quota:          params.StorageQuota{Size: 2048},
+		}, {
+			ApplicationTag: "application-wordpress",
+			Quota:          params.StorageQuota{Size: 2048},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `application "wordpress" not found`)
+	c.Assert(s.applicationQuotas["mysql"], gc.Equals, state.StorageQuota{Size: 2048})
+}
+
+func (s *quotaSuite) TestSetApplicationStorageQuotasBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestSetApplicationStorageQuotasBlocked")
+	_, err := s.api.SetApplicationStorageQuotas(params.ApplicationStorageQuotas{
+		Quotas: []params.ApplicationStorageQuota{{
+			ApplicationTag: "application-mysql",
+			Quota:          params.StorageQuota{Size: 2048},
+		}},
+	})
+	s.assertBlocked(c, err, "TestSetApplicationStorageQuotasBlocked")
+	c.Assert(s.applicationQuotas["mysql"], gc.Equals, state.StorageQuota{Count: 4})
+}
@@ -0,0 +1,60 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+type resizeSuite struct {
+	baseStorageSuite
+}
+
+var _ = gc.Suite(&resizeSuite{})
+
+func (s *resizeSuite) TestResizeStorage(c *gc.C) {
+	var sizes []uint64
+	s.state.resizeStorageInstance = func(tag names.StorageTag, size uint64) error {
+		s.calls = append(s.calls, resizeStorageInstanceCall)
+		if tag.Id() != s.storageTag.Id() {
+			return errors.NotFoundf("storage %s", tag.Id())
+		}
+		sizes = append(sizes, size)
+		return nil
+	}
+	results, err := s.api.ResizeStorage(params.StoragesResizeParams{
+		Storages: []params.StorageResizeParams{
+			{StorageTag: s.storageTag.String(), Size: 2048},
+			{StorageTag: "storage-foo-0", Size: 1024},
+			{StorageTag: "unit-mysql-0", Size: 1024},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: "storage foo/0 not found", Code: params.CodeNotFound}},
+			{Error: &params.Error{Message: `"unit-mysql-0" is not a valid storage tag`}},
+		},
+	})
+	c.Assert(sizes, jc.DeepEquals, []uint64{2048})
+	s.assertCalls(c, []string{
+		getBlockForTypeCall,
+		resizeStorageInstanceCall,
+		resizeStorageInstanceCall,
+	})
+}
+
+func (s *resizeSuite) TestResizeStorageBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestResizeStorageBlocked")
+	_, err := s.api.ResizeStorage(params.StoragesResizeParams{
+		Storages: []params.StorageResizeParams{{StorageTag: s.storageTag.String(), Size: 2048}},
+	})
+	s.assertBlocked(c, err, "TestResizeStorageBlocked")
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 )
 
@@ -44,6 +46,12 @@ type storageAccess interface {
 	// WatchVolumeAttachment is required for storage functionality.
 	WatchVolumeAttachment(names.MachineTag, names.VolumeTag) state.NotifyWatcher
 
+	// WatchFilesystem is required for storage functionality.
+	WatchFilesystem(names.FilesystemTag) state.NotifyWatcher
+
+	// WatchVolume is required for storage functionality.
+	WatchVolume(names.VolumeTag) state.NotifyWatcher
+
 	// WatchBlockDevices is required for storage functionality.
 	WatchBlockDevices(names.MachineTag) state.NotifyWatcher
 
@@ -80,6 +88,54 @@ type storageAccess interface {
 	// AddStorageForUnit is required for storage add functionality.
 	AddStorageForUnit(tag names.UnitTag, name string, cons state.StorageConstraints) error
 
+	// AddVolumeSnapshot is required for volume snapshot functionality.
+	AddVolumeSnapshot(volume names.VolumeTag) (state.VolumeSnapshot, error)
+
+	// AllVolumeSnapshots is required for volume snapshot functionality.
+	AllVolumeSnapshots() ([]state.VolumeSnapshot, error)
+
+	// VolumeSnapshot is required for volume snapshot functionality.
+	VolumeSnapshot(id string) (state.VolumeSnapshot, error)
+
+	// DestroyVolumeSnapshot is required for volume snapshot functionality.
+	DestroyVolumeSnapshot(id string) error
+
+	// ResizeStorageInstance is required for storage resize functionality.
+	ResizeStorageInstance(tag names.StorageTag, size uint64) error
+
+	// AttachStorage is required for storage attach functionality.
+	AttachStorage(names.StorageTag, names.UnitTag) error
+
+	// DetachStorage is required for storage detach functionality.
+	DetachStorage(names.StorageTag, names.UnitTag) error
+
+	// DestroyStorageInstance is required for storage remove functionality.
+	DestroyStorageInstance(names.StorageTag) error
+
+	// ReleaseStorageInstance is required for storage remove functionality.
+	ReleaseStorageInstance(names.StorageTag) error
+
+	// AddExistingFilesystem is required for storage import functionality.
+	AddExistingFilesystem(state.FilesystemInfo, *state.VolumeInfo, string) (names.StorageTag, error)
+
+	// ModelConfig is required for storage import functionality.
+	ModelConfig() (*config.Config, error)
+
+	// ControllerConfig is required for storage import functionality.
+	ControllerConfig() (controller.Config, error)
+
+	// ModelStorageQuota is required for storage quota functionality.
+	ModelStorageQuota() (state.StorageQuota, error)
+
+	// StorageUsage is required for storage quota functionality.
+	StorageUsage(applicationName string) (map[string]state.StorageUsage, error)
+
+	// ApplicationStorageQuota is required for storage quota functionality.
+	ApplicationStorageQuota(applicationName string) (state.StorageQuota, error)
+
+	// SetApplicationStorageQuota is required for storage quota functionality.
+	SetApplicationStorageQuota(applicationName string, quota state.StorageQuota) error
+
 	// GetBlockForType is required to block operations.
 	GetBlockForType(t state.BlockType) (state.Block, bool, error)
 }
@@ -116,3 +172,23 @@ func (s stateShim) ModelName() (string, error) {
 	}
 	return cfg.Name(), nil
 }
+
+// ApplicationStorageQuota returns the storage quota of the named
+// application.
+func (s stateShim) ApplicationStorageQuota(applicationName string) (state.StorageQuota, error) {
+	app, err := s.Application(applicationName)
+	if err != nil {
+		return state.StorageQuota{}, errors.Trace(err)
+	}
+	return app.StorageQuota(), nil
+}
+
+// SetApplicationStorageQuota sets the storage quota of the named
+// application.
+func (s stateShim) SetApplicationStorageQuota(applicationName string, quota state.StorageQuota) error {
+	app, err := s.Application(applicationName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return app.SetStorageQuota(quota)
+}
@@ -6,6 +6,9 @@
 package storage
 
 import (
+	"reflect"
+	"sort"
+
 	"github.com/juju/errors"
 	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
@@ -13,6 +16,8 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/common/storagecommon"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/storage"
@@ -138,10 +143,19 @@ func createStorageDetails(st storageAccess, si state.StorageInstance) (*params.S
 		// e.g. CephFS, we'll need to do set "persistent"
 		// here too.
 		filesystem, err := st.StorageInstanceFilesystem(si.StorageTag())
-		if err != nil {
+		if errors.IsNotFound(err) {
+			// Filesystem storage imported with a backing volume
+			// has no filesystem until it is first attached.
+			volume, err := st.StorageInstanceVolume(si.StorageTag())
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			statusEntity = volume
+		} else if err != nil {
 			return nil, errors.Trace(err)
+		} else {
+			statusEntity = filesystem
 		}
-		statusEntity = filesystem
 	} else {
 		volume, err := st.StorageInstanceVolume(si.StorageTag())
 		if err != nil {
@@ -180,9 +194,13 @@ func createStorageDetails(st storageAccess, si state.StorageInstance) (*params.S
 		}
 	}
 
+	var ownerTag string
+	if owner, ok := si.Owner(); ok {
+		ownerTag = owner.String()
+	}
 	return &params.StorageDetails{
 		StorageTag:  si.Tag().String(),
-		OwnerTag:    si.Owner().String(),
+		OwnerTag:    ownerTag,
 		Kind:        params.StorageKind(si.Kind()),
 		Status:      common.EntityStatusFromState(status),
 		Persistent:  persistent,
@@ -248,9 +266,77 @@ func (a *API) listPools(filter params.StoragePoolFilter) ([]params.StoragePool,
 		filterPools(pools, matches),
 		filterProviders(providers, matches)...,
 	)
+	if filter.Usage {
+		usage, err := a.poolUsage()
+		if err != nil {
+			return nil, errors.Annotate(err, "getting pool usage")
+		}
+		for i := range results {
+			poolUsage := usage[results[i].Name]
+			results[i].Usage = &poolUsage
+		}
+	}
 	return results, nil
 }
 
+// poolUsage returns the number of volumes and filesystems, and the
+// total size of storage, served by each pool, keyed by pool name.
+// Pools that are serving no storage are omitted.
+func (a *API) poolUsage() (map[string]params.StoragePoolUsage, error) {
+	usage := make(map[string]params.StoragePoolUsage)
+	volumes, err := a.storage.AllVolumes()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, v := range volumes {
+		pool, size := volumePoolAndSize(v)
+		poolUsage := usage[pool]
+		poolUsage.Volumes++
+		poolUsage.Size += size
+		usage[pool] = poolUsage
+	}
+	filesystems, err := a.storage.AllFilesystems()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, f := range filesystems {
+		pool, size := filesystemPoolAndSize(f)
+		poolUsage := usage[pool]
+		poolUsage.Filesystems++
+		// Filesystems backed by volumes are already accounted
+		// for in the size of the volume.
+		if _, err := f.Volume(); err == state.ErrNoBackingVolume {
+			poolUsage.Size += size
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		usage[pool] = poolUsage
+	}
+	return usage, nil
+}
+
+// volumePoolAndSize returns the pool and size of the volume, taken
+// from the volume's info if it has been provisioned, or from its
+// parameters otherwise.
+func volumePoolAndSize(v state.Volume) (string, uint64) {
+	if info, err := v.Info(); err == nil {
+		return info.Pool, info.Size
+	}
+	volumeParams, _ := v.Params()
+	return volumeParams.Pool, volumeParams.Size
+}
+
+// filesystemPoolAndSize returns the pool and size of the filesystem,
+// taken from the filesystem's info if it has been provisioned, or from
+// its parameters otherwise.
+func filesystemPoolAndSize(f state.Filesystem) (string, uint64) {
+	if info, err := f.Info(); err == nil {
+		return info.Pool, info.Size
+	}
+	filesystemParams, _ := f.Params()
+	return filesystemParams.Pool, filesystemParams.Size
+}
+
 func buildFilter(filter params.StoragePoolFilter) func(n, p string) bool {
 	providerSet := set.NewStrings(filter.Providers...)
 	nameSet := set.NewStrings(filter.Names...)
@@ -359,6 +445,64 @@ func (a *API) CreatePool(p params.StoragePool) error {
 	return err
 }
 
+// UpdatePool updates the attributes of an existing pool. The pool's
+// provider may not be changed, and attributes that may affect existing
+// storage may not be changed while the pool is serving storage. Unless
+// the provider identifies its immutable attributes, all of them are
+// assumed to affect existing storage.
+func (a *API) UpdatePool(p params.StoragePool) error {
+	if err := common.NewBlockChecker(a.storage).ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	validate := func(existing *storage.Config, attrs map[string]interface{}) error {
+		return a.validatePoolUpdate(existing, p)
+	}
+	_, err := a.poolManager.Update(p.Name, p.Attrs, validate)
+	return errors.Trace(err)
+}
+
+// validatePoolUpdate returns an error if the update described by p
+// may not be applied to the existing pool.
+func (a *API) validatePoolUpdate(existing *storage.Config, p params.StoragePool) error {
+	if p.Provider != "" && storage.ProviderType(p.Provider) != existing.Provider() {
+		return errors.NotSupportedf(
+			"changing provider of pool %q from %q to %q",
+			p.Name, existing.Provider(), p.Provider,
+		)
+	}
+	usage, err := a.poolUsage()
+	if err != nil {
+		return errors.Annotate(err, "getting pool usage")
+	}
+	if poolUsage := usage[p.Name]; poolUsage.Volumes+poolUsage.Filesystems == 0 {
+		return nil
+	}
+	provider, err := registry.StorageProvider(existing.Provider())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	isImmutable := func(string) bool { return true }
+	if immutable, ok := provider.(storage.ImmutableConfigProvider); ok {
+		isImmutable = set.NewStrings(immutable.ImmutableConfigAttributes()...).Contains
+	}
+	existingAttrs := existing.Attrs()
+	attrNames := make([]string, 0, len(p.Attrs))
+	for attr := range p.Attrs {
+		attrNames = append(attrNames, attr)
+	}
+	sort.Strings(attrNames)
+	for _, attr := range attrNames {
+		value := p.Attrs[attr]
+		if !isImmutable(attr) || reflect.DeepEqual(value, existingAttrs[attr]) {
+			continue
+		}
+		return errors.NotSupportedf(
+			"changing %q attribute of pool %q in use", attr, p.Name,
+		)
+	}
+	return nil
+}
+
 // ListVolumes lists volumes with the given filters. Each filter produces
 // an independent list of volumes, or an error if the filter is invalid
 // or the volumes could not be listed.
@@ -626,6 +770,9 @@ func createFilesystemDetails(
 			if err == nil {
 				info = storagecommon.FilesystemAttachmentInfoFromState(stateInfo)
 			}
+			if usage, ok := attachment.Usage(); ok {
+				info.Usage = storagecommon.FilesystemUsageFromState(usage)
+			}
 			details.MachineAttachments[attachment.Machine().String()] = info
 		}
 	}
@@ -686,10 +833,476 @@ func (a *API) AddToUnit(args params.StoragesAddParams) (params.ErrorResults, err
 			continue
 		}
 
-		err = a.storage.AddStorageForUnit(u, one.StorageName, paramsToState(one.Constraints))
+		cons := paramsToState(one.Constraints)
+		cons.Snapshot = one.Snapshot
+		err = a.storage.AddStorageForUnit(u, one.StorageName, cons)
 		if err != nil {
 			result[i] = params.ErrorResult{Error: common.ServerError(err)}
 		}
 	}
 	return params.ErrorResults{Results: result}, nil
 }
+
+// CreateVolumeSnapshots requests snapshots of the volumes backing the
+// specified storage instances. The snapshots are taken asynchronously
+// by the storage provisioner responsible for each volume.
+func (a *API) CreateVolumeSnapshots(args params.Entities) (params.VolumeSnapshotDetailsResults, error) {
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.VolumeSnapshotDetailsResults{}, errors.Trace(err)
+	}
+	results := params.VolumeSnapshotDetailsResults{
+		Results: make([]params.VolumeSnapshotDetailsResult, len(args.Entities)),
+	}
+	one := func(arg params.Entity) (*params.VolumeSnapshotDetails, error) {
+		storageTag, err := names.ParseStorageTag(arg.Tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		volume, err := a.storage.StorageInstanceVolume(storageTag)
+		if errors.IsNotFound(err) {
+			return nil, errors.NotSupportedf(
+				"snapshotting %s, which is not backed by a volume,",
+				names.ReadableString(storageTag),
+			)
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		snapshot, err := a.storage.AddVolumeSnapshot(volume.VolumeTag())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		details := createVolumeSnapshotDetails(snapshot)
+		details.StorageTag = storageTag.String()
+		return details, nil
+	}
+	for i, arg := range args.Entities {
+		details, err := one(arg)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = details
+	}
+	return results, nil
+}
+
+// ListVolumeSnapshots returns the details of all volume snapshots in
+// the model. The storage instance is included for snapshots of volumes
+// that still exist and are assigned to storage.
+func (a *API) ListVolumeSnapshots() (params.VolumeSnapshotDetailsResults, error) {
+	snapshots, err := a.storage.AllVolumeSnapshots()
+	if err != nil {
+		return params.VolumeSnapshotDetailsResults{}, common.ServerError(err)
+	}
+	results := params.VolumeSnapshotDetailsResults{
+		Results: make([]params.VolumeSnapshotDetailsResult, len(snapshots)),
+	}
+	for i, snapshot := range snapshots {
+		details := createVolumeSnapshotDetails(snapshot)
+		volume, err := a.storage.Volume(snapshot.Volume())
+		if err == nil {
+			if storageTag, err := volume.StorageInstance(); err == nil {
+				details.StorageTag = storageTag.String()
+			}
+		} else if !errors.IsNotFound(err) {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = details
+	}
+	return results, nil
+}
+
+// DestroyVolumeSnapshots marks the specified volume snapshots as Dying.
+// The snapshots are deleted asynchronously by the storage provisioner
+// responsible for each volume, after which they are removed from state.
+func (a *API) DestroyVolumeSnapshots(args params.VolumeSnapshotIds) (params.ErrorResults, error) {
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.RemoveAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Ids)),
+	}
+	one := func(id string) error {
+		if !state.IsValidVolumeSnapshotId(id) {
+			return errors.NotValidf("volume snapshot ID %q", id)
+		}
+		if _, err := a.storage.VolumeSnapshot(id); err != nil {
+			return errors.Trace(err)
+		}
+		return a.storage.DestroyVolumeSnapshot(id)
+	}
+	for i, id := range args.Ids {
+		if err := one(id); err != nil {
+			results.Results[i].Error = common.ServerError(err)
+		}
+	}
+	return results, nil
+}
+
+func createVolumeSnapshotDetails(snapshot state.VolumeSnapshot) *params.VolumeSnapshotDetails {
+	details := &params.VolumeSnapshotDetails{
+		Id:        snapshot.Id(),
+		VolumeTag: snapshot.Volume().String(),
+		Pool:      snapshot.Pool(),
+		Life:      params.Life(snapshot.Life().String()),
+		Created:   snapshot.Created(),
+	}
+	if info, err := snapshot.Info(); err == nil {
+		details.SnapshotId = info.SnapshotId
+		details.Size = info.Size
+	}
+	return details
+}
+
+// ResizeStorage requests that storage instances be grown to new sizes.
+// The storage is resized asynchronously by the responsible storage
+// provisioner, after which the units to which the storage is attached
+// are notified via the storage-resized hook.
+func (a *API) ResizeStorage(args params.StoragesResizeParams) (params.ErrorResults, error) {
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Storages)),
+	}
+	for i, arg := range args.Storages {
+		storageTag, err := names.ParseStorageTag(arg.StorageTag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		err = a.storage.ResizeStorageInstance(storageTag, arg.Size)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// Attach attaches existing, detached storage instances to units.
+// The storage-attached hook will fire on each unit once the storage
+// has been attached to the unit's machine.
+func (a *API) Attach(args params.StorageAttachmentIds) (params.ErrorResults, error) {
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Ids)),
+	}
+	one := func(arg params.StorageAttachmentId) error {
+		storageTag, err := names.ParseStorageTag(arg.StorageTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		unitTag, err := names.ParseUnitTag(arg.UnitTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return a.storage.AttachStorage(storageTag, unitTag)
+	}
+	for i, arg := range args.Ids {
+		results.Results[i].Error = common.ServerError(one(arg))
+	}
+	return results, nil
+}
+
+// Detach detaches storage instances from the units that own them,
+// leaving the storage in the model so that it may later be attached
+// to another unit. The storage-detaching hook will fire on each unit.
+func (a *API) Detach(args params.Entities) (params.ErrorResults, error) {
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	one := func(arg params.Entity) error {
+		storageTag, err := names.ParseStorageTag(arg.Tag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		storageInstance, err := a.storage.StorageInstance(storageTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		owner, ok := storageInstance.Owner()
+		if !ok {
+			return errors.Errorf(
+				"%s is not attached to a unit",
+				names.ReadableString(storageTag),
+			)
+		}
+		unitTag, ok := owner.(names.UnitTag)
+		if !ok {
+			return errors.NotSupportedf(
+				"detaching %s, which is shared by %s,",
+				names.ReadableString(storageTag),
+				names.ReadableString(owner),
+			)
+		}
+		return a.storage.DetachStorage(storageTag, unitTag)
+	}
+	for i, arg := range args.Entities {
+		results.Results[i].Error = common.ServerError(one(arg))
+	}
+	return results, nil
+}
+
+// Remove removes storage instances from the model. By default the
+// cloud storage backing each storage instance is destroyed; if Release
+// is specified, the cloud storage is instead released from the model
+// and left intact.
+func (a *API) Remove(args params.RemoveStorage) (params.ErrorResults, error) {
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.RemoveAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Storage)),
+	}
+	for i, arg := range args.Storage {
+		storageTag, err := names.ParseStorageTag(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		if arg.Release {
+			err = a.storage.ReleaseStorageInstance(storageTag)
+		} else {
+			err = a.storage.DestroyStorageInstance(storageTag)
+		}
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// ImportFilesystem imports existing filesystems into the model. Each
+// filesystem, or the volume backing it, is validated and tagged by the
+// storage provider, and a new storage instance is created for it that
+// may subsequently be attached to a unit.
+func (a *API) ImportFilesystem(args params.ImportFilesystemsParams) (params.ImportStorageResults, error) {
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ImportStorageResults{}, errors.Trace(err)
+	}
+	results := params.ImportStorageResults{
+		Results: make([]params.ImportStorageResult, len(args.Filesystems)),
+	}
+	for i, arg := range args.Filesystems {
+		details, err := a.importFilesystem(arg)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = details
+	}
+	return results, nil
+}
+
+func (a *API) importFilesystem(arg params.ImportFilesystemParams) (*params.ImportStorageDetails, error) {
+	if !names.IsValidStorageName(arg.StorageName) {
+		return nil, errors.NotValidf("storage name %q", arg.StorageName)
+	}
+	providerType, cfg, err := storagecommon.StoragePoolConfig(arg.Pool, a.poolManager)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if cfg.Name() == "" {
+		// The pool name is a provider type; use
+		// the provider's default configuration.
+		cfg, err = storage.NewConfig(arg.Pool, providerType, map[string]interface{}{})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	provider, err := registry.StorageProvider(providerType)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if provider.Scope() == storage.ScopeMachine {
+		return nil, errors.NotSupportedf(
+			"importing storage from machine-scoped %q provider", providerType,
+		)
+	}
+	modelConfig, err := a.storage.ModelConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	resourceTags, err := a.importResourceTags(modelConfig)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// The cloud storage is validated first, and only tagged for the
+	// model once it has been recorded in state; otherwise storage
+	// that failed to be imported would be destroyed with the model.
+	var importStorage func(resourceTags map[string]string) error
+	var filesystemInfo state.FilesystemInfo
+	var volumeInfo *state.VolumeInfo
+	if provider.Supports(storage.StorageKindFilesystem) {
+		source, err := provider.FilesystemSource(modelConfig, cfg)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		importer, ok := source.(storage.FilesystemImporter)
+		if !ok {
+			return nil, errors.NotSupportedf(
+				"importing filesystem with storage provider %q", providerType,
+			)
+		}
+		importStorage = func(resourceTags map[string]string) error {
+			info, err := importer.ImportFilesystem(arg.ProviderId, resourceTags)
+			if err != nil {
+				return errors.Annotate(err, "importing filesystem")
+			}
+			filesystemInfo = state.FilesystemInfo{
+				Size:         info.Size,
+				Pool:         arg.Pool,
+				FilesystemId: info.FilesystemId,
+			}
+			return nil
+		}
+	} else {
+		source, err := provider.VolumeSource(modelConfig, cfg)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		importer, ok := source.(storage.VolumeImporter)
+		if !ok {
+			return nil, errors.NotSupportedf(
+				"importing volume with storage provider %q", providerType,
+			)
+		}
+		importStorage = func(resourceTags map[string]string) error {
+			info, err := importer.ImportVolume(arg.ProviderId, resourceTags)
+			if err != nil {
+				return errors.Annotate(err, "importing volume")
+			}
+			filesystemInfo = state.FilesystemInfo{Pool: arg.Pool}
+			volumeInfo = &state.VolumeInfo{
+				HardwareId: info.HardwareId,
+				Size:       info.Size,
+				Pool:       arg.Pool,
+				VolumeId:   info.VolumeId,
+				Persistent: info.Persistent,
+			}
+			return nil
+		}
+	}
+	if err := importStorage(nil); err != nil {
+		return nil, errors.Trace(err)
+	}
+	storageTag, err := a.storage.AddExistingFilesystem(filesystemInfo, volumeInfo, arg.StorageName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := importStorage(resourceTags); err != nil {
+		return nil, errors.Annotatef(err, "tagging imported storage %s", storageTag.Id())
+	}
+	return &params.ImportStorageDetails{
+		StorageTag: storageTag.String(),
+	}, nil
+}
+
+// importResourceTags returns the tags to set on imported cloud
+// storage, identifying it as belonging to the model.
+func (a *API) importResourceTags(modelConfig *config.Config) (map[string]string, error) {
+	controllerConfig, err := a.storage.ControllerConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return tags.ResourceTags(
+		names.NewModelTag(modelConfig.UUID()),
+		names.NewModelTag(controllerConfig.ControllerUUID()),
+		modelConfig,
+	), nil
+}
+
+// StorageQuotas returns, for each filter, the storage quota of the model
+// or of an application, along with the usage of each storage pool that
+// is accounted against the quota.
+func (a *API) StorageQuotas(filters params.StorageQuotaFilters) (params.StorageQuotaResults, error) {
+	results := params.StorageQuotaResults{
+		Results: make([]params.StorageQuotaResult, len(filters.Filters)),
+	}
+	for i, filter := range filters.Filters {
+		details, err := a.storageQuota(filter)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = details
+	}
+	return results, nil
+}
+
+func (a *API) storageQuota(filter params.StorageQuotaFilter) (*params.StorageQuotaDetails, error) {
+	var quota state.StorageQuota
+	var applicationName string
+	if filter.ApplicationTag != "" {
+		applicationTag, err := names.ParseApplicationTag(filter.ApplicationTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		applicationName = applicationTag.Id()
+		if quota, err = a.storage.ApplicationStorageQuota(applicationName); err != nil {
+			return nil, errors.Trace(err)
+		}
+	} else {
+		var err error
+		if quota, err = a.storage.ModelStorageQuota(); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	usage, err := a.storage.StorageUsage(applicationName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	pools := make([]string, 0, len(usage))
+	for pool := range usage {
+		pools = append(pools, pool)
+	}
+	sort.Strings(pools)
+	details := &params.StorageQuotaDetails{
+		Quota: params.StorageQuota{
+			Size:  quota.Size,
+			Count: quota.Count,
+		},
+	}
+	for _, pool := range pools {
+		details.Usage = append(details.Usage, params.StorageQuotaUsage{
+			Pool:  pool,
+			Size:  usage[pool].Size,
+			Count: usage[pool].Count,
+		})
+	}
+	return details, nil
+}
+
+// SetApplicationStorageQuotas sets the storage quotas of applications.
+// Storage already added to the applications' units is unaffected.
+func (a *API) SetApplicationStorageQuotas(args params.ApplicationStorageQuotas) (params.ErrorResults, error) {
+	if err := common.NewBlockChecker(a.storage).ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Quotas)),
+	}
+	for i, arg := range args.Quotas {
+		applicationTag, err := names.ParseApplicationTag(arg.ApplicationTag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		err = a.storage.SetApplicationStorageQuota(applicationTag.Id(), state.StorageQuota{
+			Size:  arg.Quota.Size,
+			Count: arg.Quota.Count,
+		})
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
@@ -0,0 +1,130 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+type volumeSnapshotSuite struct {
+	baseStorageSuite
+}
+
+var _ = gc.Suite(&volumeSnapshotSuite{})
+
+func (s *volumeSnapshotSuite) TestCreateVolumeSnapshots(c *gc.C) {
+	results, err := s.api.CreateVolumeSnapshots(params.Entities{
+		Entities: []params.Entity{
+			{Tag: s.storageTag.String()},
+			{Tag: "storage-foo-0"},
+			{Tag: "unit-mysql-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.VolumeSnapshotDetailsResults{
+		Results: []params.VolumeSnapshotDetailsResult{{
+			Result: &params.VolumeSnapshotDetails{
+				Id:         "22:0",
+				VolumeTag:  "volume-22",
+				StorageTag: "storage-data-0",
+				Pool:       "loop",
+				Life:       "alive",
+				Created:    time.Time{},
+			},
+		}, {
+			Error: &params.Error{
+				Message: "snapshotting storage foo/0, which is not backed by a volume, not supported",
+				Code:    params.CodeNotSupported,
+			},
+		}, {
+			Error: &params.Error{Message: `"unit-mysql-0" is not a valid storage tag`},
+		}},
+	})
+	s.assertCalls(c, []string{
+		getBlockForTypeCall,
+		storageInstanceVolumeCall,
+		addVolumeSnapshotCall,
+		storageInstanceVolumeCall,
+	})
+}
+
+func (s *volumeSnapshotSuite) TestCreateVolumeSnapshotsBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestCreateVolumeSnapshotsBlocked")
+	_, err := s.api.CreateVolumeSnapshots(params.Entities{
+		Entities: []params.Entity{{Tag: s.storageTag.String()}},
+	})
+	s.assertBlocked(c, err, "TestCreateVolumeSnapshotsBlocked")
+}
+
+func (s *volumeSnapshotSuite) TestListVolumeSnapshots(c *gc.C) {
+	results, err := s.api.ListVolumeSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.VolumeSnapshotDetailsResults{
+		Results: []params.VolumeSnapshotDetailsResult{{
+			Result: &params.VolumeSnapshotDetails{
+				Id:         "22:0",
+				VolumeTag:  "volume-22",
+				StorageTag: "storage-data-0",
+				Pool:       "loop",
+				Life:       "alive",
+				Created:    time.Time{},
+			},
+		}, {
+			// The volume has been removed, so there is no
+			// storage instance to report.
+			Result: &params.VolumeSnapshotDetails{
+				Id:        "99:0",
+				VolumeTag: "volume-99",
+				Pool:      "loop",
+				Life:      "alive",
+				Created:   time.Time{},
+			},
+		}},
+	})
+	s.assertCalls(c, []string{
+		allVolumeSnapshotsCall,
+		volumeCall,
+		volumeCall,
+	})
+}
+
+func (s *volumeSnapshotSuite) TestDestroyVolumeSnapshots(c *gc.C) {
+	results, err := s.api.DestroyVolumeSnapshots(params.VolumeSnapshotIds{
+		Ids: []string{"22:0", "23:0", "foo"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{
+				Message: `volume snapshot "23:0" not found`,
+				Code:    params.CodeNotFound,
+			}},
+			{Error: &params.Error{
+				Message: `volume snapshot ID "foo" not valid`,
+				Code:    params.CodeNotValid,
+			}},
+		},
+	})
+	s.assertCalls(c, []string{
+		getBlockForTypeCall,
+		getBlockForTypeCall,
+		volumeSnapshotCall,
+		destroyVolumeSnapshotCall,
+		volumeSnapshotCall,
+	})
+}
+
+func (s *volumeSnapshotSuite) TestDestroyVolumeSnapshotsBlocked(c *gc.C) {
+	s.blockRemoveObject(c, "TestDestroyVolumeSnapshotsBlocked")
+	_, err := s.api.DestroyVolumeSnapshots(params.VolumeSnapshotIds{
+		Ids: []string{"22:0"},
+	})
+	s.assertBlocked(c, err, "TestDestroyVolumeSnapshotsBlocked")
+}
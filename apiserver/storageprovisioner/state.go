@@ -31,6 +31,12 @@ type provisionerState interface {
 	WatchMachineVolumes(names.MachineTag) state.StringsWatcher
 	WatchMachineVolumeAttachments(names.MachineTag) state.StringsWatcher
 	WatchVolumeAttachment(names.MachineTag, names.VolumeTag) state.NotifyWatcher
+	WatchModelVolumeSnapshots() state.StringsWatcher
+	WatchMachineVolumeSnapshots(names.MachineTag) state.StringsWatcher
+	WatchModelVolumeResizes() state.StringsWatcher
+	WatchMachineVolumeResizes(names.MachineTag) state.StringsWatcher
+	WatchModelFilesystemResizes() state.StringsWatcher
+	WatchMachineFilesystemResizes(names.MachineTag) state.StringsWatcher
 
 	StorageInstance(names.StorageTag) (state.StorageInstance, error)
 
@@ -40,16 +46,19 @@ type provisionerState interface {
 	Volume(names.VolumeTag) (state.Volume, error)
 	VolumeAttachment(names.MachineTag, names.VolumeTag) (state.VolumeAttachment, error)
 	VolumeAttachments(names.VolumeTag) ([]state.VolumeAttachment, error)
+	VolumeSnapshot(string) (state.VolumeSnapshot, error)
 
 	RemoveFilesystem(names.FilesystemTag) error
 	RemoveFilesystemAttachment(names.MachineTag, names.FilesystemTag) error
 	RemoveVolume(names.VolumeTag) error
 	RemoveVolumeAttachment(names.MachineTag, names.VolumeTag) error
+	RemoveVolumeSnapshot(string) error
 
 	SetFilesystemInfo(names.FilesystemTag, state.FilesystemInfo) error
 	SetFilesystemAttachmentInfo(names.MachineTag, names.FilesystemTag, state.FilesystemAttachmentInfo) error
 	SetVolumeInfo(names.VolumeTag, state.VolumeInfo) error
 	SetVolumeAttachmentInfo(names.MachineTag, names.VolumeTag, state.VolumeAttachmentInfo) error
+	SetVolumeSnapshotInfo(string, state.VolumeSnapshotInfo) error
 }
 
 type stateShim struct {
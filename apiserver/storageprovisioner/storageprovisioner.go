@@ -55,6 +55,7 @@ func NewStorageProvisionerAPI(st *state.State, resources *common.Resources, auth
 	if !authorizer.AuthMachineAgent() {
 		return nil, common.ErrPerm
 	}
+	stateInterface := getState(st)
 	canAccessStorageMachine := func(tag names.MachineTag, allowEnvironManager bool) bool {
 		authEntityTag := authorizer.GetAuthTag()
 		if tag == authEntityTag {
@@ -85,6 +86,24 @@ func NewStorageProvisionerAPI(st *state.State, resources *common.Resources, auth
 			}
 		}, nil
 	}
+	canAccessVolumeBackedFilesystem := func(tag names.FilesystemTag) bool {
+		// Model-scoped filesystems backed by volumes are managed
+		// by the machine that they are attached to, so the agent
+		// of that machine may access them.
+		machineTag, ok := authorizer.GetAuthTag().(names.MachineTag)
+		if !ok {
+			return false
+		}
+		filesystem, err := stateInterface.Filesystem(tag)
+		if err != nil {
+			return false
+		}
+		if _, err := filesystem.Volume(); err != nil {
+			return false
+		}
+		_, err = stateInterface.FilesystemAttachment(machineTag, tag)
+		return err == nil
+	}
 	canAccessStorageEntity := func(tag names.Tag, allowMachines bool) bool {
 		switch tag := tag.(type) {
 		case names.VolumeTag:
@@ -98,7 +117,10 @@ func NewStorageProvisionerAPI(st *state.State, resources *common.Resources, auth
 			if ok {
 				return canAccessStorageMachine(machineTag, false)
 			}
-			return authorizer.AuthModelManager()
+			if authorizer.AuthModelManager() {
+				return true
+			}
+			return canAccessVolumeBackedFilesystem(tag)
 		case names.MachineTag:
 			return allowMachines && canAccessStorageMachine(tag, true)
 		default:
@@ -159,7 +181,6 @@ func NewStorageProvisionerAPI(st *state.State, resources *common.Resources, auth
 			return false
 		}, nil
 	}
-	stateInterface := getState(st)
 	settings := getSettingsManager(st)
 	return &StorageProvisionerAPI{
 		LifeGetter:       common.NewLifeGetter(stateInterface, getLifeAuthFunc),
@@ -309,6 +330,24 @@ func (s *StorageProvisionerAPI) watchStorageEntities(
 	return results, nil
 }
 
+// WatchVolumeSnapshots watches for changes to snapshots of volumes
+// scoped to the entity with the tag passed to NewState.
+func (s *StorageProvisionerAPI) WatchVolumeSnapshots(args params.Entities) (params.StringsWatchResults, error) {
+	return s.watchStorageEntities(args, s.st.WatchModelVolumeSnapshots, s.st.WatchMachineVolumeSnapshots)
+}
+
+// WatchVolumeResizes watches for changes to the requested sizes of
+// volumes scoped to the entity with the tag passed to NewState.
+func (s *StorageProvisionerAPI) WatchVolumeResizes(args params.Entities) (params.StringsWatchResults, error) {
+	return s.watchStorageEntities(args, s.st.WatchModelVolumeResizes, s.st.WatchMachineVolumeResizes)
+}
+
+// WatchFilesystemResizes watches for changes to the requested sizes of
+// filesystems scoped to the entity with the tag passed to NewState.
+func (s *StorageProvisionerAPI) WatchFilesystemResizes(args params.Entities) (params.StringsWatchResults, error) {
+	return s.watchStorageEntities(args, s.st.WatchModelFilesystemResizes, s.st.WatchMachineFilesystemResizes)
+}
+
 // WatchVolumeAttachments watches for changes to volume attachments scoped to
 // the entity with the tag passed to NewState.
 func (s *StorageProvisionerAPI) WatchVolumeAttachments(args params.Entities) (params.MachineStorageIdsWatchResults, error) {
@@ -583,6 +622,13 @@ func (s *StorageProvisionerAPI) VolumeParams(args params.Entities) (params.Volum
 		if err != nil {
 			return params.VolumeParams{}, err
 		}
+		if stateVolumeParams, ok := volume.Params(); ok && stateVolumeParams.Snapshot != "" {
+			snapshotId, err := s.providerSnapshotId(stateVolumeParams.Snapshot)
+			if err != nil {
+				return params.VolumeParams{}, err
+			}
+			volumeParams.SnapshotId = snapshotId
+		}
 		if len(volumeAttachments) == 1 {
 			// There is exactly one attachment to be made, so make
 			// it immediately. Otherwise we will defer attachments
@@ -628,6 +674,20 @@ func (s *StorageProvisionerAPI) VolumeParams(args params.Entities) (params.Volum
 	return results, nil
 }
 
+// providerSnapshotId returns the provider-allocated ID of the volume
+// snapshot with the specified ID.
+func (s *StorageProvisionerAPI) providerSnapshotId(id string) (string, error) {
+	snapshot, err := s.st.VolumeSnapshot(id)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	info, err := snapshot.Info()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return info.SnapshotId, nil
+}
+
 // FilesystemParams returns the parameters for creating the filesystems
 // with the specified tags.
 func (s *StorageProvisionerAPI) FilesystemParams(args params.Entities) (params.FilesystemParamsResults, error) {
@@ -951,6 +1011,18 @@ func (s *StorageProvisionerAPI) SetVolumeInfo(args params.Volumes) (params.Error
 		} else if !canAccessVolume(volumeTag) {
 			return common.ErrPerm
 		}
+		volume, err := s.st.Volume(volumeTag)
+		if errors.IsNotFound(err) {
+			return common.ErrPerm
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if oldInfo, err := volume.Info(); err == nil {
+			// The pool is set by state when the volume is first
+			// provisioned. Subsequent updates, such as those made
+			// after resizing, must not change it.
+			volumeInfo.Pool = oldInfo.Pool
+		}
 		err = s.st.SetVolumeInfo(volumeTag, volumeInfo)
 		if errors.IsNotFound(err) {
 			return common.ErrPerm
@@ -980,6 +1052,18 @@ func (s *StorageProvisionerAPI) SetFilesystemInfo(args params.Filesystems) (para
 		} else if !canAccessFilesystem(filesystemTag) {
 			return common.ErrPerm
 		}
+		filesystem, err := s.st.Filesystem(filesystemTag)
+		if errors.IsNotFound(err) {
+			return common.ErrPerm
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if oldInfo, err := filesystem.Info(); err == nil {
+			// The pool is set by state when the filesystem is first
+			// provisioned. Subsequent updates, such as those made
+			// after resizing, must not change it.
+			filesystemInfo.Pool = oldInfo.Pool
+		}
 		err = s.st.SetFilesystemInfo(filesystemTag, filesystemInfo)
 		if errors.IsNotFound(err) {
 			return common.ErrPerm
@@ -1141,6 +1225,226 @@ func (s *StorageProvisionerAPI) Remove(args params.Entities) (params.ErrorResult
 	return results, nil
 }
 
+// VolumeSnapshotParams returns the parameters for creating or deleting
+// the volume snapshots with the specified IDs. The parameters are taken
+// from the snapshots alone, so that snapshots of volumes that have
+// since been removed can still be deleted.
+func (s *StorageProvisionerAPI) VolumeSnapshotParams(args params.VolumeSnapshotIds) (params.VolumeSnapshotParamsResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.VolumeSnapshotParamsResults{}, err
+	}
+	results := params.VolumeSnapshotParamsResults{
+		Results: make([]params.VolumeSnapshotParamsResult, len(args.Ids)),
+	}
+	poolManager := poolmanager.New(s.settings)
+	one := func(id string) (params.VolumeSnapshotParams, error) {
+		snapshot, err := s.volumeSnapshot(id, canAccess)
+		if err != nil {
+			return params.VolumeSnapshotParams{}, err
+		}
+		providerType, _, err := storagecommon.StoragePoolConfig(snapshot.Pool(), poolManager)
+		if err != nil {
+			return params.VolumeSnapshotParams{}, errors.Trace(err)
+		}
+		result := params.VolumeSnapshotParams{
+			Id:        snapshot.Id(),
+			VolumeTag: snapshot.Volume().String(),
+			VolumeId:  snapshot.ProviderVolumeId(),
+			Provider:  string(providerType),
+			Life:      params.Life(snapshot.Life().String()),
+		}
+		if info, err := snapshot.Info(); err == nil {
+			result.SnapshotId = info.SnapshotId
+		}
+		return result, nil
+	}
+	for i, id := range args.Ids {
+		result, err := one(id)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = result
+	}
+	return results, nil
+}
+
+// VolumeResizeParams returns the parameters for growing the volumes
+// with the specified tags. If a volume has no outstanding resize request,
+// the returned size is zero.
+func (s *StorageProvisionerAPI) VolumeResizeParams(args params.Entities) (params.VolumeResizeParamsResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.VolumeResizeParamsResults{}, err
+	}
+	results := params.VolumeResizeParamsResults{
+		Results: make([]params.VolumeResizeParamsResult, len(args.Entities)),
+	}
+	poolManager := poolmanager.New(s.settings)
+	one := func(arg params.Entity) (params.VolumeResizeParams, error) {
+		tag, err := names.ParseVolumeTag(arg.Tag)
+		if err != nil || !canAccess(tag) {
+			return params.VolumeResizeParams{}, common.ErrPerm
+		}
+		volume, err := s.st.Volume(tag)
+		if errors.IsNotFound(err) {
+			return params.VolumeResizeParams{}, common.ErrPerm
+		} else if err != nil {
+			return params.VolumeResizeParams{}, errors.Trace(err)
+		}
+		volumeInfo, err := volume.Info()
+		if err != nil {
+			return params.VolumeResizeParams{}, errors.Trace(err)
+		}
+		providerType, _, err := storagecommon.StoragePoolConfig(volumeInfo.Pool, poolManager)
+		if err != nil {
+			return params.VolumeResizeParams{}, errors.Trace(err)
+		}
+		result := params.VolumeResizeParams{
+			VolumeTag: tag.String(),
+			VolumeId:  volumeInfo.VolumeId,
+			Provider:  string(providerType),
+		}
+		if size, ok := volume.RequestedSize(); ok {
+			result.Size = size
+		}
+		return result, nil
+	}
+	for i, arg := range args.Entities {
+		result, err := one(arg)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = result
+	}
+	return results, nil
+}
+
+// FilesystemResizeParams returns the parameters for growing the
+// filesystems with the specified tags. If a filesystem has no
+// outstanding resize request, the returned size is zero.
+func (s *StorageProvisionerAPI) FilesystemResizeParams(args params.Entities) (params.FilesystemResizeParamsResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.FilesystemResizeParamsResults{}, err
+	}
+	results := params.FilesystemResizeParamsResults{
+		Results: make([]params.FilesystemResizeParamsResult, len(args.Entities)),
+	}
+	poolManager := poolmanager.New(s.settings)
+	one := func(arg params.Entity) (params.FilesystemResizeParams, error) {
+		tag, err := names.ParseFilesystemTag(arg.Tag)
+		if err != nil || !canAccess(tag) {
+			return params.FilesystemResizeParams{}, common.ErrPerm
+		}
+		filesystem, err := s.st.Filesystem(tag)
+		if errors.IsNotFound(err) {
+			return params.FilesystemResizeParams{}, common.ErrPerm
+		} else if err != nil {
+			return params.FilesystemResizeParams{}, errors.Trace(err)
+		}
+		filesystemInfo, err := filesystem.Info()
+		if err != nil {
+			return params.FilesystemResizeParams{}, errors.Trace(err)
+		}
+		providerType, _, err := storagecommon.StoragePoolConfig(filesystemInfo.Pool, poolManager)
+		if err != nil {
+			return params.FilesystemResizeParams{}, errors.Trace(err)
+		}
+		result := params.FilesystemResizeParams{
+			FilesystemTag: tag.String(),
+			FilesystemId:  filesystemInfo.FilesystemId,
+			Provider:      string(providerType),
+		}
+		if volumeTag, err := filesystem.Volume(); err == nil {
+			result.VolumeTag = volumeTag.String()
+		} else if errors.Cause(err) != state.ErrNoBackingVolume {
+			return params.FilesystemResizeParams{}, errors.Trace(err)
+		}
+		if size, ok := filesystem.RequestedSize(); ok {
+			result.Size = size
+		}
+		return result, nil
+	}
+	for i, arg := range args.Entities {
+		result, err := one(arg)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = result
+	}
+	return results, nil
+}
+
+// SetVolumeSnapshotInfo records the details of newly taken volume
+// snapshots.
+func (s *StorageProvisionerAPI) SetVolumeSnapshotInfo(args params.VolumeSnapshots) (params.ErrorResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.VolumeSnapshots)),
+	}
+	one := func(arg params.VolumeSnapshot) error {
+		if _, err := s.volumeSnapshot(arg.Id, canAccess); err != nil {
+			return err
+		}
+		return s.st.SetVolumeSnapshotInfo(arg.Id, state.VolumeSnapshotInfo{
+			SnapshotId: arg.Info.SnapshotId,
+			Size:       arg.Info.Size,
+		})
+	}
+	for i, arg := range args.VolumeSnapshots {
+		err := one(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// RemoveVolumeSnapshots removes the specified volume snapshots from state.
+func (s *StorageProvisionerAPI) RemoveVolumeSnapshots(args params.VolumeSnapshotIds) (params.ErrorResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Ids)),
+	}
+	one := func(id string) error {
+		if _, err := s.volumeSnapshot(id, canAccess); err != nil {
+			return err
+		}
+		return s.st.RemoveVolumeSnapshot(id)
+	}
+	for i, id := range args.Ids {
+		err := one(id)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// volumeSnapshot returns the volume snapshot with the specified ID, if
+// the authenticated entity can access the volume it was taken from.
+func (s *StorageProvisionerAPI) volumeSnapshot(id string, canAccess common.AuthFunc) (state.VolumeSnapshot, error) {
+	if !state.IsValidVolumeSnapshotId(id) {
+		return nil, common.ErrPerm
+	}
+	snapshot, err := s.st.VolumeSnapshot(id)
+	if errors.IsNotFound(err) {
+		return nil, common.ErrPerm
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !canAccess(snapshot.Volume()) {
+		return nil, common.ErrPerm
+	}
+	return snapshot, nil
+}
+
 // RemoveAttachments removes the specified machine storage attachments
 // from state.
 func (s *StorageProvisionerAPI) RemoveAttachment(args params.MachineStorageIds) (params.ErrorResults, error) {
@@ -43,10 +43,12 @@ func (s *provisionerSuite) SetUpSuite(c *gc.C) {
 	s.JujuConnSuite.SetUpSuite(c)
 
 	registry.RegisterProvider("environscoped", &dummy.StorageProvider{
-		StorageScope: storage.ScopeEnviron,
+		StorageScope:    storage.ScopeEnviron,
+		VolumeSnapshots: true,
 	})
 	registry.RegisterProvider("machinescoped", &dummy.StorageProvider{
-		StorageScope: storage.ScopeMachine,
+		StorageScope:    storage.ScopeMachine,
+		VolumeSnapshots: true,
 	})
 	registry.RegisterEnvironStorageProviders(
 		"dummy", "environscoped", "machinescoped",
@@ -1258,3 +1260,152 @@ func (b byMachineAndEntity) Less(i, j int) bool {
 func (b byMachineAndEntity) Swap(i, j int) {
 	b[i], b[j] = b[j], b[i]
 }
+
+func (s *provisionerSuite) TestVolumeSnapshots(c *gc.C) {
+	s.setupVolumes(c)
+	snapshot, err := s.State.AddVolumeSnapshot(names.NewVolumeTag("2"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.VolumeSnapshotParams(params.VolumeSnapshotIds{
+		Ids: []string{snapshot.Id(), "2:42", "invalid"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.VolumeSnapshotParamsResults{
+		Results: []params.VolumeSnapshotParamsResult{
+			{Result: params.VolumeSnapshotParams{
+				Id:        "2:0",
+				VolumeTag: "volume-2",
+				VolumeId:  "def",
+				Provider:  "environscoped",
+				Life:      params.Alive,
+			}},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	errResults, err := s.api.SetVolumeSnapshotInfo(params.VolumeSnapshots{
+		VolumeSnapshots: []params.VolumeSnapshot{{
+			Id:        "2:0",
+			VolumeTag: "volume-2",
+			Info:      params.VolumeSnapshotInfo{SnapshotId: "snap-def", Size: 4096},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errResults, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{}},
+	})
+	snapshot, err = s.State.VolumeSnapshot("2:0")
+	c.Assert(err, jc.ErrorIsNil)
+	info, err := snapshot.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, state.VolumeSnapshotInfo{SnapshotId: "snap-def", Size: 4096})
+
+	// Snapshots may only be removed once they are no longer alive.
+	errResults, err = s.api.RemoveVolumeSnapshots(params.VolumeSnapshotIds{Ids: []string{"2:0"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errResults.Results[0].Error, gc.ErrorMatches, `removing volume snapshot "2:0": volume snapshot is not dying`)
+	err = s.State.DestroyVolumeSnapshot("2:0")
+	c.Assert(err, jc.ErrorIsNil)
+	errResults, err = s.api.RemoveVolumeSnapshots(params.VolumeSnapshotIds{Ids: []string{"2:0"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errResults, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{}},
+	})
+}
+
+func (s *provisionerSuite) TestWatchVolumeSnapshots(c *gc.C) {
+	s.setupVolumes(c)
+	_, err := s.State.AddVolumeSnapshot(names.NewVolumeTag("0/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddVolumeSnapshot(names.NewVolumeTag("2"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	args := params.Entities{Entities: []params.Entity{
+		{"machine-0"},
+		{s.State.ModelTag().String()},
+		{"machine-42"}},
+	}
+	result, err := s.api.WatchVolumeSnapshots(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringsWatchResults{
+		Results: []params.StringsWatchResult{
+			{StringsWatcherId: "1", Changes: []string{"0/0:0"}},
+			{StringsWatcherId: "2", Changes: []string{"2:1"}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+	c.Assert(s.resources.Count(), gc.Equals, 2)
+	statetesting.AssertStop(c, s.resources.Get("1"))
+	statetesting.AssertStop(c, s.resources.Get("2"))
+}
+
+func (s *provisionerSuite) TestVolumeResizeParams(c *gc.C) {
+	s.setupVolumes(c)
+	results, err := s.api.VolumeResizeParams(params.Entities{
+		Entities: []params.Entity{{"volume-2"}, {"volume-1"}, {"volume-42"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0], jc.DeepEquals, params.VolumeResizeParamsResult{
+		Result: params.VolumeResizeParams{
+			VolumeTag: "volume-2",
+			VolumeId:  "def",
+			Provider:  "environscoped",
+		},
+	})
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `volume "1" not provisioned`)
+	c.Assert(results.Results[2].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+}
+
+func (s *provisionerSuite) TestSetVolumeInfoPreservesPool(c *gc.C) {
+	s.setupVolumes(c)
+
+	// Updating the info of a provisioned volume, as is done
+	// after it has been resized, must not change its pool.
+	errResults, err := s.api.SetVolumeInfo(params.Volumes{
+		Volumes: []params.Volume{{
+			VolumeTag: "volume-2",
+			Info: params.VolumeInfo{
+				VolumeId:   "def",
+				HardwareId: "456",
+				Size:       8192,
+			},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errResults, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{}},
+	})
+	volume, err := s.State.Volume(names.NewVolumeTag("2"))
+	c.Assert(err, jc.ErrorIsNil)
+	info, err := volume.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Pool, gc.Equals, "environscoped")
+	c.Assert(info.Size, gc.Equals, uint64(8192))
+}
+
+func (s *provisionerSuite) TestWatchVolumeResizes(c *gc.C) {
+	s.setupVolumes(c)
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	args := params.Entities{Entities: []params.Entity{
+		{"machine-0"},
+		{s.State.ModelTag().String()},
+		{"machine-42"}},
+	}
+	result, err := s.api.WatchVolumeResizes(args)
+	c.Assert(err, jc.ErrorIsNil)
+	sort.Strings(result.Results[1].Changes)
+	c.Assert(result, jc.DeepEquals, params.StringsWatchResults{
+		Results: []params.StringsWatchResult{
+			{StringsWatcherId: "1", Changes: []string{"0/0"}},
+			{StringsWatcherId: "2", Changes: []string{"1", "2", "3", "4"}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+	c.Assert(s.resources.Count(), gc.Equals, 2)
+	statetesting.AssertStop(c, s.resources.Get("1"))
+	statetesting.AssertStop(c, s.resources.Get("2"))
+}
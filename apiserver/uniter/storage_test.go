@@ -80,6 +80,10 @@ func (s *storageSuite) TestWatchStorageAttachmentVolume(c *gc.C) {
 		changes: make(chan struct{}, 1),
 	}
 	volumeWatcher.changes <- struct{}{}
+	volumeInfoWatcher := &mockNotifyWatcher{
+		changes: make(chan struct{}, 1),
+	}
+	volumeInfoWatcher.changes <- struct{}{}
 	blockDevicesWatcher := &mockNotifyWatcher{
 		changes: make(chan struct{}, 1),
 	}
@@ -107,6 +111,11 @@ func (s *storageSuite) TestWatchStorageAttachmentVolume(c *gc.C) {
 			c.Assert(u, gc.DeepEquals, unitTag)
 			return storageWatcher
 		},
+		watchVolume: func(v names.VolumeTag) state.NotifyWatcher {
+			calls = append(calls, "WatchVolume")
+			c.Assert(v, gc.DeepEquals, volumeTag)
+			return volumeInfoWatcher
+		},
 		watchVolumeAttachment: func(m names.MachineTag, v names.VolumeTag) state.NotifyWatcher {
 			calls = append(calls, "WatchVolumeAttachment")
 			c.Assert(m, gc.DeepEquals, machineTag)
@@ -138,6 +147,7 @@ func (s *storageSuite) TestWatchStorageAttachmentVolume(c *gc.C) {
 		"UnitAssignedMachine",
 		"StorageInstance",
 		"StorageInstanceVolume",
+		"WatchVolume",
 		"WatchVolumeAttachment",
 		"WatchBlockDevices",
 		"WatchStorageAttachment",
@@ -165,6 +175,10 @@ func (s *storageSuite) TestWatchStorageAttachmentFilesystem(c *gc.C) {
 		changes: make(chan struct{}, 1),
 	}
 	filesystemWatcher.changes <- struct{}{}
+	filesystemInfoWatcher := &mockNotifyWatcher{
+		changes: make(chan struct{}, 1),
+	}
+	filesystemInfoWatcher.changes <- struct{}{}
 	var calls []string
 	state := &mockStorageState{
 		storageInstance: func(s names.StorageTag) (state.StorageInstance, error) {
@@ -188,6 +202,11 @@ func (s *storageSuite) TestWatchStorageAttachmentFilesystem(c *gc.C) {
 			c.Assert(u, gc.DeepEquals, unitTag)
 			return storageWatcher
 		},
+		watchFilesystem: func(f names.FilesystemTag) state.NotifyWatcher {
+			calls = append(calls, "WatchFilesystem")
+			c.Assert(f, gc.DeepEquals, filesystemTag)
+			return filesystemInfoWatcher
+		},
 		watchFilesystemAttachment: func(m names.MachineTag, f names.FilesystemTag) state.NotifyWatcher {
 			calls = append(calls, "WatchFilesystemAttachment")
 			c.Assert(m, gc.DeepEquals, machineTag)
@@ -214,6 +233,7 @@ func (s *storageSuite) TestWatchStorageAttachmentFilesystem(c *gc.C) {
 		"UnitAssignedMachine",
 		"StorageInstance",
 		"StorageInstanceFilesystem",
+		"WatchFilesystem",
 		"WatchFilesystemAttachment",
 		"WatchStorageAttachment",
 	})
@@ -483,7 +503,9 @@ type mockStorageState struct {
 	unitAssignedMachine           func(names.UnitTag) (names.MachineTag, error)
 	watchStorageAttachments       func(names.UnitTag) state.StringsWatcher
 	watchStorageAttachment        func(names.StorageTag, names.UnitTag) state.NotifyWatcher
+	watchFilesystem               func(names.FilesystemTag) state.NotifyWatcher
 	watchFilesystemAttachment     func(names.MachineTag, names.FilesystemTag) state.NotifyWatcher
+	watchVolume                   func(names.VolumeTag) state.NotifyWatcher
 	watchVolumeAttachment         func(names.MachineTag, names.VolumeTag) state.NotifyWatcher
 	watchBlockDevices             func(names.MachineTag) state.NotifyWatcher
 	addUnitStorage                func(u names.UnitTag, name string, cons state.StorageConstraints) error
@@ -522,10 +544,18 @@ func (m *mockStorageState) WatchStorageAttachment(s names.StorageTag, u names.Un
 	return m.watchStorageAttachment(s, u)
 }
 
+func (m *mockStorageState) WatchFilesystem(f names.FilesystemTag) state.NotifyWatcher {
+	return m.watchFilesystem(f)
+}
+
 func (m *mockStorageState) WatchFilesystemAttachment(mtag names.MachineTag, f names.FilesystemTag) state.NotifyWatcher {
 	return m.watchFilesystemAttachment(mtag, f)
 }
 
+func (m *mockStorageState) WatchVolume(v names.VolumeTag) state.NotifyWatcher {
+	return m.watchVolume(v)
+}
+
 func (m *mockStorageState) WatchVolumeAttachment(mtag names.MachineTag, v names.VolumeTag) state.NotifyWatcher {
 	return m.watchVolumeAttachment(mtag, v)
 }
@@ -27,7 +27,7 @@ import (
 var logger = loggo.GetLogger("juju.apiserver.uniter")
 
 func init() {
-	common.RegisterStandardFacade("Uniter", 4, NewUniterAPIV4)
+	common.RegisterStandardFacade("Uniter", 5, NewUniterAPIV5)
 }
 
 // UniterAPIV3 implements the API version 3, used by the uniter worker.
@@ -52,8 +52,8 @@ type UniterAPIV3 struct {
 	StorageAPI
 }
 
-// NewUniterAPIV4 creates a new instance of the Uniter API, version 3.
-func NewUniterAPIV4(st *state.State, resources *common.Resources, authorizer common.Authorizer) (*UniterAPIV3, error) {
+// NewUniterAPIV5 creates a new instance of the Uniter API, version 5.
+func NewUniterAPIV5(st *state.State, resources *common.Resources, authorizer common.Authorizer) (*UniterAPIV3, error) {
 	if !authorizer.AuthUnitAgent() {
 		return nil, common.ErrPerm
 	}
@@ -803,6 +803,33 @@ func (u *UniterAPIV3) WatchApplicationRelations(args params.Entities) (params.St
 	return result, nil
 }
 
+// WatchEndpointBindings returns a NotifyWatcher for observing changes
+// to the spaces the endpoints of each given application are bound to.
+func (u *UniterAPIV3) WatchEndpointBindings(args params.Entities) (params.NotifyWatchResults, error) {
+	result := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessService()
+	if err != nil {
+		return params.NotifyWatchResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		err = common.ErrPerm
+		watcherId := ""
+		if canAccess(tag) {
+			watcherId, err = u.watchOneEndpointBindings(tag)
+		}
+		result.Results[i].NotifyWatcherId = watcherId
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
 // CharmArchiveSha256 returns the SHA256 digest of the charm archive
 // (bundle) data for each charm url in the given parameters.
 func (u *UniterAPIV3) CharmArchiveSha256(args params.CharmURLs) (params.StringResults, error) {
@@ -1297,6 +1324,19 @@ func (u *UniterAPIV3) watchOneServiceRelations(tag names.ApplicationTag) (params
 	return nothing, watcher.EnsureErr(watch)
 }
 
+func (u *UniterAPIV3) watchOneEndpointBindings(tag names.ApplicationTag) (string, error) {
+	service, err := u.getService(tag)
+	if err != nil {
+		return "", err
+	}
+	watch := service.WatchEndpointBindings()
+	// Consume the initial event.
+	if _, ok := <-watch.Changes(); ok {
+		return u.resources.Register(watch), nil
+	}
+	return "", watcher.EnsureErr(watch)
+}
+
 func (u *UniterAPIV3) watchOneUnitConfigSettings(tag names.UnitTag) (string, error) {
 	unit, err := u.getUnit(tag)
 	if err != nil {
@@ -1482,6 +1522,71 @@ func (u *UniterAPIV3) AddMetricBatches(args params.MetricBatchParams) (params.Er
 	return result, nil
 }
 
+// AddHookExecutions records the given hook executions against their
+// units' hook history.
+func (u *UniterAPIV3) AddHookExecutions(args params.HookExecutionArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Args {
+		tag, err := names.ParseUnitTag(arg.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		err = common.ErrPerm
+		if canAccess(tag) {
+			var unit *state.Unit
+			unit, err = u.getUnit(tag)
+			if err == nil {
+				err = unit.AddHookExecution(state.HookExecution{
+					Hook:       arg.Execution.Hook,
+					RelationId: arg.Execution.RelationId,
+					RemoteUnit: arg.Execution.RemoteUnit,
+					Started:    arg.Execution.Started,
+					Finished:   arg.Execution.Finished,
+					ExitCode:   arg.Execution.ExitCode,
+					ToolCalls:  arg.Execution.ToolCalls,
+				})
+			}
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// SetWorkloadVersion sets the workload version for each given unit.
+func (u *UniterAPIV3) SetWorkloadVersion(args params.EntityWorkloadVersions) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		err = common.ErrPerm
+		if canAccess(tag) {
+			var unit *state.Unit
+			unit, err = u.getUnit(tag)
+			if err == nil {
+				err = unit.SetWorkloadVersion(entity.WorkloadVersion)
+			}
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
 // NetworkConfig returns information about all given relation/unit pairs,
 // including their id, key and the local endpoint.
 func (u *UniterAPIV3) NetworkConfig(args params.UnitsNetworkConfig) (params.UnitNetworkConfigResults, error) {
@@ -1573,35 +1678,164 @@ func (u *UniterAPIV3) getOneNetworkConfig(canAccess common.AuthFunc, unitTagArg,
 	// primary address.
 	//
 	// LKK Card: https://canonical.leankit.com/Boards/View/101652562/119258804
-	addresses, err := machine.AllAddresses()
+	addresses, err := machine.SpaceAddresses(boundSpace)
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot get devices addresses")
 	}
 	logger.Infof(
-		"geting network config for machine %q with addresses %+v, hosting unit %q of application %q, with bindings %+v",
-		machineID, addresses, unit.Name(), service.Name(), bindings,
+		"geting network config for machine %q with addresses %+v in space %q, hosting unit %q of application %q",
+		machineID, addresses, boundSpace, unit.Name(), service.Name(),
 	)
 
+	var boundAddresses []network.Address
 	for _, addr := range addresses {
-		subnet, err := addr.Subnet()
-		if errors.IsNotFound(err) {
-			logger.Debugf("skipping %s: not linked to a known subnet (%v)", addr, err)
+		logger.Debugf("endpoint %q bound to space %q has address %q", bindingName, boundSpace, addr)
+		boundAddresses = append(boundAddresses, network.NewAddress(addr.Value()))
+	}
+
+	// Filter and order the addresses according to the model's address
+	// policy, as done for the machine's preferred addresses.
+	cfg, err := u.st.ModelConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, addr := range cfg.AddressPolicy().Apply(boundAddresses) {
+		// TODO(dimitern): Fill in the rest later (see linked LKK card above).
+		results = append(results, params.NetworkConfig{
+			Address: addr.Value,
+		})
+	}
+
+	return results, nil
+}
+
+// NetworkInfo returns, for each of the given endpoint bindings of a
+// unit, the link-layer devices and addresses of the unit's machine
+// bound to the endpoint's space, along with the addresses other units
+// should use to reach the unit and the subnets its traffic originates
+// from.
+func (u *UniterAPIV3) NetworkInfo(args params.NetworkInfoParams) (params.NetworkInfoResults, error) {
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.NetworkInfoResults{}, err
+	}
+	unitTag, err := names.ParseUnitTag(args.Unit)
+	if err != nil {
+		return params.NetworkInfoResults{}, errors.Trace(err)
+	}
+	if !canAccess(unitTag) {
+		return params.NetworkInfoResults{}, common.ErrPerm
+	}
+
+	unit, err := u.getUnit(unitTag)
+	if err != nil {
+		return params.NetworkInfoResults{}, errors.Trace(err)
+	}
+	application, err := unit.Application()
+	if err != nil {
+		return params.NetworkInfoResults{}, errors.Trace(err)
+	}
+	bindings, err := application.EndpointBindings()
+	if err != nil {
+		return params.NetworkInfoResults{}, errors.Trace(err)
+	}
+	machineID, err := unit.AssignedMachineId()
+	if err != nil {
+		return params.NetworkInfoResults{}, errors.Trace(err)
+	}
+	machine, err := u.st.Machine(machineID)
+	if err != nil {
+		return params.NetworkInfoResults{}, errors.Trace(err)
+	}
+	cfg, err := u.st.ModelConfig()
+	if err != nil {
+		return params.NetworkInfoResults{}, errors.Trace(err)
+	}
+
+	result := params.NetworkInfoResults{
+		Results: make(map[string]params.NetworkInfoResult),
+	}
+	for _, bindingName := range args.Bindings {
+		boundSpace, known := bindings[bindingName]
+		if !known {
+			err := errors.Errorf("binding name %q not defined by the unit's charm", bindingName)
+			result.Results[bindingName] = params.NetworkInfoResult{Error: common.ServerError(err)}
 			continue
-		} else if err != nil {
-			return nil, errors.Annotatef(err, "cannot get subnet for address %q", addr)
 		}
+		info, err := machineNetworkInfo(machine, boundSpace, cfg.AddressPolicy())
+		if err != nil {
+			result.Results[bindingName] = params.NetworkInfoResult{Error: common.ServerError(err)}
+			continue
+		}
+		result.Results[bindingName] = info
+	}
+	return result, nil
+}
 
-		if space := subnet.SpaceName(); space != boundSpace {
-			logger.Debugf("skipping %s: want bound to space %q, got space %q", addr, boundSpace, space)
+// machineNetworkInfo returns the devices and addresses of the machine
+// in the given space, allowed by the address policy. Endpoints not bound
+// to a space use the machine's preferred private address.
+func machineNetworkInfo(machine *state.Machine, space string, policy network.AddressPolicy) (params.NetworkInfoResult, error) {
+	var result params.NetworkInfoResult
+	var privateAddress network.Address
+	var addresses []*state.Address
+	var err error
+	if space == "" {
+		privateAddress, err = machine.PrivateAddress()
+		if err != nil {
+			return result, errors.Annotatef(err, "getting machine %q preferred private address", machine.Id())
+		}
+		addresses, err = machine.AllAddresses()
+	} else {
+		addresses, err = machine.SpaceAddresses(space)
+	}
+	if err != nil {
+		return result, errors.Annotate(err, "cannot get devices addresses")
+	}
+	deviceIndex := make(map[string]int)
+	var ingress []network.Address
+	for _, addr := range addresses {
+		netAddr := network.NewAddress(addr.Value())
+		if !policy.Allows(netAddr) {
+			continue
+		}
+		if space == "" && addr.Value() != privateAddress.Value {
 			continue
 		}
-		logger.Debugf("endpoint %q bound to space %q has address %q", bindingName, boundSpace, addr)
 
-		// TODO(dimitern): Fill in the rest later (see linked LKK card above).
-		results = append(results, params.NetworkConfig{
+		index, ok := deviceIndex[addr.DeviceName()]
+		if !ok {
+			device, err := addr.Device()
+			if err != nil {
+				return result, errors.Trace(err)
+			}
+			result.Info = append(result.Info, params.NetworkInfo{
+				MACAddress:    device.MACAddress(),
+				InterfaceName: device.Name(),
+			})
+			index = len(result.Info) - 1
+			deviceIndex[addr.DeviceName()] = index
+		}
+		result.Info[index].Addresses = append(result.Info[index].Addresses, params.InterfaceAddress{
 			Address: addr.Value(),
+			CIDR:    addr.SubnetCIDR(),
 		})
+		ingress = append(ingress, netAddr)
+	}
+	if space == "" && len(ingress) == 0 {
+		// The machine's devices are not known, so only the preferred
+		// private address can be used.
+		ingress = append(ingress, privateAddress)
 	}
 
-	return results, nil
+	for _, addr := range policy.Apply(ingress) {
+		result.IngressAddresses = append(result.IngressAddresses, addr.Value)
+		switch addr.Type {
+		case network.IPv4Address:
+			result.EgressSubnets = append(result.EgressSubnets, addr.Value+"/32")
+		case network.IPv6Address:
+			result.EgressSubnets = append(result.EgressSubnets, addr.Value+"/128")
+		}
+	}
+	return result, nil
 }
@@ -114,7 +114,7 @@ func (s *uniterSuite) SetUpTest(c *gc.C) {
 	s.resources = common.NewResources()
 	s.AddCleanup(func(_ *gc.C) { s.resources.StopAll() })
 
-	uniterAPIV3, err := uniter.NewUniterAPIV4(
+	uniterAPIV3, err := uniter.NewUniterAPIV5(
 		s.State,
 		s.resources,
 		s.authorizer,
@@ -126,7 +126,7 @@ func (s *uniterSuite) SetUpTest(c *gc.C) {
 func (s *uniterSuite) TestUniterFailsWithNonUnitAgentUser(c *gc.C) {
 	anAuthorizer := s.authorizer
 	anAuthorizer.Tag = names.NewMachineTag("9")
-	_, err := uniter.NewUniterAPIV4(s.State, s.resources, anAuthorizer)
+	_, err := uniter.NewUniterAPIV5(s.State, s.resources, anAuthorizer)
 	c.Assert(err, gc.NotNil)
 	c.Assert(err, gc.ErrorMatches, "permission denied")
 }
@@ -600,7 +600,7 @@ func (s *uniterSuite) TestGetPrincipal(c *gc.C) {
 	// Now try as subordinate's agent.
 	subAuthorizer := s.authorizer
 	subAuthorizer.Tag = subordinate.Tag()
-	subUniter, err := uniter.NewUniterAPIV4(s.State, s.resources, subAuthorizer)
+	subUniter, err := uniter.NewUniterAPIV5(s.State, s.resources, subAuthorizer)
 	c.Assert(err, jc.ErrorIsNil)
 
 	result, err = subUniter.GetPrincipal(args)
@@ -778,6 +778,66 @@ func (s *uniterSuite) TestSetCharmURL(c *gc.C) {
 	c.Assert(needsUpgrade, jc.IsTrue)
 }
 
+func (s *uniterSuite) TestAddHookExecutions(c *gc.C) {
+	started := time.Date(2016, 10, 1, 12, 0, 0, 0, time.UTC)
+	execution := params.HookExecution{
+		Hook:       "db-relation-changed",
+		RelationId: 1,
+		RemoteUnit: "mysql/0",
+		Started:    started,
+		Finished:   started.Add(time.Second),
+		ExitCode:   1,
+		ToolCalls:  []string{"relation-get", "status-set"},
+	}
+	args := params.HookExecutionArgs{Args: []params.HookExecutionArg{
+		{Tag: "unit-mysql-0", Execution: execution},
+		{Tag: "unit-wordpress-0", Execution: execution},
+		{Tag: "unit-foo-42", Execution: execution},
+	}}
+	result, err := s.uniter.AddHookExecutions(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{apiservertesting.ErrUnauthorized},
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	history, err := s.wordpressUnit.HookHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, jc.DeepEquals, []state.HookExecution{{
+		Hook:       "db-relation-changed",
+		RelationId: 1,
+		RemoteUnit: "mysql/0",
+		Started:    started,
+		Finished:   started.Add(time.Second),
+		ExitCode:   1,
+		ToolCalls:  []string{"relation-get", "status-set"},
+	}})
+}
+
+func (s *uniterSuite) TestSetWorkloadVersion(c *gc.C) {
+	args := params.EntityWorkloadVersions{Entities: []params.EntityWorkloadVersion{
+		{Tag: "unit-mysql-0", WorkloadVersion: "1.2"},
+		{Tag: "unit-wordpress-0", WorkloadVersion: "4.6.1"},
+		{Tag: "unit-foo-42", WorkloadVersion: "1.2"},
+	}}
+	result, err := s.uniter.SetWorkloadVersion(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{apiservertesting.ErrUnauthorized},
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	err = s.wordpressUnit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.wordpressUnit.WorkloadVersion(), gc.Equals, "4.6.1")
+}
+
 func (s *uniterSuite) TestCharmModifiedVersion(c *gc.C) {
 	args := params.Entities{Entities: []params.Entity{
 		{Tag: "application-mysql"},
@@ -1051,6 +1111,34 @@ func (s *uniterSuite) TestWatchApplicationRelations(c *gc.C) {
 	s.assertOneStringsWatcher(c, result, err)
 }
 
+func (s *uniterSuite) TestWatchEndpointBindings(c *gc.C) {
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "application-mysql"},
+		{Tag: "application-wordpress"},
+		{Tag: "application-foo"},
+	}}
+	result, err := s.uniter.WatchEndpointBindings(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.NotifyWatchResults{
+		Results: []params.NotifyWatchResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{NotifyWatcherId: "1"},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	// Verify the resource was registered and stop when done
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+
+	// Check that the Watch has consumed the initial event.
+	wc := statetesting.NewNotifyWatcherC(c, s.State, resource.(state.NotifyWatcher))
+	wc.AssertNoChange()
+}
+
 func (s *uniterSuite) TestCharmArchiveSha256(c *gc.C) {
 	dummyCharm := s.AddTestingCharm(c, "dummy")
 
@@ -1159,7 +1247,7 @@ func (s *uniterSuite) TestActionsWrongUnit(c *gc.C) {
 	mysqlUnitAuthorizer := apiservertesting.FakeAuthorizer{
 		Tag: s.mysqlUnit.Tag(),
 	}
-	mysqlUnitFacade, err := uniter.NewUniterAPIV4(s.State, s.resources, mysqlUnitAuthorizer)
+	mysqlUnitFacade, err := uniter.NewUniterAPIV5(s.State, s.resources, mysqlUnitAuthorizer)
 	c.Assert(err, jc.ErrorIsNil)
 
 	action, err := s.wordpressUnit.AddAction("fakeaction", nil)
@@ -2225,7 +2313,7 @@ func (s *unitMetricBatchesSuite) SetUpTest(c *gc.C) {
 		Tag: s.meteredUnit.Tag(),
 	}
 	var err error
-	s.uniter, err = uniter.NewUniterAPIV4(
+	s.uniter, err = uniter.NewUniterAPIV5(
 		s.State,
 		s.resources,
 		meteredAuthorizer,
@@ -2496,7 +2584,7 @@ func (s *uniterNetworkConfigSuite) setupUniterAPIForUnit(c *gc.C, givenUnit *sta
 	}
 
 	var err error
-	s.base.uniter, err = uniter.NewUniterAPIV4(
+	s.base.uniter, err = uniter.NewUniterAPIV5(
 		s.base.State,
 		s.base.resources,
 		s.base.authorizer,
@@ -2571,6 +2659,72 @@ func (s *uniterNetworkConfigSuite) TestNetworkConfigForExplicitlyBoundEndpoint(c
 	})
 }
 
+func (s *uniterNetworkConfigSuite) TestNetworkInfo(c *gc.C) {
+	args := params.NetworkInfoParams{
+		Unit:     s.base.wordpressUnit.Tag().String(),
+		Bindings: []string{"db", "admin-api", "unknown"},
+	}
+
+	result, err := s.base.uniter.NetworkInfo(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.NetworkInfoResults{
+		Results: map[string]params.NetworkInfoResult{
+			"db": {
+				Info: []params.NetworkInfo{{
+					InterfaceName: "eth0.100",
+					Addresses:     []params.InterfaceAddress{{Address: "10.0.0.10", CIDR: "10.0.0.0/24"}},
+				}, {
+					InterfaceName: "eth1.100",
+					Addresses:     []params.InterfaceAddress{{Address: "10.0.0.11", CIDR: "10.0.0.0/24"}},
+				}},
+				IngressAddresses: []string{"10.0.0.10", "10.0.0.11"},
+				EgressSubnets:    []string{"10.0.0.10/32", "10.0.0.11/32"},
+			},
+			"admin-api": {
+				Info: []params.NetworkInfo{{
+					InterfaceName: "eth0",
+					Addresses:     []params.InterfaceAddress{{Address: "8.8.8.10", CIDR: "8.8.0.0/16"}},
+				}, {
+					InterfaceName: "eth1",
+					Addresses:     []params.InterfaceAddress{{Address: "8.8.4.10", CIDR: "8.8.0.0/16"}},
+				}},
+				IngressAddresses: []string{"8.8.8.10", "8.8.4.10"},
+				EgressSubnets:    []string{"8.8.8.10/32", "8.8.4.10/32"},
+			},
+			"unknown": {
+				Error: apiservertesting.ServerError(`binding name "unknown" not defined by the unit's charm`),
+			},
+		},
+	})
+}
+
+func (s *uniterNetworkConfigSuite) TestNetworkInfoPermissions(c *gc.C) {
+	args := params.NetworkInfoParams{
+		Unit:     "unit-mysql-0",
+		Bindings: []string{"server"},
+	}
+
+	_, err := s.base.uniter.NetworkInfo(args)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *uniterNetworkConfigSuite) TestNetworkConfigAppliesAddressPolicy(c *gc.C) {
+	s.addRelationAndAssertInScope(c)
+	err := s.base.State.UpdateModelConfig(map[string]interface{}{"address-policy": "ipv6-only"}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.UnitsNetworkConfig{Args: []params.UnitNetworkConfig{
+		{BindingName: "db", UnitTag: s.base.wordpressUnit.Tag().String()},
+	}}
+
+	// All the addresses in the "internal" space are IPv4 ones.
+	result, err := s.base.uniter.NetworkConfig(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.UnitNetworkConfigResults{
+		Results: []params.UnitNetworkConfigResult{{}},
+	})
+}
+
 func (s *uniterNetworkConfigSuite) TestNetworkConfigForImplicitlyBoundEndpoint(c *gc.C) {
 	// Since wordpressUnit has explicit binding for "db", switch the API to
 	// mysqlUnit and check "mysql:server" uses the machine preferred private
@@ -0,0 +1,71 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs"
+)
+
+var newEnviron = environs.New
+
+// ClaimVirtualIPAddress moves the virtual IP address of each given
+// unit's application to the unit's machine, and returns it. Only an
+// application's leader may claim its virtual IP address. The result
+// is empty for units whose application has no virtual IP address.
+func (u *UniterAPIV3) ClaimVirtualIPAddress(args params.Entities) (params.StringResults, error) {
+	result := params.StringResults{
+		Results: make([]params.StringResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.StringResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		err = common.ErrPerm
+		if canAccess(tag) {
+			var address string
+			address, err = u.claimVirtualIPAddress(tag)
+			result.Results[i].Result = address
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (u *UniterAPIV3) claimVirtualIPAddress(tag names.UnitTag) (string, error) {
+	unit, err := u.getUnit(tag)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	application, err := unit.Application()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	address, _ := application.VirtualIPAddress()
+	if address == "" {
+		return "", nil
+	}
+	token := u.st.LeadershipChecker().LeadershipCheck(application.Name(), unit.Name())
+	if err := token.Check(nil); err != nil {
+		return "", errors.Trace(err)
+	}
+	manager, err := common.VirtualIPManager(u.st, newEnviron)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if err := common.MoveVirtualIPAddress(u.st, manager, application, unit.Name(), token); err != nil {
+		return "", errors.Trace(err)
+	}
+	return address, nil
+}
@@ -0,0 +1,128 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/apiserver/uniter"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+	jujuFactory "github.com/juju/juju/testing/factory"
+)
+
+// virtualIPInstancer is implemented by the dummy provider's environ.
+type virtualIPInstancer interface {
+	VirtualIPAddressInstance(address string) (instance.Id, bool)
+}
+
+// addProvisionedUnit adds a unit of wordpress to a new machine backed
+// by a dummy provider instance, and returns the unit and a uniter
+// facade authorized as it.
+func (s *uniterSuite) addProvisionedUnit(c *gc.C) (*state.Unit, instance.Id, *uniter.UniterAPIV3) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	inst, _ := testing.AssertStartInstance(c, s.Environ, s.ControllerConfig.ControllerUUID(), machine.Id())
+	err = machine.SetProvisioned(inst.Id(), "fake_nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	unit := s.Factory.MakeUnit(c, &jujuFactory.UnitParams{
+		Application: s.wordpress,
+		Machine:     machine,
+	})
+	api, err := uniter.NewUniterAPIV5(s.State, s.resources, apiservertesting.FakeAuthorizer{
+		Tag: unit.Tag(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return unit, inst.Id(), api
+}
+
+func (s *uniterSuite) TestClaimVirtualIPAddressNone(c *gc.C) {
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "application-wordpress"},
+	}}
+	result, err := s.uniter.ClaimVirtualIPAddress(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringResults{
+		Results: []params.StringResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Result: ""},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *uniterSuite) TestClaimVirtualIPAddress(c *gc.C) {
+	_, err := s.State.AddSubnet(state.SubnetInfo{CIDR: "10.20.0.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	address, err := s.wordpress.AllocateVirtualIPAddress("10.20.0.0/24", "")
+	c.Assert(err, jc.ErrorIsNil)
+	unit, instId, api := s.addProvisionedUnit(c)
+	args := params.Entities{Entities: []params.Entity{{Tag: unit.Tag().String()}}}
+
+	// Only the leader may claim the address.
+	result, err := api.ClaimVirtualIPAddress(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, ".* is not leader of .*")
+
+	err = s.State.LeadershipClaimer().ClaimLeadership("wordpress", unit.Name(), time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = api.ClaimVirtualIPAddress(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, jc.DeepEquals, []params.StringResult{{Result: address}})
+
+	assignedTo, ok := s.Environ.(virtualIPInstancer).VirtualIPAddressInstance(address)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(assignedTo, gc.Equals, instId)
+	err = s.wordpress.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.wordpress.VirtualIPHolder(), gc.Equals, unit.Name())
+}
+
+func (s *uniterSuite) TestClaimVirtualIPAddressOldHolderReleaseFails(c *gc.C) {
+	_, err := s.State.AddSubnet(state.SubnetInfo{CIDR: "10.20.0.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	address, err := s.wordpress.AllocateVirtualIPAddress("10.20.0.0/24", "")
+	c.Assert(err, jc.ErrorIsNil)
+	oldUnit, oldInstId, _ := s.addProvisionedUnit(c)
+	err = s.wordpress.SetVirtualIPHolder(fakeToken{}, oldUnit.Name(), oldInstId)
+	c.Assert(err, jc.ErrorIsNil)
+	unit, instId, api := s.addProvisionedUnit(c)
+	err = s.State.LeadershipClaimer().ClaimLeadership("wordpress", unit.Name(), time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The old holder's instance may be unreachable; that must not
+	// stop the new leader from taking over the address.
+	err = s.State.UpdateModelConfig(map[string]interface{}{
+		"broken": "ReleaseVirtualIPAddress",
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	args := params.Entities{Entities: []params.Entity{{Tag: unit.Tag().String()}}}
+	result, err := api.ClaimVirtualIPAddress(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, jc.DeepEquals, []params.StringResult{{Result: address}})
+
+	assignedTo, ok := s.Environ.(virtualIPInstancer).VirtualIPAddressInstance(address)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(assignedTo, gc.Equals, instId)
+	err = s.wordpress.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.wordpress.VirtualIPHolder(), gc.Equals, unit.Name())
+}
+
+// fakeToken implements leadership.Token, always claiming success.
+type fakeToken struct{}
+
+// Check is part of the leadership.Token interface.
+func (fakeToken) Check(interface{}) error {
+	return nil
+}
@@ -35,7 +35,7 @@ func (r *upgradingRootSuite) TestAllowedMethods(c *gc.C) {
 
 func (r *upgradingRootSuite) TestFindDisallowedMethod(c *gc.C) {
 	root := apiserver.TestingUpgradingRoot(nil)
-	caller, err := root.FindMethod("Client", 1, "ModelSet")
+	caller, err := root.FindMethod("Client", 2, "ModelSet")
 	c.Assert(errors.Cause(err), gc.Equals, params.UpgradeInProgressError)
 	c.Assert(caller, gc.IsNil)
 }
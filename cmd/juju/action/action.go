@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/action"
 	"github.com/juju/juju/apiserver/params"
@@ -57,6 +58,10 @@ type APIClient interface {
 	// FindActionsByNames takes a list of names and finds a corresponding list of
 	// Actions for every name.
 	FindActionsByNames(params.FindActionsByNames) (params.ActionsByNames, error)
+
+	// DownloadAttachment returns the content of the named file attached
+	// to the results of the identified Action.
+	DownloadAttachment(names.ActionTag, string) (io.ReadCloser, error)
 }
 
 // ActionCommandBase is the base type for action sub-commands.
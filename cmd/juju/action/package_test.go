@@ -5,7 +5,9 @@ package action_test
 
 import (
 	"errors"
+	"io"
 	"io/ioutil"
+	"strings"
 	"testing"
 	"time"
 
@@ -14,6 +16,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/action"
@@ -130,6 +133,7 @@ type fakeAPIClient struct {
 	actionTagMatches   params.FindTagsResults
 	actionsByNames     params.ActionsByNames
 	charmActions       *charm.Actions
+	attachments        map[string]string
 	apiErr             error
 }
 
@@ -212,3 +216,14 @@ func (c *fakeAPIClient) FindActionTagsByPrefix(arg params.FindTags) (params.Find
 func (c *fakeAPIClient) FindActionsByNames(args params.FindActionsByNames) (params.ActionsByNames, error) {
 	return c.actionsByNames, c.apiErr
 }
+
+func (c *fakeAPIClient) DownloadAttachment(tag names.ActionTag, name string) (io.ReadCloser, error) {
+	if c.apiErr != nil {
+		return nil, c.apiErr
+	}
+	content, ok := c.attachments[name]
+	if !ok {
+		return nil, errors.New("attachment not found")
+	}
+	return ioutil.NopCloser(strings.NewReader(content)), nil
+}
@@ -4,11 +4,17 @@
 package action
 
 import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
 	"regexp"
 	"time"
 
 	"github.com/juju/cmd"
 	errors "github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 	"launchpad.net/gnuflag"
 
 	"github.com/juju/juju/apiserver/params"
@@ -26,6 +32,7 @@ type showOutputCommand struct {
 	requestedId string
 	fullSchema  bool
 	wait        string
+	download    string
 }
 
 const showOutputDoc = `
@@ -37,12 +44,17 @@ to wait indefinitely.  If units are left off, seconds are assumed.
 The default behavior without --wait is to immediately check and return; if
 the results are "pending" then only the available information will be
 displayed.  This is also the behavior when any negative time is given.
+
+Files attached to the results with the action-attach hook tool are listed
+under "attachments".  Use --download with a directory to fetch them; each
+file is written to the directory under its attachment name.
 `
 
 // Set up the output.
 func (c *showOutputCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
 	f.StringVar(&c.wait, "wait", "-1s", "Wait for results")
+	f.StringVar(&c.download, "download", "", "Download any attached files to the given directory")
 }
 
 func (c *showOutputCommand) Info() *cmd.Info {
@@ -107,9 +119,68 @@ func (c *showOutputCommand) Run(ctx *cmd.Context) error {
 		return errors.Trace(err)
 	}
 
+	if c.download != "" {
+		if err := downloadAttachments(ctx, api, result, ctx.AbsPath(c.download)); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	return c.out.Write(ctx, FormatActionResult(result))
 }
 
+// downloadAttachments fetches the files attached to the given action
+// result into dir, checking the content of each against its recorded
+// hash.
+func downloadAttachments(ctx *cmd.Context, api APIClient, result params.ActionResult, dir string) error {
+	if len(result.Attachments) == 0 {
+		return nil
+	}
+	if result.Action == nil {
+		return errors.New("action result does not identify the action")
+	}
+	tag, err := names.ParseActionTag(result.Action.Tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return errors.Trace(err)
+	}
+	for _, attachment := range result.Attachments {
+		path := filepath.Join(dir, attachment.Name)
+		if err := downloadAttachment(api, tag, attachment, path); err != nil {
+			return errors.Trace(err)
+		}
+		ctx.Infof("downloaded %q to %s", attachment.Name, path)
+	}
+	return nil
+}
+
+// downloadAttachment writes the content of the given attachment to path.
+func downloadAttachment(api APIClient, tag names.ActionTag, attachment params.ActionAttachment, path string) error {
+	content, err := api.DownloadAttachment(tag, attachment.Name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer content.Close()
+
+	f, err := os.Create(path)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+	hasher := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(f, hasher), content); err != nil {
+		return errors.Annotatef(err, "cannot download attachment %q", attachment.Name)
+	}
+	if hash := fmt.Sprintf("%x", hasher.Sum(nil)); hash != attachment.SHA256 {
+		return errors.Errorf(
+			"downloaded attachment %q is corrupt: expected SHA-256 %s, got %s",
+			attachment.Name, attachment.SHA256, hash,
+		)
+	}
+	return f.Close()
+}
+
 // GetActionResult tries to repeatedly fetch an action until it is
 // in a completed state and then it returns it.
 // It waits for a maximum of "wait" before returning with the latest action status.
@@ -202,6 +273,16 @@ func FormatActionResult(result params.ActionResult) map[string]interface{} {
 	if len(result.Output) != 0 {
 		response["results"] = result.Output
 	}
+	if len(result.Attachments) != 0 {
+		attachments := make(map[string]interface{})
+		for _, attachment := range result.Attachments {
+			attachments[attachment.Name] = map[string]interface{}{
+				"size":   attachment.Size,
+				"sha256": attachment.SHA256,
+			}
+		}
+		response["attachments"] = attachments
+	}
 
 	if result.Enqueued.IsZero() && result.Started.IsZero() && result.Completed.IsZero() {
 		return response
@@ -5,10 +5,15 @@ package action_test
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
 	"strings"
 	"time"
 
+	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/common"
@@ -290,6 +295,54 @@ timing:
 	}
 }
 
+func (s *ShowOutputSuite) attachmentsClient(content string) *fakeAPIClient {
+	client := makeFakeClient(
+		0, 10*time.Second,
+		tagsForIdPrefix(validActionId, validActionTagString),
+		[]params.ActionResult{{
+			Action: &params.Action{Tag: validActionTagString},
+			Status: "completed",
+			Attachments: []params.ActionAttachment{{
+				Name:   "dump.sql",
+				Size:   9,
+				SHA256: fmt.Sprintf("%x", sha256.Sum256([]byte("some data"))),
+			}},
+		}},
+		params.ActionsByNames{},
+		"",
+	)
+	client.attachments = map[string]string{"dump.sql": content}
+	return client
+}
+
+func (s *ShowOutputSuite) TestDownloadAttachments(c *gc.C) {
+	unpatch := s.BaseActionSuite.patchAPIClient(s.attachmentsClient("some data"))
+	defer unpatch()
+	dir := filepath.Join(c.MkDir(), "out")
+	cmd, _ := action.NewShowOutputCommandForTest(s.store)
+	ctx, err := testing.RunCommand(c, cmd, "-m", "admin", validActionId, "--download", dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(ctx), gc.Equals, `
+attachments:
+  dump.sql:
+    sha256: 1307990e6ba5ca145eb35e99182a9bec46531bc54ddf656a602c780fa0240dee
+    size: 9
+status: completed
+`[1:])
+	c.Check(testing.Stderr(ctx), gc.Equals, fmt.Sprintf("downloaded \"dump.sql\" to %s\n", filepath.Join(dir, "dump.sql")))
+	data, err := ioutil.ReadFile(filepath.Join(dir, "dump.sql"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "some data")
+}
+
+func (s *ShowOutputSuite) TestDownloadAttachmentsCorrupt(c *gc.C) {
+	unpatch := s.BaseActionSuite.patchAPIClient(s.attachmentsClient("other data"))
+	defer unpatch()
+	cmd, _ := action.NewShowOutputCommandForTest(s.store)
+	_, err := testing.RunCommand(c, cmd, "-m", "admin", validActionId, "--download", c.MkDir())
+	c.Assert(err, gc.ErrorMatches, `downloaded attachment "dump.sql" is corrupt: expected SHA-256 .*, got .*`)
+}
+
 func testRunHelper(c *gc.C, s *ShowOutputSuite, client *fakeAPIClient, expectedErr, expectedOutput, wait, query, modelFlag string) {
 	unpatch := s.BaseActionSuite.patchAPIClient(client)
 	defer unpatch()
@@ -15,6 +15,23 @@ import (
 	"github.com/juju/juju/cmd/modelcmd"
 )
 
+var usageAddRelationDetails = `
+Relates two application endpoints. If an application has only one
+endpoint that can take part in the relation, its name may be omitted.
+
+Either application may be offered by another model hosted by the same
+controller, identified as [<owner>/]<model>.<offer>[:<endpoint>]. The
+owner defaults to the current user. The offered application is then
+represented in this model by a remote application named after the offer.
+
+Examples:
+    juju add-relation wordpress mysql
+    juju add-relation wordpress:db admin/prod.hosted-mysql:server
+
+See also:
+    offer
+    remove-relation`[1:]
+
 // NewAddRelationCommand returns a command to add a relation between 2 services.
 func NewAddRelationCommand() cmd.Command {
 	return modelcmd.Wrap(&addRelationCommand{})
@@ -32,6 +49,7 @@ func (c *addRelationCommand) Info() *cmd.Info {
 		Aliases: []string{"relate"},
 		Args:    "<application1>[:<relation name1>] <application2>[:<relation name2>]",
 		Purpose: "Add a relation between two applications.",
+		Doc:     usageAddRelationDetails,
 	}
 }
 
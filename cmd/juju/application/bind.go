@@ -0,0 +1,115 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageBindSummary = `
+Changes the spaces an application's endpoints are bound to.`[1:]
+
+var usageBindDetails = `
+Endpoint bindings are usually set when an application is deployed, with
+deploy --bind. This command changes them for a deployed application.
+Each binding is an endpoint name (a relation name or an extra-binding
+name) followed by "=" and a space name; leaving the space name out binds
+the endpoint to the default space. Endpoints not specified keep their
+current bindings.
+
+Every machine hosting a unit of the application must have an address in
+each space an endpoint is bound to. The application's units run the
+config-changed hook, and units related to them over rebound endpoints
+run relation-changed hooks, so charms can read the new addresses with
+network-get.
+
+Examples:
+    juju bind mysql server=db
+    juju bind mysql server=db cluster=ha
+    juju bind mysql server=
+
+See also: 
+    deploy`[1:]
+
+// NewBindCommand returns a command to change endpoint bindings.
+func NewBindCommand() cmd.Command {
+	return modelcmd.Wrap(&bindCommand{})
+}
+
+// bindCommand changes the spaces an application's endpoints are bound to.
+type bindCommand struct {
+	modelcmd.ModelCommandBase
+	ApplicationName string
+	Bindings        map[string]string
+}
+
+func (c *bindCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "bind",
+		Args:    "<application name> <endpoint>=[<space>] ...",
+		Purpose: usageBindSummary,
+		Doc:     usageBindDetails,
+	}
+}
+
+func (c *bindCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application name specified")
+	}
+	c.ApplicationName, args = args[0], args[1:]
+	if !names.IsValidApplication(c.ApplicationName) {
+		return errors.NotValidf("application name %q", c.ApplicationName)
+	}
+	if len(args) == 0 {
+		return errors.New("no endpoint bindings specified")
+	}
+	c.Bindings = make(map[string]string)
+	for _, arg := range args {
+		parts := strings.SplitN(arg, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return errors.Errorf("invalid binding %q, expected <endpoint>=[<space>]", arg)
+		}
+		endpoint, space := parts[0], parts[1]
+		if space != "" && !names.IsValidSpace(space) {
+			return errors.NotValidf("space name %q", space)
+		}
+		if _, ok := c.Bindings[endpoint]; ok {
+			return errors.Errorf("endpoint %q bound more than once", endpoint)
+		}
+		c.Bindings[endpoint] = space
+	}
+	return nil
+}
+
+type bindAPI interface {
+	Close() error
+	Bind(application string, bindings map[string]string) error
+}
+
+func (c *bindCommand) getAPI() (bindAPI, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return application.NewClient(root), nil
+}
+
+// Run changes the spaces the application's endpoints are bound to.
+func (c *bindCommand) Run(_ *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	err = client.Bind(c.ApplicationName, c.Bindings)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
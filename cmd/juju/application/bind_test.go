@@ -0,0 +1,96 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/common"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/testcharms"
+	"github.com/juju/juju/testing"
+)
+
+type BindSuite struct {
+	jujutesting.RepoSuite
+	common.CmdBlockHelper
+}
+
+func (s *BindSuite) SetUpTest(c *gc.C) {
+	s.RepoSuite.SetUpTest(c)
+	s.CmdBlockHelper = common.NewCmdBlockHelper(s.APIState)
+	c.Assert(s.CmdBlockHelper, gc.NotNil)
+	s.AddCleanup(func(*gc.C) { s.CmdBlockHelper.Close() })
+}
+
+var _ = gc.Suite(&BindSuite{})
+
+func runBind(c *gc.C, args ...string) error {
+	_, err := testing.RunCommand(c, NewBindCommand(), args...)
+	return err
+}
+
+func (s *BindSuite) TestInit(c *gc.C) {
+	for _, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no application name specified",
+	}, {
+		args: []string{"mysql"},
+		err:  "no endpoint bindings specified",
+	}, {
+		args: []string{"MySQL", "server=db"},
+		err:  `application name "MySQL" not valid`,
+	}, {
+		args: []string{"mysql", "db"},
+		err:  `invalid binding "db", expected <endpoint>=\[<space>\]`,
+	}, {
+		args: []string{"mysql", "=db"},
+		err:  `invalid binding "=db", expected <endpoint>=\[<space>\]`,
+	}, {
+		args: []string{"mysql", "server=$db"},
+		err:  `space name "\$db" not valid`,
+	}, {
+		args: []string{"mysql", "server=db", "server=ha"},
+		err:  `endpoint "server" bound more than once`,
+	}} {
+		c.Logf("args: %q", test.args)
+		err := runBind(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *BindSuite) TestBind(c *gc.C) {
+	_, err := s.State.AddSpace("db", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	ch := testcharms.Repo.CharmArchivePath(s.CharmsPath, "mysql")
+	err = runDeploy(c, ch, "mysql", "--series", "trusty")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = runBind(c, "mysql", "server=db")
+	c.Assert(err, jc.ErrorIsNil)
+	application, err := s.State.Application("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	bindings, err := application.EndpointBindings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bindings["server"], gc.Equals, "db")
+
+	// An empty space name binds to the default space.
+	err = runBind(c, "mysql", "server=")
+	c.Assert(err, jc.ErrorIsNil)
+	bindings, err = application.EndpointBindings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bindings["server"], gc.Equals, "")
+}
+
+func (s *BindSuite) TestBlockBind(c *gc.C) {
+	// Block operation
+	s.BlockAllChanges(c, "TestBlockBind")
+
+	err := runBind(c, "mysql", "server=db")
+	s.AssertBlocked(c, err, ".*TestBlockBind.*")
+}
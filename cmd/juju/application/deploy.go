@@ -164,6 +164,9 @@ Examples:
    juju deploy mysql --to 24/lxd/3 (deploy to lxd container 3 on host machine 24)
    juju deploy mysql --to lxd:25   (deploy to a new lxd container on host machine 25)
    juju deploy mysql --to lxd      (deploy to a new lxd container on a new machine)
+   juju deploy mysql --to lxd:25@10.0.0.5
+   (deploy to a new lxd container on host machine 25, using the static IP
+    address 10.0.0.5)
 
    juju deploy mysql -n 5 --constraints mem=8G
    (deploy 5 instances of mysql with at least 8 GB of RAM each)
@@ -32,6 +32,14 @@ func NewAddUnitCommandForTest(api serviceAddUnitAPI) cmd.Command {
 	})
 }
 
+// NewShowRelationDataCommandForTest returns a ShowRelationDataCommand with
+// the api provided as specified.
+func NewShowRelationDataCommandForTest(api relationDataAPI) cmd.Command {
+	return modelcmd.Wrap(&showRelationDataCommand{
+		api: api,
+	})
+}
+
 type Patcher interface {
 	PatchValue(dest, value interface{})
 }
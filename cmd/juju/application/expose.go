@@ -4,8 +4,11 @@
 package application
 
 import (
+	"net"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
 
 	"github.com/juju/juju/api/application"
 	"github.com/juju/juju/cmd/juju/block"
@@ -19,8 +22,17 @@ var usageExposeDetails = `
 Adjusts the firewall rules and any relevant security mechanisms of the
 cloud to allow public access to the application.
 
+By default, the application's open ports may be accessed from any
+address. Access may be restricted to a set of address ranges with
+--to-cidrs, and to the subnets of a set of spaces with --to-spaces.
+Exposing an application again replaces any previous restrictions.
+Subnets later added to or removed from the spaces are taken into
+account as they change.
+
 Examples:
     juju expose wordpress
+    juju expose wordpress --to-cidrs 10.0.0.0/8,192.168.1.0/24
+    juju expose wordpress --to-spaces internal
 
 See also: 
     unexpose`[1:]
@@ -34,6 +46,8 @@ func NewExposeCommand() cmd.Command {
 type exposeCommand struct {
 	modelcmd.ModelCommandBase
 	ApplicationName string
+	ToCIDRs         []string
+	ToSpaces        []string
 }
 
 func (c *exposeCommand) Info() *cmd.Info {
@@ -45,17 +59,28 @@ func (c *exposeCommand) Info() *cmd.Info {
 	}
 }
 
+func (c *exposeCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.Var(cmd.NewStringsValue(nil, &c.ToCIDRs), "to-cidrs", "Comma-separated address ranges, in CIDR notation, to expose the application to")
+	f.Var(cmd.NewStringsValue(nil, &c.ToSpaces), "to-spaces", "Comma-separated spaces whose subnets to expose the application to")
+}
+
 func (c *exposeCommand) Init(args []string) error {
 	if len(args) == 0 {
 		return errors.New("no application name specified")
 	}
 	c.ApplicationName = args[0]
+	for _, cidr := range c.ToCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return errors.Errorf("invalid CIDR %q", cidr)
+		}
+	}
 	return cmd.CheckEmpty(args[1:])
 }
 
 type serviceExposeAPI interface {
 	Close() error
 	Expose(serviceName string) error
+	ExposeTo(serviceName string, cidrs, spaces []string) error
 	Unexpose(serviceName string) error
 }
 
@@ -75,5 +100,10 @@ func (c *exposeCommand) Run(_ *cmd.Context) error {
 		return err
 	}
 	defer client.Close()
-	return block.ProcessBlockedError(client.Expose(c.ApplicationName), block.BlockChange)
+	if len(c.ToCIDRs) == 0 && len(c.ToSpaces) == 0 {
+		err = client.Expose(c.ApplicationName)
+	} else {
+		err = client.ExposeTo(c.ApplicationName, c.ToCIDRs, c.ToSpaces)
+	}
+	return block.ProcessBlockedError(err, block.BlockChange)
 }
@@ -60,6 +60,36 @@ func (s *ExposeSuite) TestExpose(c *gc.C) {
 	})
 }
 
+func (s *ExposeSuite) TestExposeTo(c *gc.C) {
+	_, err := s.State.AddSpace("internal", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	ch := testcharms.Repo.CharmArchivePath(s.CharmsPath, "dummy")
+	err = runDeploy(c, ch, "some-application-name", "--series", "trusty")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = runExpose(c, "some-application-name", "--to-cidrs", "10.0.0.0/8,192.168.1.0/24", "--to-spaces", "internal")
+	c.Assert(err, jc.ErrorIsNil)
+	svc, err := s.State.Application("some-application-name")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(svc.IsExposed(), jc.IsTrue)
+	c.Assert(svc.ExposedCIDRs(), jc.DeepEquals, []string{"10.0.0.0/8", "192.168.1.0/24"})
+	c.Assert(svc.ExposedSpaces(), jc.DeepEquals, []string{"internal"})
+
+	// Exposing again without restrictions removes them.
+	err = runExpose(c, "some-application-name")
+	c.Assert(err, jc.ErrorIsNil)
+	err = svc.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(svc.IsExposed(), jc.IsTrue)
+	c.Assert(svc.ExposedCIDRs(), gc.HasLen, 0)
+	c.Assert(svc.ExposedSpaces(), gc.HasLen, 0)
+}
+
+func (s *ExposeSuite) TestExposeToInvalidCIDR(c *gc.C) {
+	err := runExpose(c, "some-application-name", "--to-cidrs", "10.0.0.0")
+	c.Assert(err, gc.ErrorMatches, `invalid CIDR "10.0.0.0"`)
+}
+
 func (s *ExposeSuite) TestBlockExpose(c *gc.C) {
 	ch := testcharms.Repo.CharmArchivePath(s.CharmsPath, "dummy")
 	err := runDeploy(c, ch, "some-application-name", "--series", "trusty")
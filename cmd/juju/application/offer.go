@@ -0,0 +1,115 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageOfferSummary = `
+Offers application endpoints for use in other models.`[1:]
+
+var usageOfferDetails = `
+An offer makes endpoints of an application available to be related to by
+applications in other models hosted by the same controller. The offer
+is named after the application unless an offer name is specified.
+
+Applications in other models relate to an offered endpoint with
+add-relation, naming the offering model and the offer:
+    juju add-relation wordpress admin/prod.hosted-mysql:server
+
+Examples:
+    juju offer mysql:server
+    juju offer mysql:server,db-admin hosted-mysql
+
+See also: 
+    add-relation
+    remove-offer`[1:]
+
+// NewOfferCommand returns a command to offer application endpoints.
+func NewOfferCommand() cmd.Command {
+	return modelcmd.Wrap(&offerCommand{})
+}
+
+// offerCommand makes application endpoints available to other models.
+type offerCommand struct {
+	modelcmd.ModelCommandBase
+	ApplicationName string
+	Endpoints       []string
+	OfferName       string
+}
+
+func (c *offerCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "offer",
+		Args:    "<application name>:<endpoint>[,<endpoint> ...] [<offer name>]",
+		Purpose: usageOfferSummary,
+		Doc:     usageOfferDetails,
+	}
+}
+
+func (c *offerCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application endpoints specified")
+	}
+	parts := strings.SplitN(args[0], ":", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return errors.Errorf("no endpoints specified for application %q", parts[0])
+	}
+	c.ApplicationName = parts[0]
+	if !names.IsValidApplication(c.ApplicationName) {
+		return errors.NotValidf("application name %q", c.ApplicationName)
+	}
+	for _, endpoint := range strings.Split(parts[1], ",") {
+		if endpoint == "" {
+			return errors.Errorf("empty endpoint in %q", args[0])
+		}
+		c.Endpoints = append(c.Endpoints, endpoint)
+	}
+	c.OfferName = c.ApplicationName
+	if len(args) == 1 {
+		return nil
+	}
+	c.OfferName = args[1]
+	if !names.IsValidApplication(c.OfferName) {
+		return errors.NotValidf("offer name %q", c.OfferName)
+	}
+	return cmd.CheckEmpty(args[2:])
+}
+
+type offerAPI interface {
+	Close() error
+	Offer(application, offerName string, endpoints map[string]string) error
+}
+
+func (c *offerCommand) getAPI() (offerAPI, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return application.NewClient(root), nil
+}
+
+// Run offers the application's endpoints under their own names.
+func (c *offerCommand) Run(_ *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	endpoints := make(map[string]string)
+	for _, endpoint := range c.Endpoints {
+		endpoints[endpoint] = endpoint
+	}
+	err = client.Offer(c.ApplicationName, c.OfferName, endpoints)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
@@ -0,0 +1,93 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/common"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testcharms"
+	"github.com/juju/juju/testing"
+)
+
+type OfferSuite struct {
+	jujutesting.RepoSuite
+	common.CmdBlockHelper
+}
+
+func (s *OfferSuite) SetUpTest(c *gc.C) {
+	s.RepoSuite.SetUpTest(c)
+	s.CmdBlockHelper = common.NewCmdBlockHelper(s.APIState)
+	c.Assert(s.CmdBlockHelper, gc.NotNil)
+	s.AddCleanup(func(*gc.C) { s.CmdBlockHelper.Close() })
+}
+
+var _ = gc.Suite(&OfferSuite{})
+
+func runOffer(c *gc.C, args ...string) error {
+	_, err := testing.RunCommand(c, NewOfferCommand(), args...)
+	return err
+}
+
+func (s *OfferSuite) TestInit(c *gc.C) {
+	for _, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no application endpoints specified",
+	}, {
+		args: []string{"mysql"},
+		err:  `no endpoints specified for application "mysql"`,
+	}, {
+		args: []string{"mysql:server,"},
+		err:  `empty endpoint in "mysql:server,"`,
+	}, {
+		args: []string{"MySQL:server"},
+		err:  `application name "MySQL" not valid`,
+	}, {
+		args: []string{"mysql:server", "Hosted"},
+		err:  `offer name "Hosted" not valid`,
+	}, {
+		args: []string{"mysql:server", "hosted-mysql", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("args: %q", test.args)
+		err := runOffer(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *OfferSuite) TestOffer(c *gc.C) {
+	ch := testcharms.Repo.CharmArchivePath(s.CharmsPath, "mysql")
+	err := runDeploy(c, ch, "mysql", "--series", "trusty")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = runOffer(c, "mysql:server", "hosted-mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	offer, err := s.State.ApplicationOffer("hosted-mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(offer, jc.DeepEquals, &state.ApplicationOffer{
+		OfferName:       "hosted-mysql",
+		ApplicationName: "mysql",
+		Endpoints:       map[string]string{"server": "server"},
+	})
+
+	// The offer is named after the application by default.
+	err = runOffer(c, "mysql:server")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ApplicationOffer("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *OfferSuite) TestBlockOffer(c *gc.C) {
+	// Block operation
+	s.BlockAllChanges(c, "TestBlockOffer")
+
+	err := runOffer(c, "mysql:server")
+	s.AssertBlocked(c, err, ".*TestBlockOffer.*")
+}
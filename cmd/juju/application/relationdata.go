@@ -0,0 +1,163 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageShowRelationDataSummary = `
+Displays the relation settings of the units in a relation.`[1:]
+
+var usageShowRelationDataDetails = `
+Shows, for each side of the relation between the two specified
+applications, the endpoint and role of the application and the relation
+settings published by each of its units. Units that have not joined the
+relation, or have already departed it, are listed as not in scope and
+have no settings.
+
+In the case that there is more than one relation between two applications
+it is necessary to specify the relation name of at least one endpoint.
+
+A peer relation is shown by specifying its single endpoint, as the
+application and relation name.
+
+Examples:
+    juju show-relation-data wordpress mysql
+    juju show-relation-data wordpress:db mysql --format json
+    juju show-relation-data riak:ring
+
+See also:
+    add-relation
+    remove-relation`
+
+// NewShowRelationDataCommand returns a command that displays the
+// settings of every unit taking part in a relation.
+func NewShowRelationDataCommand() cmd.Command {
+	return modelcmd.Wrap(&showRelationDataCommand{})
+}
+
+// showRelationDataCommand displays the relation settings of the
+// units in a relation.
+type showRelationDataCommand struct {
+	modelcmd.ModelCommandBase
+	Endpoints []string
+	out       cmd.Output
+	api       relationDataAPI
+}
+
+func (c *showRelationDataCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-relation-data",
+		Args:    "<application1>[:<relation name1>] [<application2>[:<relation name2>]]",
+		Purpose: usageShowRelationDataSummary,
+		Doc:     usageShowRelationDataDetails,
+	}
+}
+
+func (c *showRelationDataCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+func (c *showRelationDataCommand) Init(args []string) error {
+	switch len(args) {
+	case 1:
+		if !strings.Contains(args[0], ":") {
+			return errors.New("a peer relation must be specified as <application>:<relation name>")
+		}
+	case 2:
+	default:
+		return errors.New("a relation must involve two applications, or one peer endpoint")
+	}
+	c.Endpoints = args
+	return nil
+}
+
+// relationDataAPI defines the methods on the client API
+// that the show-relation-data command calls.
+type relationDataAPI interface {
+	Close() error
+	RelationData(endpoints ...string) (*params.RelationDataResult, error)
+}
+
+func (c *showRelationDataCommand) getAPI() (relationDataAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return application.NewClient(root), nil
+}
+
+// Run fetches the relation settings and writes them out.
+func (c *showRelationDataCommand) Run(ctx *cmd.Context) error {
+	apiclient, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer apiclient.Close()
+
+	result, err := apiclient.RelationData(c.Endpoints...)
+	if err != nil {
+		return err
+	}
+	return c.out.Write(ctx, formatRelationData(result))
+}
+
+// relationData is the serialization format for the output of the
+// show-relation-data command.
+type relationData struct {
+	RelationId   int                                `yaml:"relation-id" json:"relation-id"`
+	Key          string                             `yaml:"key" json:"key"`
+	Applications map[string]relationApplicationData `yaml:"applications" json:"applications"`
+}
+
+type relationApplicationData struct {
+	Endpoint string                      `yaml:"endpoint" json:"endpoint"`
+	Role     string                      `yaml:"role" json:"role"`
+	Units    map[string]relationUnitData `yaml:"units,omitempty" json:"units,omitempty"`
+}
+
+type relationUnitData struct {
+	InScope  bool                   `yaml:"in-scope" json:"in-scope"`
+	Settings map[string]interface{} `yaml:"settings,omitempty" json:"settings,omitempty"`
+}
+
+func formatRelationData(result *params.RelationDataResult) relationData {
+	out := relationData{
+		RelationId:   result.RelationId,
+		Key:          result.Key,
+		Applications: make(map[string]relationApplicationData),
+	}
+	for _, app := range result.Applications {
+		appData := relationApplicationData{
+			Endpoint: app.Endpoint,
+			Role:     app.Role,
+		}
+		if len(app.Units) > 0 {
+			appData.Units = make(map[string]relationUnitData)
+		}
+		for _, unit := range app.Units {
+			appData.Units[unit.Unit] = relationUnitData{
+				InScope:  unit.InScope,
+				Settings: unit.Settings,
+			}
+		}
+		out.Applications[app.Application] = appData
+	}
+	return out
+}
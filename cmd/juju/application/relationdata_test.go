@@ -0,0 +1,143 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/application"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ShowRelationDataSuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+	fake *fakeRelationDataAPI
+}
+
+var _ = gc.Suite(&ShowRelationDataSuite{})
+
+func (s *ShowRelationDataSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeRelationDataAPI{
+		result: &params.RelationDataResult{
+			RelationId: 1,
+			Key:        "wordpress:db mysql:server",
+			Applications: []params.RelationApplicationData{{
+				Application: "wordpress",
+				Endpoint:    "db",
+				Role:        "requirer",
+				Units: []params.RelationUnitData{{
+					Unit:     "wordpress/0",
+					InScope:  true,
+					Settings: map[string]interface{}{"private-address": "10.0.0.1"},
+				}},
+			}, {
+				Application: "mysql",
+				Endpoint:    "server",
+				Role:        "provider",
+				Units: []params.RelationUnitData{{
+					Unit:     "mysql/0",
+					InScope:  true,
+					Settings: map[string]interface{}{"password": "sekrit"},
+				}, {
+					Unit: "mysql/1",
+				}},
+			}},
+		},
+	}
+}
+
+func (s *ShowRelationDataSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "a relation must involve two applications, or one peer endpoint",
+	}, {
+		args: []string{"wordpress", "mysql", "extra"},
+		err:  "a relation must involve two applications, or one peer endpoint",
+	}, {
+		args: []string{"wordpress"},
+		err:  "a peer relation must be specified as <application>:<relation name>",
+	}, {
+		args: []string{"riak:ring"},
+	}, {
+		args: []string{"wordpress", "mysql:server"},
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := coretesting.InitCommand(application.NewShowRelationDataCommandForTest(s.fake), test.args)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (s *ShowRelationDataSuite) TestShowRelationData(c *gc.C) {
+	ctx, err := coretesting.RunCommand(c, application.NewShowRelationDataCommandForTest(s.fake), "wordpress", "mysql:server")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stdout(ctx), jc.YAMLEquals, map[string]interface{}{
+		"relation-id": 1,
+		"key":         "wordpress:db mysql:server",
+		"applications": map[string]interface{}{
+			"wordpress": map[string]interface{}{
+				"endpoint": "db",
+				"role":     "requirer",
+				"units": map[string]interface{}{
+					"wordpress/0": map[string]interface{}{
+						"in-scope": true,
+						"settings": map[string]interface{}{"private-address": "10.0.0.1"},
+					},
+				},
+			},
+			"mysql": map[string]interface{}{
+				"endpoint": "server",
+				"role":     "provider",
+				"units": map[string]interface{}{
+					"mysql/0": map[string]interface{}{
+						"in-scope": true,
+						"settings": map[string]interface{}{"password": "sekrit"},
+					},
+					"mysql/1": map[string]interface{}{
+						"in-scope": false,
+					},
+				},
+			},
+		},
+	})
+	s.fake.CheckCalls(c, []jujutesting.StubCall{
+		{"RelationData", []interface{}{[]string{"wordpress", "mysql:server"}}},
+		{"Close", nil},
+	})
+}
+
+func (s *ShowRelationDataSuite) TestShowRelationDataError(c *gc.C) {
+	s.fake.SetErrors(errors.NotFoundf(`relation "wordpress:db mysql:server"`))
+	_, err := coretesting.RunCommand(c, application.NewShowRelationDataCommandForTest(s.fake), "wordpress", "mysql")
+	c.Assert(err, gc.ErrorMatches, `relation "wordpress:db mysql:server" not found`)
+}
+
+type fakeRelationDataAPI struct {
+	jujutesting.Stub
+	result *params.RelationDataResult
+}
+
+func (f *fakeRelationDataAPI) RelationData(endpoints ...string) (*params.RelationDataResult, error) {
+	f.MethodCall(f, "RelationData", endpoints)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return f.result, nil
+}
+
+func (f *fakeRelationDataAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
@@ -0,0 +1,84 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageRemoveOfferSummary = `
+Removes an application offer.`[1:]
+
+var usageRemoveOfferDetails = `
+Removing an offer stops applications in other models from relating to
+the offered endpoints. Relations already made through the offer are not
+affected; remove them with remove-relation.
+
+Examples:
+    juju remove-offer hosted-mysql
+
+See also: 
+    offer
+    remove-relation`[1:]
+
+// NewRemoveOfferCommand returns a command to remove application offers.
+func NewRemoveOfferCommand() cmd.Command {
+	return modelcmd.Wrap(&removeOfferCommand{})
+}
+
+// removeOfferCommand removes an application offer.
+type removeOfferCommand struct {
+	modelcmd.ModelCommandBase
+	OfferName string
+}
+
+func (c *removeOfferCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "remove-offer",
+		Args:    "<offer name>",
+		Purpose: usageRemoveOfferSummary,
+		Doc:     usageRemoveOfferDetails,
+	}
+}
+
+func (c *removeOfferCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no offer name specified")
+	}
+	c.OfferName = args[0]
+	if !names.IsValidApplication(c.OfferName) {
+		return errors.NotValidf("offer name %q", c.OfferName)
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+type removeOfferAPI interface {
+	Close() error
+	RemoveOffer(offerName string) error
+}
+
+func (c *removeOfferCommand) getAPI() (removeOfferAPI, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return application.NewClient(root), nil
+}
+
+// Run removes the application offer.
+func (c *removeOfferCommand) Run(_ *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	err = client.RemoveOffer(c.OfferName)
+	return block.ProcessBlockedError(err, block.BlockRemove)
+}
@@ -0,0 +1,78 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/common"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/testcharms"
+	"github.com/juju/juju/testing"
+)
+
+type RemoveOfferSuite struct {
+	jujutesting.RepoSuite
+	common.CmdBlockHelper
+}
+
+func (s *RemoveOfferSuite) SetUpTest(c *gc.C) {
+	s.RepoSuite.SetUpTest(c)
+	s.CmdBlockHelper = common.NewCmdBlockHelper(s.APIState)
+	c.Assert(s.CmdBlockHelper, gc.NotNil)
+	s.AddCleanup(func(*gc.C) { s.CmdBlockHelper.Close() })
+}
+
+var _ = gc.Suite(&RemoveOfferSuite{})
+
+func runRemoveOffer(c *gc.C, args ...string) error {
+	_, err := testing.RunCommand(c, NewRemoveOfferCommand(), args...)
+	return err
+}
+
+func (s *RemoveOfferSuite) TestInit(c *gc.C) {
+	for _, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no offer name specified",
+	}, {
+		args: []string{"Hosted"},
+		err:  `offer name "Hosted" not valid`,
+	}, {
+		args: []string{"hosted-mysql", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("args: %q", test.args)
+		err := runRemoveOffer(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *RemoveOfferSuite) TestRemoveOffer(c *gc.C) {
+	ch := testcharms.Repo.CharmArchivePath(s.CharmsPath, "mysql")
+	err := runDeploy(c, ch, "mysql", "--series", "trusty")
+	c.Assert(err, jc.ErrorIsNil)
+	err = runOffer(c, "mysql:server", "hosted-mysql")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = runRemoveOffer(c, "hosted-mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ApplicationOffer("hosted-mysql")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = runRemoveOffer(c, "hosted-mysql")
+	c.Assert(err, gc.ErrorMatches, `application offer "hosted-mysql" not found`)
+}
+
+func (s *RemoveOfferSuite) TestBlockRemoveOffer(c *gc.C) {
+	// Block operation
+	s.BlockRemoveObject(c, "TestBlockRemoveOffer")
+
+	err := runRemoveOffer(c, "hosted-mysql")
+	s.AssertBlocked(c, err, ".*TestBlockRemoveOffer.*")
+}
@@ -0,0 +1,114 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/network"
+)
+
+var usageSetEgressSummary = `
+Sets the egress traffic permitted from machines in the model.`[1:]
+
+var usageSetEgressDetails = `
+Egress rules restrict the outbound traffic of machines to an allow list.
+Model egress rules apply to every machine in the model; application
+egress rules apply, in addition, to the machines hosting the
+application's units. Machines with no applicable egress rules may send
+traffic anywhere.
+
+Each rule is a port range and protocol, optionally followed by "@" and
+a comma-separated list of destination address ranges in CIDR notation.
+A rule without destination address ranges permits traffic to any
+address. Setting egress rules replaces any previous rules; --reset
+removes them.
+
+Egress rules may only be set if the model's cloud supports restricting
+egress traffic.
+
+Examples:
+    juju set-egress 53/udp 443/tcp@10.0.0.0/8
+    juju set-egress mysql 3306/tcp@192.168.1.0/24
+    juju set-egress mysql --reset
+
+See also: 
+    expose`[1:]
+
+// NewSetEgressCommand returns a command to set egress rules.
+func NewSetEgressCommand() cmd.Command {
+	return modelcmd.Wrap(&setEgressCommand{})
+}
+
+// setEgressCommand sets the egress rules of the model or of an
+// application.
+type setEgressCommand struct {
+	modelcmd.ModelCommandBase
+	ApplicationName string
+	Rules           []network.EgressRule
+	Reset           bool
+}
+
+func (c *setEgressCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "set-egress",
+		Args:    "[<application name>] [<rule> ...]",
+		Purpose: usageSetEgressSummary,
+		Doc:     usageSetEgressDetails,
+	}
+}
+
+func (c *setEgressCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.BoolVar(&c.Reset, "reset", false, "Remove all egress rules")
+}
+
+func (c *setEgressCommand) Init(args []string) error {
+	if len(args) > 0 && names.IsValidApplication(args[0]) {
+		c.ApplicationName, args = args[0], args[1:]
+	}
+	for _, arg := range args {
+		rule, err := network.ParseEgressRule(arg)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.Rules = append(c.Rules, rule)
+	}
+	switch {
+	case c.Reset && len(c.Rules) > 0:
+		return errors.New("cannot specify egress rules with --reset")
+	case !c.Reset && len(c.Rules) == 0:
+		return errors.New("no egress rules specified")
+	}
+	return nil
+}
+
+type setEgressAPI interface {
+	Close() error
+	SetEgressRules(application string, rules []network.EgressRule) error
+}
+
+func (c *setEgressCommand) getAPI() (setEgressAPI, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return application.NewClient(root), nil
+}
+
+// Run sets the egress rules of the model or of an application.
+func (c *setEgressCommand) Run(_ *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	err = client.SetEgressRules(c.ApplicationName, c.Rules)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
@@ -0,0 +1,102 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/common"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/testcharms"
+	"github.com/juju/juju/testing"
+)
+
+type SetEgressSuite struct {
+	jujutesting.RepoSuite
+	common.CmdBlockHelper
+}
+
+func (s *SetEgressSuite) SetUpTest(c *gc.C) {
+	s.RepoSuite.SetUpTest(c)
+	s.CmdBlockHelper = common.NewCmdBlockHelper(s.APIState)
+	c.Assert(s.CmdBlockHelper, gc.NotNil)
+	s.AddCleanup(func(*gc.C) { s.CmdBlockHelper.Close() })
+}
+
+var _ = gc.Suite(&SetEgressSuite{})
+
+func runSetEgress(c *gc.C, args ...string) error {
+	_, err := testing.RunCommand(c, NewSetEgressCommand(), args...)
+	return err
+}
+
+func (s *SetEgressSuite) TestInit(c *gc.C) {
+	for _, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no egress rules specified",
+	}, {
+		args: []string{"mysql"},
+		err:  "no egress rules specified",
+	}, {
+		args: []string{"--reset", "53/udp"},
+		err:  "cannot specify egress rules with --reset",
+	}, {
+		args: []string{"443/tcp@10.0.0.0"},
+		err:  `invalid egress rule "443/tcp@10.0.0.0": invalid destination CIDR "10.0.0.0"`,
+	}} {
+		c.Logf("args: %q", test.args)
+		err := runSetEgress(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *SetEgressSuite) TestSetModelEgress(c *gc.C) {
+	err := runSetEgress(c, "53/udp", "443/tcp@10.0.0.0/8")
+	c.Assert(err, jc.ErrorIsNil)
+	cfg, err := s.State.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.EgressRules(), jc.DeepEquals, []network.EgressRule{
+		network.NewEgressRule(network.PortRange{53, 53, "udp"}),
+		network.NewEgressRule(network.PortRange{443, 443, "tcp"}, "10.0.0.0/8"),
+	})
+
+	err = runSetEgress(c, "--reset")
+	c.Assert(err, jc.ErrorIsNil)
+	cfg, err = s.State.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.EgressRules(), gc.HasLen, 0)
+}
+
+func (s *SetEgressSuite) TestSetApplicationEgress(c *gc.C) {
+	ch := testcharms.Repo.CharmArchivePath(s.CharmsPath, "dummy")
+	err := runDeploy(c, ch, "some-application-name", "--series", "trusty")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = runSetEgress(c, "some-application-name", "3306/tcp@192.168.1.0/24")
+	c.Assert(err, jc.ErrorIsNil)
+	svc, err := s.State.Application("some-application-name")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(svc.EgressRules(), jc.DeepEquals, []network.EgressRule{
+		network.NewEgressRule(network.PortRange{3306, 3306, "tcp"}, "192.168.1.0/24"),
+	})
+
+	err = runSetEgress(c, "some-application-name", "--reset")
+	c.Assert(err, jc.ErrorIsNil)
+	err = svc.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(svc.EgressRules(), gc.HasLen, 0)
+}
+
+func (s *SetEgressSuite) TestBlockSetEgress(c *gc.C) {
+	// Block operation
+	s.BlockAllChanges(c, "TestBlockSetEgress")
+
+	err := runSetEgress(c, "53/udp")
+	s.AssertBlocked(c, err, ".*TestBlockSetEgress.*")
+}
@@ -0,0 +1,131 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageSetVirtualIPSummary = `
+Allocates a virtual IP address to an application.`[1:]
+
+var usageSetVirtualIPDetails = `
+A virtual IP address is allocated from a known subnet and follows the
+application's leader: it is assigned to the machine hosting the leader
+unit, and moved to the machine hosting the new leader whenever
+leadership changes.
+
+If no address is given, a free address of the subnet is chosen. The
+allocated address is printed. --release removes the application's
+virtual IP address.
+
+Virtual IP addresses may only be allocated if the model's cloud
+supports moving addresses between machines.
+
+Examples:
+    juju set-virtual-ip mysql 10.0.0.0/24
+    juju set-virtual-ip mysql 10.0.0.0/24 10.0.0.200
+    juju set-virtual-ip mysql --release
+
+See also: 
+    set-egress`[1:]
+
+// NewSetVirtualIPCommand returns a command to allocate or release
+// an application's virtual IP address.
+func NewSetVirtualIPCommand() cmd.Command {
+	return modelcmd.Wrap(&setVirtualIPCommand{})
+}
+
+// setVirtualIPCommand allocates or releases the virtual IP address
+// of an application.
+type setVirtualIPCommand struct {
+	modelcmd.ModelCommandBase
+	ApplicationName string
+	SubnetCIDR      string
+	Address         string
+	Release         bool
+}
+
+func (c *setVirtualIPCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "set-virtual-ip",
+		Args:    "<application name> [<subnet CIDR> [<address>]]",
+		Purpose: usageSetVirtualIPSummary,
+		Doc:     usageSetVirtualIPDetails,
+	}
+}
+
+func (c *setVirtualIPCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.BoolVar(&c.Release, "release", false, "Release the application's virtual IP address")
+}
+
+func (c *setVirtualIPCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application name specified")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.NotValidf("application name %q", args[0])
+	}
+	c.ApplicationName, args = args[0], args[1:]
+	if c.Release {
+		return cmd.CheckEmpty(args)
+	}
+	if len(args) == 0 {
+		return errors.New("no subnet CIDR specified")
+	}
+	if _, _, err := net.ParseCIDR(args[0]); err != nil {
+		return errors.NotValidf("subnet CIDR %q", args[0])
+	}
+	c.SubnetCIDR, args = args[0], args[1:]
+	if len(args) > 0 {
+		if net.ParseIP(args[0]) == nil {
+			return errors.NotValidf("address %q", args[0])
+		}
+		c.Address, args = args[0], args[1:]
+	}
+	return cmd.CheckEmpty(args)
+}
+
+type setVirtualIPAPI interface {
+	Close() error
+	AllocateVirtualIPAddress(application, subnetCIDR, address string) (string, error)
+	ReleaseVirtualIPAddress(application string) error
+}
+
+func (c *setVirtualIPCommand) getAPI() (setVirtualIPAPI, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return application.NewClient(root), nil
+}
+
+// Run allocates or releases the virtual IP address of an application.
+func (c *setVirtualIPCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	if c.Release {
+		err := client.ReleaseVirtualIPAddress(c.ApplicationName)
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	address, err := client.AllocateVirtualIPAddress(c.ApplicationName, c.SubnetCIDR, c.Address)
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	fmt.Fprintln(ctx.Stdout, address)
+	return nil
+}
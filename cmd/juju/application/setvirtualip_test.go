@@ -0,0 +1,98 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/common"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testcharms"
+	"github.com/juju/juju/testing"
+)
+
+type SetVirtualIPSuite struct {
+	jujutesting.RepoSuite
+	common.CmdBlockHelper
+}
+
+func (s *SetVirtualIPSuite) SetUpTest(c *gc.C) {
+	s.RepoSuite.SetUpTest(c)
+	s.CmdBlockHelper = common.NewCmdBlockHelper(s.APIState)
+	c.Assert(s.CmdBlockHelper, gc.NotNil)
+	s.AddCleanup(func(*gc.C) { s.CmdBlockHelper.Close() })
+}
+
+var _ = gc.Suite(&SetVirtualIPSuite{})
+
+func runSetVirtualIP(c *gc.C, args ...string) (string, error) {
+	ctx, err := testing.RunCommand(c, NewSetVirtualIPCommand(), args...)
+	if err != nil {
+		return "", err
+	}
+	return testing.Stdout(ctx), nil
+}
+
+func (s *SetVirtualIPSuite) TestInit(c *gc.C) {
+	for _, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no application name specified",
+	}, {
+		args: []string{"mysql"},
+		err:  "no subnet CIDR specified",
+	}, {
+		args: []string{"mysql", "10.0.0.0"},
+		err:  `subnet CIDR "10.0.0.0" not valid`,
+	}, {
+		args: []string{"mysql", "10.0.0.0/24", "foo"},
+		err:  `address "foo" not valid`,
+	}, {
+		args: []string{"mysql", "10.0.0.0/24", "10.0.0.200", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}, {
+		args: []string{"mysql", "--release", "10.0.0.0/24"},
+		err:  `unrecognized args: \["10.0.0.0/24"\]`,
+	}} {
+		c.Logf("args: %q", test.args)
+		_, err := runSetVirtualIP(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *SetVirtualIPSuite) TestSetVirtualIP(c *gc.C) {
+	_, err := s.State.AddSubnet(state.SubnetInfo{CIDR: "10.0.0.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	ch := testcharms.Repo.CharmArchivePath(s.CharmsPath, "dummy")
+	err = runDeploy(c, ch, "some-application-name", "--series", "trusty")
+	c.Assert(err, jc.ErrorIsNil)
+
+	out, err := runSetVirtualIP(c, "some-application-name", "10.0.0.0/24", "10.0.0.200")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, "10.0.0.200\n")
+	svc, err := s.State.Application("some-application-name")
+	c.Assert(err, jc.ErrorIsNil)
+	address, subnetCIDR := svc.VirtualIPAddress()
+	c.Assert(address, gc.Equals, "10.0.0.200")
+	c.Assert(subnetCIDR, gc.Equals, "10.0.0.0/24")
+
+	_, err = runSetVirtualIP(c, "some-application-name", "--release")
+	c.Assert(err, jc.ErrorIsNil)
+	err = svc.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	address, _ = svc.VirtualIPAddress()
+	c.Assert(address, gc.Equals, "")
+}
+
+func (s *SetVirtualIPSuite) TestBlockSetVirtualIP(c *gc.C) {
+	// Block operation
+	s.BlockAllChanges(c, "TestBlockSetVirtualIP")
+
+	_, err := runSetVirtualIP(c, "some-application-name", "--release")
+	s.AssertBlocked(c, err, ".*TestBlockSetVirtualIP.*")
+}
@@ -232,6 +232,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(status.NewStatusCommand())
 	r.Register(newSwitchCommand())
 	r.Register(status.NewStatusHistoryCommand())
+	r.Register(status.NewHookHistoryCommand())
 
 	// Error resolution and debugging commands.
 	r.Register(newRunCommand())
@@ -317,10 +318,16 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	// Manage and control services
 	r.Register(application.NewAddUnitCommand())
 	r.Register(application.NewGetCommand())
+	r.Register(application.NewShowRelationDataCommand())
 	r.Register(application.NewSetCommand())
 	r.Register(application.NewDeployCommand())
 	r.Register(application.NewExposeCommand())
 	r.Register(application.NewUnexposeCommand())
+	r.Register(application.NewSetEgressCommand())
+	r.Register(application.NewSetVirtualIPCommand())
+	r.Register(application.NewOfferCommand())
+	r.Register(application.NewRemoveOfferCommand())
+	r.Register(application.NewBindCommand())
 	r.Register(application.NewServiceGetConstraintsCommand())
 	r.Register(application.NewServiceSetConstraintsCommand())
 
@@ -333,7 +340,17 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(storage.NewListCommand())
 	r.Register(storage.NewPoolCreateCommand())
 	r.Register(storage.NewPoolListCommand())
+	r.Register(storage.NewPoolUpdateCommand())
 	r.Register(storage.NewShowCommand())
+	r.Register(storage.NewSnapshotCommand())
+	r.Register(storage.NewSnapshotListCommand())
+	r.Register(storage.NewSnapshotRemoveCommand())
+	r.Register(storage.NewResizeCommand())
+	r.Register(storage.NewAttachCommand())
+	r.Register(storage.NewDetachCommand())
+	r.Register(storage.NewRemoveCommand())
+	r.Register(storage.NewImportFilesystemCommand())
+	r.Register(storage.NewStorageQuotaCommand())
 
 	// Manage spaces
 	r.Register(space.NewAddCommand())
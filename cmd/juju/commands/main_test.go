@@ -370,8 +370,10 @@ var commandNames = []string{
 	"agree",
 	"agreements",
 	"allocate",
+	"attach-storage",
 	"autoload-credentials",
 	"backups",
+	"bind",
 	"block",
 	"blocks",
 	"bootstrap",
@@ -395,6 +397,7 @@ var commandNames = []string{
 	"destroy-relation",
 	"destroy-application",
 	"destroy-unit",
+	"detach-storage",
 	"disable-user",
 	"download-backup",
 	"enable-ha",
@@ -410,6 +413,7 @@ var commandNames = []string{
 	"gui",
 	"help",
 	"help-tool",
+	"import-filesystem",
 	"import-ssh-key",
 	"import-ssh-keys",
 	"kill-controller",
@@ -433,6 +437,7 @@ var commandNames = []string{
 	"list-spaces",
 	"list-storage",
 	"list-storage-pools",
+	"list-storage-snapshots",
 	"list-subnets",
 	"list-users",
 	"login",
@@ -440,6 +445,7 @@ var commandNames = []string{
 	"machine",
 	"machines",
 	"models",
+	"offer",
 	"plans",
 	"publish",
 	"register",
@@ -452,10 +458,14 @@ var commandNames = []string{
 	"remove-credential",
 	"remove-machine",
 	"remove-machines",
+	"remove-offer",
 	"remove-relation", // alias for destroy-relation
 	"remove-ssh-key",
 	"remove-ssh-keys",
+	"remove-storage",
+	"remove-storage-snapshot",
 	"remove-unit", // alias for destroy-unit
+	"resize-storage",
 	"resolved",
 	"restore-backup",
 	"retry-provisioning",
@@ -469,10 +479,12 @@ var commandNames = []string{
 	"set-constraints",
 	"set-default-credential",
 	"set-default-region",
+	"set-egress",
 	"set-meter-status",
 	"set-model-config",
 	"set-model-constraints",
 	"set-plan",
+	"set-virtual-ip",
 	"ssh-key",
 	"ssh-keys",
 	"shares",
@@ -483,18 +495,23 @@ var commandNames = []string{
 	"show-cloud",
 	"show-controller",
 	"show-controllers",
+	"show-hook-history",
 	"show-machine",
 	"show-machines",
 	"show-model",
+	"show-relation-data",
 	"show-status",
 	"show-storage",
 	"show-user",
+	"snapshot-storage",
 	"spaces",
 	"ssh",
 	"status",
 	"status-history",
 	"storage",
 	"storage-pools",
+	"storage-quota",
+	"storage-snapshots",
 	"subnets",
 	"switch",
 	"sync-tools",
@@ -505,6 +522,7 @@ var commandNames = []string{
 	"unregister",
 	"unset-model-config",
 	"update-clouds",
+	"update-storage-pool",
 	"upgrade-charm",
 	"upgrade-gui",
 	"upgrade-juju",
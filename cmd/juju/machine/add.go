@@ -44,6 +44,11 @@ also possible to add containers to existing machines using the format
 deploying a container to an existing machine. The currently supported
 container types are: $CONTAINER_TYPES$.
 
+A static IP address can be requested for a new container by appending
+"@<address>" to the placement, e.g. "lxd:4@10.0.0.5" or "lxd:@10.0.0.5".
+The address must be part of a subnet known to Juju, and not be in use by
+any other machine.
+
 Manual provisioning is the process of installing Juju on an existing machine
 and bringing it under Juju's management; currently this requires that the
 machine be running Ubuntu, that it be accessible via SSH, and be running on
@@ -61,6 +66,8 @@ Examples:
    juju add-machine lxd                  (starts a new machine with an lxd container)
    juju add-machine lxd -n 2             (starts 2 new machines with an lxd container)
    juju add-machine lxd:4                (starts a new lxd container on machine 4)
+   juju add-machine lxd:4@10.0.0.5       (starts a new lxd container on machine 4
+                                          using the static IP address 10.0.0.5)
    juju add-machine --constraints mem=8G (starts a machine with at least 8GB RAM)
    juju add-machine ssh:user@10.10.0.3   (manually provisions a machine with ssh)
    juju add-machine zone=us-east-1a      (start a machine in zone us-east-1a on AWS)
@@ -36,16 +36,17 @@ type modelStatus struct {
 }
 
 type machineStatus struct {
-	Err           error                    `json:"-" yaml:",omitempty"`
-	JujuStatus    statusInfoContents       `json:"juju-status,omitempty" yaml:"juju-status,omitempty"`
-	DNSName       string                   `json:"dns-name,omitempty" yaml:"dns-name,omitempty"`
-	InstanceId    instance.Id              `json:"instance-id,omitempty" yaml:"instance-id,omitempty"`
-	MachineStatus statusInfoContents       `json:"machine-status,omitempty" yaml:"machine-status,omitempty"`
-	Series        string                   `json:"series,omitempty" yaml:"series,omitempty"`
-	Id            string                   `json:"-" yaml:"-"`
-	Containers    map[string]machineStatus `json:"containers,omitempty" yaml:"containers,omitempty"`
-	Hardware      string                   `json:"hardware,omitempty" yaml:"hardware,omitempty"`
-	HAStatus      string                   `json:"controller-member-status,omitempty" yaml:"controller-member-status,omitempty"`
+	Err             error                    `json:"-" yaml:",omitempty"`
+	JujuStatus      statusInfoContents       `json:"juju-status,omitempty" yaml:"juju-status,omitempty"`
+	DNSName         string                   `json:"dns-name,omitempty" yaml:"dns-name,omitempty"`
+	InstanceId      instance.Id              `json:"instance-id,omitempty" yaml:"instance-id,omitempty"`
+	MachineStatus   statusInfoContents       `json:"machine-status,omitempty" yaml:"machine-status,omitempty"`
+	Series          string                   `json:"series,omitempty" yaml:"series,omitempty"`
+	Id              string                   `json:"-" yaml:"-"`
+	Containers      map[string]machineStatus `json:"containers,omitempty" yaml:"containers,omitempty"`
+	Hardware        string                   `json:"hardware,omitempty" yaml:"hardware,omitempty"`
+	HAStatus        string                   `json:"controller-member-status,omitempty" yaml:"controller-member-status,omitempty"`
+	FirewallWarning string                   `json:"firewall-warning,omitempty" yaml:"firewall-warning,omitempty"`
 }
 
 // A goyaml bug means we can't declare these types
@@ -68,6 +69,7 @@ func (s machineStatus) MarshalYAML() (interface{}, error) {
 
 type applicationStatus struct {
 	Err           error                 `json:"-" yaml:",omitempty"`
+	Version       string                `json:"version,omitempty" yaml:"version,omitempty"`
 	Charm         string                `json:"charm" yaml:"charm"`
 	Series        string                `json:"series"`
 	OS            string                `json:"os"`
@@ -110,11 +112,12 @@ type unitStatus struct {
 	JujuStatusInfo     statusInfoContents `json:"juju-status,omitempty" yaml:"juju-status"`
 	MeterStatus        *meterStatus       `json:"meter-status,omitempty" yaml:"meter-status,omitempty"`
 
-	Charm         string                `json:"upgrading-from,omitempty" yaml:"upgrading-from,omitempty"`
-	Machine       string                `json:"machine,omitempty" yaml:"machine,omitempty"`
-	OpenedPorts   []string              `json:"open-ports,omitempty" yaml:"open-ports,omitempty"`
-	PublicAddress string                `json:"public-address,omitempty" yaml:"public-address,omitempty"`
-	Subordinates  map[string]unitStatus `json:"subordinates,omitempty" yaml:"subordinates,omitempty"`
+	Charm           string                `json:"upgrading-from,omitempty" yaml:"upgrading-from,omitempty"`
+	Machine         string                `json:"machine,omitempty" yaml:"machine,omitempty"`
+	WorkloadVersion string                `json:"workload-version,omitempty" yaml:"workload-version,omitempty"`
+	OpenedPorts     []string              `json:"open-ports,omitempty" yaml:"open-ports,omitempty"`
+	PublicAddress   string                `json:"public-address,omitempty" yaml:"public-address,omitempty"`
+	Subordinates    map[string]unitStatus `json:"subordinates,omitempty" yaml:"subordinates,omitempty"`
 }
 
 type statusInfoContents struct {
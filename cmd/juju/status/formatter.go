@@ -109,9 +109,25 @@ func (sf *statusFormatter) formatMachine(machine params.MachineStatus) machineSt
 			break
 		}
 	}
+	if machine.FirewallDrift != nil {
+		out.FirewallWarning = makeFirewallWarning(*machine.FirewallDrift)
+	}
 	return out
 }
 
+// makeFirewallWarning describes how the ingress rules applied to a
+// machine differ from those expected.
+func makeFirewallWarning(drift params.FirewallDrift) string {
+	var parts []string
+	if len(drift.Missing) > 0 {
+		parts = append(parts, "missing "+strings.Join(drift.Missing, ", "))
+	}
+	if len(drift.Unexpected) > 0 {
+		parts = append(parts, "unexpected "+strings.Join(drift.Unexpected, ", "))
+	}
+	return "firewall rules differ from expected: " + strings.Join(parts, "; ")
+}
+
 func (sf *statusFormatter) formatApplication(name string, application params.ApplicationStatus) applicationStatus {
 	appOS, _ := series.GetOSFromSeries(application.Series)
 	var (
@@ -138,6 +154,7 @@ func (sf *statusFormatter) formatApplication(name string, application params.App
 
 	out := applicationStatus{
 		Err:           application.Err,
+		Version:       application.WorkloadVersion,
 		Charm:         application.Charm,
 		Series:        application.Series,
 		OS:            strings.ToLower(appOS.String()),
@@ -192,6 +209,7 @@ func (sf *statusFormatter) formatUnit(info unitFormatInfo) unitStatus {
 		WorkloadStatusInfo: sf.getWorkloadStatusInfo(info.unit),
 		JujuStatusInfo:     sf.getAgentStatusInfo(info.unit),
 		Machine:            info.unit.Machine,
+		WorkloadVersion:    info.unit.WorkloadVersion,
 		OpenedPorts:        info.unit.OpenedPorts,
 		PublicAddress:      info.unit.PublicAddress,
 		Charm:              info.unit.Charm,
@@ -0,0 +1,186 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/juju/osenv"
+)
+
+// HookHistoryAPI defines the API methods used by the
+// show-hook-history command.
+type HookHistoryAPI interface {
+	HookHistory(names.UnitTag) ([]params.HookExecution, error)
+	Close() error
+}
+
+// NewHookHistoryCommand returns a command that reports the most
+// recent hook executions of the specified unit.
+func NewHookHistoryCommand() cmd.Command {
+	return modelcmd.Wrap(&hookHistoryCommand{})
+}
+
+type hookHistoryCommand struct {
+	modelcmd.ModelCommandBase
+	out      cmd.Output
+	isoTime  bool
+	unitName string
+	api      HookHistoryAPI
+}
+
+var hookHistoryDoc = `
+Displays the most recent hook executions of a unit, oldest first.
+For each execution, the hook name, the relation and remote unit it
+was run for, when it started, how long it took, its exit code and
+the hook tools it invoked are shown.
+
+Only the most recent executions of each unit are kept by the
+controller.
+
+Examples:
+    juju show-hook-history mysql/0
+    juju show-hook-history mysql/0 --format yaml
+
+See also:
+    status-history
+`
+
+// Info implements Command.Info.
+func (c *hookHistoryCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-hook-history",
+		Args:    "<unit name>",
+		Purpose: "Output the most recent hook executions of a unit.",
+		Doc:     hookHistoryDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *hookHistoryCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": c.formatTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *hookHistoryCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no unit name specified")
+	case 1:
+		c.unitName = args[0]
+	default:
+		return cmd.CheckEmpty(args[1:])
+	}
+	if !names.IsValidUnit(c.unitName) {
+		return errors.NotValidf("unit name %q", c.unitName)
+	}
+	// If use of ISO time not specified on command line,
+	// check env var.
+	if !c.isoTime {
+		var err error
+		envVarValue := os.Getenv(osenv.JujuStatusIsoTimeEnvKey)
+		if envVarValue != "" {
+			if c.isoTime, err = strconv.ParseBool(envVarValue); err != nil {
+				return errors.Annotatef(err, "invalid %s env var, expected true|false", osenv.JujuStatusIsoTimeEnvKey)
+			}
+		}
+	}
+	return nil
+}
+
+func (c *hookHistoryCommand) getAPI() (HookHistoryAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewAPIClient()
+}
+
+// Run implements Command.Run.
+func (c *hookHistoryCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	executions, err := api.HookHistory(names.NewUnitTag(c.unitName))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(executions) == 0 {
+		ctx.Infof("no hook history available for unit %q", c.unitName)
+		return nil
+	}
+	output := make([]hookExecution, len(executions))
+	for i, exec := range executions {
+		output[i] = c.formatExecution(exec)
+	}
+	return c.out.Write(ctx, output)
+}
+
+// hookExecution is the serialization format for a single hook
+// execution in the show-hook-history output.
+type hookExecution struct {
+	Hook       string   `yaml:"hook" json:"hook"`
+	RelationId *int     `yaml:"relation-id,omitempty" json:"relation-id,omitempty"`
+	RemoteUnit string   `yaml:"remote-unit,omitempty" json:"remote-unit,omitempty"`
+	Started    string   `yaml:"started" json:"started"`
+	Duration   string   `yaml:"duration" json:"duration"`
+	ExitCode   int      `yaml:"exit-code" json:"exit-code"`
+	ToolCalls  []string `yaml:"tool-calls,omitempty" json:"tool-calls,omitempty"`
+}
+
+func (c *hookHistoryCommand) formatExecution(exec params.HookExecution) hookExecution {
+	out := hookExecution{
+		Hook:       exec.Hook,
+		RemoteUnit: exec.RemoteUnit,
+		Started:    common.FormatTime(&exec.Started, c.isoTime),
+		Duration:   (exec.Finished.Sub(exec.Started) / time.Millisecond * time.Millisecond).String(),
+		ExitCode:   exec.ExitCode,
+		ToolCalls:  exec.ToolCalls,
+	}
+	if exec.RelationId >= 0 {
+		relationId := exec.RelationId
+		out.RelationId = &relationId
+	}
+	return out
+}
+
+func (c *hookHistoryCommand) formatTabular(value interface{}) ([]byte, error) {
+	executions, ok := value.([]hookExecution)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", executions, value)
+	}
+	var out bytes.Buffer
+	tw := getTabWriter(&out)
+	p := printHelper(tw)
+	p("STARTED", "DURATION", "HOOK", "RELATION", "REMOTE-UNIT", "EXIT", "TOOLS")
+	for _, exec := range executions {
+		relation := ""
+		if exec.RelationId != nil {
+			relation = fmt.Sprint(*exec.RelationId)
+		}
+		p(exec.Started, exec.Duration, exec.Hook, relation, exec.RemoteUnit, exec.ExitCode, strings.Join(exec.ToolCalls, ","))
+	}
+	tw.Flush()
+	return out.Bytes(), nil
+}
@@ -0,0 +1,154 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type HookHistorySuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+	api   *fakeHookHistoryAPI
+	store *jujuclienttesting.MemStore
+}
+
+var _ = gc.Suite(&HookHistorySuite{})
+
+func (s *HookHistorySuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	started := time.Date(2016, 10, 1, 12, 0, 0, 0, time.UTC)
+	s.api = &fakeHookHistoryAPI{
+		executions: []params.HookExecution{{
+			Hook:       "install",
+			RelationId: -1,
+			Started:    started,
+			Finished:   started.Add(1500 * time.Millisecond),
+			ToolCalls:  []string{"status-set", "open-port"},
+		}, {
+			Hook:       "db-relation-changed",
+			RelationId: 3,
+			RemoteUnit: "mysql/0",
+			Started:    started.Add(time.Minute),
+			Finished:   started.Add(time.Minute + 250*time.Millisecond),
+			ExitCode:   1,
+		}},
+	}
+
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = &jujuclient.ControllerAccounts{
+		CurrentAccount: "admin@local",
+	}
+	err := s.store.UpdateModel("testing", "admin@local", "mymodel", jujuclient.ModelDetails{
+		coretesting.ModelTag.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].AccountModels["admin@local"].CurrentModel = "mymodel"
+}
+
+func (s *HookHistorySuite) run(c *gc.C, args ...string) (string, error) {
+	command := &hookHistoryCommand{api: s.api}
+	command.SetClientStore(s.store)
+	ctx, err := coretesting.RunCommand(c, modelcmd.Wrap(command), args...)
+	if err != nil {
+		return "", err
+	}
+	return coretesting.Stdout(ctx), nil
+}
+
+func (s *HookHistorySuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no unit name specified",
+	}, {
+		args: []string{"mysql/0", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}, {
+		args: []string{"mysql"},
+		err:  `unit name "mysql" not valid`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.run(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *HookHistorySuite) TestTabular(c *gc.C) {
+	out, err := s.run(c, "mysql/0", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, ""+
+		"STARTED               DURATION  HOOK                 RELATION  REMOTE-UNIT  EXIT  TOOLS                 \n"+
+		"2016-10-01 12:00:00Z  1.5s      install                                     0     status-set,open-port  \n"+
+		"2016-10-01 12:01:00Z  250ms     db-relation-changed  3         mysql/0      1                           \n",
+	)
+	s.api.CheckCalls(c, []gitjujutesting.StubCall{
+		{"HookHistory", []interface{}{names.NewUnitTag("mysql/0")}},
+		{"Close", nil},
+	})
+}
+
+func (s *HookHistorySuite) TestYAML(c *gc.C) {
+	out, err := s.run(c, "mysql/0", "--utc", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, jc.YAMLEquals, []interface{}{
+		map[string]interface{}{
+			"hook":       "install",
+			"started":    "2016-10-01 12:00:00Z",
+			"duration":   "1.5s",
+			"exit-code":  0,
+			"tool-calls": []interface{}{"status-set", "open-port"},
+		},
+		map[string]interface{}{
+			"hook":        "db-relation-changed",
+			"relation-id": 3,
+			"remote-unit": "mysql/0",
+			"started":     "2016-10-01 12:01:00Z",
+			"duration":    "250ms",
+			"exit-code":   1,
+		},
+	})
+}
+
+func (s *HookHistorySuite) TestNoHistory(c *gc.C) {
+	s.api.executions = nil
+	out, err := s.run(c, "mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, "")
+}
+
+func (s *HookHistorySuite) TestAPIError(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	_, err := s.run(c, "mysql/0")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type fakeHookHistoryAPI struct {
+	gitjujutesting.Stub
+	executions []params.HookExecution
+}
+
+func (f *fakeHookHistoryAPI) HookHistory(tag names.UnitTag) ([]params.HookExecution, error) {
+	f.MethodCall(f, "HookHistory", tag)
+	return f.executions, f.NextErr()
+}
+
+func (f *fakeHookHistoryAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
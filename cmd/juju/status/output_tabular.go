@@ -120,10 +120,11 @@ func FormatTabular(value interface{}) ([]byte, error) {
 	units := make(map[string]unitStatus)
 	metering := false
 	relations := newRelationFormatter()
-	outputHeaders("APP", "STATUS", "EXPOSED", "ORIGIN", "CHARM", "REV", "OS")
+	outputHeaders("APP", "VERSION", "STATUS", "EXPOSED", "ORIGIN", "CHARM", "REV", "OS")
 	for _, appName := range common.SortStringsNaturally(stringKeysFromMap(fs.Applications)) {
 		app := fs.Applications[appName]
 		p(appName,
+			app.Version,
 			app.StatusInfo.Current,
 			fmt.Sprintf("%t", app.Exposed),
 			app.CharmOrigin,
@@ -220,6 +221,27 @@ func printMachines(tw *tabwriter.Writer, machines map[string]machineStatus) {
 	for _, name := range common.SortStringsNaturally(stringKeysFromMap(machines)) {
 		printMachine(p, machines[name], "")
 	}
+
+	var firewallWarnings [][]interface{}
+	var collectWarnings func(machineStatus)
+	collectWarnings = func(m machineStatus) {
+		if m.FirewallWarning != "" {
+			firewallWarnings = append(firewallWarnings, []interface{}{m.Id, m.FirewallWarning})
+		}
+		for _, name := range common.SortStringsNaturally(stringKeysFromMap(m.Containers)) {
+			collectWarnings(m.Containers[name])
+		}
+	}
+	for _, name := range common.SortStringsNaturally(stringKeysFromMap(machines)) {
+		collectWarnings(machines[name])
+	}
+	if len(firewallWarnings) > 0 {
+		p()
+		p("MACHINE", "FIREWALL")
+		for _, warning := range firewallWarnings {
+			p(warning...)
+		}
+	}
 }
 
 func printMachine(p func(...interface{}), m machineStatus, prefix string) {
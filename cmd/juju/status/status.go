@@ -61,7 +61,7 @@ Explanation of the different formats:
            - APPLICATIONS: total #, and # exposed of each application.
 - tabular (default): Displays information in a tabular format in these sections:
            - Machines: ID, STATE, DNS, INS-ID, SERIES, AZ
-           - Applications: NAME, EXPOSED, CHARM
+           - Applications: NAME, VERSION, EXPOSED, CHARM
            - Units: ID, STATE, VERSION, MACHINE, PORTS, PUBLIC-ADDRESS
              - Also displays subordinate units.
 - yaml: Displays information on machines, applications, and units in yaml format.
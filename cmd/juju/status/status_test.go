@@ -2581,6 +2581,18 @@ func (st setUnitTools) step(c *gc.C, ctx *context) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+type setUnitWorkloadVersion struct {
+	unitName string
+	version  string
+}
+
+func (wv setUnitWorkloadVersion) step(c *gc.C, ctx *context) {
+	u, err := ctx.st.Unit(wv.unitName)
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.SetWorkloadVersion(wv.version)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 type addCharm struct {
 	name string
 }
@@ -3201,6 +3213,7 @@ func (s *StatusSuite) prepareTabularData(c *gc.C) *context {
 			status.StatusMaintenance,
 			"installing all the things", nil},
 		setUnitTools{"mysql/0", version.MustParseBinary("1.2.3-trusty-ppc")},
+		setUnitWorkloadVersion{"mysql/0", "5.7.13"},
 		addService{name: "logging", charm: "logging"},
 		setServiceExposed{"logging", true},
 		relateServices{"wordpress", "mysql"},
@@ -3233,10 +3246,10 @@ func (s *StatusSuite) testStatusWithFormatTabular(c *gc.C, useFeatureFlag bool)
 MODEL       CONTROLLER  CLOUD  VERSION  UPGRADE-AVAILABLE  
 controller  kontroll    dummy  1.2.3    1.2.4              
 
-APP        STATUS       EXPOSED  ORIGIN      CHARM      REV  OS      
-logging                 true     jujucharms  logging    1    ubuntu  
-mysql      maintenance  true     jujucharms  mysql      1    ubuntu  
-wordpress  active       true     jujucharms  wordpress  3    ubuntu  
+APP        VERSION  STATUS       EXPOSED  ORIGIN      CHARM      REV  OS      
+logging                          true     jujucharms  logging    1    ubuntu  
+mysql      5.7.13   maintenance  true     jujucharms  mysql      1    ubuntu  
+wordpress           active       true     jujucharms  wordpress  3    ubuntu  
 
 RELATION           PROVIDES   CONSUMES   TYPE         
 juju-info          logging    mysql      regular      
@@ -3299,8 +3312,8 @@ func (s *StatusSuite) TestFormatTabularHookActionName(c *gc.C) {
 MODEL  CONTROLLER  CLOUD  VERSION  
                                    
 
-APP  STATUS  EXPOSED  ORIGIN  CHARM  REV  OS  
-foo          false                   0        
+APP  VERSION  STATUS  EXPOSED  ORIGIN  CHARM  REV  OS  
+foo                   false                   0        
 
 UNIT   WORKLOAD     AGENT      MACHINE  PORTS  PUBLIC-ADDRESS  MESSAGE                            
 foo/0  maintenance  executing                                  (config-changed) doing some work   
@@ -3365,8 +3378,8 @@ func (s *StatusSuite) TestFormatTabularMetering(c *gc.C) {
 MODEL  CONTROLLER  CLOUD  VERSION  
                                    
 
-APP  STATUS  EXPOSED  ORIGIN  CHARM  REV  OS  
-foo          false                   0        
+APP  VERSION  STATUS  EXPOSED  ORIGIN  CHARM  REV  OS  
+foo                   false                   0        
 
 UNIT   WORKLOAD  AGENT  MACHINE  PORTS  PUBLIC-ADDRESS  MESSAGE  
 foo/0                                                            
@@ -3380,6 +3393,47 @@ MACHINE  STATE  DNS  INS-ID  SERIES  AZ
 `[1:])
 }
 
+func (s *StatusSuite) TestFormatTabularFirewallWarning(c *gc.C) {
+	status := formattedStatus{
+		Machines: map[string]machineStatus{
+			"0": {
+				Id:         "0",
+				JujuStatus: statusInfoContents{Current: status.StatusStarted},
+				InstanceId: "i-0",
+				Series:     "trusty",
+				Containers: map[string]machineStatus{
+					"0/lxd/0": {
+						Id:              "0/lxd/0",
+						JujuStatus:      statusInfoContents{Current: status.StatusStarted},
+						InstanceId:      "juju-0-lxd-0",
+						Series:          "trusty",
+						FirewallWarning: "firewall rules differ from expected: missing 80/tcp",
+					},
+				},
+			},
+			"1": {
+				Id:              "1",
+				JujuStatus:      statusInfoContents{Current: status.StatusStarted},
+				InstanceId:      "i-1",
+				Series:          "trusty",
+				FirewallWarning: "firewall rules differ from expected: unexpected 22/tcp",
+			},
+		},
+	}
+	out, err := FormatMachineTabular(formattedMachineStatus{Machines: status.Machines})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(out), gc.Equals, `
+MACHINE    STATE    DNS  INS-ID        SERIES  AZ  
+0          started       i-0           trusty      
+  0/lxd/0  started       juju-0-lxd-0  trusty      
+1          started       i-1           trusty      
+
+MACHINE  FIREWALL                                                
+0/lxd/0  firewall rules differ from expected: missing 80/tcp     
+1        firewall rules differ from expected: unexpected 22/tcp  
+`[1:])
+}
+
 //
 // Filtering Feature
 //
@@ -3894,3 +3948,54 @@ func (s *StatusSuite) TestFormatProvisioningError(c *gc.C) {
 		Applications: map[string]applicationStatus{},
 	})
 }
+
+func (s *StatusSuite) TestFormatFirewallDrift(c *gc.C) {
+	status := &params.FullStatus{
+		Machines: map[string]params.MachineStatus{
+			"1": {
+				InstanceId: "i-1",
+				Series:     "trusty",
+				Id:         "1",
+				Jobs:       []multiwatcher.MachineJob{"JobHostUnits"},
+				FirewallDrift: &params.FirewallDrift{
+					Missing:    []string{"80/tcp"},
+					Unexpected: []string{"22/tcp", "443/tcp from 10.0.0.0/8"},
+				},
+			},
+			"2": {
+				InstanceId: "i-2",
+				Series:     "trusty",
+				Id:         "2",
+				Jobs:       []multiwatcher.MachineJob{"JobHostUnits"},
+			},
+		},
+	}
+	formatter := NewStatusFormatter(status, true)
+	formatted := formatter.format()
+
+	c.Check(formatted.Machines["1"].FirewallWarning, gc.Equals,
+		"firewall rules differ from expected: missing 80/tcp; unexpected 22/tcp, 443/tcp from 10.0.0.0/8")
+	c.Check(formatted.Machines["2"].FirewallWarning, gc.Equals, "")
+}
+
+func (s *StatusSuite) TestMakeFirewallWarning(c *gc.C) {
+	for i, test := range []struct {
+		drift    params.FirewallDrift
+		expected string
+	}{{
+		drift:    params.FirewallDrift{Missing: []string{"80/tcp", "8080/tcp"}},
+		expected: "firewall rules differ from expected: missing 80/tcp, 8080/tcp",
+	}, {
+		drift:    params.FirewallDrift{Unexpected: []string{"22/tcp"}},
+		expected: "firewall rules differ from expected: unexpected 22/tcp",
+	}, {
+		drift: params.FirewallDrift{
+			Missing:    []string{"80/tcp"},
+			Unexpected: []string{"22/tcp"},
+		},
+		expected: "firewall rules differ from expected: missing 80/tcp; unexpected 22/tcp",
+	}} {
+		c.Logf("test %d: %+v", i, test.drift)
+		c.Check(makeFirewallWarning(test.drift), gc.Equals, test.expected)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
+	"launchpad.net/gnuflag"
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
@@ -72,6 +73,13 @@ Examples:
       juju add-storage u/0 data=1 
     or
       juju add-storage u/0 data 
+
+
+    # Add 1 storage instance for "data" storage to unit u/0,
+    # restoring the contents of volume snapshot 0/1:2 (see
+    # juju snapshot-storage):
+
+      juju add-storage u/0 data --from-snapshot 0/1:2
 `
 	addCommandAgs = `
 <unit name> <storage directive> ...
@@ -90,7 +98,18 @@ type addCommand struct {
 	// storageCons is a map of storage constraints, keyed on the storage name
 	// defined in charm storage metadata.
 	storageCons map[string]storage.Constraints
-	newAPIFunc  func() (StorageAddAPI, error)
+
+	// fromSnapshot is the ID of a volume snapshot from which
+	// the storage should be created, if any.
+	fromSnapshot string
+
+	newAPIFunc func() (StorageAddAPI, error)
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *addCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	f.StringVar(&c.fromSnapshot, "from-snapshot", "", "Create the storage from the specified volume snapshot")
 }
 
 // Init implements Command.Init.
@@ -106,7 +125,13 @@ func (c *addCommand) Init(args []string) (err error) {
 	c.unitTag = names.NewUnitTag(u).String()
 
 	c.storageCons, err = storage.ParseConstraintsMap(args[1:], false)
-	return
+	if err != nil {
+		return err
+	}
+	if c.fromSnapshot != "" && len(c.storageCons) != 1 {
+		return errors.New("--from-snapshot requires exactly one storage directive")
+	}
+	return nil
 }
 
 // Info implements Command.Info.
@@ -192,6 +217,7 @@ func (c *addCommand) createStorageAddParams() []params.StorageAddParams {
 					&cons.Size,
 					&cons.Count,
 				},
+				Snapshot: c.fromSnapshot,
 			})
 	}
 
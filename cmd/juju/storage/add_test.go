@@ -106,6 +106,23 @@ func (s *addSuite) TestAddSuccess(c *gc.C) {
 	}
 }
 
+func (s *addSuite) TestAddFromSnapshot(c *gc.C) {
+	s.args = []string{"tst/123", "data", "--from-snapshot", "0/1:2"}
+	s.mockAPI.addToUnitFunc = func(storages []params.StorageAddParams) ([]params.ErrorResult, error) {
+		c.Assert(storages, gc.HasLen, 1)
+		c.Assert(storages[0].StorageName, gc.Equals, "data")
+		c.Assert(storages[0].Snapshot, gc.Equals, "0/1:2")
+		return make([]params.ErrorResult, len(storages)), nil
+	}
+	s.assertAddOutput(c, "added \"data\"\n", "")
+}
+
+func (s *addSuite) TestAddFromSnapshotMultipleDirectives(c *gc.C) {
+	s.args = []string{"tst/123", "data", "logs", "--from-snapshot", "0/1:2"}
+	expectedErr := "--from-snapshot requires exactly one storage directive"
+	s.assertAddErrorOutput(c, expectedErr, "", visibleErrorMessage(expectedErr))
+}
+
 func (s *addSuite) TestAddOperationAborted(c *gc.C) {
 	s.args = []string{"tst/123", "data=676"}
 	s.mockAPI.addToUnitFunc = func(storages []params.StorageAddParams) ([]params.ErrorResult, error) {
@@ -0,0 +1,103 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewAttachCommand returns a command used to attach detached storage
+// instances to units.
+func NewAttachCommand() cmd.Command {
+	cmd := &attachCommand{}
+	cmd.newAPIFunc = func() (StorageAttachAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const attachCommandDoc = `
+Attach an existing storage instance to a unit.
+
+The storage instance must not currently be attached to any unit; storage
+is left in this state by juju detach-storage. The unit's charm must
+declare storage with the same name and kind as the storage instance, and
+must allow another instance of it to be attached.
+
+Once the storage has been attached to the unit's machine, the
+storage-attached hook is run for the unit.
+
+Examples:
+    juju attach-storage data/0 postgresql/1
+`
+
+// attachCommand attaches a storage instance to a unit.
+type attachCommand struct {
+	StorageCommandBase
+	storageId  string
+	unitId     string
+	newAPIFunc func() (StorageAttachAPI, error)
+}
+
+// Init implements Command.Init.
+func (c *attachCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("attach-storage requires a storage ID and a unit")
+	case 1:
+		return errors.New("attach-storage requires a unit")
+	case 2:
+	default:
+		return cmd.CheckEmpty(args[2:])
+	}
+	if !names.IsValidStorage(args[0]) {
+		return errors.NotValidf("storage ID %q", args[0])
+	}
+	if !names.IsValidUnit(args[1]) {
+		return errors.NotValidf("unit name %q", args[1])
+	}
+	c.storageId = args[0]
+	c.unitId = args[1]
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *attachCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "attach-storage",
+		Args:    "<storage ID> <unit name>",
+		Purpose: "Attaches existing storage to a unit.",
+		Doc:     attachCommandDoc,
+	}
+}
+
+// Run implements Command.Run.
+func (c *attachCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	storageTag := names.NewStorageTag(c.storageId)
+	unitTag := names.NewUnitTag(c.unitId)
+	if err := api.Attach(storageTag, unitTag); err != nil {
+		return errors.Annotatef(err, "cannot attach %q to %q", c.storageId, c.unitId)
+	}
+	fmt.Fprintf(ctx.Stdout, "attaching %q to %q\n", c.storageId, c.unitId)
+	return nil
+}
+
+// StorageAttachAPI defines the API methods that the attach-storage
+// command uses.
+type StorageAttachAPI interface {
+	Close() error
+	Attach(names.StorageTag, names.UnitTag) error
+}
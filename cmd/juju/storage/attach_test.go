@@ -0,0 +1,87 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type AttachSuite struct {
+	SubStorageSuite
+	mockAPI *mockAttachAPI
+}
+
+var _ = gc.Suite(&AttachSuite{})
+
+func (s *AttachSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.mockAPI = &mockAttachAPI{}
+}
+
+func (s *AttachSuite) runAttach(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, storage.NewAttachCommandForTest(s.mockAPI, s.store), args...)
+}
+
+func (s *AttachSuite) TestAttachInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "attach-storage requires a storage ID and a unit",
+	}, {
+		args: []string{"data/0"},
+		err:  "attach-storage requires a unit",
+	}, {
+		args: []string{"data", "mysql/0"},
+		err:  `storage ID "data" not valid`,
+	}, {
+		args: []string{"data/0", "mysql"},
+		err:  `unit name "mysql" not valid`,
+	}, {
+		args: []string{"data/0", "mysql/0", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d: %q", i, test.args)
+		_, err := s.runAttach(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *AttachSuite) TestAttach(c *gc.C) {
+	ctx, err := s.runAttach(c, "data/0", "mysql/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.storage, gc.Equals, names.NewStorageTag("data/0"))
+	c.Assert(s.mockAPI.unit, gc.Equals, names.NewUnitTag("mysql/1"))
+	c.Assert(testing.Stdout(ctx), gc.Equals, `attaching "data/0" to "mysql/1"`+"\n")
+}
+
+func (s *AttachSuite) TestAttachError(c *gc.C) {
+	s.mockAPI.err = errors.New("storage is attached to unit mysql/0")
+	_, err := s.runAttach(c, "data/0", "mysql/1")
+	c.Assert(err, gc.ErrorMatches, `cannot attach "data/0" to "mysql/1": storage is attached to unit mysql/0`)
+}
+
+type mockAttachAPI struct {
+	storage names.StorageTag
+	unit    names.UnitTag
+	err     error
+}
+
+func (s *mockAttachAPI) Close() error {
+	return nil
+}
+
+func (s *mockAttachAPI) Attach(storage names.StorageTag, unit names.UnitTag) error {
+	s.storage = storage
+	s.unit = unit
+	return s.err
+}
@@ -0,0 +1,111 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewDetachCommand returns a command used to detach storage instances
+// from the units they are attached to.
+func NewDetachCommand() cmd.Command {
+	cmd := &detachCommand{}
+	cmd.newAPIFunc = func() (StorageDetachAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const detachCommandDoc = `
+Detach storage instances from the units they are attached to.
+
+The storage-detaching hook is run for each unit before the storage is
+detached. Detached storage remains in the model, and may be attached to
+another unit with juju attach-storage, or removed from the model with
+juju remove-storage.
+
+Only storage that is not tied to the lifetime of a machine, such as
+storage backed by a cloud volume, can be detached. Storage that the
+unit's charm requires cannot be detached.
+
+Examples:
+    juju detach-storage data/0
+    juju detach-storage data/0 data/1
+`
+
+// detachCommand detaches storage instances from their units.
+type detachCommand struct {
+	StorageCommandBase
+	ids        []string
+	newAPIFunc func() (StorageDetachAPI, error)
+}
+
+// Init implements Command.Init.
+func (c *detachCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("detach-storage requires at least one storage ID")
+	}
+	for _, id := range args {
+		if !names.IsValidStorage(id) {
+			return errors.NotValidf("storage ID %q", id)
+		}
+	}
+	c.ids = args
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *detachCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "detach-storage",
+		Args:    "<storage ID> [...]",
+		Purpose: "Detaches storage from units.",
+		Doc:     detachCommandDoc,
+	}
+}
+
+// Run implements Command.Run.
+func (c *detachCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	tags := make([]names.StorageTag, len(c.ids))
+	for i, id := range c.ids {
+		tags[i] = names.NewStorageTag(id)
+	}
+	results, err := api.Detach(tags)
+	if err != nil {
+		return err
+	}
+	var failed bool
+	for i, result := range results {
+		if result.Error != nil {
+			failed = true
+			fmt.Fprintf(ctx.Stderr, "failed to detach %q: %v\n", c.ids[i], result.Error)
+			continue
+		}
+		fmt.Fprintf(ctx.Stdout, "detaching %q\n", c.ids[i])
+	}
+	if failed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+// StorageDetachAPI defines the API methods that the detach-storage
+// command uses.
+type StorageDetachAPI interface {
+	Close() error
+	Detach([]names.StorageTag) ([]params.ErrorResult, error)
+}
@@ -0,0 +1,68 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type DetachSuite struct {
+	SubStorageSuite
+	mockAPI *mockDetachAPI
+}
+
+var _ = gc.Suite(&DetachSuite{})
+
+func (s *DetachSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.mockAPI = &mockDetachAPI{}
+}
+
+func (s *DetachSuite) runDetach(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, storage.NewDetachCommandForTest(s.mockAPI, s.store), args...)
+}
+
+func (s *DetachSuite) TestDetachNoArgs(c *gc.C) {
+	_, err := s.runDetach(c)
+	c.Assert(err, gc.ErrorMatches, "detach-storage requires at least one storage ID")
+}
+
+func (s *DetachSuite) TestDetachInvalidId(c *gc.C) {
+	_, err := s.runDetach(c, "data")
+	c.Assert(err, gc.ErrorMatches, `storage ID "data" not valid`)
+}
+
+func (s *DetachSuite) TestDetach(c *gc.C) {
+	ctx, err := s.runDetach(c, "data/0", "data/1")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(s.mockAPI.tags, jc.DeepEquals, []names.StorageTag{
+		names.NewStorageTag("data/0"),
+		names.NewStorageTag("data/1"),
+	})
+	c.Assert(testing.Stdout(ctx), gc.Equals, `detaching "data/0"`+"\n")
+	c.Assert(testing.Stderr(ctx), gc.Equals, `failed to detach "data/1": storage data/1 not found`+"\n")
+}
+
+type mockDetachAPI struct {
+	tags []names.StorageTag
+}
+
+func (s *mockDetachAPI) Close() error {
+	return nil
+}
+
+func (s *mockDetachAPI) Detach(tags []names.StorageTag) ([]params.ErrorResult, error) {
+	s.tags = tags
+	return []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: "storage data/1 not found"}},
+	}, nil
+}
@@ -31,6 +31,14 @@ func NewPoolCreateCommandForTest(api PoolCreateAPI, store jujuclient.ClientStore
 	return modelcmd.Wrap(cmd)
 }
 
+func NewPoolUpdateCommandForTest(api PoolUpdateAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &poolUpdateCommand{newAPIFunc: func() (PoolUpdateAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
 func NewShowCommandForTest(api StorageShowAPI, store jujuclient.ClientStore) cmd.Command {
 	cmd := &showCommand{newAPIFunc: func() (StorageShowAPI, error) {
 		return api, nil
@@ -54,3 +62,75 @@ func NewAddCommandForTest(api StorageAddAPI, store jujuclient.ClientStore) cmd.C
 	cmd.SetClientStore(store)
 	return modelcmd.Wrap(cmd)
 }
+
+func NewSnapshotCommandForTest(api StorageSnapshotAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &snapshotCommand{newAPIFunc: func() (StorageSnapshotAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewSnapshotListCommandForTest(api StorageSnapshotListAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &snapshotListCommand{newAPIFunc: func() (StorageSnapshotListAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewSnapshotRemoveCommandForTest(api StorageSnapshotRemoveAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &snapshotRemoveCommand{newAPIFunc: func() (StorageSnapshotRemoveAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewResizeCommandForTest(api StorageResizeAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &resizeCommand{newAPIFunc: func() (StorageResizeAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewAttachCommandForTest(api StorageAttachAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &attachCommand{newAPIFunc: func() (StorageAttachAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewDetachCommandForTest(api StorageDetachAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &detachCommand{newAPIFunc: func() (StorageDetachAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewRemoveCommandForTest(api StorageRemoveAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &removeCommand{newAPIFunc: func() (StorageRemoveAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewImportFilesystemCommandForTest(api StorageImportFilesystemAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &importFilesystemCommand{newAPIFunc: func() (StorageImportFilesystemAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewStorageQuotaCommandForTest(api StorageQuotaAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &storageQuotaCommand{newAPIFunc: func() (StorageQuotaAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
@@ -47,8 +47,18 @@ type FilesystemAttachments struct {
 }
 
 type MachineFilesystemAttachment struct {
-	MountPoint string `yaml:"mount-point" json:"mount-point"`
-	ReadOnly   bool   `yaml:"read-only" json:"read-only"`
+	MountPoint string           `yaml:"mount-point" json:"mount-point"`
+	ReadOnly   bool             `yaml:"read-only" json:"read-only"`
+	Usage      *FilesystemUsage `yaml:"usage,omitempty" json:"usage,omitempty"`
+}
+
+// FilesystemUsage describes the space and inode usage of a filesystem,
+// as most recently reported by the machine that it is attached to.
+type FilesystemUsage struct {
+	UsedBytes  uint64 `yaml:"used-bytes" json:"used-bytes"`
+	FreeBytes  uint64 `yaml:"free-bytes" json:"free-bytes"`
+	UsedInodes uint64 `yaml:"used-inodes" json:"used-inodes"`
+	FreeInodes uint64 `yaml:"free-inodes" json:"free-inodes"`
 }
 
 // generateListFilesystemOutput returns a map filesystem IDs to filesystem info
@@ -130,10 +140,19 @@ func createFilesystemInfo(details params.FilesystemDetails) (names.FilesystemTag
 			if err != nil {
 				return names.FilesystemTag{}, FilesystemInfo{}, errors.Trace(err)
 			}
-			machineAttachments[machineId] = MachineFilesystemAttachment{
-				attachment.MountPoint,
-				attachment.ReadOnly,
+			machineAttachment := MachineFilesystemAttachment{
+				MountPoint: attachment.MountPoint,
+				ReadOnly:   attachment.ReadOnly,
+			}
+			if attachment.Usage != nil {
+				machineAttachment.Usage = &FilesystemUsage{
+					UsedBytes:  attachment.Usage.UsedBytes,
+					FreeBytes:  attachment.Usage.FreeBytes,
+					UsedInodes: attachment.Usage.UsedInodes,
+					FreeInodes: attachment.Usage.FreeInodes,
+				}
 			}
+			machineAttachments[machineId] = machineAttachment
 		}
 		info.Attachments = &FilesystemAttachments{
 			Machines: machineAttachments,
@@ -38,7 +38,7 @@ func formatFilesystemListTabularTyped(infos map[string]FilesystemInfo) []byte {
 	print := func(values ...string) {
 		fmt.Fprintln(tw, strings.Join(values, "\t"))
 	}
-	print("MACHINE", "UNIT", "STORAGE", "ID", "VOLUME", "PROVIDER-ID", "MOUNTPOINT", "SIZE", "STATE", "MESSAGE")
+	print("MACHINE", "UNIT", "STORAGE", "ID", "VOLUME", "PROVIDER-ID", "MOUNTPOINT", "SIZE", "USED", "FREE", "FREE-INODES", "STATE", "MESSAGE")
 
 	filesystemAttachmentInfos := make(filesystemAttachmentInfos, 0, len(infos))
 	for filesystemId, info := range infos {
@@ -76,10 +76,16 @@ func formatFilesystemListTabularTyped(infos map[string]FilesystemInfo) []byte {
 		if info.Size > 0 {
 			size = humanize.IBytes(info.Size * humanize.MiByte)
 		}
+		var used, free, freeInodes string
+		if info.Usage != nil {
+			used = humanize.IBytes(info.Usage.UsedBytes)
+			free = humanize.IBytes(info.Usage.FreeBytes)
+			freeInodes = fmt.Sprint(info.Usage.FreeInodes)
+		}
 		print(
 			info.MachineId, info.UnitId, info.Storage,
 			info.FilesystemId, info.Volume, info.ProviderFilesystemId,
-			info.MountPoint, size,
+			info.MountPoint, size, used, free, freeInodes,
 			string(info.Status.Current), info.Status.Message,
 		)
 	}
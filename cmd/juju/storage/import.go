@@ -0,0 +1,101 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewImportFilesystemCommand returns a command used to import existing
+// filesystems into the model.
+func NewImportFilesystemCommand() cmd.Command {
+	cmd := &importFilesystemCommand{}
+	cmd.newAPIFunc = func() (StorageImportFilesystemAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const importFilesystemCommandDoc = `
+Import an existing filesystem into the model.
+
+The filesystem, or the volume backing it, is identified by the storage
+pool it belongs to and its ID in the storage provider. The storage
+provider validates that it may be imported and tags it as belonging to
+the model. Only storage that is not in use may be imported.
+
+A storage instance with the specified storage name is created for the
+imported filesystem. The storage instance is not attached to any unit;
+it may be attached to a unit whose charm declares filesystem storage
+with the same name, using juju attach-storage.
+
+Examples:
+    # Import the EBS volume "vol-123456" as "pgdata" storage.
+    juju import-filesystem ebs vol-123456 pgdata
+`
+
+// importFilesystemCommand imports an existing filesystem into the model.
+type importFilesystemCommand struct {
+	StorageCommandBase
+	pool        string
+	providerId  string
+	storageName string
+	newAPIFunc  func() (StorageImportFilesystemAPI, error)
+}
+
+// Init implements Command.Init.
+func (c *importFilesystemCommand) Init(args []string) error {
+	if len(args) < 3 {
+		return errors.New("import-filesystem requires a storage pool, a provider ID and a storage name")
+	}
+	if err := cmd.CheckEmpty(args[3:]); err != nil {
+		return err
+	}
+	if !names.IsValidStorageName(args[2]) {
+		return errors.NotValidf("storage name %q", args[2])
+	}
+	c.pool = args[0]
+	c.providerId = args[1]
+	c.storageName = args[2]
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *importFilesystemCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "import-filesystem",
+		Args:    "<storage pool> <provider ID> <storage name>",
+		Purpose: "Imports an existing filesystem into the model.",
+		Doc:     importFilesystemCommandDoc,
+	}
+}
+
+// Run implements Command.Run.
+func (c *importFilesystemCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	storageTag, err := api.ImportFilesystem(c.pool, c.providerId, c.storageName)
+	if err != nil {
+		return errors.Annotatef(err, "cannot import %q", c.providerId)
+	}
+	fmt.Fprintf(ctx.Stdout, "imported %q as storage %q\n", c.providerId, storageTag.Id())
+	return nil
+}
+
+// StorageImportFilesystemAPI defines the API methods that the
+// import-filesystem command uses.
+type StorageImportFilesystemAPI interface {
+	Close() error
+	ImportFilesystem(pool, providerId, storageName string) (names.StorageTag, error)
+}
@@ -0,0 +1,84 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type ImportFilesystemSuite struct {
+	SubStorageSuite
+	mockAPI *mockImportFilesystemAPI
+}
+
+var _ = gc.Suite(&ImportFilesystemSuite{})
+
+func (s *ImportFilesystemSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.mockAPI = &mockImportFilesystemAPI{}
+}
+
+func (s *ImportFilesystemSuite) runImport(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, storage.NewImportFilesystemCommandForTest(s.mockAPI, s.store), args...)
+}
+
+func (s *ImportFilesystemSuite) TestImportInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "import-filesystem requires a storage pool, a provider ID and a storage name",
+	}, {
+		args: []string{"ebs", "vol-123"},
+		err:  "import-filesystem requires a storage pool, a provider ID and a storage name",
+	}, {
+		args: []string{"ebs", "vol-123", "0data"},
+		err:  `storage name "0data" not valid`,
+	}, {
+		args: []string{"ebs", "vol-123", "data", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d: %q", i, test.args)
+		_, err := s.runImport(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ImportFilesystemSuite) TestImport(c *gc.C) {
+	ctx, err := s.runImport(c, "ebs", "vol-123", "data")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.args, jc.DeepEquals, []string{"ebs", "vol-123", "data"})
+	c.Assert(testing.Stdout(ctx), gc.Equals, `imported "vol-123" as storage "data/0"`+"\n")
+}
+
+func (s *ImportFilesystemSuite) TestImportError(c *gc.C) {
+	s.mockAPI.err = errors.New(`cannot import volume with status "in-use"`)
+	_, err := s.runImport(c, "ebs", "vol-123", "data")
+	c.Assert(err, gc.ErrorMatches, `cannot import "vol-123": cannot import volume with status "in-use"`)
+}
+
+type mockImportFilesystemAPI struct {
+	args []string
+	err  error
+}
+
+func (s *mockImportFilesystemAPI) Close() error {
+	return nil
+}
+
+func (s *mockImportFilesystemAPI) ImportFilesystem(pool, providerId, storageName string) (names.StorageTag, error) {
+	s.args = []string{pool, providerId, storageName}
+	if s.err != nil {
+		return names.StorageTag{}, s.err
+	}
+	return names.NewStorageTag(storageName + "/0"), nil
+}
@@ -20,15 +20,33 @@ type PoolCommandBase struct {
 type PoolInfo struct {
 	Provider string                 `yaml:"provider" json:"provider"`
 	Attrs    map[string]interface{} `yaml:"attrs,omitempty" json:"attrs,omitempty"`
+	Usage    *PoolUsage             `yaml:"usage,omitempty" json:"usage,omitempty"`
+}
+
+// PoolUsage defines the serialization behaviour of the storage pool
+// usage information.
+type PoolUsage struct {
+	Volumes     int `yaml:"volumes" json:"volumes"`
+	Filesystems int `yaml:"filesystems" json:"filesystems"`
+	// Size is the total size of storage served by the pool, in MiB.
+	Size uint64 `yaml:"size" json:"size"`
 }
 
 func formatPoolInfo(all []params.StoragePool) map[string]PoolInfo {
 	output := make(map[string]PoolInfo)
 	for _, one := range all {
-		output[one.Name] = PoolInfo{
+		info := PoolInfo{
 			Provider: one.Provider,
 			Attrs:    one.Attrs,
 		}
+		if one.Usage != nil {
+			info.Usage = &PoolUsage{
+				Volumes:     one.Usage.Volumes,
+				Filesystems: one.Usage.Filesystems,
+				Size:        one.Usage.Size,
+			}
+		}
+		output[one.Name] = info
 	}
 	return output
 }
@@ -44,6 +62,9 @@ If only types are specified, all pools of the specified types will be listed.
 
 Both pool types and names must be valid.
 Valid pool types are pool types that are registered for Juju model.
+
+If --usage is specified, the number of volumes and filesystems served
+by each pool, and the total size of that storage, are also shown.
 `
 
 // NewPoolListCommand returns a command that lists storage pools on a model
@@ -61,6 +82,7 @@ type poolListCommand struct {
 	newAPIFunc func() (PoolListAPI, error)
 	Providers  []string
 	Names      []string
+	Usage      bool
 	out        cmd.Output
 }
 
@@ -84,6 +106,7 @@ func (c *poolListCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.StorageCommandBase.SetFlags(f)
 	f.Var(cmd.NewAppendStringsValue(&c.Providers), "provider", "Only show pools of these provider types")
 	f.Var(cmd.NewAppendStringsValue(&c.Names), "name", "Only show pools with these names")
+	f.BoolVar(&c.Usage, "usage", false, "Show the storage served by each pool")
 
 	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
 		"yaml":    cmd.FormatYaml,
@@ -99,7 +122,7 @@ func (c *poolListCommand) Run(ctx *cmd.Context) (err error) {
 		return err
 	}
 	defer api.Close()
-	result, err := api.ListPools(c.Providers, c.Names)
+	result, err := api.ListPools(c.Providers, c.Names, c.Usage)
 	if err != nil {
 		return err
 	}
@@ -113,5 +136,5 @@ func (c *poolListCommand) Run(ctx *cmd.Context) (err error) {
 // PoolListAPI defines the API methods that the storage commands use.
 type PoolListAPI interface {
 	Close() error
-	ListPools(providers, names []string) ([]params.StoragePool, error)
+	ListPools(providers, names []string, usage bool) ([]params.StoragePool, error)
 }
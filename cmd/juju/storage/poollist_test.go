@@ -110,6 +110,40 @@ xyz   testType  a=true b=maybe c=well
 `[1:])
 }
 
+func (s *poolListSuite) TestPoolListTabularUsage(c *gc.C) {
+	s.mockAPI.attrs = map[string]interface{}{"a": true}
+	s.mockAPI.usage = &params.StoragePoolUsage{
+		Volumes:     2,
+		Filesystems: 1,
+		Size:        3072,
+	}
+	s.assertValidList(
+		c,
+		[]string{"--name", "xyz", "--name", "abc", "--usage"},
+		`
+NAME  PROVIDER  VOLUMES  FILESYSTEMS  SIZE    ATTRS
+abc   testType  2        1            3.0GiB  a=true
+xyz   testType  2        1            3.0GiB  a=true
+
+`[1:])
+}
+
+func (s *poolListSuite) TestPoolListYAMLUsage(c *gc.C) {
+	s.mockAPI.attrs = nil
+	s.mockAPI.usage = &params.StoragePoolUsage{Volumes: 2, Size: 3072}
+	s.assertValidList(
+		c,
+		[]string{"--name", "abc", "--usage", "--format", "yaml"},
+		`
+abc:
+  provider: testType
+  usage:
+    volumes: 2
+    filesystems: 0
+    size: 3072
+`[1:])
+}
+
 type unmarshaller func(in []byte, out interface{}) (err error)
 
 func (s *poolListSuite) assertUnmarshalledOutput(c *gc.C, unmarshall unmarshaller, args ...string) {
@@ -151,11 +185,11 @@ func (s poolListSuite) assertSamePoolInfos(c *gc.C, one, two map[string]storage.
 }
 
 func (s poolListSuite) expect(c *gc.C, types, names []string) map[string]storage.PoolInfo {
-	all, err := s.mockAPI.ListPools(types, names)
+	all, err := s.mockAPI.ListPools(types, names, false)
 	c.Assert(err, jc.ErrorIsNil)
 	result := make(map[string]storage.PoolInfo, len(all))
 	for _, one := range all {
-		result[one.Name] = storage.PoolInfo{one.Provider, one.Attrs}
+		result[one.Name] = storage.PoolInfo{Provider: one.Provider, Attrs: one.Attrs}
 	}
 	return result
 }
@@ -170,17 +204,21 @@ func (s *poolListSuite) assertValidList(c *gc.C, args []string, expected string)
 
 type mockPoolListAPI struct {
 	attrs map[string]interface{}
+	usage *params.StoragePoolUsage
 }
 
 func (s mockPoolListAPI) Close() error {
 	return nil
 }
 
-func (s mockPoolListAPI) ListPools(types []string, names []string) ([]params.StoragePool, error) {
+func (s mockPoolListAPI) ListPools(types []string, names []string, usage bool) ([]params.StoragePool, error) {
 	results := make([]params.StoragePool, len(types)+len(names))
 	var index int
 	addInstance := func(aname, atype string) {
 		results[index] = s.createTestPoolInstance(aname, atype)
+		if usage {
+			results[index].Usage = s.usage
+		}
 		index++
 	}
 	for i, atype := range types {
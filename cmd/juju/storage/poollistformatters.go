@@ -10,6 +10,7 @@ import (
 	"strings"
 	"text/tabwriter"
 
+	"github.com/dustin/go-humanize"
 	"github.com/juju/errors"
 )
 
@@ -39,12 +40,21 @@ func formatPoolsTabular(pools map[string]PoolInfo) ([]byte, error) {
 		fmt.Fprintln(tw, strings.Join(values, "\t"))
 	}
 
-	print("NAME", "PROVIDER", "ATTRS")
-
+	// Usage columns are only shown if usage was requested.
+	var showUsage bool
 	poolNames := make([]string, 0, len(pools))
-	for name := range pools {
+	for name, pool := range pools {
 		poolNames = append(poolNames, name)
+		if pool.Usage != nil {
+			showUsage = true
+		}
+	}
+	if showUsage {
+		print("NAME", "PROVIDER", "VOLUMES", "FILESYSTEMS", "SIZE", "ATTRS")
+	} else {
+		print("NAME", "PROVIDER", "ATTRS")
 	}
+
 	sort.Strings(poolNames)
 	for _, name := range poolNames {
 		pool := pools[name]
@@ -58,7 +68,21 @@ func formatPoolsTabular(pools map[string]PoolInfo) ([]byte, error) {
 		for i, key := range keys {
 			attrs[i] = fmt.Sprintf("%v=%v", key, pool.Attrs[key])
 		}
-		print(name, pool.Provider, strings.Join(attrs, " "))
+		if !showUsage {
+			print(name, pool.Provider, strings.Join(attrs, " "))
+			continue
+		}
+		var usage PoolUsage
+		if pool.Usage != nil {
+			usage = *pool.Usage
+		}
+		print(
+			name, pool.Provider,
+			fmt.Sprint(usage.Volumes),
+			fmt.Sprint(usage.Filesystems),
+			humanize.IBytes(usage.Size*humanize.MiByte),
+			strings.Join(attrs, " "),
+		)
 	}
 	tw.Flush()
 
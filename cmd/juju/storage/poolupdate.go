@@ -0,0 +1,93 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils/keyvalues"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// PoolUpdateAPI defines the API methods that pool update command uses.
+type PoolUpdateAPI interface {
+	Close() error
+	UpdatePool(pname string, pconfig map[string]interface{}) error
+}
+
+const poolUpdateCommandDoc = `
+Updates the attributes of an existing storage pool. The specified
+attributes are merged with the pool's existing attributes, and the
+result is validated by the pool's storage provider.
+
+The provider of a pool cannot be changed. Some providers also prevent
+attributes that would affect existing storage from being changed while
+the pool is serving volumes or filesystems; use "juju storage-pools
+--usage" to see which pools are in use.
+
+Examples:
+    juju update-storage-pool ebs-fast iops=3000
+`
+
+// NewPoolUpdateCommand returns a command that updates a storage pool.
+func NewPoolUpdateCommand() cmd.Command {
+	cmd := &poolUpdateCommand{}
+	cmd.newAPIFunc = func() (PoolUpdateAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// poolUpdateCommand updates a storage pool.
+type poolUpdateCommand struct {
+	PoolCommandBase
+	newAPIFunc func() (PoolUpdateAPI, error)
+	poolName   string
+	attrs      map[string]interface{}
+}
+
+// Init implements Command.Init.
+func (c *poolUpdateCommand) Init(args []string) (err error) {
+	if len(args) < 2 {
+		return errors.New("pool update requires name and attrs for configuration")
+	}
+	c.poolName = args[0]
+
+	options, err := keyvalues.Parse(args[1:], false)
+	if err != nil {
+		return err
+	}
+	c.attrs = make(map[string]interface{})
+	for key, value := range options {
+		c.attrs[key] = value
+	}
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *poolUpdateCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "update-storage-pool",
+		Args:    "<name> <key>=<value> [<key>=<value>...]",
+		Purpose: "Update the attributes of a storage pool.",
+		Doc:     poolUpdateCommandDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *poolUpdateCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+}
+
+// Run implements Command.Run.
+func (c *poolUpdateCommand) Run(ctx *cmd.Context) (err error) {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+	return api.UpdatePool(c.poolName, c.attrs)
+}
@@ -0,0 +1,194 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"strconv"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"github.com/juju/utils/keyvalues"
+	"gopkg.in/juju/names.v2"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// StorageQuotaAPI defines the API methods that the storage quota
+// command uses.
+type StorageQuotaAPI interface {
+	Close() error
+	StorageQuota(application string) (params.StorageQuotaDetails, error)
+	SetApplicationStorageQuota(application string, quota params.StorageQuota) error
+}
+
+const (
+	quotaSizeKey  = "size"
+	quotaCountKey = "count"
+)
+
+const storageQuotaCommandDoc = `
+Shows the storage quota of the model or of an application, along with
+the storage currently provisioned from each storage pool.
+
+A storage quota limits the total size and number of storage instances
+that may be provisioned from each storage pool. Storage that would
+exceed a quota is rejected when it is requested, whether by deploying
+an application, adding units or adding storage.
+
+The model's quota applies to all storage in the model, and is set with
+the "storage-quota-size" and "storage-quota-count" model config
+attributes. An application's quota applies to the storage of that
+application's units, and is set by specifying "size" and/or "count"
+after the application name; the size is specified as a number with an
+optional multiplier suffix (M, G, T, P, E, Z, Y). A value of 0 removes
+the limit. Existing storage is never removed by lowering a quota.
+
+Examples:
+    juju storage-quota
+    juju storage-quota mysql
+    juju storage-quota mysql size=100G count=10
+    juju set-model-config storage-quota-size=1T
+`
+
+// NewStorageQuotaCommand returns a command that shows or sets storage
+// quotas.
+func NewStorageQuotaCommand() cmd.Command {
+	cmd := &storageQuotaCommand{}
+	cmd.newAPIFunc = func() (StorageQuotaAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// storageQuotaCommand shows or sets storage quotas.
+type storageQuotaCommand struct {
+	StorageCommandBase
+	newAPIFunc  func() (StorageQuotaAPI, error)
+	application string
+	size        *uint64
+	count       *uint64
+	out         cmd.Output
+}
+
+// Init implements Command.Init.
+func (c *storageQuotaCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return nil
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.NotValidf("application name %q", args[0])
+	}
+	c.application = args[0]
+	options, err := keyvalues.Parse(args[1:], false)
+	if err != nil {
+		return err
+	}
+	for key, value := range options {
+		switch key {
+		case quotaSizeKey:
+			size, err := utils.ParseSize(value)
+			if err != nil {
+				return errors.Annotate(err, "cannot parse size")
+			}
+			c.size = &size
+		case quotaCountKey:
+			count, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				return errors.Errorf("expected a non-negative integer for count, got %q", value)
+			}
+			c.count = &count
+		default:
+			return errors.Errorf("unknown quota attribute %q", key)
+		}
+	}
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *storageQuotaCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "storage-quota",
+		Args:    "[<application name> [size=<size>] [count=<count>]]",
+		Purpose: "Shows or sets storage quotas.",
+		Doc:     storageQuotaCommandDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *storageQuotaCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatStorageQuotaTabular,
+	})
+}
+
+// Run implements Command.Run.
+func (c *storageQuotaCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	details, err := api.StorageQuota(c.application)
+	if err != nil {
+		return err
+	}
+	if c.size == nil && c.count == nil {
+		return c.out.Write(ctx, formatStorageQuotaInfo(details))
+	}
+
+	// Only the specified limits are changed.
+	quota := details.Quota
+	if c.size != nil {
+		quota.Size = *c.size
+	}
+	if c.count != nil {
+		quota.Count = *c.count
+	}
+	if err := api.SetApplicationStorageQuota(c.application, quota); err != nil {
+		return errors.Annotatef(err, "cannot set storage quota for %q", c.application)
+	}
+	return nil
+}
+
+// StorageQuotaInfo defines the serialization behaviour of storage quota
+// information.
+type StorageQuotaInfo struct {
+	// Size is the maximum total size of storage per pool, in MiB.
+	Size uint64 `yaml:"size" json:"size"`
+	// Count is the maximum number of storage instances per pool.
+	Count uint64                       `yaml:"count" json:"count"`
+	Usage map[string]StorageQuotaUsage `yaml:"usage,omitempty" json:"usage,omitempty"`
+}
+
+// StorageQuotaUsage defines the serialization behaviour of the storage
+// provisioned from a storage pool.
+type StorageQuotaUsage struct {
+	// Size is the total size of storage, in MiB.
+	Size  uint64 `yaml:"size" json:"size"`
+	Count uint64 `yaml:"count" json:"count"`
+}
+
+func formatStorageQuotaInfo(details params.StorageQuotaDetails) StorageQuotaInfo {
+	info := StorageQuotaInfo{
+		Size:  details.Quota.Size,
+		Count: details.Quota.Count,
+	}
+	if len(details.Usage) > 0 {
+		info.Usage = make(map[string]StorageQuotaUsage)
+		for _, usage := range details.Usage {
+			info.Usage[usage.Pool] = StorageQuotaUsage{
+				Size:  usage.Size,
+				Count: usage.Count,
+			}
+		}
+	}
+	return info
+}
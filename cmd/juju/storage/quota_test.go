@@ -0,0 +1,131 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	_ "github.com/juju/juju/provider/dummy"
+	"github.com/juju/juju/testing"
+)
+
+type StorageQuotaSuite struct {
+	SubStorageSuite
+	mockAPI *mockStorageQuotaAPI
+}
+
+var _ = gc.Suite(&StorageQuotaSuite{})
+
+func (s *StorageQuotaSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+
+	s.mockAPI = &mockStorageQuotaAPI{
+		details: params.StorageQuotaDetails{
+			Quota: params.StorageQuota{Size: 10240, Count: 5},
+			Usage: []params.StorageQuotaUsage{
+				{Pool: "loop", Size: 1024, Count: 1},
+				{Pool: "ebs", Size: 4096, Count: 2},
+			},
+		},
+	}
+}
+
+func (s *StorageQuotaSuite) runStorageQuota(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, storage.NewStorageQuotaCommandForTest(s.mockAPI, s.store), args...)
+}
+
+func (s *StorageQuotaSuite) TestStorageQuotaModel(c *gc.C) {
+	ctx, err := s.runStorageQuota(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.application, gc.Equals, "")
+	c.Assert(testing.Stdout(ctx), gc.Equals, `
+POOL  COUNT  COUNT-LIMIT  SIZE    SIZE-LIMIT
+ebs   2      5            4.0GiB  10GiB
+loop  1      5            1.0GiB  10GiB
+`[1:])
+}
+
+func (s *StorageQuotaSuite) TestStorageQuotaApplicationUnlimited(c *gc.C) {
+	s.mockAPI.details = params.StorageQuotaDetails{}
+	ctx, err := s.runStorageQuota(c, "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.application, gc.Equals, "mysql")
+	c.Assert(testing.Stdout(ctx), gc.Equals, `
+POOL  COUNT  COUNT-LIMIT  SIZE  SIZE-LIMIT
+-     0      unlimited    0B    unlimited
+`[1:])
+}
+
+func (s *StorageQuotaSuite) TestStorageQuotaYAML(c *gc.C) {
+	ctx, err := s.runStorageQuota(c, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, `
+size: 10240
+count: 5
+usage:
+  ebs:
+    size: 4096
+    count: 2
+  loop:
+    size: 1024
+    count: 1
+`[1:])
+}
+
+func (s *StorageQuotaSuite) TestSetStorageQuota(c *gc.C) {
+	_, err := s.runStorageQuota(c, "mysql", "size=1G")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.application, gc.Equals, "mysql")
+	c.Assert(s.mockAPI.quota, jc.DeepEquals, &params.StorageQuota{Size: 1024, Count: 5})
+
+	_, err = s.runStorageQuota(c, "mysql", "size=0", "count=3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.quota, jc.DeepEquals, &params.StorageQuota{Count: 3})
+}
+
+func (s *StorageQuotaSuite) TestSetStorageQuotaError(c *gc.C) {
+	s.mockAPI.setErr = errors.New("boom")
+	_, err := s.runStorageQuota(c, "mysql", "count=3")
+	c.Assert(err, gc.ErrorMatches, `cannot set storage quota for "mysql": boom`)
+}
+
+func (s *StorageQuotaSuite) TestStorageQuotaInitErrors(c *gc.C) {
+	s.testStorageQuotaInitError(c, []string{"mysql/0"}, `application name "mysql/0" not valid`)
+	s.testStorageQuotaInitError(c, []string{"mysql", "size"}, `expected "key=value", got "size"`)
+	s.testStorageQuotaInitError(c, []string{"mysql", "size=lots"}, `cannot parse size: .*`)
+	s.testStorageQuotaInitError(c, []string{"mysql", "count=-1"}, `expected a non-negative integer for count, got "-1"`)
+	s.testStorageQuotaInitError(c, []string{"mysql", "iops=3"}, `unknown quota attribute "iops"`)
+}
+
+func (s *StorageQuotaSuite) testStorageQuotaInitError(c *gc.C, args []string, expect string) {
+	_, err := s.runStorageQuota(c, args...)
+	c.Assert(err, gc.ErrorMatches, expect)
+}
+
+type mockStorageQuotaAPI struct {
+	details     params.StorageQuotaDetails
+	application string
+	quota       *params.StorageQuota
+	setErr      error
+}
+
+func (s *mockStorageQuotaAPI) Close() error {
+	return nil
+}
+
+func (s *mockStorageQuotaAPI) StorageQuota(application string) (params.StorageQuotaDetails, error) {
+	s.application = application
+	return s.details, nil
+}
+
+func (s *mockStorageQuotaAPI) SetApplicationStorageQuota(application string, quota params.StorageQuota) error {
+	s.application = application
+	s.quota = &quota
+	return s.setErr
+}
@@ -0,0 +1,66 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/dustin/go-humanize"
+	"github.com/juju/errors"
+)
+
+// formatStorageQuotaTabular returns a tabular summary of storage quota
+// information or errors out if parameter is not a StorageQuotaInfo.
+func formatStorageQuotaTabular(value interface{}) ([]byte, error) {
+	info, ok := value.(StorageQuotaInfo)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", info, value)
+	}
+	var out bytes.Buffer
+	const (
+		// To format things into columns.
+		minwidth = 0
+		tabwidth = 1
+		padding  = 2
+		padchar  = ' '
+		flags    = 0
+	)
+	tw := tabwriter.NewWriter(&out, minwidth, tabwidth, padding, padchar, flags)
+	print := func(values ...string) {
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+
+	countLimit, sizeLimit := "unlimited", "unlimited"
+	if info.Count > 0 {
+		countLimit = fmt.Sprint(info.Count)
+	}
+	if info.Size > 0 {
+		sizeLimit = humanize.IBytes(info.Size * humanize.MiByte)
+	}
+	print("POOL", "COUNT", "COUNT-LIMIT", "SIZE", "SIZE-LIMIT")
+	if len(info.Usage) == 0 {
+		print("-", "0", countLimit, humanize.IBytes(0), sizeLimit)
+	}
+
+	pools := make([]string, 0, len(info.Usage))
+	for pool := range info.Usage {
+		pools = append(pools, pool)
+	}
+	sort.Strings(pools)
+	for _, pool := range pools {
+		usage := info.Usage[pool]
+		print(
+			pool,
+			fmt.Sprint(usage.Count), countLimit,
+			humanize.IBytes(usage.Size*humanize.MiByte), sizeLimit,
+		)
+	}
+	tw.Flush()
+
+	return out.Bytes(), nil
+}
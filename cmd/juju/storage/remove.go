@@ -0,0 +1,128 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewRemoveCommand returns a command used to remove storage instances
+// from the model.
+func NewRemoveCommand() cmd.Command {
+	cmd := &removeCommand{}
+	cmd.newAPIFunc = func() (StorageRemoveAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const removeCommandDoc = `
+Remove storage instances from the model.
+
+Storage that is attached to a unit is first detached, running the
+storage-detaching hook for the unit.
+
+By default, or if --destroy is specified, the cloud storage backing each
+storage instance is destroyed along with it. If --release is specified,
+the cloud storage is instead released from the model and left intact,
+so that it may be managed outside of Juju. Only storage that is not tied
+to the lifetime of a machine can be released.
+
+Examples:
+    juju remove-storage data/0
+    juju remove-storage --release data/0 data/1
+`
+
+// removeCommand removes storage instances from the model.
+type removeCommand struct {
+	StorageCommandBase
+	ids        []string
+	destroy    bool
+	release    bool
+	newAPIFunc func() (StorageRemoveAPI, error)
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *removeCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	f.BoolVar(&c.destroy, "destroy", false, "Destroy the cloud storage (default)")
+	f.BoolVar(&c.release, "release", false, "Release the cloud storage from the model, leaving it intact")
+}
+
+// Init implements Command.Init.
+func (c *removeCommand) Init(args []string) error {
+	if c.destroy && c.release {
+		return errors.New("--destroy and --release are mutually exclusive")
+	}
+	if len(args) < 1 {
+		return errors.New("remove-storage requires at least one storage ID")
+	}
+	for _, id := range args {
+		if !names.IsValidStorage(id) {
+			return errors.NotValidf("storage ID %q", id)
+		}
+	}
+	c.ids = args
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *removeCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "remove-storage",
+		Args:    "[--destroy|--release] <storage ID> [...]",
+		Purpose: "Removes storage from the model.",
+		Doc:     removeCommandDoc,
+	}
+}
+
+// Run implements Command.Run.
+func (c *removeCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	tags := make([]names.StorageTag, len(c.ids))
+	for i, id := range c.ids {
+		tags[i] = names.NewStorageTag(id)
+	}
+	results, err := api.Remove(tags, c.release)
+	if err != nil {
+		return err
+	}
+	verb := "removing"
+	if c.release {
+		verb = "releasing"
+	}
+	var failed bool
+	for i, result := range results {
+		if result.Error != nil {
+			failed = true
+			fmt.Fprintf(ctx.Stderr, "failed to remove %q: %v\n", c.ids[i], result.Error)
+			continue
+		}
+		fmt.Fprintf(ctx.Stdout, "%s %q\n", verb, c.ids[i])
+	}
+	if failed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+// StorageRemoveAPI defines the API methods that the remove-storage
+// command uses.
+type StorageRemoveAPI interface {
+	Close() error
+	Remove([]names.StorageTag, bool) ([]params.ErrorResult, error)
+}
@@ -0,0 +1,93 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type RemoveSuite struct {
+	SubStorageSuite
+	mockAPI *mockRemoveAPI
+}
+
+var _ = gc.Suite(&RemoveSuite{})
+
+func (s *RemoveSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.mockAPI = &mockRemoveAPI{}
+}
+
+func (s *RemoveSuite) runRemove(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, storage.NewRemoveCommandForTest(s.mockAPI, s.store), args...)
+}
+
+func (s *RemoveSuite) TestRemoveInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "remove-storage requires at least one storage ID",
+	}, {
+		args: []string{"data"},
+		err:  `storage ID "data" not valid`,
+	}, {
+		args: []string{"--destroy", "--release", "data/0"},
+		err:  "--destroy and --release are mutually exclusive",
+	}} {
+		c.Logf("test %d: %q", i, test.args)
+		_, err := s.runRemove(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *RemoveSuite) TestRemove(c *gc.C) {
+	for _, args := range [][]string{
+		{"data/0", "data/1"},
+		{"--destroy", "data/0", "data/1"},
+	} {
+		ctx, err := s.runRemove(c, args...)
+		c.Assert(err, gc.Equals, cmd.ErrSilent)
+		c.Assert(s.mockAPI.tags, jc.DeepEquals, []names.StorageTag{
+			names.NewStorageTag("data/0"),
+			names.NewStorageTag("data/1"),
+		})
+		c.Assert(s.mockAPI.release, jc.IsFalse)
+		c.Assert(testing.Stdout(ctx), gc.Equals, `removing "data/0"`+"\n")
+		c.Assert(testing.Stderr(ctx), gc.Equals, `failed to remove "data/1": storage data/1 not found`+"\n")
+	}
+}
+
+func (s *RemoveSuite) TestRemoveRelease(c *gc.C) {
+	ctx, err := s.runRemove(c, "--release", "data/0", "data/1")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(s.mockAPI.release, jc.IsTrue)
+	c.Assert(testing.Stdout(ctx), gc.Equals, `releasing "data/0"`+"\n")
+}
+
+type mockRemoveAPI struct {
+	tags    []names.StorageTag
+	release bool
+}
+
+func (s *mockRemoveAPI) Close() error {
+	return nil
+}
+
+func (s *mockRemoveAPI) Remove(tags []names.StorageTag, release bool) ([]params.ErrorResult, error) {
+	s.tags = tags
+	s.release = release
+	return []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: "storage data/1 not found"}},
+	}, nil
+}
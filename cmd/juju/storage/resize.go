@@ -0,0 +1,108 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewResizeCommand returns a command used to grow storage instances.
+func NewResizeCommand() cmd.Command {
+	cmd := &resizeCommand{}
+	cmd.newAPIFunc = func() (StorageResizeAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const resizeCommandDoc = `
+Grow a storage instance to a new size.
+
+The size is specified as a number with an optional multiplier suffix
+(M, G, T, P, E, Z, Y); if no suffix is given, the size is in MiB. The
+new size must be larger than the storage's current size; storage cannot
+be shrunk.
+
+Storage is resized asynchronously by the storage provisioner responsible
+for it; this command only records the request. Once the storage has been
+resized, the storage-resized hook is run for each unit that the storage
+is attached to. Only storage provided by a storage provider that supports
+resizing, such as loop, can be resized.
+
+Examples:
+    juju resize-storage data/0 20G
+`
+
+// resizeCommand requests that a storage instance be grown.
+type resizeCommand struct {
+	StorageCommandBase
+	id         string
+	size       uint64
+	newAPIFunc func() (StorageResizeAPI, error)
+}
+
+// Init implements Command.Init.
+func (c *resizeCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("resize-storage requires a storage ID and a size")
+	case 1:
+		return errors.New("resize-storage requires a size")
+	case 2:
+	default:
+		return cmd.CheckEmpty(args[2:])
+	}
+	if !names.IsValidStorage(args[0]) {
+		return errors.NotValidf("storage ID %q", args[0])
+	}
+	size, err := utils.ParseSize(args[1])
+	if err != nil {
+		return errors.Annotate(err, "cannot parse size")
+	}
+	if size == 0 {
+		return errors.NotValidf("size 0")
+	}
+	c.id = args[0]
+	c.size = size
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *resizeCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "resize-storage",
+		Args:    "<storage ID> <size>",
+		Purpose: "Grows a storage instance.",
+		Doc:     resizeCommandDoc,
+	}
+}
+
+// Run implements Command.Run.
+func (c *resizeCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	if err := api.ResizeStorage(names.NewStorageTag(c.id), c.size); err != nil {
+		return errors.Annotatef(err, "cannot resize %q", c.id)
+	}
+	fmt.Fprintf(ctx.Stdout, "resize of %q to %dMiB requested\n", c.id, c.size)
+	return nil
+}
+
+// StorageResizeAPI defines the API methods that the resize-storage
+// command uses.
+type StorageResizeAPI interface {
+	Close() error
+	ResizeStorage(names.StorageTag, uint64) error
+}
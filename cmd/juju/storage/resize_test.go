@@ -0,0 +1,90 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type ResizeSuite struct {
+	SubStorageSuite
+	mockAPI *mockResizeAPI
+}
+
+var _ = gc.Suite(&ResizeSuite{})
+
+func (s *ResizeSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.mockAPI = &mockResizeAPI{}
+}
+
+func (s *ResizeSuite) runResize(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, storage.NewResizeCommandForTest(s.mockAPI, s.store), args...)
+}
+
+func (s *ResizeSuite) TestResizeInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "resize-storage requires a storage ID and a size",
+	}, {
+		args: []string{"data/0"},
+		err:  "resize-storage requires a size",
+	}, {
+		args: []string{"data", "1G"},
+		err:  `storage ID "data" not valid`,
+	}, {
+		args: []string{"data/0", "big"},
+		err:  `cannot parse size: expected a non-negative number, got "big"`,
+	}, {
+		args: []string{"data/0", "0"},
+		err:  `size 0 not valid`,
+	}, {
+		args: []string{"data/0", "1G", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d: %q", i, test.args)
+		_, err := s.runResize(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ResizeSuite) TestResize(c *gc.C) {
+	ctx, err := s.runResize(c, "data/0", "2G")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.tag, gc.Equals, names.NewStorageTag("data/0"))
+	c.Assert(s.mockAPI.size, gc.Equals, uint64(2048))
+	c.Assert(testing.Stdout(ctx), gc.Equals, `resize of "data/0" to 2048MiB requested`+"\n")
+}
+
+func (s *ResizeSuite) TestResizeError(c *gc.C) {
+	s.mockAPI.err = errors.New("new size 512M must be larger than current size 1024M")
+	_, err := s.runResize(c, "data/0", "512M")
+	c.Assert(err, gc.ErrorMatches, `cannot resize "data/0": new size 512M must be larger than current size 1024M`)
+}
+
+type mockResizeAPI struct {
+	tag  names.StorageTag
+	size uint64
+	err  error
+}
+
+func (s *mockResizeAPI) Close() error {
+	return nil
+}
+
+func (s *mockResizeAPI) ResizeStorage(tag names.StorageTag, size uint64) error {
+	s.tag = tag
+	s.size = size
+	return s.err
+}
@@ -0,0 +1,111 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewSnapshotCommand returns a command used to take snapshots of
+// volume-backed storage instances.
+func NewSnapshotCommand() cmd.Command {
+	cmd := &snapshotCommand{}
+	cmd.newAPIFunc = func() (StorageSnapshotAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const snapshotCommandDoc = `
+Take a point-in-time snapshot of the volumes backing storage instances.
+
+Snapshots are taken asynchronously by the storage provisioner responsible
+for each volume; this command only records the request. Only storage
+backed by a storage provider that supports snapshots, such as loop, can
+be snapshotted.
+
+The ID of each requested snapshot is printed, and may be passed to
+juju add-storage --from-snapshot to create new storage with the
+snapshot's contents.
+
+Examples:
+    juju snapshot-storage data/0
+    juju snapshot-storage data/0 data/1
+`
+
+// snapshotCommand requests snapshots of storage instances.
+type snapshotCommand struct {
+	StorageCommandBase
+	ids        []string
+	newAPIFunc func() (StorageSnapshotAPI, error)
+}
+
+// Init implements Command.Init.
+func (c *snapshotCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("snapshot-storage requires at least one storage ID")
+	}
+	for _, id := range args {
+		if !names.IsValidStorage(id) {
+			return errors.NotValidf("storage ID %q", id)
+		}
+	}
+	c.ids = args
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *snapshotCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "snapshot-storage",
+		Args:    "<storage ID> [...]",
+		Purpose: "Takes snapshots of storage instances.",
+		Doc:     snapshotCommandDoc,
+	}
+}
+
+// Run implements Command.Run.
+func (c *snapshotCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	tags := make([]names.StorageTag, len(c.ids))
+	for i, id := range c.ids {
+		tags[i] = names.NewStorageTag(id)
+	}
+	results, err := api.CreateVolumeSnapshots(tags)
+	if err != nil {
+		return err
+	}
+	var failed bool
+	for i, result := range results {
+		if result.Error != nil {
+			failed = true
+			fmt.Fprintf(ctx.Stderr, "failed to snapshot %q: %v\n", c.ids[i], result.Error)
+			continue
+		}
+		fmt.Fprintf(ctx.Stdout, "snapshot %q of %q requested\n", result.Result.Id, c.ids[i])
+	}
+	if failed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+// StorageSnapshotAPI defines the API methods that the snapshot-storage
+// command uses.
+type StorageSnapshotAPI interface {
+	Close() error
+	CreateVolumeSnapshots([]names.StorageTag) ([]params.VolumeSnapshotDetailsResult, error)
+}
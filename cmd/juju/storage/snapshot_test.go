@@ -0,0 +1,75 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type SnapshotSuite struct {
+	SubStorageSuite
+	mockAPI *mockSnapshotAPI
+}
+
+var _ = gc.Suite(&SnapshotSuite{})
+
+func (s *SnapshotSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.mockAPI = &mockSnapshotAPI{}
+}
+
+func (s *SnapshotSuite) runSnapshot(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, storage.NewSnapshotCommandForTest(s.mockAPI, s.store), args...)
+}
+
+func (s *SnapshotSuite) TestSnapshotNoArgs(c *gc.C) {
+	_, err := s.runSnapshot(c)
+	c.Assert(err, gc.ErrorMatches, "snapshot-storage requires at least one storage ID")
+}
+
+func (s *SnapshotSuite) TestSnapshotInvalidId(c *gc.C) {
+	_, err := s.runSnapshot(c, "data")
+	c.Assert(err, gc.ErrorMatches, `storage ID "data" not valid`)
+}
+
+func (s *SnapshotSuite) TestSnapshot(c *gc.C) {
+	ctx, err := s.runSnapshot(c, "data/0", "data/1")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(s.mockAPI.tags, jc.DeepEquals, []names.StorageTag{
+		names.NewStorageTag("data/0"),
+		names.NewStorageTag("data/1"),
+	})
+	c.Assert(testing.Stdout(ctx), gc.Equals, `snapshot "0/0:1" of "data/0" requested`+"\n")
+	c.Assert(testing.Stderr(ctx), gc.Equals, `failed to snapshot "data/1": storage data/1 not found`+"\n")
+}
+
+type mockSnapshotAPI struct {
+	tags []names.StorageTag
+}
+
+func (s *mockSnapshotAPI) Close() error {
+	return nil
+}
+
+func (s *mockSnapshotAPI) CreateVolumeSnapshots(tags []names.StorageTag) ([]params.VolumeSnapshotDetailsResult, error) {
+	s.tags = tags
+	return []params.VolumeSnapshotDetailsResult{{
+		Result: &params.VolumeSnapshotDetails{
+			Id:         "0/0:1",
+			VolumeTag:  "volume-0-0",
+			StorageTag: "storage-data-0",
+			Pool:       "loop",
+			Life:       params.Alive,
+		},
+	}, {
+		Error: &params.Error{Message: "storage data/1 not found"},
+	}}, nil
+}
@@ -0,0 +1,144 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewSnapshotListCommand returns a command used to list the volume
+// snapshots in the model.
+func NewSnapshotListCommand() cmd.Command {
+	cmd := &snapshotListCommand{}
+	cmd.newAPIFunc = func() (StorageSnapshotListAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const snapshotListCommandDoc = `
+List the volume snapshots in the model.
+
+Snapshots of volumes that have since been removed are still listed,
+and may be removed with juju remove-storage-snapshot.
+
+Examples:
+    juju storage-snapshots
+    juju storage-snapshots --format yaml
+`
+
+// SnapshotInfo defines the serialization behaviour of volume snapshot
+// information.
+type SnapshotInfo struct {
+	Volume     string    `yaml:"volume" json:"volume"`
+	Storage    string    `yaml:"storage,omitempty" json:"storage,omitempty"`
+	Pool       string    `yaml:"pool" json:"pool"`
+	Life       string    `yaml:"life" json:"life"`
+	Created    time.Time `yaml:"created" json:"created"`
+	SnapshotId string    `yaml:"snapshot-id,omitempty" json:"snapshot-id,omitempty"`
+	// Size is the size of the snapshot, in MiB.
+	Size uint64 `yaml:"size,omitempty" json:"size,omitempty"`
+}
+
+// snapshotListCommand lists volume snapshots.
+type snapshotListCommand struct {
+	StorageCommandBase
+	out        cmd.Output
+	newAPIFunc func() (StorageSnapshotListAPI, error)
+}
+
+// Init implements Command.Init.
+func (c *snapshotListCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Info implements Command.Info.
+func (c *snapshotListCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "storage-snapshots",
+		Purpose: "Lists volume snapshots.",
+		Doc:     snapshotListCommandDoc,
+		Aliases: []string{"list-storage-snapshots"},
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *snapshotListCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatSnapshotListTabular,
+	})
+}
+
+// Run implements Command.Run.
+func (c *snapshotListCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.ListVolumeSnapshots()
+	if err != nil {
+		return err
+	}
+	output := make(map[string]SnapshotInfo)
+	for _, result := range results {
+		if result.Error != nil {
+			fmt.Fprintln(ctx.Stderr, result.Error)
+			continue
+		}
+		info, err := convertToSnapshotInfo(*result.Result)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		output[result.Result.Id] = info
+	}
+	if len(output) == 0 {
+		ctx.Infof("No snapshots to display.")
+		return nil
+	}
+	return c.out.Write(ctx, output)
+}
+
+func convertToSnapshotInfo(details params.VolumeSnapshotDetails) (SnapshotInfo, error) {
+	volumeTag, err := names.ParseVolumeTag(details.VolumeTag)
+	if err != nil {
+		return SnapshotInfo{}, errors.Trace(err)
+	}
+	info := SnapshotInfo{
+		Volume:     volumeTag.Id(),
+		Pool:       details.Pool,
+		Life:       string(details.Life),
+		Created:    details.Created,
+		SnapshotId: details.SnapshotId,
+		Size:       details.Size,
+	}
+	if details.StorageTag != "" {
+		storageTag, err := names.ParseStorageTag(details.StorageTag)
+		if err != nil {
+			return SnapshotInfo{}, errors.Trace(err)
+		}
+		info.Storage = storageTag.Id()
+	}
+	return info, nil
+}
+
+// StorageSnapshotListAPI defines the API methods that the
+// storage-snapshots command uses.
+type StorageSnapshotListAPI interface {
+	Close() error
+	ListVolumeSnapshots() ([]params.VolumeSnapshotDetailsResult, error)
+}
@@ -0,0 +1,103 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type SnapshotListSuite struct {
+	SubStorageSuite
+	mockAPI *mockSnapshotListAPI
+}
+
+var _ = gc.Suite(&SnapshotListSuite{})
+
+func (s *SnapshotListSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.mockAPI = &mockSnapshotListAPI{
+		results: []params.VolumeSnapshotDetailsResult{{
+			Result: &params.VolumeSnapshotDetails{
+				Id:         "0/0:1",
+				VolumeTag:  "volume-0-0",
+				StorageTag: "storage-data-0",
+				Pool:       "loop",
+				Life:       params.Alive,
+				SnapshotId: "snap-0",
+				Size:       1024,
+			},
+		}, {
+			Result: &params.VolumeSnapshotDetails{
+				Id:        "2:3",
+				VolumeTag: "volume-2",
+				Pool:      "lvm",
+				Life:      params.Dying,
+			},
+		}},
+	}
+}
+
+func (s *SnapshotListSuite) runSnapshotList(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, storage.NewSnapshotListCommandForTest(s.mockAPI, s.store), args...)
+}
+
+func (s *SnapshotListSuite) TestSnapshotListArgs(c *gc.C) {
+	_, err := s.runSnapshotList(c, "data/0")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["data/0"\]`)
+}
+
+func (s *SnapshotListSuite) TestSnapshotListTabular(c *gc.C) {
+	ctx, err := s.runSnapshotList(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"ID     VOLUME  STORAGE  POOL  SIZE    LIFE\n"+
+		"0/0:1  0/0     data/0   loop  1.0GiB  alive\n"+
+		"2:3    2       -        lvm   -       dying\n",
+	)
+}
+
+func (s *SnapshotListSuite) TestSnapshotListJSON(c *gc.C) {
+	ctx, err := s.runSnapshotList(c, "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		`{"0/0:1":{"volume":"0/0","storage":"data/0","pool":"loop","life":"alive",`+
+		`"created":"0001-01-01T00:00:00Z","snapshot-id":"snap-0","size":1024},`+
+		`"2:3":{"volume":"2","pool":"lvm","life":"dying","created":"0001-01-01T00:00:00Z"}}`+"\n",
+	)
+}
+
+func (s *SnapshotListSuite) TestSnapshotListError(c *gc.C) {
+	s.mockAPI.results = append(s.mockAPI.results, params.VolumeSnapshotDetailsResult{
+		Error: &params.Error{Message: "boom"},
+	})
+	ctx, err := s.runSnapshotList(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stderr(ctx), gc.Equals, "boom\n")
+}
+
+func (s *SnapshotListSuite) TestSnapshotListEmpty(c *gc.C) {
+	s.mockAPI.results = nil
+	ctx, err := s.runSnapshotList(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "")
+	c.Assert(testing.Stderr(ctx), gc.Equals, "No snapshots to display.\n")
+}
+
+type mockSnapshotListAPI struct {
+	results []params.VolumeSnapshotDetailsResult
+}
+
+func (s *mockSnapshotListAPI) Close() error {
+	return nil
+}
+
+func (s *mockSnapshotListAPI) ListVolumeSnapshots() ([]params.VolumeSnapshotDetailsResult, error) {
+	return s.results, nil
+}
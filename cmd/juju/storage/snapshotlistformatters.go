@@ -0,0 +1,59 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/dustin/go-humanize"
+	"github.com/juju/errors"
+)
+
+// formatSnapshotListTabular returns a tabular summary of volume snapshot
+// information or errors out if parameter is not a map of SnapshotInfo.
+func formatSnapshotListTabular(value interface{}) ([]byte, error) {
+	snapshots, ok := value.(map[string]SnapshotInfo)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", snapshots, value)
+	}
+	var out bytes.Buffer
+	const (
+		// To format things into columns.
+		minwidth = 0
+		tabwidth = 1
+		padding  = 2
+		padchar  = ' '
+		flags    = 0
+	)
+	tw := tabwriter.NewWriter(&out, minwidth, tabwidth, padding, padchar, flags)
+	print := func(values ...string) {
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+
+	ids := make([]string, 0, len(snapshots))
+	for id := range snapshots {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	print("ID", "VOLUME", "STORAGE", "POOL", "SIZE", "LIFE")
+	for _, id := range ids {
+		info := snapshots[id]
+		storage, size := "-", "-"
+		if info.Storage != "" {
+			storage = info.Storage
+		}
+		if info.Size > 0 {
+			size = humanize.IBytes(info.Size * humanize.MiByte)
+		}
+		print(id, info.Volume, storage, info.Pool, size, info.Life)
+	}
+	tw.Flush()
+
+	return out.Bytes(), nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewSnapshotRemoveCommand returns a command used to remove volume
+// snapshots from the model.
+func NewSnapshotRemoveCommand() cmd.Command {
+	cmd := &snapshotRemoveCommand{}
+	cmd.newAPIFunc = func() (StorageSnapshotRemoveAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const snapshotRemoveCommandDoc = `
+Remove volume snapshots from the model.
+
+Snapshots are deleted asynchronously by the storage provisioner
+responsible for each volume, after which they are removed from the
+model. Snapshot IDs are shown by juju storage-snapshots.
+
+Examples:
+    juju remove-storage-snapshot 0/0:1
+    juju remove-storage-snapshot 0/0:1 2:4
+`
+
+// snapshotRemoveCommand removes volume snapshots from the model.
+type snapshotRemoveCommand struct {
+	StorageCommandBase
+	ids        []string
+	newAPIFunc func() (StorageSnapshotRemoveAPI, error)
+}
+
+// Init implements Command.Init.
+func (c *snapshotRemoveCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("remove-storage-snapshot requires at least one snapshot ID")
+	}
+	c.ids = args
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *snapshotRemoveCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "remove-storage-snapshot",
+		Args:    "<snapshot ID> [...]",
+		Purpose: "Removes volume snapshots from the model.",
+		Doc:     snapshotRemoveCommandDoc,
+	}
+}
+
+// Run implements Command.Run.
+func (c *snapshotRemoveCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.DestroyVolumeSnapshots(c.ids)
+	if err != nil {
+		return err
+	}
+	var failed bool
+	for i, result := range results {
+		if result.Error != nil {
+			failed = true
+			fmt.Fprintf(ctx.Stderr, "failed to remove snapshot %q: %v\n", c.ids[i], result.Error)
+			continue
+		}
+		fmt.Fprintf(ctx.Stdout, "removing snapshot %q\n", c.ids[i])
+	}
+	if failed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+// StorageSnapshotRemoveAPI defines the API methods that the
+// remove-storage-snapshot command uses.
+type StorageSnapshotRemoveAPI interface {
+	Close() error
+	DestroyVolumeSnapshots([]string) ([]params.ErrorResult, error)
+}
@@ -0,0 +1,59 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type SnapshotRemoveSuite struct {
+	SubStorageSuite
+	mockAPI *mockSnapshotRemoveAPI
+}
+
+var _ = gc.Suite(&SnapshotRemoveSuite{})
+
+func (s *SnapshotRemoveSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.mockAPI = &mockSnapshotRemoveAPI{}
+}
+
+func (s *SnapshotRemoveSuite) runSnapshotRemove(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, storage.NewSnapshotRemoveCommandForTest(s.mockAPI, s.store), args...)
+}
+
+func (s *SnapshotRemoveSuite) TestSnapshotRemoveNoArgs(c *gc.C) {
+	_, err := s.runSnapshotRemove(c)
+	c.Assert(err, gc.ErrorMatches, "remove-storage-snapshot requires at least one snapshot ID")
+}
+
+func (s *SnapshotRemoveSuite) TestSnapshotRemove(c *gc.C) {
+	ctx, err := s.runSnapshotRemove(c, "0/0:1", "2:3")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(s.mockAPI.ids, jc.DeepEquals, []string{"0/0:1", "2:3"})
+	c.Assert(testing.Stdout(ctx), gc.Equals, `removing snapshot "0/0:1"`+"\n")
+	c.Assert(testing.Stderr(ctx), gc.Equals, `failed to remove snapshot "2:3": volume snapshot "2:3" not found`+"\n")
+}
+
+type mockSnapshotRemoveAPI struct {
+	ids []string
+}
+
+func (s *mockSnapshotRemoveAPI) Close() error {
+	return nil
+}
+
+func (s *mockSnapshotRemoveAPI) DestroyVolumeSnapshots(ids []string) ([]params.ErrorResult, error) {
+	s.ids = ids
+	return []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: `volume snapshot "2:3" not found`}},
+	}, nil
+}
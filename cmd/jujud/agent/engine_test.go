@@ -42,6 +42,7 @@ var (
 		"migration-fortress",
 		"migration-master",
 		"application-scaler",
+		"remote-relations",
 		"space-importer",
 		"state-cleaner",
 		"status-history-pruner",
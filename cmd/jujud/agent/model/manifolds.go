@@ -32,6 +32,7 @@ import (
 	"github.com/juju/juju/worker/metricworker"
 	"github.com/juju/juju/worker/migrationmaster"
 	"github.com/juju/juju/worker/provisioner"
+	"github.com/juju/juju/worker/remoterelations"
 	"github.com/juju/juju/worker/singular"
 	"github.com/juju/juju/worker/statushistorypruner"
 	"github.com/juju/juju/worker/storageprovisioner"
@@ -210,6 +211,11 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 		firewallerName: ifNotDead(firewaller.Manifold(firewaller.ManifoldConfig{
 			APICallerName: apiCallerName,
 		})),
+		remoteRelationsName: ifNotDead(remoterelations.Manifold(remoterelations.ManifoldConfig{
+			APICallerName: apiCallerName,
+			NewFacade:     remoterelations.NewFacade,
+			NewWorker:     remoterelations.NewWorker,
+		})),
 		unitAssignerName: ifNotDead(unitassigner.Manifold(unitassigner.ManifoldConfig{
 			APICallerName: apiCallerName,
 		})),
@@ -307,6 +313,7 @@ const (
 	computeProvisionerName   = "compute-provisioner"
 	storageProvisionerName   = "storage-provisioner"
 	firewallerName           = "firewaller"
+	remoteRelationsName      = "remote-relations"
 	unitAssignerName         = "unit-assigner"
 	applicationscalerName    = "application-scaler"
 	instancePollerName       = "instance-poller"
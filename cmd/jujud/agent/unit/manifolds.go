@@ -31,6 +31,7 @@ import (
 	"github.com/juju/juju/worker/retrystrategy"
 	"github.com/juju/juju/worker/uniter"
 	"github.com/juju/juju/worker/upgrader"
+	"github.com/juju/juju/worker/virtualip"
 )
 
 // ManifoldsConfig allows specialisation of the result of Manifolds.
@@ -223,6 +224,16 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewIsolatedStatusWorker:  meterstatus.NewIsolatedStatusWorker,
 		}),
 
+		// The virtual IP worker claims the application's virtual IP
+		// address for the unit's machine while the unit is leader.
+		virtualIPName: virtualip.Manifold(virtualip.ManifoldConfig{
+			AgentName:             agentName,
+			APICallerName:         apiCallerName,
+			LeadershipTrackerName: leadershipTrackerName,
+			NewFacade:             virtualip.NewFacade,
+			NewWorker:             virtualip.NewWorker,
+		}),
+
 		// The metric sender worker periodically sends accumulated metrics to the controller.
 		metricSenderName: sender.Manifold(sender.ManifoldConfig{
 			AgentName:       agentName,
@@ -250,6 +261,7 @@ const (
 	leadershipTrackerName = "leadership-tracker"
 	hookRetryStrategyName = "hook-retry-strategy"
 	uniterName            = "uniter"
+	virtualIPName         = "virtual-ip"
 
 	metricSpoolName   = "metric-spool"
 	meterStatusName   = "meter-status"
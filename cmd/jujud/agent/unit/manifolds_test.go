@@ -52,6 +52,7 @@ func (s *ManifoldsSuite) TestManifoldNames(c *gc.C) {
 		"leadership-tracker",
 		"hook-retry-strategy",
 		"uniter",
+		"virtual-ip",
 		"metric-spool",
 		"meter-status",
 		"metric-collect",
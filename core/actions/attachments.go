@@ -0,0 +1,17 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actions
+
+import "regexp"
+
+// validAttachmentName matches the names that may be given to files
+// attached to an action. Names are used as file names when the
+// attachments are downloaded, so path separators are not allowed.
+var validAttachmentName = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`)
+
+// IsValidAttachmentName reports whether name is a valid name for a file
+// attached to the results of an action.
+func IsValidAttachmentName(name string) bool {
+	return validAttachmentName.MatchString(name)
+}
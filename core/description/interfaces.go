@@ -112,6 +112,7 @@ type Machine interface {
 	Placement() string
 	Series() string
 	ContainerType() string
+	StaticIPAddress() string
 	Jobs() []string
 	SupportedContainers() ([]string, bool)
 
@@ -220,8 +221,14 @@ type Application interface {
 	CharmModifiedVersion() int
 	ForceCharm() bool
 	Exposed() bool
+	ExposedCIDRs() []string
+	ExposedSpaces() []string
+	EgressRules() []string
 	MinUnits() int
 
+	StorageQuotaSize() uint64
+	StorageQuotaCount() uint64
+
 	Settings() map[string]interface{}
 	SettingsRefCount() int
 
@@ -256,6 +263,8 @@ type Unit interface {
 	MeterStatusCode() string
 	MeterStatusInfo() string
 
+	WorkloadVersion() string
+
 	// TODO: storage
 
 	Tools() AgentTools
@@ -24,6 +24,8 @@ type machine struct {
 	Series_        string         `yaml:"series"`
 	ContainerType_ string         `yaml:"container-type,omitempty"`
 
+	StaticIPAddress_ string `yaml:"static-ip-address,omitempty"`
+
 	Status_        *status `yaml:"status"`
 	StatusHistory_ `yaml:"status-history"`
 
@@ -56,6 +58,9 @@ type MachineArgs struct {
 	Series        string
 	ContainerType string
 	Jobs          []string
+	// StaticIPAddress is the IP address requested for a container,
+	// if any.
+	StaticIPAddress string
 	// A null value means that we don't yet know which containers
 	// are supported. An empty slice means 'no containers are supported'.
 	SupportedContainers *[]string
@@ -76,6 +81,8 @@ func newMachine(args MachineArgs) *machine {
 		ContainerType_: args.ContainerType,
 		Jobs_:          jobs,
 		StatusHistory_: newStatusHistory(),
+
+		StaticIPAddress_: args.StaticIPAddress,
 	}
 	if args.SupportedContainers != nil {
 		supported := make([]string, len(*args.SupportedContainers))
@@ -110,6 +117,11 @@ func (m *machine) Placement() string {
 	return m.Placement_
 }
 
+// StaticIPAddress implements Machine.
+func (m *machine) StaticIPAddress() string {
+	return m.StaticIPAddress_
+}
+
 // Instance implements Machine.
 func (m *machine) Instance() CloudInstance {
 	// To avoid typed nils check nil here.
@@ -368,6 +380,7 @@ func importMachineV1(source map[string]interface{}) (*machine, error) {
 		"instance":             schema.StringMap(schema.Any()),
 		"series":               schema.String(),
 		"container-type":       schema.String(),
+		"static-ip-address":    schema.String(),
 		"jobs":                 schema.List(schema.String()),
 		"status":               schema.StringMap(schema.Any()),
 		"supported-containers": schema.List(schema.String()),
@@ -382,8 +395,9 @@ func importMachineV1(source map[string]interface{}) (*machine, error) {
 	}
 
 	defaults := schema.Defaults{
-		"placement":      "",
-		"container-type": "",
+		"placement":         "",
+		"container-type":    "",
+		"static-ip-address": "",
 		// Even though we are expecting instance data for every machine,
 		// it isn't strictly necessary, so we allow it to not exist here.
 		"instance":                  schema.Omit,
@@ -414,6 +428,8 @@ func importMachineV1(source map[string]interface{}) (*machine, error) {
 		Series_:        valid["series"].(string),
 		ContainerType_: valid["container-type"].(string),
 		StatusHistory_: newStatusHistory(),
+
+		StaticIPAddress_: valid["static-ip-address"].(string),
 	}
 	result.importAnnotations(valid)
 	if err := result.importStatusHistory(valid); err != nil {
@@ -101,6 +101,7 @@ func (s *MachineSerializationSuite) TestNewMachine(c *gc.C) {
 	c.Assert(m.Placement(), gc.Equals, "placement")
 	c.Assert(m.Series(), gc.Equals, "zesty")
 	c.Assert(m.ContainerType(), gc.Equals, "magic")
+	c.Assert(m.StaticIPAddress(), gc.Equals, "")
 	c.Assert(m.Jobs(), jc.DeepEquals, []string{"this", "that"})
 	supportedContainers, ok := m.SupportedContainers()
 	c.Assert(ok, jc.IsFalse)
@@ -242,6 +243,14 @@ func (s *MachineSerializationSuite) TestAnnotations(c *gc.C) {
 	c.Assert(machine.Annotations(), jc.DeepEquals, annotations)
 }
 
+func (s *MachineSerializationSuite) TestStaticIPAddress(c *gc.C) {
+	initial := minimalMachine("42")
+	initial.StaticIPAddress_ = "10.0.0.5"
+
+	machine := s.exportImport(c, initial)
+	c.Assert(machine.StaticIPAddress(), gc.Equals, "10.0.0.5")
+}
+
 func (s *MachineSerializationSuite) TestConstraints(c *gc.C) {
 	initial := minimalMachine("42")
 	args := ConstraintsArgs{
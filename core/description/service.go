@@ -28,9 +28,15 @@ type application struct {
 
 	// ForceCharm is true if an upgrade charm is forced.
 	// It means upgrade even if the charm is in an error state.
-	ForceCharm_ bool `yaml:"force-charm,omitempty"`
-	Exposed_    bool `yaml:"exposed,omitempty"`
-	MinUnits_   int  `yaml:"min-units,omitempty"`
+	ForceCharm_    bool     `yaml:"force-charm,omitempty"`
+	Exposed_       bool     `yaml:"exposed,omitempty"`
+	ExposedCIDRs_  []string `yaml:"exposed-cidrs,omitempty"`
+	ExposedSpaces_ []string `yaml:"exposed-spaces,omitempty"`
+	EgressRules_   []string `yaml:"egress-rules,omitempty"`
+	MinUnits_      int      `yaml:"min-units,omitempty"`
+
+	StorageQuotaSize_  uint64 `yaml:"storage-quota-size,omitempty"`
+	StorageQuotaCount_ uint64 `yaml:"storage-quota-count,omitempty"`
 
 	Status_        *status `yaml:"status"`
 	StatusHistory_ `yaml:"status-history"`
@@ -63,7 +69,12 @@ type ApplicationArgs struct {
 	CharmModifiedVersion int
 	ForceCharm           bool
 	Exposed              bool
+	ExposedCIDRs         []string
+	ExposedSpaces        []string
+	EgressRules          []string
 	MinUnits             int
+	StorageQuotaSize     uint64
+	StorageQuotaCount    uint64
 	Settings             map[string]interface{}
 	SettingsRefCount     int
 	Leader               string
@@ -82,7 +93,12 @@ func newApplication(args ApplicationArgs) *application {
 		CharmModifiedVersion_: args.CharmModifiedVersion,
 		ForceCharm_:           args.ForceCharm,
 		Exposed_:              args.Exposed,
+		ExposedCIDRs_:         args.ExposedCIDRs,
+		ExposedSpaces_:        args.ExposedSpaces,
+		EgressRules_:          args.EgressRules,
 		MinUnits_:             args.MinUnits,
+		StorageQuotaSize_:     args.StorageQuotaSize,
+		StorageQuotaCount_:    args.StorageQuotaCount,
 		Settings_:             args.Settings,
 		SettingsRefCount_:     args.SettingsRefCount,
 		Leader_:               args.Leader,
@@ -139,11 +155,36 @@ func (s *application) Exposed() bool {
 	return s.Exposed_
 }
 
+// ExposedCIDRs implements Application.
+func (s *application) ExposedCIDRs() []string {
+	return s.ExposedCIDRs_
+}
+
+// ExposedSpaces implements Application.
+func (s *application) ExposedSpaces() []string {
+	return s.ExposedSpaces_
+}
+
+// EgressRules implements Application.
+func (s *application) EgressRules() []string {
+	return s.EgressRules_
+}
+
 // MinUnits implements Application.
 func (s *application) MinUnits() int {
 	return s.MinUnits_
 }
 
+// StorageQuotaSize implements Application.
+func (s *application) StorageQuotaSize() uint64 {
+	return s.StorageQuotaSize_
+}
+
+// StorageQuotaCount implements Application.
+func (s *application) StorageQuotaCount() uint64 {
+	return s.StorageQuotaCount_
+}
+
 // Settings implements Application.
 func (s *application) Settings() map[string]interface{} {
 	return s.Settings_
@@ -307,7 +348,12 @@ func importApplicationV1(source map[string]interface{}) (*application, error) {
 		"charm-mod-version":   schema.Int(),
 		"force-charm":         schema.Bool(),
 		"exposed":             schema.Bool(),
+		"exposed-cidrs":       schema.List(schema.String()),
+		"exposed-spaces":      schema.List(schema.String()),
+		"egress-rules":        schema.List(schema.String()),
 		"min-units":           schema.Int(),
+		"storage-quota-size":  schema.Int(),
+		"storage-quota-count": schema.Int(),
 		"status":              schema.StringMap(schema.Any()),
 		"settings":            schema.StringMap(schema.Any()),
 		"settings-refcount":   schema.Int(),
@@ -318,12 +364,17 @@ func importApplicationV1(source map[string]interface{}) (*application, error) {
 	}
 
 	defaults := schema.Defaults{
-		"subordinate":   false,
-		"force-charm":   false,
-		"exposed":       false,
-		"min-units":     int64(0),
-		"leader":        "",
-		"metrics-creds": "",
+		"subordinate":         false,
+		"force-charm":         false,
+		"exposed":             false,
+		"exposed-cidrs":       schema.Omit,
+		"exposed-spaces":      schema.Omit,
+		"egress-rules":        schema.Omit,
+		"min-units":           int64(0),
+		"storage-quota-size":  int64(0),
+		"storage-quota-count": int64(0),
+		"leader":              "",
+		"metrics-creds":       "",
 	}
 	addAnnotationSchema(fields, defaults)
 	addConstraintsSchema(fields, defaults)
@@ -346,7 +397,12 @@ func importApplicationV1(source map[string]interface{}) (*application, error) {
 		CharmModifiedVersion_: int(valid["charm-mod-version"].(int64)),
 		ForceCharm_:           valid["force-charm"].(bool),
 		Exposed_:              valid["exposed"].(bool),
+		ExposedCIDRs_:         convertToStringSlice(valid["exposed-cidrs"]),
+		ExposedSpaces_:        convertToStringSlice(valid["exposed-spaces"]),
+		EgressRules_:          convertToStringSlice(valid["egress-rules"]),
 		MinUnits_:             int(valid["min-units"].(int64)),
+		StorageQuotaSize_:     uint64(valid["storage-quota-size"].(int64)),
+		StorageQuotaCount_:    uint64(valid["storage-quota-count"].(int64)),
 		Settings_:             valid["settings"].(map[string]interface{}),
 		SettingsRefCount_:     int(valid["settings-refcount"].(int64)),
 		Leader_:               valid["leader"].(string),
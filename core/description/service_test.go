@@ -110,7 +110,12 @@ func (s *ApplicationSerializationSuite) TestNewApplication(c *gc.C) {
 		CharmModifiedVersion: 1,
 		ForceCharm:           true,
 		Exposed:              true,
+		ExposedCIDRs:         []string{"10.0.0.0/8"},
+		ExposedSpaces:        []string{"internal"},
+		EgressRules:          []string{"53/udp", "443/tcp@10.0.0.0/8"},
 		MinUnits:             42, // no judgement is made by the migration code
+		StorageQuotaSize:     1024,
+		StorageQuotaCount:    10,
 		Settings: map[string]interface{}{
 			"key": "value",
 		},
@@ -132,7 +137,12 @@ func (s *ApplicationSerializationSuite) TestNewApplication(c *gc.C) {
 	c.Assert(application.CharmModifiedVersion(), gc.Equals, 1)
 	c.Assert(application.ForceCharm(), jc.IsTrue)
 	c.Assert(application.Exposed(), jc.IsTrue)
+	c.Assert(application.ExposedCIDRs(), jc.DeepEquals, []string{"10.0.0.0/8"})
+	c.Assert(application.ExposedSpaces(), jc.DeepEquals, []string{"internal"})
+	c.Assert(application.EgressRules(), jc.DeepEquals, []string{"53/udp", "443/tcp@10.0.0.0/8"})
 	c.Assert(application.MinUnits(), gc.Equals, 42)
+	c.Assert(application.StorageQuotaSize(), gc.Equals, uint64(1024))
+	c.Assert(application.StorageQuotaCount(), gc.Equals, uint64(10))
 	c.Assert(application.Settings(), jc.DeepEquals, args.Settings)
 	c.Assert(application.SettingsRefCount(), gc.Equals, 1)
 	c.Assert(application.Leader(), gc.Equals, "magic/1")
@@ -38,6 +38,8 @@ type unit struct {
 	MeterStatusCode_ string `yaml:"meter-status-code,omitempty"`
 	MeterStatusInfo_ string `yaml:"meter-status-info,omitempty"`
 
+	WorkloadVersion_ string `yaml:"workload-version,omitempty"`
+
 	Annotations_ `yaml:"annotations,omitempty"`
 
 	Constraints_ *constraints `yaml:"constraints,omitempty"`
@@ -54,6 +56,8 @@ type UnitArgs struct {
 	MeterStatusCode string
 	MeterStatusInfo string
 
+	WorkloadVersion string
+
 	// TODO: storage attachment count
 }
 
@@ -70,6 +74,7 @@ func newUnit(args UnitArgs) *unit {
 		Subordinates_:          subordinates,
 		MeterStatusCode_:       args.MeterStatusCode,
 		MeterStatusInfo_:       args.MeterStatusInfo,
+		WorkloadVersion_:       args.WorkloadVersion,
 		WorkloadStatusHistory_: newStatusHistory(),
 		AgentStatusHistory_:    newStatusHistory(),
 	}
@@ -122,6 +127,11 @@ func (u *unit) MeterStatusInfo() string {
 	return u.MeterStatusInfo_
 }
 
+// WorkloadVersion implements Unit.
+func (u *unit) WorkloadVersion() string {
+	return u.WorkloadVersion_
+}
+
 // Tools implements Unit.
 func (u *unit) Tools() AgentTools {
 	// To avoid a typed nil, check before returning.
@@ -271,12 +281,15 @@ func importUnitV1(source map[string]interface{}) (*unit, error) {
 
 		"meter-status-code": schema.String(),
 		"meter-status-info": schema.String(),
+
+		"workload-version": schema.String(),
 	}
 	defaults := schema.Defaults{
 		"principal":         "",
 		"subordinates":      schema.Omit,
 		"meter-status-code": "",
 		"meter-status-info": "",
+		"workload-version":  "",
 	}
 	addAnnotationSchema(fields, defaults)
 	addConstraintsSchema(fields, defaults)
@@ -297,6 +310,7 @@ func importUnitV1(source map[string]interface{}) (*unit, error) {
 		PasswordHash_:          valid["password-hash"].(string),
 		MeterStatusCode_:       valid["meter-status-code"].(string),
 		MeterStatusInfo_:       valid["meter-status-info"].(string),
+		WorkloadVersion_:       valid["workload-version"].(string),
 		WorkloadStatusHistory_: newStatusHistory(),
 		AgentStatusHistory_:    newStatusHistory(),
 	}
@@ -70,6 +70,11 @@ type StartInstanceParams struct {
 	// that may be used to start this instance.
 	ImageMetadata []*imagemetadata.ImageMetadata
 
+	// StaticIPAddress, if non-empty, is the IP address requested for a
+	// container, which must be assigned to it instead of one handed out
+	// by DHCP.
+	StaticIPAddress string
+
 	// StatusCallback is a callback to be used by the instance to report changes in status.
 	StatusCallback func(settableStatus status.Status, info string, data map[string]interface{}) error
 }
@@ -24,6 +24,7 @@ import (
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/juju/osenv"
 	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/network"
 )
 
 var logger = loggo.GetLogger("juju.environs.config")
@@ -55,6 +56,30 @@ const (
 	// refreshing the addresses, in seconds. Not too frequent, as we
 	// refresh addresses from the provider each time.
 	DefaultBootstrapSSHAddressesDelay int = 10
+
+	// DefaultUpdateStatusHookInterval is the default interval at
+	// which the update-status hook is run on each unit.
+	DefaultUpdateStatusHookInterval = 5 * time.Minute
+
+	// MinUpdateStatusHookInterval and MaxUpdateStatusHookInterval
+	// bound the values that may be configured for the update-status
+	// hook interval.
+	MinUpdateStatusHookInterval = 1 * time.Minute
+	MaxUpdateStatusHookInterval = 60 * time.Minute
+
+	// DefaultFirewallCheckInterval is the default interval at which
+	// the firewaller checks the rules applied by the provider for
+	// drift from the expected rules.
+	DefaultFirewallCheckInterval = 5 * time.Minute
+
+	// MinFirewallCheckInterval is the shortest allowed interval at
+	// which the firewaller checks for drift, unless disabled.
+	MinFirewallCheckInterval = 1 * time.Minute
+
+	// DefaultStorageUsageWarningThreshold is the default percentage
+	// of a filesystem's space or inodes that may be used before the
+	// filesystem's status is set to "warning".
+	DefaultStorageUsageWarningThreshold = 90
 )
 
 // TODO(katco-): Please grow this over time.
@@ -113,10 +138,44 @@ const (
 	// The default block storage source.
 	StorageDefaultBlockSourceKey = "storage-default-block-source"
 
+	// StorageQuotaSizeKey is the maximum total size of storage that
+	// may be provisioned from each storage pool in the model,
+	// expressed as a size, e.g. 500G.
+	StorageQuotaSizeKey = "storage-quota-size"
+
+	// StorageQuotaCountKey is the maximum number of storage instances
+	// that may be provisioned from each storage pool in the model.
+	StorageQuotaCountKey = "storage-quota-count"
+
+	// StorageUsageWarningThresholdKey is the percentage of a filesystem's
+	// space or inodes that may be used before the filesystem's status
+	// is set to "warning".
+	StorageUsageWarningThresholdKey = "storage-usage-warning-threshold"
+
 	// ResourceTagsKey is an optional list or space-separated string
 	// of k=v pairs, defining the tags for ResourceTags.
 	ResourceTagsKey = "resource-tags"
 
+	// EgressRulesKey is an optional space-separated list of egress
+	// rules, e.g. "53/udp 443/tcp@10.0.0.0/8", defining the egress
+	// traffic permitted from all machines in the model.
+	EgressRulesKey = "egress-rules"
+
+	// AddressPolicyKey determines which IP address families are used,
+	// and which one is preferred, when selecting machine and unit
+	// addresses in the model. Addresses already selected are only
+	// reselected when a machine's addresses next change.
+	AddressPolicyKey = "address-policy"
+
+	// FirewallCheckIntervalKey is how often the firewaller reads back
+	// the rules applied by the provider to detect drift from the
+	// expected rules, expressed as a duration. Zero disables checking.
+	FirewallCheckIntervalKey = "firewall-check-interval"
+
+	// FirewallAutoRepairKey determines whether the firewaller repairs
+	// any drift it detects, rather than only reporting it.
+	FirewallAutoRepairKey = "firewall-auto-repair"
+
 	// CloudImageBaseURL allows a user to override the default url that the
 	// 'ubuntu-cloudimg-query' executable uses to find container images. This
 	// is primarily for enabling Juju to work cleanly in a closed network.
@@ -145,6 +204,10 @@ const (
 	// automatically retry a hook that has failed
 	AutomaticallyRetryHooks = "automatically-retry-hooks"
 
+	// UpdateStatusHookInterval is how often the uniter runs
+	// the update-status hook, expressed as a duration.
+	UpdateStatusHookInterval = "update-status-hook-interval"
+
 	//
 	// Deprecated Settings Attributes
 	//
@@ -478,6 +541,36 @@ func Validate(cfg, old *Config) error {
 		return errors.Annotate(err, "validating resource tags")
 	}
 
+	if _, err := cfg.updateStatusHookInterval(); err != nil {
+		return errors.Trace(err)
+	}
+
+	if _, err := cfg.egressRules(); err != nil {
+		return errors.Trace(err)
+	}
+
+	if _, err := cfg.firewallCheckInterval(); err != nil {
+		return errors.Trace(err)
+	}
+
+	if _, err := cfg.storageQuotaSize(); err != nil {
+		return errors.Trace(err)
+	}
+	if v, ok := cfg.defined[StorageQuotaCountKey].(int); ok && v < 0 {
+		return &InvalidConfigValueError{
+			Key:    StorageQuotaCountKey,
+			Value:  fmt.Sprint(v),
+			Reason: errors.New("must not be negative"),
+		}
+	}
+	if v, ok := cfg.defined[StorageUsageWarningThresholdKey].(int); ok && (v < 1 || v > 100) {
+		return &InvalidConfigValueError{
+			Key:    StorageUsageWarningThresholdKey,
+			Value:  fmt.Sprint(v),
+			Reason: errors.New("must be between 1 and 100"),
+		}
+	}
+
 	// Check the immutable config values.  These can't change
 	if old != nil {
 		allImmutableAttributes := append(immutableAttributes, controller.ControllerOnlyConfigAttributes...)
@@ -820,6 +913,42 @@ func (c *Config) AutomaticallyRetryHooks() bool {
 	}
 }
 
+// UpdateStatusHookInterval returns how often the uniter should run
+// the update-status hook. By default this is every 5 minutes.
+func (c *Config) UpdateStatusHookInterval() time.Duration {
+	interval, err := c.updateStatusHookInterval()
+	if err != nil {
+		panic(err) // should be prevented by Validate
+	}
+	return interval
+}
+
+func (c *Config) updateStatusHookInterval() (time.Duration, error) {
+	v := c.asString(UpdateStatusHookInterval)
+	if v == "" {
+		return DefaultUpdateStatusHookInterval, nil
+	}
+	interval, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, &InvalidConfigValueError{
+			Key:    UpdateStatusHookInterval,
+			Value:  v,
+			Reason: err,
+		}
+	}
+	if interval < MinUpdateStatusHookInterval || interval > MaxUpdateStatusHookInterval {
+		return 0, &InvalidConfigValueError{
+			Key:   UpdateStatusHookInterval,
+			Value: v,
+			Reason: errors.Errorf(
+				"must be between %v and %v",
+				MinUpdateStatusHookInterval, MaxUpdateStatusHookInterval,
+			),
+		}
+	}
+	return interval, nil
+}
+
 // ProvisionerHarvestMode reports the harvesting methodology the
 // provisioner should take.
 func (c *Config) ProvisionerHarvestMode() HarvestMode {
@@ -886,6 +1015,126 @@ func (c *Config) StorageDefaultBlockSource() (string, bool) {
 	return bs, bs != ""
 }
 
+// StorageQuotaSize returns the maximum total size of storage, in MiB,
+// that may be provisioned from each storage pool in the model, and
+// whether or not a limit has been set.
+func (c *Config) StorageQuotaSize() (uint64, bool) {
+	size, err := c.storageQuotaSize()
+	if err != nil {
+		panic(err) // should be prevented by Validate
+	}
+	return size, size != 0
+}
+
+func (c *Config) storageQuotaSize() (uint64, error) {
+	v := c.asString(StorageQuotaSizeKey)
+	if v == "" {
+		return 0, nil
+	}
+	size, err := utils.ParseSize(v)
+	if err != nil {
+		return 0, &InvalidConfigValueError{
+			Key:    StorageQuotaSizeKey,
+			Value:  v,
+			Reason: err,
+		}
+	}
+	return size, nil
+}
+
+// StorageQuotaCount returns the maximum number of storage instances
+// that may be provisioned from each storage pool in the model, and
+// whether or not a limit has been set.
+func (c *Config) StorageQuotaCount() (uint64, bool) {
+	count, _ := c.defined[StorageQuotaCountKey].(int)
+	return uint64(count), count > 0
+}
+
+// EgressRules returns the egress rules defining the egress traffic
+// permitted from all machines in the model. If no rules are defined,
+// egress traffic is not restricted at the model level.
+func (c *Config) EgressRules() []network.EgressRule {
+	rules, err := c.egressRules()
+	if err != nil {
+		panic(err) // should be prevented by Validate
+	}
+	return rules
+}
+
+func (c *Config) egressRules() ([]network.EgressRule, error) {
+	v := c.asString(EgressRulesKey)
+	rules, err := network.ParseEgressRules(v)
+	if err != nil {
+		return nil, &InvalidConfigValueError{
+			Key:    EgressRulesKey,
+			Value:  v,
+			Reason: err,
+		}
+	}
+	return rules, nil
+}
+
+// AddressPolicy returns the policy used to select machine and unit
+// addresses in the model. By default IPv4 addresses are preferred.
+func (c *Config) AddressPolicy() network.AddressPolicy {
+	if v, ok := c.defined[AddressPolicyKey].(string); ok && v != "" {
+		return network.AddressPolicy(v)
+	}
+	return network.DefaultAddressPolicy
+}
+
+// FirewallCheckInterval returns how often the firewaller checks the
+// rules applied by the provider for drift. By default this is every
+// 5 minutes; zero means drift is never checked.
+func (c *Config) FirewallCheckInterval() time.Duration {
+	interval, err := c.firewallCheckInterval()
+	if err != nil {
+		panic(err) // should be prevented by Validate
+	}
+	return interval
+}
+
+func (c *Config) firewallCheckInterval() (time.Duration, error) {
+	v := c.asString(FirewallCheckIntervalKey)
+	if v == "" {
+		return DefaultFirewallCheckInterval, nil
+	}
+	interval, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, &InvalidConfigValueError{
+			Key:    FirewallCheckIntervalKey,
+			Value:  v,
+			Reason: err,
+		}
+	}
+	if interval != 0 && interval < MinFirewallCheckInterval {
+		return 0, &InvalidConfigValueError{
+			Key:    FirewallCheckIntervalKey,
+			Value:  v,
+			Reason: errors.Errorf("must be 0 or at least %v", MinFirewallCheckInterval),
+		}
+	}
+	return interval, nil
+}
+
+// FirewallAutoRepair reports whether the firewaller should repair any
+// drift it detects in the rules applied by the provider. By default
+// drift is only reported.
+func (c *Config) FirewallAutoRepair() bool {
+	v, _ := c.defined[FirewallAutoRepairKey].(bool)
+	return v
+}
+
+// StorageUsageWarningThreshold returns the percentage of a filesystem's
+// space or inodes that may be used before the filesystem's status is set
+// to "warning". By default this is 90%.
+func (c *Config) StorageUsageWarningThreshold() int {
+	if threshold, ok := c.defined[StorageUsageWarningThresholdKey].(int); ok {
+		return threshold
+	}
+	return DefaultStorageUsageWarningThreshold
+}
+
 // CloudImageBaseURL returns the specified override url that the 'ubuntu-
 // cloudimg-query' executable uses to find container images. The empty string
 // means that the default URL is used.
@@ -1020,13 +1269,23 @@ var alwaysOptional = schema.Defaults{
 	AgentStreamKey:               schema.Omit,
 	ResourceTagsKey:              schema.Omit,
 	CloudImageBaseURL:            schema.Omit,
+	EgressRulesKey:               schema.Omit,
+	AddressPolicyKey:             schema.Omit,
+	FirewallCheckIntervalKey:     schema.Omit,
+	FirewallAutoRepairKey:        schema.Omit,
 
 	// AutomaticallyRetryHooks is assumed to be true if missing
 	AutomaticallyRetryHooks: schema.Omit,
 
+	// UpdateStatusHookInterval is assumed to be the default if missing
+	UpdateStatusHookInterval: schema.Omit,
+
 	// Storage related config.
 	// Environ providers will specify their own defaults.
-	StorageDefaultBlockSourceKey: schema.Omit,
+	StorageDefaultBlockSourceKey:    schema.Omit,
+	StorageQuotaSizeKey:             schema.Omit,
+	StorageQuotaCountKey:            schema.Omit,
+	StorageUsageWarningThresholdKey: schema.Omit,
 
 	"proxy-ssh":                schema.Omit,
 	"enable-os-refresh-update": schema.Omit,
@@ -1436,6 +1695,33 @@ global or per instance security groups.`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	EgressRulesKey: {
+		Description: `Space-separated list of egress rules, each a port range
+optionally followed by "@" and a comma-separated list of destination CIDRs,
+e.g. "53/udp 443/tcp@10.0.0.0/8". If set, only the specified egress traffic
+is permitted from machines in the model.`,
+		Type:  environschema.Tstring,
+		Group: environschema.EnvironGroup,
+	},
+	AddressPolicyKey: {
+		Description: `Which IP address families are used, and which one is preferred,
+when selecting machine and unit addresses: "ipv4-only", "ipv6-only",
+"prefer-ipv4" or "prefer-ipv6" (default prefer-ipv4). Changes apply to
+each machine when its addresses next change`,
+		Type:   environschema.Tstring,
+		Values: []interface{}{"ipv4-only", "ipv6-only", "prefer-ipv4", "prefer-ipv6"},
+		Group:  environschema.EnvironGroup,
+	},
+	FirewallCheckIntervalKey: {
+		Description: "How often to check the provider's firewall rules for drift from the expected rules, e.g. 5m (default 5m, 0 disables checking)",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	FirewallAutoRepairKey: {
+		Description: "Whether drift detected in the provider's firewall rules is repaired, rather than only reported (default false)",
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
 	"ssl-hostname-verification": {
 		Description: "Whether SSL hostname verification is enabled (default true)",
 		Type:        environschema.Tbool,
@@ -1446,6 +1732,21 @@ global or per instance security groups.`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	StorageQuotaSizeKey: {
+		Description: "The maximum total size of storage that may be provisioned from each storage pool in the model, e.g. 500G (default unlimited)",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	StorageQuotaCountKey: {
+		Description: "The maximum number of storage instances that may be provisioned from each storage pool in the model (default unlimited)",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	StorageUsageWarningThresholdKey: {
+		Description: "The percentage of a filesystem's space or inodes that may be used before the filesystem's status is set to warning (default 90)",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
 	"test-mode": {
 		Description: `Whether the model is intended for testing.
 If true, accessing the charm store does not affect statistical
@@ -1471,4 +1772,9 @@ data of the store. (default false)`,
 		Type:        environschema.Tbool,
 		Group:       environschema.EnvironGroup,
 	},
+	UpdateStatusHookInterval: {
+		Description: "How often to run the update-status hook on each unit, e.g. 5m (default 5m, between 1m and 60m)",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 }
@@ -26,6 +26,7 @@ import (
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/testing"
 )
 
@@ -652,6 +653,48 @@ var configTests = []configTest{
 			"resource-tags": []string{"a"},
 		}),
 		err: `resource-tags: expected "key=value", got "a"`,
+	}, {
+		about:       "Invalid update-status-hook-interval",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"update-status-hook-interval": "soon",
+		}),
+		err: `invalid config value for update-status-hook-interval: "soon": time: invalid duration .*`,
+	}, {
+		about:       "update-status-hook-interval too short",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"update-status-hook-interval": "30s",
+		}),
+		err: `invalid config value for update-status-hook-interval: "30s": must be between 1m0s and 1h0m0s`,
+	}, {
+		about:       "update-status-hook-interval too long",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"update-status-hook-interval": "2h",
+		}),
+		err: `invalid config value for update-status-hook-interval: "2h": must be between 1m0s and 1h0m0s`,
+	}, {
+		about:       "Invalid storage-quota-size",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"storage-quota-size": "lots",
+		}),
+		err: `invalid config value for storage-quota-size: "lots": expected a non-negative number, got "lots"`,
+	}, {
+		about:       "Negative storage-quota-count",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"storage-quota-count": -1,
+		}),
+		err: `invalid config value for storage-quota-count: "-1": must not be negative`,
+	}, {
+		about:       "Invalid storage-usage-warning-threshold",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"storage-usage-warning-threshold": 101,
+		}),
+		err: `invalid config value for storage-usage-warning-threshold: "101": must be between 1 and 100`,
 	}, {
 		about:       "Invalid syslog server cert",
 		useDefaults: config.UseDefaults,
@@ -1466,6 +1509,118 @@ func (s *ConfigSuite) TestAutoHookRetryTrueEnv(c *gc.C) {
 	c.Assert(config.AutomaticallyRetryHooks(), gc.Equals, true)
 }
 
+func (s *ConfigSuite) TestUpdateStatusHookIntervalDefault(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{})
+	c.Assert(config.UpdateStatusHookInterval(), gc.Equals, 5*time.Minute)
+}
+
+func (s *ConfigSuite) TestUpdateStatusHookInterval(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{
+		"update-status-hook-interval": "15m"})
+	c.Assert(config.UpdateStatusHookInterval(), gc.Equals, 15*time.Minute)
+}
+
+func (s *ConfigSuite) TestStorageQuotaDefault(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{})
+	_, ok := config.StorageQuotaSize()
+	c.Assert(ok, jc.IsFalse)
+	_, ok = config.StorageQuotaCount()
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *ConfigSuite) TestStorageQuota(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{
+		"storage-quota-size":  "2G",
+		"storage-quota-count": 10,
+	})
+	size, ok := config.StorageQuotaSize()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(size, gc.Equals, uint64(2048))
+	count, ok := config.StorageQuotaCount()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(count, gc.Equals, uint64(10))
+}
+
+func (s *ConfigSuite) TestStorageUsageWarningThreshold(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{})
+	c.Assert(config.StorageUsageWarningThreshold(), gc.Equals, 90)
+	config = newTestConfig(c, testing.Attrs{
+		"storage-usage-warning-threshold": 75,
+	})
+	c.Assert(config.StorageUsageWarningThreshold(), gc.Equals, 75)
+}
+
+func (s *ConfigSuite) TestEgressRules(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{})
+	c.Assert(config.EgressRules(), gc.HasLen, 0)
+	config = newTestConfig(c, testing.Attrs{
+		"egress-rules": "53/udp 443/tcp@10.0.0.0/8",
+	})
+	c.Assert(config.EgressRules(), jc.DeepEquals, []network.EgressRule{
+		network.NewEgressRule(network.PortRange{53, 53, "udp"}),
+		network.NewEgressRule(network.PortRange{443, 443, "tcp"}, "10.0.0.0/8"),
+	})
+}
+
+func (s *ConfigSuite) TestEgressRulesInvalid(c *gc.C) {
+	_, err := config.New(config.UseDefaults, testing.FakeConfig().Merge(testing.Attrs{
+		"egress-rules": "443/tcp@10.0.0.0",
+	}))
+	c.Assert(err, gc.ErrorMatches, `invalid config value for egress-rules: "443/tcp@10.0.0.0": invalid egress rule "443/tcp@10.0.0.0": invalid destination CIDR "10.0.0.0"`)
+}
+
+func (s *ConfigSuite) TestAddressPolicy(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{})
+	c.Assert(config.AddressPolicy(), gc.Equals, network.AddressPolicyPreferIPv4)
+	config = newTestConfig(c, testing.Attrs{
+		"address-policy": "ipv6-only",
+	})
+	c.Assert(config.AddressPolicy(), gc.Equals, network.AddressPolicyIPv6Only)
+}
+
+func (s *ConfigSuite) TestAddressPolicyInvalid(c *gc.C) {
+	_, err := config.New(config.UseDefaults, testing.FakeConfig().Merge(testing.Attrs{
+		"address-policy": "ipv5",
+	}))
+	c.Assert(err, gc.ErrorMatches, `address-policy: expected one of \[ipv4-only ipv6-only prefer-ipv4 prefer-ipv6\], got "ipv5"`)
+}
+
+func (s *ConfigSuite) TestFirewallDriftSettings(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{})
+	c.Assert(config.FirewallCheckInterval(), gc.Equals, 5*time.Minute)
+	c.Assert(config.FirewallAutoRepair(), jc.IsFalse)
+
+	config = newTestConfig(c, testing.Attrs{
+		"firewall-check-interval": "10m",
+		"firewall-auto-repair":    true,
+	})
+	c.Assert(config.FirewallCheckInterval(), gc.Equals, 10*time.Minute)
+	c.Assert(config.FirewallAutoRepair(), jc.IsTrue)
+
+	config = newTestConfig(c, testing.Attrs{
+		"firewall-check-interval": "0",
+	})
+	c.Assert(config.FirewallCheckInterval(), gc.Equals, time.Duration(0))
+}
+
+func (s *ConfigSuite) TestFirewallCheckIntervalInvalid(c *gc.C) {
+	for _, test := range []struct {
+		value string
+		err   string
+	}{{
+		value: "30s",
+		err:   `invalid config value for firewall-check-interval: "30s": must be 0 or at least 1m0s`,
+	}, {
+		value: "often",
+		err:   `invalid config value for firewall-check-interval: "often": time: invalid duration "?often"?`,
+	}} {
+		_, err := config.New(config.UseDefaults, testing.FakeConfig().Merge(testing.Attrs{
+			"firewall-check-interval": test.value,
+		}))
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
 func (s *ConfigSuite) TestCloudImageBaseURL(c *gc.C) {
 	s.addJujuFiles(c)
 	config := newTestConfig(c, testing.Attrs{})
@@ -265,6 +265,60 @@ type Firewaller interface {
 	Ports() ([]network.PortRange, error)
 }
 
+// IngressRuleFirewaller is an optional interface that may be implemented
+// by an Environ whose firewall can restrict the source addresses of
+// ingress traffic.
+type IngressRuleFirewaller interface {
+	// OpenIngressRules opens the given ingress rules for the whole
+	// environment. Must only be used if the environment was setup
+	// with the FwGlobal firewall mode.
+	OpenIngressRules(rules []network.IngressRule) error
+
+	// CloseIngressRules closes the given ingress rules for the whole
+	// environment. Must only be used if the environment was setup
+	// with the FwGlobal firewall mode.
+	CloseIngressRules(rules []network.IngressRule) error
+
+	// IngressRules returns the ingress rules opened for the whole
+	// environment. Must only be used if the environment was setup
+	// with the FwGlobal firewall mode.
+	IngressRules() ([]network.IngressRule, error)
+}
+
+// EgressFirewaller is an optional interface that may be implemented
+// by an Environ whose firewall can restrict the egress traffic of
+// instances. Egress rules are allow lists: if an instance has any
+// egress rules, only the egress traffic they permit is allowed;
+// if it has none, egress traffic is not restricted.
+type EgressFirewaller interface {
+	// SetEgressRules replaces the egress rules of the instance with
+	// the given ID, started for the specified machine. An empty set
+	// of rules lifts any restriction on the instance's egress traffic.
+	SetEgressRules(machineId string, instId instance.Id, rules []network.EgressRule) error
+
+	// EgressRules returns the egress rules of the instance with the
+	// given ID, started for the specified machine.
+	EgressRules(machineId string, instId instance.Id) ([]network.EgressRule, error)
+}
+
+// VirtualIPManager is an optional interface that may be implemented
+// by an Environ that can move a virtual IP address between instances,
+// so that an application's virtual IP address is always reachable on
+// the instance hosting the application's leader unit.
+type VirtualIPManager interface {
+	// AssignVirtualIPAddress makes the given address, allocated from
+	// the subnet with the given CIDR, reachable on the instance with
+	// the given ID. Assigning an address that is already assigned to
+	// the instance is not an error.
+	AssignVirtualIPAddress(instId instance.Id, address, subnetCIDR string) error
+
+	// ReleaseVirtualIPAddress makes the given address, allocated from
+	// the subnet with the given CIDR, no longer reachable on the
+	// instance with the given ID. Releasing an address that is not
+	// assigned to the instance is not an error.
+	ReleaseVirtualIPAddress(instId instance.Id, address, subnetCIDR string) error
+}
+
 // InstanceTagger is an interface that can be used for tagging instances.
 type InstanceTagger interface {
 	// TagInstance tags the given instance with the specified tags.
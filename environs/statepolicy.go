@@ -85,3 +85,14 @@ func (environStatePolicy) InstanceDistributor(cfg *config.Config) (state.Instanc
 	}
 	return nil, errors.NotImplementedf("InstanceDistributor")
 }
+
+func (environStatePolicy) VirtualIPReleaser(cfg *config.Config) (state.VirtualIPReleaser, error) {
+	env, err := New(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if p, ok := env.(state.VirtualIPReleaser); ok {
+		return p, nil
+	}
+	return nil, errors.NotImplementedf("VirtualIPReleaser")
+}
@@ -236,6 +236,8 @@ ebs:
   provider: ebs
 loop:
   provider: loop
+lvm:
+  provider: lvm
 rootfs:
   provider: rootfs
 tmpfs:
@@ -252,6 +254,7 @@ NAME    PROVIDER  ATTRS
 block   loop      it=works
 ebs     ebs       
 loop    loop      
+lvm     lvm       
 rootfs  rootfs    
 tmpfs   tmpfs     
 
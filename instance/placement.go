@@ -5,6 +5,7 @@ package instance
 
 import (
 	"fmt"
+	"net"
 	"strings"
 
 	"gopkg.in/juju/names.v2"
@@ -31,7 +32,9 @@ type Placement struct {
 	// Directive is a scope-specific placement directive.
 	//
 	// For MachineScope or a container scope, this may be empty or
-	// the ID of an existing machine.
+	// the ID of an existing machine. A container scope directive may
+	// also request a static IP address for the container, by appending
+	// "@<address>" to the (possibly empty) machine ID.
 	Directive string
 }
 
@@ -60,9 +63,17 @@ func ParsePlacement(directive string) (*Placement, error) {
 			return nil, ErrPlacementScopeMissing
 		}
 		// Sanity check: machine/container scopes require a machine ID as the value.
-		if (scope == MachineScope || isContainerType(scope)) && !names.IsValidMachine(directive) {
+		if scope == MachineScope && !names.IsValidMachine(directive) {
 			return nil, fmt.Errorf("invalid value %q for %q scope: expected machine-id", directive, scope)
 		}
+		if isContainerType(scope) {
+			if directive == "" {
+				return nil, fmt.Errorf("invalid value %q for %q scope: expected machine-id", directive, scope)
+			}
+			if _, _, err := ParseContainerDirective(directive); err != nil {
+				return nil, fmt.Errorf("invalid value %q for %q scope: %v", directive, scope, err)
+			}
+		}
 		return &Placement{Scope: scope, Directive: directive}, nil
 	}
 	if names.IsValidMachine(directive) {
@@ -74,6 +85,27 @@ func ParsePlacement(directive string) (*Placement, error) {
 	return nil, ErrPlacementScopeMissing
 }
 
+// ParseContainerDirective splits the directive of a container scope
+// placement, of the form "[<machine-id>][@<address>]", into the ID of the
+// host machine and the static IP address requested for the container.
+// The machine ID is empty when a new host machine should be used, and
+// the address is empty when none was requested.
+func ParseContainerDirective(directive string) (machineId, address string, err error) {
+	machineId = directive
+	if at := strings.IndexRune(directive, '@'); at != -1 {
+		machineId, address = directive[:at], directive[at+1:]
+		ip := net.ParseIP(address)
+		if ip == nil {
+			return "", "", fmt.Errorf("invalid static IP address %q", address)
+		}
+		address = ip.String()
+	}
+	if machineId != "" && !names.IsValidMachine(machineId) {
+		return "", "", fmt.Errorf("expected machine-id")
+	}
+	return machineId, address, nil
+}
+
 // MustParsePlacement attempts to parse the specified string and create
 // a corresponding Placement structure, panicking if an error occurs.
 func MustParsePlacement(directive string) *Placement {
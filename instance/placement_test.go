@@ -42,6 +42,23 @@ func (s *PlacementSuite) TestParsePlacement(c *gc.C) {
 		arg:             "kvm:123",
 		expectScope:     string(instance.KVM),
 		expectDirective: "123",
+	}, {
+		arg:             "lxd:0@10.0.0.5",
+		expectScope:     string(instance.LXD),
+		expectDirective: "0@10.0.0.5",
+	}, {
+		arg:             "lxd:@fd00::5",
+		expectScope:     string(instance.LXD),
+		expectDirective: "@fd00::5",
+	}, {
+		arg: "lxd:",
+		err: `invalid value "" for "lxd" scope: expected machine-id`,
+	}, {
+		arg: "lxd:x@10.0.0.5",
+		err: `invalid value "x@10.0.0.5" for "lxd" scope: expected machine-id`,
+	}, {
+		arg: "lxd:0@foo",
+		err: `invalid value "0@foo" for "lxd" scope: invalid static IP address "foo"`,
 	}, {
 		arg:         "lxd",
 		expectScope: string(instance.LXD),
@@ -75,3 +92,40 @@ func (s *PlacementSuite) TestParsePlacement(c *gc.C) {
 		}
 	}
 }
+
+func (s *PlacementSuite) TestParseContainerDirective(c *gc.C) {
+	for i, t := range []struct {
+		directive     string
+		expectMachine string
+		expectAddress string
+		err           string
+	}{{
+		directive: "",
+	}, {
+		directive:     "0",
+		expectMachine: "0",
+	}, {
+		directive:     "0/lxd/1@10.0.0.5",
+		expectMachine: "0/lxd/1",
+		expectAddress: "10.0.0.5",
+	}, {
+		directive:     "@fd00:0::5",
+		expectAddress: "fd00::5",
+	}, {
+		directive: "0@",
+		err:       `invalid static IP address ""`,
+	}, {
+		directive: "x",
+		err:       "expected machine-id",
+	}} {
+		c.Logf("test %d: %s", i, t.directive)
+		machineId, address, err := instance.ParseContainerDirective(t.directive)
+		if t.err != "" {
+			c.Check(err, gc.ErrorMatches, t.err)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(machineId, gc.Equals, t.expectMachine)
+		c.Check(address, gc.Equals, t.expectAddress)
+	}
+}
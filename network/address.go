@@ -544,7 +544,9 @@ func filterAndCollateAddressIndexes(numAddr int, getAddrFunc addressByIndexFunc,
 // - machine-local next;
 // - link-local next;
 // - non-hostnames with unknown scope last.
-func (a Address) sortOrder() int {
+// Within each scope, IPv4 addresses come before IPv6 ones unless
+// preferIPv6 is true.
+func (a Address) sortOrder(preferIPv6 bool) int {
 	order := 0xFF
 	switch a.Scope {
 	case ScopePublic:
@@ -563,9 +565,13 @@ func (a Address) sortOrder() int {
 			order++
 		}
 	case IPv6Address:
-		// Prefer IPv4 over IPv6 addresses.
-		order++
+		if !preferIPv6 {
+			order++
+		}
 	case IPv4Address:
+		if preferIPv6 {
+			order++
+		}
 	}
 	return order
 }
@@ -577,8 +583,8 @@ func (a addressesPreferringIPv4Slice) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
 func (a addressesPreferringIPv4Slice) Less(i, j int) bool {
 	addr1 := a[i]
 	addr2 := a[j]
-	order1 := addr1.sortOrder()
-	order2 := addr2.sortOrder()
+	order1 := addr1.sortOrder(false)
+	order2 := addr2.sortOrder(false)
 	if order1 == order2 {
 		return addr1.Value < addr2.Value
 	}
@@ -0,0 +1,123 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package network
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+)
+
+// AddressPolicy determines which IP address families can be used, and
+// which of them is preferred, when selecting addresses. Hostnames are
+// allowed by every policy.
+type AddressPolicy string
+
+const (
+	// AddressPolicyIPv4Only allows only IPv4 addresses.
+	AddressPolicyIPv4Only AddressPolicy = "ipv4-only"
+
+	// AddressPolicyIPv6Only allows only IPv6 addresses.
+	AddressPolicyIPv6Only AddressPolicy = "ipv6-only"
+
+	// AddressPolicyPreferIPv4 allows both IPv4 and IPv6 addresses,
+	// preferring IPv4 ones when both are available.
+	AddressPolicyPreferIPv4 AddressPolicy = "prefer-ipv4"
+
+	// AddressPolicyPreferIPv6 allows both IPv4 and IPv6 addresses,
+	// preferring IPv6 ones when both are available.
+	AddressPolicyPreferIPv6 AddressPolicy = "prefer-ipv6"
+
+	// DefaultAddressPolicy is the policy used when none is specified.
+	DefaultAddressPolicy = AddressPolicyPreferIPv4
+)
+
+// Validate returns an error if the policy is not one of the known
+// address policies.
+func (p AddressPolicy) Validate() error {
+	switch p {
+	case AddressPolicyIPv4Only, AddressPolicyIPv6Only,
+		AddressPolicyPreferIPv4, AddressPolicyPreferIPv6:
+		return nil
+	}
+	return errors.NotValidf("address policy %q", string(p))
+}
+
+// Allows reports whether the policy allows the given address to be used.
+func (p AddressPolicy) Allows(addr Address) bool {
+	switch p {
+	case AddressPolicyIPv4Only:
+		return addr.Type != IPv6Address
+	case AddressPolicyIPv6Only:
+		return addr.Type != IPv4Address
+	}
+	return true
+}
+
+// Prefers reports whether the given address is of the address family
+// preferred by the policy. Hostnames are always preferred.
+func (p AddressPolicy) Prefers(addr Address) bool {
+	switch addr.Type {
+	case IPv4Address:
+		return !p.prefersIPv6()
+	case IPv6Address:
+		return p.prefersIPv6()
+	}
+	return true
+}
+
+func (p AddressPolicy) prefersIPv6() bool {
+	return p == AddressPolicyIPv6Only || p == AddressPolicyPreferIPv6
+}
+
+// Apply returns the addresses allowed by the policy, ordered by scope
+// as SortAddresses does but with the preferred address family first
+// within each scope. Addresses of equal order keep their relative
+// positions. The given slice is not modified.
+func (p AddressPolicy) Apply(addrs []Address) []Address {
+	result := make([]Address, 0, len(addrs))
+	for _, addr := range addrs {
+		if p.Allows(addr) {
+			result = append(result, addr)
+		}
+	}
+	sort.Stable(addressesByPolicy{result, p.prefersIPv6()})
+	return result
+}
+
+// ApplyToHostPorts returns the host/ports allowed by the policy, ordered
+// in the same way as Apply orders addresses. The given slice is not
+// modified.
+func (p AddressPolicy) ApplyToHostPorts(hps []HostPort) []HostPort {
+	result := make([]HostPort, 0, len(hps))
+	for _, hp := range hps {
+		if p.Allows(hp.Address) {
+			result = append(result, hp)
+		}
+	}
+	sort.Stable(hostPortsByPolicy{result, p.prefersIPv6()})
+	return result
+}
+
+type addressesByPolicy struct {
+	addrs      []Address
+	preferIPv6 bool
+}
+
+func (a addressesByPolicy) Len() int      { return len(a.addrs) }
+func (a addressesByPolicy) Swap(i, j int) { a.addrs[i], a.addrs[j] = a.addrs[j], a.addrs[i] }
+func (a addressesByPolicy) Less(i, j int) bool {
+	return a.addrs[i].sortOrder(a.preferIPv6) < a.addrs[j].sortOrder(a.preferIPv6)
+}
+
+type hostPortsByPolicy struct {
+	hps        []HostPort
+	preferIPv6 bool
+}
+
+func (hp hostPortsByPolicy) Len() int      { return len(hp.hps) }
+func (hp hostPortsByPolicy) Swap(i, j int) { hp.hps[i], hp.hps[j] = hp.hps[j], hp.hps[i] }
+func (hp hostPortsByPolicy) Less(i, j int) bool {
+	return hp.hps[i].sortOrder(hp.preferIPv6) < hp.hps[j].sortOrder(hp.preferIPv6)
+}
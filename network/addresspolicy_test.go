@@ -0,0 +1,95 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package network_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/testing"
+)
+
+type AddressPolicySuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&AddressPolicySuite{})
+
+func (s *AddressPolicySuite) TestValidate(c *gc.C) {
+	for _, policy := range []network.AddressPolicy{
+		network.AddressPolicyIPv4Only,
+		network.AddressPolicyIPv6Only,
+		network.AddressPolicyPreferIPv4,
+		network.AddressPolicyPreferIPv6,
+	} {
+		c.Check(policy.Validate(), jc.ErrorIsNil)
+	}
+	err := network.AddressPolicy("ipv5").Validate()
+	c.Check(err, gc.ErrorMatches, `address policy "ipv5" not valid`)
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+var policyAddresses = []network.Address{
+	network.NewScopedAddress("2001:db8::1", network.ScopePublic),
+	network.NewScopedAddress("10.0.0.1", network.ScopeCloudLocal),
+	network.NewScopedAddress("example.com", network.ScopePublic),
+	network.NewScopedAddress("203.0.113.1", network.ScopePublic),
+	network.NewScopedAddress("fd00::1", network.ScopeCloudLocal),
+}
+
+func (s *AddressPolicySuite) TestApply(c *gc.C) {
+	for i, test := range []struct {
+		policy   network.AddressPolicy
+		expected []string
+	}{{
+		policy:   network.AddressPolicyPreferIPv4,
+		expected: []string{"203.0.113.1", "2001:db8::1", "example.com", "10.0.0.1", "fd00::1"},
+	}, {
+		policy:   network.AddressPolicyPreferIPv6,
+		expected: []string{"2001:db8::1", "203.0.113.1", "example.com", "fd00::1", "10.0.0.1"},
+	}, {
+		policy:   network.AddressPolicyIPv4Only,
+		expected: []string{"203.0.113.1", "example.com", "10.0.0.1"},
+	}, {
+		policy:   network.AddressPolicyIPv6Only,
+		expected: []string{"2001:db8::1", "example.com", "fd00::1"},
+	}} {
+		c.Logf("test %d: %s", i, test.policy)
+		addrs := test.policy.Apply(policyAddresses)
+		var values []string
+		for _, addr := range addrs {
+			values = append(values, addr.Value)
+		}
+		c.Check(values, jc.DeepEquals, test.expected)
+	}
+	// The original slice is left alone.
+	c.Check(policyAddresses[0].Value, gc.Equals, "2001:db8::1")
+}
+
+func (s *AddressPolicySuite) TestApplySelection(c *gc.C) {
+	public, ok := network.SelectPublicAddress(network.AddressPolicyPreferIPv6.Apply(policyAddresses))
+	c.Check(ok, jc.IsTrue)
+	c.Check(public.Value, gc.Equals, "2001:db8::1")
+
+	internal, ok := network.SelectInternalAddress(network.AddressPolicyPreferIPv6.Apply(policyAddresses), false)
+	c.Check(ok, jc.IsTrue)
+	c.Check(internal.Value, gc.Equals, "fd00::1")
+
+	internal, ok = network.SelectInternalAddress(network.AddressPolicyIPv4Only.Apply(policyAddresses), false)
+	c.Check(ok, jc.IsTrue)
+	c.Check(internal.Value, gc.Equals, "10.0.0.1")
+}
+
+func (s *AddressPolicySuite) TestApplyToHostPorts(c *gc.C) {
+	hps := network.NewHostPorts(17070, "127.0.0.1", "::1", "10.0.0.1", "fd00::1")
+
+	c.Check(network.AddressPolicyPreferIPv6.ApplyToHostPorts(hps), jc.DeepEquals,
+		network.NewHostPorts(17070, "fd00::1", "10.0.0.1", "::1", "127.0.0.1"))
+	c.Check(network.AddressPolicyPreferIPv4.ApplyToHostPorts(hps), jc.DeepEquals,
+		network.NewHostPorts(17070, "10.0.0.1", "fd00::1", "127.0.0.1", "::1"))
+	c.Check(network.AddressPolicyIPv4Only.ApplyToHostPorts(hps), jc.DeepEquals,
+		network.NewHostPorts(17070, "10.0.0.1", "127.0.0.1"))
+}
@@ -0,0 +1,132 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package network
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+)
+
+// EgressRule represents a range of ports to which egress traffic
+// is permitted for a set of destination address ranges.
+type EgressRule struct {
+	PortRange
+
+	// DestinationCIDRs is the sorted set of address ranges, in CIDR
+	// notation, to which egress traffic on the port range is
+	// permitted.
+	DestinationCIDRs []string
+}
+
+// NewEgressRule returns an EgressRule for the given port range,
+// permitting egress traffic to the specified destination address
+// ranges. If no destination address ranges are specified, egress
+// traffic is permitted to any address.
+func NewEgressRule(portRange PortRange, destinationCIDRs ...string) EgressRule {
+	if len(destinationCIDRs) == 0 {
+		destinationCIDRs = []string{OpenToAllCIDR}
+	}
+	return EgressRule{
+		PortRange:        portRange,
+		DestinationCIDRs: set.NewStrings(destinationCIDRs...).SortedValues(),
+	}
+}
+
+// ParseEgressRule builds an EgressRule from the provided string, which
+// is a port range as accepted by ParsePortRange, optionally followed by
+// "@" and a comma-separated list of destination address ranges in CIDR
+// notation. Validate() gets called on the result before returning.
+// Example strings: "53/udp", "443/tcp@10.0.0.0/8",
+// "8000-8080/tcp@10.0.0.0/8,192.168.1.0/24".
+func ParseEgressRule(inRule string) (EgressRule, error) {
+	var cidrs []string
+	parts := strings.SplitN(inRule, "@", 2)
+	if len(parts) == 2 {
+		for _, cidr := range strings.Split(parts[1], ",") {
+			cidrs = append(cidrs, strings.TrimSpace(cidr))
+		}
+	}
+	portRange, err := ParsePortRange(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return EgressRule{}, errors.Annotatef(err, "invalid egress rule %q", inRule)
+	}
+	rule := NewEgressRule(portRange, cidrs...)
+	if err := rule.Validate(); err != nil {
+		return EgressRule{}, errors.Annotatef(err, "invalid egress rule %q", inRule)
+	}
+	return rule, nil
+}
+
+// ParseEgressRules splits the provided string on whitespace and
+// extracts an EgressRule from each field.
+// Example strings: "53/udp 443/tcp@10.0.0.0/8".
+func ParseEgressRules(inRules string) ([]EgressRule, error) {
+	var rules []EgressRule
+	for _, field := range strings.Fields(inRules) {
+		rule, err := ParseEgressRule(field)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// Validate determines if the egress rule is valid.
+func (r EgressRule) Validate() error {
+	if err := r.PortRange.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if len(r.DestinationCIDRs) == 0 {
+		return errors.New("no destination CIDRs specified")
+	}
+	for _, cidr := range r.DestinationCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return errors.Errorf("invalid destination CIDR %q", cidr)
+		}
+	}
+	return nil
+}
+
+// IsOpenToAll reports whether or not the rule permits egress
+// traffic to any address.
+func (r EgressRule) IsOpenToAll() bool {
+	return len(r.DestinationCIDRs) == 1 && r.DestinationCIDRs[0] == OpenToAllCIDR
+}
+
+// String returns the rule in the format accepted by ParseEgressRule.
+func (r EgressRule) String() string {
+	if r.IsOpenToAll() {
+		return r.PortRange.String()
+	}
+	return fmt.Sprintf("%s@%s", r.PortRange, strings.Join(r.DestinationCIDRs, ","))
+}
+
+func (r EgressRule) GoString() string {
+	return r.String()
+}
+
+type egressRuleSlice []EgressRule
+
+func (r egressRuleSlice) Len() int      { return len(r) }
+func (r egressRuleSlice) Swap(i, j int) { r[i], r[j] = r[j], r[i] }
+func (r egressRuleSlice) Less(i, j int) bool {
+	r1 := r[i]
+	r2 := r[j]
+	if r1.PortRange != r2.PortRange {
+		return portRangeSlice{r1.PortRange, r2.PortRange}.Less(0, 1)
+	}
+	return strings.Join(r1.DestinationCIDRs, ",") < strings.Join(r2.DestinationCIDRs, ",")
+}
+
+// SortEgressRules sorts the given rules, first by port range, then
+// by destination address ranges.
+func SortEgressRules(rules []EgressRule) {
+	sort.Sort(egressRuleSlice(rules))
+}
@@ -0,0 +1,121 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package network_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/testing"
+)
+
+type EgressRuleSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&EgressRuleSuite{})
+
+func (*EgressRuleSuite) TestNewEgressRule(c *gc.C) {
+	portRange := network.MustParsePortRange("443/tcp")
+	rule := network.NewEgressRule(portRange)
+	c.Assert(rule, jc.DeepEquals, network.EgressRule{
+		PortRange:        portRange,
+		DestinationCIDRs: []string{"0.0.0.0/0"},
+	})
+	c.Assert(rule.IsOpenToAll(), jc.IsTrue)
+
+	rule = network.NewEgressRule(portRange, "192.168.1.0/24", "10.0.0.0/8", "192.168.1.0/24")
+	c.Assert(rule, jc.DeepEquals, network.EgressRule{
+		PortRange:        portRange,
+		DestinationCIDRs: []string{"10.0.0.0/8", "192.168.1.0/24"},
+	})
+	c.Assert(rule.IsOpenToAll(), jc.IsFalse)
+}
+
+func (*EgressRuleSuite) TestParseEgressRule(c *gc.C) {
+	for _, test := range []struct {
+		in     string
+		expect network.EgressRule
+	}{{
+		"53/udp",
+		network.NewEgressRule(network.PortRange{53, 53, "udp"}),
+	}, {
+		"443",
+		network.NewEgressRule(network.PortRange{443, 443, "tcp"}),
+	}, {
+		"443/tcp@10.0.0.0/8",
+		network.NewEgressRule(network.PortRange{443, 443, "tcp"}, "10.0.0.0/8"),
+	}, {
+		"8000-8080/tcp@192.168.1.0/24, 10.0.0.0/8",
+		network.NewEgressRule(network.PortRange{8000, 8080, "tcp"}, "10.0.0.0/8", "192.168.1.0/24"),
+	}} {
+		c.Logf("parsing %q", test.in)
+		rule, err := network.ParseEgressRule(test.in)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(rule, jc.DeepEquals, test.expect)
+		// String round-trips through ParseEgressRule.
+		reparsed, err := network.ParseEgressRule(rule.String())
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(reparsed, jc.DeepEquals, test.expect)
+	}
+}
+
+func (*EgressRuleSuite) TestParseEgressRuleInvalid(c *gc.C) {
+	for _, test := range []struct {
+		in  string
+		err string
+	}{{
+		"443/icmp",
+		`invalid egress rule "443/icmp": invalid protocol "icmp", expected "tcp" or "udp"`,
+	}, {
+		"https/tcp",
+		`invalid egress rule "https/tcp": invalid port "https": .*`,
+	}, {
+		"443/tcp@10.0.0.0",
+		`invalid egress rule "443/tcp@10.0.0.0": invalid destination CIDR "10.0.0.0"`,
+	}} {
+		c.Logf("parsing %q", test.in)
+		_, err := network.ParseEgressRule(test.in)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (*EgressRuleSuite) TestParseEgressRules(c *gc.C) {
+	rules, err := network.ParseEgressRules(" 53/udp\t443/tcp@10.0.0.0/8 ")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.EgressRule{
+		network.NewEgressRule(network.PortRange{53, 53, "udp"}),
+		network.NewEgressRule(network.PortRange{443, 443, "tcp"}, "10.0.0.0/8"),
+	})
+
+	rules, err = network.ParseEgressRules("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.HasLen, 0)
+}
+
+func (*EgressRuleSuite) TestString(c *gc.C) {
+	portRange := network.MustParsePortRange("8000-8080/tcp")
+	c.Assert(network.NewEgressRule(portRange).String(), gc.Equals, "8000-8080/tcp")
+	c.Assert(
+		network.NewEgressRule(portRange, "10.0.0.0/8", "192.168.1.0/24").String(),
+		gc.Equals, "8000-8080/tcp@10.0.0.0/8,192.168.1.0/24",
+	)
+}
+
+func (*EgressRuleSuite) TestSortEgressRules(c *gc.C) {
+	rules := []network.EgressRule{
+		network.NewEgressRule(network.MustParsePortRange("443/tcp"), "192.168.1.0/24"),
+		network.NewEgressRule(network.MustParsePortRange("53/udp")),
+		network.NewEgressRule(network.MustParsePortRange("443/tcp"), "10.0.0.0/8"),
+		network.NewEgressRule(network.MustParsePortRange("22/tcp")),
+	}
+	network.SortEgressRules(rules)
+	c.Assert(rules, jc.DeepEquals, []network.EgressRule{
+		network.NewEgressRule(network.MustParsePortRange("22/tcp")),
+		network.NewEgressRule(network.MustParsePortRange("443/tcp"), "10.0.0.0/8"),
+		network.NewEgressRule(network.MustParsePortRange("443/tcp"), "192.168.1.0/24"),
+		network.NewEgressRule(network.MustParsePortRange("53/udp")),
+	})
+}
@@ -109,8 +109,8 @@ func (hp hostPortsPreferringIPv4Slice) Swap(i, j int) { hp[i], hp[j] = hp[j], hp
 func (hp hostPortsPreferringIPv4Slice) Less(i, j int) bool {
 	hp1 := hp[i]
 	hp2 := hp[j]
-	order1 := hp1.sortOrder()
-	order2 := hp2.sortOrder()
+	order1 := hp1.sortOrder(false)
+	order2 := hp2.sortOrder(false)
 	if order1 == order2 {
 		if hp1.Address.Value == hp2.Address.Value {
 			return hp1.Port < hp2.Port
@@ -0,0 +1,107 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package network
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+)
+
+// OpenToAllCIDR is the source address range that permits ingress
+// traffic from any address.
+const OpenToAllCIDR = "0.0.0.0/0"
+
+// IngressRule represents a range of ports that is open to ingress
+// traffic from a set of source address ranges.
+type IngressRule struct {
+	PortRange
+
+	// SourceCIDRs is the sorted set of address ranges, in CIDR
+	// notation, from which ingress traffic to the port range is
+	// permitted.
+	SourceCIDRs []string
+}
+
+// NewIngressRule returns an IngressRule for the given port range,
+// permitting ingress traffic from the specified source address
+// ranges. If no source address ranges are specified, ingress
+// traffic is permitted from any address.
+func NewIngressRule(portRange PortRange, sourceCIDRs ...string) IngressRule {
+	if len(sourceCIDRs) == 0 {
+		sourceCIDRs = []string{OpenToAllCIDR}
+	}
+	return IngressRule{
+		PortRange:   portRange,
+		SourceCIDRs: set.NewStrings(sourceCIDRs...).SortedValues(),
+	}
+}
+
+// NewOpenIngressRules returns IngressRules for each of the given port
+// ranges, permitting ingress traffic from any address.
+func NewOpenIngressRules(portRanges []PortRange) []IngressRule {
+	rules := make([]IngressRule, len(portRanges))
+	for i, portRange := range portRanges {
+		rules[i] = NewIngressRule(portRange)
+	}
+	return rules
+}
+
+// Validate determines if the ingress rule is valid.
+func (r IngressRule) Validate() error {
+	if err := r.PortRange.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if len(r.SourceCIDRs) == 0 {
+		return errors.New("no source CIDRs specified")
+	}
+	for _, cidr := range r.SourceCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return errors.Errorf("invalid source CIDR %q", cidr)
+		}
+	}
+	return nil
+}
+
+// IsOpenToAll reports whether or not the rule permits ingress
+// traffic from any address.
+func (r IngressRule) IsOpenToAll() bool {
+	return len(r.SourceCIDRs) == 1 && r.SourceCIDRs[0] == OpenToAllCIDR
+}
+
+// String returns the port range and, if the rule does not permit
+// ingress traffic from any address, the source address ranges.
+func (r IngressRule) String() string {
+	if r.IsOpenToAll() {
+		return r.PortRange.String()
+	}
+	return fmt.Sprintf("%s from %s", r.PortRange, strings.Join(r.SourceCIDRs, ","))
+}
+
+func (r IngressRule) GoString() string {
+	return r.String()
+}
+
+type ingressRuleSlice []IngressRule
+
+func (r ingressRuleSlice) Len() int      { return len(r) }
+func (r ingressRuleSlice) Swap(i, j int) { r[i], r[j] = r[j], r[i] }
+func (r ingressRuleSlice) Less(i, j int) bool {
+	r1 := r[i]
+	r2 := r[j]
+	if r1.PortRange != r2.PortRange {
+		return portRangeSlice{r1.PortRange, r2.PortRange}.Less(0, 1)
+	}
+	return strings.Join(r1.SourceCIDRs, ",") < strings.Join(r2.SourceCIDRs, ",")
+}
+
+// SortIngressRules sorts the given rules, first by port range, then
+// by source address ranges.
+func SortIngressRules(rules []IngressRule) {
+	sort.Sort(ingressRuleSlice(rules))
+}
@@ -0,0 +1,77 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package network_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/testing"
+)
+
+type IngressRuleSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&IngressRuleSuite{})
+
+func (*IngressRuleSuite) TestNewIngressRule(c *gc.C) {
+	portRange := network.MustParsePortRange("80/tcp")
+	rule := network.NewIngressRule(portRange)
+	c.Assert(rule, jc.DeepEquals, network.IngressRule{
+		PortRange:   portRange,
+		SourceCIDRs: []string{"0.0.0.0/0"},
+	})
+	c.Assert(rule.IsOpenToAll(), jc.IsTrue)
+
+	rule = network.NewIngressRule(portRange, "192.168.1.0/24", "10.0.0.0/8", "192.168.1.0/24")
+	c.Assert(rule, jc.DeepEquals, network.IngressRule{
+		PortRange:   portRange,
+		SourceCIDRs: []string{"10.0.0.0/8", "192.168.1.0/24"},
+	})
+	c.Assert(rule.IsOpenToAll(), jc.IsFalse)
+}
+
+func (*IngressRuleSuite) TestString(c *gc.C) {
+	portRange := network.MustParsePortRange("80-100/tcp")
+	c.Assert(network.NewIngressRule(portRange).String(), gc.Equals, "80-100/tcp")
+	c.Assert(
+		network.NewIngressRule(portRange, "10.0.0.0/8", "192.168.1.0/24").String(),
+		gc.Equals, "80-100/tcp from 10.0.0.0/8,192.168.1.0/24",
+	)
+}
+
+func (*IngressRuleSuite) TestValidate(c *gc.C) {
+	portRange := network.MustParsePortRange("80/tcp")
+	c.Assert(network.NewIngressRule(portRange, "10.0.0.0/8").Validate(), jc.ErrorIsNil)
+	c.Assert(
+		network.IngressRule{PortRange: portRange}.Validate(),
+		gc.ErrorMatches, "no source CIDRs specified",
+	)
+	c.Assert(
+		network.NewIngressRule(portRange, "10.0.0.0").Validate(),
+		gc.ErrorMatches, `invalid source CIDR "10.0.0.0"`,
+	)
+	c.Assert(
+		network.NewIngressRule(network.PortRange{80, 80, "icmp"}).Validate(),
+		gc.ErrorMatches, `invalid protocol "icmp", expected "tcp" or "udp"`,
+	)
+}
+
+func (*IngressRuleSuite) TestSortIngressRules(c *gc.C) {
+	rules := []network.IngressRule{
+		network.NewIngressRule(network.MustParsePortRange("80/tcp"), "192.168.1.0/24"),
+		network.NewIngressRule(network.MustParsePortRange("53/udp")),
+		network.NewIngressRule(network.MustParsePortRange("80/tcp"), "10.0.0.0/8"),
+		network.NewIngressRule(network.MustParsePortRange("22/tcp")),
+	}
+	network.SortIngressRules(rules)
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.NewIngressRule(network.MustParsePortRange("22/tcp")),
+		network.NewIngressRule(network.MustParsePortRange("80/tcp"), "10.0.0.0/8"),
+		network.NewIngressRule(network.MustParsePortRange("80/tcp"), "192.168.1.0/24"),
+		network.NewIngressRule(network.MustParsePortRange("53/udp")),
+	})
+}
@@ -0,0 +1,100 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/ssh"
+)
+
+// assignVirtualIPScript adds a virtual IP address to the interface
+// attached to the subnet it was allocated from, and announces the
+// address's new location to the rest of the subnet.
+const assignVirtualIPScript = `
+set -e
+dev=$(ip -o addr show to %[3]s | awk '{print $2; exit}')
+if [ -z "$dev" ]; then
+    echo "no interface attached to subnet %[3]s" >&2
+    exit 1
+fi
+ip addr replace %[1]s/%[2]d dev "$dev"
+if command -v arping >/dev/null; then
+    arping -q -U -c 3 -I "$dev" %[1]s || true
+fi
+`
+
+// releaseVirtualIPScript removes a virtual IP address from whichever
+// interface it was added to, if any.
+const releaseVirtualIPScript = `
+set -e
+dev=$(ip -o addr show to %[1]s/%[2]d | awk '{print $2; exit}')
+if [ -n "$dev" ]; then
+    ip addr del %[1]s/%[2]d dev "$dev"
+fi
+`
+
+// AssignVirtualIPAddress makes the given virtual IP address, allocated
+// from the subnet with the given CIDR, reachable on the host with the
+// given address. The host is configured over SSH, using the
+// controller's system identity.
+func AssignVirtualIPAddress(host, address, subnetCIDR string) error {
+	script, err := virtualIPScript(assignVirtualIPScript, address, subnetCIDR)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := runVirtualIPScript(host, script); err != nil {
+		return errors.Annotatef(err, "cannot assign virtual IP address %q to %q", address, host)
+	}
+	return nil
+}
+
+// ReleaseVirtualIPAddress makes the given virtual IP address, allocated
+// from the subnet with the given CIDR, no longer reachable on the host
+// with the given address.
+func ReleaseVirtualIPAddress(host, address, subnetCIDR string) error {
+	script, err := virtualIPScript(releaseVirtualIPScript, address, subnetCIDR)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := runVirtualIPScript(host, script); err != nil {
+		return errors.Annotatef(err, "cannot release virtual IP address %q from %q", address, host)
+	}
+	return nil
+}
+
+func virtualIPScript(format, address, subnetCIDR string) (string, error) {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return "", errors.NotValidf("virtual IP address %q", address)
+	}
+	_, ipNet, err := net.ParseCIDR(subnetCIDR)
+	if err != nil {
+		return "", errors.NotValidf("subnet CIDR %q", subnetCIDR)
+	}
+	if !ipNet.Contains(ip) {
+		return "", errors.NotValidf("virtual IP address %q in subnet %q", address, subnetCIDR)
+	}
+	prefix, _ := ipNet.Mask.Size()
+	return fmt.Sprintf(format, ip.String(), prefix, ipNet.String()), nil
+}
+
+var runVirtualIPScript = func(host, script string) error {
+	options := ssh.Options{}
+	options.SetIdentities("/var/lib/juju/system-identity")
+	command := ssh.Command("ubuntu@"+host, []string{"sudo", "/bin/bash"}, &options)
+	command.Stdin = strings.NewReader(script)
+	output, err := command.CombinedOutput()
+	if err != nil {
+		if output := strings.TrimSpace(string(output)); output != "" {
+			err = errors.Annotate(err, output)
+		}
+		return err
+	}
+	logger.Tracef("virtual IP address script output: %s", output)
+	return nil
+}
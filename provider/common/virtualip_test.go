@@ -0,0 +1,60 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common_test
+
+import (
+	"errors"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/provider/common"
+)
+
+type VirtualIPSuite struct {
+	testing.IsolationSuite
+	host   string
+	script string
+}
+
+var _ = gc.Suite(&VirtualIPSuite{})
+
+func (s *VirtualIPSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.host, s.script = "", ""
+	s.PatchValue(common.RunVirtualIPScript, func(host, script string) error {
+		s.host, s.script = host, script
+		return nil
+	})
+}
+
+func (s *VirtualIPSuite) TestAssignVirtualIPAddress(c *gc.C) {
+	err := common.AssignVirtualIPAddress("10.0.0.2", "10.0.0.100", "10.0.0.0/24")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.host, gc.Equals, "10.0.0.2")
+	c.Check(s.script, jc.Contains, "ip -o addr show to 10.0.0.0/24")
+	c.Check(s.script, jc.Contains, `ip addr replace 10.0.0.100/24 dev "$dev"`)
+}
+
+func (s *VirtualIPSuite) TestReleaseVirtualIPAddress(c *gc.C) {
+	err := common.ReleaseVirtualIPAddress("10.0.0.2", "10.0.0.100", "10.0.0.0/24")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.host, gc.Equals, "10.0.0.2")
+	c.Check(s.script, jc.Contains, `ip addr del 10.0.0.100/24 dev "$dev"`)
+}
+
+func (s *VirtualIPSuite) TestAssignVirtualIPAddressOutsideSubnet(c *gc.C) {
+	err := common.AssignVirtualIPAddress("10.0.0.2", "10.0.1.100", "10.0.0.0/24")
+	c.Assert(err, gc.ErrorMatches, `virtual IP address "10.0.1.100" in subnet "10.0.0.0/24" not valid`)
+	c.Check(s.script, gc.Equals, "")
+}
+
+func (s *VirtualIPSuite) TestAssignVirtualIPAddressFails(c *gc.C) {
+	s.PatchValue(common.RunVirtualIPScript, func(host, script string) error {
+		return errors.New("no interface attached to subnet 10.0.0.0/24")
+	})
+	err := common.AssignVirtualIPAddress("10.0.0.2", "10.0.0.100", "10.0.0.0/24")
+	c.Assert(err, gc.ErrorMatches, `cannot assign virtual IP address "10.0.0.100" to "10.0.0.2": no interface attached to subnet 10.0.0.0/24`)
+}
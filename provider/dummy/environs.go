@@ -176,6 +176,7 @@ type OpOpenPorts struct {
 	MachineId  string
 	InstanceId instance.Id
 	Ports      []network.PortRange
+	Rules      []network.IngressRule
 }
 
 type OpClosePorts struct {
@@ -183,6 +184,7 @@ type OpClosePorts struct {
 	MachineId  string
 	InstanceId instance.Id
 	Ports      []network.PortRange
+	Rules      []network.IngressRule
 }
 
 type OpPutFile struct {
@@ -218,7 +220,9 @@ type environState struct {
 	maxId           int // maximum instance id allocated so far.
 	maxAddr         int // maximum allocated address last byte
 	insts           map[instance.Id]*dummyInstance
-	globalPorts     map[network.PortRange]bool
+	globalRules     map[string]network.IngressRule
+	egressRules     map[instance.Id][]network.EgressRule
+	virtualIPs      map[string]instance.Id
 	bootstrapped    bool
 	apiListener     net.Listener
 	apiServer       *apiserver.Server
@@ -355,7 +359,9 @@ func newState(name string, ops chan<- Operation, policy state.Policy) *environSt
 		ops:         ops,
 		statePolicy: policy,
 		insts:       make(map[instance.Id]*dummyInstance),
-		globalPorts: make(map[network.PortRange]bool),
+		globalRules: make(map[string]network.IngressRule),
+		egressRules: make(map[instance.Id][]network.EgressRule),
+		virtualIPs:  make(map[string]instance.Id),
 	}
 	return s
 }
@@ -676,7 +682,7 @@ func (e *environ) Bootstrap(ctx environs.BootstrapContext, args environs.Bootstr
 	i := &dummyInstance{
 		id:           BootstrapInstanceId,
 		addresses:    network.NewAddresses("localhost"),
-		ports:        make(map[network.PortRange]bool),
+		rules:        make(map[string]network.IngressRule),
 		machineId:    agent.BootstrapMachineId,
 		series:       series,
 		firewallMode: e.Config().FirewallMode(),
@@ -911,7 +917,7 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 	i := &dummyInstance{
 		id:           instance.Id(idString),
 		addresses:    addrs,
-		ports:        make(map[network.PortRange]bool),
+		rules:        make(map[string]network.IngressRule),
 		machineId:    machineId,
 		series:       series,
 		firewallMode: e.Config().FirewallMode(),
@@ -1016,6 +1022,7 @@ func (e *environ) StopInstances(ids ...instance.Id) error {
 	defer estate.mu.Unlock()
 	for _, id := range ids {
 		delete(estate.insts, id)
+		delete(estate.egressRules, id)
 	}
 	estate.ops <- OpStopInstances{
 		Env: e.name,
@@ -1302,7 +1309,27 @@ func (e *environ) AllInstances() ([]instance.Instance, error) {
 	return insts, nil
 }
 
+var _ environs.IngressRuleFirewaller = (*environ)(nil)
+
 func (e *environ) OpenPorts(ports []network.PortRange) error {
+	return e.OpenIngressRules(network.NewOpenIngressRules(ports))
+}
+
+func (e *environ) ClosePorts(ports []network.PortRange) error {
+	return e.CloseIngressRules(network.NewOpenIngressRules(ports))
+}
+
+func (e *environ) Ports() (ports []network.PortRange, err error) {
+	rules, err := e.IngressRules()
+	if err != nil {
+		return nil, err
+	}
+	return ingressRulePorts(rules), nil
+}
+
+// OpenIngressRules is specified on the environs.IngressRuleFirewaller
+// interface.
+func (e *environ) OpenIngressRules(rules []network.IngressRule) error {
 	if mode := e.ecfg().FirewallMode(); mode != config.FwGlobal {
 		return fmt.Errorf("invalid firewall mode %q for opening ports on model", mode)
 	}
@@ -1312,13 +1339,15 @@ func (e *environ) OpenPorts(ports []network.PortRange) error {
 	}
 	estate.mu.Lock()
 	defer estate.mu.Unlock()
-	for _, p := range ports {
-		estate.globalPorts[p] = true
+	for _, rule := range rules {
+		estate.globalRules[rule.String()] = rule
 	}
 	return nil
 }
 
-func (e *environ) ClosePorts(ports []network.PortRange) error {
+// CloseIngressRules is specified on the environs.IngressRuleFirewaller
+// interface.
+func (e *environ) CloseIngressRules(rules []network.IngressRule) error {
 	if mode := e.ecfg().FirewallMode(); mode != config.FwGlobal {
 		return fmt.Errorf("invalid firewall mode %q for closing ports on model", mode)
 	}
@@ -1328,13 +1357,15 @@ func (e *environ) ClosePorts(ports []network.PortRange) error {
 	}
 	estate.mu.Lock()
 	defer estate.mu.Unlock()
-	for _, p := range ports {
-		delete(estate.globalPorts, p)
+	for _, rule := range rules {
+		delete(estate.globalRules, rule.String())
 	}
 	return nil
 }
 
-func (e *environ) Ports() (ports []network.PortRange, err error) {
+// IngressRules is specified on the environs.IngressRuleFirewaller
+// interface.
+func (e *environ) IngressRules() (rules []network.IngressRule, err error) {
 	if mode := e.ecfg().FirewallMode(); mode != config.FwGlobal {
 		return nil, fmt.Errorf("invalid firewall mode %q for retrieving ports from model", mode)
 	}
@@ -1344,11 +1375,141 @@ func (e *environ) Ports() (ports []network.PortRange, err error) {
 	}
 	estate.mu.Lock()
 	defer estate.mu.Unlock()
-	for p := range estate.globalPorts {
-		ports = append(ports, p)
+	for _, rule := range estate.globalRules {
+		rules = append(rules, rule)
+	}
+	network.SortIngressRules(rules)
+	return rules, nil
+}
+
+var _ environs.EgressFirewaller = (*environ)(nil)
+
+// SetEgressRules is specified on the environs.EgressFirewaller
+// interface.
+func (e *environ) SetEgressRules(machineId string, instId instance.Id, rules []network.EgressRule) error {
+	if err := e.checkBroken("SetEgressRules"); err != nil {
+		return err
+	}
+	estate, err := e.state()
+	if err != nil {
+		return err
+	}
+	estate.mu.Lock()
+	defer estate.mu.Unlock()
+	inst, ok := estate.insts[instId]
+	if !ok {
+		return errors.NotFoundf("instance %q", instId)
+	}
+	if inst.machineId != machineId {
+		return fmt.Errorf("setting egress rules of instance %q for machine %q, but instance is for machine %q", instId, machineId, inst.machineId)
+	}
+	if len(rules) == 0 {
+		delete(estate.egressRules, instId)
+		return nil
+	}
+	estate.egressRules[instId] = append([]network.EgressRule(nil), rules...)
+	return nil
+}
+
+// EgressRules is specified on the environs.EgressFirewaller
+// interface.
+func (e *environ) EgressRules(machineId string, instId instance.Id) ([]network.EgressRule, error) {
+	estate, err := e.state()
+	if err != nil {
+		return nil, err
+	}
+	estate.mu.Lock()
+	defer estate.mu.Unlock()
+	inst, ok := estate.insts[instId]
+	if !ok {
+		return nil, errors.NotFoundf("instance %q", instId)
+	}
+	if inst.machineId != machineId {
+		return nil, fmt.Errorf("getting egress rules of instance %q for machine %q, but instance is for machine %q", instId, machineId, inst.machineId)
+	}
+	return append([]network.EgressRule(nil), estate.egressRules[instId]...), nil
+}
+
+var _ environs.VirtualIPManager = (*environ)(nil)
+
+// AssignVirtualIPAddress is specified on the environs.VirtualIPManager
+// interface.
+func (e *environ) AssignVirtualIPAddress(instId instance.Id, address, subnetCIDR string) error {
+	if err := e.checkBroken("AssignVirtualIPAddress"); err != nil {
+		return err
+	}
+	estate, err := e.state()
+	if err != nil {
+		return err
+	}
+	estate.mu.Lock()
+	defer estate.mu.Unlock()
+	if _, ok := estate.insts[instId]; !ok {
+		return errors.NotFoundf("instance %q", instId)
+	}
+	if !subnetContains(subnetCIDR, address) {
+		return errors.NotValidf("virtual IP address %q in subnet %q", address, subnetCIDR)
+	}
+	estate.virtualIPs[address] = instId
+	return nil
+}
+
+// ReleaseVirtualIPAddress is specified on the environs.VirtualIPManager
+// interface.
+func (e *environ) ReleaseVirtualIPAddress(instId instance.Id, address, subnetCIDR string) error {
+	if err := e.checkBroken("ReleaseVirtualIPAddress"); err != nil {
+		return err
+	}
+	estate, err := e.state()
+	if err != nil {
+		return err
+	}
+	estate.mu.Lock()
+	defer estate.mu.Unlock()
+	if estate.virtualIPs[address] == instId {
+		delete(estate.virtualIPs, address)
+	}
+	return nil
+}
+
+// VirtualIPAddressInstance returns the ID of the instance the given
+// virtual IP address is assigned to, if any. It is intended for use
+// in tests.
+func (e *environ) VirtualIPAddressInstance(address string) (instance.Id, bool) {
+	estate, err := e.state()
+	if err != nil {
+		return "", false
+	}
+	estate.mu.Lock()
+	defer estate.mu.Unlock()
+	instId, ok := estate.virtualIPs[address]
+	return instId, ok
+}
+
+// subnetContains reports whether the subnet with the given CIDR
+// contains the given address.
+func subnetContains(cidr, address string) bool {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return false
+	}
+	ip := net.ParseIP(address)
+	return ip != nil && ipNet.Contains(ip)
+}
+
+// ingressRulePorts returns the distinct port ranges of the given
+// ingress rules, sorted.
+func ingressRulePorts(rules []network.IngressRule) []network.PortRange {
+	var ports []network.PortRange
+	seen := make(map[network.PortRange]bool)
+	for _, rule := range rules {
+		if !seen[rule.PortRange] {
+			seen[rule.PortRange] = true
+			ports = append(ports, rule.PortRange)
+		}
 	}
 	network.SortPortRanges(ports)
-	return
+	return ports
 }
 
 func (*environ) Provider() environs.EnvironProvider {
@@ -1357,7 +1518,7 @@ func (*environ) Provider() environs.EnvironProvider {
 
 type dummyInstance struct {
 	state        *environState
-	ports        map[network.PortRange]bool
+	rules        map[string]network.IngressRule
 	id           instance.Id
 	status       string
 	machineId    string
@@ -1439,78 +1600,116 @@ func (inst *dummyInstance) Addresses() ([]network.Address, error) {
 	return append([]network.Address{}, inst.addresses...), nil
 }
 
+var _ instance.IngressRuleFirewaller = (*dummyInstance)(nil)
+
 func (inst *dummyInstance) OpenPorts(machineId string, ports []network.PortRange) error {
+	return inst.openIngressRules("OpenPorts", machineId, network.NewOpenIngressRules(ports))
+}
+
+func (inst *dummyInstance) ClosePorts(machineId string, ports []network.PortRange) error {
+	return inst.closeIngressRules("ClosePorts", machineId, network.NewOpenIngressRules(ports))
+}
+
+func (inst *dummyInstance) Ports(machineId string) (ports []network.PortRange, err error) {
+	rules, err := inst.ingressRules("Ports", machineId)
+	if err != nil {
+		return nil, err
+	}
+	return ingressRulePorts(rules), nil
+}
+
+// OpenIngressRules is specified on the instance.IngressRuleFirewaller
+// interface.
+func (inst *dummyInstance) OpenIngressRules(machineId string, rules []network.IngressRule) error {
+	return inst.openIngressRules("OpenIngressRules", machineId, rules)
+}
+
+// CloseIngressRules is specified on the instance.IngressRuleFirewaller
+// interface.
+func (inst *dummyInstance) CloseIngressRules(machineId string, rules []network.IngressRule) error {
+	return inst.closeIngressRules("CloseIngressRules", machineId, rules)
+}
+
+// IngressRules is specified on the instance.IngressRuleFirewaller
+// interface.
+func (inst *dummyInstance) IngressRules(machineId string) ([]network.IngressRule, error) {
+	return inst.ingressRules("IngressRules", machineId)
+}
+
+func (inst *dummyInstance) openIngressRules(method, machineId string, rules []network.IngressRule) error {
 	defer delay()
-	logger.Infof("openPorts %s, %#v", machineId, ports)
+	logger.Infof("openIngressRules %s, %#v", machineId, rules)
 	if inst.firewallMode != config.FwInstance {
 		return fmt.Errorf("invalid firewall mode %q for opening ports on instance",
 			inst.firewallMode)
 	}
 	if inst.machineId != machineId {
-		panic(fmt.Errorf("OpenPorts with mismatched machine id, expected %q got %q", inst.machineId, machineId))
+		panic(fmt.Errorf("%s with mismatched machine id, expected %q got %q", method, inst.machineId, machineId))
 	}
 	inst.state.mu.Lock()
 	defer inst.state.mu.Unlock()
-	if err := inst.checkBroken("OpenPorts"); err != nil {
+	if err := inst.checkBroken(method); err != nil {
 		return err
 	}
 	inst.state.ops <- OpOpenPorts{
 		Env:        inst.state.name,
 		MachineId:  machineId,
 		InstanceId: inst.Id(),
-		Ports:      ports,
+		Ports:      ingressRulePorts(rules),
+		Rules:      rules,
 	}
-	for _, p := range ports {
-		inst.ports[p] = true
+	for _, rule := range rules {
+		inst.rules[rule.String()] = rule
 	}
 	return nil
 }
 
-func (inst *dummyInstance) ClosePorts(machineId string, ports []network.PortRange) error {
+func (inst *dummyInstance) closeIngressRules(method, machineId string, rules []network.IngressRule) error {
 	defer delay()
 	if inst.firewallMode != config.FwInstance {
 		return fmt.Errorf("invalid firewall mode %q for closing ports on instance",
 			inst.firewallMode)
 	}
 	if inst.machineId != machineId {
-		panic(fmt.Errorf("ClosePorts with mismatched machine id, expected %s got %s", inst.machineId, machineId))
+		panic(fmt.Errorf("%s with mismatched machine id, expected %s got %s", method, inst.machineId, machineId))
 	}
 	inst.state.mu.Lock()
 	defer inst.state.mu.Unlock()
-	if err := inst.checkBroken("ClosePorts"); err != nil {
+	if err := inst.checkBroken(method); err != nil {
 		return err
 	}
 	inst.state.ops <- OpClosePorts{
 		Env:        inst.state.name,
 		MachineId:  machineId,
 		InstanceId: inst.Id(),
-		Ports:      ports,
+		Ports:      ingressRulePorts(rules),
+		Rules:      rules,
 	}
-	for _, p := range ports {
-		delete(inst.ports, p)
+	for _, rule := range rules {
+		delete(inst.rules, rule.String())
 	}
 	return nil
 }
 
-func (inst *dummyInstance) Ports(machineId string) (ports []network.PortRange, err error) {
+func (inst *dummyInstance) ingressRules(method, machineId string) (rules []network.IngressRule, err error) {
 	defer delay()
 	if inst.firewallMode != config.FwInstance {
 		return nil, fmt.Errorf("invalid firewall mode %q for retrieving ports from instance",
 			inst.firewallMode)
 	}
 	if inst.machineId != machineId {
-		panic(fmt.Errorf("Ports with mismatched machine id, expected %q got %q", inst.machineId, machineId))
+		panic(fmt.Errorf("%s with mismatched machine id, expected %q got %q", method, inst.machineId, machineId))
 	}
 	inst.state.mu.Lock()
 	defer inst.state.mu.Unlock()
-	if err := inst.checkBroken("Ports"); err != nil {
+	if err := inst.checkBroken(method); err != nil {
 		return nil, err
 	}
-	for p := range inst.ports {
-		ports = append(ports, p)
+	for _, rule := range inst.rules {
+		rules = append(rules, rule)
 	}
-	network.SortPortRanges(ports)
-	return
+	network.SortIngressRules(rules)
+	return rules, nil
 }
 
 // providerDelay controls the delay before dummy responds.
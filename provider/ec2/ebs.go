@@ -428,6 +428,27 @@ func (v *ebsVolumeSource) DescribeVolumes(volIds []string) ([]storage.DescribeVo
 	return results, nil
 }
 
+// ImportVolume is specified on the storage.VolumeImporter interface.
+func (v *ebsVolumeSource) ImportVolume(volumeId string, resourceTags map[string]string) (storage.VolumeInfo, error) {
+	volume, err := describeVolume(v.ec2, volumeId)
+	if err != nil {
+		return storage.VolumeInfo{}, errors.Trace(err)
+	}
+	if volume.Status != volumeStatusAvailable {
+		return storage.VolumeInfo{}, errors.Errorf(
+			"cannot import volume with status %q", volume.Status,
+		)
+	}
+	if err := tagResources(v.ec2, resourceTags, volumeId); err != nil {
+		return storage.VolumeInfo{}, errors.Annotate(err, "tagging volume")
+	}
+	return storage.VolumeInfo{
+		VolumeId:   volumeId,
+		Size:       gibToMib(uint64(volume.Size)),
+		Persistent: true,
+	}, nil
+}
+
 // DestroyVolumes is specified on the storage.VolumeSource interface.
 func (v *ebsVolumeSource) DestroyVolumes(volIds []string) ([]error, error) {
 	return destroyVolumes(v.ec2, volIds), nil
@@ -416,6 +416,47 @@ func (s *ebsVolumeSuite) TestDescribeVolumesNotFound(c *gc.C) {
 	c.Assert(vols[0].Error, gc.ErrorMatches, "vol-42 not found")
 }
 
+func (s *ebsVolumeSuite) TestImportVolume(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	s.assertCreateVolumes(c, vs, "")
+
+	importer := vs.(storage.VolumeImporter)
+	info, err := importer.ImportVolume("vol-0", map[string]string{
+		"juju-model-uuid": "new-model",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, storage.VolumeInfo{
+		VolumeId:   "vol-0",
+		Size:       10240,
+		Persistent: true,
+	})
+
+	ec2Client := ec2.StorageEC2(vs)
+	ec2Vols, err := ec2Client.Volumes([]string{"vol-0"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ec2Vols.Volumes, gc.HasLen, 1)
+	c.Assert(ec2Vols.Volumes[0].Tags, jc.SameContents, []awsec2.Tag{
+		{"juju-model-uuid", "new-model"},
+		{"Name", "juju-sample-volume-0"},
+	})
+}
+
+func (s *ebsVolumeSuite) TestImportVolumeInUse(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	s.setupAttachVolumesTest(c, vs, ec2test.Running)
+
+	importer := vs.(storage.VolumeImporter)
+	_, err := importer.ImportVolume("vol-0", nil)
+	c.Assert(err, gc.ErrorMatches, `cannot import volume with status "in-use"`)
+}
+
+func (s *ebsVolumeSuite) TestImportVolumeNotFound(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	importer := vs.(storage.VolumeImporter)
+	_, err := importer.ImportVolume("vol-42", nil)
+	c.Assert(err, gc.ErrorMatches, ".*vol-42.*")
+}
+
 func (s *ebsVolumeSuite) TestListVolumes(c *gc.C) {
 	vs := s.volumeSource(c, nil)
 	s.assertCreateVolumes(c, vs, "")
@@ -6,12 +6,26 @@
 package lxd
 
 import (
+	"net"
+	"sort"
+	"strings"
+
 	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/provider/common"
 )
 
+// defaultSpaceName is the name (and provider id) of the single space
+// that holds every subnet of the LXD host's bridges.
+const defaultSpaceName = "default"
+
+var _ environs.Networking = (*environ)(nil)
+
 // globalFirewallName returns the name to use for the global firewall.
 func (env *environ) globalFirewallName() string {
 	return common.EnvFullName(env.uuid)
@@ -52,3 +66,143 @@ func (env *environ) Ports() ([]network.PortRange, error) {
 	}
 	return ports, errors.Trace(err)
 }
+
+// bridgeSubnets returns the subnets of the LXD host's bridges, ordered
+// by bridge name. Bridges with no known CIDR are omitted.
+func (env *environ) bridgeSubnets() ([]network.SubnetInfo, error) {
+	bridges, err := env.raw.BridgeSubnets()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	bridgeNames := make([]string, 0, len(bridges))
+	for name := range bridges {
+		bridgeNames = append(bridgeNames, name)
+	}
+	sort.Strings(bridgeNames)
+
+	var subnets []network.SubnetInfo
+	for _, name := range bridgeNames {
+		for _, cidr := range bridges[name] {
+			subnets = append(subnets, network.SubnetInfo{
+				CIDR:            cidr,
+				ProviderId:      network.Id(name + "-" + cidr),
+				SpaceProviderId: defaultSpaceName,
+			})
+		}
+	}
+	return subnets, nil
+}
+
+// Subnets returns the subnets of the LXD host's bridges. Containers are
+// attached to the same bridges, so inst is ignored. If subnetIds is not
+// empty, only the subnets with those provider ids are returned.
+func (env *environ) Subnets(inst instance.Id, subnetIds []network.Id) ([]network.SubnetInfo, error) {
+	subnets, err := env.bridgeSubnets()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(subnetIds) == 0 {
+		return subnets, nil
+	}
+
+	wanted := set.NewStrings()
+	for _, id := range subnetIds {
+		wanted.Add(string(id))
+	}
+	var result []network.SubnetInfo
+	for _, subnet := range subnets {
+		if wanted.Contains(string(subnet.ProviderId)) {
+			result = append(result, subnet)
+			wanted.Remove(string(subnet.ProviderId))
+		}
+	}
+	if !wanted.IsEmpty() {
+		return nil, errors.NotFoundf("subnets %s", strings.Join(wanted.SortedValues(), ", "))
+	}
+	return result, nil
+}
+
+// NetworkInterfaces returns no interfaces; LXD machines report their
+// own network config as observed by the machine agent.
+func (env *environ) NetworkInterfaces(instId instance.Id) ([]network.InterfaceInfo, error) {
+	return nil, nil
+}
+
+// SupportsSpaces implements environs.Networking.
+func (env *environ) SupportsSpaces() (bool, error) {
+	return true, nil
+}
+
+// SupportsSpaceDiscovery implements environs.Networking.
+func (env *environ) SupportsSpaceDiscovery() (bool, error) {
+	return true, nil
+}
+
+// Spaces returns a single "default" space holding the subnets of all
+// the LXD host's bridges.
+func (env *environ) Spaces() ([]network.SpaceInfo, error) {
+	subnets, err := env.bridgeSubnets()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(subnets) == 0 {
+		return nil, nil
+	}
+	return []network.SpaceInfo{{
+		Name:       defaultSpaceName,
+		ProviderId: defaultSpaceName,
+		Subnets:    subnets,
+	}}, nil
+}
+
+// AllocateContainerAddresses is not supported; containers on LXD
+// machines get their addresses from the host bridge.
+func (env *environ) AllocateContainerAddresses(hostInstanceID instance.Id, containerTag names.MachineTag, preparedInfo []network.InterfaceInfo) ([]network.InterfaceInfo, error) {
+	return nil, errors.NotSupportedf("container address allocation")
+}
+
+var (
+	assignVirtualIPAddress  = common.AssignVirtualIPAddress
+	releaseVirtualIPAddress = common.ReleaseVirtualIPAddress
+)
+
+var _ environs.VirtualIPManager = (*environ)(nil)
+
+// AssignVirtualIPAddress adds the virtual IP address to the container's
+// interface on the bridge that holds the given subnet.
+func (env *environ) AssignVirtualIPAddress(instId instance.Id, address, subnetCIDR string) error {
+	host, err := env.bridgeAddress(instId, subnetCIDR)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return assignVirtualIPAddress(host, address, subnetCIDR)
+}
+
+// ReleaseVirtualIPAddress removes the virtual IP address from the
+// container's interface on the bridge that holds the given subnet.
+func (env *environ) ReleaseVirtualIPAddress(instId instance.Id, address, subnetCIDR string) error {
+	host, err := env.bridgeAddress(instId, subnetCIDR)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return releaseVirtualIPAddress(host, address, subnetCIDR)
+}
+
+// bridgeAddress returns the address of the container with the given
+// ID on the bridge that holds the given subnet.
+func (env *environ) bridgeAddress(instId instance.Id, subnetCIDR string) (string, error) {
+	_, ipNet, err := net.ParseCIDR(subnetCIDR)
+	if err != nil {
+		return "", errors.NotValidf("subnet CIDR %q", subnetCIDR)
+	}
+	addresses, err := env.raw.Addresses(string(instId))
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	for _, address := range addresses {
+		if ip := net.ParseIP(address.Value); ip != nil && ipNet.Contains(ip) {
+			return address.Value, nil
+		}
+	}
+	return "", errors.NotFoundf("address of %q in subnet %q", instId, subnetCIDR)
+}
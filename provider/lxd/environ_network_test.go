@@ -6,10 +6,13 @@
 package lxd_test
 
 import (
+	"github.com/juju/errors"
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/provider/lxd"
 )
 
@@ -87,3 +90,117 @@ func (s *environNetSuite) TestPortsAPI(c *gc.C) {
 		},
 	}})
 }
+
+func (s *environNetSuite) TestSupportsSpaces(c *gc.C) {
+	supported, err := s.Env.SupportsSpaces()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(supported, jc.IsTrue)
+
+	supported, err = s.Env.SupportsSpaceDiscovery()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(supported, jc.IsTrue)
+}
+
+func (s *environNetSuite) TestSubnets(c *gc.C) {
+	s.Client.Bridges = map[string][]string{
+		"lxdbr1": {"10.0.9.0/24"},
+		"lxdbr0": {"10.0.8.0/24", "fd00:1::/64"},
+		"br-ext": nil,
+	}
+
+	subnets, err := s.Env.Subnets(instance.UnknownId, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(subnets, jc.DeepEquals, []network.SubnetInfo{{
+		CIDR:            "10.0.8.0/24",
+		ProviderId:      "lxdbr0-10.0.8.0/24",
+		SpaceProviderId: "default",
+	}, {
+		CIDR:            "fd00:1::/64",
+		ProviderId:      "lxdbr0-fd00:1::/64",
+		SpaceProviderId: "default",
+	}, {
+		CIDR:            "10.0.9.0/24",
+		ProviderId:      "lxdbr1-10.0.9.0/24",
+		SpaceProviderId: "default",
+	}})
+	s.Stub.CheckCallNames(c, "BridgeSubnets")
+}
+
+func (s *environNetSuite) TestSubnetsByProviderId(c *gc.C) {
+	s.Client.Bridges = map[string][]string{
+		"lxdbr0": {"10.0.8.0/24", "fd00:1::/64"},
+	}
+
+	subnets, err := s.Env.Subnets(instance.UnknownId, []network.Id{"lxdbr0-fd00:1::/64"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(subnets, jc.DeepEquals, []network.SubnetInfo{{
+		CIDR:            "fd00:1::/64",
+		ProviderId:      "lxdbr0-fd00:1::/64",
+		SpaceProviderId: "default",
+	}})
+
+	_, err = s.Env.Subnets(instance.UnknownId, []network.Id{"lxdbr0-10.0.8.0/24", "missing"})
+	c.Check(err, gc.ErrorMatches, "subnets missing not found")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *environNetSuite) TestSpaces(c *gc.C) {
+	s.Client.Bridges = map[string][]string{
+		"lxdbr0": {"10.0.8.0/24"},
+	}
+
+	spaces, err := s.Env.Spaces()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(spaces, jc.DeepEquals, []network.SpaceInfo{{
+		Name:       "default",
+		ProviderId: "default",
+		Subnets: []network.SubnetInfo{{
+			CIDR:            "10.0.8.0/24",
+			ProviderId:      "lxdbr0-10.0.8.0/24",
+			SpaceProviderId: "default",
+		}},
+	}})
+}
+
+func (s *environNetSuite) TestSpacesNoSubnets(c *gc.C) {
+	s.Client.Bridges = map[string][]string{
+		"lxdbr0": nil,
+	}
+
+	spaces, err := s.Env.Spaces()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(spaces, gc.HasLen, 0)
+}
+
+func (s *environNetSuite) TestSpacesError(c *gc.C) {
+	s.Stub.SetErrors(errors.New("boom"))
+
+	_, err := s.Env.Spaces()
+	c.Check(err, gc.ErrorMatches, "boom")
+}
+
+func (s *environNetSuite) TestAssignVirtualIPAddress(c *gc.C) {
+	var hosts []string
+	s.PatchValue(lxd.AssignVirtualIPAddress, func(host, address, subnetCIDR string) error {
+		hosts = append(hosts, host)
+		c.Check(address, gc.Equals, "10.0.0.100")
+		c.Check(subnetCIDR, gc.Equals, "10.0.0.0/24")
+		return nil
+	})
+
+	err := s.Env.AssignVirtualIPAddress("juju-machine-0", "10.0.0.100", "10.0.0.0/24")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(hosts, jc.DeepEquals, []string{"10.0.0.1"})
+	s.Stub.CheckCall(c, 0, "Addresses", "juju-machine-0")
+}
+
+func (s *environNetSuite) TestReleaseVirtualIPAddressNotOnSubnet(c *gc.C) {
+	s.PatchValue(lxd.ReleaseVirtualIPAddress, func(host, address, subnetCIDR string) error {
+		c.Fatalf("unexpected call")
+		return nil
+	})
+
+	err := s.Env.ReleaseVirtualIPAddress("juju-machine-0", "10.0.8.100", "10.0.8.0/24")
+	c.Check(err, gc.ErrorMatches, `address of "juju-machine-0" in subnet "10.0.8.0/24" not found`)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
@@ -17,6 +17,7 @@ type rawProvider struct {
 	lxdInstances
 	lxdProfiles
 	lxdImages
+	lxdNetworks
 	common.Firewaller
 	policyProvider
 }
@@ -37,6 +38,10 @@ type lxdImages interface {
 	EnsureImageExists(series string, sources []lxdclient.Remote, copyProgressHandler func(string)) error
 }
 
+type lxdNetworks interface {
+	BridgeSubnets() (map[string][]string, error)
+}
+
 func newRawProvider(ecfg *environConfig) (*rawProvider, error) {
 	client, err := newClient(ecfg)
 	if err != nil {
@@ -54,6 +59,7 @@ func newRawProvider(ecfg *environConfig) (*rawProvider, error) {
 		lxdInstances:   client,
 		lxdProfiles:    client,
 		lxdImages:      client,
+		lxdNetworks:    client,
 		Firewaller:     firewaller,
 		policyProvider: policy,
 	}
@@ -11,9 +11,11 @@ import (
 )
 
 var (
-	Provider           environs.EnvironProvider = providerInstance
-	GlobalFirewallName                          = (*environ).globalFirewallName
-	NewInstance                                 = newInstance
+	Provider                environs.EnvironProvider = providerInstance
+	GlobalFirewallName                               = (*environ).globalFirewallName
+	NewInstance                                      = newInstance
+	AssignVirtualIPAddress                           = &assignVirtualIPAddress
+	ReleaseVirtualIPAddress                          = &releaseVirtualIPAddress
 )
 
 func ExposeInstRaw(inst *environInstance) *lxdclient.Instance {
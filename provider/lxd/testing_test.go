@@ -314,6 +314,7 @@ func (s *BaseSuite) SetUpTest(c *gc.C) {
 	s.Env.raw = &rawProvider{
 		lxdInstances:   s.Client,
 		lxdImages:      s.Client,
+		lxdNetworks:    s.Client,
 		Firewaller:     s.Firewaller,
 		policyProvider: s.Policy,
 	}
@@ -490,8 +491,9 @@ func (s *stubPolicy) SupportedArchitectures() ([]string, error) {
 type StubClient struct {
 	*gitjujutesting.Stub
 
-	Insts []lxdclient.Instance
-	Inst  *lxdclient.Instance
+	Insts   []lxdclient.Instance
+	Inst    *lxdclient.Instance
+	Bridges map[string][]string
 }
 
 func (conn *StubClient) Instances(prefix string, statuses ...string) ([]lxdclient.Instance, error) {
@@ -543,6 +545,15 @@ func (conn *StubClient) Addresses(name string) ([]network.Address, error) {
 	}}, nil
 }
 
+func (conn *StubClient) BridgeSubnets() (map[string][]string, error) {
+	conn.AddCall("BridgeSubnets")
+	if err := conn.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return conn.Bridges, nil
+}
+
 // TODO(ericsnow) Move stubFirewaller to environs/testing or provider/common/testing.
 
 type stubFirewaller struct {
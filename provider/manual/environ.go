@@ -279,6 +279,32 @@ func (e *manualEnviron) Ports() ([]network.PortRange, error) {
 	return nil, nil
 }
 
+var (
+	assignVirtualIPAddress  = common.AssignVirtualIPAddress
+	releaseVirtualIPAddress = common.ReleaseVirtualIPAddress
+)
+
+// AssignVirtualIPAddress is specified on the environs.VirtualIPManager
+// interface.
+func (e *manualEnviron) AssignVirtualIPAddress(instId instance.Id, address, subnetCIDR string) error {
+	return assignVirtualIPAddress(e.instanceHost(instId), address, subnetCIDR)
+}
+
+// ReleaseVirtualIPAddress is specified on the environs.VirtualIPManager
+// interface.
+func (e *manualEnviron) ReleaseVirtualIPAddress(instId instance.Id, address, subnetCIDR string) error {
+	return releaseVirtualIPAddress(e.instanceHost(instId), address, subnetCIDR)
+}
+
+// instanceHost returns the host of the manually provisioned machine
+// with the given instance ID.
+func (e *manualEnviron) instanceHost(instId instance.Id) string {
+	if instId == BootstrapInstanceId {
+		return e.envConfig().bootstrapHost()
+	}
+	return strings.TrimPrefix(string(instId), string(BootstrapInstanceId))
+}
+
 func (*manualEnviron) Provider() environs.EnvironProvider {
 	return manualProvider{}
 }
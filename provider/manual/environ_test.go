@@ -118,6 +118,28 @@ exit 0
 	}
 }
 
+func (s *environSuite) TestVirtualIPAddress(c *gc.C) {
+	var calls []string
+	record := func(op string) func(host, address, subnetCIDR string) error {
+		return func(host, address, subnetCIDR string) error {
+			calls = append(calls, op+" "+host+" "+address+" "+subnetCIDR)
+			return nil
+		}
+	}
+	s.PatchValue(&assignVirtualIPAddress, record("assign"))
+	s.PatchValue(&releaseVirtualIPAddress, record("release"))
+
+	var _ environs.VirtualIPManager = s.env
+	err := s.env.AssignVirtualIPAddress(BootstrapInstanceId, "10.0.0.100", "10.0.0.0/24")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.env.ReleaseVirtualIPAddress(instance.Id("manual:10.0.0.3"), "10.0.0.100", "10.0.0.0/24")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(calls, jc.DeepEquals, []string{
+		"assign hostname 10.0.0.100 10.0.0.0/24",
+		"release 10.0.0.3 10.0.0.100 10.0.0.0/24",
+	})
+}
+
 func (s *environSuite) TestSupportedArchitectures(c *gc.C) {
 	arches, err := s.env.SupportedArchitectures()
 	c.Assert(err, jc.ErrorIsNil)
@@ -104,6 +104,9 @@ type actionDoc struct {
 
 	// Results are the structured results from the action.
 	Results map[string]interface{} `bson:"results"`
+
+	// Attachments describes the files attached to the action's results.
+	Attachments []actionAttachmentDoc `bson:"attachments,omitempty"`
 }
 
 // action represents an instruction to do some "action" and is expected
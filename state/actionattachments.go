@@ -0,0 +1,208 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/actions"
+	"github.com/juju/juju/state/storage"
+)
+
+// ActionAttachment describes a file attached to the results of an
+// action. The content of the file is held in the model's blob storage.
+type ActionAttachment struct {
+	// Name is the name of the attachment, unique within the action.
+	Name string `bson:"name"`
+
+	// Size is the size of the attachment content in bytes.
+	Size int64 `bson:"size"`
+
+	// SHA256 is the hex-encoded SHA-256 hash of the attachment content.
+	SHA256 string `bson:"sha256"`
+}
+
+// actionAttachmentDoc records a file attached to the results of an
+// action, and where its content is held in blob storage.
+type actionAttachmentDoc struct {
+	ActionAttachment `bson:",inline"`
+
+	// StoragePath is the blob storage path of the attachment content.
+	StoragePath string `bson:"storagepath"`
+}
+
+// Attachments returns the files attached to the action's results.
+func (a *action) Attachments() []ActionAttachment {
+	if len(a.doc.Attachments) == 0 {
+		return nil
+	}
+	attachments := make([]ActionAttachment, len(a.doc.Attachments))
+	for i, doc := range a.doc.Attachments {
+		attachments[i] = doc.ActionAttachment
+	}
+	return attachments
+}
+
+// actionAttachmentStoragePath returns a blob storage path for the named
+// attachment of the action with the given id, using a random UUID to
+// avoid colliding with concurrent uploads.
+func actionAttachmentStoragePath(actionId, name string) (string, error) {
+	uuid, err := utils.NewUUID()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return fmt.Sprintf("actions/%s/attachments/%s-%s", actionId, name, uuid), nil
+}
+
+// AddActionAttachment stores the content read from r as a file with the
+// given name attached to the results of the identified action. The
+// action must be running, and must not already have an attachment with
+// the same name. If expectedSHA256 is not empty, the hash of the content
+// read must match it.
+func (st *State) AddActionAttachment(tag names.ActionTag, name string, r io.Reader, size int64, expectedSHA256 string) (_ ActionAttachment, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot attach %q to action %q", name, tag.Id())
+	if !actions.IsValidAttachmentName(name) {
+		return ActionAttachment{}, errors.NotValidf("attachment name %q", name)
+	}
+	a, err := st.ActionByTag(tag)
+	if err != nil {
+		return ActionAttachment{}, errors.Trace(err)
+	}
+	if err := checkCanAttach(a, name); err != nil {
+		return ActionAttachment{}, errors.Trace(err)
+	}
+
+	path, err := actionAttachmentStoragePath(a.Id(), name)
+	if err != nil {
+		return ActionAttachment{}, errors.Trace(err)
+	}
+	hasher := sha256.New()
+	stor := storage.NewStorage(st.ModelUUID(), st.MongoSession())
+	if err := stor.Put(path, io.TeeReader(r, hasher), size); err != nil {
+		return ActionAttachment{}, errors.Trace(err)
+	}
+	// The content was written to a path unique to this upload, so it
+	// can be removed without affecting any other upload; but only if
+	// it is certain that the action does not refer to it.
+	removeContent := func() {
+		if err := stor.Remove(path); err != nil {
+			logger.Errorf("cannot remove action attachment %q: %v", path, err)
+		}
+	}
+	doc := actionAttachmentDoc{
+		ActionAttachment: ActionAttachment{
+			Name:   name,
+			Size:   size,
+			SHA256: fmt.Sprintf("%x", hasher.Sum(nil)),
+		},
+		StoragePath: path,
+	}
+	if expectedSHA256 != "" && doc.SHA256 != expectedSHA256 {
+		removeContent()
+		return ActionAttachment{}, errors.Errorf(
+			"hash mismatch: expected %q, got %q", expectedSHA256, doc.SHA256,
+		)
+	}
+
+	ops := []txn.Op{{
+		C:  actionsC,
+		Id: st.docID(a.Id()),
+		Assert: bson.D{
+			{"status", ActionRunning},
+			{"attachments.name", bson.D{{"$ne", name}}},
+		},
+		Update: bson.D{{"$push", bson.D{{"attachments", doc}}}},
+	}}
+	if err := st.runTransaction(ops); err == txn.ErrAborted {
+		removeContent()
+		a, err := st.ActionByTag(tag)
+		if err != nil {
+			return ActionAttachment{}, errors.Trace(err)
+		}
+		if err := checkCanAttach(a, name); err != nil {
+			return ActionAttachment{}, errors.Trace(err)
+		}
+		return ActionAttachment{}, errors.New("action changed while attaching")
+	} else if err != nil {
+		return ActionAttachment{}, errors.Trace(err)
+	}
+	return doc.ActionAttachment, nil
+}
+
+// checkCanAttach returns an error if a file with the given name cannot
+// be attached to the action's results.
+func checkCanAttach(a Action, name string) error {
+	if a.Status() != ActionRunning {
+		return errors.Errorf("action is %s", a.Status())
+	}
+	for _, existing := range a.Attachments() {
+		if existing.Name == name {
+			return errors.AlreadyExistsf("attachment %q", name)
+		}
+	}
+	return nil
+}
+
+// ActionAttachment returns the details and content of the named file
+// attached to the results of the identified action. It is the caller's
+// responsibility to close the returned reader.
+func (st *State) ActionAttachment(tag names.ActionTag, name string) (ActionAttachment, io.ReadCloser, error) {
+	a, err := st.ActionByTag(tag)
+	if err != nil {
+		return ActionAttachment{}, nil, errors.Trace(err)
+	}
+	for _, doc := range a.(*action).doc.Attachments {
+		if doc.Name != name {
+			continue
+		}
+		stor := storage.NewStorage(st.ModelUUID(), st.MongoSession())
+		content, _, err := stor.Get(doc.StoragePath)
+		if err != nil {
+			return ActionAttachment{}, nil, errors.Annotatef(err, "cannot read attachment %q", name)
+		}
+		return doc.ActionAttachment, content, nil
+	}
+	return ActionAttachment{}, nil, errors.NotFoundf("attachment %q for action %q", name, tag.Id())
+}
+
+// removeActionAttachments removes the content of the files attached to
+// the results of the actions matching the given query from blob
+// storage, and then removes the attachments from the actions.
+func (st *State) removeActionAttachments(query bson.D) error {
+	actions, closer := st.getCollection(actionsC)
+	defer closer()
+
+	query = append(query, bson.DocElem{"attachments", bson.D{{"$exists", true}}})
+	var docs []actionDoc
+	if err := actions.Find(query).All(&docs); err != nil {
+		return errors.Trace(err)
+	}
+	stor := storage.NewStorage(st.ModelUUID(), st.MongoSession())
+	for _, doc := range docs {
+		for _, attachment := range doc.Attachments {
+			err := stor.Remove(attachment.StoragePath)
+			if err != nil && !errors.IsNotFound(err) {
+				return errors.Annotatef(err, "cannot remove attachment %q of action %q", attachment.Name, st.localID(doc.DocId))
+			}
+		}
+		ops := []txn.Op{{
+			C:      actionsC,
+			Id:     doc.DocId,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$unset", bson.D{{"attachments", nil}}}},
+		}}
+		if err := st.runTransaction(ops); err != nil && err != txn.ErrAborted {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
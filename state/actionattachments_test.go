@@ -0,0 +1,156 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type ActionAttachmentSuite struct {
+	ConnSuite
+	unit   *state.Unit
+	action state.Action
+}
+
+var _ = gc.Suite(&ActionAttachmentSuite{})
+
+func (s *ActionAttachmentSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	ch := s.AddTestingCharm(c, "dummy")
+	app := s.AddTestingService(c, "dummy", ch)
+	var err error
+	s.unit, err = app.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	s.action, err = s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ActionAttachmentSuite) begin(c *gc.C) {
+	var err error
+	s.action, err = s.action.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func hashOf(content string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(content)))
+}
+
+func (s *ActionAttachmentSuite) TestAddActionAttachment(c *gc.C) {
+	s.begin(c)
+	content := "database dump"
+	attachment, err := s.State.AddActionAttachment(
+		s.action.ActionTag(), "dump.sql", strings.NewReader(content), int64(len(content)), hashOf(content),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachment, jc.DeepEquals, state.ActionAttachment{
+		Name:   "dump.sql",
+		Size:   int64(len(content)),
+		SHA256: hashOf(content),
+	})
+
+	action, err := s.State.ActionByTag(s.action.ActionTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(action.Attachments(), jc.DeepEquals, []state.ActionAttachment{attachment})
+
+	stored, reader, err := s.State.ActionAttachment(s.action.ActionTag(), "dump.sql")
+	c.Assert(err, jc.ErrorIsNil)
+	defer reader.Close()
+	c.Assert(stored, jc.DeepEquals, attachment)
+	data, err := ioutil.ReadAll(reader)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, content)
+}
+
+func (s *ActionAttachmentSuite) TestAddActionAttachmentSurvivesFinish(c *gc.C) {
+	s.begin(c)
+	_, err := s.State.AddActionAttachment(s.action.ActionTag(), "report", strings.NewReader("ok"), 2, "")
+	c.Assert(err, jc.ErrorIsNil)
+	action, err := s.action.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(action.Attachments(), gc.HasLen, 1)
+	c.Assert(action.Attachments()[0].Name, gc.Equals, "report")
+}
+
+func (s *ActionAttachmentSuite) TestAddActionAttachmentNotRunning(c *gc.C) {
+	_, err := s.State.AddActionAttachment(s.action.ActionTag(), "report", strings.NewReader("ok"), 2, "")
+	c.Assert(err, gc.ErrorMatches, `cannot attach "report" to action ".*": action is pending`)
+}
+
+func (s *ActionAttachmentSuite) TestAddActionAttachmentInvalidName(c *gc.C) {
+	s.begin(c)
+	_, err := s.State.AddActionAttachment(s.action.ActionTag(), "../etc/passwd", strings.NewReader("ok"), 2, "")
+	c.Assert(err, gc.ErrorMatches, `cannot attach "../etc/passwd" to action ".*": attachment name "../etc/passwd" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *ActionAttachmentSuite) TestAddActionAttachmentDuplicateName(c *gc.C) {
+	s.begin(c)
+	_, err := s.State.AddActionAttachment(s.action.ActionTag(), "report", strings.NewReader("ok"), 2, "")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddActionAttachment(s.action.ActionTag(), "report", strings.NewReader("ko"), 2, "")
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *ActionAttachmentSuite) TestAddActionAttachmentDuplicateNameConcurrent(c *gc.C) {
+	s.begin(c)
+	defer state.SetBeforeHooks(c, s.State, func() {
+		_, err := s.State.AddActionAttachment(s.action.ActionTag(), "report", strings.NewReader("ok"), 2, "")
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	_, err := s.State.AddActionAttachment(s.action.ActionTag(), "report", strings.NewReader("ko"), 2, "")
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+
+	// The content of the attachment that won is intact.
+	_, reader, err := s.State.ActionAttachment(s.action.ActionTag(), "report")
+	c.Assert(err, jc.ErrorIsNil)
+	defer reader.Close()
+	data, err := ioutil.ReadAll(reader)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "ok")
+}
+
+func (s *ActionAttachmentSuite) TestAddActionAttachmentHashMismatch(c *gc.C) {
+	s.begin(c)
+	_, err := s.State.AddActionAttachment(s.action.ActionTag(), "report", strings.NewReader("ok"), 2, hashOf("ko"))
+	c.Assert(err, gc.ErrorMatches, `cannot attach "report" to action ".*": hash mismatch: .*`)
+
+	_, _, err = s.State.ActionAttachment(s.action.ActionTag(), "report")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ActionAttachmentSuite) TestActionAttachmentNotFound(c *gc.C) {
+	_, _, err := s.State.ActionAttachment(s.action.ActionTag(), "missing")
+	c.Assert(err, gc.ErrorMatches, `attachment "missing" for action ".*" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ActionAttachmentSuite) TestRemoveUnitRemovesAttachments(c *gc.C) {
+	s.begin(c)
+	_, err := s.State.AddActionAttachment(s.action.ActionTag(), "report", strings.NewReader("ok"), 2, "")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+
+	action, err := s.State.ActionByTag(s.action.ActionTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(action.Status(), gc.Equals, state.ActionCancelled)
+	c.Assert(action.Attachments(), gc.HasLen, 0)
+	_, _, err = s.State.ActionAttachment(s.action.ActionTag(), "report")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
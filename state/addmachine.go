@@ -11,6 +11,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/replicaset"
 	jujutxn "github.com/juju/txn"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
@@ -92,6 +93,12 @@ type MachineTemplate struct {
 	// with the machine.
 	Placement string
 
+	// StaticIPAddress holds the IP address requested for a new
+	// container. It must be part of a known subnet and not be in use
+	// by, or requested for, any other machine. It cannot be set for
+	// machines which are not containers.
+	StaticIPAddress string
+
 	// principals holds the principal units that will
 	// associated with the machine.
 	principals []string
@@ -202,6 +209,13 @@ func (st *State) addMachine(mdoc *machineDoc, ops []txn.Op) (*Machine, error) {
 			if err := checkModelActive(st); err != nil {
 				return nil, errors.Trace(err)
 			}
+			if mdoc.StaticIPAddress != "" {
+				// The address may have been requested for
+				// another machine in the meantime.
+				if _, err := st.checkStaticIPAddress(mdoc.StaticIPAddress, ""); err != nil {
+					return nil, errors.Annotate(err, "cannot add a new machine")
+				}
+			}
 		}
 		return nil, errors.Trace(err)
 	}
@@ -270,6 +284,9 @@ func (st *State) addMachineOps(template MachineTemplate) (*machineDoc, []txn.Op,
 	if err != nil {
 		return nil, nil, err
 	}
+	if template.StaticIPAddress != "" {
+		return nil, nil, errStaticIPAddressNotContainer
+	}
 	if template.InstanceId == "" {
 		if err := st.precheckInstance(template.Series, template.Constraints, template.Placement); err != nil {
 			return nil, nil, err
@@ -342,6 +359,11 @@ func (st *State) addMachineInsideMachineOps(template MachineTemplate, parentId s
 	if containerType == "" {
 		return nil, nil, errors.New("no container type specified")
 	}
+	if template.StaticIPAddress != "" {
+		if _, err := st.checkStaticIPAddress(template.StaticIPAddress, ""); err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+	}
 	// Adding a machine within a machine implies add-machine or placement.
 	if err := st.supportsUnitPlacement(); err != nil {
 		return nil, nil, err
@@ -405,6 +427,9 @@ func (st *State) addMachineInsideNewMachineOps(template, parentTemplate MachineT
 	if containerType == "" {
 		return nil, nil, errors.New("no container type specified")
 	}
+	if parentTemplate.StaticIPAddress != "" {
+		return nil, nil, errStaticIPAddressNotContainer
+	}
 	if parentTemplate.InstanceId == "" {
 		// Adding a machine within a machine implies add-machine or placement.
 		if err := st.supportsUnitPlacement(); err != nil {
@@ -424,6 +449,11 @@ func (st *State) addMachineInsideNewMachineOps(template, parentTemplate MachineT
 	if err != nil {
 		return nil, nil, err
 	}
+	if template.StaticIPAddress != "" {
+		if _, err := st.checkStaticIPAddress(template.StaticIPAddress, ""); err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+	}
 	mdoc := st.machineDocForTemplate(template, newId)
 	mdoc.ContainerType = string(containerType)
 	parentPrereqOps, parentOp, err := st.insertNewMachineOps(parentDoc, parentTemplate)
@@ -466,6 +496,7 @@ func (st *State) machineDocForTemplate(template MachineTemplate, id string) *mac
 		PreferredPublicAddress:  fromNetworkAddress(publicAddr, OriginMachine),
 		NoVote:                  template.NoVote,
 		Placement:               template.Placement,
+		StaticIPAddress:         template.StaticIPAddress,
 	}
 }
 
@@ -538,6 +569,9 @@ func (st *State) baseNewMachineOps(mdoc *machineDoc, machineStatusDoc, instanceS
 		createMachineBlockDevicesOp(mdoc.Id),
 		addModelMachineRefOp(st, mdoc.Id),
 	}
+	if mdoc.StaticIPAddress != "" {
+		prereqOps = append(prereqOps, st.reserveIPAddressOp(mdoc.StaticIPAddress))
+	}
 	return prereqOps, machineOp
 }
 
@@ -588,8 +622,29 @@ func (st *State) machineStorageOps(
 		})
 	}
 
-	// TODO(axw) handle args.filesystemAttachments, args.volumeAttachments
-	// when we handle attaching to existing (e.g. shared) storage.
+	// Create attachments to existing (shared) filesystems, in order
+	// of tag to simplify testing. Filesystems that are already attached
+	// to the machine, e.g. because another unit on the machine shares
+	// the filesystem, are skipped.
+	filesystemIds := set.NewStrings()
+	for tag := range args.filesystemAttachments {
+		filesystemIds.Add(tag.Id())
+	}
+	attachedFilesystems := set.NewStrings(mdoc.Filesystems...)
+	for _, id := range filesystemIds.Difference(attachedFilesystems).SortedValues() {
+		tag := names.NewFilesystemTag(id)
+		ops, storageTag, err := st.attachExistingFilesystemOps(tag, mdoc.Id)
+		if err != nil {
+			return nil, nil, nil, errors.Trace(err)
+		}
+		filesystemOps = append(filesystemOps, ops...)
+		fsAttachments = append(fsAttachments, filesystemAttachmentTemplate{
+			tag, storageTag, args.filesystemAttachments[tag],
+		})
+	}
+
+	// TODO(axw) handle args.volumeAttachments when we handle
+	// attaching to existing (e.g. shared) volumes.
 
 	ops := make([]txn.Op, 0, len(filesystemOps)+len(volumeOps)+len(fsAttachments)+len(volumeAttachments))
 	if len(fsAttachments) > 0 {
@@ -676,6 +731,8 @@ func hasJob(jobs []MachineJob, job MachineJob) bool {
 
 var errControllerNotAllowed = errors.New("controller jobs specified but not allowed")
 
+var errStaticIPAddressNotContainer = errors.New("static IP address can only be requested for containers")
+
 // maintainControllersOps returns a set of operations that will maintain
 // the controller information when the given machine documents
 // are added to the machines collection. If currentInfo is nil,
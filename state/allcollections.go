@@ -241,6 +241,10 @@ func allCollections() collectionSchema {
 
 		// meterStatusC is the collection used to store meter status information.
 		meterStatusC:  {},
+
+		// unitHookHistoryC holds the most recent hook executions of
+		// each unit, for diagnosing slow or failing hooks.
+		unitHookHistoryC: {},
 		settingsrefsC: {},
 		relationsC: {
 			indexes: []mgo.Index{{
@@ -251,6 +255,11 @@ func allCollections() collectionSchema {
 		},
 		relationScopesC: {},
 
+		// These collections hold information associated with relations
+		// to applications in other models on the same controller.
+		remoteApplicationsC: {},
+		applicationOffersC:  {},
+
 		// -----
 
 		// These collections hold information associated with machines.
@@ -286,12 +295,18 @@ func allCollections() collectionSchema {
 				Key: []string{"model-uuid", "unitid"},
 			}},
 		},
+		storagePoolUsageC: {},
 		volumesC: {
 			indexes: []mgo.Index{{
 				Key: []string{"model-uuid", "storageid"},
 			}},
 		},
 		volumeAttachmentsC: {},
+		volumeSnapshotsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "volumeid"},
+			}},
+		},
 
 		// -----
 
@@ -373,6 +388,7 @@ const (
 	actionresultsC           = "actionresults"
 	actionsC                 = "actions"
 	annotationsC             = "annotations"
+	applicationOffersC       = "applicationOffers"
 	assignUnitC              = "assignUnits"
 	bakeryStorageItemsC      = "bakeryStorageItems"
 	blockDevicesC            = "blockdevices"
@@ -410,6 +426,7 @@ const (
 	rebootC                  = "reboot"
 	relationScopesC          = "relationscopes"
 	relationsC               = "relations"
+	remoteApplicationsC      = "remoteApplications"
 	restoreInfoC             = "restoreInfo"
 	sequenceC                = "sequence"
 	applicationsC            = "applications"
@@ -423,6 +440,7 @@ const (
 	storageAttachmentsC      = "storageattachments"
 	storageConstraintsC      = "storageconstraints"
 	storageInstancesC        = "storageinstances"
+	storagePoolUsageC        = "storagepoolusage"
 	subnetsC                 = "subnets"
 	linkLayerDevicesC        = "linklayerdevices"
 	linkLayerDevicesRefsC    = "linklayerdevicesrefs"
@@ -430,6 +448,7 @@ const (
 	toolsmetadataC           = "toolsmetadata"
 	txnLogC                  = "txns.log"
 	txnsC                    = "txns"
+	unitHookHistoryC         = "unithookhistory"
 	unitsC                   = "units"
 	upgradeInfoC             = "upgradeInfo"
 	userLastLoginC           = "userLastLogin"
@@ -437,6 +456,7 @@ const (
 	usersC                   = "users"
 	volumeAttachmentsC       = "volumeattachments"
 	volumesC                 = "volumes"
+	volumeSnapshotsC         = "volumesnapshots"
 	// "payloads" (see payload/persistence/mongo.go)
 	// "resources" (see resource/persistence/mongo.go)
 )
@@ -6,6 +6,7 @@ package state
 import (
 	stderrors "errors"
 	"fmt"
+	"net"
 	"sort"
 	"strconv"
 	"strings"
@@ -14,6 +15,7 @@ import (
 	"github.com/juju/errors"
 	jujutxn "github.com/juju/txn"
 	"github.com/juju/utils/series"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/charm.v6-unstable"
 	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
 	"gopkg.in/juju/names.v2"
@@ -35,22 +37,31 @@ type Application struct {
 // serviceDoc represents the internal state of an application in MongoDB.
 // Note the correspondence with ApplicationInfo in apiserver.
 type applicationDoc struct {
-	DocID                string     `bson:"_id"`
-	Name                 string     `bson:"name"`
-	ModelUUID            string     `bson:"model-uuid"`
-	Series               string     `bson:"series"`
-	Subordinate          bool       `bson:"subordinate"`
-	CharmURL             *charm.URL `bson:"charmurl"`
-	Channel              string     `bson:"cs-channel"`
-	CharmModifiedVersion int        `bson:"charmmodifiedversion"`
-	ForceCharm           bool       `bson:"forcecharm"`
-	Life                 Life       `bson:"life"`
-	UnitCount            int        `bson:"unitcount"`
-	RelationCount        int        `bson:"relationcount"`
-	Exposed              bool       `bson:"exposed"`
-	MinUnits             int        `bson:"minunits"`
-	TxnRevno             int64      `bson:"txn-revno"`
-	MetricCredentials    []byte     `bson:"metric-credentials"`
+	DocID                string            `bson:"_id"`
+	Name                 string            `bson:"name"`
+	ModelUUID            string            `bson:"model-uuid"`
+	Series               string            `bson:"series"`
+	Subordinate          bool              `bson:"subordinate"`
+	CharmURL             *charm.URL        `bson:"charmurl"`
+	Channel              string            `bson:"cs-channel"`
+	CharmModifiedVersion int               `bson:"charmmodifiedversion"`
+	ForceCharm           bool              `bson:"forcecharm"`
+	Life                 Life              `bson:"life"`
+	UnitCount            int               `bson:"unitcount"`
+	RelationCount        int               `bson:"relationcount"`
+	Exposed              bool              `bson:"exposed"`
+	ExposedCIDRs         []string          `bson:"exposed-cidrs,omitempty"`
+	ExposedSpaces        []string          `bson:"exposed-spaces,omitempty"`
+	EgressRules          []egressRuleDoc   `bson:"egress-rules,omitempty"`
+	RelationIngress      map[string]string `bson:"relation-ingress,omitempty"`
+	MinUnits             int               `bson:"minunits"`
+	TxnRevno             int64             `bson:"txn-revno"`
+	MetricCredentials    []byte            `bson:"metric-credentials"`
+	StorageQuota         StorageQuota      `bson:"storagequota,omitempty"`
+	VirtualIPAddress     string            `bson:"virtual-ip-address,omitempty"`
+	VirtualIPSubnet      string            `bson:"virtual-ip-subnet,omitempty"`
+	VirtualIPHolder      string            `bson:"virtual-ip-holder,omitempty"`
+	VirtualIPInstance    string            `bson:"virtual-ip-instance,omitempty"`
 }
 
 func newApplication(st *State, doc *applicationDoc) *Application {
@@ -191,7 +202,11 @@ func (s *Application) destroyOps() ([]txn.Op, error) {
 	// removed, the application can also be removed.
 	if s.doc.UnitCount == 0 && s.doc.RelationCount == removeCount {
 		hasLastRefs := bson.D{{"life", Alive}, {"unitcount", 0}, {"relationcount", removeCount}}
-		return append(ops, s.removeOps(hasLastRefs)...), nil
+		removeOps, err := s.removeOps(hasLastRefs)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, removeOps...), nil
 	}
 	// In all other cases, application removal will be handled as a consequence
 	// of the removal of the last unit or relation referencing it. If any
@@ -245,8 +260,15 @@ func removeResourcesOps(st *State, serviceID string) ([]txn.Op, error) {
 
 // removeOps returns the operations required to remove the service. Supplied
 // asserts will be included in the operation on the application document.
-func (s *Application) removeOps(asserts bson.D) []txn.Op {
+func (s *Application) removeOps(asserts bson.D) ([]txn.Op, error) {
 	settingsDocID := s.st.docID(s.settingsKey())
+	// The reservation of the application's virtual IP address must be
+	// released along with it, so assert that it is the one we know of.
+	vipAssert := bson.DocElem{"virtual-ip-address", bson.D{{"$exists", false}}}
+	if s.doc.VirtualIPAddress != "" {
+		vipAssert.Value = s.doc.VirtualIPAddress
+	}
+	asserts = append(append(bson.D{}, asserts...), vipAssert)
 	ops := []txn.Op{
 		{
 			C:      applicationsC,
@@ -276,7 +298,17 @@ func (s *Application) removeOps(asserts bson.D) []txn.Op {
 	if s.doc.CharmURL.Schema == "local" {
 		ops = append(ops, s.st.newCleanupOp(cleanupCharmForDyingService, s.doc.CharmURL.String()))
 	}
-	return ops
+	if s.doc.VirtualIPAddress != "" {
+		// The address must be released from the instance it was last
+		// assigned to before its reservation is, which needs the
+		// provider, so it is left to a cleanup.
+		ops = append(ops, s.virtualIPCleanupOp())
+	}
+	offerOps, err := removeApplicationOffersOps(s.st, s.doc.Name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append(ops, offerOps...), nil
 }
 
 // IsExposed returns whether this application is exposed. The explicitly open
@@ -286,29 +318,96 @@ func (s *Application) IsExposed() bool {
 	return s.doc.Exposed
 }
 
-// SetExposed marks the application as exposed.
+// ExposedCIDRs returns the source address ranges, in CIDR notation,
+// from which the explicitly open ports of an exposed application may
+// be accessed. If neither ExposedCIDRs nor ExposedSpaces returns any
+// values, the ports of an exposed application may be accessed from
+// any address. See SetExposedTo.
+func (s *Application) ExposedCIDRs() []string {
+	return s.doc.ExposedCIDRs
+}
+
+// ExposedSpaces returns the names of the spaces from whose subnets
+// the explicitly open ports of an exposed application may be accessed.
+// See ExposedCIDRs and SetExposedTo.
+func (s *Application) ExposedSpaces() []string {
+	return s.doc.ExposedSpaces
+}
+
+// SetExposed marks the application as exposed to all addresses,
+// removing any restrictions previously set with SetExposedTo.
 // See ClearExposed and IsExposed.
 func (s *Application) SetExposed() error {
-	return s.setExposed(true)
+	return s.setExposed(true, nil, nil)
+}
+
+// SetExposedTo marks the application as exposed to the given source
+// address ranges, in CIDR notation, and to the subnets of the named
+// spaces only. If no address ranges or spaces are specified, the
+// application is exposed to all addresses.
+// See SetExposed, ExposedCIDRs and ExposedSpaces.
+func (s *Application) SetExposedTo(cidrs, spaces []string) error {
+	return s.setExposed(true, cidrs, spaces)
 }
 
 // ClearExposed removes the exposed flag from the service.
 // See SetExposed and IsExposed.
 func (s *Application) ClearExposed() error {
-	return s.setExposed(false)
+	return s.setExposed(false, nil, nil)
 }
 
-func (s *Application) setExposed(exposed bool) (err error) {
+func (s *Application) setExposed(exposed bool, cidrs, spaces []string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set exposed flag for application %q to %v", s, exposed)
+	for _, cidr := range cidrs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return errors.NotValidf("CIDR %q", cidr)
+		}
+	}
+	if len(cidrs) > 0 {
+		cidrs = set.NewStrings(cidrs...).SortedValues()
+	}
+	if len(spaces) > 0 {
+		spaces = set.NewStrings(spaces...).SortedValues()
+	}
+
+	setFields := bson.D{{"exposed", exposed}}
+	unsetFields := bson.D{}
+	if len(cidrs) > 0 {
+		setFields = append(setFields, bson.DocElem{"exposed-cidrs", cidrs})
+	} else {
+		unsetFields = append(unsetFields, bson.DocElem{"exposed-cidrs", nil})
+	}
+	if len(spaces) > 0 {
+		setFields = append(setFields, bson.DocElem{"exposed-spaces", spaces})
+	} else {
+		unsetFields = append(unsetFields, bson.DocElem{"exposed-spaces", nil})
+	}
+	update := bson.D{{"$set", setFields}}
+	if len(unsetFields) > 0 {
+		update = append(update, bson.DocElem{"$unset", unsetFields})
+	}
 	ops := []txn.Op{{
 		C:      applicationsC,
 		Id:     s.doc.DocID,
 		Assert: isAliveDoc,
-		Update: bson.D{{"$set", bson.D{{"exposed", exposed}}}},
+		Update: update,
 	}}
+	for _, name := range spaces {
+		if _, err := s.st.Space(name); err != nil {
+			return errors.Trace(err)
+		}
+		ops = append(ops, txn.Op{
+			C:      spacesC,
+			Id:     s.st.docID(name),
+			Assert: txn.DocExists,
+		})
+	}
 	if err := s.st.runTransaction(ops); err != nil {
-		return fmt.Errorf("cannot set exposed flag for application %q to %v: %v", s, exposed, onAbort(err, errNotAlive))
+		return onAbort(err, errNotAlive)
 	}
 	s.doc.Exposed = exposed
+	s.doc.ExposedCIDRs = cidrs
+	s.doc.ExposedSpaces = spaces
 	return nil
 }
 
@@ -891,15 +990,31 @@ func (s *Application) addUnitOps(principalName string, asserts bson.D) (string,
 	if err != nil {
 		return "", nil, err
 	}
+	ch, _, err := s.Charm()
+	if err != nil {
+		return "", nil, err
+	}
+	requested := requestedStorageUsage(ch.Meta(), storageCons, 1, false)
+	quotaOps, err := validateStorageQuotas(s.st, s, requested)
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	sharedStorageOps, sharedStorage, err := sharedStorageAttachmentsOps(s.st, s.ApplicationTag())
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
 	args := applicationAddUnitOpsArgs{
 		cons:          cons,
 		principalName: principalName,
 		storageCons:   storageCons,
+		sharedStorage: sharedStorage,
 	}
 	names, ops, err := s.addUnitOpsWithCons(args)
 	if err != nil {
 		return names, ops, err
 	}
+	ops = append(ops, sharedStorageOps...)
+	ops = append(ops, quotaOps...)
 	// we verify the application is alive
 	asserts = append(isAliveDoc, asserts...)
 	ops = append(ops, s.incUnitCountOp(asserts))
@@ -910,6 +1025,10 @@ type applicationAddUnitOpsArgs struct {
 	principalName string
 	cons          constraints.Value
 	storageCons   map[string]StorageConstraints
+
+	// sharedStorage holds the tags of the application's shared
+	// storage instances, to which the unit will be attached.
+	sharedStorage []names.StorageTag
 }
 
 // addServiceUnitOps is just like addUnitOps but explicitly takes a
@@ -939,6 +1058,10 @@ func (s *Application) addUnitOpsWithCons(args applicationAddUnitOpsArgs) (string
 	if err != nil {
 		return "", nil, errors.Trace(err)
 	}
+	for _, storageTag := range args.sharedStorage {
+		storageOps = append(storageOps, createStorageAttachmentOp(storageTag, names.NewUnitTag(name)))
+		numStorageAttachments++
+	}
 
 	docID := s.st.docID(name)
 	globalKey := unitGlobalKey(name)
@@ -1021,7 +1144,6 @@ func (s *Application) unitStorageOps(unitName string, cons map[string]StorageCon
 	meta := charm.Meta()
 	url := charm.URL()
 	tag := names.NewUnitTag(unitName)
-	// TODO(wallyworld) - record constraints info in data model - size and pool name
 	ops, numStorageAttachments, err = createStorageOps(
 		s.st, tag, meta, url, cons,
 		s.doc.Series,
@@ -1047,6 +1169,11 @@ func (s *Application) AddUnit() (unit *Unit, err error) {
 		} else if !alive {
 			return nil, fmt.Errorf("application is not alive")
 		}
+		if changed, err := storagePoolUsageChanged(s.st, ops); err != nil {
+			return nil, err
+		} else if changed {
+			return nil, errStorageUsageChanged
+		}
 		return nil, fmt.Errorf("inconsistent state")
 	} else if err != nil {
 		return nil, err
@@ -1088,6 +1215,7 @@ func (s *Application) removeUnitOps(u *Unit, asserts bson.D) ([]txn.Op, error) {
 			Remove: true,
 		},
 		removeMeterStatusOp(s.st, u.globalMeterStatusKey()),
+		removeHookHistoryOp(s.st, u.globalKey()),
 		removeStatusOp(s.st, u.globalAgentKey()),
 		removeStatusOp(s.st, u.globalKey()),
 		removeConstraintsOp(s.st, u.globalAgentKey()),
@@ -1107,7 +1235,11 @@ func (s *Application) removeUnitOps(u *Unit, asserts bson.D) ([]txn.Op, error) {
 	}
 	if s.doc.Life == Dying && s.doc.RelationCount == 0 && s.doc.UnitCount == 1 {
 		hasLastRef := bson.D{{"life", Dying}, {"relationcount", 0}, {"unitcount", 1}}
-		return append(ops, s.removeOps(hasLastRef)...), nil
+		removeOps, err := s.removeOps(hasLastRef)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, removeOps...), nil
 	}
 	svcOp := txn.Op{
 		C:      applicationsC,
@@ -1384,6 +1516,58 @@ func (s *Application) defaultEndpointBindings() (map[string]string, error) {
 	return DefaultEndpointBindingsForCharm(charm.Meta()), nil
 }
 
+// SetEndpointBindings updates the spaces the application's endpoints are
+// bound to, merging the given bindings with the existing ones. Every
+// machine hosting a unit of the application must have an address in each
+// space an endpoint is newly bound to. The units' settings in relations
+// using rebound endpoints are updated with the units' addresses in the
+// new spaces, so that related units are notified of the change.
+func (s *Application) SetEndpointBindings(bindings map[string]string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set endpoint bindings for application %q", s)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := s.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if s.doc.Life != Alive {
+			return nil, errNotAlive
+		}
+		ch, _, err := s.Charm()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		current, err := s.EndpointBindings()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		changed := make(map[string]string)
+		for endpoint, space := range bindings {
+			if oldSpace, ok := current[endpoint]; !ok || oldSpace != space {
+				changed[endpoint] = space
+			}
+		}
+		if len(changed) == 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		bindingsOp, err := updateEndpointBindingsOp(s.st, s.globalKey(), bindings, ch.Meta())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		settingsOps, err := s.rebindRelationSettingsOps(changed)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := []txn.Op{{
+			C:      applicationsC,
+			Id:     s.doc.DocID,
+			Assert: bson.D{{"life", Alive}, {"charmurl", s.doc.CharmURL}},
+		}, bindingsOp}
+		return append(ops, settingsOps...), nil
+	}
+	return s.st.run(buildTxn)
+}
+
 // MetricCredentials returns any metric credentials associated with this service.
 func (s *Application) MetricCredentials() []byte {
 	return s.doc.MetricCredentials
@@ -21,6 +21,7 @@ import (
 
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/testing"
 	"github.com/juju/juju/status"
@@ -1345,6 +1346,144 @@ func (s *ServiceSuite) TestServiceExposed(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, notAliveErr)
 }
 
+func (s *ServiceSuite) TestServiceExposedTo(c *gc.C) {
+	_, err := s.State.AddSpace("internal", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.mysql.SetExposedTo([]string{"192.168.1.0/24", "10.0.0.0/8"}, []string{"internal"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	c.Assert(s.mysql.ExposedCIDRs(), jc.DeepEquals, []string{"10.0.0.0/8", "192.168.1.0/24"})
+	c.Assert(s.mysql.ExposedSpaces(), jc.DeepEquals, []string{"internal"})
+
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	c.Assert(s.mysql.ExposedCIDRs(), jc.DeepEquals, []string{"10.0.0.0/8", "192.168.1.0/24"})
+	c.Assert(s.mysql.ExposedSpaces(), jc.DeepEquals, []string{"internal"})
+
+	// SetExposed removes any restrictions.
+	err = s.mysql.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	c.Assert(s.mysql.ExposedCIDRs(), gc.HasLen, 0)
+	c.Assert(s.mysql.ExposedSpaces(), gc.HasLen, 0)
+
+	// ClearExposed removes any restrictions too.
+	err = s.mysql.SetExposedTo([]string{"10.0.0.0/8"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsFalse)
+	c.Assert(s.mysql.ExposedCIDRs(), gc.HasLen, 0)
+}
+
+func (s *ServiceSuite) TestServiceExposedToInvalid(c *gc.C) {
+	err := s.mysql.SetExposedTo([]string{"10.0.0.0"}, nil)
+	c.Assert(err, gc.ErrorMatches, `cannot set exposed flag for application "mysql" to true: CIDR "10.0.0.0" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+
+	err = s.mysql.SetExposedTo(nil, []string{"missing"})
+	c.Assert(err, gc.ErrorMatches, `cannot set exposed flag for application "mysql" to true: space "missing" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsFalse)
+}
+
+func (s *ServiceSuite) TestSetEgressRules(c *gc.C) {
+	c.Assert(s.mysql.EgressRules(), gc.HasLen, 0)
+
+	rules := []network.EgressRule{
+		network.NewEgressRule(network.PortRange{443, 443, "tcp"}, "10.0.0.0/8"),
+		network.NewEgressRule(network.PortRange{53, 53, "udp"}),
+	}
+	err := s.mysql.SetEgressRules(rules)
+	c.Assert(err, jc.ErrorIsNil)
+	expected := []network.EgressRule{
+		network.NewEgressRule(network.PortRange{443, 443, "tcp"}, "10.0.0.0/8"),
+		network.NewEgressRule(network.PortRange{53, 53, "udp"}),
+	}
+	c.Assert(s.mysql.EgressRules(), jc.DeepEquals, expected)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.EgressRules(), jc.DeepEquals, expected)
+
+	err = s.mysql.SetEgressRules(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.EgressRules(), gc.HasLen, 0)
+}
+
+func (s *ServiceSuite) TestSetEgressRulesInvalid(c *gc.C) {
+	err := s.mysql.SetEgressRules([]network.EgressRule{
+		network.NewEgressRule(network.PortRange{443, 443, "tcp"}, "10.0.0.0"),
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot set egress rules for application "mysql": invalid destination CIDR "10.0.0.0"`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *ServiceSuite) TestSetEgressRulesDying(c *gc.C) {
+	_, err := s.mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.SetEgressRules([]network.EgressRule{
+		network.NewEgressRule(network.PortRange{53, 53, "udp"}),
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot set egress rules for application "mysql": not found or not alive`)
+}
+
+func (s *ServiceSuite) TestSetRelationIngressCIDR(c *gc.C) {
+	c.Assert(s.mysql.RelationIngressCIDRs(), gc.HasLen, 0)
+	err := s.mysql.SetRelationIngressCIDR("wordpress/0", "10.0.0.1/32")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.SetRelationIngressCIDR("wordpress/1", "10.0.0.2/32")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.SetRelationIngressCIDR("blog/0", "10.0.0.1/32")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.RelationIngressCIDRs(), jc.DeepEquals, []string{"10.0.0.1/32", "10.0.0.2/32"})
+
+	err = s.mysql.SetRelationIngressCIDR("wordpress/1", "")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.RelationIngressCIDRs(), jc.DeepEquals, []string{"10.0.0.1/32"})
+}
+
+func (s *ServiceSuite) TestClearRelationIngressCIDRs(c *gc.C) {
+	err := s.mysql.SetRelationIngressCIDR("wordpress/0", "10.0.0.1/32")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.SetRelationIngressCIDR("wordpress/1", "10.0.0.2/32")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.SetRelationIngressCIDR("blog/0", "10.0.0.3/32")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.mysql.ClearRelationIngressCIDRs("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.RelationIngressCIDRs(), jc.DeepEquals, []string{"10.0.0.3/32"})
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.RelationIngressCIDRs(), jc.DeepEquals, []string{"10.0.0.3/32"})
+
+	// Clearing again is not an error.
+	err = s.mysql.ClearRelationIngressCIDRs("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ServiceSuite) TestSetRelationIngressCIDRInvalid(c *gc.C) {
+	err := s.mysql.SetRelationIngressCIDR("wordpress/0", "10.0.0.1")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	err = s.mysql.SetRelationIngressCIDR("wordpress", "10.0.0.1/32")
+	c.Assert(err, gc.ErrorMatches, `cannot set relation ingress for application "mysql": unit name "wordpress" not valid`)
+}
+
 func (s *ServiceSuite) TestAddUnit(c *gc.C) {
 	// Check that principal units can be added on their own.
 	unitZero, err := s.mysql.AddUnit()
@@ -2409,6 +2548,88 @@ func (s *ServiceSuite) TestEndpointBindingsWithExplictOverrides(c *gc.C) {
 	s.assertServiceRemovedWithItsBindings(c, service)
 }
 
+func (s *ServiceSuite) TestSetEndpointBindings(c *gc.C) {
+	_, err := s.State.AddSpace("db", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSubnet(state.SubnetInfo{CIDR: "10.0.0.0/24", SpaceName: "db"})
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err := s.mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	wordpress := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	eps, err := s.State.InferEndpoints(wordpress.Name(), s.mysql.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	ru, err := rel.Unit(unit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ru.EnterScope(map[string]interface{}{"private-address": "192.168.0.1"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The unit's machine has no address in the space.
+	err = s.mysql.SetEndpointBindings(map[string]string{"server": "db"})
+	c.Assert(err, gc.ErrorMatches, `cannot set endpoint bindings for application "mysql": `+
+		`machine "0" hosting unit "mysql/0" has no address in space "db"`)
+
+	err = machine.SetInstanceInfo("i-am", "fake_nonce", nil, []state.LinkLayerDeviceArgs{{
+		Name: "eth0",
+		Type: state.EthernetDevice,
+	}}, []state.LinkLayerDeviceAddress{{
+		DeviceName:   "eth0",
+		ConfigMethod: state.StaticAddress,
+		CIDRAddress:  "10.0.0.5/24",
+	}}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.mysql.SetEndpointBindings(map[string]string{"server": "db"})
+	c.Assert(err, jc.ErrorIsNil)
+	bindings, err := s.mysql.EndpointBindings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bindings["server"], gc.Equals, "db")
+
+	// The unit's relation settings are updated, so related
+	// units are notified of its address in the new space.
+	settings, err := ru.Settings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings.Map(), jc.DeepEquals, map[string]interface{}{
+		"private-address": "10.0.0.5",
+	})
+}
+
+func (s *ServiceSuite) TestSetEndpointBindingsInvalid(c *gc.C) {
+	err := s.mysql.SetEndpointBindings(map[string]string{"server": "missing"})
+	c.Assert(err, gc.ErrorMatches, `cannot set endpoint bindings for application "mysql": unknown space "missing" not valid`)
+	err = s.mysql.SetEndpointBindings(map[string]string{"missing": ""})
+	c.Assert(err, gc.ErrorMatches, `cannot set endpoint bindings for application "mysql": unknown endpoint "missing" not valid`)
+}
+
+func (s *ServiceSuite) TestWatchEndpointBindings(c *gc.C) {
+	_, err := s.State.AddSpace("db", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	w := s.mysql.WatchEndpointBindings()
+	defer testing.AssertStop(c, w)
+
+	// Initial event.
+	wc := testing.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err = s.mysql.SetEndpointBindings(map[string]string{"server": "db"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Setting the same bindings again changes nothing.
+	err = s.mysql.SetEndpointBindings(map[string]string{"server": "db"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	testing.AssertStop(c, w)
+	wc.AssertClosed()
+}
+
 func (s *ServiceSuite) TestSetCharmExtraBindingsUseDefaults(c *gc.C) {
 	_, err := s.State.AddSpace("db", "", nil, true)
 	c.Assert(err, jc.ErrorIsNil)
@@ -0,0 +1,182 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"regexp"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// ApplicationOffer holds the details of an application offered
+// to other models on the same controller.
+type ApplicationOffer struct {
+	// OfferName is the name of the offer, unique within the model.
+	OfferName string
+
+	// ApplicationName is the name of the offered application.
+	ApplicationName string
+
+	// Endpoints maps the names of the offered endpoints to
+	// the names of the application's endpoints.
+	Endpoints map[string]string
+}
+
+// validOfferEndpointName matches valid names for offered endpoints,
+// which follow the rules for charm relation names.
+var validOfferEndpointName = regexp.MustCompile("^[a-z][a-z0-9]*(?:[-_][a-z0-9]+)*$")
+
+// applicationOfferDoc represents an application offer in MongoDB.
+type applicationOfferDoc struct {
+	DocID           string            `bson:"_id"`
+	ModelUUID       string            `bson:"model-uuid"`
+	OfferName       string            `bson:"offer-name"`
+	ApplicationName string            `bson:"application-name"`
+	Endpoints       map[string]string `bson:"endpoints"`
+}
+
+func (doc *applicationOfferDoc) offer() *ApplicationOffer {
+	endpoints := make(map[string]string)
+	for offerEndpoint, endpoint := range doc.Endpoints {
+		endpoints[offerEndpoint] = endpoint
+	}
+	return &ApplicationOffer{
+		OfferName:       doc.OfferName,
+		ApplicationName: doc.ApplicationName,
+		Endpoints:       endpoints,
+	}
+}
+
+// AddApplicationOffer makes the endpoints of an application available
+// to be related to by applications in other models on the controller.
+func (st *State) AddApplicationOffer(offer ApplicationOffer) (_ *ApplicationOffer, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot add application offer %q", offer.OfferName)
+
+	// The offer name is used to name the remote application
+	// in consuming models, so it must be a valid application
+	// name.
+	if !names.IsValidApplication(offer.OfferName) {
+		return nil, errors.NotValidf("offer name")
+	}
+	if len(offer.Endpoints) == 0 {
+		return nil, errors.New("no endpoints specified")
+	}
+	application, err := st.Application(offer.ApplicationName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if application.Life() != Alive {
+		return nil, errors.Errorf("application %q is not alive", offer.ApplicationName)
+	}
+	for offerEndpoint, endpoint := range offer.Endpoints {
+		if !validOfferEndpointName.MatchString(offerEndpoint) {
+			return nil, errors.NotValidf("offered endpoint name %q", offerEndpoint)
+		}
+		ep, err := application.Endpoint(endpoint)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if ep.Role == charm.RolePeer || ep.Scope == charm.ScopeContainer {
+			return nil, errors.Errorf("endpoint %q cannot be offered", endpoint)
+		}
+	}
+	doc := &applicationOfferDoc{
+		DocID:           st.docID(offer.OfferName),
+		ModelUUID:       st.ModelUUID(),
+		OfferName:       offer.OfferName,
+		ApplicationName: offer.ApplicationName,
+		Endpoints:       offer.Endpoints,
+	}
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     st.docID(offer.ApplicationName),
+		Assert: isAliveDoc,
+	}, {
+		C:      applicationOffersC,
+		Id:     doc.DocID,
+		Assert: txn.DocMissing,
+		Insert: doc,
+	}}
+	if err := st.runTransaction(ops); err == txn.ErrAborted {
+		if _, err := st.ApplicationOffer(offer.OfferName); err == nil {
+			return nil, errors.AlreadyExistsf("application offer %q", offer.OfferName)
+		}
+		return nil, errors.Errorf("application %q is not alive", offer.ApplicationName)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return doc.offer(), nil
+}
+
+// ApplicationOffer returns the application offer with the given name.
+func (st *State) ApplicationOffer(offerName string) (*ApplicationOffer, error) {
+	applicationOffers, closer := st.getCollection(applicationOffersC)
+	defer closer()
+
+	var doc applicationOfferDoc
+	err := applicationOffers.FindId(offerName).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("application offer %q", offerName)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get application offer %q", offerName)
+	}
+	return doc.offer(), nil
+}
+
+// AllApplicationOffers returns all the application offers in the model.
+func (st *State) AllApplicationOffers() ([]*ApplicationOffer, error) {
+	applicationOffers, closer := st.getCollection(applicationOffersC)
+	defer closer()
+
+	var docs []applicationOfferDoc
+	if err := applicationOffers.Find(nil).Sort("offer-name").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get all application offers")
+	}
+	offers := make([]*ApplicationOffer, len(docs))
+	for i, doc := range docs {
+		offers[i] = doc.offer()
+	}
+	return offers, nil
+}
+
+// RemoveApplicationOffer removes the application offer with the
+// given name. Existing relations made through the offer are not
+// affected. It is not an error to remove an offer that does not
+// exist.
+func (st *State) RemoveApplicationOffer(offerName string) error {
+	ops := []txn.Op{{
+		C:      applicationOffersC,
+		Id:     st.docID(offerName),
+		Remove: true,
+	}}
+	return errors.Annotatef(st.runTransaction(ops), "cannot remove application offer %q", offerName)
+}
+
+// removeApplicationOffersOps returns the operations required to
+// remove the offers of the named application, which is being removed.
+func removeApplicationOffersOps(st *State, applicationName string) ([]txn.Op, error) {
+	applicationOffers, closer := st.getCollection(applicationOffersC)
+	defer closer()
+
+	var docs []applicationOfferDoc
+	sel := bson.D{{"application-name", applicationName}}
+	if err := applicationOffers.Find(sel).All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot get offers of application %q", applicationName)
+	}
+	ops := make([]txn.Op, len(docs))
+	for i, doc := range docs {
+		ops[i] = txn.Op{
+			C:      applicationOffersC,
+			Id:     doc.DocID,
+			Remove: true,
+		}
+	}
+	return ops, nil
+}
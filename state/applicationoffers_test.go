@@ -0,0 +1,129 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type ApplicationOfferSuite struct {
+	ConnSuite
+	mysql *state.Application
+}
+
+var _ = gc.Suite(&ApplicationOfferSuite{})
+
+func (s *ApplicationOfferSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.mysql = s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+}
+
+func (s *ApplicationOfferSuite) TestAddApplicationOffer(c *gc.C) {
+	offer, err := s.State.AddApplicationOffer(state.ApplicationOffer{
+		OfferName:       "hosted-mysql",
+		ApplicationName: "mysql",
+		Endpoints:       map[string]string{"database": "server"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	expect := &state.ApplicationOffer{
+		OfferName:       "hosted-mysql",
+		ApplicationName: "mysql",
+		Endpoints:       map[string]string{"database": "server"},
+	}
+	c.Assert(offer, jc.DeepEquals, expect)
+
+	offer, err = s.State.ApplicationOffer("hosted-mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(offer, jc.DeepEquals, expect)
+
+	offers, err := s.State.AllApplicationOffers()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(offers, jc.DeepEquals, []*state.ApplicationOffer{expect})
+}
+
+func (s *ApplicationOfferSuite) TestAddApplicationOfferDuplicate(c *gc.C) {
+	offer := state.ApplicationOffer{
+		OfferName:       "hosted-mysql",
+		ApplicationName: "mysql",
+		Endpoints:       map[string]string{"database": "server"},
+	}
+	_, err := s.State.AddApplicationOffer(offer)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddApplicationOffer(offer)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *ApplicationOfferSuite) TestAddApplicationOfferInvalid(c *gc.C) {
+	for i, test := range []struct {
+		offer state.ApplicationOffer
+		err   string
+	}{{
+		offer: state.ApplicationOffer{
+			OfferName:       "Hosted",
+			ApplicationName: "mysql",
+			Endpoints:       map[string]string{"database": "server"},
+		},
+		err: `cannot add application offer "Hosted": offer name not valid`,
+	}, {
+		offer: state.ApplicationOffer{
+			OfferName:       "hosted-mysql",
+			ApplicationName: "mysql",
+		},
+		err: `cannot add application offer "hosted-mysql": no endpoints specified`,
+	}, {
+		offer: state.ApplicationOffer{
+			OfferName:       "hosted-mysql",
+			ApplicationName: "postgresql",
+			Endpoints:       map[string]string{"database": "server"},
+		},
+		err: `cannot add application offer "hosted-mysql": application "postgresql" not found`,
+	}, {
+		offer: state.ApplicationOffer{
+			OfferName:       "hosted-mysql",
+			ApplicationName: "mysql",
+			Endpoints:       map[string]string{"database": "admin"},
+		},
+		err: `cannot add application offer "hosted-mysql": application "mysql" has no "admin" relation`,
+	}} {
+		c.Logf("test %d: %s", i, test.err)
+		_, err := s.State.AddApplicationOffer(test.offer)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ApplicationOfferSuite) TestRemoveApplicationOffer(c *gc.C) {
+	_, err := s.State.AddApplicationOffer(state.ApplicationOffer{
+		OfferName:       "hosted-mysql",
+		ApplicationName: "mysql",
+		Endpoints:       map[string]string{"database": "server"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RemoveApplicationOffer("hosted-mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ApplicationOffer("hosted-mysql")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// Removing a missing offer is not an error.
+	err = s.State.RemoveApplicationOffer("hosted-mysql")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ApplicationOfferSuite) TestOffersRemovedWithApplication(c *gc.C) {
+	_, err := s.State.AddApplicationOffer(state.ApplicationOffer{
+		OfferName:       "hosted-mysql",
+		ApplicationName: "mysql",
+		Endpoints:       map[string]string{"database": "server"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ApplicationOffer("hosted-mysql")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
@@ -31,6 +31,7 @@ const (
 	cleanupAttachmentsForDyingFilesystem cleanupKind = "filesystemAttachments"
 	cleanupModelsForDyingController      cleanupKind = "models"
 	cleanupMachinesForDyingModel         cleanupKind = "modelMachines"
+	cleanupVirtualIPAddress              cleanupKind = "virtualIPAddress"
 )
 
 // cleanupDoc represents a potentially large set of documents that should be
@@ -108,6 +109,8 @@ func (st *State) Cleanup() (err error) {
 			err = st.cleanupModelsForDyingController()
 		case cleanupMachinesForDyingModel:
 			err = st.cleanupMachinesForDyingModel()
+		case cleanupVirtualIPAddress:
+			err = st.cleanupVirtualIPAddress(doc.Prefix)
 		default:
 			handler, ok := cleanupHandlers[doc.Kind]
 			if !ok {
@@ -260,6 +263,29 @@ func (st *State) cleanupUnitsForDyingService(applicationname string) (err error)
 			return err
 		}
 	}
+	return st.destroySharedStorageForDyingService(applicationname)
+}
+
+// destroySharedStorageForDyingService destroys the shared storage
+// instances owned by the application. Each storage instance will be
+// removed once its last attachment is removed.
+func (st *State) destroySharedStorageForDyingService(applicationname string) (err error) {
+	storageInstances, closer := st.getCollection(storageInstancesC)
+	defer closer()
+
+	var doc storageInstanceDoc
+	owner := names.NewApplicationTag(applicationname).String()
+	sel := bson.D{{"owner", owner}, {"life", Alive}}
+	iter := storageInstances.Find(sel).Select(bson.D{{"id", true}}).Iter()
+	defer closeIter(iter, &err, "reading storage instance document")
+	for iter.Next(&doc) {
+		err := st.DestroyStorageInstance(names.NewStorageTag(doc.Id))
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -342,7 +368,7 @@ func (st *State) cleanupRemovedUnit(unitId string) error {
 			return err
 		}
 	}
-	return nil
+	return st.removeActionAttachments(bson.D{{"receiver", unitId}})
 }
 
 // cleanupDyingMachine marks resources owned by the machine as dying, to ensure
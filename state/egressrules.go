@@ -0,0 +1,101 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/network"
+)
+
+// egressRuleDoc represents an egress rule in MongoDB.
+type egressRuleDoc struct {
+	Protocol         string   `bson:"protocol"`
+	FromPort         int      `bson:"from-port"`
+	ToPort           int      `bson:"to-port"`
+	DestinationCIDRs []string `bson:"destination-cidrs"`
+}
+
+func newEgressRuleDocs(rules []network.EgressRule) []egressRuleDoc {
+	if len(rules) == 0 {
+		return nil
+	}
+	docs := make([]egressRuleDoc, len(rules))
+	for i, rule := range rules {
+		docs[i] = egressRuleDoc{
+			Protocol:         rule.Protocol,
+			FromPort:         rule.FromPort,
+			ToPort:           rule.ToPort,
+			DestinationCIDRs: rule.DestinationCIDRs,
+		}
+	}
+	return docs
+}
+
+func (doc egressRuleDoc) egressRule() network.EgressRule {
+	return network.NewEgressRule(network.PortRange{
+		Protocol: doc.Protocol,
+		FromPort: doc.FromPort,
+		ToPort:   doc.ToPort,
+	}, doc.DestinationCIDRs...)
+}
+
+// egressRuleStrings returns the string form of each of the given rules.
+func egressRuleStrings(rules []network.EgressRule) []string {
+	if len(rules) == 0 {
+		return nil
+	}
+	values := make([]string, len(rules))
+	for i, rule := range rules {
+		values[i] = rule.String()
+	}
+	return values
+}
+
+// EgressRules returns the rules defining the egress traffic permitted
+// from machines hosting the application's units, in addition to that
+// permitted by the model's "egress-rules" config attribute.
+func (s *Application) EgressRules() []network.EgressRule {
+	if len(s.doc.EgressRules) == 0 {
+		return nil
+	}
+	rules := make([]network.EgressRule, len(s.doc.EgressRules))
+	for i, doc := range s.doc.EgressRules {
+		rules[i] = doc.egressRule()
+	}
+	return rules
+}
+
+// SetEgressRules replaces the application's egress rules. An empty
+// set of rules removes any application-level egress restrictions.
+func (s *Application) SetEgressRules(rules []network.EgressRule) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set egress rules for application %q", s)
+	rules = append([]network.EgressRule(nil), rules...)
+	for _, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return errors.NewNotValid(err, "")
+		}
+	}
+	network.SortEgressRules(rules)
+	docs := newEgressRuleDocs(rules)
+	var update bson.D
+	if len(docs) > 0 {
+		update = bson.D{{"$set", bson.D{{"egress-rules", docs}}}}
+	} else {
+		update = bson.D{{"$unset", bson.D{{"egress-rules", nil}}}}
+	}
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     s.doc.DocID,
+		Assert: isAliveDoc,
+		Update: update,
+	}}
+	if err := s.st.runTransaction(ops); err != nil {
+		return onAbort(err, errNotAlive)
+	}
+	s.doc.EgressRules = docs
+	return nil
+}
@@ -11,6 +11,8 @@ import (
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/network"
 )
 
 // endpointBindingsDoc represents how a service endpoints are bound to spaces.
@@ -285,3 +287,99 @@ func DefaultEndpointBindingsForCharm(charmMeta *charm.Meta) map[string]string {
 	}
 	return bindings
 }
+
+// rebindRelationSettingsOps returns the ops needed to update the settings
+// of the application's units in relations using the given endpoints with
+// the addresses of the units' machines in the spaces the endpoints are
+// being bound to. An error is returned if any of the machines has no
+// address in one of those spaces.
+func (s *Application) rebindRelationSettingsOps(bindings map[string]string) ([]txn.Op, error) {
+	units, err := s.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	relations, err := s.Relations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var ops []txn.Op
+	for _, unit := range units {
+		machineId, err := unit.AssignedMachineId()
+		if errors.IsNotAssigned(err) {
+			// The unit's machine will be provisioned
+			// with the bindings in effect.
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		machine, err := s.st.Machine(machineId)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		addresses := make(map[string]network.Address)
+		for endpoint, space := range bindings {
+			address, err := machine.spaceAddress(space)
+			if errors.IsNotFound(err) && space == "" {
+				// Any address will do for the default
+				// space, once the machine has one.
+				continue
+			} else if errors.IsNotFound(err) {
+				return nil, errors.Errorf(
+					"machine %q hosting unit %q has no address in space %q",
+					machineId, unit.Name(), space,
+				)
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			addresses[endpoint] = address
+		}
+		for _, relation := range relations {
+			ep, err := relation.Endpoint(s.doc.Name)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			address, ok := addresses[ep.Name]
+			if !ok {
+				continue
+			}
+			ru, err := relation.Unit(unit)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if inScope, err := ru.InScope(); err != nil {
+				return nil, errors.Trace(err)
+			} else if !inScope {
+				continue
+			}
+			settings, err := ru.Settings()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			settings.Set("private-address", address.Value)
+			_, settingsOps := settings.settingsUpdateOps()
+			ops = append(ops, settingsOps...)
+		}
+	}
+	return ops, nil
+}
+
+// spaceAddress returns an address of the machine in the named space or,
+// if space is empty, the machine's preferred private address. An error
+// satisfying errors.IsNotFound is returned if there is no such address.
+func (m *Machine) spaceAddress(space string) (network.Address, error) {
+	if space == "" {
+		address, err := m.PrivateAddress()
+		if network.IsNoAddressError(err) {
+			return network.Address{}, errors.NotFoundf("private address of machine %q", m.Id())
+		}
+		return address, errors.Trace(err)
+	}
+	addresses, err := m.SpaceAddresses(space)
+	if err != nil {
+		return network.Address{}, errors.Trace(err)
+	}
+	if len(addresses) == 0 {
+		return network.Address{}, errors.NotFoundf("address of machine %q in space %q", m.Id(), space)
+	}
+	return network.NewScopedAddress(addresses[0].Value(), network.ScopeCloudLocal), nil
+}
@@ -37,6 +37,8 @@ const (
 	StorageInstancesC = storageInstancesC
 	GUISettingsC      = guisettingsC
 	GlobalSettingsC   = globalSettingsC
+	UnitHookHistoryC  = unitHookHistoryC
+	MaxHookHistory    = maxHookHistory
 )
 
 var (
@@ -491,3 +493,17 @@ func LeadershipLeases(st *State) (map[string]lease.Info, error) {
 func DeleteCharm(st *State, curl *charm.URL) error {
 	return st.deleteCharm(curl)
 }
+
+// RemoveStorageInstancePoolAndSize removes the pool and size from the
+// storage instance doc, as for storage instances added before they
+// were recorded.
+func RemoveStorageInstancePoolAndSize(c *gc.C, st *State, tag names.StorageTag) {
+	ops := []txn.Op{{
+		C:      storageInstancesC,
+		Id:     tag.Id(),
+		Assert: txn.DocExists,
+		Update: bson.D{{"$unset", bson.D{{"pool", nil}, {"size", nil}}}},
+	}}
+	err := st.runTransaction(ops)
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -60,6 +60,15 @@ type Filesystem interface {
 	// if it needs to be provisioned. Params returns true if the returned
 	// parameters are usable for provisioning, otherwise false.
 	Params() (FilesystemParams, bool)
+
+	// RequestedSize returns the size, in MiB, that the filesystem has
+	// been requested to grow to. RequestedSize returns false if there
+	// is no outstanding request to resize the filesystem.
+	RequestedSize() (uint64, bool)
+
+	// Releasing reports whether the filesystem is to be released from
+	// the model, rather than destroyed, once it is Dead.
+	Releasing() bool
 }
 
 // FilesystemAttachment describes an attachment of a filesystem to a machine.
@@ -85,6 +94,11 @@ type FilesystemAttachment interface {
 	// if it has not already been made. Params returns true if the returned
 	// parameters are usable for creating an attachment, otherwise false.
 	Params() (FilesystemAttachmentParams, bool)
+
+	// Usage returns the space and inode usage of the filesystem, as most
+	// recently reported by the machine. Usage returns true if usage has
+	// been reported, otherwise false.
+	Usage() (FilesystemUsage, bool)
 }
 
 type filesystem struct {
@@ -111,6 +125,8 @@ type filesystemDoc struct {
 	Binding         string            `bson:"binding,omitempty"`
 	Info            *FilesystemInfo   `bson:"info,omitempty"`
 	Params          *FilesystemParams `bson:"params,omitempty"`
+	RequestedSize   uint64            `bson:"requestedsize,omitempty"`
+	Releasing       bool              `bson:"releasing,omitempty"`
 }
 
 // filesystemAttachmentDoc records information about a filesystem attachment.
@@ -123,6 +139,7 @@ type filesystemAttachmentDoc struct {
 	Life       Life                        `bson:"life"`
 	Info       *FilesystemAttachmentInfo   `bson:"info,omitempty"`
 	Params     *FilesystemAttachmentParams `bson:"params,omitempty"`
+	Usage      *FilesystemUsage            `bson:"usage,omitempty"`
 }
 
 // FilesystemParams records parameters for provisioning a new filesystem.
@@ -269,6 +286,16 @@ func (f *filesystem) Params() (FilesystemParams, bool) {
 	return *f.doc.Params, true
 }
 
+// RequestedSize is required to implement Filesystem.
+func (f *filesystem) RequestedSize() (uint64, bool) {
+	return f.doc.RequestedSize, f.doc.RequestedSize != 0
+}
+
+// Releasing is required to implement Filesystem.
+func (f *filesystem) Releasing() bool {
+	return f.doc.Releasing
+}
+
 // Status is required to implement StatusGetter.
 func (f *filesystem) Status() (status.StatusInfo, error) {
 	return f.st.FilesystemStatus(f.FilesystemTag())
@@ -598,19 +625,22 @@ func (st *State) DestroyFilesystem(tag names.FilesystemTag) (err error) {
 		if filesystem.doc.Life != Alive {
 			return nil, jujutxn.ErrNoOperations
 		}
-		return destroyFilesystemOps(st, filesystem), nil
+		return destroyFilesystemOps(st, filesystem, false), nil
 	}
 	return st.run(buildTxn)
 }
 
-func destroyFilesystemOps(st *State, f *filesystem) []txn.Op {
+// destroyFilesystemOps returns txn.Ops to destroy the filesystem. If
+// release is true, the filesystem will be released from the model when
+// it is Dead, rather than being destroyed.
+func destroyFilesystemOps(st *State, f *filesystem, release bool) []txn.Op {
 	if f.doc.AttachmentCount == 0 {
 		hasNoAttachments := bson.D{{"attachmentcount", 0}}
 		return []txn.Op{{
 			C:      filesystemsC,
 			Id:     f.doc.FilesystemId,
 			Assert: append(hasNoAttachments, isAliveDoc...),
-			Update: bson.D{{"$set", bson.D{{"life", Dead}}}},
+			Update: bson.D{{"$set", lifeWithReleasing(Dead, release)}},
 		}}
 	}
 	hasAttachments := bson.D{{"attachmentcount", bson.D{{"$gt", 0}}}}
@@ -619,7 +649,7 @@ func destroyFilesystemOps(st *State, f *filesystem) []txn.Op {
 		C:      filesystemsC,
 		Id:     f.doc.FilesystemId,
 		Assert: append(hasAttachments, isAliveDoc...),
-		Update: bson.D{{"$set", bson.D{{"life", Dying}}}},
+		Update: bson.D{{"$set", lifeWithReleasing(Dying, release)}},
 	}, cleanupOp}
 }
 
@@ -654,6 +684,9 @@ func removeFilesystemOps(st *State, filesystem Filesystem) ([]txn.Op, error) {
 		},
 		removeStatusOp(st, filesystem.globalKey()),
 	}
+	if info, err := filesystem.Info(); err == nil {
+		ops = append(ops, removeStorageProviderIdOp(st, "filesystem", info.FilesystemId))
+	}
 	// If the filesystem is backed by a volume, the volume should
 	// be destroyed once the filesystem is removed if it is bound
 	// to the filesystem.
@@ -664,7 +697,7 @@ func removeFilesystemOps(st *State, filesystem Filesystem) ([]txn.Op, error) {
 			return nil, errors.Trace(err)
 		}
 		if volume.LifeBinding() == filesystem.Tag() {
-			ops = append(ops, destroyVolumeOps(st, volume)...)
+			ops = append(ops, destroyVolumeOps(st, volume, filesystem.Releasing())...)
 		}
 	} else if err != ErrNoBackingVolume {
 		return nil, errors.Trace(err)
@@ -778,6 +811,46 @@ func (st *State) addFilesystemOps(params FilesystemParams, machineId string) ([]
 	return ops, filesystemTag, volumeTag, nil
 }
 
+// attachExistingFilesystemOps returns txn.Ops for attaching an existing
+// filesystem to the specified machine, along with the tag of the storage
+// instance that the filesystem is assigned to. The caller is responsible
+// for creating the filesystem attachment. A machine-scoped filesystem
+// may only be attached to the machine it is scoped to, or to one of that
+// machine's containers.
+func (st *State) attachExistingFilesystemOps(tag names.FilesystemTag, machineId string) ([]txn.Op, names.StorageTag, error) {
+	f, err := st.filesystemByTag(tag)
+	if err != nil {
+		return nil, names.StorageTag{}, errors.Trace(err)
+	}
+	if f.doc.Life != Alive {
+		return nil, names.StorageTag{}, errors.Errorf("filesystem %q is not alive", tag.Id())
+	}
+	var storageTag names.StorageTag
+	if f.doc.StorageId != "" {
+		storageTag = names.NewStorageTag(f.doc.StorageId)
+	}
+	if scope, ok := names.FilesystemMachine(tag); ok && scope.Id() != TopParentId(machineId) {
+		if f.doc.StorageId != "" {
+			return nil, names.StorageTag{}, errors.Errorf(
+				"storage %q is shared from machine %q, and cannot be attached to machine %q: "+
+					"units sharing the storage must be placed on machine %q, or its containers",
+				storageTag.Id(), scope.Id(), machineId, scope.Id(),
+			)
+		}
+		return nil, names.StorageTag{}, errors.Errorf(
+			"filesystem %q is scoped to machine %q, cannot attach to machine %q",
+			tag.Id(), scope.Id(), machineId,
+		)
+	}
+	ops := []txn.Op{{
+		C:      filesystemsC,
+		Id:     tag.Id(),
+		Assert: isAliveDoc,
+		Update: bson.D{{"$inc", bson.D{{"attachmentcount", 1}}}},
+	}}
+	return ops, storageTag, nil
+}
+
 func (st *State) filesystemParamsWithDefaults(params FilesystemParams) (FilesystemParams, error) {
 	if params.Pool != "" {
 		return params, nil
@@ -891,6 +964,16 @@ func (st *State) SetFilesystemInfo(tag names.FilesystemTag, info FilesystemInfo)
 			}
 		}
 		ops := setFilesystemInfoOps(tag, info, unsetParams)
+		if size, ok := fs.RequestedSize(); ok && info.Size >= size {
+			// The filesystem has grown to the requested size,
+			// so the resize request has been satisfied.
+			ops = append(ops, txn.Op{
+				C:      filesystemsC,
+				Id:     tag.Id(),
+				Assert: bson.D{{"requestedsize", size}},
+				Update: bson.D{{"$unset", bson.D{{"requestedsize", nil}}}},
+			})
+		}
 		return ops, nil
 	}
 	return st.run(buildTxn)
@@ -1143,7 +1226,7 @@ func (st *State) FilesystemStatus(tag names.FilesystemTag) (status.StatusInfo, e
 func (st *State) SetFilesystemStatus(tag names.FilesystemTag, fsStatus status.Status, info string, data map[string]interface{}, updated *time.Time) error {
 	switch fsStatus {
 	case status.StatusAttaching, status.StatusAttached, status.StatusDetaching, status.StatusDetached, status.StatusDestroying:
-	case status.StatusError:
+	case status.StatusError, status.StatusWarning:
 		if info == "" {
 			return errors.Errorf("cannot set status %q without info", fsStatus)
 		}
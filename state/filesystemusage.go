@@ -0,0 +1,156 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/status"
+)
+
+// FilesystemUsage describes the space and inode usage of a filesystem,
+// as reported by a machine that it is attached to.
+type FilesystemUsage struct {
+	UsedBytes  uint64 `bson:"used-bytes"`
+	FreeBytes  uint64 `bson:"free-bytes"`
+	UsedInodes uint64 `bson:"used-inodes"`
+	FreeInodes uint64 `bson:"free-inodes"`
+}
+
+// PercentUsed returns the percentage of the filesystem's space or
+// inodes that is used, whichever is greater.
+func (u FilesystemUsage) PercentUsed() int {
+	percent := func(used, free uint64) int {
+		if used+free == 0 {
+			return 0
+		}
+		return int(used * 100 / (used + free))
+	}
+	bytes := percent(u.UsedBytes, u.FreeBytes)
+	inodes := percent(u.UsedInodes, u.FreeInodes)
+	if inodes > bytes {
+		return inodes
+	}
+	return bytes
+}
+
+// Usage is required to implement FilesystemAttachment.
+func (f *filesystemAttachment) Usage() (FilesystemUsage, bool) {
+	if f.doc.Usage == nil {
+		return FilesystemUsage{}, false
+	}
+	return *f.doc.Usage, true
+}
+
+// SetFilesystemAttachmentUsage records the space and inode usage of the
+// filesystem attached to the specified machine, as reported by the machine.
+//
+// The usage is only written if it differs significantly from the
+// recorded usage; see filesystemUsageChanged.
+//
+// If the usage crosses the model's storage usage warning threshold, the
+// status of an attached filesystem is set to "warning"; when the usage
+// falls back below the threshold, the status is set back to "attached".
+func (st *State) SetFilesystemAttachmentUsage(
+	machineTag names.MachineTag,
+	filesystemTag names.FilesystemTag,
+	usage FilesystemUsage,
+) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set usage for filesystem attachment %s:%s", filesystemTag.Id(), machineTag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		fsa, err := st.FilesystemAttachment(machineTag, filesystemTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if fsa.Life() != Alive {
+			// The filesystem is being detached,
+			// so its usage is no longer of interest.
+			return nil, jujutxn.ErrNoOperations
+		}
+		if _, err := fsa.Info(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if current, ok := fsa.Usage(); ok && !filesystemUsageChanged(current, usage) {
+			// Usage is reported periodically; avoid writing
+			// to the database for insignificant changes.
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:  filesystemAttachmentsC,
+			Id: filesystemAttachmentId(machineTag.Id(), filesystemTag.Id()),
+			Assert: append(isAliveDoc, bson.DocElem{
+				"info", bson.D{{"$exists", true}},
+			}),
+			Update: bson.D{{"$set", bson.D{{"usage", &usage}}}},
+		}}, nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(st.updateFilesystemUsageStatus(filesystemTag, usage))
+}
+
+// filesystemUsageChanged reports whether or not the reported usage of
+// a filesystem differs significantly from the recorded usage: that is,
+// the capacity has changed, or the space or inodes used have changed
+// by at least one percent of the capacity.
+func filesystemUsageChanged(current, reported FilesystemUsage) bool {
+	changed := func(currentUsed, currentFree, reportedUsed, reportedFree uint64) bool {
+		total := reportedUsed + reportedFree
+		if currentUsed+currentFree != total {
+			return true
+		}
+		delta := reportedUsed - currentUsed
+		if currentUsed > reportedUsed {
+			delta = currentUsed - reportedUsed
+		}
+		return delta > 0 && delta*100 >= total
+	}
+	return changed(current.UsedBytes, current.FreeBytes, reported.UsedBytes, reported.FreeBytes) ||
+		changed(current.UsedInodes, current.FreeInodes, reported.UsedInodes, reported.FreeInodes)
+}
+
+// updateFilesystemUsageStatus sets the status of the specified filesystem
+// to "warning" if its usage has crossed the model's storage usage warning
+// threshold, or back to "attached" if it has fallen below the threshold.
+func (st *State) updateFilesystemUsageStatus(tag names.FilesystemTag, usage FilesystemUsage) error {
+	cfg, err := st.ModelConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	threshold := cfg.StorageUsageWarningThreshold()
+	fsStatus, err := st.FilesystemStatus(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	now := time.Now()
+	percent := usage.PercentUsed()
+	if percent < threshold {
+		if fsStatus.Status != status.StatusWarning {
+			return nil
+		}
+		return st.SetFilesystemStatus(tag, status.StatusAttached, "", nil, &now)
+	}
+	message := fmt.Sprintf("%d%% used (warning threshold is %d%%)", percent, threshold)
+	switch fsStatus.Status {
+	case status.StatusAttached:
+	case status.StatusWarning:
+		if fsStatus.Message == message {
+			return nil
+		}
+	default:
+		// Only attached filesystems are
+		// subject to usage warnings.
+		return nil
+	}
+	return st.SetFilesystemStatus(tag, status.StatusWarning, message, nil, &now)
+}
@@ -0,0 +1,224 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+)
+
+type FilesystemUsageSuite struct {
+	StorageStateSuiteBase
+	machine    *state.Machine
+	filesystem names.FilesystemTag
+}
+
+var _ = gc.Suite(&FilesystemUsageSuite{})
+
+func (s *FilesystemUsageSuite) SetUpTest(c *gc.C) {
+	s.StorageStateSuiteBase.SetUpTest(c)
+	machine, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+		Filesystems: []state.MachineFilesystemParams{{
+			Filesystem: state.FilesystemParams{Pool: "rootfs", Size: 1024},
+			Attachment: state.FilesystemAttachmentParams{
+				Location: "/srv",
+			},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	attachments, err := s.State.MachineFilesystemAttachments(machine.MachineTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachments, gc.HasLen, 1)
+	s.machine = machine
+	s.filesystem = attachments[0].Filesystem()
+}
+
+func (s *FilesystemUsageSuite) provisionAttachment(c *gc.C) {
+	err := s.machine.SetProvisioned("inst-id", "fake_nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetFilesystemInfo(s.filesystem, state.FilesystemInfo{FilesystemId: "fs-123"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetFilesystemAttachmentInfo(
+		s.machine.MachineTag(), s.filesystem,
+		state.FilesystemAttachmentInfo{MountPoint: "/srv"},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	s.setFilesystemStatus(c, status.StatusAttached, "")
+}
+
+func (s *FilesystemUsageSuite) setFilesystemStatus(c *gc.C, fsStatus status.Status, message string) {
+	now := time.Now()
+	err := s.State.SetFilesystemStatus(s.filesystem, fsStatus, message, nil, &now)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *FilesystemUsageSuite) assertFilesystemStatus(c *gc.C, fsStatus status.Status, message string) {
+	info, err := s.State.FilesystemStatus(s.filesystem)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Status, gc.Equals, fsStatus)
+	c.Assert(info.Message, gc.Equals, message)
+}
+
+func (s *FilesystemUsageSuite) TestPercentUsed(c *gc.C) {
+	for _, test := range []struct {
+		usage   state.FilesystemUsage
+		percent int
+	}{
+		{state.FilesystemUsage{}, 0},
+		{state.FilesystemUsage{UsedBytes: 1, FreeBytes: 3}, 25},
+		{state.FilesystemUsage{UsedBytes: 1, FreeBytes: 3, UsedInodes: 1, FreeInodes: 1}, 50},
+		{state.FilesystemUsage{UsedBytes: 9, FreeBytes: 1, UsedInodes: 1, FreeInodes: 1}, 90},
+	} {
+		c.Check(test.usage.PercentUsed(), gc.Equals, test.percent)
+	}
+}
+
+func (s *FilesystemUsageSuite) TestSetFilesystemAttachmentUsage(c *gc.C) {
+	s.provisionAttachment(c)
+	usage := state.FilesystemUsage{
+		UsedBytes:  1024,
+		FreeBytes:  3072,
+		UsedInodes: 10,
+		FreeInodes: 90,
+	}
+	err := s.State.SetFilesystemAttachmentUsage(s.machine.MachineTag(), s.filesystem, usage)
+	c.Assert(err, jc.ErrorIsNil)
+
+	attachment := s.filesystemAttachment(c, s.machine.MachineTag(), s.filesystem)
+	recorded, ok := attachment.Usage()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(recorded, jc.DeepEquals, usage)
+	s.assertFilesystemStatus(c, status.StatusAttached, "")
+}
+
+func (s *FilesystemUsageSuite) TestSetFilesystemAttachmentUsageInsignificantChange(c *gc.C) {
+	s.provisionAttachment(c)
+	usage := state.FilesystemUsage{
+		UsedBytes:  1000,
+		FreeBytes:  9000,
+		UsedInodes: 100,
+		FreeInodes: 900,
+	}
+	err := s.State.SetFilesystemAttachmentUsage(s.machine.MachineTag(), s.filesystem, usage)
+	c.Assert(err, jc.ErrorIsNil)
+
+	assertUsage := func(expect state.FilesystemUsage) {
+		attachment := s.filesystemAttachment(c, s.machine.MachineTag(), s.filesystem)
+		recorded, ok := attachment.Usage()
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(recorded, jc.DeepEquals, expect)
+	}
+
+	// Changes of less than one percent of the capacity
+	// are not written.
+	err = s.State.SetFilesystemAttachmentUsage(s.machine.MachineTag(), s.filesystem, state.FilesystemUsage{
+		UsedBytes:  1099,
+		FreeBytes:  8901,
+		UsedInodes: 109,
+		FreeInodes: 891,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	assertUsage(usage)
+
+	// Changes of at least one percent are.
+	changed := state.FilesystemUsage{
+		UsedBytes:  900,
+		FreeBytes:  9100,
+		UsedInodes: 100,
+		FreeInodes: 900,
+	}
+	err = s.State.SetFilesystemAttachmentUsage(s.machine.MachineTag(), s.filesystem, changed)
+	c.Assert(err, jc.ErrorIsNil)
+	assertUsage(changed)
+
+	// As are changes to the capacity.
+	resized := state.FilesystemUsage{
+		UsedBytes:  900,
+		FreeBytes:  19100,
+		UsedInodes: 100,
+		FreeInodes: 900,
+	}
+	err = s.State.SetFilesystemAttachmentUsage(s.machine.MachineTag(), s.filesystem, resized)
+	c.Assert(err, jc.ErrorIsNil)
+	assertUsage(resized)
+}
+
+func (s *FilesystemUsageSuite) TestSetFilesystemAttachmentUsageNotProvisioned(c *gc.C) {
+	err := s.State.SetFilesystemAttachmentUsage(s.machine.MachineTag(), s.filesystem, state.FilesystemUsage{})
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+	c.Assert(err, gc.ErrorMatches, `cannot set usage for filesystem attachment 0/0:0: filesystem attachment "0/0" on "0" not provisioned`)
+
+	attachment := s.filesystemAttachment(c, s.machine.MachineTag(), s.filesystem)
+	_, ok := attachment.Usage()
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *FilesystemUsageSuite) TestSetFilesystemAttachmentUsageWarning(c *gc.C) {
+	s.provisionAttachment(c)
+	err := s.State.SetFilesystemAttachmentUsage(s.machine.MachineTag(), s.filesystem, state.FilesystemUsage{
+		UsedBytes: 95, FreeBytes: 5,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertFilesystemStatus(c, status.StatusWarning, "95% used (warning threshold is 90%)")
+
+	// Inode usage is also considered.
+	err = s.State.SetFilesystemAttachmentUsage(s.machine.MachineTag(), s.filesystem, state.FilesystemUsage{
+		UsedBytes: 5, FreeBytes: 95, UsedInodes: 99, FreeInodes: 1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertFilesystemStatus(c, status.StatusWarning, "99% used (warning threshold is 90%)")
+
+	// The status reverts to attached when usage falls below the threshold.
+	err = s.State.SetFilesystemAttachmentUsage(s.machine.MachineTag(), s.filesystem, state.FilesystemUsage{
+		UsedBytes: 5, FreeBytes: 95,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertFilesystemStatus(c, status.StatusAttached, "")
+}
+
+func (s *FilesystemUsageSuite) TestSetFilesystemAttachmentUsageWarningThreshold(c *gc.C) {
+	err := s.State.UpdateModelConfig(map[string]interface{}{
+		"storage-usage-warning-threshold": 50,
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.provisionAttachment(c)
+
+	err = s.State.SetFilesystemAttachmentUsage(s.machine.MachineTag(), s.filesystem, state.FilesystemUsage{
+		UsedBytes: 60, FreeBytes: 40,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertFilesystemStatus(c, status.StatusWarning, "60% used (warning threshold is 50%)")
+}
+
+func (s *FilesystemUsageSuite) TestSetFilesystemAttachmentUsageNotAttached(c *gc.C) {
+	s.provisionAttachment(c)
+	s.setFilesystemStatus(c, status.StatusDetaching, "")
+	err := s.State.SetFilesystemAttachmentUsage(s.machine.MachineTag(), s.filesystem, state.FilesystemUsage{
+		UsedBytes: 95, FreeBytes: 5,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertFilesystemStatus(c, status.StatusDetaching, "")
+}
+
+func (s *FilesystemUsageSuite) TestSetFilesystemAttachmentUsageDying(c *gc.C) {
+	s.provisionAttachment(c)
+	err := s.State.DetachFilesystem(s.machine.MachineTag(), s.filesystem)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetFilesystemAttachmentUsage(s.machine.MachineTag(), s.filesystem, state.FilesystemUsage{
+		UsedBytes: 95, FreeBytes: 5,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	attachment := s.filesystemAttachment(c, s.machine.MachineTag(), s.filesystem)
+	_, ok := attachment.Usage()
+	c.Assert(ok, jc.IsFalse)
+}
@@ -0,0 +1,62 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// FirewallDrift describes how the ingress rules applied by the provider
+// to a machine's instance differ from the rules Juju expects to be
+// applied, e.g. because of manual changes or partial failures.
+type FirewallDrift struct {
+	// Missing holds the rules which are expected but not applied.
+	Missing []string `bson:"missing,omitempty"`
+
+	// Unexpected holds the rules which are applied but not expected.
+	Unexpected []string `bson:"unexpected,omitempty"`
+}
+
+// IsEmpty reports whether the drift has neither missing nor
+// unexpected rules.
+func (d FirewallDrift) IsEmpty() bool {
+	return len(d.Missing) == 0 && len(d.Unexpected) == 0
+}
+
+// FirewallDrift returns the firewall drift last recorded for the
+// machine. The result is empty if no drift was detected.
+func (m *Machine) FirewallDrift() FirewallDrift {
+	if m.doc.FirewallDrift == nil {
+		return FirewallDrift{}
+	}
+	return *m.doc.FirewallDrift
+}
+
+// SetFirewallDrift records the firewall drift detected for the machine.
+// An empty drift clears any previously recorded drift.
+func (m *Machine) SetFirewallDrift(drift FirewallDrift) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set firewall drift of machine %v", m)
+
+	var update bson.D
+	var newDrift *FirewallDrift
+	if drift.IsEmpty() {
+		update = bson.D{{"$unset", bson.D{{"firewalldrift", nil}}}}
+	} else {
+		newDrift = &drift
+		update = bson.D{{"$set", bson.D{{"firewalldrift", newDrift}}}}
+	}
+	ops := []txn.Op{{
+		C:      machinesC,
+		Id:     m.doc.DocID,
+		Assert: notDeadDoc,
+		Update: update,
+	}}
+	if err := m.st.runTransaction(ops); err != nil {
+		return onAbort(err, ErrDead)
+	}
+	m.doc.FirewallDrift = newDrift
+	return nil
+}
@@ -0,0 +1,175 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// maxHookHistory is the number of hook executions retained for
+// each unit; older executions are discarded as new ones are added.
+const maxHookHistory = 100
+
+// HookExecution records a single run of a charm hook by a unit agent.
+type HookExecution struct {
+	// Hook is the name of the hook that was run.
+	Hook string
+
+	// RelationId is the id of the relation the hook was run for,
+	// or -1 if it was not a relation hook.
+	RelationId int
+
+	// RemoteUnit is the name of the remote unit the hook was run
+	// for, if any.
+	RemoteUnit string
+
+	// Started and Finished record when the hook process started
+	// and finished running.
+	Started  time.Time
+	Finished time.Time
+
+	// ExitCode is the exit code of the hook process, or -1 if the
+	// hook could not be run to completion.
+	ExitCode int
+
+	// ToolCalls holds the names of the hook tools invoked by the
+	// hook, in the order they were called.
+	ToolCalls []string
+}
+
+// hookHistoryDoc holds the most recent hook executions of a unit.
+type hookHistoryDoc struct {
+	DocID      string             `bson:"_id"`
+	ModelUUID  string             `bson:"model-uuid"`
+	Executions []hookExecutionDoc `bson:"executions"`
+}
+
+type hookExecutionDoc struct {
+	Hook       string   `bson:"hook"`
+	RelationId int      `bson:"relation-id"`
+	RemoteUnit string   `bson:"remote-unit,omitempty"`
+	Started    int64    `bson:"started"`
+	Finished   int64    `bson:"finished"`
+	ExitCode   int      `bson:"exit-code"`
+	ToolCalls  []string `bson:"tool-calls,omitempty"`
+}
+
+func newHookExecutionDoc(exec HookExecution) hookExecutionDoc {
+	return hookExecutionDoc{
+		Hook:       exec.Hook,
+		RelationId: exec.RelationId,
+		RemoteUnit: exec.RemoteUnit,
+		Started:    exec.Started.UnixNano(),
+		Finished:   exec.Finished.UnixNano(),
+		ExitCode:   exec.ExitCode,
+		ToolCalls:  exec.ToolCalls,
+	}
+}
+
+func (doc hookExecutionDoc) execution() HookExecution {
+	return HookExecution{
+		Hook:       doc.Hook,
+		RelationId: doc.RelationId,
+		RemoteUnit: doc.RemoteUnit,
+		Started:    time.Unix(0, doc.Started).UTC(),
+		Finished:   time.Unix(0, doc.Finished).UTC(),
+		ExitCode:   doc.ExitCode,
+		ToolCalls:  doc.ToolCalls,
+	}
+}
+
+// AddHookExecution records the execution of a hook by the unit. Only
+// the most recent executions are retained.
+func (u *Unit) AddHookExecution(exec HookExecution) error {
+	if exec.Hook == "" {
+		return errors.NotValidf("hook execution with no hook name")
+	}
+	doc := newHookExecutionDoc(exec)
+	docID := u.st.docID(u.globalKey())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := u.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if u.doc.Life == Dead {
+			return nil, errors.New("unit is dead")
+		}
+		ops := []txn.Op{{
+			C:      unitsC,
+			Id:     u.doc.DocID,
+			Assert: notDeadDoc,
+		}}
+		exists, err := u.hasHookHistory()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !exists {
+			return append(ops, txn.Op{
+				C:      unitHookHistoryC,
+				Id:     docID,
+				Assert: txn.DocMissing,
+				Insert: &hookHistoryDoc{
+					ModelUUID:  u.st.ModelUUID(),
+					Executions: []hookExecutionDoc{doc},
+				},
+			}), nil
+		}
+		return append(ops, txn.Op{
+			C:      unitHookHistoryC,
+			Id:     docID,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$push", bson.D{{"executions", bson.D{
+				{"$each", []hookExecutionDoc{doc}},
+				{"$slice", -maxHookHistory},
+			}}}}},
+		}), nil
+	}
+	return errors.Annotatef(u.st.run(buildTxn), "cannot record hook execution for unit %q", u.Name())
+}
+
+// HookHistory returns the most recent hook executions recorded for
+// the unit, oldest first.
+func (u *Unit) HookHistory() ([]HookExecution, error) {
+	history, closer := u.st.getCollection(unitHookHistoryC)
+	defer closer()
+
+	var doc hookHistoryDoc
+	err := history.FindId(u.globalKey()).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get hook history for unit %q", u.Name())
+	}
+	result := make([]HookExecution, len(doc.Executions))
+	for i, execDoc := range doc.Executions {
+		result[i] = execDoc.execution()
+	}
+	return result, nil
+}
+
+func (u *Unit) hasHookHistory() (bool, error) {
+	history, closer := u.st.getCollection(unitHookHistoryC)
+	defer closer()
+	count, err := history.FindId(u.globalKey()).Count()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return count > 0, nil
+}
+
+// removeHookHistoryOp returns the operation needed to remove the hook
+// history document associated with the given globalKey.
+func removeHookHistoryOp(st *State, globalKey string) txn.Op {
+	return txn.Op{
+		C:      unitHookHistoryC,
+		Id:     st.docID(globalKey),
+		Remove: true,
+	}
+}
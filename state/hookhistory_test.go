@@ -0,0 +1,116 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"fmt"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type HookHistorySuite struct {
+	ConnSuite
+	unit *state.Unit
+}
+
+var _ = gc.Suite(&HookHistorySuite{})
+
+func (s *HookHistorySuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.unit = factory.NewFactory(s.State).MakeUnit(c, nil)
+}
+
+func (s *HookHistorySuite) hookExecution(hook string, started time.Time) state.HookExecution {
+	return state.HookExecution{
+		Hook:       hook,
+		RelationId: -1,
+		Started:    started,
+		Finished:   started.Add(time.Second),
+		ToolCalls:  []string{"status-set", "config-get"},
+	}
+}
+
+func (s *HookHistorySuite) TestHookHistoryEmpty(c *gc.C) {
+	history, err := s.unit.HookHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 0)
+}
+
+func (s *HookHistorySuite) TestAddHookExecution(c *gc.C) {
+	started := time.Date(2016, 10, 1, 12, 0, 0, 0, time.UTC)
+	first := s.hookExecution("install", started)
+	second := state.HookExecution{
+		Hook:       "db-relation-changed",
+		RelationId: 0,
+		RemoteUnit: "mysql/0",
+		Started:    started.Add(time.Minute),
+		Finished:   started.Add(2 * time.Minute),
+		ExitCode:   1,
+	}
+	err := s.unit.AddHookExecution(first)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.AddHookExecution(second)
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.unit.HookHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, jc.DeepEquals, []state.HookExecution{first, second})
+}
+
+func (s *HookHistorySuite) TestAddHookExecutionNoHook(c *gc.C) {
+	err := s.unit.AddHookExecution(state.HookExecution{RelationId: -1})
+	c.Assert(err, gc.ErrorMatches, "hook execution with no hook name not valid")
+}
+
+func (s *HookHistorySuite) TestAddHookExecutionIncludesModelUUID(c *gc.C) {
+	err := s.unit.AddHookExecution(s.hookExecution("install", time.Now()))
+	c.Assert(err, jc.ErrorIsNil)
+
+	history := s.MgoSuite.Session.DB("juju").C(state.UnitHookHistoryC)
+	var docs []bson.M
+	err = history.Find(nil).All(&docs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(docs, gc.HasLen, 1)
+	c.Assert(docs[0]["model-uuid"], gc.Equals, s.State.ModelUUID())
+}
+
+func (s *HookHistorySuite) TestHookHistoryBounded(c *gc.C) {
+	started := time.Date(2016, 10, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < state.MaxHookHistory+5; i++ {
+		exec := s.hookExecution(fmt.Sprintf("hook-%d", i), started.Add(time.Duration(i)*time.Minute))
+		err := s.unit.AddHookExecution(exec)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	history, err := s.unit.HookHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, state.MaxHookHistory)
+	c.Assert(history[0].Hook, gc.Equals, "hook-5")
+	c.Assert(history[len(history)-1].Hook, gc.Equals, fmt.Sprintf("hook-%d", state.MaxHookHistory+4))
+}
+
+func (s *HookHistorySuite) TestAddHookExecutionDeadUnit(c *gc.C) {
+	err := s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.AddHookExecution(s.hookExecution("stop", time.Now()))
+	c.Assert(err, gc.ErrorMatches, `cannot record hook execution for unit "[^"]*": unit is dead`)
+}
+
+func (s *HookHistorySuite) TestHookHistoryRemovedWithUnit(c *gc.C) {
+	err := s.unit.AddHookExecution(s.hookExecution("install", time.Now()))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	count, err := s.MgoSuite.Session.DB("juju").C(state.UnitHookHistoryC).Count()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 0)
+}
@@ -215,6 +215,9 @@ type Action interface {
 	// Results returns the structured output of the action and any error.
 	Results() (map[string]interface{}, string)
 
+	// Attachments returns the files attached to the action's results.
+	Attachments() []ActionAttachment
+
 	// ActionTag returns an ActionTag constructed from this action's
 	// Prefix and Sequence.
 	ActionTag() names.ActionTag
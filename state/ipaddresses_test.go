@@ -10,6 +10,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 )
@@ -197,6 +198,26 @@ func (s *ipAddressesStateSuite) assertAllAddressesOnMachineMatchCount(c *gc.C, m
 	c.Assert(results, gc.HasLen, expectedCount, gc.Commentf("expected %d, got %d: %+v", expectedCount, len(results), results))
 }
 
+func (s *ipAddressesStateSuite) TestMachineSpaceAddresses(c *gc.C) {
+	_, err := s.State.AddSpace("internal", "", []string{"10.20.0.0/16"}, false)
+	c.Assert(err, jc.ErrorIsNil)
+	_, addresses := s.addNamedDeviceWithAddresses(c, "eth0", "0.1.2.3/24", "10.20.30.40/16", "192.168.1.1/24")
+
+	results, err := s.machine.SpaceAddresses("internal")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []*state.Address{addresses[1]})
+
+	// Addresses of subnets not in a space are returned for the empty
+	// space; those of unknown subnets never are.
+	results, err = s.machine.SpaceAddresses("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []*state.Address{addresses[0]})
+
+	results, err = s.machine.SpaceAddresses("missing")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 0)
+}
+
 func (s *ipAddressesStateSuite) TestRemoveTwiceStillSucceeds(c *gc.C) {
 	_, existingAddresses := s.addNamedDeviceWithAddresses(c, "eth0", "0.1.2.3/24")
 
@@ -689,3 +710,128 @@ func (s *ipAddressesStateSuite) testMachineSetDevicesAddressesIdempotently(c *gc
 		}
 	}
 }
+
+func (s *ipAddressesStateSuite) addContainerWithStaticIPAddress(c *gc.C, address string) (*state.Machine, error) {
+	template := state.MachineTemplate{
+		Series:          "quantal",
+		Jobs:            []state.MachineJob{state.JobHostUnits},
+		StaticIPAddress: address,
+	}
+	return s.State.AddMachineInsideMachine(template, s.machine.Id(), instance.LXD)
+}
+
+func (s *ipAddressesStateSuite) TestAddContainerWithStaticIPAddress(c *gc.C) {
+	container, err := s.addContainerWithStaticIPAddress(c, "10.20.0.5")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(container.StaticIPAddress(), gc.Equals, "10.20.0.5")
+
+	err = container.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(container.StaticIPAddress(), gc.Equals, "10.20.0.5")
+}
+
+func (s *ipAddressesStateSuite) TestAddMachineWithStaticIPAddressFailsWhenNotAContainer(c *gc.C) {
+	_, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series:          "quantal",
+		Jobs:            []state.MachineJob{state.JobHostUnits},
+		StaticIPAddress: "10.20.0.5",
+	})
+	c.Assert(err, gc.ErrorMatches, "cannot add a new machine: static IP address can only be requested for containers")
+}
+
+func (s *ipAddressesStateSuite) TestAddContainerWithStaticIPAddressFailsWithUnknownSubnet(c *gc.C) {
+	_, err := s.addContainerWithStaticIPAddress(c, "192.168.0.5")
+	c.Assert(err, gc.ErrorMatches, `cannot add a new machine: static IP address "192.168.0.5" is not part of any known subnet`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *ipAddressesStateSuite) TestAddContainerWithStaticIPAddressFailsWhenAddressInUse(c *gc.C) {
+	s.addNamedDeviceWithAddresses(c, "eth0", "10.20.0.5/16")
+
+	_, err := s.addContainerWithStaticIPAddress(c, "10.20.0.5")
+	c.Assert(err, gc.ErrorMatches, `cannot add a new machine: static IP address "10.20.0.5" already in use by machine "0"`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *ipAddressesStateSuite) TestAddContainerWithStaticIPAddressFailsWhenAddressRequested(c *gc.C) {
+	container, err := s.addContainerWithStaticIPAddress(c, "10.20.0.5")
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.addContainerWithStaticIPAddress(c, "10.20.0.5")
+	expectedError := fmt.Sprintf(
+		`cannot add a new machine: static IP address "10.20.0.5" already requested for machine %q`, container.Id(),
+	)
+	c.Assert(err, gc.ErrorMatches, expectedError)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsAlreadyExists)
+
+	// The same address in another model is fine.
+	_, err = s.otherState.AddMachineInsideMachine(state.MachineTemplate{
+		Series:          "quantal",
+		Jobs:            []state.MachineJob{state.JobHostUnits},
+		StaticIPAddress: "10.20.0.5",
+	}, s.otherStateMachine.Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ipAddressesStateSuite) TestAddContainerWithStaticIPAddressFailsWhenAddressRequestedConcurrently(c *gc.C) {
+	var container *state.Machine
+	defer state.SetBeforeHooks(c, s.State, func() {
+		var err error
+		container, err = s.addContainerWithStaticIPAddress(c, "10.20.0.5")
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	_, err := s.addContainerWithStaticIPAddress(c, "10.20.0.5")
+	expectedError := fmt.Sprintf(
+		`cannot add a new machine: static IP address "10.20.0.5" already requested for machine %q`, container.Id(),
+	)
+	c.Assert(err, gc.ErrorMatches, expectedError)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *ipAddressesStateSuite) TestRemoveContainerReleasesStaticIPAddress(c *gc.C) {
+	container, err := s.addContainerWithStaticIPAddress(c, "10.20.0.5")
+	c.Assert(err, jc.ErrorIsNil)
+	err = container.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The address is still requested until the container is removed.
+	_, err = s.addContainerWithStaticIPAddress(c, "10.20.0.5")
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsAlreadyExists)
+
+	err = container.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.addContainerWithStaticIPAddress(c, "10.20.0.5")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ipAddressesStateSuite) TestReserveStaticIPAddress(c *gc.C) {
+	container, err := s.addContainerWithStaticIPAddress(c, "10.20.0.5")
+	c.Assert(err, jc.ErrorIsNil)
+	s.addNamedDeviceForMachine(c, "eth0", container)
+
+	err = container.ReserveStaticIPAddress("eth0", "10.20.0.1")
+	c.Assert(err, jc.ErrorIsNil)
+	// Reserving again is a no-op.
+	err = container.ReserveStaticIPAddress("eth0", "10.20.0.1")
+	c.Assert(err, jc.ErrorIsNil)
+
+	addresses, err := container.AllAddresses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addresses, gc.HasLen, 1)
+	c.Check(addresses[0].Value(), gc.Equals, "10.20.0.5")
+	c.Check(addresses[0].DeviceName(), gc.Equals, "eth0")
+	c.Check(addresses[0].SubnetCIDR(), gc.Equals, "10.20.0.0/16")
+	c.Check(addresses[0].ConfigMethod(), gc.Equals, state.StaticAddress)
+	c.Check(addresses[0].GatewayAddress(), gc.Equals, "10.20.0.1")
+
+	// Now the address is in use, so it cannot be requested again.
+	_, err = s.addContainerWithStaticIPAddress(c, "10.20.0.5")
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *ipAddressesStateSuite) TestReserveStaticIPAddressFailsWhenNoneRequested(c *gc.C) {
+	err := s.machine.ReserveStaticIPAddress("eth0", "")
+	c.Assert(err, gc.ErrorMatches, `cannot reserve static IP address for machine "0": requested static IP address not found`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotFound)
+}
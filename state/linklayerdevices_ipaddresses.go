@@ -5,8 +5,10 @@ package state
 
 import (
 	"fmt"
+	"net"
 
 	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
@@ -325,3 +327,119 @@ func (st *State) forEachIPAddressDoc(findQuery bson.D, callbackFunc func(resultD
 
 	return errors.Trace(iter.Close())
 }
+
+// checkStaticIPAddress verifies the given value can be used as a static IP
+// address for the machine with the given ID (empty for a new machine), and
+// returns the known subnet it belongs to. An error satisfying
+// errors.IsNotValid is returned when the value is not an IP address or is not
+// part of any known subnet. An error satisfying errors.IsAlreadyExists is
+// returned when the address is assigned to, or requested for, another machine.
+//
+// The check is made outside of any transaction, so the transactions adding
+// machines with a static IP address also reserve the address with
+// reserveIPAddressOp.
+func (st *State) checkStaticIPAddress(value, machineID string) (*Subnet, error) {
+	ip := net.ParseIP(value)
+	if ip == nil {
+		return nil, errors.NotValidf("static IP address %q", value)
+	}
+	subnets, err := st.AllSubnets()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var found *Subnet
+	for _, subnet := range subnets {
+		_, ipNet, err := net.ParseCIDR(subnet.CIDR())
+		if err != nil {
+			continue
+		}
+		if ipNet.Contains(ip) {
+			found = subnet
+			break
+		}
+	}
+	if found == nil {
+		return nil, errors.NewNotValid(nil, fmt.Sprintf(
+			"static IP address %q is not part of any known subnet", value,
+		))
+	}
+
+	addresses, closer := st.getCollection(ipAddressesC)
+	defer closer()
+	query := bson.D{
+		{"value", ip.String()},
+		{"machine-id", bson.D{{"$ne", machineID}}},
+	}
+	var inUse ipAddressDoc
+	err = addresses.Find(query).One(&inUse)
+	if err == nil {
+		return nil, errors.NewAlreadyExists(nil, fmt.Sprintf(
+			"static IP address %q already in use by machine %q", value, inUse.MachineID,
+		))
+	} else if err != mgo.ErrNotFound {
+		return nil, errors.Trace(err)
+	}
+
+	machines, closer := st.getCollection(machinesC)
+	defer closer()
+	query = bson.D{
+		{"staticipaddress", ip.String()},
+		{"machineid", bson.D{{"$ne", machineID}}},
+	}
+	var requestedBy machineDoc
+	err = machines.Find(query).One(&requestedBy)
+	if err == nil {
+		return nil, errors.NewAlreadyExists(nil, fmt.Sprintf(
+			"static IP address %q already requested for machine %q", value, requestedBy.Id,
+		))
+	} else if err != mgo.ErrNotFound {
+		return nil, errors.Trace(err)
+	}
+
+	applications, closer := st.getCollection(applicationsC)
+	defer closer()
+	var allocatedTo applicationDoc
+	err = applications.Find(bson.D{{"virtual-ip-address", ip.String()}}).One(&allocatedTo)
+	if err == nil {
+		return nil, errors.NewAlreadyExists(nil, fmt.Sprintf(
+			"static IP address %q already allocated to application %q", value, allocatedTo.Name,
+		))
+	} else if err != mgo.ErrNotFound {
+		return nil, errors.Trace(err)
+	}
+	return found, nil
+}
+
+// ipAddressReservationKey returns the key of the document, in the
+// providerIDs collection, reserving the given IP address in the model.
+// Static IP addresses requested for containers and the virtual IP
+// addresses of applications share these documents, so that no address
+// can be reserved twice.
+func (st *State) ipAddressReservationKey(value string) string {
+	if ip := net.ParseIP(value); ip != nil {
+		value = ip.String()
+	}
+	return st.docID("ip-address-reservation:" + value)
+}
+
+// reserveIPAddressOp returns the operation reserving the given IP
+// address in the model, which aborts if it is already reserved.
+func (st *State) reserveIPAddressOp(value string) txn.Op {
+	key := st.ipAddressReservationKey(value)
+	return txn.Op{
+		C:      providerIDsC,
+		Id:     key,
+		Assert: txn.DocMissing,
+		Insert: providerIdDoc{ID: key},
+	}
+}
+
+// releaseIPAddressOp returns the operation releasing the reservation
+// of the given IP address in the model.
+func (st *State) releaseIPAddressOp(value string) txn.Op {
+	return txn.Op{
+		C:      providerIDsC,
+		Id:     st.ipAddressReservationKey(value),
+		Remove: true,
+	}
+}
@@ -149,6 +149,15 @@ type machineDoc struct {
 	// an instance for the machine.
 	Placement string `bson:",omitempty"`
 
+	// StaticIPAddress is the IP address requested for a container
+	// machine, which is assigned to it instead of one handed out by
+	// DHCP or the provider.
+	StaticIPAddress string `bson:",omitempty"`
+
+	// FirewallDrift records how the ingress rules applied to the
+	// machine's instance differ from those expected.
+	FirewallDrift *FirewallDrift `bson:",omitempty"`
+
 	// StopMongoUntilVersion holds the version that must be checked to
 	// know if mongo must be stopped.
 	StopMongoUntilVersion string `bson:",omitempty"`
@@ -882,6 +891,9 @@ func (m *Machine) Remove() (err error) {
 	ops = append(ops, removeContainerRefOps(m.st, m.Id())...)
 	ops = append(ops, filesystemOps...)
 	ops = append(ops, volumeOps...)
+	if m.doc.StaticIPAddress != "" {
+		ops = append(ops, m.st.releaseIPAddressOp(m.doc.StaticIPAddress))
+	}
 	logger.Tracef("removing machine %q", m.Id())
 	// The only abort conditions in play indicate that the machine has already
 	// been removed.
@@ -1166,7 +1178,7 @@ func (m *Machine) PublicAddress() (network.Address, error) {
 // match, and if not it selects the best from the slice of all available
 // addresses. It returns the new address and a bool indicating if a different
 // one was picked.
-func maybeGetNewAddress(addr address, providerAddresses, machineAddresses []address, getAddr func([]address) network.Address, checkScope func(address) bool) (address, bool) {
+func maybeGetNewAddress(addr address, providerAddresses, machineAddresses []address, getAddr func([]address) network.Address, checkScope func(address) bool, policy network.AddressPolicy) (address, bool) {
 	// For picking the best address, try provider addresses first.
 	var newAddr address
 	netAddr := getAddr(providerAddresses)
@@ -1189,6 +1201,9 @@ func maybeGetNewAddress(addr address, providerAddresses, machineAddresses []addr
 	if !containsAddress(providerAddresses, addr) && !containsAddress(machineAddresses, addr) {
 		return newAddr, true
 	}
+	if !policy.Allows(addr.networkAddress()) {
+		return newAddr, true
+	}
 	if Origin(addr.Origin) != OriginProvider && Origin(newAddr.Origin) == OriginProvider {
 		return newAddr, true
 	}
@@ -1255,19 +1270,20 @@ func (m *Machine) setPreferredAddressOps(addr address, isPublic bool) []txn.Op {
 	return ops
 }
 
-func (m *Machine) setPublicAddressOps(providerAddresses []address, machineAddresses []address) ([]txn.Op, address, bool) {
+func (m *Machine) setPublicAddressOps(providerAddresses []address, machineAddresses []address, policy network.AddressPolicy) ([]txn.Op, address, bool) {
 	publicAddress := m.doc.PreferredPublicAddress
-	// Always prefer an exact match if available.
+	// Always prefer an exact match of the preferred family if available.
 	checkScope := func(addr address) bool {
-		return network.ExactScopeMatch(addr.networkAddress(), network.ScopePublic)
+		netAddr := addr.networkAddress()
+		return network.ExactScopeMatch(netAddr, network.ScopePublic) && policy.Prefers(netAddr)
 	}
 	// Without an exact match, prefer a fallback match.
 	getAddr := func(addresses []address) network.Address {
-		addr, _ := network.SelectPublicAddress(networkAddresses(addresses))
+		addr, _ := network.SelectPublicAddress(policy.Apply(networkAddresses(addresses)))
 		return addr
 	}
 
-	newAddr, changed := maybeGetNewAddress(publicAddress, providerAddresses, machineAddresses, getAddr, checkScope)
+	newAddr, changed := maybeGetNewAddress(publicAddress, providerAddresses, machineAddresses, getAddr, checkScope, policy)
 	if !changed {
 		// No change, so no ops.
 		return []txn.Op{}, publicAddress, false
@@ -1277,19 +1293,20 @@ func (m *Machine) setPublicAddressOps(providerAddresses []address, machineAddres
 	return ops, newAddr, true
 }
 
-func (m *Machine) setPrivateAddressOps(providerAddresses []address, machineAddresses []address) ([]txn.Op, address, bool) {
+func (m *Machine) setPrivateAddressOps(providerAddresses []address, machineAddresses []address, policy network.AddressPolicy) ([]txn.Op, address, bool) {
 	privateAddress := m.doc.PreferredPrivateAddress
-	// Always prefer an exact match if available.
+	// Always prefer an exact match of the preferred family if available.
 	checkScope := func(addr address) bool {
-		return network.ExactScopeMatch(addr.networkAddress(), network.ScopeMachineLocal, network.ScopeCloudLocal)
+		netAddr := addr.networkAddress()
+		return network.ExactScopeMatch(netAddr, network.ScopeMachineLocal, network.ScopeCloudLocal) && policy.Prefers(netAddr)
 	}
 	// Without an exact match, prefer a fallback match.
 	getAddr := func(addresses []address) network.Address {
-		addr, _ := network.SelectInternalAddress(networkAddresses(addresses), false)
+		addr, _ := network.SelectInternalAddress(policy.Apply(networkAddresses(addresses)), false)
 		return addr
 	}
 
-	newAddr, changed := maybeGetNewAddress(privateAddress, providerAddresses, machineAddresses, getAddr, checkScope)
+	newAddr, changed := maybeGetNewAddress(privateAddress, providerAddresses, machineAddresses, getAddr, checkScope, policy)
 	if !changed {
 		// No change, so no ops.
 		return []txn.Op{}, privateAddress, false
@@ -1360,10 +1377,15 @@ func (m *Machine) setAddresses(addresses []network.Address, field *[]address, fi
 	}
 	stateAddresses := fromNetworkAddresses(addressesToSet, origin)
 
+	cfg, err := m.st.ModelConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	policy := cfg.AddressPolicy()
+
 	var (
 		newPrivate, newPublic         address
 		changedPrivate, changedPublic bool
-		err                           error
 	)
 	machine := m
 	buildTxn := func(attempt int) ([]txn.Op, error) {
@@ -1393,8 +1415,8 @@ func (m *Machine) setAddresses(addresses []network.Address, field *[]address, fi
 		}
 
 		var setPrivateAddressOps, setPublicAddressOps []txn.Op
-		setPrivateAddressOps, newPrivate, changedPrivate = machine.setPrivateAddressOps(providerAddresses, machineAddresses)
-		setPublicAddressOps, newPublic, changedPublic = machine.setPublicAddressOps(providerAddresses, machineAddresses)
+		setPrivateAddressOps, newPrivate, changedPrivate = machine.setPrivateAddressOps(providerAddresses, machineAddresses, policy)
+		setPublicAddressOps, newPublic, changedPublic = machine.setPublicAddressOps(providerAddresses, machineAddresses, policy)
 		ops = append(ops, setPrivateAddressOps...)
 		ops = append(ops, setPublicAddressOps...)
 		return ops, nil
@@ -1432,6 +1454,12 @@ func (m *Machine) Placement() string {
 	return m.doc.Placement
 }
 
+// StaticIPAddress returns the IP address requested for the machine when
+// it was added as a container, or an empty string if none was requested.
+func (m *Machine) StaticIPAddress() string {
+	return m.doc.StaticIPAddress
+}
+
 // Constraints returns the exact constraints that should apply when provisioning
 // an instance for the machine.
 func (m *Machine) Constraints() (constraints.Value, error) {
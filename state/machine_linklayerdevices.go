@@ -828,6 +828,30 @@ func (m *Machine) AllAddresses() ([]*Address, error) {
 	return allAddresses, nil
 }
 
+// SpaceAddresses returns the addresses assigned to devices of the
+// machine that are in subnets of the named space. Addresses in
+// subnets unknown to the model are skipped.
+func (m *Machine) SpaceAddresses(space string) ([]*Address, error) {
+	addresses, err := m.AllAddresses()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var spaceAddresses []*Address
+	for _, address := range addresses {
+		subnet, err := address.Subnet()
+		if errors.IsNotFound(err) {
+			logger.Tracef("skipping %s: not linked to a known subnet (%v)", address, err)
+			continue
+		} else if err != nil {
+			return nil, errors.Annotatef(err, "cannot get subnet for address %q", address)
+		}
+		if subnet.SpaceName() == space {
+			spaceAddresses = append(spaceAddresses, address)
+		}
+	}
+	return spaceAddresses, nil
+}
+
 // SetParentLinkLayerDevicesBeforeTheirChildren splits the given devicesArgs
 // into multiple sets of args and calls SetLinkLayerDevices() for each set, such
 // that child devices are set only after their parents.
@@ -936,6 +960,35 @@ func (m *Machine) SetContainerLinkLayerDevices(containerMachine *Machine) error
 	return nil
 }
 
+// ReserveStaticIPAddress assigns the static IP address requested for the
+// container machine to its device with the given name, using the given
+// gatewayAddress (which can be empty). An error satisfying errors.IsNotFound
+// is returned when the machine has no static IP address requested, and one
+// satisfying errors.IsAlreadyExists when the address is assigned to another
+// machine in the meantime.
+func (m *Machine) ReserveStaticIPAddress(deviceName, gatewayAddress string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot reserve static IP address for machine %q", m.doc.Id)
+
+	if m.doc.StaticIPAddress == "" {
+		return errors.NotFoundf("requested static IP address")
+	}
+	subnet, err := m.st.checkStaticIPAddress(m.doc.StaticIPAddress, m.doc.Id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_, ipNet, err := net.ParseCIDR(subnet.CIDR())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	prefixLength, _ := ipNet.Mask.Size()
+	return m.SetDevicesAddresses(LinkLayerDeviceAddress{
+		DeviceName:     deviceName,
+		ConfigMethod:   StaticAddress,
+		CIDRAddress:    fmt.Sprintf("%s/%d", m.doc.StaticIPAddress, prefixLength),
+		GatewayAddress: gatewayAddress,
+	})
+}
+
 // MACAddressTemplate is used to generate a unique MAC address for a
 // container. Every '%x' is replaced by a random hexadecimal digit,
 // while the rest is kept as-is.
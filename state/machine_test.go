@@ -1915,6 +1915,76 @@ func (s *MachineSuite) TestStablePublicAddress(c *gc.C) {
 	c.Assert(addr.Value, gc.Equals, "8.8.8.8")
 }
 
+func (s *MachineSuite) TestAddressPolicy(c *gc.C) {
+	err := s.State.UpdateModelConfig(map[string]interface{}{"address-policy": "prefer-ipv6"}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = machine.SetProviderAddresses(
+		network.NewAddress("8.8.8.8"),
+		network.NewAddress("2001:db8::1"),
+		network.NewAddress("10.0.0.1"),
+		network.NewAddress("fd00::1"),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	addr, err := machine.PublicAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(addr.Value, gc.Equals, "2001:db8::1")
+	addr, err = machine.PrivateAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(addr.Value, gc.Equals, "fd00::1")
+
+	// Switching to a policy that disallows the preferred addresses
+	// replaces them the next time addresses are set.
+	err = s.State.UpdateModelConfig(map[string]interface{}{"address-policy": "ipv4-only"}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetProviderAddresses(
+		network.NewAddress("8.8.8.8"),
+		network.NewAddress("2001:db8::1"),
+		network.NewAddress("10.0.0.1"),
+		network.NewAddress("fd00::1"),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	addr, err = machine.PublicAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(addr.Value, gc.Equals, "8.8.8.8")
+	addr, err = machine.PrivateAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(addr.Value, gc.Equals, "10.0.0.1")
+}
+
+func (s *MachineSuite) TestSetFirewallDrift(c *gc.C) {
+	c.Assert(s.machine.FirewallDrift().IsEmpty(), jc.IsTrue)
+
+	drift := state.FirewallDrift{
+		Missing:    []string{"80/tcp"},
+		Unexpected: []string{"22/tcp from 10.0.0.0/8"},
+	}
+	err := s.machine.SetFirewallDrift(drift)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.machine.FirewallDrift(), jc.DeepEquals, drift)
+
+	machine, err := s.State.Machine(s.machine.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machine.FirewallDrift(), jc.DeepEquals, drift)
+
+	err = machine.SetFirewallDrift(state.FirewallDrift{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.machine.FirewallDrift().IsEmpty(), jc.IsTrue)
+}
+
+func (s *MachineSuite) TestSetFirewallDriftWhenDead(c *gc.C) {
+	err := s.machine.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.SetFirewallDrift(state.FirewallDrift{Missing: []string{"80/tcp"}})
+	c.Assert(err, gc.ErrorMatches, "cannot set firewall drift of machine 1: not found or dead")
+}
+
 func (s *MachineSuite) TestAddressesRaceMachineFirst(c *gc.C) {
 	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
 	c.Assert(err, jc.ErrorIsNil)
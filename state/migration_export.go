@@ -22,6 +22,33 @@ func (st *State) Export() (description.Model, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	// Relations to applications in other models cannot be
+	// migrated yet.
+	remoteApplications, err := st.AllRemoteApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(remoteApplications) > 0 {
+		return nil, errors.NotSupportedf("exporting model with remote applications")
+	}
+	offers, err := st.AllApplicationOffers()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(offers) > 0 {
+		return nil, errors.NotSupportedf("exporting model with application offers")
+	}
+	// Virtual IP addresses are assigned to instances of this
+	// model's cloud, and cannot be migrated to another.
+	applications, err := st.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, application := range applications {
+		if address, _ := application.VirtualIPAddress(); address != "" {
+			return nil, errors.NotSupportedf("exporting model with application virtual IP addresses")
+		}
+	}
 
 	export := exporter{
 		st:      st,
@@ -245,6 +272,8 @@ func (e *exporter) newMachine(exParent description.Machine, machine *Machine, in
 		Placement:     machine.doc.Placement,
 		Series:        machine.doc.Series,
 		ContainerType: machine.doc.ContainerType,
+
+		StaticIPAddress: machine.doc.StaticIPAddress,
 	}
 
 	if supported, ok := machine.SupportedContainers(); ok {
@@ -461,7 +490,12 @@ func (e *exporter) addApplication(application *Application, refcounts map[string
 		CharmModifiedVersion: application.doc.CharmModifiedVersion,
 		ForceCharm:           application.doc.ForceCharm,
 		Exposed:              application.doc.Exposed,
+		ExposedCIDRs:         application.doc.ExposedCIDRs,
+		ExposedSpaces:        application.doc.ExposedSpaces,
+		EgressRules:          egressRuleStrings(application.EgressRules()),
 		MinUnits:             application.doc.MinUnits,
+		StorageQuotaSize:     application.doc.StorageQuota.Size,
+		StorageQuotaCount:    application.doc.StorageQuota.Count,
 		Settings:             applicationSettingsDoc.Settings,
 		SettingsRefCount:     refCount,
 		Leader:               leader,
@@ -498,6 +532,7 @@ func (e *exporter) addApplication(application *Application, refcounts map[string
 			PasswordHash:    unit.doc.PasswordHash,
 			MeterStatusCode: unitMeterStatus.Code,
 			MeterStatusInfo: unitMeterStatus.Info,
+			WorkloadVersion: unit.doc.WorkloadVersion,
 		}
 		if principalName, isSubordinate := unit.PrincipalName(); isSubordinate {
 			args.Principal = names.NewUnitTag(principalName)
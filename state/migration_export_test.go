@@ -7,6 +7,7 @@ import (
 	"math/rand"
 	"time"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
@@ -284,6 +285,18 @@ func (s *MigrationExportSuite) TestMultipleServices(c *gc.C) {
 	c.Assert(applications, gc.HasLen, 3)
 }
 
+func (s *MigrationExportSuite) TestServiceVirtualIPAddressNotSupported(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	_, err := s.State.AddSubnet(state.SubnetInfo{CIDR: "10.20.0.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = application.AllocateVirtualIPAddress("10.20.0.0/24", "")
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.Export()
+	c.Check(err, gc.ErrorMatches, "exporting model with application virtual IP addresses not supported")
+	c.Check(errors.Cause(err), jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *MigrationExportSuite) TestUnits(c *gc.C) {
 	unit := s.Factory.MakeUnit(c, &factory.UnitParams{
 		Constraints: constraints.MustParse("arch=amd64 mem=8G"),
@@ -17,6 +17,7 @@ import (
 	"github.com/juju/juju/core/description"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/tools"
 )
@@ -431,6 +432,7 @@ func (i *importer) makeMachineDoc(m description.Machine) (*machineDoc, error) {
 		SupportedContainersKnown: supportedSet,
 		SupportedContainers:      supportedContainers,
 		Placement:                m.Placement(),
+		StaticIPAddress:          m.StaticIPAddress(),
 	}, nil
 }
 
@@ -665,6 +667,14 @@ func (i *importer) makeApplicationDoc(s description.Application) (*applicationDo
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	var egressRules []network.EgressRule
+	for _, value := range s.EgressRules() {
+		rule, err := network.ParseEgressRule(value)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		egressRules = append(egressRules, rule)
+	}
 
 	return &applicationDoc{
 		Name:                 s.Name(),
@@ -678,8 +688,15 @@ func (i *importer) makeApplicationDoc(s description.Application) (*applicationDo
 		UnitCount:            len(s.Units()),
 		RelationCount:        i.relationCount(s.Name()),
 		Exposed:              s.Exposed(),
+		ExposedCIDRs:         s.ExposedCIDRs(),
+		ExposedSpaces:        s.ExposedSpaces(),
+		EgressRules:          newEgressRuleDocs(egressRules),
 		MinUnits:             s.MinUnits(),
 		MetricCredentials:    s.MetricsCredentials(),
+		StorageQuota: StorageQuota{
+			Size:  s.StorageQuotaSize(),
+			Count: s.StorageQuotaCount(),
+		},
 	}, nil
 }
 
@@ -723,10 +740,11 @@ func (i *importer) makeUnitDoc(s description.Application, u description.Unit) (*
 		Principal:    u.Principal().Id(),
 		Subordinates: subordinates,
 		// StorageAttachmentCount int `bson:"storageattachmentcount"`
-		MachineId:    u.Machine().Id(),
-		Tools:        i.makeTools(u.Tools()),
-		Life:         Alive,
-		PasswordHash: u.PasswordHash(),
+		MachineId:       u.Machine().Id(),
+		Tools:           i.makeTools(u.Tools()),
+		Life:            Alive,
+		PasswordHash:    u.PasswordHash(),
+		WorkloadVersion: u.WorkloadVersion(),
 	}, nil
 }
 
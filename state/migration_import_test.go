@@ -15,6 +15,7 @@ import (
 
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
@@ -270,6 +271,39 @@ func (s *MigrationImportSuite) TestMachines(c *gc.C) {
 	c.Assert(newCons.String(), gc.Equals, cons.String())
 }
 
+func (s *MigrationImportSuite) TestMachineStaticIPAddress(c *gc.C) {
+	_, err := s.State.AddSubnet(state.SubnetInfo{CIDR: "10.20.0.0/16"})
+	c.Assert(err, jc.ErrorIsNil)
+	parent := s.Factory.MakeMachine(c, nil)
+	container, err := s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series:          "quantal",
+		Jobs:            []state.MachineJob{state.JobHostUnits},
+		StaticIPAddress: "10.20.0.5",
+	}, parent.Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+	err = container.SetProvisioned("inst-lxd", "nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = container.SetAgentVersion(version.MustParseBinary("2.0.1-quantal-amd64"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+	defer newSt.Close()
+
+	imported, err := newSt.Machine(container.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(imported.StaticIPAddress(), gc.Equals, "10.20.0.5")
+
+	// The address remains reserved in the imported model.
+	_, err = newSt.AddSubnet(state.SubnetInfo{CIDR: "10.20.0.0/16"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = newSt.AddMachineInsideMachine(state.MachineTemplate{
+		Series:          "quantal",
+		Jobs:            []state.MachineJob{state.JobHostUnits},
+		StaticIPAddress: "10.20.0.5",
+	}, parent.Id(), instance.LXD)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsAlreadyExists)
+}
+
 func (s *MigrationImportSuite) TestServices(c *gc.C) {
 	// Add a service with both settings and leadership settings.
 	cons := constraints.MustParse("arch=amd64 mem=8G")
@@ -357,6 +391,8 @@ func (s *MigrationImportSuite) TestUnits(c *gc.C) {
 	})
 	err := exported.SetMeterStatus("GREEN", "some info")
 	c.Assert(err, jc.ErrorIsNil)
+	err = exported.SetWorkloadVersion("1.2.3")
+	c.Assert(err, jc.ErrorIsNil)
 	err = s.State.SetAnnotations(exported, testAnnotations)
 	c.Assert(err, jc.ErrorIsNil)
 	s.primeStatusHistory(c, exported, status.StatusActive, 5)
@@ -393,6 +429,7 @@ func (s *MigrationImportSuite) TestUnits(c *gc.C) {
 	meterStatus, err := imported.GetMeterStatus()
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(meterStatus, gc.Equals, state.MeterStatus{state.MeterGreen, "some info"})
+	c.Assert(imported.WorkloadVersion(), gc.Equals, "1.2.3")
 	s.assertAnnotations(c, newSt, imported)
 	s.checkStatusHistory(c, exported, imported, 5)
 	s.checkStatusHistory(c, exported.Agent(), imported.Agent(), 5)
@@ -110,6 +110,9 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// The SSH host keys for each machine will be reported as each
 		// machine agent starts up.
 		sshHostKeysC,
+		// Hook execution history is diagnostic, and is rebuilt as
+		// units run hooks in the new controller.
+		unitHookHistoryC,
 	)
 
 	// THIS SET WILL BE REMOVED WHEN MIGRATIONS ARE COMPLETE
@@ -139,6 +142,10 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		storageConstraintsC,
 		volumesC,
 		volumeAttachmentsC,
+		volumeSnapshotsC,
+		// Storage pool usage changes are only used to serialise
+		// storage quota checks.
+		storagePoolUsageC,
 
 		// network
 		ipAddressesC,
@@ -153,6 +160,10 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		actionNotificationsC,
 		actionresultsC,
 
+		// cross model relations
+		remoteApplicationsC,
+		applicationOffersC,
+
 		// uncategorised
 		metricsManagerC, // should really be copied across
 	)
@@ -255,6 +266,7 @@ func (s *MigrationSuite) TestMachineDocFields(c *gc.C) {
 		"PreferredPublicAddress",
 		"Principals",
 		"Series",
+		"StaticIPAddress",
 		"SupportedContainers",
 		"SupportedContainersKnown",
 		"Tools",
@@ -269,6 +281,7 @@ func (s *MigrationSuite) TestMachineDocFields(c *gc.C) {
 		"Clean",
 		"Filesystems",
 		"HasVote",
+		"FirewallDrift",
 	)
 	s.AssertExportedFields(c, machineDoc{}, fields.Union(todo))
 }
@@ -311,6 +324,13 @@ func (s *MigrationSuite) TestServiceDocFields(c *gc.C) {
 		// RelationCount is handled by the number of times the application name
 		// appears in relation endpoints.
 		"RelationCount",
+		// Relations to remote applications are not migrated.
+		"RelationIngress",
+		// Models with virtual IP addresses cannot be exported.
+		"VirtualIPAddress",
+		"VirtualIPSubnet",
+		"VirtualIPHolder",
+		"VirtualIPInstance",
 	)
 	migrated := set.NewStrings(
 		"Name",
@@ -321,8 +341,12 @@ func (s *MigrationSuite) TestServiceDocFields(c *gc.C) {
 		"CharmModifiedVersion",
 		"ForceCharm",
 		"Exposed",
+		"ExposedCIDRs",
+		"ExposedSpaces",
+		"EgressRules",
 		"MinUnits",
 		"MetricCredentials",
+		"StorageQuota",
 	)
 	s.AssertExportedFields(c, applicationDoc{}, migrated.Union(ignored))
 }
@@ -362,6 +386,7 @@ func (s *MigrationSuite) TestUnitDocFields(c *gc.C) {
 		// TxnRevno isn't migrated.
 		"TxnRevno",
 		"PasswordHash",
+		"WorkloadVersion",
 	)
 	todo := set.NewStrings(
 		"StorageAttachmentCount",
@@ -42,6 +42,10 @@ type Policy interface {
 	// InstanceDistributor takes a *config.Config and returns an
 	// InstanceDistributor or an error.
 	InstanceDistributor(*config.Config) (InstanceDistributor, error)
+
+	// VirtualIPReleaser takes a *config.Config and returns a
+	// VirtualIPReleaser or an error.
+	VirtualIPReleaser(*config.Config) (VirtualIPReleaser, error)
 }
 
 // Prechecker is a policy interface that is provided to State
@@ -58,6 +62,16 @@ type Prechecker interface {
 	PrecheckInstance(series string, cons constraints.Value, placement string) error
 }
 
+// VirtualIPReleaser is a policy interface that is provided to State
+// to release the virtual IP addresses of removed applications from the
+// instances they were assigned to.
+type VirtualIPReleaser interface {
+	// ReleaseVirtualIPAddress makes the given address, allocated from
+	// the subnet with the given CIDR, no longer reachable on the
+	// instance with the given ID.
+	ReleaseVirtualIPAddress(instId instance.Id, address, subnetCIDR string) error
+}
+
 // ConfigValidator is a policy interface that is provided to State
 // to check validity of new configuration attributes before applying them to state.
 type ConfigValidator interface {
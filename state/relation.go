@@ -6,6 +6,7 @@ package state
 import (
 	stderrors "errors"
 	"fmt"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -147,7 +148,7 @@ func (r *Relation) destroyOps(ignoreService string) (ops []txn.Op, isRemove bool
 		return nil, false, errAlreadyDying
 	}
 	if r.doc.UnitCount == 0 {
-		removeOps, err := r.removeOps(ignoreService, nil)
+		removeOps, err := r.removeOps(ignoreService, "")
 		if err != nil {
 			return nil, false, err
 		}
@@ -163,16 +164,23 @@ func (r *Relation) destroyOps(ignoreService string) (ops []txn.Op, isRemove bool
 
 // removeOps returns the operations necessary to remove the relation. If
 // ignoreService is not empty, no operations affecting that service will be
-// included; if departingUnit is not nil, this implies that the relation's
-// services may be Dying and otherwise unreferenced, and may thus require
-// removal themselves.
-func (r *Relation) removeOps(ignoreService string, departingUnit *Unit) ([]txn.Op, error) {
+// included; if departingUnitName is not empty, this implies that the
+// relation's services may be Dying and otherwise unreferenced, and may thus
+// require removal themselves. Remote applications are removed along with
+// their last relation.
+func (r *Relation) removeOps(ignoreService string, departingUnitName string) ([]txn.Op, error) {
 	relOp := txn.Op{
 		C:      relationsC,
 		Id:     r.doc.DocID,
 		Remove: true,
 	}
-	if departingUnit != nil {
+	var departingApplicationName string
+	if departingUnitName != "" {
+		var err error
+		departingApplicationName, err = names.UnitApplication(departingUnitName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
 		relOp.Assert = bson.D{{"life", Dying}, {"unitcount", 1}}
 	} else {
 		relOp.Assert = bson.D{{"life", Alive}, {"unitcount", 0}}
@@ -182,14 +190,24 @@ func (r *Relation) removeOps(ignoreService string, departingUnit *Unit) ([]txn.O
 		if ep.ApplicationName == ignoreService {
 			continue
 		}
+		if isRemote, err := isRemoteApplication(r.st, ep.ApplicationName); err != nil {
+			return nil, errors.Trace(err)
+		} else if isRemote {
+			remoteOps, err := r.removeRemoteEndpointOps(ep)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, remoteOps...)
+			continue
+		}
 		var asserts bson.D
 		hasRelation := bson.D{{"relationcount", bson.D{{"$gt", 0}}}}
-		if departingUnit == nil {
+		if departingUnitName == "" {
 			// We're constructing a destroy operation, either of the relation
 			// or one of its services, and can therefore be assured that both
 			// services are Alive.
 			asserts = append(hasRelation, isAliveDoc...)
-		} else if ep.ApplicationName == departingUnit.ApplicationName() {
+		} else if ep.ApplicationName == departingApplicationName {
 			// This service must have at least one unit -- the one that's
 			// departing the relation -- so it cannot be ready for removal.
 			cannotDieYet := bson.D{{"unitcount", bson.D{{"$gt", 0}}}}
@@ -203,7 +221,11 @@ func (r *Relation) removeOps(ignoreService string, departingUnit *Unit) ([]txn.O
 			hasLastRef := bson.D{{"life", Dying}, {"unitcount", 0}, {"relationcount", 1}}
 			removable := append(bson.D{{"_id", ep.ApplicationName}}, hasLastRef...)
 			if err := applications.Find(removable).One(&svc.doc); err == nil {
-				ops = append(ops, svc.removeOps(hasLastRef)...)
+				removeOps, err := svc.removeOps(hasLastRef)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				ops = append(ops, removeOps...)
 				continue
 			} else if err != mgo.ErrNotFound {
 				return nil, err
@@ -227,6 +249,26 @@ func (r *Relation) removeOps(ignoreService string, departingUnit *Unit) ([]txn.O
 	return append(ops, cleanupOp), nil
 }
 
+// removeRemoteEndpointOps returns the operations necessary to release
+// the relation's reference to the remote application of the supplied
+// endpoint, removing the remote application if this is its last
+// relation.
+func (r *Relation) removeRemoteEndpointOps(ep Endpoint) ([]txn.Op, error) {
+	remoteApp, err := r.st.RemoteApplication(ep.ApplicationName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if remoteApp.doc.RelationCount == 1 {
+		return remoteApp.removeOps(bson.D{{"relationcount", 1}}), nil
+	}
+	return []txn.Op{{
+		C:      remoteApplicationsC,
+		Id:     remoteApp.doc.DocID,
+		Assert: bson.D{{"relationcount", bson.D{{"$gt", 1}}}},
+		Update: bson.D{{"$inc", bson.D{{"relationcount", -1}}}},
+	}}, nil
+}
+
 // Id returns the integer internal relation key. This is exposed
 // because the unit agent needs to expose a value derived from this
 // (as JUJU_RELATION_ID) to allow relation hooks to differentiate
@@ -290,7 +332,65 @@ func (r *Relation) Unit(u *Unit) (*RelationUnit, error) {
 		st:       r.st,
 		relation: r,
 		unit:     u,
+		unitName: u.doc.Name,
 		endpoint: ep,
 		scope:    strings.Join(scope, "#"),
 	}, nil
 }
+
+// RemoteUnit returns a RelationUnit for the named unit of a remote
+// application in the relation. Remote units have no unit documents
+// of their own; they enter and leave the relation's scope on behalf
+// of units in the remote application's source model.
+func (r *Relation) RemoteUnit(unitName string) (*RelationUnit, error) {
+	applicationName, err := names.UnitApplication(unitName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ep, err := r.Endpoint(applicationName)
+	if err != nil {
+		return nil, err
+	}
+	if isRemote, err := isRemoteApplication(r.st, applicationName); err != nil {
+		return nil, errors.Trace(err)
+	} else if !isRemote {
+		return nil, errors.NotValidf("remote unit %q of local application", unitName)
+	}
+	return &RelationUnit{
+		st:       r.st,
+		relation: r,
+		unitName: unitName,
+		endpoint: ep,
+		scope:    r.globalScope(),
+	}, nil
+}
+
+// RemoteUnitsInScope returns the names of the units of the named
+// remote application that are in the relation's scope.
+func (r *Relation) RemoteUnitsInScope(applicationName string) ([]string, error) {
+	ep, err := r.Endpoint(applicationName)
+	if err != nil {
+		return nil, err
+	}
+	relationScopes, closer := r.st.getCollection(relationScopesC)
+	defer closer()
+
+	prefix := strings.Join([]string{r.globalScope(), string(ep.Role), applicationName + "/"}, "#")
+	sel := bson.D{{"key", bson.D{{"$regex", "^" + regexp.QuoteMeta(prefix)}}}}
+	var docs []relationScopeDoc
+	if err := relationScopes.Find(sel).All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot get units of %q in scope of relation %q", applicationName, r)
+	}
+	unitNames := make([]string, len(docs))
+	for i, doc := range docs {
+		unitNames[i] = doc.unitName()
+	}
+	sort.Strings(unitNames)
+	return unitNames, nil
+}
+
+// globalScope returns the scope prefix of units in a relation
+// without container scope.
+func (r *Relation) globalScope() string {
+	return "r#" + strconv.Itoa(r.doc.Id)
+}
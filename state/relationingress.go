@@ -0,0 +1,116 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"net"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// RelationIngressCIDRs returns the address ranges, in CIDR notation, of
+// the units of remote applications related to the application. These
+// ranges may access the application's open ports whether or not the
+// application is exposed.
+func (s *Application) RelationIngressCIDRs() []string {
+	if len(s.doc.RelationIngress) == 0 {
+		return nil
+	}
+	cidrs := set.NewStrings()
+	for _, cidr := range s.doc.RelationIngress {
+		cidrs.Add(cidr)
+	}
+	return cidrs.SortedValues()
+}
+
+// SetRelationIngressCIDR records the address range, in CIDR notation,
+// from which the named unit of a remote application related to the
+// application accesses it. An empty range removes the record.
+func (s *Application) SetRelationIngressCIDR(remoteUnitName, cidr string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set relation ingress for application %q", s)
+	if !names.IsValidUnit(remoteUnitName) {
+		return errors.NotValidf("unit name %q", remoteUnitName)
+	}
+	field := "relation-ingress." + remoteUnitName
+	var update bson.D
+	if cidr != "" {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return errors.NewNotValid(err, "")
+		}
+		update = bson.D{{"$set", bson.D{{field, cidr}}}}
+	} else {
+		update = bson.D{{"$unset", bson.D{{field, nil}}}}
+	}
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     s.doc.DocID,
+		Assert: txn.DocExists,
+		Update: update,
+	}}
+	if err := s.st.runTransaction(ops); err != nil {
+		return onAbort(err, errors.NotFoundf("application"))
+	}
+	if cidr != "" {
+		if s.doc.RelationIngress == nil {
+			s.doc.RelationIngress = make(map[string]string)
+		}
+		s.doc.RelationIngress[remoteUnitName] = cidr
+	} else {
+		delete(s.doc.RelationIngress, remoteUnitName)
+	}
+	return nil
+}
+
+// ClearRelationIngressCIDRs removes the address ranges recorded for
+// all units of the named remote application related to the
+// application.
+func (s *Application) ClearRelationIngressCIDRs(remoteApplicationName string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot clear relation ingress for application %q", s)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := s.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		unitNames := s.relationIngressUnits(remoteApplicationName)
+		if len(unitNames) == 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		unset := make(bson.D, len(unitNames))
+		for i, unitName := range unitNames {
+			unset[i] = bson.DocElem{"relation-ingress." + unitName, nil}
+		}
+		return []txn.Op{{
+			C:      applicationsC,
+			Id:     s.doc.DocID,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$unset", unset}},
+		}}, nil
+	}
+	if err := s.st.run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	for _, unitName := range s.relationIngressUnits(remoteApplicationName) {
+		delete(s.doc.RelationIngress, unitName)
+	}
+	return nil
+}
+
+// relationIngressUnits returns the names of the units of the named
+// remote application for which address ranges are recorded.
+func (s *Application) relationIngressUnits(remoteApplicationName string) []string {
+	var unitNames []string
+	for unitName := range s.doc.RelationIngress {
+		applicationName, err := names.UnitApplication(unitName)
+		if err == nil && applicationName == remoteApplicationName {
+			unitNames = append(unitNames, unitName)
+		}
+	}
+	return unitNames
+}
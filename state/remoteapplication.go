@@ -0,0 +1,335 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// RemoteApplication represents the state of an application hosted
+// in another model on the same controller, which is related to
+// applications in this model. A remote application has no units
+// or charm of its own; it exists only to be related to, and is
+// removed along with its last relation.
+type RemoteApplication struct {
+	st  *State
+	doc remoteApplicationDoc
+}
+
+// remoteEndpointDoc represents one of the endpoints of a remote
+// application.
+type remoteEndpointDoc struct {
+	Name      string              `bson:"name"`
+	Role      charm.RelationRole  `bson:"role"`
+	Interface string              `bson:"interface"`
+	Limit     int                 `bson:"limit"`
+	Scope     charm.RelationScope `bson:"scope"`
+}
+
+// remoteApplicationDoc represents the internal state of a remote
+// application in MongoDB.
+type remoteApplicationDoc struct {
+	DocID                 string              `bson:"_id"`
+	Name                  string              `bson:"name"`
+	ModelUUID             string              `bson:"model-uuid"`
+	SourceModelUUID       string              `bson:"source-model-uuid"`
+	SourceApplicationName string              `bson:"source-application-name"`
+	OfferName             string              `bson:"offer-name"`
+	IsConsumerProxy       bool                `bson:"is-consumer-proxy"`
+	Endpoints             []remoteEndpointDoc `bson:"endpoints"`
+	Life                  Life                `bson:"life"`
+	RelationCount         int                 `bson:"relationcount"`
+}
+
+func newRemoteApplication(st *State, doc *remoteApplicationDoc) *RemoteApplication {
+	return &RemoteApplication{
+		st:  st,
+		doc: *doc,
+	}
+}
+
+// String returns the remote application name.
+func (s *RemoteApplication) String() string {
+	return s.doc.Name
+}
+
+// Name returns the remote application name.
+func (s *RemoteApplication) Name() string {
+	return s.doc.Name
+}
+
+// Tag returns a name identifying the remote application.
+func (s *RemoteApplication) Tag() names.Tag {
+	return names.NewApplicationTag(s.doc.Name)
+}
+
+// SourceModel returns the tag of the model hosting the application
+// represented by the remote application.
+func (s *RemoteApplication) SourceModel() names.ModelTag {
+	return names.NewModelTag(s.doc.SourceModelUUID)
+}
+
+// SourceApplicationName returns the name of the application, in the
+// source model, represented by the remote application.
+func (s *RemoteApplication) SourceApplicationName() string {
+	return s.doc.SourceApplicationName
+}
+
+// OfferName returns the name of the application offer through which
+// the remote application and the applications of this model are
+// related.
+func (s *RemoteApplication) OfferName() string {
+	return s.doc.OfferName
+}
+
+// IsConsumerProxy returns whether the remote application represents
+// an application consuming an offer made by this model, rather than
+// an offered application consumed by this model.
+func (s *RemoteApplication) IsConsumerProxy() bool {
+	return s.doc.IsConsumerProxy
+}
+
+// Life returns whether the remote application is Alive, Dying or Dead.
+func (s *RemoteApplication) Life() Life {
+	return s.doc.Life
+}
+
+// Endpoints returns the remote application's relation endpoints.
+func (s *RemoteApplication) Endpoints() ([]Endpoint, error) {
+	eps := make([]Endpoint, len(s.doc.Endpoints))
+	for i, ep := range s.doc.Endpoints {
+		eps[i] = Endpoint{
+			ApplicationName: s.doc.Name,
+			Relation: charm.Relation{
+				Name:      ep.Name,
+				Role:      ep.Role,
+				Interface: ep.Interface,
+				Limit:     ep.Limit,
+				Scope:     ep.Scope,
+			},
+		}
+	}
+	sort.Sort(epSlice(eps))
+	return eps, nil
+}
+
+// Endpoint returns the relation endpoint with the supplied name, if it exists.
+func (s *RemoteApplication) Endpoint(relationName string) (Endpoint, error) {
+	eps, err := s.Endpoints()
+	if err != nil {
+		return Endpoint{}, err
+	}
+	for _, ep := range eps {
+		if ep.Name == relationName {
+			return ep, nil
+		}
+	}
+	return Endpoint{}, fmt.Errorf("remote application %q has no %q relation", s, relationName)
+}
+
+// Relations returns a Relation for every relation the remote
+// application is in.
+func (s *RemoteApplication) Relations() ([]*Relation, error) {
+	return applicationRelations(s.st, s.doc.Name)
+}
+
+// Refresh refreshes the contents of the remote application from the
+// underlying state. It returns an error that satisfies
+// errors.IsNotFound if the remote application has been removed.
+func (s *RemoteApplication) Refresh() error {
+	remoteApplications, closer := s.st.getCollection(remoteApplicationsC)
+	defer closer()
+
+	err := remoteApplications.FindId(s.doc.DocID).One(&s.doc)
+	if err == mgo.ErrNotFound {
+		return errors.NotFoundf("remote application %q", s)
+	}
+	if err != nil {
+		return errors.Annotatef(err, "cannot refresh remote application %q", s)
+	}
+	return nil
+}
+
+// Destroy removes the remote application. A remote application taking
+// part in relations cannot be destroyed: it is removed along with its
+// last relation instead.
+func (s *RemoteApplication) Destroy() (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot destroy remote application %q", s)
+	ops := s.removeOps(bson.D{{"relationcount", 0}})
+	if err := s.st.runTransaction(ops); err == txn.ErrAborted {
+		if err := s.Refresh(); errors.IsNotFound(err) {
+			return nil
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		return errors.Errorf("remote application has %d relation(s)", s.doc.RelationCount)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// removeOps returns the operations required to remove the remote
+// application. Supplied asserts will be included in the operation
+// on the remote application document.
+func (s *RemoteApplication) removeOps(asserts bson.D) []txn.Op {
+	return []txn.Op{{
+		C:      remoteApplicationsC,
+		Id:     s.doc.DocID,
+		Assert: asserts,
+		Remove: true,
+	}}
+}
+
+// AddRemoteApplicationArgs contains the parameters for adding a
+// remote application to the model.
+type AddRemoteApplicationArgs struct {
+	// Name is the name of the remote application in this model.
+	Name string
+
+	// SourceModel is the tag of the model hosting the application
+	// represented by the remote application.
+	SourceModel names.ModelTag
+
+	// SourceApplicationName is the name of the application, in
+	// the source model, represented by the remote application.
+	SourceApplicationName string
+
+	// OfferName is the name of the application offer through which
+	// the remote application is related.
+	OfferName string
+
+	// IsConsumerProxy is true if the remote application represents
+	// an application consuming an offer made by this model.
+	IsConsumerProxy bool
+
+	// Endpoints holds the remote application's relation endpoints.
+	Endpoints []charm.Relation
+}
+
+// AddRemoteApplication creates a new remote application record,
+// having the supplied relation endpoints, with the supplied name
+// (which must be unique across all applications, local and remote).
+func (st *State) AddRemoteApplication(args AddRemoteApplicationArgs) (_ *RemoteApplication, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot add remote application %q", args.Name)
+
+	// Sanity checks.
+	if !names.IsValidApplication(args.Name) {
+		return nil, errors.NotValidf("name")
+	}
+	if !names.IsValidModel(args.SourceModel.Id()) {
+		return nil, errors.NotValidf("source model %q", args.SourceModel.Id())
+	}
+	if len(args.Endpoints) == 0 {
+		return nil, errors.New("no endpoints specified")
+	}
+	if err := checkModelActive(st); err != nil {
+		return nil, errors.Trace(err)
+	}
+	applicationID := st.docID(args.Name)
+	doc := &remoteApplicationDoc{
+		DocID:                 applicationID,
+		Name:                  args.Name,
+		ModelUUID:             st.ModelUUID(),
+		SourceModelUUID:       args.SourceModel.Id(),
+		SourceApplicationName: args.SourceApplicationName,
+		OfferName:             args.OfferName,
+		IsConsumerProxy:       args.IsConsumerProxy,
+		Life:                  Alive,
+	}
+	for _, ep := range args.Endpoints {
+		if ep.Role == charm.RolePeer {
+			return nil, errors.Errorf("peer endpoint %q not valid for remote application", ep.Name)
+		}
+		if ep.Scope == charm.ScopeContainer {
+			return nil, errors.Errorf("container scoped endpoint %q not valid for remote application", ep.Name)
+		}
+		doc.Endpoints = append(doc.Endpoints, remoteEndpointDoc{
+			Name:      ep.Name,
+			Role:      ep.Role,
+			Interface: ep.Interface,
+			Limit:     ep.Limit,
+			Scope:     ep.Scope,
+		})
+	}
+	ops := []txn.Op{
+		assertModelActiveOp(st.ModelUUID()),
+		{
+			C:      applicationsC,
+			Id:     applicationID,
+			Assert: txn.DocMissing,
+		}, {
+			C:      remoteApplicationsC,
+			Id:     applicationID,
+			Assert: txn.DocMissing,
+			Insert: doc,
+		},
+	}
+	if err := st.runTransaction(ops); err == txn.ErrAborted {
+		if err := checkModelActive(st); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if _, err := st.Application(args.Name); err == nil {
+			return nil, errors.AlreadyExistsf("application %q", args.Name)
+		}
+		return nil, errors.AlreadyExistsf("remote application %q", args.Name)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return newRemoteApplication(st, doc), nil
+}
+
+// RemoteApplication returns a remote application state by name.
+func (st *State) RemoteApplication(name string) (_ *RemoteApplication, err error) {
+	if !names.IsValidApplication(name) {
+		return nil, errors.NotValidf("remote application name %q", name)
+	}
+	remoteApplications, closer := st.getCollection(remoteApplicationsC)
+	defer closer()
+
+	doc := &remoteApplicationDoc{}
+	err = remoteApplications.FindId(name).One(doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("remote application %q", name)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get remote application %q", name)
+	}
+	return newRemoteApplication(st, doc), nil
+}
+
+// AllRemoteApplications returns all the remote applications in
+// the model.
+func (st *State) AllRemoteApplications() (applications []*RemoteApplication, err error) {
+	remoteApplications, closer := st.getCollection(remoteApplicationsC)
+	defer closer()
+
+	docs := []remoteApplicationDoc{}
+	err = remoteApplications.Find(nil).All(&docs)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get all remote applications")
+	}
+	for _, v := range docs {
+		applications = append(applications, newRemoteApplication(st, &v))
+	}
+	return applications, nil
+}
+
+// isRemoteApplication returns whether the named application is a
+// remote application.
+func isRemoteApplication(st *State, name string) (bool, error) {
+	remoteApplications, closer := st.getCollection(remoteApplicationsC)
+	defer closer()
+
+	n, err := remoteApplications.FindId(name).Count()
+	return n > 0, err
+}
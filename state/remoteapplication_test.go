@@ -0,0 +1,299 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/testing"
+)
+
+type RemoteApplicationSuite struct {
+	ConnSuite
+	sourceModel names.ModelTag
+	mysql       *state.RemoteApplication
+	wordpress   *state.Application
+}
+
+var _ = gc.Suite(&RemoteApplicationSuite{})
+
+func (s *RemoteApplicationSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.sourceModel = names.NewModelTag(utils.MustNewUUID().String())
+	var err error
+	s.mysql, err = s.State.AddRemoteApplication(state.AddRemoteApplicationArgs{
+		Name:                  "mysql",
+		SourceModel:           s.sourceModel,
+		SourceApplicationName: "database",
+		OfferName:             "mysql",
+		Endpoints: []charm.Relation{{
+			Name:      "server",
+			Role:      charm.RoleProvider,
+			Interface: "mysql",
+			Scope:     charm.ScopeGlobal,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.wordpress = s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+}
+
+func (s *RemoteApplicationSuite) addRelation(c *gc.C) *state.Relation {
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	return rel
+}
+
+func (s *RemoteApplicationSuite) TestAddRemoteApplication(c *gc.C) {
+	mysql, err := s.State.RemoteApplication("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mysql.Name(), gc.Equals, "mysql")
+	c.Assert(mysql.Tag(), gc.Equals, names.NewApplicationTag("mysql"))
+	c.Assert(mysql.SourceModel(), gc.Equals, s.sourceModel)
+	c.Assert(mysql.SourceApplicationName(), gc.Equals, "database")
+	c.Assert(mysql.OfferName(), gc.Equals, "mysql")
+	c.Assert(mysql.IsConsumerProxy(), jc.IsFalse)
+	c.Assert(mysql.Life(), gc.Equals, state.Alive)
+	eps, err := mysql.Endpoints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(eps, jc.DeepEquals, []state.Endpoint{{
+		ApplicationName: "mysql",
+		Relation: charm.Relation{
+			Name:      "server",
+			Role:      charm.RoleProvider,
+			Interface: "mysql",
+			Scope:     charm.ScopeGlobal,
+		},
+	}})
+
+	all, err := s.State.AllRemoteApplications()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 1)
+	c.Assert(all[0].Name(), gc.Equals, "mysql")
+}
+
+func (s *RemoteApplicationSuite) TestAddRemoteApplicationInvalid(c *gc.C) {
+	_, err := s.State.AddRemoteApplication(state.AddRemoteApplicationArgs{
+		Name:        "riak",
+		SourceModel: s.sourceModel,
+		Endpoints: []charm.Relation{{
+			Name:      "ring",
+			Role:      charm.RolePeer,
+			Interface: "riak",
+		}},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add remote application "riak": peer endpoint "ring" not valid for remote application`)
+
+	_, err = s.State.AddRemoteApplication(state.AddRemoteApplicationArgs{
+		Name:        "riak",
+		SourceModel: s.sourceModel,
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add remote application "riak": no endpoints specified`)
+}
+
+func (s *RemoteApplicationSuite) TestNamesUnique(c *gc.C) {
+	_, err := s.State.AddRemoteApplication(state.AddRemoteApplicationArgs{
+		Name:        "wordpress",
+		SourceModel: s.sourceModel,
+		Endpoints: []charm.Relation{{
+			Name:      "db",
+			Role:      charm.RoleRequirer,
+			Interface: "mysql",
+		}},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+	c.Assert(err, gc.ErrorMatches, `cannot add remote application "wordpress": application "wordpress" already exists`)
+
+	_, err = s.State.AddApplication(state.AddApplicationArgs{
+		Name:  "mysql",
+		Charm: s.AddTestingCharm(c, "mysql"),
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add application "mysql": remote application with same name already exists`)
+}
+
+func (s *RemoteApplicationSuite) TestAddRelation(c *gc.C) {
+	rel := s.addRelation(c)
+	c.Assert(rel.String(), gc.Equals, "wordpress:db mysql:server")
+
+	rels, err := s.mysql.Relations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rels, gc.HasLen, 1)
+	c.Assert(rels[0].Id(), gc.Equals, rel.Id())
+}
+
+func (s *RemoteApplicationSuite) TestAddRelationBetweenRemoteApplications(c *gc.C) {
+	_, err := s.State.AddRemoteApplication(state.AddRemoteApplicationArgs{
+		Name:        "blog",
+		SourceModel: s.sourceModel,
+		Endpoints: []charm.Relation{{
+			Name:      "db",
+			Role:      charm.RoleRequirer,
+			Interface: "mysql",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	eps, err := s.State.InferEndpoints("blog", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddRelation(eps...)
+	c.Assert(err, gc.ErrorMatches, `cannot add relation "blog:db mysql:server": cannot relate remote applications to each other`)
+}
+
+func (s *RemoteApplicationSuite) TestRemovedWithLastRelation(c *gc.C) {
+	rel := s.addRelation(c)
+	err := rel.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *RemoteApplicationSuite) TestRemovedWithLocalApplication(c *gc.C) {
+	s.addRelation(c)
+	err := s.wordpress.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *RemoteApplicationSuite) TestDestroy(c *gc.C) {
+	s.addRelation(c)
+	err := s.mysql.Destroy()
+	c.Assert(err, gc.ErrorMatches, `cannot destroy remote application "mysql": remote application has 1 relation\(s\)`)
+
+	blog, err := s.State.AddRemoteApplication(state.AddRemoteApplicationArgs{
+		Name:        "blog",
+		SourceModel: s.sourceModel,
+		Endpoints: []charm.Relation{{
+			Name:      "db",
+			Role:      charm.RoleRequirer,
+			Interface: "mysql",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = blog.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.RemoteApplication("blog")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// Destroying a removed remote application is not an error.
+	err = blog.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *RemoteApplicationSuite) TestRemoteUnitScope(c *gc.C) {
+	rel := s.addRelation(c)
+	unit, err := s.wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	localRU, err := rel.Unit(unit)
+	c.Assert(err, jc.ErrorIsNil)
+	w := localRU.Watch()
+	defer testing.AssertStop(c, w)
+	wc := testing.NewRelationUnitsWatcherC(c, s.State, w)
+	wc.AssertChange(nil, nil)
+	wc.AssertNoChange()
+
+	remoteRU, err := rel.RemoteUnit("mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	err = remoteRU.EnterScope(map[string]interface{}{"host": "10.0.0.1"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange([]string{"mysql/0"}, nil)
+	wc.AssertNoChange()
+
+	settings, err := localRU.ReadSettings("mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, map[string]interface{}{"host": "10.0.0.1"})
+
+	// The relation is removed, along with the remote
+	// application, when the last remote unit leaves.
+	err = rel.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = remoteRU.LeaveScope()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(nil, []string{"mysql/0"})
+	err = rel.Refresh()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *RemoteApplicationSuite) TestRemoteUnitsInScope(c *gc.C) {
+	rel := s.addRelation(c)
+	unitNames, err := rel.RemoteUnitsInScope("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitNames, gc.HasLen, 0)
+
+	for _, unitName := range []string{"mysql/1", "mysql/0"} {
+		ru, err := rel.RemoteUnit(unitName)
+		c.Assert(err, jc.ErrorIsNil)
+		err = ru.EnterScope(nil)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	unit, err := s.wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	localRU, err := rel.Unit(unit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = localRU.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	unitNames, err = rel.RemoteUnitsInScope("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitNames, jc.DeepEquals, []string{"mysql/0", "mysql/1"})
+}
+
+func (s *RemoteApplicationSuite) TestRemoteUnitOfLocalApplication(c *gc.C) {
+	rel := s.addRelation(c)
+	_, err := rel.RemoteUnit("wordpress/0")
+	c.Assert(err, gc.ErrorMatches, `remote unit "wordpress/0" of local application not valid`)
+}
+
+func (s *RemoteApplicationSuite) TestWatchUnits(c *gc.C) {
+	rel := s.addRelation(c)
+	w, err := rel.WatchUnits("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	defer testing.AssertStop(c, w)
+	wc := testing.NewRelationUnitsWatcherC(c, s.State, w)
+	wc.AssertChange(nil, nil)
+	wc.AssertNoChange()
+
+	remoteRU, err := rel.RemoteUnit("mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	err = remoteRU.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange([]string{"mysql/0"}, nil)
+	wc.AssertNoChange()
+
+	// Units of the other application are not reported.
+	unit, err := s.wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	localRU, err := rel.Unit(unit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = localRU.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	err = remoteRU.LeaveScope()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(nil, []string{"mysql/0"})
+	wc.AssertNoChange()
+}
+
+func (s *RemoteApplicationSuite) TestWatchRemoteApplications(c *gc.C) {
+	w := s.State.WatchRemoteApplications()
+	defer testing.AssertStop(c, w)
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChange("mysql")
+	wc.AssertNoChange()
+
+	rel := s.addRelation(c)
+	err := rel.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange("mysql")
+	wc.AssertNoChange()
+}
@@ -478,6 +478,17 @@ func (s *StateSettings) ReadSettings(key string) (map[string]interface{}, error)
 	}
 }
 
+// ReadSettingsWithVersion exposes readSettings on state for use outside
+// the state package, returning the settings along with their version. The
+// version is increased every time the settings change.
+func (s *StateSettings) ReadSettingsWithVersion(key string) (map[string]interface{}, int64, error) {
+	settings, err := readSettings(s.st, s.collection, key)
+	if err != nil {
+		return nil, 0, err
+	}
+	return settings.Map(), settings.version, nil
+}
+
 // RemoveSettings exposes removeSettings on state for use outside the state package.
 func (s *StateSettings) RemoveSettings(key string) error {
 	return removeSettings(s.st, s.collection, key)
@@ -487,3 +498,36 @@ func (s *StateSettings) RemoveSettings(key string) error {
 func (s *StateSettings) ListSettings(keyPrefix string) (map[string]map[string]interface{}, error) {
 	return listSettings(s.st, s.collection, keyPrefix)
 }
+
+// ReplaceSettings exposes replaceSettingsOp on state for use outside the state package.
+func (s *StateSettings) ReplaceSettings(key string, settings map[string]interface{}) error {
+	op, settingsChanged, err := replaceSettingsOp(s.st, s.collection, key, settings)
+	if err != nil {
+		return err
+	}
+	err = s.st.runTransaction([]txn.Op{op})
+	if err == txn.ErrAborted {
+		if changed, err := settingsChanged(); err != nil {
+			return err
+		} else if changed {
+			return errors.Errorf("settings %q changed concurrently", key)
+		}
+	}
+	return err
+}
+
+// ReplaceSettingsWithVersion exposes replaceSettingsOp on state for use
+// outside the state package. The settings are replaced only if their
+// version is still the one specified.
+func (s *StateSettings) ReplaceSettingsWithVersion(key string, version int64, settings map[string]interface{}) error {
+	op, _, err := replaceSettingsOp(s.st, s.collection, key, settings)
+	if err != nil {
+		return err
+	}
+	op.Assert = bson.D{{"version", version}}
+	err = s.st.runTransaction([]txn.Op{op})
+	if err == txn.ErrAborted {
+		return errors.Errorf("settings %q changed concurrently", key)
+	}
+	return err
+}
@@ -173,6 +173,12 @@ func (st *State) removeAllModelDocs(modelAssertion bson.D) error {
 		ops = append(ops, decHostedModelCountOp())
 	}
 
+	// The content of action attachments is held in blob storage,
+	// which is not removed with the documents.
+	if err := st.removeActionAttachments(nil); err != nil {
+		return errors.Trace(err)
+	}
+
 	// Add all per-model docs to the txn.
 	for name, info := range st.database.Schema() {
 		if info.global {
@@ -879,6 +885,11 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 	} else if exists {
 		return nil, errors.Errorf("application already exists")
 	}
+	if exists, err := isRemoteApplication(st, args.Name); err != nil {
+		return nil, errors.Trace(err)
+	} else if exists {
+		return nil, errors.Errorf("remote application with same name already exists")
+	}
 	if err := checkModelActive(st); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -891,6 +902,13 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 	if err := validateStorageConstraints(st, args.Storage, args.Charm.Meta()); err != nil {
 		return nil, errors.Trace(err)
 	}
+	requestedStorage := requestedStorageUsage(
+		args.Charm.Meta(), args.Storage, uint64(args.NumUnits), true,
+	)
+	quotaOps, err := validateStorageQuotas(st, nil, requestedStorage)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	storagePools := make(set.Strings)
 	for _, storageParams := range args.Storage {
 		storagePools.Add(storageParams.Pool)
@@ -964,8 +982,21 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 			if err := st.precheckInstance(args.Series, args.Constraints, data.directive); err != nil {
 				return nil, errors.Trace(err)
 			}
+
+		case containerPlacement:
+			if data.staticIPAddress == "" {
+				break
+			}
+			if _, err := st.checkStaticIPAddress(data.staticIPAddress, ""); err != nil {
+				return nil, errors.Trace(err)
+			}
 		}
 	}
+	if err := validateSharedStoragePlacement(
+		st, args.Charm.Meta(), args.Storage, args.NumUnits, args.Placement,
+	); err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	applicationID := st.docID(args.Name)
 
@@ -1016,7 +1047,11 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 	ops := append(
 		[]txn.Op{
 			assertModelActiveOp(st.ModelUUID()),
-			endpointBindingsOp,
+			endpointBindingsOp, {
+				C:      remoteApplicationsC,
+				Id:     applicationID,
+				Assert: txn.DocMissing,
+			},
 		},
 		addApplicationOps(st, addApplicationOpsArgs{
 			applicationDoc:   svcDoc,
@@ -1037,6 +1072,18 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 	}
 	ops = append(ops, peerOps...)
 
+	// Collect shared storage addition operations. Each unit
+	// added below is attached to the shared storage.
+	sharedStorageOps, sharedStorage, err := createSharedStorageOps(
+		st, svc.ApplicationTag(), args.Charm.Meta(), args.Charm.URL(),
+		args.Storage, args.NumUnits,
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, sharedStorageOps...)
+	ops = append(ops, quotaOps...)
+
 	if len(args.Resources) > 0 {
 		// Collect pending resource resolution operations.
 		resources, err := st.Resources()
@@ -1052,7 +1099,11 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 
 	// Collect unit-adding operations.
 	for x := 0; x < args.NumUnits; x++ {
-		unitName, unitOps, err := svc.addServiceUnitOps(applicationAddUnitOpsArgs{cons: args.Constraints, storageCons: args.Storage})
+		unitName, unitOps, err := svc.addServiceUnitOps(applicationAddUnitOpsArgs{
+			cons:          args.Constraints,
+			storageCons:   args.Storage,
+			sharedStorage: sharedStorage,
+		})
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -1070,6 +1121,11 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 		if err := checkModelActive(st); err != nil {
 			return nil, errors.Trace(err)
 		}
+		if changed, err := storagePoolUsageChanged(st, ops); err != nil {
+			return nil, errors.Trace(err)
+		} else if changed {
+			return nil, errStorageUsageChanged
+		}
 		return nil, errors.Errorf("application already exists")
 	} else if err != nil {
 		return nil, errors.Trace(err)
@@ -1181,9 +1237,10 @@ func (st *State) AssignUnitWithPlacement(unit *Unit, placement *instance.Placeme
 // instance.Placement gets translated into a placement directive the providers
 // understand.
 type placementData struct {
-	machineId     string
-	directive     string
-	containerType instance.ContainerType
+	machineId       string
+	directive       string
+	containerType   instance.ContainerType
+	staticIPAddress string
 }
 
 type placementType int
@@ -1208,9 +1265,14 @@ func (p placementData) placementType() placementType {
 func (st *State) parsePlacement(placement *instance.Placement) (*placementData, error) {
 	// Extract container type and parent from container placement directives.
 	if container, err := instance.ParseContainerType(placement.Scope); err == nil {
+		machineId, address, err := instance.ParseContainerDirective(placement.Directive)
+		if err != nil {
+			return nil, errors.Annotatef(err, "placement directive %q", placement.Directive)
+		}
 		return &placementData{
-			containerType: container,
-			machineId:     placement.Directive,
+			containerType:   container,
+			machineId:       machineId,
+			staticIPAddress: address,
 		}, nil
 	}
 	switch placement.Scope {
@@ -1250,10 +1312,12 @@ func (st *State) addMachineWithPlacement(unit *Unit, placement *instance.Placeme
 			Dirty:       true,
 			Constraints: *unitCons,
 		}
+		parentTemplate := template
+		template.StaticIPAddress = data.staticIPAddress
 		if data.machineId != "" {
 			return st.AddMachineInsideMachine(template, data.machineId, data.containerType)
 		}
-		return st.AddMachineInsideNewMachine(template, template, data.containerType)
+		return st.AddMachineInsideNewMachine(template, parentTemplate, data.containerType)
 	case directivePlacement:
 		// If a placement directive is to be used, do that here.
 		template := MachineTemplate{
@@ -1408,7 +1472,7 @@ func (st *State) endpoints(name string, filter func(ep Endpoint) bool) ([]Endpoi
 	} else {
 		return nil, errors.Errorf("invalid endpoint %q", name)
 	}
-	svc, err := st.Application(svcName)
+	svc, err := st.endpointer(svcName)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -1434,6 +1498,30 @@ func (st *State) endpoints(name string, filter func(ep Endpoint) bool) ([]Endpoi
 	return final, nil
 }
 
+// endpointer is implemented by both local and remote applications.
+type endpointer interface {
+	Endpoints() ([]Endpoint, error)
+	Endpoint(relationName string) (Endpoint, error)
+}
+
+// endpointer returns the local or remote application with the
+// given name.
+func (st *State) endpointer(name string) (endpointer, error) {
+	svc, err := st.Application(name)
+	if err == nil {
+		return svc, nil
+	} else if !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	remoteApp, remoteErr := st.RemoteApplication(name)
+	if errors.IsNotFound(remoteErr) {
+		return nil, errors.Trace(err)
+	} else if remoteErr != nil {
+		return nil, errors.Trace(remoteErr)
+	}
+	return remoteApp, nil
+}
+
 // AddRelation creates a new relation with the given endpoints.
 func (st *State) AddRelation(eps ...Endpoint) (r *Relation, err error) {
 	key := relationKey(eps)
@@ -1473,9 +1561,17 @@ func (st *State) AddRelation(eps ...Endpoint) (r *Relation, err error) {
 		}
 		// Collect per-service operations, checking sanity as we go.
 		var ops []txn.Op
-		var subordinateCount int
+		var subordinateCount, remoteCount int
 		series := map[string]bool{}
 		for _, ep := range eps {
+			remoteOps, err := st.addRemoteRelationOps(ep)
+			if err == nil {
+				remoteCount++
+				ops = append(ops, remoteOps...)
+				continue
+			} else if !errors.IsNotFound(err) {
+				return nil, errors.Trace(err)
+			}
 			svc, err := st.Application(ep.ApplicationName)
 			if errors.IsNotFound(err) {
 				return nil, errors.Errorf("application %q does not exist", ep.ApplicationName)
@@ -1502,6 +1598,15 @@ func (st *State) AddRelation(eps ...Endpoint) (r *Relation, err error) {
 				Update: bson.D{{"$inc", bson.D{{"relationcount", 1}}}},
 			})
 		}
+		if remoteCount > 0 {
+			if remoteCount == len(eps) {
+				return nil, errors.Errorf("cannot relate remote applications to each other")
+			}
+			if eps[0].Scope == charm.ScopeContainer {
+				return nil, errors.Errorf("container scoped relation not supported with remote applications")
+			}
+			matchSeries = false
+		}
 		if matchSeries && len(series) != 1 {
 			return nil, errors.Errorf("principal and subordinate applications' series must match")
 		}
@@ -1540,6 +1645,33 @@ func (st *State) AddRelation(eps ...Endpoint) (r *Relation, err error) {
 	return nil, errors.Trace(err)
 }
 
+// addRemoteRelationOps returns the operations required to add a relation
+// to the given endpoint of a remote application. If the application is
+// not a remote application, an error satisfying errors.IsNotFound is
+// returned.
+func (st *State) addRemoteRelationOps(ep Endpoint) ([]txn.Op, error) {
+	remoteApp, err := st.RemoteApplication(ep.ApplicationName)
+	if err != nil {
+		return nil, err
+	}
+	if remoteApp.doc.Life != Alive {
+		return nil, errors.Errorf("remote application %q is not alive", ep.ApplicationName)
+	}
+	remoteEp, err := remoteApp.Endpoint(ep.Name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if remoteEp.Role != ep.Role || remoteEp.Interface != ep.Interface {
+		return nil, errors.Errorf("%q does not implement %q", ep.ApplicationName, ep)
+	}
+	return []txn.Op{{
+		C:      remoteApplicationsC,
+		Id:     st.docID(ep.ApplicationName),
+		Assert: isAliveDoc,
+		Update: bson.D{{"$inc", bson.D{{"relationcount", 1}}}},
+	}}, nil
+}
+
 // EndpointsRelation returns the existing relation with the given endpoints.
 func (st *State) EndpointsRelation(endpoints ...Endpoint) (*Relation, error) {
 	return st.KeyRelation(relationKey(endpoints))
@@ -559,6 +559,15 @@ func (s *MultiEnvStateSuite) TestWatchTwoEnvironments(c *gc.C) {
 				err = wordpress.SetMinUnits(2)
 				c.Assert(err, jc.ErrorIsNil)
 			},
+		}, {
+			about: "subnets",
+			getWatcher: func(st *state.State) interface{} {
+				return st.WatchSubnets()
+			},
+			triggerEvent: func(st *state.State) {
+				_, err := st.AddSubnet(state.SubnetInfo{CIDR: "10.0.0.0/24"})
+				c.Assert(err, jc.ErrorIsNil)
+			},
 		},
 	} {
 		c.Logf("Test %d: %s", i, test.about)
@@ -5,6 +5,7 @@ package state
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/dustin/go-humanize"
 	"github.com/juju/errors"
@@ -17,6 +18,7 @@ import (
 	"gopkg.in/mgo.v2/txn"
 
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/storage/poolmanager"
 	"github.com/juju/juju/storage/provider"
@@ -35,8 +37,10 @@ type StorageInstance interface {
 	Kind() StorageKind
 
 	// Owner returns the tag of the service or unit that owns this storage
-	// instance.
-	Owner() names.Tag
+	// instance, and a boolean indicating whether or not there is an owner.
+	// A non-shared storage instance that has been detached from its unit
+	// has no owner until it is attached to another unit.
+	Owner() (names.Tag, bool)
 
 	// StorageName returns the name of the storage, as defined in the charm
 	// storage metadata. This does not uniquely identify storage instances,
@@ -93,14 +97,29 @@ func (s *storageInstance) Kind() StorageKind {
 	return s.doc.Kind
 }
 
-func (s *storageInstance) Owner() names.Tag {
+func (s *storageInstance) Owner() (names.Tag, bool) {
+	if s.doc.Owner == "" {
+		return nil, false
+	}
 	tag, err := names.ParseTag(s.doc.Owner)
 	if err != nil {
-		// This should be impossible; we do not expose
-		// a means of modifying the owner tag.
+		// This should be impossible; the owner tag
+		// is only ever set to a valid tag, or cleared.
 		panic(err)
 	}
-	return tag
+	return tag, true
+}
+
+// ownerApplication returns the tag of the application that owns the
+// storage instance, and a boolean indicating whether or not the storage
+// instance is owned by an application, i.e. is shared storage.
+func (s *storageInstance) ownerApplication() (names.ApplicationTag, bool) {
+	owner, ok := s.Owner()
+	if !ok {
+		return names.ApplicationTag{}, false
+	}
+	tag, ok := owner.(names.ApplicationTag)
+	return tag, ok
 }
 
 func (s *storageInstance) StorageName() string {
@@ -128,6 +147,13 @@ type storageInstanceDoc struct {
 	StorageName     string      `bson:"storagename"`
 	AttachmentCount int         `bson:"attachmentcount"`
 	CharmURL        *charm.URL  `bson:"charmurl"`
+	Releasing       bool        `bson:"releasing,omitempty"`
+
+	// Pool and Size record the pool from which the storage instance
+	// was provisioned, and its requested size in MiB. They are used
+	// to account for storage against storage quotas.
+	Pool string `bson:"pool,omitempty"`
+	Size uint64 `bson:"size,omitempty"`
 }
 
 type storageAttachment struct {
@@ -192,6 +218,21 @@ func (st *State) storageInstance(tag names.StorageTag) (*storageInstance, error)
 	return &s, nil
 }
 
+func (st *State) storageInstances(query bson.D) ([]*storageInstance, error) {
+	storageCollection, closer := st.getCollection(storageInstancesC)
+	defer closer()
+
+	var docs []storageInstanceDoc
+	if err := storageCollection.Find(query).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get storage instances")
+	}
+	storageInstances := make([]*storageInstance, len(docs))
+	for i, doc := range docs {
+		storageInstances[i] = &storageInstance{st, doc}
+	}
+	return storageInstances, nil
+}
+
 // AllStorageInstances lists all storage instances currently in state
 // for this Juju model.
 func (st *State) AllStorageInstances() (storageInstances []StorageInstance, err error) {
@@ -214,6 +255,26 @@ func (st *State) AllStorageInstances() (storageInstances []StorageInstance, err
 // no attachments, it will be removed immediately.
 func (st *State) DestroyStorageInstance(tag names.StorageTag) (err error) {
 	defer errors.DeferredAnnotatef(&err, "cannot destroy storage %q", tag.Id())
+	return st.destroyStorageInstance(tag, false)
+}
+
+// ReleaseStorageInstance ensures that the storage instance and all its
+// attachments will be removed at some point, like DestroyStorageInstance.
+// Unlike DestroyStorageInstance, the volumes and filesystems bound to the
+// storage instance will be removed from the model without being destroyed
+// in the cloud, leaving them to be managed outside of Juju.
+//
+// Only storage whose volumes and filesystems are not scoped to a machine
+// may be released.
+func (st *State) ReleaseStorageInstance(tag names.StorageTag) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot release storage %q", tag.Id())
+	if err := st.validateStorageNotMachineScoped(tag); err != nil {
+		return errors.Trace(err)
+	}
+	return st.destroyStorageInstance(tag, true)
+}
+
+func (st *State) destroyStorageInstance(tag names.StorageTag, release bool) error {
 	buildTxn := func(attempt int) ([]txn.Op, error) {
 		s, err := st.storageInstance(tag)
 		if errors.IsNotFound(err) {
@@ -221,7 +282,7 @@ func (st *State) DestroyStorageInstance(tag names.StorageTag) (err error) {
 		} else if err != nil {
 			return nil, errors.Trace(err)
 		}
-		switch ops, err := st.destroyStorageInstanceOps(s); err {
+		switch ops, err := st.destroyStorageInstanceOps(s, release); err {
 		case errAlreadyDying:
 			return nil, jujutxn.ErrNoOperations
 		case nil:
@@ -233,7 +294,7 @@ func (st *State) DestroyStorageInstance(tag names.StorageTag) (err error) {
 	return st.run(buildTxn)
 }
 
-func (st *State) destroyStorageInstanceOps(s *storageInstance) ([]txn.Op, error) {
+func (st *State) destroyStorageInstanceOps(s *storageInstance, release bool) ([]txn.Op, error) {
 	if s.doc.Life == Dying {
 		return nil, errAlreadyDying
 	}
@@ -242,7 +303,7 @@ func (st *State) destroyStorageInstanceOps(s *storageInstance) ([]txn.Op, error)
 		// remove the storage instance immediately.
 		hasNoAttachments := bson.D{{"attachmentcount", 0}}
 		assert := append(hasNoAttachments, isAliveDoc...)
-		return removeStorageInstanceOps(st, s.StorageTag(), assert)
+		return removeStorageInstanceOps(st, s.StorageTag(), assert, release)
 	}
 	// There are still attachments: the storage instance will be removed
 	// when the last attachment is removed. We schedule a cleanup to destroy
@@ -251,7 +312,10 @@ func (st *State) destroyStorageInstanceOps(s *storageInstance) ([]txn.Op, error)
 		{"life", Alive},
 		{"attachmentcount", bson.D{{"$gt", 0}}},
 	}
-	update := bson.D{{"$set", bson.D{{"life", Dying}}}}
+	// Whether the storage is to be released is recorded on the
+	// storage instance, so that the storage's volumes and filesystems
+	// can be released when the last attachment is removed.
+	update := bson.D{{"$set", lifeWithReleasing(Dying, release)}}
 	ops := []txn.Op{
 		st.newCleanupOp(cleanupAttachmentsForDyingStorage, s.doc.Id),
 		{
@@ -265,11 +329,14 @@ func (st *State) destroyStorageInstanceOps(s *storageInstance) ([]txn.Op, error)
 }
 
 // removeStorageInstanceOps removes the storage instance with the given
-// tag from state, if the specified assertions hold true.
+// tag from state, if the specified assertions hold true. If release is
+// true, then the volumes and filesystems bound to the storage instance
+// will be released rather than destroyed.
 func removeStorageInstanceOps(
 	st *State,
 	tag names.StorageTag,
 	assert bson.D,
+	release bool,
 ) ([]txn.Op, error) {
 	ops := []txn.Op{{
 		C:      storageInstancesC,
@@ -296,7 +363,7 @@ func removeStorageInstanceOps(
 			volumesC, volume.Tag().Id(),
 		))
 		if volume.LifeBinding() == tag {
-			ops = append(ops, destroyVolumeOps(st, volume)...)
+			ops = append(ops, destroyVolumeOps(st, volume, release)...)
 		}
 	} else if !errors.IsNotFound(err) {
 		return nil, errors.Trace(err)
@@ -307,7 +374,7 @@ func removeStorageInstanceOps(
 			filesystemsC, filesystem.Tag().Id(),
 		))
 		if filesystem.LifeBinding() == tag {
-			ops = append(ops, destroyFilesystemOps(st, filesystem)...)
+			ops = append(ops, destroyFilesystemOps(st, filesystem, release)...)
 		}
 	} else if !errors.IsNotFound(err) {
 		return nil, errors.Trace(err)
@@ -404,6 +471,8 @@ func createStorageOps(
 				Owner:       owner,
 				StorageName: t.storageName,
 				CharmURL:    curl,
+				Pool:        t.cons.Pool,
+				Size:        t.cons.Size,
 			}
 			if unit, ok := entity.(names.UnitTag); ok {
 				doc.AttachmentCount = 1
@@ -433,16 +502,95 @@ func createStorageOps(
 		}
 	}
 
-	// TODO(axw) create storage attachments for each shared storage
-	// instance owned by the service.
-	//
-	// TODO(axw) prevent creation of shared storage after service
-	// creation, because the only sane time to add storage attachments
-	// is when units are added to said service.
-
 	return ops, numStorageAttachments, nil
 }
 
+// createSharedStorageOps returns txn.Ops for creating the shared storage
+// instances owned by a new application, along with the tags of the created
+// storage instances. Shared storage is only created along with the
+// application; storage attachments are created as units are added.
+//
+// Each storage instance is created with an attachment count of numUnits;
+// the caller is responsible for creating a storage attachment for each of
+// the application's initial units.
+func createSharedStorageOps(
+	st *State,
+	app names.ApplicationTag,
+	charmMeta *charm.Meta,
+	curl *charm.URL,
+	cons map[string]StorageConstraints,
+	numUnits int,
+) (ops []txn.Op, storageTags []names.StorageTag, err error) {
+	// Create storage instances in order of name, to simplify testing.
+	storageNames := set.NewStrings()
+	for name := range cons {
+		storageNames.Add(name)
+	}
+	for _, store := range storageNames.SortedValues() {
+		cons := cons[store]
+		charmStorage, ok := charmMeta.Storage[store]
+		if !ok {
+			return nil, nil, errors.NotFoundf("charm storage %q", store)
+		}
+		if !charmStorage.Shared {
+			continue
+		}
+		if charmStorage.Type != charm.StorageFilesystem {
+			return nil, nil, errors.NotSupportedf("shared %s storage", charmStorage.Type)
+		}
+		for i := uint64(0); i < cons.Count; i++ {
+			id, err := newStorageInstanceId(st, store)
+			if err != nil {
+				return nil, nil, errors.Annotate(err, "cannot generate storage instance name")
+			}
+			ops = append(ops, txn.Op{
+				C:      storageInstancesC,
+				Id:     id,
+				Assert: txn.DocMissing,
+				Insert: &storageInstanceDoc{
+					Id:              id,
+					Kind:            StorageKindFilesystem,
+					Owner:           app.String(),
+					StorageName:     store,
+					AttachmentCount: numUnits,
+					CharmURL:        curl,
+					Pool:            cons.Pool,
+					Size:            cons.Size,
+				},
+			})
+			storageTags = append(storageTags, names.NewStorageTag(id))
+		}
+	}
+	return ops, storageTags, nil
+}
+
+// sharedStorageAttachmentsOps returns txn.Ops for attaching a new unit
+// to each of the shared storage instances owned by its application, along
+// with the tags of the storage instances. The caller is responsible for
+// creating the storage attachments.
+func sharedStorageAttachmentsOps(st *State, app names.ApplicationTag) ([]txn.Op, []names.StorageTag, error) {
+	coll, closer := st.getCollection(storageInstancesC)
+	defer closer()
+
+	var docs []storageInstanceDoc
+	query := bson.D{{"owner", app.String()}, {"life", Alive}}
+	if err := coll.Find(query).Sort("id").All(&docs); err != nil {
+		return nil, nil, errors.Annotatef(err, "cannot get shared storage for %s", names.ReadableString(app))
+	}
+	ops := make([]txn.Op, len(docs))
+	storageTags := make([]names.StorageTag, len(docs))
+	for i, doc := range docs {
+		ops[i] = txn.Op{
+			C:      storageInstancesC,
+			Id:     doc.Id,
+			Assert: isAliveDoc,
+			Update: bson.D{{"$inc", bson.D{{"attachmentcount", 1}}}},
+		}
+		storageTags[i] = names.NewStorageTag(doc.Id)
+	}
+	return ops, storageTags, nil
+}
+
 // unitAssignedMachineStorageOps returns ops for creating volumes, filesystems
 // and their attachments to the machine that the specified unit is assigned to,
 // corresponding to the specified storage instance.
@@ -669,6 +817,26 @@ func removeStorageAttachmentOps(
 		Assert: txn.DocExists,
 		Update: bson.D{{"$inc", bson.D{{"storageattachmentcount", -1}}}},
 	}}
+	if si.doc.Owner == "" && si.doc.Life == Alive {
+		// The storage has been detached from the unit, and will
+		// outlive the attachment; detach the storage's volume or
+		// filesystem from the unit's machine, so that the storage
+		// may be attached elsewhere.
+		detachOps, err := detachStorageMachineOps(st, si, names.NewUnitTag(s.doc.Unit))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, detachOps...)
+	} else if _, ok := si.ownerApplication(); ok && si.doc.Life == Alive {
+		// The storage is shared by the units of an application, and
+		// will outlive the attachment; detach the storage's filesystem
+		// from the unit's machine if no other unit there shares it.
+		detachOps, err := detachSharedStorageMachineOps(st, si, names.NewUnitTag(s.doc.Unit))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, detachOps...)
+	}
 	if si.doc.AttachmentCount == 1 {
 		var hasLastRef bson.D
 		if si.doc.Life == Dying {
@@ -680,7 +848,9 @@ func removeStorageAttachmentOps(
 			// Either the storage instance is dying, or its owner
 			// is a unit; in either case, no more attachments can
 			// be added to the instance, so it can be removed.
-			siOps, err := removeStorageInstanceOps(st, si.StorageTag(), hasLastRef)
+			siOps, err := removeStorageInstanceOps(
+				st, si.StorageTag(), hasLastRef, si.doc.Releasing,
+			)
 			if err != nil {
 				return nil, errors.Trace(err)
 			}
@@ -756,6 +926,12 @@ type StorageConstraints struct {
 
 	// Count is the required number of storage instances.
 	Count uint64 `bson:"count"`
+
+	// Snapshot, if non-empty, is the ID of the volume snapshot from
+	// which block storage instances should be created. Snapshot is
+	// only used when adding storage to an existing unit, and is
+	// never recorded in state.
+	Snapshot string `bson:"-"`
 }
 
 func createStorageConstraintsOp(key string, cons map[string]StorageConstraints) txn.Op {
@@ -828,10 +1004,9 @@ func validateStorageConstraintsAgainstCharm(
 		if !ok {
 			return errors.Errorf("charm %q has no store called %q", charmMeta.Name, name)
 		}
-		if charmStorage.Shared {
-			// TODO(axw) implement shared storage support.
+		if charmStorage.Shared && charmStorage.Type != charm.StorageFilesystem {
 			return errors.Errorf(
-				"charm %q store %q: shared storage support not implemented",
+				"charm %q store %q: shared block storage not supported",
 				charmMeta.Name, name,
 			)
 		}
@@ -859,10 +1034,96 @@ func validateStorageConstraintsAgainstCharm(
 		if err := validateStoragePool(st, cons.Pool, kind, nil); err != nil {
 			return err
 		}
+		if charmStorage.Shared {
+			_, provider, err := poolStorageProvider(st, cons.Pool)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if !supportsSharedFilesystems(provider) {
+				return errors.Errorf(
+					"charm %q store %q: pool %q does not support shared filesystems",
+					charmMeta.Name, name, cons.Pool,
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// validateSharedStoragePlacement validates that the units of a new
+// application with shared storage from a machine-scoped storage pool,
+// such as hostdir, will all be placed on one machine or its containers.
+// The shared filesystem is scoped to the machine that the first unit
+// is assigned to, and cannot be attached to any other machine.
+func validateSharedStoragePlacement(
+	st *State,
+	charmMeta *charm.Meta,
+	allCons map[string]StorageConstraints,
+	numUnits int,
+	placement []*instance.Placement,
+) error {
+	if numUnits < 2 {
+		return nil
+	}
+	for name, cons := range allCons {
+		if !charmMeta.Storage[name].Shared {
+			continue
+		}
+		_, provider, err := poolStorageProvider(st, cons.Pool)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if provider.Scope() != storage.ScopeMachine {
+			continue
+		}
+		var hostId string
+		for i := 0; i < numUnits; i++ {
+			var machineId string
+			if i < len(placement) {
+				data, err := st.parsePlacement(placement[i])
+				if err != nil {
+					return errors.Trace(err)
+				}
+				machineId = data.machineId
+			}
+			if machineId == "" || (hostId != "" && TopParentId(machineId) != hostId) {
+				return errors.Errorf(
+					"charm %q store %q: units sharing storage from machine-scoped pool %q "+
+						"must all be placed on the same machine, or its containers",
+					charmMeta.Name, name, cons.Pool,
+				)
+			}
+			hostId = TopParentId(machineId)
+		}
 	}
 	return nil
 }
 
+// supportsSharedFilesystems reports whether or not the given storage
+// provider supports filesystems that are attached to multiple machines.
+func supportsSharedFilesystems(provider storage.Provider) bool {
+	shared, ok := provider.(storage.SharedFilesystemProvider)
+	return ok && shared.SupportsSharedFilesystems()
+}
+
+// supportsContainerPassthrough reports whether or not the given storage
+// provider supports passing storage on a host machine through to the
+// host's containers.
+func supportsContainerPassthrough(provider storage.Provider) bool {
+	passthrough, ok := provider.(storage.ContainerPassthroughProvider)
+	return ok && passthrough.SupportsContainerPassthrough()
+}
+
+// hostScopedStorage reports whether or not machine-scoped storage of the
+// given kind, from the given storage provider, is scoped to the top-level
+// host machine rather than to the machine it is attached to.
+func hostScopedStorage(provider storage.Provider, kind storage.StorageKind) bool {
+	if kind == storage.StorageKindFilesystem && supportsSharedFilesystems(provider) {
+		return true
+	}
+	return supportsContainerPassthrough(provider)
+}
+
 // validateStoragePool validates the storage pool for the model.
 // If machineId is non-nil, the storage scope will be validated against
 // the machineId; if the storage is not machine-scoped, then the machineId
@@ -903,6 +1164,13 @@ func validateStoragePool(
 			if *machineId == "" {
 				return errors.Annotate(err, "machine unspecified for machine-scoped storage")
 			}
+			if hostScopedStorage(provider, kind) {
+				// Shared filesystems, and storage passed
+				// through to containers, are scoped to the
+				// host machine, which manages the storage
+				// and its attachments to the host's containers.
+				*machineId = TopParentId(*machineId)
+			}
 		default:
 			// The storage is not machine-scoped, so we clear out
 			// the machine ID to inform the caller that the storage
@@ -966,17 +1234,7 @@ func addDefaultStorageConstraints(st *State, allCons map[string]StorageConstrain
 	}
 
 	for name, charmStorage := range charmMeta.Storage {
-		cons, ok := allCons[name]
-		if !ok {
-			if charmStorage.Shared {
-				// TODO(axw) get the model's default shared storage
-				// pool, and create constraints here.
-				return errors.Errorf(
-					"no constraints specified for shared charm storage %q",
-					name,
-				)
-			}
-		}
+		cons := allCons[name]
 		cons, err := storageConstraintsWithDefaults(conf, charmStorage, name, cons)
 		if err != nil {
 			return errors.Trace(err)
@@ -998,7 +1256,11 @@ func storageConstraintsWithDefaults(
 	withDefaults := cons
 
 	// If no pool is specified, determine the pool from the env config and other constraints.
-	if cons.Pool == "" {
+	if cons.Pool == "" && charmStorage.Shared {
+		// Shared storage defaults to directories on the host
+		// machine, which may be shared with its containers.
+		withDefaults.Pool = string(provider.HostDirProviderType)
+	} else if cons.Pool == "" {
 		kind := storageKind(charmStorage.Type)
 		poolName, err := defaultStoragePool(cfg, kind, cons)
 		if err != nil {
@@ -1101,6 +1363,24 @@ func (st *State) addStorageForUnit(
 	if !exists {
 		return errors.NotFoundf("charm storage %q", name)
 	}
+	if ch.Meta().Storage[name].Shared {
+		// Shared storage is created along with the application,
+		// and attached to each of its units.
+		return errors.NotSupportedf("adding shared storage %q to unit", name)
+	}
+
+	var snapshot *volumeSnapshot
+	if cons.Snapshot != "" {
+		snapshot, err = st.volumeSnapshotForUnitStorage(ch, u, name, &cons)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	app, err := u.Application()
+	if err != nil {
+		return errors.Trace(err)
+	}
 
 	// Populate missing configuration parameters with default values.
 	conf, err := st.ModelConfig()
@@ -1136,10 +1416,25 @@ func (st *State) addStorageForUnit(
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
+		requested := requestedStorageUsage(
+			ch.Meta(), map[string]StorageConstraints{name: completeCons}, 1, false,
+		)
+		quotaOps, err := validateStorageQuotas(st, app, requested)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
 		ops, err := st.constructAddUnitStorageOps(ch, u, name, completeCons)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
+		ops = append(ops, quotaOps...)
+		if snapshot != nil {
+			ops = append(ops, txn.Op{
+				C:      volumeSnapshotsC,
+				Id:     snapshot.Id(),
+				Assert: isAliveDoc,
+			})
+		}
 		return ops, nil
 	}
 	if err := st.run(buildTxn); err != nil {
@@ -1148,6 +1443,61 @@ func (st *State) addStorageForUnit(
 	return nil
 }
 
+// volumeSnapshotForUnitStorage validates that the volume snapshot named
+// in the supplied storage constraints can be used to create storage for
+// the unit, and fills in the constraints' pool and size from the snapshot
+// where they are not specified.
+func (st *State) volumeSnapshotForUnitStorage(
+	ch *Charm, u *Unit, name string, cons *StorageConstraints,
+) (*volumeSnapshot, error) {
+	if charmStorage := ch.Meta().Storage[name]; charmStorage.Type != charm.StorageBlock {
+		return nil, errors.NotValidf("creating %s storage %q from a volume snapshot", charmStorage.Type, name)
+	}
+	snapshot, err := st.volumeSnapshot(cons.Snapshot)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if snapshot.Life() != Alive {
+		return nil, errors.Errorf("volume snapshot %q is not alive", snapshot.Id())
+	}
+	info, err := snapshot.Info()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if cons.Pool == "" {
+		cons.Pool = snapshot.Pool()
+	} else if cons.Pool != snapshot.Pool() {
+		return nil, errors.Errorf(
+			"cannot create storage in pool %q from snapshot %q in pool %q",
+			cons.Pool, snapshot.Id(), snapshot.Pool(),
+		)
+	}
+	if cons.Size == 0 {
+		cons.Size = info.Size
+	} else if cons.Size < info.Size {
+		return nil, errors.Errorf(
+			"size %dM is smaller than snapshot %q size %dM",
+			cons.Size, snapshot.Id(), info.Size,
+		)
+	}
+	// Snapshots of machine-scoped volumes are only available
+	// on the machine that the volume was scoped to.
+	volumeId := snapshot.Volume().Id()
+	if slash := strings.LastIndex(volumeId, "/"); slash != -1 {
+		machineId, err := u.AssignedMachineId()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if snapshotMachineId := volumeId[:slash]; machineId != snapshotMachineId {
+			return nil, errors.Errorf(
+				"volume snapshot %q is only available on machine %s",
+				snapshot.Id(), snapshotMachineId,
+			)
+		}
+	}
+	return snapshot, nil
+}
+
 func (st *State) validateUnitStorage(
 	charmMeta *charm.Meta, u *Unit, name string, cons StorageConstraints,
 ) error {
@@ -0,0 +1,480 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// DetachStorage ensures that the storage attachment between the specified
+// storage instance and unit will be removed at some point. Unlike
+// DestroyStorageAttachment, the storage instance is not removed along
+// with the attachment; it is left in the model without an owner, so
+// that it may later be attached to another unit with AttachStorage.
+//
+// Only storage that is not scoped to a machine may be detached, and the
+// unit must be left with at least as many instances of the storage as
+// its charm requires.
+func (st *State) DetachStorage(storage names.StorageTag, unit names.UnitTag) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot detach storage %s from unit %s", storage.Id(), unit.Id())
+	if err := st.validateStorageNotMachineScoped(storage); err != nil {
+		return errors.Trace(err)
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		u, err := st.Unit(unit.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ch, err := unitCharm(u)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		s, err := st.storageInstance(storage)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if s.doc.Life != Alive {
+			return nil, errors.New("storage is not alive")
+		}
+		a, err := st.storageAttachment(storage, unit)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if a.doc.Life != Alive {
+			return nil, jujutxn.ErrNoOperations
+		}
+		if s.doc.Owner != unit.String() {
+			return nil, errors.New("storage is shared, and cannot be detached")
+		}
+
+		// Detaching the storage must not leave the unit with
+		// fewer instances of the storage than the charm requires.
+		owned, err := st.storageInstances(bson.D{
+			{"owner", unit.String()},
+			{"storagename", s.doc.StorageName},
+		})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		charmStorage := ch.Meta().Storage[s.doc.StorageName]
+		if len(owned) <= charmStorage.CountMin {
+			return nil, errors.Errorf(
+				"charm %q requires at least %d instance(s) of storage %q",
+				ch.Meta().Name, charmStorage.CountMin, s.doc.StorageName,
+			)
+		}
+
+		ops := destroyStorageAttachmentOps(storage, unit)
+		ops = append(ops, txn.Op{
+			C:  unitsC,
+			Id: u.doc.DocID,
+			Assert: bson.D{
+				{"charmurl", u.doc.CharmURL},
+				{"storageattachmentcount", u.doc.StorageAttachmentCount},
+			},
+		}, txn.Op{
+			C:      storageInstancesC,
+			Id:     s.doc.Id,
+			Assert: bson.D{{"life", Alive}, {"owner", unit.String()}},
+			Update: bson.D{{"$set", bson.D{{"owner", ""}}}},
+		})
+		// The other instances of the storage must remain attached,
+		// so that concurrent detachments cannot together take the
+		// unit below the charm's minimum.
+		for _, other := range owned {
+			if other.doc.Id == s.doc.Id {
+				continue
+			}
+			ops = append(ops, txn.Op{
+				C:      storageInstancesC,
+				Id:     other.doc.Id,
+				Assert: bson.D{{"owner", unit.String()}},
+			})
+		}
+		return ops, nil
+	}
+	return st.run(buildTxn)
+}
+
+// AttachStorage attaches the specified storage instance, which must not
+// be owned by any entity, to the specified unit. The storage instance's
+// volume or filesystem will be attached to the unit's assigned machine,
+// and the unit will become the owner of the storage instance.
+func (st *State) AttachStorage(storage names.StorageTag, unit names.UnitTag) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot attach storage %s to unit %s", storage.Id(), unit.Id())
+	if err := st.validateStorageNotMachineScoped(storage); err != nil {
+		return errors.Trace(err)
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := st.storageInstance(storage)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if s.doc.Life != Alive {
+			return nil, errors.New("storage is not alive")
+		}
+		if owner, ok := s.Owner(); ok {
+			return nil, errors.Errorf("storage is attached to %s", names.ReadableString(owner))
+		}
+		if s.doc.AttachmentCount > 0 {
+			return nil, errors.New("storage is still being detached")
+		}
+
+		u, err := st.Unit(unit.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if u.Life() != Alive {
+			return nil, unitNotAliveErr
+		}
+		m, err := u.machine()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ch, err := unitCharm(u)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		charmStorage, ok := ch.Meta().Storage[s.doc.StorageName]
+		if !ok {
+			return nil, errors.NotFoundf("charm storage %q", s.doc.StorageName)
+		}
+		if err := validateAttachStorageKind(s, charmStorage); err != nil {
+			return nil, errors.Trace(err)
+		}
+		count, err := st.countEntityStorageInstancesForName(unit, s.doc.StorageName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if charmStorage.CountMax >= 0 && count >= uint64(charmStorage.CountMax) {
+			return nil, errors.Errorf(
+				"charm %q allows at most %d instance(s) of storage %q",
+				ch.Meta().Name, charmStorage.CountMax, s.doc.StorageName,
+			)
+		}
+		pool, size, err := storageInstancePoolAndSize(st, &s.doc)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		var quotaOps []txn.Op
+		if pool != "" {
+			app, err := u.Application()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			requested := map[string]StorageUsage{
+				pool: {Count: 1, Size: size},
+			}
+			quotaOps, err = validateApplicationStorageQuota(st, app, requested)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+
+		machineParams := &machineStorageParams{
+			volumeAttachments:     make(map[names.VolumeTag]VolumeAttachmentParams),
+			filesystemAttachments: make(map[names.FilesystemTag]FilesystemAttachmentParams),
+		}
+		var ops []txn.Op
+		var volumeAttachments []volumeAttachmentTemplate
+		var filesystemAttachments []filesystemAttachmentTemplate
+		attachVolume := func(v *volume, readOnly bool) error {
+			if v.doc.Life != Alive {
+				return errors.Errorf("volume %s is not alive", v.doc.Name)
+			}
+			if v.doc.AttachmentCount > 0 {
+				return errors.Errorf("volume %s is still attached", v.doc.Name)
+			}
+			params := VolumeAttachmentParams{readOnly}
+			machineParams.volumeAttachments[v.VolumeTag()] = params
+			volumeAttachments = append(volumeAttachments, volumeAttachmentTemplate{
+				v.VolumeTag(), params,
+			})
+			ops = append(ops, txn.Op{
+				C:      volumesC,
+				Id:     v.doc.Name,
+				Assert: append(bson.D{{"attachmentcount", 0}}, isAliveDoc...),
+				Update: bson.D{{"$inc", bson.D{{"attachmentcount", 1}}}},
+			})
+			return nil
+		}
+		switch s.doc.Kind {
+		case StorageKindBlock:
+			v, err := st.storageInstanceVolume(storage)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if err := attachVolume(v, charmStorage.ReadOnly); err != nil {
+				return nil, errors.Trace(err)
+			}
+		case StorageKindFilesystem:
+			location, err := filesystemMountPoint(charmStorage, storage, u.Series())
+			if err != nil {
+				return nil, errors.Annotatef(
+					err, "getting filesystem mount point for storage %s",
+					s.doc.StorageName,
+				)
+			}
+			params := FilesystemAttachmentParams{
+				charmStorage.Location == "", // auto-generated location
+				location,
+				charmStorage.ReadOnly,
+			}
+			var filesystemTag names.FilesystemTag
+			f, err := st.storageInstanceFilesystem(storage)
+			if errors.IsNotFound(err) {
+				// Storage imported along with a backing volume
+				// has no filesystem until it is first attached,
+				// as the filesystem is managed on the machine.
+				v, err := st.storageInstanceVolume(storage)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				if err := attachVolume(v, charmStorage.ReadOnly); err != nil {
+					return nil, errors.Trace(err)
+				}
+				filesystemOps, tag, err := st.addImportedFilesystemOps(storage, v, m.Id())
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				ops = append(ops, filesystemOps...)
+				filesystemTag = tag
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			} else {
+				if f.doc.Life != Alive {
+					return nil, errors.Errorf("filesystem %s is not alive", f.doc.FilesystemId)
+				}
+				if f.doc.AttachmentCount > 0 {
+					return nil, errors.Errorf("filesystem %s is still attached", f.doc.FilesystemId)
+				}
+				if f.doc.VolumeId != "" {
+					// The filesystem requires a volume, so
+					// attach the volume too.
+					v, err := st.volumeByTag(names.NewVolumeTag(f.doc.VolumeId))
+					if err != nil {
+						return nil, errors.Trace(err)
+					}
+					if err := attachVolume(v, charmStorage.ReadOnly); err != nil {
+						return nil, errors.Trace(err)
+					}
+				}
+				machineParams.filesystemAttachments[f.FilesystemTag()] = params
+				ops = append(ops, txn.Op{
+					C:      filesystemsC,
+					Id:     f.doc.FilesystemId,
+					Assert: append(bson.D{{"attachmentcount", 0}}, isAliveDoc...),
+					Update: bson.D{{"$inc", bson.D{{"attachmentcount", 1}}}},
+				})
+				filesystemTag = f.FilesystemTag()
+			}
+			filesystemAttachments = append(filesystemAttachments, filesystemAttachmentTemplate{
+				filesystemTag, storage, params,
+			})
+		default:
+			return nil, errors.Errorf("invalid storage kind %v", s.doc.Kind)
+		}
+		ops = append(ops, createMachineVolumeAttachmentsOps(m.Id(), volumeAttachments)...)
+		ops = append(ops, createMachineFilesystemAttachmentsOps(m.Id(), filesystemAttachments)...)
+		if err := validateDynamicMachineStorageParams(m, machineParams); err != nil {
+			return nil, errors.Trace(err)
+		}
+		machineOps, err := addMachineStorageAttachmentsOps(
+			m, volumeAttachments, filesystemAttachments,
+		)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, machineOps...)
+		ops = append(ops,
+			createStorageAttachmentOp(storage, unit),
+			txn.Op{
+				C:  storageInstancesC,
+				Id: s.doc.Id,
+				Assert: bson.D{
+					{"life", Alive},
+					{"owner", ""},
+					{"attachmentcount", 0},
+				},
+				Update: bson.D{
+					{"$set", bson.D{{"owner", unit.String()}}},
+					{"$inc", bson.D{{"attachmentcount", 1}}},
+				},
+			},
+			txn.Op{
+				C:      unitsC,
+				Id:     u.doc.Name,
+				Assert: append(bson.D{{"charmurl", u.doc.CharmURL}}, isAliveDoc...),
+				Update: bson.D{{"$inc", bson.D{{"storageattachmentcount", 1}}}},
+			},
+		)
+		ops = append(ops, quotaOps...)
+		return ops, nil
+	}
+	return st.run(buildTxn)
+}
+
+// detachStorageMachineOps returns txn.Ops to detach the volume or
+// filesystem of a detached storage instance from the machine that the
+// specified unit is assigned to. The volume or filesystem is left in
+// the model, bound to the storage instance.
+func detachStorageMachineOps(st *State, si *storageInstance, unit names.UnitTag) ([]txn.Op, error) {
+	u, err := st.Unit(unit.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machineId, err := u.AssignedMachineId()
+	if errors.IsNotAssigned(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machineTag := names.NewMachineTag(machineId)
+	switch si.doc.Kind {
+	case StorageKindBlock:
+		v, err := st.storageInstanceVolume(si.StorageTag())
+		if errors.IsNotFound(err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		va, err := st.VolumeAttachment(machineTag, v.VolumeTag())
+		if errors.IsNotFound(err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if va.Life() != Alive {
+			return nil, nil
+		}
+		return detachVolumeOps(machineTag, v.VolumeTag()), nil
+	case StorageKindFilesystem:
+		f, err := st.storageInstanceFilesystem(si.StorageTag())
+		if errors.IsNotFound(err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		fa, err := st.FilesystemAttachment(machineTag, f.FilesystemTag())
+		if errors.IsNotFound(err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if fa.Life() != Alive {
+			return nil, nil
+		}
+		return detachFilesystemOps(machineTag, f.FilesystemTag()), nil
+	}
+	return nil, errors.Errorf("invalid storage kind %v", si.doc.Kind)
+}
+
+// detachSharedStorageMachineOps returns txn.Ops for detaching a shared
+// storage instance's filesystem from the machine that the specified unit
+// is assigned to, unless another unit attached to the storage instance is
+// assigned to the same machine.
+func detachSharedStorageMachineOps(st *State, si *storageInstance, unit names.UnitTag) ([]txn.Op, error) {
+	u, err := st.Unit(unit.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machineId, err := u.AssignedMachineId()
+	if errors.IsNotAssigned(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	attachments, err := st.StorageAttachments(si.StorageTag())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, a := range attachments {
+		if a.Unit() == unit {
+			continue
+		}
+		other, err := st.Unit(a.Unit().Id())
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		otherMachineId, err := other.AssignedMachineId()
+		if errors.IsNotAssigned(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if otherMachineId == machineId {
+			return nil, nil
+		}
+	}
+	return detachStorageMachineOps(st, si, unit)
+}
+
+// validateStorageNotMachineScoped returns an error if the volume or
+// filesystem assigned to the specified storage instance is scoped to
+// a machine, and so cannot outlive the machine or be moved to another.
+func (st *State) validateStorageNotMachineScoped(tag names.StorageTag) error {
+	if v, err := st.storageInstanceVolume(tag); err == nil {
+		if _, ok := names.VolumeMachine(v.VolumeTag()); ok {
+			return errors.NotSupportedf("machine-scoped volume %s", v.doc.Name)
+		}
+	} else if !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	if f, err := st.storageInstanceFilesystem(tag); err == nil {
+		if _, ok := names.FilesystemMachine(f.FilesystemTag()); ok {
+			return errors.NotSupportedf("machine-scoped filesystem %s", f.doc.FilesystemId)
+		}
+	} else if !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// validateAttachStorageKind returns an error if the storage instance is
+// not of the kind required by the charm storage it is to be attached as.
+func validateAttachStorageKind(s *storageInstance, charmStorage charm.Storage) error {
+	var kind StorageKind
+	switch charmStorage.Type {
+	case charm.StorageBlock:
+		kind = StorageKindBlock
+	case charm.StorageFilesystem:
+		kind = StorageKindFilesystem
+	}
+	if s.doc.Kind != kind {
+		return errors.Errorf(
+			"storage kind %v does not match charm storage %q type %q",
+			s.doc.Kind, charmStorage.Name, charmStorage.Type,
+		)
+	}
+	return nil
+}
+
+// unitCharm returns the charm that the unit is running. If the unit has
+// not yet set its charm URL, the charm of the unit's application is
+// returned, as that is the charm the unit will run.
+func unitCharm(u *Unit) (*Charm, error) {
+	if curl, ok := u.CharmURL(); ok {
+		ch, err := u.st.Charm(curl)
+		if err != nil {
+			return nil, errors.Annotatef(err, "getting charm for unit %q", u.Tag().Id())
+		}
+		return ch, nil
+	}
+	app, err := u.Application()
+	if err != nil {
+		return nil, errors.Annotatef(err, "getting application for unit %v", u.Tag().Id())
+	}
+	ch, _, err := app.Charm()
+	if err != nil {
+		return nil, errors.Annotatef(err, "getting charm for unit %q", u.Tag().Id())
+	}
+	return ch, nil
+}
@@ -0,0 +1,245 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+)
+
+type StorageAttachSuite struct {
+	StorageStateSuiteBase
+}
+
+var _ = gc.Suite(&StorageAttachSuite{})
+
+// setupDetachableStorage adds an assigned unit of the storage-block
+// charm, with an instance of the optional "allecto" storage backed by
+// a model-scoped volume, and returns the unit and storage tag.
+func (s *StorageAttachSuite) setupDetachableStorage(c *gc.C) (*state.Application, *state.Unit, names.StorageTag) {
+	ch := s.AddTestingCharm(c, "storage-block")
+	storage := map[string]state.StorageConstraints{
+		"data":    makeStorageCons("loop-pool", 1024, 1),
+		"allecto": makeStorageCons("persistent-block", 1024, 1),
+	}
+	app := s.AddTestingServiceWithStorage(c, "storage-block", ch, storage)
+	u := s.addAssignedUnit(c, app)
+
+	attachments, err := s.State.UnitStorageAttachments(u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	for _, a := range attachments {
+		si, err := s.State.StorageInstance(a.StorageInstance())
+		c.Assert(err, jc.ErrorIsNil)
+		if si.StorageName() == "allecto" {
+			return app, u, si.StorageTag()
+		}
+	}
+	c.Fatalf("allecto storage not found")
+	panic("unreachable")
+}
+
+func (s *StorageAttachSuite) addAssignedUnit(c *gc.C, app *state.Application) *state.Unit {
+	u, err := app.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	return u
+}
+
+// detachStorage detaches the storage from the unit, and removes the
+// resulting dying storage and volume attachments, as the uniter and
+// storage provisioner would.
+func (s *StorageAttachSuite) detachStorage(c *gc.C, u *state.Unit, storageTag names.StorageTag) {
+	err := s.State.DetachStorage(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RemoveStorageAttachment(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	machineId, err := u.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machineTag := names.NewMachineTag(machineId)
+	volume := s.storageInstanceVolume(c, storageTag)
+	attachment := s.volumeAttachment(c, machineTag, volume.VolumeTag())
+	c.Assert(attachment.Life(), gc.Equals, state.Dying)
+	err = s.State.RemoveVolumeAttachment(machineTag, volume.VolumeTag())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *StorageAttachSuite) TestDetachStorage(c *gc.C) {
+	_, u, storageTag := s.setupDetachableStorage(c)
+	err := s.State.DetachStorage(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	attachment, err := s.State.StorageAttachment(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachment.Life(), gc.Equals, state.Dying)
+	si, err := s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := si.Owner()
+	c.Assert(ok, jc.IsFalse)
+
+	// Removing the attachment leaves the storage instance and its
+	// volume in the model, and detaches the volume from the machine.
+	s.detachStorage(c, u, storageTag)
+	si, err = s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(si.Life(), gc.Equals, state.Alive)
+	volume := s.storageInstanceVolume(c, storageTag)
+	c.Assert(volume.Life(), gc.Equals, state.Alive)
+}
+
+func (s *StorageAttachSuite) TestDetachStorageMachineScoped(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.DetachStorage(storageTag, u.UnitTag())
+	c.Assert(err, gc.ErrorMatches, `cannot detach storage data/0 from unit storage-block/0: machine-scoped volume 0/0 not supported`)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *StorageAttachSuite) TestDetachStorageCountMin(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "persistent-block")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.DetachStorage(storageTag, u.UnitTag())
+	c.Assert(err, gc.ErrorMatches, `cannot detach storage data/0 from unit storage-block/0: charm "storage-block" requires at least 1 instance\(s\) of storage "data"`)
+}
+
+func (s *StorageAttachSuite) TestDetachStorageCountMinConcurrent(c *gc.C) {
+	ch := s.AddTestingCharm(c, "storage-block2")
+	app := s.AddTestingServiceWithStorage(c, "storage-block2", ch, map[string]state.StorageConstraints{
+		"multi1to10": makeStorageCons("persistent-block", 1024, 2),
+	})
+	u := s.addAssignedUnit(c, app)
+	attachments, err := s.State.UnitStorageAttachments(u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	var storageTags []names.StorageTag
+	for _, a := range attachments {
+		if name, _ := names.StorageName(a.StorageInstance().Id()); name == "multi1to10" {
+			storageTags = append(storageTags, a.StorageInstance())
+		}
+	}
+	c.Assert(storageTags, gc.HasLen, 2)
+
+	defer state.SetBeforeHooks(c, s.State, func() {
+		err := s.State.DetachStorage(storageTags[1], u.UnitTag())
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	err = s.State.DetachStorage(storageTags[0], u.UnitTag())
+	c.Assert(err, gc.ErrorMatches, `cannot detach storage multi1to10/[0-9]+ from unit storage-block2/0: charm "storage-block2" requires at least 1 instance\(s\) of storage "multi1to10"`)
+}
+
+func (s *StorageAttachSuite) TestAttachStorage(c *gc.C) {
+	app, u, storageTag := s.setupDetachableStorage(c)
+	s.detachStorage(c, u, storageTag)
+
+	u2 := s.addAssignedUnit(c, app)
+	err := s.State.AttachStorage(storageTag, u2.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	si, err := s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	owner, ok := si.Owner()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(owner, gc.Equals, u2.Tag())
+	attachment, err := s.State.StorageAttachment(storageTag, u2.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachment.Life(), gc.Equals, state.Alive)
+
+	machineId, err := u2.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	volume := s.storageInstanceVolume(c, storageTag)
+	volumeAttachment := s.volumeAttachment(c, names.NewMachineTag(machineId), volume.VolumeTag())
+	c.Assert(volumeAttachment.Life(), gc.Equals, state.Alive)
+}
+
+func (s *StorageAttachSuite) TestAttachStorageFilesystemWithBackingVolume(c *gc.C) {
+	app, u, _ := s.setupSingleStorage(c, "filesystem", "rootfs")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	storageTag, err := s.State.AddExistingFilesystem(
+		state.FilesystemInfo{Pool: "persistent-block"},
+		&state.VolumeInfo{VolumeId: "vol-123", Pool: "persistent-block", Size: 2048},
+		"data",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AttachStorage(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Detach the storage, removing the filesystem and volume
+	// attachments as the storage provisioner would.
+	err = s.State.DetachStorage(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RemoveStorageAttachment(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	machineId, err := u.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machineTag := names.NewMachineTag(machineId)
+	filesystem := s.storageInstanceFilesystem(c, storageTag)
+	volume := s.storageInstanceVolume(c, storageTag)
+	err = s.State.RemoveFilesystemAttachment(machineTag, filesystem.FilesystemTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RemoveVolumeAttachment(machineTag, volume.VolumeTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Attaching the storage to a unit on another machine
+	// attaches both the filesystem and its backing volume.
+	u2 := s.addAssignedUnit(c, app)
+	err = s.State.AttachStorage(storageTag, u2.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	machineId2, err := u2.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machineId2, gc.Not(gc.Equals), machineId)
+	machineTag2 := names.NewMachineTag(machineId2)
+	filesystemAttachment := s.filesystemAttachment(c, machineTag2, filesystem.FilesystemTag())
+	c.Assert(filesystemAttachment.Life(), gc.Equals, state.Alive)
+	volumeAttachment := s.volumeAttachment(c, machineTag2, volume.VolumeTag())
+	c.Assert(volumeAttachment.Life(), gc.Equals, state.Alive)
+}
+
+func (s *StorageAttachSuite) TestAttachStorageOwned(c *gc.C) {
+	app, _, storageTag := s.setupDetachableStorage(c)
+	u2 := s.addAssignedUnit(c, app)
+	err := s.State.AttachStorage(storageTag, u2.UnitTag())
+	c.Assert(err, gc.ErrorMatches, `cannot attach storage allecto/\d+ to unit storage-block/1: storage is attached to unit storage-block/0`)
+}
+
+func (s *StorageAttachSuite) TestAttachStorageStillDetaching(c *gc.C) {
+	app, u, storageTag := s.setupDetachableStorage(c)
+	err := s.State.DetachStorage(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	u2 := s.addAssignedUnit(c, app)
+	err = s.State.AttachStorage(storageTag, u2.UnitTag())
+	c.Assert(err, gc.ErrorMatches, `cannot attach storage allecto/\d+ to unit storage-block/1: storage is still being detached`)
+}
+
+func (s *StorageAttachSuite) TestReleaseStorageInstance(c *gc.C) {
+	_, u, storageTag := s.setupDetachableStorage(c)
+	s.detachStorage(c, u, storageTag)
+	volumeTag := s.storageInstanceVolume(c, storageTag).VolumeTag()
+
+	err := s.State.ReleaseStorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// The volume is Dead, and marked for release
+	// rather than destruction.
+	volume := s.volume(c, volumeTag)
+	c.Assert(volume.Life(), gc.Equals, state.Dead)
+	c.Assert(volume.Releasing(), jc.IsTrue)
+}
+
+func (s *StorageAttachSuite) TestReleaseStorageInstanceMachineScoped(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.ReleaseStorageInstance(storageTag)
+	c.Assert(err, gc.ErrorMatches, `cannot release storage "data/0": machine-scoped volume 0/0 not supported`)
+}
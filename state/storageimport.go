@@ -0,0 +1,294 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/storage"
+)
+
+// AddExistingFilesystem imports an existing, already-provisioned
+// filesystem into the model. A new storage instance with the specified
+// storage name is added for the filesystem; the storage instance is not
+// owned by any entity, and may subsequently be attached to a unit with
+// AttachStorage.
+//
+// If backingVolume is non-nil, the filesystem is one managed by Juju
+// on the specified existing volume, which is imported along with it.
+// Because such a filesystem is managed on the machine that the volume
+// is attached to, only the volume is recorded by AddExistingFilesystem;
+// the filesystem is recorded when the storage is first attached.
+//
+// If the filesystem, or its backing volume, is already in the model,
+// then an error satisfying errors.IsAlreadyExists is returned.
+func (st *State) AddExistingFilesystem(
+	info FilesystemInfo,
+	backingVolume *VolumeInfo,
+	storageName string,
+) (_ names.StorageTag, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot add existing filesystem")
+	if err := validateAddExistingFilesystem(st, info, backingVolume, storageName); err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	storageId, err := newStorageInstanceId(st, storageName)
+	if err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	storageTag := names.NewStorageTag(storageId)
+	size := info.Size
+	if backingVolume != nil {
+		size = backingVolume.Size
+	}
+	ops := []txn.Op{{
+		C:      storageInstancesC,
+		Id:     storageId,
+		Assert: txn.DocMissing,
+		Insert: &storageInstanceDoc{
+			Id:          storageId,
+			Kind:        StorageKindFilesystem,
+			StorageName: storageName,
+			Pool:        info.Pool,
+			Size:        size,
+		},
+	}}
+	if backingVolume != nil {
+		volumeOps, err := st.addExistingVolumeOps(*backingVolume, storageTag)
+		if err != nil {
+			return names.StorageTag{}, errors.Trace(err)
+		}
+		ops = append(ops, volumeOps...)
+	} else {
+		filesystemOps, err := st.addExistingFilesystemOps(info, storageTag)
+		if err != nil {
+			return names.StorageTag{}, errors.Trace(err)
+		}
+		ops = append(ops, filesystemOps...)
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		// The provider ID is recorded in the transaction, so
+		// concurrent imports of the same storage cannot both
+		// succeed; storage that was provisioned by the model
+		// has no such record, and is checked for here.
+		if err := checkImportedStorageUnique(st, info, backingVolume); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return ops, nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	return storageTag, nil
+}
+
+// checkImportedStorageUnique returns an error satisfying
+// errors.IsAlreadyExists if the volume or filesystem being imported
+// is already in the model.
+func checkImportedStorageUnique(st *State, info FilesystemInfo, backingVolume *VolumeInfo) error {
+	collection, field, providerId := filesystemsC, "info.filesystemid", info.FilesystemId
+	kind := "filesystem"
+	if backingVolume != nil {
+		collection, field, providerId = volumesC, "info.volumeid", backingVolume.VolumeId
+		kind = "volume"
+	}
+	coll, closer := st.getCollection(collection)
+	defer closer()
+	n, err := coll.Find(bson.D{{field, providerId}}).Count()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if n == 0 {
+		idColl, closer := st.getCollection(providerIDsC)
+		defer closer()
+		n, err = idColl.FindId(storageProviderIdKey(st, kind, providerId)).Count()
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if n > 0 {
+		return errors.AlreadyExistsf("%s %q in the model", kind, providerId)
+	}
+	return nil
+}
+
+func validateAddExistingFilesystem(
+	st *State,
+	info FilesystemInfo,
+	backingVolume *VolumeInfo,
+	storageName string,
+) error {
+	if !names.IsValidStorageName(storageName) {
+		return errors.NotValidf("storage name %q", storageName)
+	}
+	if backingVolume != nil {
+		if backingVolume.VolumeId == "" {
+			return errors.NotValidf("backing volume info missing volume ID")
+		}
+		if backingVolume.Pool != info.Pool {
+			return errors.NotValidf(
+				"backing volume pool %q differing from filesystem pool %q",
+				backingVolume.Pool, info.Pool,
+			)
+		}
+	} else if info.FilesystemId == "" {
+		return errors.NotValidf("filesystem info missing filesystem ID")
+	}
+	if info.Pool == "" {
+		return errors.NotValidf("filesystem info missing pool")
+	}
+	providerType, provider, err := poolStorageProvider(st, info.Pool)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if provider.Scope() == storage.ScopeMachine {
+		return errors.NotSupportedf(
+			"importing storage from machine-scoped %q provider", providerType,
+		)
+	}
+	kind := storage.StorageKindFilesystem
+	if backingVolume != nil {
+		kind = storage.StorageKindBlock
+	}
+	if !provider.Supports(kind) {
+		return errors.Errorf("%q provider does not support %q storage", providerType, kind)
+	}
+	return nil
+}
+
+// addExistingFilesystemOps returns txn.Ops to add a model-scoped,
+// already-provisioned filesystem bound to the specified storage
+// instance.
+func (st *State) addExistingFilesystemOps(info FilesystemInfo, storage names.StorageTag) ([]txn.Op, error) {
+	filesystemId, err := newFilesystemId(st, "")
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot generate filesystem name")
+	}
+	return []txn.Op{
+		createStatusOp(st, filesystemGlobalKey(filesystemId), statusDoc{
+			Status:  status.StatusDetached,
+			Updated: GetClock().Now().UnixNano(),
+		}),
+		{
+			C:      filesystemsC,
+			Id:     filesystemId,
+			Assert: txn.DocMissing,
+			Insert: &filesystemDoc{
+				FilesystemId: filesystemId,
+				StorageId:    storage.Id(),
+				Binding:      storage.String(),
+				Info:         &info,
+			},
+		},
+		addStorageProviderIdOp(st, "filesystem", info.FilesystemId),
+	}, nil
+}
+
+// addExistingVolumeOps returns txn.Ops to add a model-scoped,
+// already-provisioned volume bound to the specified storage instance.
+func (st *State) addExistingVolumeOps(info VolumeInfo, storage names.StorageTag) ([]txn.Op, error) {
+	name, err := newVolumeName(st, "")
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot generate volume name")
+	}
+	return []txn.Op{
+		createStatusOp(st, volumeGlobalKey(name), statusDoc{
+			Status:  status.StatusDetached,
+			Updated: GetClock().Now().UnixNano(),
+		}),
+		{
+			C:      volumesC,
+			Id:     name,
+			Assert: txn.DocMissing,
+			Insert: &volumeDoc{
+				Name:      name,
+				StorageId: storage.Id(),
+				Binding:   storage.String(),
+				Info:      &info,
+			},
+		},
+		addStorageProviderIdOp(st, "volume", info.VolumeId),
+	}, nil
+}
+
+// storageProviderIdKey returns the providerIDs document ID recording
+// the provider ID of an imported volume or filesystem.
+func storageProviderIdKey(st *State, kind, providerId string) string {
+	return st.docID(kind + ":" + providerId)
+}
+
+// addStorageProviderIdOp returns a txn.Op that records the provider ID
+// of an imported volume or filesystem, asserting that the same cloud
+// storage has not already been imported.
+func addStorageProviderIdOp(st *State, kind, providerId string) txn.Op {
+	key := storageProviderIdKey(st, kind, providerId)
+	return txn.Op{
+		C:      providerIDsC,
+		Id:     key,
+		Assert: txn.DocMissing,
+		Insert: providerIdDoc{ID: key},
+	}
+}
+
+// removeStorageProviderIdOp returns a txn.Op that removes the record
+// of an imported volume's or filesystem's provider ID, if there is one,
+// so that the cloud storage may be imported again once it has been
+// removed from the model.
+func removeStorageProviderIdOp(st *State, kind, providerId string) txn.Op {
+	return txn.Op{
+		C:      providerIDsC,
+		Id:     storageProviderIdKey(st, kind, providerId),
+		Remove: true,
+	}
+}
+
+// addImportedFilesystemOps returns txn.Ops to add the filesystem for
+// storage imported with a backing volume, when the storage is first
+// attached to the specified machine. The filesystem already exists on
+// the volume, so it is recorded as provisioned.
+func (st *State) addImportedFilesystemOps(
+	storage names.StorageTag,
+	volume *volume,
+	machineId string,
+) ([]txn.Op, names.FilesystemTag, error) {
+	volumeInfo, err := volume.Info()
+	if err != nil {
+		return nil, names.FilesystemTag{}, errors.Trace(err)
+	}
+	// The filesystem is model-scoped like the volume, so that the
+	// storage may be detached and attached to other machines.
+	filesystemId, err := newFilesystemId(st, "")
+	if err != nil {
+		return nil, names.FilesystemTag{}, errors.Annotate(err, "cannot generate filesystem name")
+	}
+	filesystemTag := names.NewFilesystemTag(filesystemId)
+	ops := []txn.Op{
+		createStatusOp(st, filesystemGlobalKey(filesystemId), statusDoc{
+			Status:  status.StatusAttaching,
+			Updated: GetClock().Now().UnixNano(),
+		}),
+		{
+			C:      filesystemsC,
+			Id:     filesystemId,
+			Assert: txn.DocMissing,
+			Insert: &filesystemDoc{
+				FilesystemId: filesystemId,
+				VolumeId:     volume.doc.Name,
+				StorageId:    storage.Id(),
+				Binding:      storage.String(),
+				Info: &FilesystemInfo{
+					Size:         volumeInfo.Size,
+					Pool:         volumeInfo.Pool,
+					FilesystemId: filesystemTag.String(),
+				},
+				// The filesystem is created with one attachment.
+				AttachmentCount: 1,
+			},
+		},
+	}
+	return ops, filesystemTag, nil
+}
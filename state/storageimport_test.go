@@ -0,0 +1,256 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/testing"
+)
+
+type StorageImportSuite struct {
+	StorageStateSuiteBase
+}
+
+var _ = gc.Suite(&StorageImportSuite{})
+
+func (s *StorageImportSuite) TestAddExistingFilesystem(c *gc.C) {
+	info := state.FilesystemInfo{
+		FilesystemId: "fs-123",
+		Pool:         "environscoped",
+		Size:         1024,
+	}
+	storageTag, err := s.State.AddExistingFilesystem(info, nil, "data")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storageTag.Id(), gc.Matches, `data/\d+`)
+
+	si, err := s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(si.Kind(), gc.Equals, state.StorageKindFilesystem)
+	c.Assert(si.StorageName(), gc.Equals, "data")
+	_, ok := si.Owner()
+	c.Assert(ok, jc.IsFalse)
+
+	filesystem := s.storageInstanceFilesystem(c, storageTag)
+	_, ok = names.FilesystemMachine(filesystem.FilesystemTag())
+	c.Assert(ok, jc.IsFalse)
+	filesystemInfo, err := filesystem.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(filesystemInfo, jc.DeepEquals, info)
+}
+
+func (s *StorageImportSuite) TestAddExistingFilesystemWithBackingVolume(c *gc.C) {
+	volumeInfo := state.VolumeInfo{
+		VolumeId:   "vol-123",
+		Pool:       "persistent-block",
+		Size:       2048,
+		Persistent: true,
+	}
+	storageTag, err := s.State.AddExistingFilesystem(
+		state.FilesystemInfo{Pool: "persistent-block"}, &volumeInfo, "data",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Only the volume is recorded until the storage is attached.
+	_, err = s.State.StorageInstanceFilesystem(storageTag)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	volume := s.storageInstanceVolume(c, storageTag)
+	_, ok := names.VolumeMachine(volume.VolumeTag())
+	c.Assert(ok, jc.IsFalse)
+	info, err := volume.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, volumeInfo)
+}
+
+func (s *StorageImportSuite) TestAttachImportedFilesystem(c *gc.C) {
+	_, u, _ := s.setupSingleStorage(c, "filesystem", "rootfs")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+
+	storageTag, err := s.State.AddExistingFilesystem(state.FilesystemInfo{
+		FilesystemId: "fs-123",
+		Pool:         "environscoped",
+		Size:         1024,
+	}, nil, "data")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AttachStorage(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	machineId, err := u.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	filesystem := s.storageInstanceFilesystem(c, storageTag)
+	attachment := s.filesystemAttachment(c, names.NewMachineTag(machineId), filesystem.FilesystemTag())
+	c.Assert(attachment.Life(), gc.Equals, state.Alive)
+}
+
+func (s *StorageImportSuite) TestAttachImportedFilesystemWithBackingVolume(c *gc.C) {
+	_, u, _ := s.setupSingleStorage(c, "filesystem", "rootfs")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+
+	storageTag, err := s.State.AddExistingFilesystem(
+		state.FilesystemInfo{Pool: "persistent-block"},
+		&state.VolumeInfo{VolumeId: "vol-123", Pool: "persistent-block", Size: 2048},
+		"data",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AttachStorage(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The filesystem is created model-scoped like the volume,
+	// already provisioned, and the backing volume is attached
+	// to the unit's machine.
+	machineId, err := u.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machineTag := names.NewMachineTag(machineId)
+	filesystem := s.storageInstanceFilesystem(c, storageTag)
+	_, ok := names.FilesystemMachine(filesystem.FilesystemTag())
+	c.Assert(ok, jc.IsFalse)
+	info, err := filesystem.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, state.FilesystemInfo{
+		Size:         2048,
+		Pool:         "persistent-block",
+		FilesystemId: filesystem.FilesystemTag().String(),
+	})
+
+	volume := s.storageInstanceVolume(c, storageTag)
+	volumeTag, err := filesystem.Volume()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volumeTag, gc.Equals, volume.VolumeTag())
+	s.volumeAttachment(c, machineTag, volume.VolumeTag())
+	s.filesystemAttachment(c, machineTag, filesystem.FilesystemTag())
+}
+
+func (s *StorageImportSuite) TestWatchImportedFilesystemAttachments(c *gc.C) {
+	_, u, rootfsStorageTag := s.setupSingleStorage(c, "filesystem", "rootfs")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	machineId, err := u.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machineTag := names.NewMachineTag(machineId)
+	rootfs := s.storageInstanceFilesystem(c, rootfsStorageTag)
+
+	machineWatcher := s.State.WatchMachineFilesystemAttachments(machineTag)
+	defer testing.AssertStop(c, machineWatcher)
+	machineWC := testing.NewStringsWatcherC(c, s.State, machineWatcher)
+	machineWC.AssertChangeInSingleEvent(machineId + ":" + rootfs.Tag().Id()) // initial
+	modelWatcher := s.State.WatchEnvironFilesystemAttachments()
+	defer testing.AssertStop(c, modelWatcher)
+	modelWC := testing.NewStringsWatcherC(c, s.State, modelWatcher)
+	modelWC.AssertChangeInSingleEvent() // initial
+
+	storageTag, err := s.State.AddExistingFilesystem(
+		state.FilesystemInfo{Pool: "persistent-block"},
+		&state.VolumeInfo{VolumeId: "vol-123", Pool: "persistent-block", Size: 2048},
+		"data",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AttachStorage(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The model-scoped filesystem is backed by a volume, so it
+	// is managed by the machine that it is attached to.
+	filesystem := s.storageInstanceFilesystem(c, storageTag)
+	machineWC.AssertChangeInSingleEvent(machineId + ":" + filesystem.Tag().Id())
+	machineWC.AssertNoChange()
+	modelWC.AssertNoChange()
+}
+
+func (s *StorageImportSuite) TestAddExistingFilesystemAlreadyImported(c *gc.C) {
+	info := state.FilesystemInfo{
+		FilesystemId: "fs-123",
+		Pool:         "environscoped",
+		Size:         1024,
+	}
+	_, err := s.State.AddExistingFilesystem(info, nil, "data")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddExistingFilesystem(info, nil, "data")
+	c.Assert(err, gc.ErrorMatches, `cannot add existing filesystem: filesystem "fs-123" in the model already exists`)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *StorageImportSuite) TestAddExistingFilesystemWithBackingVolumeAlreadyImported(c *gc.C) {
+	volumeInfo := state.VolumeInfo{
+		VolumeId: "vol-123",
+		Pool:     "persistent-block",
+		Size:     2048,
+	}
+	info := state.FilesystemInfo{Pool: "persistent-block"}
+	_, err := s.State.AddExistingFilesystem(info, &volumeInfo, "data")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddExistingFilesystem(info, &volumeInfo, "data")
+	c.Assert(err, gc.ErrorMatches, `cannot add existing filesystem: volume "vol-123" in the model already exists`)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *StorageImportSuite) TestAddExistingFilesystemAlreadyProvisioned(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "filesystem", "environscoped")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	filesystem := s.storageInstanceFilesystem(c, storageTag)
+	err = s.State.SetFilesystemInfo(filesystem.FilesystemTag(), state.FilesystemInfo{
+		FilesystemId: "fs-123",
+		Pool:         "environscoped",
+		Size:         1024,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.AddExistingFilesystem(state.FilesystemInfo{
+		FilesystemId: "fs-123",
+		Pool:         "environscoped",
+	}, nil, "data")
+	c.Assert(err, gc.ErrorMatches, `cannot add existing filesystem: filesystem "fs-123" in the model already exists`)
+}
+
+func (s *StorageImportSuite) TestAddExistingFilesystemMachineScoped(c *gc.C) {
+	_, err := s.State.AddExistingFilesystem(state.FilesystemInfo{
+		FilesystemId: "fs-123",
+		Pool:         "machinescoped",
+	}, nil, "data")
+	c.Assert(err, gc.ErrorMatches, `cannot add existing filesystem: importing storage from machine-scoped "machinescoped" provider not supported`)
+}
+
+func (s *StorageImportSuite) TestAddExistingFilesystemInvalid(c *gc.C) {
+	for i, test := range []struct {
+		info          state.FilesystemInfo
+		backingVolume *state.VolumeInfo
+		storageName   string
+		err           string
+	}{{
+		info:        state.FilesystemInfo{FilesystemId: "fs-123", Pool: "environscoped"},
+		storageName: "0data",
+		err:         `storage name "0data" not valid`,
+	}, {
+		info:        state.FilesystemInfo{Pool: "environscoped"},
+		storageName: "data",
+		err:         `filesystem info missing filesystem ID not valid`,
+	}, {
+		info:        state.FilesystemInfo{FilesystemId: "fs-123"},
+		storageName: "data",
+		err:         `filesystem info missing pool not valid`,
+	}, {
+		info:          state.FilesystemInfo{Pool: "persistent-block"},
+		backingVolume: &state.VolumeInfo{Pool: "persistent-block"},
+		storageName:   "data",
+		err:           `backing volume info missing volume ID not valid`,
+	}, {
+		info:          state.FilesystemInfo{Pool: "persistent-block"},
+		backingVolume: &state.VolumeInfo{VolumeId: "vol-123", Pool: "environscoped"},
+		storageName:   "data",
+		err:           `backing volume pool "environscoped" differing from filesystem pool "persistent-block" not valid`,
+	}, {
+		info:        state.FilesystemInfo{FilesystemId: "fs-123", Pool: "persistent-block"},
+		storageName: "data",
+		err:         `"environscoped-block" provider does not support "filesystem" storage`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := s.State.AddExistingFilesystem(test.info, test.backingVolume, test.storageName)
+		c.Check(err, gc.ErrorMatches, "cannot add existing filesystem: "+test.err)
+	}
+}
@@ -0,0 +1,369 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/dustin/go-humanize"
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// StorageQuota limits the storage that may be provisioned from each
+// storage pool, either across a model or by the units of a single
+// application. A zero value for a field imposes no limit.
+type StorageQuota struct {
+	// Size is the maximum total size of storage, in MiB.
+	Size uint64 `bson:"size,omitempty"`
+
+	// Count is the maximum number of storage instances.
+	Count uint64 `bson:"count,omitempty"`
+}
+
+// StorageUsage describes the storage provisioned from a storage pool.
+type StorageUsage struct {
+	// Size is the total size of storage, in MiB.
+	Size uint64
+
+	// Count is the number of storage instances.
+	Count uint64
+}
+
+// ModelStorageQuota returns the storage quota for the model, as
+// defined by the "storage-quota-size" and "storage-quota-count"
+// model config attributes.
+func (st *State) ModelStorageQuota() (StorageQuota, error) {
+	cfg, err := st.ModelConfig()
+	if err != nil {
+		return StorageQuota{}, errors.Trace(err)
+	}
+	size, _ := cfg.StorageQuotaSize()
+	count, _ := cfg.StorageQuotaCount()
+	return StorageQuota{Size: size, Count: count}, nil
+}
+
+// StorageUsage returns the storage provisioned from each storage pool,
+// keyed by pool name. If applicationName is non-empty, only storage
+// owned by that application or its units is included.
+func (st *State) StorageUsage(applicationName string) (map[string]StorageUsage, error) {
+	coll, closer := st.getCollection(storageInstancesC)
+	defer closer()
+
+	var query bson.D
+	if applicationName != "" {
+		query = append(query, bson.DocElem{"owner", bson.RegEx{
+			Pattern: fmt.Sprintf(
+				"^(%s-%s-[0-9]+|%s)$",
+				names.UnitTagKind, applicationName,
+				names.NewApplicationTag(applicationName),
+			),
+		}})
+	}
+	var docs []storageInstanceDoc
+	if err := coll.Find(query).Select(bson.D{
+		{"id", 1}, {"storagekind", 1}, {"pool", 1}, {"size", 1},
+	}).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get storage usage")
+	}
+	usage := make(map[string]StorageUsage)
+	for _, doc := range docs {
+		pool, size, err := storageInstancePoolAndSize(st, &doc)
+		if err != nil {
+			return nil, errors.Annotate(err, "cannot get storage usage")
+		}
+		if pool == "" {
+			continue
+		}
+		poolUsage := usage[pool]
+		poolUsage.Count++
+		poolUsage.Size += size
+		usage[pool] = poolUsage
+	}
+	return usage, nil
+}
+
+// storageInstancePoolAndSize returns the pool from which the storage
+// instance was provisioned, and its size in MiB. Storage instances
+// added before the pool and size were recorded on them are accounted
+// for using their volume or filesystem; if that is gone too, the pool
+// is returned empty.
+func storageInstancePoolAndSize(st *State, doc *storageInstanceDoc) (string, uint64, error) {
+	if doc.Pool != "" {
+		return doc.Pool, doc.Size, nil
+	}
+	tag := names.NewStorageTag(doc.Id)
+	switch doc.Kind {
+	case StorageKindBlock:
+		v, err := st.storageInstanceVolume(tag)
+		if errors.IsNotFound(err) {
+			return "", 0, nil
+		} else if err != nil {
+			return "", 0, errors.Trace(err)
+		}
+		if info, err := v.Info(); err == nil {
+			return info.Pool, info.Size, nil
+		}
+		params, _ := v.Params()
+		return params.Pool, params.Size, nil
+	case StorageKindFilesystem:
+		f, err := st.storageInstanceFilesystem(tag)
+		if errors.IsNotFound(err) {
+			return "", 0, nil
+		} else if err != nil {
+			return "", 0, errors.Trace(err)
+		}
+		if info, err := f.Info(); err == nil {
+			return info.Pool, info.Size, nil
+		}
+		params, _ := f.Params()
+		return params.Pool, params.Size, nil
+	}
+	return "", 0, nil
+}
+
+// StorageQuota returns the application's storage quota.
+func (s *Application) StorageQuota() StorageQuota {
+	return s.doc.StorageQuota
+}
+
+// SetStorageQuota sets the application's storage quota. Storage that
+// has already been added to the application's units is unaffected,
+// even if it exceeds the new quota.
+func (s *Application) SetStorageQuota(quota StorageQuota) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set storage quota for application %q", s)
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     s.doc.DocID,
+		Assert: isAliveDoc,
+		Update: bson.D{{"$set", bson.D{{"storagequota", quota}}}},
+	}}
+	if err := s.st.runTransaction(ops); err != nil {
+		return onAbort(err, errNotAlive)
+	}
+	s.doc.StorageQuota = quota
+	return nil
+}
+
+// requestedStorageUsage returns the storage, per pool, that would be
+// provisioned by creating storage with the given constraints for n
+// units. Shared storage is created once for the application rather
+// than for each unit, and is included only if includeShared is true.
+func requestedStorageUsage(
+	charmMeta *charm.Meta,
+	cons map[string]StorageConstraints,
+	n uint64,
+	includeShared bool,
+) map[string]StorageUsage {
+	requested := make(map[string]StorageUsage)
+	for name, cons := range cons {
+		if cons.Count == 0 {
+			continue
+		}
+		instances := cons.Count * n
+		if charmMeta.Storage[name].Shared {
+			if !includeShared {
+				continue
+			}
+			instances = cons.Count
+		}
+		poolUsage := requested[cons.Pool]
+		poolUsage.Count += instances
+		poolUsage.Size += instances * cons.Size
+		requested[cons.Pool] = poolUsage
+	}
+	return requested
+}
+
+// errStorageUsageChanged is returned when storage is added to a pool
+// concurrently with an operation whose storage quota check relied on
+// the pool's previous usage.
+var errStorageUsageChanged = errors.New("storage usage changed concurrently")
+
+// validateStorageQuotas returns an error if provisioning the requested
+// storage would exceed the model's storage quota or, if app is non-nil,
+// the application's storage quota. If any quota applies, txn.Ops are
+// returned that ensure the storage provisioned from the requested pools
+// does not change before the storage is added.
+func validateStorageQuotas(st *State, app *Application, requested map[string]StorageUsage) ([]txn.Op, error) {
+	modelQuota, err := st.ModelStorageQuota()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var appQuota StorageQuota
+	if app != nil {
+		appQuota = app.StorageQuota()
+	}
+	if modelQuota == (StorageQuota{}) && appQuota == (StorageQuota{}) {
+		return nil, nil
+	}
+	// Read the pools' usage changes before their usage, so that any
+	// storage added after the usage is read will fail the asserts.
+	ops, err := storagePoolUsageOps(st, requested)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := checkStorageQuota(st, "model", modelQuota, "", requested); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if app != nil {
+		if err := checkStorageQuota(
+			st, fmt.Sprintf("application %q", app.Name()),
+			appQuota, app.Name(), requested,
+		); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return ops, nil
+}
+
+// validateApplicationStorageQuota returns an error if adding the
+// requested storage to the application's units would exceed the
+// application's storage quota. If the quota applies, txn.Ops are
+// returned that ensure the storage provisioned from the requested
+// pools does not change before the storage is added.
+func validateApplicationStorageQuota(st *State, app *Application, requested map[string]StorageUsage) ([]txn.Op, error) {
+	quota := app.StorageQuota()
+	if quota == (StorageQuota{}) {
+		return nil, nil
+	}
+	ops, err := storagePoolUsageOps(st, requested)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := checkStorageQuota(
+		st, fmt.Sprintf("application %q", app.Name()),
+		quota, app.Name(), requested,
+	); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return ops, nil
+}
+
+func checkStorageQuota(
+	st *State,
+	scope string,
+	quota StorageQuota,
+	applicationName string,
+	requested map[string]StorageUsage,
+) error {
+	if quota == (StorageQuota{}) {
+		return nil
+	}
+	usage, err := st.StorageUsage(applicationName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, pool := range sortedPools(requested) {
+		req, current := requested[pool], usage[pool]
+		if quota.Count > 0 && current.Count+req.Count > quota.Count {
+			return errors.Errorf(
+				"%s storage quota for pool %q exceeded: %d instance(s) requested, %d of %d in use",
+				scope, pool, req.Count, current.Count, quota.Count,
+			)
+		}
+		if quota.Size > 0 && current.Size+req.Size > quota.Size {
+			return errors.Errorf(
+				"%s storage quota for pool %q exceeded: %s requested, %s of %s in use",
+				scope, pool,
+				humanize.IBytes(req.Size*humanize.MiByte),
+				humanize.IBytes(current.Size*humanize.MiByte),
+				humanize.IBytes(quota.Size*humanize.MiByte),
+			)
+		}
+	}
+	return nil
+}
+
+// sortedPools returns the names of the pools in usage, in order, so
+// that errors and txn.Ops are deterministic.
+func sortedPools(usage map[string]StorageUsage) []string {
+	pools := make([]string, 0, len(usage))
+	for pool := range usage {
+		pools = append(pools, pool)
+	}
+	sort.Strings(pools)
+	return pools
+}
+
+// storagePoolUsageDoc counts the changes made to the storage provisioned
+// from a storage pool by operations subject to storage quotas. Each such
+// operation asserts that the count is unchanged since it checked the
+// quotas, and increments it, so that concurrent operations cannot
+// together exceed a quota.
+type storagePoolUsageDoc struct {
+	DocID     string `bson:"_id"`
+	ModelUUID string `bson:"model-uuid"`
+	Pool      string `bson:"pool"`
+	Changes   int64  `bson:"changes"`
+}
+
+// storagePoolUsageOps returns txn.Ops that assert that the storage
+// provisioned from each of the requested pools has not been changed by
+// another operation subject to storage quotas, and record the change
+// being made.
+func storagePoolUsageOps(st *State, requested map[string]StorageUsage) ([]txn.Op, error) {
+	coll, closer := st.getCollection(storagePoolUsageC)
+	defer closer()
+
+	var ops []txn.Op
+	for _, pool := range sortedPools(requested) {
+		var doc storagePoolUsageDoc
+		err := coll.FindId(pool).One(&doc)
+		if err == mgo.ErrNotFound {
+			ops = append(ops, txn.Op{
+				C:      storagePoolUsageC,
+				Id:     pool,
+				Assert: txn.DocMissing,
+				Insert: &storagePoolUsageDoc{Pool: pool, Changes: 1},
+			})
+			continue
+		} else if err != nil {
+			return nil, errors.Annotatef(err, "cannot get usage of storage pool %q", pool)
+		}
+		ops = append(ops, txn.Op{
+			C:      storagePoolUsageC,
+			Id:     pool,
+			Assert: bson.D{{"changes", doc.Changes}},
+			Update: bson.D{{"$inc", bson.D{{"changes", 1}}}},
+		})
+	}
+	return ops, nil
+}
+
+// storagePoolUsageChanged reports whether any of the storage pool usage
+// asserts in the given txn.Ops no longer hold.
+func storagePoolUsageChanged(st *State, ops []txn.Op) (bool, error) {
+	coll, closer := st.getCollection(storagePoolUsageC)
+	defer closer()
+
+	for _, op := range ops {
+		if op.C != storagePoolUsageC {
+			continue
+		}
+		var doc storagePoolUsageDoc
+		err := coll.FindId(op.Id).One(&doc)
+		if err == mgo.ErrNotFound {
+			if op.Assert != txn.DocMissing {
+				return true, nil
+			}
+			continue
+		} else if err != nil {
+			return false, errors.Trace(err)
+		}
+		if op.Assert == txn.DocMissing {
+			return true, nil
+		}
+		if !reflect.DeepEqual(op.Assert, bson.D{{"changes", doc.Changes}}) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
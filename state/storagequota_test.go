@@ -0,0 +1,177 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type StorageQuotaSuite struct {
+	StorageStateSuiteBase
+}
+
+var _ = gc.Suite(&StorageQuotaSuite{})
+
+func (s *StorageQuotaSuite) setModelStorageQuota(c *gc.C, attrs map[string]interface{}) {
+	err := s.State.UpdateModelConfig(attrs, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *StorageQuotaSuite) TestModelStorageQuota(c *gc.C) {
+	quota, err := s.State.ModelStorageQuota()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(quota, gc.Equals, state.StorageQuota{})
+
+	s.setModelStorageQuota(c, map[string]interface{}{
+		"storage-quota-size":  "10G",
+		"storage-quota-count": 5,
+	})
+	quota, err = s.State.ModelStorageQuota()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(quota, gc.Equals, state.StorageQuota{Size: 10240, Count: 5})
+}
+
+func (s *StorageQuotaSuite) TestStorageUsage(c *gc.C) {
+	_, u, _ := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.State.AddStorageForUnit(u.UnitTag(), "allecto", makeStorageCons("loop-pool", 2048, 2))
+	c.Assert(err, jc.ErrorIsNil)
+
+	expected := map[string]state.StorageUsage{
+		"loop-pool": {Count: 3, Size: 1024 + 2*2048},
+	}
+	usage, err := s.State.StorageUsage("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(usage, jc.DeepEquals, expected)
+	usage, err = s.State.StorageUsage("storage-block")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(usage, jc.DeepEquals, expected)
+	usage, err = s.State.StorageUsage("storage")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(usage, gc.HasLen, 0)
+}
+
+func (s *StorageQuotaSuite) TestSetStorageQuota(c *gc.C) {
+	app, _, _ := s.setupSingleStorage(c, "block", "loop-pool")
+	c.Assert(app.StorageQuota(), gc.Equals, state.StorageQuota{})
+
+	quota := state.StorageQuota{Size: 4096, Count: 2}
+	err := app.SetStorageQuota(quota)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.StorageQuota(), gc.Equals, quota)
+
+	app, err = s.State.Application(app.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.StorageQuota(), gc.Equals, quota)
+}
+
+func (s *StorageQuotaSuite) TestSetStorageQuotaApplicationNotAlive(c *gc.C) {
+	app, _, _ := s.setupSingleStorage(c, "block", "loop-pool")
+	err := app.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.SetStorageQuota(state.StorageQuota{Count: 1})
+	c.Assert(err, gc.ErrorMatches, `cannot set storage quota for application "storage-block": not found or not alive`)
+}
+
+func (s *StorageQuotaSuite) TestAddApplicationExceedsModelQuota(c *gc.C) {
+	s.setModelStorageQuota(c, map[string]interface{}{"storage-quota-size": "3G"})
+	ch := s.AddTestingCharm(c, "storage-block")
+	_, err := s.State.AddApplication(state.AddApplicationArgs{
+		Name:     "storage-block",
+		Charm:    ch,
+		NumUnits: 2,
+		Storage: map[string]state.StorageConstraints{
+			"data": makeStorageCons("loop-pool", 2048, 1),
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add application "storage-block": `+
+		`model storage quota for pool "loop-pool" exceeded: 4.0GiB requested, 0B of 3.0GiB in use`)
+}
+
+func (s *StorageQuotaSuite) TestAddUnitExceedsModelQuota(c *gc.C) {
+	s.setModelStorageQuota(c, map[string]interface{}{"storage-quota-count": 1})
+	app, _, _ := s.setupSingleStorage(c, "block", "loop-pool")
+	_, err := app.AddUnit()
+	c.Assert(err, gc.ErrorMatches, `cannot add unit to application "storage-block": `+
+		`model storage quota for pool "loop-pool" exceeded: 1 instance\(s\) requested, 1 of 1 in use`)
+}
+
+func (s *StorageQuotaSuite) TestAddUnitExceedsApplicationQuota(c *gc.C) {
+	app, _, _ := s.setupSingleStorage(c, "block", "loop-pool")
+	err := app.SetStorageQuota(state.StorageQuota{Size: 1536})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = app.AddUnit()
+	c.Assert(err, gc.ErrorMatches, `cannot add unit to application "storage-block": `+
+		`application "storage-block" storage quota for pool "loop-pool" exceeded: 1.0GiB requested, 1.0GiB of 1.5GiB in use`)
+}
+
+func (s *StorageQuotaSuite) TestAddStorageForUnitExceedsApplicationQuota(c *gc.C) {
+	app, u, _ := s.setupSingleStorage(c, "block", "loop-pool")
+	err := app.SetStorageQuota(state.StorageQuota{Size: 2048})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AddStorageForUnit(u.UnitTag(), "allecto", makeStorageCons("loop-pool", 2048, 1))
+	c.Assert(err, gc.ErrorMatches, `adding storage to unit storage-block/0: `+
+		`application "storage-block" storage quota for pool "loop-pool" exceeded: 2.0GiB requested, 1.0GiB of 2.0GiB in use`)
+
+	// Storage from other pools is not limited by the quota on loop-pool.
+	err = s.State.AddStorageForUnit(u.UnitTag(), "allecto", makeStorageCons("loop", 2048, 1))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *StorageQuotaSuite) TestQuotaOtherApplicationsUnaffected(c *gc.C) {
+	app, _, _ := s.setupSingleStorage(c, "block", "loop-pool")
+	err := app.SetStorageQuota(state.StorageQuota{Count: 1})
+	c.Assert(err, jc.ErrorIsNil)
+
+	ch := s.AddTestingCharm(c, "storage-block")
+	other := s.AddTestingServiceWithStorage(c, "other", ch, map[string]state.StorageConstraints{
+		"data": makeStorageCons("loop-pool", 1024, 1),
+	})
+	_, err = other.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *StorageQuotaSuite) TestStorageUsageWithoutRecordedPool(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	state.RemoveStorageInstancePoolAndSize(c, s.State, storageTag)
+
+	usage, err := s.State.StorageUsage("storage-block")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(usage, jc.DeepEquals, map[string]state.StorageUsage{
+		"loop-pool": {Count: 1, Size: 1024},
+	})
+}
+
+func (s *StorageQuotaSuite) TestAddStorageForUnitQuotaConcurrentChange(c *gc.C) {
+	app, u, _ := s.setupSingleStorage(c, "block", "loop-pool")
+	err := app.SetStorageQuota(state.StorageQuota{Count: 2})
+	c.Assert(err, jc.ErrorIsNil)
+
+	defer state.SetBeforeHooks(c, s.State, func() {
+		err := s.State.AddStorageForUnit(u.UnitTag(), "allecto", makeStorageCons("loop-pool", 1024, 1))
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	err = s.State.AddStorageForUnit(u.UnitTag(), "allecto", makeStorageCons("loop-pool", 1024, 1))
+	c.Assert(err, gc.ErrorMatches, `adding storage to unit storage-block/0: `+
+		`application "storage-block" storage quota for pool "loop-pool" exceeded: 1 instance\(s\) requested, 2 of 2 in use`)
+}
+
+func (s *StorageQuotaSuite) TestAddUnitQuotaConcurrentChange(c *gc.C) {
+	app, u, _ := s.setupSingleStorage(c, "block", "loop-pool")
+	err := app.SetStorageQuota(state.StorageQuota{Count: 2})
+	c.Assert(err, jc.ErrorIsNil)
+
+	defer state.SetBeforeHooks(c, s.State, func() {
+		err := s.State.AddStorageForUnit(u.UnitTag(), "allecto", makeStorageCons("loop-pool", 1024, 1))
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	_, err = app.AddUnit()
+	c.Assert(err, gc.ErrorMatches, `cannot add unit to application "storage-block": storage usage changed concurrently`)
+}
@@ -0,0 +1,233 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/storage"
+)
+
+// ResizeStorageInstance requests that the volume or filesystem assigned
+// to the specified storage instance be grown to the specified size, in
+// MiB. If the storage instance is a filesystem backed by a volume, then
+// the volume will be grown too.
+//
+// The storage must be provisioned, and the requested size must be larger
+// than its current size. If the storage provider cannot grow the storage,
+// an error satisfying errors.IsNotSupported is returned. The storage will
+// be resized by its storage provisioner at some point in the future; until
+// then, the requested size is reported by the volume's or filesystem's
+// RequestedSize method.
+func (st *State) ResizeStorageInstance(tag names.StorageTag, size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot resize storage %s", tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := st.storageInstance(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if s.Life() != Alive {
+			return nil, errors.New("storage is not alive")
+		}
+		pool, currentSize, err := storageInstancePoolAndSize(st, &s.doc)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		var ops []txn.Op
+		if pool != "" && size > currentSize {
+			quotaOps, err := validateResizeStorageQuotas(st, s, pool, size-currentSize)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, quotaOps...)
+		}
+		switch s.Kind() {
+		case StorageKindBlock:
+			v, err := st.storageInstanceVolume(tag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if err := checkVolumeResizeSupported(st, v); err != nil {
+				return nil, errors.Trace(err)
+			}
+			volumeOps, err := resizeVolumeOps(v, size)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, volumeOps...)
+		case StorageKindFilesystem:
+			f, err := st.storageInstanceFilesystem(tag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			var backingVolume *volume
+			if volumeTag, err := f.Volume(); err == nil {
+				// Volume-backed filesystems are managed by
+				// Juju, and can be grown whenever the backing
+				// volume can.
+				backingVolume, err = st.volumeByTag(volumeTag)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				if err := checkVolumeResizeSupported(st, backingVolume); err != nil {
+					return nil, errors.Trace(err)
+				}
+			} else if errors.Cause(err) != ErrNoBackingVolume {
+				return nil, errors.Trace(err)
+			} else if err := checkFilesystemResizeSupported(st, f); err != nil {
+				return nil, errors.Trace(err)
+			}
+			filesystemOps, err := resizeFilesystemOps(f, size)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, filesystemOps...)
+			if backingVolume != nil {
+				info, err := backingVolume.Info()
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				if info.Size < size {
+					// The filesystem cannot grow beyond
+					// its backing volume.
+					volumeOps, err := resizeVolumeOps(backingVolume, size)
+					if err != nil {
+						return nil, errors.Trace(err)
+					}
+					ops = append(ops, volumeOps...)
+				}
+			}
+		default:
+			return nil, errors.Errorf("invalid storage kind %v", s.Kind())
+		}
+		ops = append(ops, txn.Op{
+			C:      storageInstancesC,
+			Id:     tag.Id(),
+			Assert: isAliveDoc,
+			Update: bson.D{{"$set", bson.D{{"pool", pool}, {"size", size}}}},
+		})
+		return ops, nil
+	}
+	return st.run(buildTxn)
+}
+
+// validateResizeStorageQuotas returns an error if growing the storage
+// instance in the specified pool by the specified size would exceed the
+// model's storage quota, or the storage quota of the application owning
+// the storage. If any quota applies, txn.Ops are returned that ensure
+// the storage provisioned from the pool does not change before the
+// storage instance is resized.
+func validateResizeStorageQuotas(st *State, s *storageInstance, pool string, growth uint64) ([]txn.Op, error) {
+	requested := map[string]StorageUsage{
+		pool: {Size: growth},
+	}
+	var app *Application
+	if owner, ok := s.Owner(); ok {
+		var appName string
+		switch owner := owner.(type) {
+		case names.UnitTag:
+			var err error
+			if appName, err = names.UnitApplication(owner.Id()); err != nil {
+				return nil, errors.Trace(err)
+			}
+		case names.ApplicationTag:
+			// Shared storage is owned by the application.
+			appName = owner.Id()
+		}
+		if appName != "" {
+			var err error
+			if app, err = st.Application(appName); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+	}
+	return validateStorageQuotas(st, app, requested)
+}
+
+// checkVolumeResizeSupported returns an error satisfying
+// errors.IsNotSupported if the storage provider of the volume's pool
+// cannot grow volumes.
+func checkVolumeResizeSupported(st *State, v *volume) error {
+	info, err := v.Info()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	providerType, provider, err := poolStorageProvider(st, info.Pool)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if p, ok := provider.(storage.VolumeResizeProvider); !ok || !p.SupportsVolumeResize() {
+		return errors.NotSupportedf("resizing volumes in pool %q with storage provider %q", info.Pool, providerType)
+	}
+	return nil
+}
+
+// checkFilesystemResizeSupported returns an error satisfying
+// errors.IsNotSupported if the storage provider of the filesystem's
+// pool cannot grow filesystems.
+func checkFilesystemResizeSupported(st *State, f *filesystem) error {
+	info, err := f.Info()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	providerType, provider, err := poolStorageProvider(st, info.Pool)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if p, ok := provider.(storage.FilesystemResizeProvider); !ok || !p.SupportsFilesystemResize() {
+		return errors.NotSupportedf("resizing filesystems in pool %q with storage provider %q", info.Pool, providerType)
+	}
+	return nil
+}
+
+// resizeVolumeOps returns txn.Ops to record a request to grow the
+// volume to the specified size.
+func resizeVolumeOps(v *volume, size uint64) ([]txn.Op, error) {
+	if v.Life() != Alive {
+		return nil, errors.Errorf("volume %s is not alive", v.doc.Name)
+	}
+	info, err := v.Info()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if size <= info.Size {
+		return nil, errors.Errorf(
+			"new size %dM must be larger than current size %dM",
+			size, info.Size,
+		)
+	}
+	return []txn.Op{{
+		C:      volumesC,
+		Id:     v.doc.Name,
+		Assert: append(bson.D{{"info.size", info.Size}}, isAliveDoc...),
+		Update: bson.D{{"$set", bson.D{{"requestedsize", size}}}},
+	}}, nil
+}
+
+// resizeFilesystemOps returns txn.Ops to record a request to grow the
+// filesystem to the specified size.
+func resizeFilesystemOps(f *filesystem, size uint64) ([]txn.Op, error) {
+	if f.Life() != Alive {
+		return nil, errors.Errorf("filesystem %s is not alive", f.doc.FilesystemId)
+	}
+	info, err := f.Info()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if size <= info.Size {
+		return nil, errors.Errorf(
+			"new size %dM must be larger than current size %dM",
+			size, info.Size,
+		)
+	}
+	return []txn.Op{{
+		C:      filesystemsC,
+		Id:     f.doc.FilesystemId,
+		Assert: append(bson.D{{"info.size", info.Size}}, isAliveDoc...),
+		Update: bson.D{{"$set", bson.D{{"requestedsize", size}}}},
+	}}, nil
+}
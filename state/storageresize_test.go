@@ -0,0 +1,183 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/testing"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider/dummy"
+	"github.com/juju/juju/storage/provider/registry"
+)
+
+type StorageResizeSuite struct {
+	StorageStateSuiteBase
+}
+
+var _ = gc.Suite(&StorageResizeSuite{})
+
+func (s *StorageResizeSuite) setupStorage(c *gc.C, kind string) (*state.Unit, names.StorageTag) {
+	_, u, storageTag := s.setupSingleStorage(c, kind, "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	return u, storageTag
+}
+
+func (s *StorageResizeSuite) provisionVolume(c *gc.C, tag names.VolumeTag) {
+	err := s.State.SetVolumeInfo(tag, state.VolumeInfo{
+		VolumeId: "vol-" + tag.String(),
+		Size:     1024,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *StorageResizeSuite) TestResizeBlockStorage(c *gc.C) {
+	_, storageTag := s.setupStorage(c, "block")
+	volumeTag := names.NewVolumeTag("0/0")
+	s.provisionVolume(c, volumeTag)
+
+	err := s.State.ResizeStorageInstance(storageTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	size, ok := s.volume(c, volumeTag).RequestedSize()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(size, gc.Equals, uint64(2048))
+
+	// Recording a size smaller than the requested
+	// size leaves the request outstanding.
+	info, err := s.volume(c, volumeTag).Info()
+	c.Assert(err, jc.ErrorIsNil)
+	info.Size = 1536
+	err = s.State.SetVolumeInfo(volumeTag, info)
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok = s.volume(c, volumeTag).RequestedSize()
+	c.Assert(ok, jc.IsTrue)
+
+	info.Size = 2048
+	err = s.State.SetVolumeInfo(volumeTag, info)
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok = s.volume(c, volumeTag).RequestedSize()
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *StorageResizeSuite) TestResizeBlockStorageUnprovisioned(c *gc.C) {
+	_, storageTag := s.setupStorage(c, "block")
+	err := s.State.ResizeStorageInstance(storageTag, 2048)
+	c.Assert(err, gc.ErrorMatches, `cannot resize storage data/0: volume "0/0" not provisioned`)
+}
+
+func (s *StorageResizeSuite) TestResizeBlockStorageShrink(c *gc.C) {
+	_, storageTag := s.setupStorage(c, "block")
+	s.provisionVolume(c, names.NewVolumeTag("0/0"))
+	err := s.State.ResizeStorageInstance(storageTag, 1024)
+	c.Assert(err, gc.ErrorMatches, `cannot resize storage data/0: new size 1024M must be larger than current size 1024M`)
+}
+
+func (s *StorageResizeSuite) TestResizeBlockStorageNotSupported(c *gc.C) {
+	registry.RegisterProvider("noresize", &dummy.StorageProvider{
+		StorageScope:   storage.ScopeEnviron,
+		IsDynamic:      true,
+		NoVolumeResize: true,
+	})
+	defer registry.RegisterProvider("noresize", nil)
+	registry.RegisterEnvironStorageProviders("someprovider", "noresize")
+
+	_, u, storageTag := s.setupSingleStorage(c, "block", "noresize")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volumeTag := names.NewVolumeTag("0")
+	s.provisionVolume(c, volumeTag)
+
+	err = s.State.ResizeStorageInstance(storageTag, 2048)
+	c.Assert(err, gc.ErrorMatches, `cannot resize storage data/0: resizing volumes in pool "noresize" with storage provider "noresize" not supported`)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	_, ok := s.volume(c, volumeTag).RequestedSize()
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *StorageResizeSuite) TestResizeFilesystemStorage(c *gc.C) {
+	_, storageTag := s.setupStorage(c, "filesystem")
+	volumeTag := names.NewVolumeTag("0/0")
+	filesystemTag := names.NewFilesystemTag("0/0")
+	s.provisionVolume(c, volumeTag)
+	err := s.State.SetVolumeAttachmentInfo(
+		names.NewMachineTag("0"), volumeTag,
+		state.VolumeAttachmentInfo{DeviceName: "loop0"},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetFilesystemInfo(filesystemTag, state.FilesystemInfo{
+		FilesystemId: "fs-0-0",
+		Size:         1024,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.ResizeStorageInstance(storageTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Both the filesystem and its backing volume must grow.
+	size, ok := s.filesystem(c, filesystemTag).RequestedSize()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(size, gc.Equals, uint64(2048))
+	size, ok = s.volume(c, volumeTag).RequestedSize()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(size, gc.Equals, uint64(2048))
+
+	info, err := s.filesystem(c, filesystemTag).Info()
+	c.Assert(err, jc.ErrorIsNil)
+	info.Size = 2048
+	err = s.State.SetFilesystemInfo(filesystemTag, info)
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok = s.filesystem(c, filesystemTag).RequestedSize()
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *StorageResizeSuite) TestWatchMachineVolumeResizes(c *gc.C) {
+	_, storageTag := s.setupStorage(c, "block")
+	w := s.State.WatchMachineVolumeResizes(names.NewMachineTag("0"))
+	defer testing.AssertStop(c, w)
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChangeInSingleEvent("0/0") // initial
+	wc.AssertNoChange()
+
+	s.provisionVolume(c, names.NewVolumeTag("0/0"))
+	wc.AssertChangeInSingleEvent("0/0")
+	wc.AssertNoChange()
+
+	err := s.State.ResizeStorageInstance(storageTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent("0/0")
+	wc.AssertNoChange()
+
+	// Model-scoped resize watchers are not
+	// interested in machine-scoped volumes.
+	w2 := s.State.WatchModelVolumeResizes()
+	defer testing.AssertStop(c, w2)
+	wc2 := testing.NewStringsWatcherC(c, s.State, w2)
+	wc2.AssertChangeInSingleEvent() // initial
+	wc2.AssertNoChange()
+}
+
+func (s *StorageResizeSuite) TestResizeFilesystemStorageNotSupported(c *gc.C) {
+	// Filesystems that are not backed by volumes can only be grown
+	// if their storage provider supports it.
+	_, u, storageTag := s.setupSingleStorage(c, "filesystem", "environscoped")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	filesystemTag := names.NewFilesystemTag("0")
+	err = s.State.SetFilesystemInfo(filesystemTag, state.FilesystemInfo{
+		FilesystemId: "fs-0",
+		Size:         1024,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.ResizeStorageInstance(storageTag, 2048)
+	c.Assert(err, gc.ErrorMatches, `cannot resize storage data/0: resizing filesystems in pool "environscoped" with storage provider "environscoped" not supported`)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	_, ok := s.filesystem(c, filesystemTag).RequestedSize()
+	c.Assert(ok, jc.IsFalse)
+}
@@ -0,0 +1,310 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/testing"
+)
+
+type SharedStorageSuite struct {
+	StorageStateSuiteBase
+}
+
+var _ = gc.Suite(&SharedStorageSuite{})
+
+func (s *SharedStorageSuite) addSharedStorageService(c *gc.C, pool string) *state.Application {
+	ch := s.createStorageCharm(c, "shared", charm.Storage{
+		Name:     "data",
+		Type:     charm.StorageFilesystem,
+		Shared:   true,
+		CountMin: 1,
+		CountMax: 1,
+	})
+	return s.AddTestingServiceWithStorage(c, "shared", ch, map[string]state.StorageConstraints{
+		"data": makeStorageCons(pool, 1024, 1),
+	})
+}
+
+func (s *SharedStorageSuite) addHostAndContainer(c *gc.C) (*state.Machine, *state.Machine) {
+	host, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	container, err := s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, host.Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+	return host, container
+}
+
+func (s *SharedStorageSuite) TestAddApplicationCreatesSharedStorage(c *gc.C) {
+	app := s.addSharedStorageService(c, "hostdir")
+	storageTag := names.NewStorageTag("data/0")
+
+	si, err := s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	owner, ok := si.Owner()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(owner, gc.Equals, app.Tag())
+	c.Assert(si.Kind(), gc.Equals, state.StorageKindFilesystem)
+
+	for i := 0; i < 2; i++ {
+		_, err := app.AddUnit()
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	all, err := s.State.AllStorageInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 1)
+
+	attachments, err := s.State.StorageAttachments(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	var units []names.UnitTag
+	for _, a := range attachments {
+		units = append(units, a.Unit())
+	}
+	c.Assert(units, jc.SameContents, []names.UnitTag{
+		names.NewUnitTag("shared/0"),
+		names.NewUnitTag("shared/1"),
+	})
+}
+
+func (s *SharedStorageSuite) TestAddApplicationWithUnitsAttachesSharedStorage(c *gc.C) {
+	ch := s.createStorageCharm(c, "shared", charm.Storage{
+		Name:     "data",
+		Type:     charm.StorageFilesystem,
+		Shared:   true,
+		CountMin: 1,
+		CountMax: 1,
+	})
+	host, _ := s.addHostAndContainer(c)
+	_, err := s.State.AddApplication(state.AddApplicationArgs{
+		Name:     "shared",
+		Charm:    ch,
+		NumUnits: 2,
+		Storage: map[string]state.StorageConstraints{
+			"data": makeStorageCons("hostdir", 1024, 1),
+		},
+		Placement: []*instance.Placement{
+			{Scope: instance.MachineScope, Directive: host.Id()},
+			{Scope: string(instance.LXD), Directive: host.Id()},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	attachments, err := s.State.StorageAttachments(names.NewStorageTag("data/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachments, gc.HasLen, 2)
+}
+
+func (s *SharedStorageSuite) TestAddApplicationMachineScopedSharedStoragePlacement(c *gc.C) {
+	ch := s.createStorageCharm(c, "shared", charm.Storage{
+		Name:     "data",
+		Type:     charm.StorageFilesystem,
+		Shared:   true,
+		CountMin: 1,
+		CountMax: 1,
+	})
+	host, _ := s.addHostAndContainer(c)
+	other, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	for i, placement := range [][]*instance.Placement{
+		nil,
+		{{Scope: instance.MachineScope, Directive: host.Id()}},
+		{
+			{Scope: instance.MachineScope, Directive: host.Id()},
+			{Scope: instance.MachineScope, Directive: other.Id()},
+		},
+	} {
+		c.Logf("test %d", i)
+		_, err := s.State.AddApplication(state.AddApplicationArgs{
+			Name:     "shared",
+			Charm:    ch,
+			NumUnits: 2,
+			Storage: map[string]state.StorageConstraints{
+				"data": makeStorageCons("hostdir", 1024, 1),
+			},
+			Placement: placement,
+		})
+		c.Assert(err, gc.ErrorMatches, `cannot add application "shared": charm "shared" store "data": `+
+			`units sharing storage from machine-scoped pool "hostdir" must all be placed on the same machine, or its containers`)
+	}
+}
+
+func (s *SharedStorageSuite) TestAssignUnitSharedStorageOtherMachine(c *gc.C) {
+	app := s.addSharedStorageService(c, "hostdir")
+	host, _ := s.addHostAndContainer(c)
+	u0, err := app.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = u0.AssignToMachine(host)
+	c.Assert(err, jc.ErrorIsNil)
+
+	u1, err := app.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AssignUnit(u1, state.AssignNew)
+	c.Assert(err, gc.ErrorMatches, `.*storage "data/0" is shared from machine "`+host.Id()+`", `+
+		`and cannot be attached to machine "\d+": units sharing the storage must be placed on machine "`+host.Id()+`", or its containers`)
+}
+
+func (s *SharedStorageSuite) TestSharedStorageDefaultPool(c *gc.C) {
+	ch := s.createStorageCharm(c, "shared", charm.Storage{
+		Name:     "data",
+		Type:     charm.StorageFilesystem,
+		Shared:   true,
+		CountMin: 1,
+		CountMax: 1,
+	})
+	app := s.AddTestingService(c, "shared", ch)
+	cons, err := app.StorageConstraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cons["data"].Pool, gc.Equals, "hostdir")
+}
+
+func (s *SharedStorageSuite) TestSharedBlockStorageNotSupported(c *gc.C) {
+	ch := s.createStorageCharm(c, "shared", charm.Storage{
+		Name:     "data",
+		Type:     charm.StorageBlock,
+		Shared:   true,
+		CountMin: 1,
+		CountMax: 1,
+	})
+	_, err := s.State.AddApplication(state.AddApplicationArgs{
+		Name:  "shared",
+		Charm: ch,
+		Storage: map[string]state.StorageConstraints{
+			"data": makeStorageCons("loop", 1024, 1),
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add application "shared": charm "shared" store "data": shared block storage not supported`)
+}
+
+func (s *SharedStorageSuite) TestSharedStoragePoolNotSupported(c *gc.C) {
+	ch := s.createStorageCharm(c, "shared", charm.Storage{
+		Name:     "data",
+		Type:     charm.StorageFilesystem,
+		Shared:   true,
+		CountMin: 1,
+		CountMax: 1,
+	})
+	_, err := s.State.AddApplication(state.AddApplicationArgs{
+		Name:  "shared",
+		Charm: ch,
+		Storage: map[string]state.StorageConstraints{
+			"data": makeStorageCons("rootfs", 1024, 1),
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add application "shared": charm "shared" store "data": pool "rootfs" does not support shared filesystems`)
+}
+
+func (s *SharedStorageSuite) TestAddStorageForUnitShared(c *gc.C) {
+	app := s.addSharedStorageService(c, "hostdir")
+	u, err := app.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AddStorageForUnit(u.UnitTag(), "data", makeStorageCons("hostdir", 1024, 1))
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, `adding shared storage "data" to unit not supported`)
+}
+
+func (s *SharedStorageSuite) TestAssignUnitsShareFilesystem(c *gc.C) {
+	app := s.addSharedStorageService(c, "hostdir")
+	host, container := s.addHostAndContainer(c)
+
+	u0, err := app.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = u0.AssignToMachine(host)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The shared filesystem is created when the first unit
+	// is assigned, and is scoped to the host machine.
+	filesystem := s.storageInstanceFilesystem(c, names.NewStorageTag("data/0"))
+	scope, ok := names.FilesystemMachine(filesystem.FilesystemTag())
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(scope, gc.Equals, host.MachineTag())
+	s.assertFilesystemAttachmentUnprovisioned(c, host.MachineTag(), filesystem.FilesystemTag())
+
+	// A unit assigned to a container on the host shares
+	// the host's filesystem.
+	u1, err := app.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = u1.AssignToMachine(container)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertFilesystemAttachmentUnprovisioned(c, container.MachineTag(), filesystem.FilesystemTag())
+
+	// A second unit on the host shares the existing attachment.
+	u2, err := app.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = u2.AssignToMachine(host)
+	c.Assert(err, jc.ErrorIsNil)
+
+	all, err := s.State.MachineFilesystemAttachments(host.MachineTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 1)
+	filesystems, err := s.State.AllFilesystems()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(filesystems, gc.HasLen, 1)
+}
+
+func (s *SharedStorageSuite) TestWatchMachineFilesystemAttachmentsContainers(c *gc.C) {
+	app := s.addSharedStorageService(c, "hostdir")
+	host, container := s.addHostAndContainer(c)
+
+	w := s.State.WatchMachineFilesystemAttachments(host.MachineTag())
+	defer testing.AssertStop(c, w)
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChangeInSingleEvent() // initial
+	wc.AssertNoChange()
+
+	u0, err := app.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = u0.AssignToMachine(host)
+	c.Assert(err, jc.ErrorIsNil)
+	filesystem := s.storageInstanceFilesystem(c, names.NewStorageTag("data/0"))
+	wc.AssertChangeInSingleEvent(host.Id() + ":" + filesystem.FilesystemTag().Id())
+	wc.AssertNoChange()
+
+	u1, err := app.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = u1.AssignToMachine(container)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent(container.Id() + ":" + filesystem.FilesystemTag().Id())
+	wc.AssertNoChange()
+}
+
+func (s *SharedStorageSuite) TestDestroyApplicationDestroysSharedStorage(c *gc.C) {
+	app := s.addSharedStorageService(c, "hostdir")
+	_, err := app.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+
+	si, err := s.State.StorageInstance(names.NewStorageTag("data/0"))
+	if err == nil {
+		c.Assert(si.Life(), gc.Not(gc.Equals), state.Alive)
+	} else {
+		c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	}
+}
+
+func (s *SharedStorageSuite) TestAssignUnitContainerPassthroughVolume(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "lxd")
+	host, container := s.addHostAndContainer(c)
+	err := u.AssignToMachine(container)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Volumes passed through to a container are scoped to
+	// the container's host, and attached to the container.
+	volume := s.storageInstanceVolume(c, storageTag)
+	scope, ok := names.VolumeMachine(volume.VolumeTag())
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(scope, gc.Equals, host.MachineTag())
+	s.volumeAttachment(c, container.MachineTag(), volume.VolumeTag())
+}
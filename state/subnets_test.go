@@ -11,6 +11,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
 )
 
 type SubnetSuite struct {
@@ -272,3 +273,26 @@ func (s *SubnetSuite) TestAllSubnets(c *gc.C) {
 		c.Assert(subnet.AvailabilityZone(), gc.Equals, subnetInfos[i].AvailabilityZone)
 	}
 }
+
+func (s *SubnetSuite) TestWatchSubnets(c *gc.C) {
+	w := s.State.WatchSubnets()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	_, err := s.State.AddSubnet(state.SubnetInfo{CIDR: "10.0.0.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Adding a space moves the subnet into it.
+	_, err = s.State.AddSpace("internal", "", []string{"10.0.0.0/24"}, false)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	_, err = s.State.AddSubnet(state.SubnetInfo{CIDR: "10.1.0.0/24", SpaceName: "internal"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	statetesting.AssertStop(c, w)
+	wc.AssertClosed()
+}
@@ -17,6 +17,7 @@ type MockPolicy struct {
 	GetEnvironCapability    func(*config.Config) (state.EnvironCapability, error)
 	GetConstraintsValidator func(*config.Config, state.SupportedArchitecturesQuerier) (constraints.Validator, error)
 	GetInstanceDistributor  func(*config.Config) (state.InstanceDistributor, error)
+	GetVirtualIPReleaser    func(*config.Config) (state.VirtualIPReleaser, error)
 }
 
 func (p *MockPolicy) Prechecker(cfg *config.Config) (state.Prechecker, error) {
@@ -53,3 +54,10 @@ func (p *MockPolicy) InstanceDistributor(cfg *config.Config) (state.InstanceDist
 	}
 	return nil, errors.NewNotImplemented(nil, "InstanceDistributor")
 }
+
+func (p *MockPolicy) VirtualIPReleaser(cfg *config.Config) (state.VirtualIPReleaser, error) {
+	if p.GetVirtualIPReleaser != nil {
+		return p.GetVirtualIPReleaser(cfg)
+	}
+	return nil, errors.NewNotImplemented(nil, "VirtualIPReleaser")
+}
@@ -91,6 +91,7 @@ type unitDoc struct {
 	Life                   Life
 	TxnRevno               int64 `bson:"txn-revno"`
 	PasswordHash           string
+	WorkloadVersion        string `bson:",omitempty"`
 }
 
 // Unit represents the state of a service unit.
@@ -212,6 +213,30 @@ func (u *Unit) SetAgentVersion(v version.Binary) (err error) {
 	return nil
 }
 
+// WorkloadVersion returns the version of the workload running on the
+// unit, as most recently reported by its charm, or an empty string if
+// no version has been reported.
+func (u *Unit) WorkloadVersion() string {
+	return u.doc.WorkloadVersion
+}
+
+// SetWorkloadVersion records the version of the workload running on
+// the unit.
+func (u *Unit) SetWorkloadVersion(version string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set workload version for unit %q", u)
+	ops := []txn.Op{{
+		C:      unitsC,
+		Id:     u.doc.DocID,
+		Assert: notDeadDoc,
+		Update: bson.D{{"$set", bson.D{{"workloadversion", version}}}},
+	}}
+	if err := u.st.runTransaction(ops); err != nil {
+		return onAbort(err, ErrDead)
+	}
+	u.doc.WorkloadVersion = version
+	return nil
+}
+
 // SetPassword sets the password for the machine's agent.
 func (u *Unit) SetPassword(password string) error {
 	if len(password) < utils.MinAgentPasswordLength {
@@ -1379,19 +1404,40 @@ func validateDynamicMachineStoragePools(m *Machine, pools set.Strings) error {
 		return nil
 	}
 	if m.ContainerType() != "" {
+		// Shared filesystems, and storage passed through from
+		// the host machine, are attached to containers by the
+		// host machine, so they may be added to containers.
+		//
 		// TODO(axw) consult storage providers to check if they
 		// support adding storage to containers. Loop is fine,
 		// for example.
-		//
-		// TODO(axw) later we might allow *any* storage, and
-		// passthrough/bindmount storage. That would imply either
-		// container creation time only, or requiring containers
-		// to be restarted to pick up new configuration.
-		return errors.NotSupportedf("adding storage to %s container", m.ContainerType())
+		hostScoped, err := hostScopedPools(m.st, pools)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if !hostScoped {
+			return errors.NotSupportedf("adding storage to %s container", m.ContainerType())
+		}
 	}
 	return validateDynamicStoragePools(m.st, pools)
 }
 
+// hostScopedPools reports whether or not all of the specified storage
+// pools support shared filesystems or container passthrough, and so
+// may be attached to containers by their host machines.
+func hostScopedPools(st *State, pools set.Strings) (bool, error) {
+	for pool := range pools {
+		_, provider, err := poolStorageProvider(st, pool)
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		if !supportsSharedFilesystems(provider) && !supportsContainerPassthrough(provider) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
 // validateDynamicStoragePools validates that all of the specified storage
 // providers support dynamic storage provisioning. If any provider doesn't
 // support dynamic storage, then an IsNotSupported error is returned.
@@ -1725,15 +1771,16 @@ func machineStorageParamsForStorageInstance(
 		volumeAttachmentParams := VolumeAttachmentParams{
 			charmStorage.ReadOnly,
 		}
-		if unit == storage.Owner() {
+		if owner, _ := storage.Owner(); owner == unit {
 			// The storage instance is owned by the unit, so we'll need
 			// to create a volume.
 			cons := allCons[storage.StorageName()]
 			volumeParams := VolumeParams{
-				storage: storage.StorageTag(),
-				binding: storage.StorageTag(),
-				Pool:    cons.Pool,
-				Size:    cons.Size,
+				storage:  storage.StorageTag(),
+				binding:  storage.StorageTag(),
+				Pool:     cons.Pool,
+				Size:     cons.Size,
+				Snapshot: cons.Snapshot,
 			}
 			volumes = append(volumes, MachineVolumeParams{
 				volumeParams, volumeAttachmentParams,
@@ -1761,7 +1808,7 @@ func machineStorageParamsForStorageInstance(
 			location,
 			charmStorage.ReadOnly,
 		}
-		if unit == storage.Owner() {
+		if owner, _ := storage.Owner(); owner == unit {
 			// The storage instance is owned by the unit, so we'll need
 			// to create a filesystem.
 			cons := allCons[storage.StorageName()]
@@ -1775,11 +1822,24 @@ func machineStorageParamsForStorageInstance(
 				filesystemParams, filesystemAttachmentParams,
 			})
 		} else {
-			// The storage instance is owned by the service, so there
-			// should be a (shared) filesystem already, for which we will
-			// just add an attachment.
+			// The storage instance is owned by the application, so
+			// there may be a (shared) filesystem already, for which
+			// we will just add an attachment. Shared filesystems are
+			// created when the first unit is assigned to a machine.
 			filesystem, err := st.StorageInstanceFilesystem(storage.StorageTag())
-			if err != nil {
+			if errors.IsNotFound(err) {
+				cons := allCons[storage.StorageName()]
+				filesystemParams := FilesystemParams{
+					storage: storage.StorageTag(),
+					binding: storage.StorageTag(),
+					Pool:    cons.Pool,
+					Size:    cons.Size,
+				}
+				filesystems = append(filesystems, MachineFilesystemParams{
+					filesystemParams, filesystemAttachmentParams,
+				})
+				break
+			} else if err != nil {
 				return nil, errors.Annotatef(err, "getting filesystem for storage %q", storage.Tag().Id())
 			}
 			filesystemAttachments[filesystem.FilesystemTag()] = filesystemAttachmentParams
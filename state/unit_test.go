@@ -571,6 +571,28 @@ func (s *UnitSuite) TestRefresh(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
 
+func (s *UnitSuite) TestWorkloadVersion(c *gc.C) {
+	c.Assert(s.unit.WorkloadVersion(), gc.Equals, "")
+
+	err := s.unit.SetWorkloadVersion("3.4.1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.unit.WorkloadVersion(), gc.Equals, "3.4.1")
+
+	unit, err := s.State.Unit(s.unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unit.WorkloadVersion(), gc.Equals, "3.4.1")
+}
+
+func (s *UnitSuite) TestSetWorkloadVersionDeadUnit(c *gc.C) {
+	preventUnitDestroyRemove(c, s.unit)
+	err := s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.SetWorkloadVersion("3.4.1")
+	c.Assert(err, gc.ErrorMatches, `cannot set workload version for unit "wordpress/0": not found or dead`)
+	c.Assert(errors.Cause(err), gc.Equals, state.ErrDead)
+}
+
 func (s *UnitSuite) TestSetCharmURLSuccess(c *gc.C) {
 	preventUnitDestroyRemove(c, s.unit)
 	curl, ok := s.unit.CharmURL()
@@ -0,0 +1,325 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"math/big"
+	"net"
+	"strings"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"github.com/juju/utils/set"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/instance"
+)
+
+// maxVirtualIPCandidates is the number of addresses of a subnet that
+// are considered when choosing a free virtual IP address.
+const maxVirtualIPCandidates = 1 << 16
+
+// VirtualIPAddress returns the virtual IP address allocated to the
+// application, and the CIDR of the subnet it was allocated from. Both
+// are empty if the application has no virtual IP address.
+func (s *Application) VirtualIPAddress() (address, subnetCIDR string) {
+	return s.doc.VirtualIPAddress, s.doc.VirtualIPSubnet
+}
+
+// VirtualIPHolder returns the name of the unit whose machine the
+// application's virtual IP address was last assigned to, or the empty
+// string if it has not been assigned.
+func (s *Application) VirtualIPHolder() string {
+	return s.doc.VirtualIPHolder
+}
+
+// AllocateVirtualIPAddress allocates a virtual IP address to the
+// application from the known subnet with the given CIDR, and returns
+// it. If address is empty, the highest address of the subnet that is
+// not already in use is chosen; otherwise the given address is
+// allocated, if it is in the subnet and not already in use. The
+// address is reserved in the same transaction, so it cannot also be
+// allocated to another application or requested for a machine.
+func (s *Application) AllocateVirtualIPAddress(subnetCIDR, address string) (_ string, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot allocate virtual IP address for application %q", s)
+	if s.doc.VirtualIPAddress != "" {
+		return "", errors.AlreadyExistsf("virtual IP address %q", s.doc.VirtualIPAddress)
+	}
+	subnet, err := s.st.Subnet(subnetCIDR)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	_, ipNet, err := net.ParseCIDR(subnet.CIDR())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if address != "" {
+		ip := net.ParseIP(address)
+		if ip == nil || !ipNet.Contains(ip) {
+			return "", errors.NewNotValid(nil, fmt.Sprintf(
+				"address %q is not part of subnet %q", address, subnet.CIDR(),
+			))
+		}
+		address = ip.String()
+	}
+
+	var allocated string
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := s.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if s.doc.Life != Alive {
+			return nil, errNotAlive
+		}
+		if s.doc.VirtualIPAddress != "" {
+			return nil, errors.AlreadyExistsf("virtual IP address %q", s.doc.VirtualIPAddress)
+		}
+		used, err := s.st.usedIPAddresses()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		allocated = address
+		if allocated == "" {
+			allocated, err = freeIPAddress(ipNet, used)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+		} else if used.Contains(allocated) {
+			return nil, errors.AlreadyExistsf("address %q", allocated)
+		}
+		return []txn.Op{{
+			C:  applicationsC,
+			Id: s.doc.DocID,
+			Assert: bson.D{
+				{"life", Alive},
+				{"virtual-ip-address", bson.D{{"$exists", false}}},
+			},
+			Update: bson.D{{"$set", bson.D{
+				{"virtual-ip-address", allocated},
+				{"virtual-ip-subnet", subnet.CIDR()},
+			}}},
+		}, s.st.reserveIPAddressOp(allocated)}, nil
+	}
+	if err := s.st.run(buildTxn); err != nil {
+		return "", errors.Trace(err)
+	}
+	s.doc.VirtualIPAddress = allocated
+	s.doc.VirtualIPSubnet = subnet.CIDR()
+	s.doc.VirtualIPHolder = ""
+	return allocated, nil
+}
+
+// SetVirtualIPHolder records that the application's virtual IP address
+// has been assigned to the instance with the given ID, hosting the named
+// unit. It fails if the supplied token loses validity, so that only the
+// application's leader can claim the address.
+func (s *Application) SetVirtualIPHolder(token leadership.Token, unitName string, instId instance.Id) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set virtual IP holder for application %q", s)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := s.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if s.doc.Life != Alive {
+			return nil, errNotAlive
+		}
+		if s.doc.VirtualIPAddress == "" {
+			return nil, errors.NotFoundf("virtual IP address")
+		}
+		return []txn.Op{{
+			C:  applicationsC,
+			Id: s.doc.DocID,
+			Assert: bson.D{
+				{"life", Alive},
+				{"virtual-ip-address", s.doc.VirtualIPAddress},
+			},
+			Update: bson.D{{"$set", bson.D{
+				{"virtual-ip-holder", unitName},
+				{"virtual-ip-instance", string(instId)},
+			}}},
+		}}, nil
+	}
+	if err := s.st.run(buildTxnWithLeadership(buildTxn, token)); err != nil {
+		return errors.Trace(err)
+	}
+	s.doc.VirtualIPHolder = unitName
+	s.doc.VirtualIPInstance = string(instId)
+	return nil
+}
+
+// ReleaseVirtualIPAddress removes the application's virtual IP address,
+// if it has one, and releases its reservation.
+func (s *Application) ReleaseVirtualIPAddress() (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot release virtual IP address of application %q", s)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := s.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if s.doc.VirtualIPAddress == "" {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      applicationsC,
+			Id:     s.doc.DocID,
+			Assert: bson.D{{"virtual-ip-address", s.doc.VirtualIPAddress}},
+			Update: bson.D{{"$unset", bson.D{
+				{"virtual-ip-address", nil},
+				{"virtual-ip-subnet", nil},
+				{"virtual-ip-holder", nil},
+				{"virtual-ip-instance", nil},
+			}}},
+		}, s.st.releaseIPAddressOp(s.doc.VirtualIPAddress)}, nil
+	}
+	if err := s.st.run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	s.doc.VirtualIPAddress = ""
+	s.doc.VirtualIPSubnet = ""
+	s.doc.VirtualIPHolder = ""
+	s.doc.VirtualIPInstance = ""
+	return nil
+}
+
+// virtualIPCleanupOp returns the operation scheduling the release of
+// the application's virtual IP address, from the instance it was last
+// assigned to and then from its reservation, once the application has
+// been removed.
+func (s *Application) virtualIPCleanupOp() txn.Op {
+	fields := []string{s.doc.VirtualIPAddress, s.doc.VirtualIPSubnet}
+	if s.doc.VirtualIPInstance != "" {
+		fields = append(fields, s.doc.VirtualIPInstance)
+	}
+	return s.st.newCleanupOp(cleanupVirtualIPAddress, strings.Join(fields, " "))
+}
+
+// cleanupVirtualIPAddress releases the virtual IP address of a removed
+// application, described by the prefix of a cleanup scheduled by
+// virtualIPCleanupOp. The address is released from the instance it was
+// last assigned to, if the provider supports it, before its reservation
+// is released; until then, it cannot be allocated again.
+func (st *State) cleanupVirtualIPAddress(prefix string) error {
+	fields := strings.Fields(prefix)
+	if len(fields) < 2 {
+		return errors.Errorf("invalid virtual IP address cleanup %q", prefix)
+	}
+	address, subnetCIDR := fields[0], fields[1]
+	if len(fields) > 2 && st.policy != nil {
+		instId := instance.Id(fields[2])
+		cfg, err := st.ModelConfig()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		releaser, err := st.policy.VirtualIPReleaser(cfg)
+		if errors.IsNotImplemented(err) {
+			logger.Warningf("cannot release virtual IP address %q from instance %q: %v", address, instId, err)
+		} else if err != nil {
+			return errors.Trace(err)
+		} else if err := releaser.ReleaseVirtualIPAddress(instId, address, subnetCIDR); err != nil && !errors.IsNotFound(err) {
+			return errors.Annotatef(err, "cannot release virtual IP address %q from instance %q", address, instId)
+		}
+	}
+	return st.runTransaction([]txn.Op{st.releaseIPAddressOp(address)})
+}
+
+// usedIPAddresses returns the addresses that cannot be allocated as
+// virtual IP addresses: those of machines' devices and their gateways,
+// those requested as static addresses of machines, the virtual IP
+// addresses of other applications, and those still reserved for
+// removed applications.
+func (st *State) usedIPAddresses() (set.Strings, error) {
+	used := set.NewStrings()
+
+	reservations, closer := st.getCollection(providerIDsC)
+	defer closer()
+	var reservationDocs []providerIdDoc
+	reservationPrefix := st.ipAddressReservationKey("")
+	query := bson.D{{"_id", bson.D{{"$regex", "^" + reservationPrefix}}}}
+	if err := reservations.Find(query).All(&reservationDocs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, doc := range reservationDocs {
+		used.Add(strings.TrimPrefix(doc.ID, reservationPrefix))
+	}
+
+	addresses, closer := st.getCollection(ipAddressesC)
+	defer closer()
+	var addressDocs []ipAddressDoc
+	if err := addresses.Find(nil).All(&addressDocs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, doc := range addressDocs {
+		used.Add(doc.Value)
+		if doc.GatewayAddress != "" {
+			used.Add(doc.GatewayAddress)
+		}
+	}
+
+	machines, closer := st.getCollection(machinesC)
+	defer closer()
+	var machineDocs []machineDoc
+	query = bson.D{{"staticipaddress", bson.D{{"$exists", true}}}}
+	if err := machines.Find(query).All(&machineDocs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, doc := range machineDocs {
+		used.Add(doc.StaticIPAddress)
+	}
+
+	applications, closer := st.getCollection(applicationsC)
+	defer closer()
+	var applicationDocs []applicationDoc
+	query = bson.D{{"virtual-ip-address", bson.D{{"$exists", true}}}}
+	if err := applications.Find(query).All(&applicationDocs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, doc := range applicationDocs {
+		used.Add(doc.VirtualIPAddress)
+	}
+	return used, nil
+}
+
+// freeIPAddress returns the highest address of the given subnet that
+// is not in the used set. The subnet's network address and, for IPv4,
+// its broadcast address are never returned.
+func freeIPAddress(ipNet *net.IPNet, used set.Strings) (string, error) {
+	ip := ipNet.IP.To4()
+	if ip == nil {
+		ip = ipNet.IP.To16()
+	}
+	ones, bits := ipNet.Mask.Size()
+	first := new(big.Int).SetBytes(ip)
+	size := new(big.Int).Lsh(big.NewInt(1), uint(bits-ones))
+	last := new(big.Int).Add(first, size)
+	last.Sub(last, big.NewInt(1))
+	if len(ip) == net.IPv4len {
+		// Skip the broadcast address.
+		last.Sub(last, big.NewInt(1))
+	}
+	one := big.NewInt(1)
+	for i := 0; i < maxVirtualIPCandidates && last.Cmp(first) > 0; i++ {
+		candidate := bigIntToIP(last, len(ip)).String()
+		if !used.Contains(candidate) {
+			return candidate, nil
+		}
+		last.Sub(last, one)
+	}
+	return "", errors.Errorf("no free address in subnet %q", ipNet.String())
+}
+
+// bigIntToIP returns the IP address of the given length with the
+// value of n.
+func bigIntToIP(n *big.Int, length int) net.IP {
+	b := n.Bytes()
+	ip := make(net.IP, length)
+	copy(ip[length-len(b):], b)
+	return ip
+}
@@ -0,0 +1,277 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
+)
+
+type virtualIPSuite struct {
+	ConnSuite
+	application *state.Application
+}
+
+var _ = gc.Suite(&virtualIPSuite{})
+
+func (s *virtualIPSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.application = s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	for _, cidr := range []string{"10.20.0.0/29", "fc00::/126"} {
+		_, err := s.State.AddSubnet(state.SubnetInfo{CIDR: cidr})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *virtualIPSuite) TestAllocateVirtualIPAddress(c *gc.C) {
+	address, err := s.application.AllocateVirtualIPAddress("10.20.0.0/29", "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(address, gc.Equals, "10.20.0.6")
+
+	err = s.application.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	address, subnetCIDR := s.application.VirtualIPAddress()
+	c.Check(address, gc.Equals, "10.20.0.6")
+	c.Check(subnetCIDR, gc.Equals, "10.20.0.0/29")
+	c.Check(s.application.VirtualIPHolder(), gc.Equals, "")
+}
+
+func (s *virtualIPSuite) TestAllocateVirtualIPAddressIPv6(c *gc.C) {
+	address, err := s.application.AllocateVirtualIPAddress("fc00::/126", "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(address, gc.Equals, "fc00::3")
+}
+
+func (s *virtualIPSuite) TestAllocateVirtualIPAddressSkipsUsedAddresses(c *gc.C) {
+	other := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	_, err := other.AllocateVirtualIPAddress("10.20.0.0/29", "10.20.0.6")
+	c.Assert(err, jc.ErrorIsNil)
+	host, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series:          "quantal",
+		Jobs:            []state.MachineJob{state.JobHostUnits},
+		StaticIPAddress: "10.20.0.5",
+	}, host.Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+
+	address, err := s.application.AllocateVirtualIPAddress("10.20.0.0/29", "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(address, gc.Equals, "10.20.0.4")
+
+	_, err = other.AllocateVirtualIPAddress("10.20.0.0/29", "")
+	c.Check(err, gc.ErrorMatches, `cannot allocate virtual IP address for application "mysql": virtual IP address "10.20.0.6" already exists`)
+}
+
+func (s *virtualIPSuite) TestAllocateVirtualIPAddressGiven(c *gc.C) {
+	address, err := s.application.AllocateVirtualIPAddress("10.20.0.0/29", "10.20.0.2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(address, gc.Equals, "10.20.0.2")
+
+	other := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	_, err = other.AllocateVirtualIPAddress("10.20.0.0/29", "10.20.0.2")
+	c.Check(err, gc.ErrorMatches, `cannot allocate virtual IP address for application "mysql": address "10.20.0.2" already exists`)
+	c.Check(errors.Cause(err), jc.Satisfies, errors.IsAlreadyExists)
+
+	_, err = other.AllocateVirtualIPAddress("10.20.0.0/29", "10.30.0.2")
+	c.Check(err, gc.ErrorMatches, `cannot allocate virtual IP address for application "mysql": address "10.30.0.2" is not part of subnet "10.20.0.0/29"`)
+	c.Check(errors.Cause(err), jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *virtualIPSuite) TestAllocateVirtualIPAddressFailsWhenStaticIPAddressRequestedConcurrently(c *gc.C) {
+	host, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	defer state.SetBeforeHooks(c, s.State, func() {
+		_, err := s.State.AddMachineInsideMachine(state.MachineTemplate{
+			Series:          "quantal",
+			Jobs:            []state.MachineJob{state.JobHostUnits},
+			StaticIPAddress: "10.20.0.2",
+		}, host.Id(), instance.LXD)
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	_, err = s.application.AllocateVirtualIPAddress("10.20.0.0/29", "10.20.0.2")
+	c.Check(err, gc.ErrorMatches, `cannot allocate virtual IP address for application "wordpress": address "10.20.0.2" already exists`)
+	c.Check(errors.Cause(err), jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *virtualIPSuite) TestAllocateVirtualIPAddressSkipsAddressAllocatedConcurrently(c *gc.C) {
+	other := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	defer state.SetBeforeHooks(c, s.State, func() {
+		_, err := other.AllocateVirtualIPAddress("10.20.0.0/29", "")
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	address, err := s.application.AllocateVirtualIPAddress("10.20.0.0/29", "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(address, gc.Equals, "10.20.0.5")
+}
+
+func (s *virtualIPSuite) TestAllocateVirtualIPAddressUnknownSubnet(c *gc.C) {
+	_, err := s.application.AllocateVirtualIPAddress("192.168.0.0/24", "")
+	c.Check(err, gc.ErrorMatches, `cannot allocate virtual IP address for application "wordpress": subnet "192.168.0.0/24" not found`)
+	c.Check(errors.Cause(err), jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *virtualIPSuite) TestAllocateVirtualIPAddressNoFreeAddress(c *gc.C) {
+	for _, name := range []string{"a", "b", "c", "d", "e", "f"} {
+		application := s.AddTestingService(c, "mysql-"+name, s.AddTestingCharm(c, "mysql"))
+		_, err := application.AllocateVirtualIPAddress("10.20.0.0/29", "")
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	_, err := s.application.AllocateVirtualIPAddress("10.20.0.0/29", "")
+	c.Check(err, gc.ErrorMatches, `cannot allocate virtual IP address for application "wordpress": no free address in subnet "10.20.0.0/29"`)
+}
+
+func (s *virtualIPSuite) TestStaticIPAddressCannotUseVirtualIPAddress(c *gc.C) {
+	_, err := s.application.AllocateVirtualIPAddress("10.20.0.0/29", "10.20.0.2")
+	c.Assert(err, jc.ErrorIsNil)
+	host, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series:          "quantal",
+		Jobs:            []state.MachineJob{state.JobHostUnits},
+		StaticIPAddress: "10.20.0.2",
+	}, host.Id(), instance.LXD)
+	c.Check(err, gc.ErrorMatches, `cannot add a new machine: static IP address "10.20.0.2" already allocated to application "wordpress"`)
+	c.Check(errors.Cause(err), jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *virtualIPSuite) TestSetVirtualIPHolder(c *gc.C) {
+	err := s.application.SetVirtualIPHolder(&fakeToken{}, "wordpress/0", "i-0")
+	c.Check(err, gc.ErrorMatches, `cannot set virtual IP holder for application "wordpress": virtual IP address not found`)
+	c.Check(errors.Cause(err), jc.Satisfies, errors.IsNotFound)
+
+	_, err = s.application.AllocateVirtualIPAddress("10.20.0.0/29", "")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.SetVirtualIPHolder(&fakeToken{}, "wordpress/0", "i-0")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.application.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.application.VirtualIPHolder(), gc.Equals, "wordpress/0")
+}
+
+func (s *virtualIPSuite) TestSetVirtualIPHolderTokenError(c *gc.C) {
+	_, err := s.application.AllocateVirtualIPAddress("10.20.0.0/29", "")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.SetVirtualIPHolder(&failToken{}, "wordpress/0", "i-0")
+	c.Check(err, gc.ErrorMatches, `cannot set virtual IP holder for application "wordpress": prerequisites failed: something bad happened`)
+}
+
+func (s *virtualIPSuite) TestSetVirtualIPHolderTokenAssertFailure(c *gc.C) {
+	_, err := s.application.AllocateVirtualIPAddress("10.20.0.0/29", "")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.SetVirtualIPHolder(&raceToken{}, "wordpress/0", "i-0")
+	c.Check(err, gc.ErrorMatches, `cannot set virtual IP holder for application "wordpress": prerequisites failed: too late`)
+
+	err = s.application.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.application.VirtualIPHolder(), gc.Equals, "")
+}
+
+func (s *virtualIPSuite) TestReleaseVirtualIPAddress(c *gc.C) {
+	_, err := s.application.AllocateVirtualIPAddress("10.20.0.0/29", "")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.SetVirtualIPHolder(&fakeToken{}, "wordpress/0", "i-0")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.application.ReleaseVirtualIPAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	address, subnetCIDR := s.application.VirtualIPAddress()
+	c.Check(address, gc.Equals, "")
+	c.Check(subnetCIDR, gc.Equals, "")
+	c.Check(s.application.VirtualIPHolder(), gc.Equals, "")
+
+	// Releasing again is a no-op.
+	err = s.application.ReleaseVirtualIPAddress()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *virtualIPSuite) TestReleaseVirtualIPAddressFreesAddress(c *gc.C) {
+	_, err := s.application.AllocateVirtualIPAddress("10.20.0.0/29", "10.20.0.2")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.ReleaseVirtualIPAddress()
+	c.Assert(err, jc.ErrorIsNil)
+
+	host, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series:          "quantal",
+		Jobs:            []state.MachineJob{state.JobHostUnits},
+		StaticIPAddress: "10.20.0.2",
+	}, host.Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *virtualIPSuite) TestRemoveApplicationFreesVirtualIPAddress(c *gc.C) {
+	_, err := s.application.AllocateVirtualIPAddress("10.20.0.0/29", "10.20.0.2")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.Refresh()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// The address stays reserved until the cleanup has run.
+	other := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	_, err = other.AllocateVirtualIPAddress("10.20.0.0/29", "10.20.0.2")
+	c.Assert(err, gc.ErrorMatches, `cannot allocate virtual IP address for application "mysql": address "10.20.0.2" already exists`)
+	assertCleanupRuns(c, s.State)
+
+	address, err := other.AllocateVirtualIPAddress("10.20.0.0/29", "10.20.0.2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(address, gc.Equals, "10.20.0.2")
+}
+
+func (s *virtualIPSuite) TestRemoveApplicationReleasesVirtualIPAddressFromHolder(c *gc.C) {
+	releaser := &fakeVirtualIPReleaser{}
+	s.policy.GetVirtualIPReleaser = func(*config.Config) (state.VirtualIPReleaser, error) {
+		return releaser, nil
+	}
+	_, err := s.application.AllocateVirtualIPAddress("10.20.0.0/29", "10.20.0.2")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.SetVirtualIPHolder(&fakeToken{}, "wordpress/0", "i-0")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The reservation is kept while the address cannot be released
+	// from the holder's instance.
+	releaser.SetErrors(errors.New("instance unreachable"))
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+	other := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	_, err = other.AllocateVirtualIPAddress("10.20.0.0/29", "10.20.0.2")
+	c.Assert(err, gc.ErrorMatches, `.* address "10.20.0.2" already exists`)
+
+	assertNeedsCleanup(c, s.State)
+	assertCleanupRuns(c, s.State)
+	assertDoesNotNeedCleanup(c, s.State)
+	releaser.CheckCalls(c, []jujutesting.StubCall{
+		{FuncName: "ReleaseVirtualIPAddress", Args: []interface{}{instance.Id("i-0"), "10.20.0.2", "10.20.0.0/29"}},
+		{FuncName: "ReleaseVirtualIPAddress", Args: []interface{}{instance.Id("i-0"), "10.20.0.2", "10.20.0.0/29"}},
+	})
+	_, err = other.AllocateVirtualIPAddress("10.20.0.0/29", "10.20.0.2")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+// fakeVirtualIPReleaser implements state.VirtualIPReleaser.
+type fakeVirtualIPReleaser struct {
+	jujutesting.Stub
+}
+
+// ReleaseVirtualIPAddress is part of the state.VirtualIPReleaser interface.
+func (r *fakeVirtualIPReleaser) ReleaseVirtualIPAddress(instId instance.Id, address, subnetCIDR string) error {
+	r.AddCall("ReleaseVirtualIPAddress", instId, address, subnetCIDR)
+	return r.NextErr()
+}
@@ -46,6 +46,15 @@ type Volume interface {
 	// if it has not already been provisioned. Params returns true if the
 	// returned parameters are usable for provisioning, otherwise false.
 	Params() (VolumeParams, bool)
+
+	// RequestedSize returns the size, in MiB, that the volume has been
+	// requested to grow to. RequestedSize returns false if there is no
+	// outstanding request to resize the volume.
+	RequestedSize() (uint64, bool)
+
+	// Releasing reports whether the volume is to be released from the
+	// model, rather than destroyed, once it is Dead.
+	Releasing() bool
 }
 
 // VolumeAttachment describes an attachment of a volume to a machine.
@@ -91,6 +100,8 @@ type volumeDoc struct {
 	Binding         string        `bson:"binding,omitempty"`
 	Info            *VolumeInfo   `bson:"info,omitempty"`
 	Params          *VolumeParams `bson:"params,omitempty"`
+	RequestedSize   uint64        `bson:"requestedsize,omitempty"`
+	Releasing       bool          `bson:"releasing,omitempty"`
 }
 
 // volumeAttachmentDoc records information about a volume attachment.
@@ -117,6 +128,10 @@ type VolumeParams struct {
 
 	Pool string `bson:"pool"`
 	Size uint64 `bson:"size"`
+
+	// Snapshot, if non-empty, is the ID of the volume snapshot
+	// that the volume is to be created from.
+	Snapshot string `bson:"snapshot,omitempty"`
 }
 
 // VolumeInfo describes information about a volume.
@@ -236,6 +251,16 @@ func (v *volume) Params() (VolumeParams, bool) {
 	return *v.doc.Params, true
 }
 
+// RequestedSize is required to implement Volume.
+func (v *volume) RequestedSize() (uint64, bool) {
+	return v.doc.RequestedSize, v.doc.RequestedSize != 0
+}
+
+// Releasing is required to implement Volume.
+func (v *volume) Releasing() bool {
+	return v.doc.Releasing
+}
+
 // Status is required to implement StatusGetter.
 func (v *volume) Status() (status.StatusInfo, error) {
 	return v.st.VolumeStatus(v.VolumeTag())
@@ -440,6 +465,13 @@ func (st *State) removeMachineVolumesOps(machine names.MachineTag) ([]txn.Op, er
 			Assert: txn.DocExists,
 			Remove: true,
 		})
+		if _, ok := names.VolumeMachine(volumeTag); ok {
+			snapshotOps, err := st.removeVolumeSnapshotsOps(volumeTag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, snapshotOps...)
+		}
 	}
 	return ops, nil
 }
@@ -658,19 +690,22 @@ func (st *State) DestroyVolume(tag names.VolumeTag) (err error) {
 		if volume.Life() != Alive {
 			return nil, jujutxn.ErrNoOperations
 		}
-		return destroyVolumeOps(st, volume), nil
+		return destroyVolumeOps(st, volume, false), nil
 	}
 	return st.run(buildTxn)
 }
 
-func destroyVolumeOps(st *State, v *volume) []txn.Op {
+// destroyVolumeOps returns txn.Ops to destroy the volume. If release is
+// true, the volume will be released from the model when it is Dead,
+// rather than being destroyed.
+func destroyVolumeOps(st *State, v *volume, release bool) []txn.Op {
 	if v.doc.AttachmentCount == 0 {
 		hasNoAttachments := bson.D{{"attachmentcount", 0}}
 		return []txn.Op{{
 			C:      volumesC,
 			Id:     v.doc.Name,
 			Assert: append(hasNoAttachments, isAliveDoc...),
-			Update: bson.D{{"$set", bson.D{{"life", Dead}}}},
+			Update: bson.D{{"$set", lifeWithReleasing(Dead, release)}},
 		}}
 	}
 	cleanupOp := st.newCleanupOp(cleanupAttachmentsForDyingVolume, v.doc.Name)
@@ -679,10 +714,21 @@ func destroyVolumeOps(st *State, v *volume) []txn.Op {
 		C:      volumesC,
 		Id:     v.doc.Name,
 		Assert: append(hasAttachments, isAliveDoc...),
-		Update: bson.D{{"$set", bson.D{{"life", Dying}}}},
+		Update: bson.D{{"$set", lifeWithReleasing(Dying, release)}},
 	}, cleanupOp}
 }
 
+// lifeWithReleasing returns the fields to $set on a storage instance,
+// volume or filesystem document to advance its life, and to mark it for
+// release if required.
+func lifeWithReleasing(life Life, release bool) bson.D {
+	set := bson.D{{"life", life}}
+	if release {
+		set = append(set, bson.DocElem{"releasing", true})
+	}
+	return set
+}
+
 // RemoveVolume removes the volume from state. RemoveVolume will fail if
 // the volume is not Dead, which implies that it still has attachments.
 func (st *State) RemoveVolume(tag names.VolumeTag) (err error) {
@@ -697,7 +743,7 @@ func (st *State) RemoveVolume(tag names.VolumeTag) (err error) {
 		if volume.Life() != Dead {
 			return nil, errors.New("volume is not dead")
 		}
-		return []txn.Op{
+		ops := []txn.Op{
 			{
 				C:      volumesC,
 				Id:     tag.Id(),
@@ -705,7 +751,11 @@ func (st *State) RemoveVolume(tag names.VolumeTag) (err error) {
 				Remove: true,
 			},
 			removeStatusOp(st, volumeGlobalKey(tag.Id())),
-		}, nil
+		}
+		if info, err := volume.Info(); err == nil {
+			ops = append(ops, removeStorageProviderIdOp(st, "volume", info.VolumeId))
+		}
+		return ops, nil
 	}
 	return st.run(buildTxn)
 }
@@ -975,6 +1025,16 @@ func (st *State) SetVolumeInfo(tag names.VolumeTag, info VolumeInfo) (err error)
 			}
 		}
 		ops = append(ops, setVolumeInfoOps(tag, info, unsetParams)...)
+		if size, ok := v.RequestedSize(); ok && info.Size >= size {
+			// The volume has grown to the requested size,
+			// so the resize request has been satisfied.
+			ops = append(ops, txn.Op{
+				C:      volumesC,
+				Id:     tag.Id(),
+				Assert: bson.D{{"requestedsize", size}},
+				Update: bson.D{{"$unset", bson.D{{"requestedsize", nil}}}},
+			})
+		}
 		return ops, nil
 	}
 	return st.run(buildTxn)
@@ -0,0 +1,354 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/storage"
+)
+
+// VolumeSnapshot describes a point-in-time copy of a volume.
+type VolumeSnapshot interface {
+	// Id returns the unique ID of the snapshot. The ID is made up of
+	// the ID of the volume the snapshot was taken from, and a sequence
+	// number; e.g. "0/1:2" is snapshot 2, taken of volume 0/1.
+	Id() string
+
+	// Volume returns the tag of the volume that the snapshot was
+	// taken from.
+	Volume() names.VolumeTag
+
+	// Pool returns the name of the storage pool of the volume that
+	// the snapshot was taken from. Volumes created from the snapshot
+	// must be created in the same pool.
+	Pool() string
+
+	// ProviderVolumeId returns the provider-allocated ID of the volume
+	// that the snapshot was taken from. The snapshot keeps the ID so
+	// that it can be managed after the volume has been removed.
+	ProviderVolumeId() string
+
+	// Life returns the life of the snapshot.
+	Life() Life
+
+	// Created returns the time at which the snapshot was requested.
+	Created() time.Time
+
+	// Info returns the snapshot's VolumeSnapshotInfo, or a NotProvisioned
+	// error if the snapshot has not yet been taken.
+	Info() (VolumeSnapshotInfo, error)
+}
+
+type volumeSnapshot struct {
+	doc volumeSnapshotDoc
+}
+
+// volumeSnapshotDoc records information about a volume snapshot.
+type volumeSnapshotDoc struct {
+	DocID            string              `bson:"_id"`
+	Id               string              `bson:"snapshotid"`
+	ModelUUID        string              `bson:"model-uuid"`
+	Volume           string              `bson:"volumeid"`
+	Pool             string              `bson:"pool"`
+	ProviderVolumeId string              `bson:"providervolumeid"`
+	Life             Life                `bson:"life"`
+	Created          time.Time           `bson:"created"`
+	Info             *VolumeSnapshotInfo `bson:"info,omitempty"`
+}
+
+// VolumeSnapshotInfo describes information about a volume snapshot.
+type VolumeSnapshotInfo struct {
+	SnapshotId string `bson:"snapshotid"`
+	Size       uint64 `bson:"size"`
+}
+
+// Id is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Id() string {
+	return s.doc.Id
+}
+
+// Volume is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Volume() names.VolumeTag {
+	return names.NewVolumeTag(s.doc.Volume)
+}
+
+// Pool is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Pool() string {
+	return s.doc.Pool
+}
+
+// ProviderVolumeId is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) ProviderVolumeId() string {
+	return s.doc.ProviderVolumeId
+}
+
+// Life is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Life() Life {
+	return s.doc.Life
+}
+
+// Created is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Created() time.Time {
+	return s.doc.Created
+}
+
+// Info is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Info() (VolumeSnapshotInfo, error) {
+	if s.doc.Info == nil {
+		return VolumeSnapshotInfo{}, errors.NotProvisionedf("volume snapshot %q", s.doc.Id)
+	}
+	return *s.doc.Info, nil
+}
+
+var validVolumeSnapshotSeq = regexp.MustCompile("^" + names.NumberSnippet + "$")
+
+// IsValidVolumeSnapshotId reports whether the supplied string is a
+// valid volume snapshot ID.
+func IsValidVolumeSnapshotId(id string) bool {
+	colon := strings.LastIndex(id, ":")
+	if colon == -1 {
+		return false
+	}
+	if !names.IsValidVolume(id[:colon]) {
+		return false
+	}
+	return validVolumeSnapshotSeq.MatchString(id[colon+1:])
+}
+
+// VolumeSnapshot returns the volume snapshot with the specified ID.
+func (st *State) VolumeSnapshot(id string) (VolumeSnapshot, error) {
+	s, err := st.volumeSnapshot(id)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (st *State) volumeSnapshot(id string) (*volumeSnapshot, error) {
+	snapshots, err := st.volumeSnapshots(bson.D{{"_id", id}})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(snapshots) == 0 {
+		return nil, errors.NotFoundf("volume snapshot %q", id)
+	}
+	return snapshots[0], nil
+}
+
+// VolumeSnapshots returns all of the snapshots taken of the specified
+// volume.
+func (st *State) VolumeSnapshots(volume names.VolumeTag) ([]VolumeSnapshot, error) {
+	snapshots, err := st.volumeSnapshots(bson.D{{"volumeid", volume.Id()}})
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get snapshots for volume %s", volume.Id())
+	}
+	return volumeSnapshotsToInterfaces(snapshots), nil
+}
+
+// AllVolumeSnapshots returns all of the volume snapshots in the model.
+func (st *State) AllVolumeSnapshots() ([]VolumeSnapshot, error) {
+	snapshots, err := st.volumeSnapshots(nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get volume snapshots")
+	}
+	return volumeSnapshotsToInterfaces(snapshots), nil
+}
+
+func (st *State) volumeSnapshots(query interface{}) ([]*volumeSnapshot, error) {
+	coll, cleanup := st.getCollection(volumeSnapshotsC)
+	defer cleanup()
+
+	var docs []volumeSnapshotDoc
+	if err := coll.Find(query).Sort("created").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "querying volume snapshots")
+	}
+	snapshots := make([]*volumeSnapshot, len(docs))
+	for i, doc := range docs {
+		snapshots[i] = &volumeSnapshot{doc}
+	}
+	return snapshots, nil
+}
+
+func volumeSnapshotsToInterfaces(snapshots []*volumeSnapshot) []VolumeSnapshot {
+	result := make([]VolumeSnapshot, len(snapshots))
+	for i, s := range snapshots {
+		result[i] = s
+	}
+	return result
+}
+
+// AddVolumeSnapshot requests that a snapshot be taken of the specified
+// volume. The volume must be alive and provisioned, and its storage
+// provider must support snapshots; the snapshot itself will be taken
+// by the volume's storage provisioner at some point in the future.
+func (st *State) AddVolumeSnapshot(volume names.VolumeTag) (_ VolumeSnapshot, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot add snapshot of volume %s", volume.Id())
+	seq, err := st.sequence("volumesnapshot")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	id := fmt.Sprintf("%s:%d", volume.Id(), seq)
+	doc := volumeSnapshotDoc{
+		Id:      id,
+		Volume:  volume.Id(),
+		Life:    Alive,
+		Created: GetClock().Now().UTC(),
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		v, err := st.volumeByTag(volume)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if v.Life() != Alive {
+			return nil, errors.New("volume is not alive")
+		}
+		info, err := v.Info()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := checkVolumeSnapshotsSupported(st, info.Pool); err != nil {
+			return nil, errors.Trace(err)
+		}
+		doc.Pool = info.Pool
+		doc.ProviderVolumeId = info.VolumeId
+		return []txn.Op{{
+			C:      volumesC,
+			Id:     volume.Id(),
+			Assert: append(bson.D{{"info", bson.D{{"$exists", true}}}}, isAliveDoc...),
+		}, {
+			C:      volumeSnapshotsC,
+			Id:     id,
+			Assert: txn.DocMissing,
+			Insert: &doc,
+		}}, nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return nil, err
+	}
+	return &volumeSnapshot{doc}, nil
+}
+
+// checkVolumeSnapshotsSupported returns an error satisfying
+// errors.IsNotSupported if the storage provider of the named pool
+// cannot take volume snapshots.
+func checkVolumeSnapshotsSupported(st *State, poolName string) error {
+	providerType, provider, err := poolStorageProvider(st, poolName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if p, ok := provider.(storage.VolumeSnapshotProvider); !ok || !p.SupportsVolumeSnapshots() {
+		return errors.NotSupportedf("snapshots of volumes in pool %q with storage provider %q", poolName, providerType)
+	}
+	return nil
+}
+
+// SetVolumeSnapshotInfo records the details of a newly taken volume
+// snapshot.
+func (st *State) SetVolumeSnapshotInfo(id string, info VolumeSnapshotInfo) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set info for volume snapshot %q", id)
+	if info.SnapshotId == "" {
+		return errors.New("snapshot ID not set")
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := st.volumeSnapshot(id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if oldInfo, err := s.Info(); err == nil {
+			if oldInfo.SnapshotId != info.SnapshotId {
+				return nil, errors.Errorf(
+					"cannot change snapshot ID from %q to %q",
+					oldInfo.SnapshotId, info.SnapshotId,
+				)
+			}
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      volumeSnapshotsC,
+			Id:     id,
+			Assert: bson.D{{"info", bson.D{{"$exists", false}}}},
+			Update: bson.D{{"$set", bson.D{{"info", &info}}}},
+		}}, nil
+	}
+	return st.run(buildTxn)
+}
+
+// DestroyVolumeSnapshot ensures that the volume snapshot will be deleted
+// and removed from state at some point in the future.
+func (st *State) DestroyVolumeSnapshot(id string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "destroying volume snapshot %q", id)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := st.volumeSnapshot(id)
+		if errors.IsNotFound(err) {
+			return nil, jujutxn.ErrNoOperations
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if s.Life() != Alive {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      volumeSnapshotsC,
+			Id:     id,
+			Assert: isAliveDoc,
+			Update: bson.D{{"$set", bson.D{{"life", Dying}}}},
+		}}, nil
+	}
+	return st.run(buildTxn)
+}
+
+// removeVolumeSnapshotsOps returns txn.Ops to remove all of the snapshots
+// of the specified volume, regardless of their life. This is used when
+// removing a machine-scoped volume along with its machine: the snapshots
+// were managed by the machine's storage provisioner, so there is nothing
+// left to delete them.
+func (st *State) removeVolumeSnapshotsOps(volume names.VolumeTag) ([]txn.Op, error) {
+	snapshots, err := st.volumeSnapshots(bson.D{{"volumeid", volume.Id()}})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops := make([]txn.Op, len(snapshots))
+	for i, s := range snapshots {
+		ops[i] = txn.Op{
+			C:      volumeSnapshotsC,
+			Id:     s.doc.Id,
+			Remove: true,
+		}
+	}
+	return ops, nil
+}
+
+// RemoveVolumeSnapshot removes the volume snapshot from state.
+// RemoveVolumeSnapshot will fail if the snapshot is still Alive.
+func (st *State) RemoveVolumeSnapshot(id string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "removing volume snapshot %q", id)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := st.volumeSnapshot(id)
+		if errors.IsNotFound(err) {
+			return nil, jujutxn.ErrNoOperations
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if s.Life() == Alive {
+			return nil, errors.New("volume snapshot is not dying")
+		}
+		return []txn.Op{{
+			C:      volumeSnapshotsC,
+			Id:     id,
+			Assert: bson.D{{"life", bson.D{{"$ne", Alive}}}},
+			Remove: true,
+		}}, nil
+	}
+	return st.run(buildTxn)
+}
@@ -0,0 +1,287 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/testing"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider/dummy"
+	"github.com/juju/juju/storage/provider/registry"
+)
+
+type VolumeSnapshotSuite struct {
+	StorageStateSuiteBase
+
+	unit      *state.Unit
+	volumeTag names.VolumeTag
+}
+
+var _ = gc.Suite(&VolumeSnapshotSuite{})
+
+func (s *VolumeSnapshotSuite) SetUpTest(c *gc.C) {
+	s.StorageStateSuiteBase.SetUpTest(c)
+	storageCons := map[string]state.StorageConstraints{
+		"multi1to10": makeStorageCons("loop-pool", 1024, 1),
+	}
+	ch := s.AddTestingCharm(c, "storage-block2")
+	application, err := s.State.AddApplication(state.AddApplicationArgs{
+		Name: "storage-block2", Charm: ch, Storage: storageCons,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.unit, err = application.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AssignUnit(s.unit, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	s.volumeTag = names.NewVolumeTag("0/0")
+}
+
+func (s *VolumeSnapshotSuite) provisionVolume(c *gc.C) {
+	err := s.State.SetVolumeInfo(s.volumeTag, state.VolumeInfo{
+		VolumeId: "volume-0-0",
+		Size:     1024,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *VolumeSnapshotSuite) addProvisionedSnapshot(c *gc.C) state.VolumeSnapshot {
+	s.provisionVolume(c)
+	snapshot, err := s.State.AddVolumeSnapshot(s.volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetVolumeSnapshotInfo(snapshot.Id(), state.VolumeSnapshotInfo{
+		SnapshotId: "snapshot-0-0-0",
+		Size:       1024,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return snapshot
+}
+
+func (s *VolumeSnapshotSuite) TestAddVolumeSnapshot(c *gc.C) {
+	s.provisionVolume(c)
+	snapshot, err := s.State.AddVolumeSnapshot(s.volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshot.Id(), gc.Equals, "0/0:0")
+	c.Assert(snapshot.Volume(), gc.Equals, s.volumeTag)
+	c.Assert(snapshot.Pool(), gc.Equals, "loop-pool")
+	c.Assert(snapshot.ProviderVolumeId(), gc.Equals, "volume-0-0")
+	c.Assert(snapshot.Life(), gc.Equals, state.Alive)
+	c.Assert(snapshot.Created().IsZero(), jc.IsFalse)
+	_, err = snapshot.Info()
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+
+	snapshots, err := s.State.VolumeSnapshots(s.volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshots, gc.HasLen, 1)
+	c.Assert(snapshots[0].Id(), gc.Equals, "0/0:0")
+}
+
+func (s *VolumeSnapshotSuite) TestAddVolumeSnapshotUnprovisioned(c *gc.C) {
+	_, err := s.State.AddVolumeSnapshot(s.volumeTag)
+	c.Assert(err, gc.ErrorMatches, `cannot add snapshot of volume 0/0: volume "0/0" not provisioned`)
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+}
+
+func (s *VolumeSnapshotSuite) TestAddVolumeSnapshotNotSupported(c *gc.C) {
+	registry.RegisterProvider("nosnapshots", &dummy.StorageProvider{
+		StorageScope: storage.ScopeEnviron,
+		IsDynamic:    true,
+	})
+	defer registry.RegisterProvider("nosnapshots", nil)
+	registry.RegisterEnvironStorageProviders("someprovider", "nosnapshots")
+
+	machine, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+		Volumes: []state.MachineVolumeParams{{
+			Volume: state.VolumeParams{Pool: "nosnapshots", Size: 1024},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	attachments, err := s.State.MachineVolumeAttachments(machine.MachineTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachments, gc.HasLen, 1)
+	volumeTag := attachments[0].Volume()
+	err = s.State.SetVolumeInfo(volumeTag, state.VolumeInfo{VolumeId: "vol-1", Size: 1024})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.AddVolumeSnapshot(volumeTag)
+	c.Assert(err, gc.ErrorMatches, `cannot add snapshot of volume .*: snapshots of volumes in pool "nosnapshots" with storage provider "nosnapshots" not supported`)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	snapshots, err := s.State.VolumeSnapshots(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshots, gc.HasLen, 0)
+}
+
+func (s *VolumeSnapshotSuite) TestAddVolumeSnapshotNotFound(c *gc.C) {
+	_, err := s.State.AddVolumeSnapshot(names.NewVolumeTag("42"))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *VolumeSnapshotSuite) TestSetVolumeSnapshotInfo(c *gc.C) {
+	snapshot := s.addProvisionedSnapshot(c)
+	snapshot, err := s.State.VolumeSnapshot(snapshot.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	info, err := snapshot.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, state.VolumeSnapshotInfo{
+		SnapshotId: "snapshot-0-0-0",
+		Size:       1024,
+	})
+
+	err = s.State.SetVolumeSnapshotInfo(snapshot.Id(), state.VolumeSnapshotInfo{
+		SnapshotId: "something-else",
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot set info for volume snapshot "0/0:0": cannot change snapshot ID from "snapshot-0-0-0" to "something-else"`)
+}
+
+func (s *VolumeSnapshotSuite) TestSetVolumeSnapshotInfoNoSnapshotId(c *gc.C) {
+	err := s.State.SetVolumeSnapshotInfo("0/0:0", state.VolumeSnapshotInfo{})
+	c.Assert(err, gc.ErrorMatches, `cannot set info for volume snapshot "0/0:0": snapshot ID not set`)
+}
+
+func (s *VolumeSnapshotSuite) TestDestroyRemoveVolumeSnapshot(c *gc.C) {
+	snapshot := s.addProvisionedSnapshot(c)
+	err := s.State.RemoveVolumeSnapshot(snapshot.Id())
+	c.Assert(err, gc.ErrorMatches, `removing volume snapshot "0/0:0": volume snapshot is not dying`)
+
+	err = s.State.DestroyVolumeSnapshot(snapshot.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	snapshot, err = s.State.VolumeSnapshot(snapshot.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshot.Life(), gc.Equals, state.Dying)
+
+	err = s.State.RemoveVolumeSnapshot(snapshot.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.VolumeSnapshot(snapshot.Id())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// Destroying and removing are idempotent.
+	err = s.State.DestroyVolumeSnapshot(snapshot.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RemoveVolumeSnapshot(snapshot.Id())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *VolumeSnapshotSuite) TestWatchMachineVolumeSnapshots(c *gc.C) {
+	s.provisionVolume(c)
+	w := s.State.WatchMachineVolumeSnapshots(names.NewMachineTag("0"))
+	defer testing.AssertStop(c, w)
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChangeInSingleEvent() // initial
+	wc.AssertNoChange()
+
+	snapshot, err := s.State.AddVolumeSnapshot(s.volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent("0/0:0")
+	wc.AssertNoChange()
+
+	err = s.State.DestroyVolumeSnapshot(snapshot.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent("0/0:0") // dying
+	wc.AssertNoChange()
+
+	// Model-scoped snapshot watchers are not
+	// interested in machine-scoped volumes.
+	w2 := s.State.WatchModelVolumeSnapshots()
+	defer testing.AssertStop(c, w2)
+	wc2 := testing.NewStringsWatcherC(c, s.State, w2)
+	wc2.AssertChangeInSingleEvent() // initial
+	wc2.AssertNoChange()
+}
+
+func (s *VolumeSnapshotSuite) TestAddStorageFromSnapshot(c *gc.C) {
+	snapshot := s.addProvisionedSnapshot(c)
+	err := s.State.AddStorageForUnit(s.unit.UnitTag(), "multi1to10", state.StorageConstraints{
+		Count:    1,
+		Snapshot: snapshot.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	volume := s.volume(c, names.NewVolumeTag("0/1"))
+	params, ok := volume.Params()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(params, jc.DeepEquals, state.VolumeParams{
+		Pool:     "loop-pool",
+		Size:     1024,
+		Snapshot: "0/0:0",
+	})
+}
+
+func (s *VolumeSnapshotSuite) TestAddStorageFromSnapshotErrors(c *gc.C) {
+	snapshot := s.addProvisionedSnapshot(c)
+	for i, test := range []struct {
+		cons state.StorageConstraints
+		err  string
+	}{{
+		cons: state.StorageConstraints{Count: 1, Snapshot: "0/0:42"},
+		err:  `volume snapshot "0/0:42" not found`,
+	}, {
+		cons: state.StorageConstraints{Count: 1, Snapshot: snapshot.Id(), Pool: "persistent-block"},
+		err:  `cannot create storage in pool "persistent-block" from snapshot "0/0:0" in pool "loop-pool"`,
+	}, {
+		cons: state.StorageConstraints{Count: 1, Snapshot: snapshot.Id(), Size: 512},
+		err:  `size 512M is smaller than snapshot "0/0:0" size 1024M`,
+	}} {
+		c.Logf("test %d", i)
+		err := s.State.AddStorageForUnit(s.unit.UnitTag(), "multi1to10", test.cons)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+
+	err := s.State.DestroyVolumeSnapshot(snapshot.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AddStorageForUnit(s.unit.UnitTag(), "multi1to10", state.StorageConstraints{
+		Count:    1,
+		Snapshot: snapshot.Id(),
+	})
+	c.Assert(err, gc.ErrorMatches, `volume snapshot "0/0:0" is not alive`)
+}
+
+func (s *VolumeSnapshotSuite) TestRemoveMachineRemovesVolumeSnapshots(c *gc.C) {
+	machine, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+		Volumes: []state.MachineVolumeParams{{
+			Volume: state.VolumeParams{Pool: "loop-pool", Size: 1024},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	attachments, err := s.State.MachineVolumeAttachments(machine.MachineTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachments, gc.HasLen, 1)
+	volumeTag := attachments[0].Volume()
+	err = s.State.SetVolumeInfo(volumeTag, state.VolumeInfo{
+		VolumeId: "volume-1-1",
+		Size:     1024,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(machine.Destroy(), jc.ErrorIsNil)
+	c.Assert(machine.EnsureDead(), jc.ErrorIsNil)
+	c.Assert(machine.Remove(), jc.ErrorIsNil)
+
+	snapshots, err := s.State.VolumeSnapshots(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshots, gc.HasLen, 0)
+}
+
+func (s *VolumeSnapshotSuite) TestIsValidVolumeSnapshotId(c *gc.C) {
+	for id, valid := range map[string]bool{
+		"0:1":         true,
+		"0/1:2":       true,
+		"0/lxd/0/1:2": true,
+		"0":           false,
+		"0:":          false,
+		":1":          false,
+		"0:a":         false,
+	} {
+		c.Check(state.IsValidVolumeSnapshotId(id), gc.Equals, valid, gc.Commentf("%q", id))
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/utils/set"
+	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
@@ -253,6 +254,97 @@ func (st *State) watchMachineStorage(m names.MachineTag, collection string) Stri
 	return newLifecycleWatcher(st, collection, members, filter, nil)
 }
 
+// WatchModelVolumeResizes returns a StringsWatcher that notifies of
+// changes to model-scoped volumes, so that outstanding requests to resize
+// them may be observed.
+func (st *State) WatchModelVolumeResizes() StringsWatcher {
+	return st.watchModelStorageResizes(volumesC)
+}
+
+// WatchModelFilesystemResizes returns a StringsWatcher that notifies of
+// changes to model-scoped filesystems, so that outstanding requests to
+// resize them may be observed.
+func (st *State) WatchModelFilesystemResizes() StringsWatcher {
+	return st.watchModelStorageResizes(filesystemsC)
+}
+
+func (st *State) watchModelStorageResizes(collection string) StringsWatcher {
+	return newcollectionWatcher(st, colWCfg{
+		col: collection,
+		filter: func(id interface{}) bool {
+			k, err := st.strictLocalID(id.(string))
+			if err != nil {
+				return false
+			}
+			return !strings.Contains(k, "/")
+		},
+	})
+}
+
+// WatchMachineVolumeResizes returns a StringsWatcher that notifies of
+// changes to volumes scoped to the specified machine, so that outstanding
+// requests to resize them may be observed.
+func (st *State) WatchMachineVolumeResizes(m names.MachineTag) StringsWatcher {
+	return st.watchMachineStorageResizes(m, volumesC)
+}
+
+// WatchMachineFilesystemResizes returns a StringsWatcher that notifies of
+// changes to filesystems scoped to the specified machine, so that
+// outstanding requests to resize them may be observed.
+func (st *State) WatchMachineFilesystemResizes(m names.MachineTag) StringsWatcher {
+	return st.watchMachineStorageResizes(m, filesystemsC)
+}
+
+func (st *State) watchMachineStorageResizes(m names.MachineTag, collection string) StringsWatcher {
+	prefix := m.Id() + "/"
+	return newcollectionWatcher(st, colWCfg{
+		col: collection,
+		filter: func(id interface{}) bool {
+			k, err := st.strictLocalID(id.(string))
+			if err != nil {
+				return false
+			}
+			return strings.HasPrefix(k, prefix) && !strings.Contains(k[len(prefix):], "/")
+		},
+	})
+}
+
+// WatchModelVolumeSnapshots returns a StringsWatcher that notifies of
+// changes to the lifecycles of all snapshots of model-scoped volumes.
+func (st *State) WatchModelVolumeSnapshots() StringsWatcher {
+	pattern := fmt.Sprintf("^%s:%s$", st.docID(names.NumberSnippet), names.NumberSnippet)
+	members := bson.D{{"_id", bson.D{{"$regex", pattern}}}}
+	filter := func(id interface{}) bool {
+		k, err := st.strictLocalID(id.(string))
+		if err != nil {
+			return false
+		}
+		colon := strings.LastIndex(k, ":")
+		if colon == -1 {
+			return false
+		}
+		return !strings.Contains(k[:colon], "/")
+	}
+	return newLifecycleWatcher(st, volumeSnapshotsC, members, filter, nil)
+}
+
+// WatchMachineVolumeSnapshots returns a StringsWatcher that notifies of
+// changes to the lifecycles of all snapshots of volumes scoped to the
+// specified machine.
+func (st *State) WatchMachineVolumeSnapshots(m names.MachineTag) StringsWatcher {
+	pattern := fmt.Sprintf("^%s/%s:%s$", st.docID(m.Id()), names.NumberSnippet, names.NumberSnippet)
+	members := bson.D{{"_id", bson.D{{"$regex", pattern}}}}
+	prefix := m.Id() + "/"
+	filter := func(id interface{}) bool {
+		k, err := st.strictLocalID(id.(string))
+		if err != nil {
+			return false
+		}
+		return strings.HasPrefix(k, prefix)
+	}
+	return newLifecycleWatcher(st, volumeSnapshotsC, members, filter, nil)
+}
+
 // WatchEnvironVolumeAttachments returns a StringsWatcher that notifies of
 // changes to the lifecycles of all volume attachments related to environ-
 // scoped volumes.
@@ -262,9 +354,13 @@ func (st *State) WatchEnvironVolumeAttachments() StringsWatcher {
 
 // WatchEnvironFilesystemAttachments returns a StringsWatcher that notifies
 // of changes to the lifecycles of all filesystem attachments related to
-// environ-scoped filesystems.
+// environ-scoped filesystems. Attachments of environ-scoped filesystems
+// backed by volumes are not included, as they are managed by the machines
+// that they are attached to.
 func (st *State) WatchEnvironFilesystemAttachments() StringsWatcher {
-	return st.watchModelMachinestorageAttachments(filesystemAttachmentsC)
+	return newVolumeBackedFilesystemAttachmentsWatcher(
+		st, st.watchModelMachinestorageAttachments(filesystemAttachmentsC), false,
+	)
 }
 
 func (st *State) watchModelMachinestorageAttachments(collection string) StringsWatcher {
@@ -286,28 +382,175 @@ func (st *State) watchModelMachinestorageAttachments(collection string) StringsW
 
 // WatchMachineVolumeAttachments returns a StringsWatcher that notifies of
 // changes to the lifecycles of all volume attachments related to the specified
-// machine, for volumes scoped to the machine.
+// machine, for volumes scoped to the machine. This includes attachments of
+// the machine's containers to volumes scoped to the machine, which are passed
+// through to the containers by the machine.
 func (st *State) WatchMachineVolumeAttachments(m names.MachineTag) StringsWatcher {
 	return st.watchMachineStorageAttachments(m, volumeAttachmentsC)
 }
 
 // WatchMachineFilesystemAttachments returns a StringsWatcher that notifies of
 // changes to the lifecycles of all filesystem attachments related to the specified
-// machine, for filesystems scoped to the machine.
+// machine, for filesystems scoped to the machine. This includes attachments of
+// the machine's containers to (shared) filesystems scoped to the machine, which
+// are managed by the machine. Attachments of the machine to environ-scoped
+// filesystems backed by volumes, which are also managed by the machine, are
+// included too.
 func (st *State) WatchMachineFilesystemAttachments(m names.MachineTag) StringsWatcher {
-	return st.watchMachineStorageAttachments(m, filesystemAttachmentsC)
+	pattern := fmt.Sprintf("^%s(/.*)?:(%s/)?%s$", st.docID(m.Id()), m.Id(), names.NumberSnippet)
+	members := bson.D{{"_id", bson.D{{"$regex", pattern}}}}
+	prefix := m.Id() + "/"
+	filter := func(id interface{}) bool {
+		k, err := st.strictLocalID(id.(string))
+		if err != nil {
+			return false
+		}
+		colon := strings.IndexRune(k, ':')
+		if colon == -1 {
+			return false
+		}
+		machineId, storageId := k[:colon], k[colon+1:]
+		if !strings.Contains(storageId, "/") {
+			// Environ-scoped filesystem.
+			return machineId == m.Id()
+		}
+		if machineId != m.Id() && !strings.HasPrefix(machineId, prefix) {
+			return false
+		}
+		return strings.HasPrefix(storageId, prefix) && !strings.Contains(storageId[len(prefix):], "/")
+	}
+	return newVolumeBackedFilesystemAttachmentsWatcher(
+		st, newLifecycleWatcher(st, filesystemAttachmentsC, members, filter, nil), true,
+	)
+}
+
+// volumeBackedFilesystemAttachmentsWatcher filters the filesystem
+// attachment IDs reported by another StringsWatcher, by whether the
+// attached environ-scoped filesystems are backed by volumes. Attachments
+// of machine-scoped filesystems are always reported.
+type volumeBackedFilesystemAttachmentsWatcher struct {
+	commonWatcher
+	source StringsWatcher
+	// volumeBacked records whether attachments of volume-backed
+	// filesystems, or of other filesystems, are to be reported.
+	volumeBacked bool
+	// known caches whether filesystems are backed by volumes,
+	// which never changes once a filesystem is created.
+	known map[string]bool
+	out   chan []string
 }
 
+func newVolumeBackedFilesystemAttachmentsWatcher(
+	st *State, source StringsWatcher, volumeBacked bool,
+) StringsWatcher {
+	w := &volumeBackedFilesystemAttachmentsWatcher{
+		commonWatcher: newCommonWatcher(st),
+		source:        source,
+		volumeBacked:  volumeBacked,
+		known:         make(map[string]bool),
+		out:           make(chan []string),
+	}
+	go func() {
+		defer w.tomb.Done()
+		defer close(w.out)
+		defer watcher.Stop(w.source, &w.tomb)
+		w.tomb.Kill(w.loop())
+	}()
+	return w
+}
+
+// Changes returns the event channel for the watcher.
+func (w *volumeBackedFilesystemAttachmentsWatcher) Changes() <-chan []string {
+	return w.out
+}
+
+func (w *volumeBackedFilesystemAttachmentsWatcher) loop() error {
+	var sentInitial bool
+	var out chan<- []string
+	changes := make(set.Strings)
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case ids, ok := <-w.source.Changes():
+			if !ok {
+				return watcher.EnsureErr(w.source)
+			}
+			for _, id := range ids {
+				include, err := w.include(id)
+				if err != nil {
+					return errors.Trace(err)
+				}
+				if include {
+					changes.Add(id)
+				}
+			}
+			if !sentInitial || !changes.IsEmpty() {
+				out = w.out
+			}
+		case out <- changes.SortedValues():
+			sentInitial = true
+			changes = make(set.Strings)
+			out = nil
+		}
+	}
+}
+
+// include reports whether the filesystem attachment with the specified
+// ID should be reported by the watcher.
+func (w *volumeBackedFilesystemAttachmentsWatcher) include(id string) (bool, error) {
+	colon := strings.IndexRune(id, ':')
+	if colon == -1 {
+		return false, nil
+	}
+	filesystemId := id[colon+1:]
+	if strings.Contains(filesystemId, "/") {
+		// Machine-scoped filesystem.
+		return true, nil
+	}
+	volumeBacked, ok := w.known[filesystemId]
+	if !ok {
+		filesystems, closer := w.st.getCollection(filesystemsC)
+		defer closer()
+		var doc struct {
+			VolumeId string `bson:"volumeid"`
+		}
+		err := filesystems.FindId(filesystemId).Select(bson.D{{"volumeid", 1}}).One(&doc)
+		if err == mgo.ErrNotFound {
+			// The filesystem has been removed, so we cannot
+			// tell who manages it; only the environ's watcher
+			// reports the attachment, as it did before.
+			return !w.volumeBacked, nil
+		} else if err != nil {
+			return false, errors.Annotatef(err, "getting filesystem %q", filesystemId)
+		}
+		volumeBacked = doc.VolumeId != ""
+		w.known[filesystemId] = volumeBacked
+	}
+	return volumeBacked == w.volumeBacked, nil
+}
+
+// watchMachineStorageAttachments returns a StringsWatcher that notifies of
+// changes to the lifecycles of attachments of storage scoped to the specified
+// machine, to either the machine or one of its containers.
 func (st *State) watchMachineStorageAttachments(m names.MachineTag, collection string) StringsWatcher {
-	pattern := fmt.Sprintf("^%s:%s/.*", st.docID(m.Id()), m.Id())
+	pattern := fmt.Sprintf("^%s(/.*)?:%s/%s$", st.docID(m.Id()), m.Id(), names.NumberSnippet)
 	members := bson.D{{"_id", bson.D{{"$regex", pattern}}}}
-	prefix := m.Id() + fmt.Sprintf(":%s/", m.Id())
+	prefix := m.Id() + "/"
 	filter := func(id interface{}) bool {
 		k, err := st.strictLocalID(id.(string))
 		if err != nil {
 			return false
 		}
-		return strings.HasPrefix(k, prefix)
+		colon := strings.IndexRune(k, ':')
+		if colon == -1 {
+			return false
+		}
+		machineId, storageId := k[:colon], k[colon+1:]
+		if machineId != m.Id() && !strings.HasPrefix(machineId, prefix) {
+			return false
+		}
+		return strings.HasPrefix(storageId, prefix) && !strings.Contains(storageId[len(prefix):], "/")
 	}
 	return newLifecycleWatcher(st, collection, members, filter, nil)
 }
@@ -356,10 +599,20 @@ func (s *Application) WatchUnits() StringsWatcher {
 // WatchRelations returns a StringsWatcher that notifies of changes to the
 // lifecycles of relations involving s.
 func (s *Application) WatchRelations() StringsWatcher {
-	prefix := s.doc.Name + ":"
+	return watchApplicationRelations(s.st, s.doc.Name)
+}
+
+// WatchRelations returns a StringsWatcher that notifies of changes to the
+// lifecycles of relations involving s.
+func (s *RemoteApplication) WatchRelations() StringsWatcher {
+	return watchApplicationRelations(s.st, s.doc.Name)
+}
+
+func watchApplicationRelations(st *State, applicationName string) StringsWatcher {
+	prefix := applicationName + ":"
 	infix := " " + prefix
 	filter := func(id interface{}) bool {
-		k, err := s.st.strictLocalID(id.(string))
+		k, err := st.strictLocalID(id.(string))
 		if err != nil {
 			return false
 		}
@@ -367,8 +620,14 @@ func (s *Application) WatchRelations() StringsWatcher {
 		return out
 	}
 
-	members := bson.D{{"endpoints.applicationname", s.doc.Name}}
-	return newLifecycleWatcher(s.st, relationsC, members, filter, nil)
+	members := bson.D{{"endpoints.applicationname", applicationName}}
+	return newLifecycleWatcher(st, relationsC, members, filter, nil)
+}
+
+// WatchRemoteApplications returns a StringsWatcher that notifies of changes
+// to the lifecycles of the remote applications in the model.
+func (st *State) WatchRemoteApplications() StringsWatcher {
+	return newLifecycleWatcher(st, remoteApplicationsC, nil, isLocalID(st), nil)
 }
 
 // WatchModelMachines returns a StringsWatcher that notifies of changes to
@@ -888,13 +1147,28 @@ type relationUnitsWatcher struct {
 // Watch returns a watcher that notifies of changes to conterpart units in
 // the relation.
 func (ru *RelationUnit) Watch() RelationUnitsWatcher {
-	return newRelationUnitsWatcher(ru)
+	return newRelationUnitsWatcher(ru.st, ru.WatchScope())
 }
 
-func newRelationUnitsWatcher(ru *RelationUnit) RelationUnitsWatcher {
+// WatchUnits returns a watcher that notifies of changes to the units
+// of the named application in the relation. The relation must not
+// have container scope.
+func (r *Relation) WatchUnits(applicationName string) (RelationUnitsWatcher, error) {
+	ep, err := r.Endpoint(applicationName)
+	if err != nil {
+		return nil, err
+	}
+	if ep.Scope == charm.ScopeContainer {
+		return nil, errors.NotSupportedf("watching units of container scoped relation %q", r)
+	}
+	scope := r.globalScope() + "#" + string(ep.Role)
+	return newRelationUnitsWatcher(r.st, newRelationScopeWatcher(r.st, scope, "")), nil
+}
+
+func newRelationUnitsWatcher(st *State, sw *RelationScopeWatcher) RelationUnitsWatcher {
 	w := &relationUnitsWatcher{
-		commonWatcher: newCommonWatcher(ru.st),
-		sw:            ru.WatchScope(),
+		commonWatcher: newCommonWatcher(st),
+		sw:            sw,
 		watching:      make(set.Strings),
 		updates:       make(chan watcher.Change),
 		out:           make(chan params.RelationUnitsChange),
@@ -1272,6 +1546,12 @@ func (s *Application) Watch() NotifyWatcher {
 	return newEntityWatcher(s.st, applicationsC, s.doc.DocID)
 }
 
+// WatchEndpointBindings returns a watcher that notifies of changes to
+// the spaces the application's endpoints are bound to.
+func (s *Application) WatchEndpointBindings() NotifyWatcher {
+	return newEntityWatcher(s.st, endpointBindingsC, s.st.docID(s.globalKey()))
+}
+
 // WatchLeaderSettings returns a watcher for observing changed to a service's
 // leader settings.
 func (s *Application) WatchLeaderSettings() NotifyWatcher {
@@ -1326,6 +1606,16 @@ func (st *State) WatchStorageAttachment(s names.StorageTag, u names.UnitTag) Not
 	return newEntityWatcher(st, storageAttachmentsC, st.docID(id))
 }
 
+// WatchVolume returns a watcher for observing changes to a volume.
+func (st *State) WatchVolume(v names.VolumeTag) NotifyWatcher {
+	return newEntityWatcher(st, volumesC, st.docID(v.Id()))
+}
+
+// WatchFilesystem returns a watcher for observing changes to a filesystem.
+func (st *State) WatchFilesystem(f names.FilesystemTag) NotifyWatcher {
+	return newEntityWatcher(st, filesystemsC, st.docID(f.Id()))
+}
+
 // WatchVolumeAttachment returns a watcher for observing changes
 // to a volume attachment.
 func (st *State) WatchVolumeAttachment(m names.MachineTag, v names.VolumeTag) NotifyWatcher {
@@ -1739,6 +2029,61 @@ func (w *cleanupWatcher) loop() (err error) {
 	}
 }
 
+// subnetsWatcher notifies of changes to the model's subnets and spaces.
+type subnetsWatcher struct {
+	commonWatcher
+	out chan struct{}
+}
+
+var _ Watcher = (*subnetsWatcher)(nil)
+
+// WatchSubnets returns a NotifyWatcher that notifies of subnets and
+// spaces being added to, changed in or removed from the model,
+// including subnets moving between spaces.
+func (st *State) WatchSubnets() NotifyWatcher {
+	w := &subnetsWatcher{
+		commonWatcher: newCommonWatcher(st),
+		out:           make(chan struct{}),
+	}
+	go func() {
+		defer w.tomb.Done()
+		defer close(w.out)
+		w.tomb.Kill(w.loop())
+	}()
+	return w
+}
+
+// Changes returns the event channel for w.
+func (w *subnetsWatcher) Changes() <-chan struct{} {
+	return w.out
+}
+
+func (w *subnetsWatcher) loop() (err error) {
+	in := make(chan watcher.Change)
+	filter := isLocalID(w.st)
+	w.watcher.WatchCollectionWithFilter(subnetsC, in, filter)
+	defer w.watcher.UnwatchCollection(subnetsC, in)
+	w.watcher.WatchCollectionWithFilter(spacesC, in, filter)
+	defer w.watcher.UnwatchCollection(spacesC, in)
+
+	out := w.out
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.watcher.Dead():
+			return stateWatcherDeadError(w.watcher.Err())
+		case ch := <-in:
+			if _, ok := collect(ch, in, w.tomb.Dying()); !ok {
+				return tomb.ErrDying
+			}
+			out = w.out
+		case out <- struct{}{}:
+			out = nil
+		}
+	}
+}
+
 // actionStatusWatcher is a StringsWatcher that filters notifications
 // to Action Id's that match the ActionReceiver and ActionStatus set
 // provided.
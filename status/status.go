@@ -167,6 +167,11 @@ const (
 	// StatusDetached indicates that the storage is not attached to
 	// any machine.
 	StatusDetached Status = "detached"
+
+	// StatusWarning indicates that the storage is attached to a
+	// machine, but requires attention; for example, because it is
+	// nearly full.
+	StatusWarning Status = "warning"
 )
 
 const (
@@ -52,3 +52,26 @@ type FilesystemAttachmentInfo struct {
 	// ReadOnly indicates that the filesystem is mounted read-only.
 	ReadOnly bool
 }
+
+// FilesystemUsage describes the space and inode usage of a filesystem,
+// as observed on a machine that it is attached to.
+type FilesystemUsage struct {
+	// Filesystem is the unique tag assigned by Juju for the filesystem.
+	Filesystem names.FilesystemTag
+
+	// Machine is the unique tag assigned by Juju for the machine on
+	// which the usage was observed.
+	Machine names.MachineTag
+
+	// UsedBytes is the number of bytes used in the filesystem.
+	UsedBytes uint64
+
+	// FreeBytes is the number of bytes free in the filesystem.
+	FreeBytes uint64
+
+	// UsedInodes is the number of inodes used in the filesystem.
+	UsedInodes uint64
+
+	// FreeInodes is the number of inodes free in the filesystem.
+	FreeInodes uint64
+}
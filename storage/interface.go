@@ -62,6 +62,46 @@ type Provider interface {
 	ValidateConfig(*Config) error
 }
 
+// ImmutableConfigProvider is an optional interface that a Provider may
+// implement to identify the pool configuration attributes that must not
+// be changed while the pool is in use, because doing so would disrupt
+// existing storage. For example, changing the location in which volumes
+// are created would orphan the pool's existing volumes.
+type ImmutableConfigProvider interface {
+	// ImmutableConfigAttributes returns the names of the pool
+	// configuration attributes that may not be changed while
+	// the pool is in use.
+	ImmutableConfigAttributes() []string
+}
+
+// SharedFilesystemProvider is an optional interface that a Provider may
+// implement to indicate that its filesystems may be shared: attached to
+// more than one machine at a time, such as to each of the machines that
+// an application's units are assigned to.
+//
+// Machine-scoped shared filesystems are scoped to a host machine, and
+// may be attached to the host and to any of its containers. Attachments
+// to containers are managed by the host machine.
+type SharedFilesystemProvider interface {
+	// SupportsSharedFilesystems reports whether or not the provider
+	// supports sharing filesystems between machines.
+	SupportsSharedFilesystems() bool
+}
+
+// ContainerPassthroughProvider is an optional interface that a Provider
+// may implement to indicate that storage for a container may be created
+// on the container's host machine, and passed through to the container.
+//
+// Machine-scoped storage for containers from such a provider is scoped
+// to the host machine, which manages the storage and its attachments
+// to the host's containers.
+type ContainerPassthroughProvider interface {
+	// SupportsContainerPassthrough reports whether or not the
+	// provider supports passing storage on a host machine through
+	// to the host's containers.
+	SupportsContainerPassthrough() bool
+}
+
 // VolumeSource provides an interface for creating, destroying, describing,
 // attaching and detaching volumes in the environment. A VolumeSource is
 // configured in a particular way, and corresponds to a storage "pool".
@@ -108,6 +148,84 @@ type VolumeSource interface {
 	DetachVolumes(params []VolumeAttachmentParams) ([]error, error)
 }
 
+// VolumeSnapshotter is an interface that may optionally be implemented
+// by a VolumeSource that supports taking point-in-time copies of its
+// volumes, and creating new volumes from them.
+type VolumeSnapshotter interface {
+	// CreateVolumeSnapshots creates snapshots of the volumes with
+	// the specified parameters.
+	CreateVolumeSnapshots(params []VolumeSnapshotParams) ([]CreateVolumeSnapshotsResult, error)
+
+	// ListVolumeSnapshots lists the provider snapshot IDs for every
+	// volume snapshot created by this volume source.
+	ListVolumeSnapshots() ([]string, error)
+
+	// DeleteVolumeSnapshots deletes the volume snapshots with the
+	// specified provider snapshot IDs.
+	DeleteVolumeSnapshots(snapshotIds []string) ([]error, error)
+
+	// CreateVolumesFromSnapshots creates volumes with the specified
+	// parameters, populating each from the snapshot identified by
+	// the parameters' SnapshotId field. If the volumes are initially
+	// attached, then CreateVolumesFromSnapshots returns information
+	// about those attachments too.
+	CreateVolumesFromSnapshots(params []VolumeParams) ([]CreateVolumesResult, error)
+}
+
+// VolumeResizer is an interface that may optionally be implemented
+// by a VolumeSource that supports growing existing volumes.
+type VolumeResizer interface {
+	// ResizeVolumes grows the volumes with the specified parameters
+	// to at least the requested size, in MiB. Volumes may be resized
+	// while they are attached.
+	ResizeVolumes(params []VolumeResizeParams) ([]ResizeVolumesResult, error)
+}
+
+// VolumeImporter is an interface that may optionally be implemented
+// by a VolumeSource that supports adopting volumes that were created
+// outside of the model.
+type VolumeImporter interface {
+	// ImportVolume validates that the volume with the specified
+	// provider volume ID may be imported into the model, tags it
+	// with the specified resource tags, and returns its properties.
+	// If no resource tags are specified, the volume is only
+	// validated.
+	//
+	// Only volumes that are not attached to any machine may be
+	// imported.
+	ImportVolume(volumeId string, resourceTags map[string]string) (VolumeInfo, error)
+}
+
+// VolumeSnapshotProvider is an interface that may optionally be
+// implemented by a Provider whose volume sources implement
+// VolumeSnapshotter, so that snapshot requests for volumes of other
+// providers can be rejected before they are recorded.
+type VolumeSnapshotProvider interface {
+	// SupportsVolumeSnapshots reports whether the volume sources
+	// created by the provider can take volume snapshots.
+	SupportsVolumeSnapshots() bool
+}
+
+// VolumeResizeProvider is an interface that may optionally be
+// implemented by a Provider whose volume sources implement
+// VolumeResizer, so that resize requests for volumes of other
+// providers can be rejected before they are recorded.
+type VolumeResizeProvider interface {
+	// SupportsVolumeResize reports whether the volume sources
+	// created by the provider can grow volumes.
+	SupportsVolumeResize() bool
+}
+
+// FilesystemResizeProvider is an interface that may optionally be
+// implemented by a Provider whose filesystem sources implement
+// FilesystemResizer. Filesystems backed by volumes are managed by
+// Juju, and can be grown whenever their volumes can.
+type FilesystemResizeProvider interface {
+	// SupportsFilesystemResize reports whether the filesystem
+	// sources created by the provider can grow filesystems.
+	SupportsFilesystemResize() bool
+}
+
 // FilesystemSource provides an interface for creating, destroying and
 // describing filesystems in the environment. A FilesystemSource is
 // configured in a particular way, and corresponds to a storage "pool".
@@ -141,6 +259,30 @@ type FilesystemSource interface {
 	DetachFilesystems(params []FilesystemAttachmentParams) ([]error, error)
 }
 
+// FilesystemResizer is an interface that may optionally be implemented
+// by a FilesystemSource that supports growing existing filesystems.
+type FilesystemResizer interface {
+	// ResizeFilesystems grows the filesystems with the specified
+	// parameters to at least the requested size, in MiB. Filesystems
+	// may be resized while they are attached.
+	ResizeFilesystems(params []FilesystemResizeParams) ([]ResizeFilesystemsResult, error)
+}
+
+// FilesystemImporter is an interface that may optionally be implemented
+// by a FilesystemSource that supports adopting filesystems that were
+// created outside of the model.
+type FilesystemImporter interface {
+	// ImportFilesystem validates that the filesystem with the
+	// specified provider filesystem ID may be imported into the
+	// model, tags it with the specified resource tags, and returns
+	// its properties. If no resource tags are specified, the
+	// filesystem is only validated.
+	//
+	// Only filesystems that are not attached to any machine may be
+	// imported.
+	ImportFilesystem(filesystemId string, resourceTags map[string]string) (FilesystemInfo, error)
+}
+
 // VolumeParams is a fully specified set of parameters for volume creation,
 // derived from one or more of user-specified storage constraints, a
 // storage pool definition, and charm storage metadata.
@@ -175,6 +317,41 @@ type VolumeParams struct {
 	// once the instance is created there are still unprovisioned volumes,
 	// the dynamic storage provisioner will take care of creating them.
 	Attachment *VolumeAttachmentParams
+
+	// SnapshotId, if non-empty, is the provider-supplied ID of the
+	// volume snapshot that the volume should be created from. Volumes
+	// with a SnapshotId may only be created by volume sources that
+	// implement VolumeSnapshotter.
+	SnapshotId string
+}
+
+// VolumeSnapshotParams is a set of parameters for creating a snapshot
+// of a volume.
+type VolumeSnapshotParams struct {
+	// Id is the unique ID assigned by Juju for the requested snapshot.
+	Id string
+
+	// Volume is the unique tag assigned by Juju for the volume that
+	// is to be snapshotted.
+	Volume names.VolumeTag
+
+	// VolumeId is the unique provider-supplied ID for the volume that
+	// is to be snapshotted.
+	VolumeId string
+}
+
+// VolumeResizeParams is a set of parameters for growing a volume.
+type VolumeResizeParams struct {
+	// Tag is the unique tag assigned by Juju for the volume that
+	// is to be resized.
+	Tag names.VolumeTag
+
+	// VolumeId is the unique provider-supplied ID for the volume that
+	// is to be resized.
+	VolumeId string
+
+	// Size is the requested size of the volume in MiB.
+	Size uint64
 }
 
 // VolumeAttachmentParams is a set of parameters for volume attachment or
@@ -239,6 +416,23 @@ type FilesystemParams struct {
 	ResourceTags map[string]string
 }
 
+// FilesystemResizeParams is a set of parameters for growing a filesystem.
+type FilesystemResizeParams struct {
+	// Tag is the unique tag assigned by Juju for the filesystem that
+	// is to be resized.
+	Tag names.FilesystemTag
+
+	// Volume is the tag of the volume that backs the filesystem, if any.
+	Volume names.VolumeTag
+
+	// FilesystemId is the unique provider-supplied ID for the filesystem
+	// that is to be resized.
+	FilesystemId string
+
+	// Size is the requested size of the filesystem in MiB.
+	Size uint64
+}
+
 // FilesystemAttachmentParams is a set of parameters for filesystem attachment
 // or detachment.
 type FilesystemAttachmentParams struct {
@@ -266,6 +460,21 @@ type CreateVolumesResult struct {
 	Error            error
 }
 
+// CreateVolumeSnapshotsResult contains the result of a
+// VolumeSnapshotter.CreateVolumeSnapshots call for one snapshot.
+// VolumeSnapshot should only be used if Error is nil.
+type CreateVolumeSnapshotsResult struct {
+	VolumeSnapshot *VolumeSnapshot
+	Error          error
+}
+
+// ResizeVolumesResult contains the result of a VolumeResizer.ResizeVolumes
+// call for one volume. VolumeInfo should only be used if Error is nil.
+type ResizeVolumesResult struct {
+	VolumeInfo *VolumeInfo
+	Error      error
+}
+
 // DescribeVolumesResult contains the result of a VolumeSource.DescribeVolumes call
 // for one volume. Volume should only be used if Error is nil.
 type DescribeVolumesResult struct {
@@ -287,6 +496,14 @@ type CreateFilesystemsResult struct {
 	Error      error
 }
 
+// ResizeFilesystemsResult contains the result of a
+// FilesystemResizer.ResizeFilesystems call for one filesystem.
+// FilesystemInfo should only be used if Error is nil.
+type ResizeFilesystemsResult struct {
+	FilesystemInfo *FilesystemInfo
+	Error          error
+}
+
 // DescribeFilesystemsResult contains the result of a FilesystemSource.DescribeFilesystems call
 // for one filesystem. Filesystem should only be used if Error is nil.
 type DescribeFilesystemsResult struct {
@@ -12,6 +12,14 @@ type PoolManager interface {
 	// Create makes a new pool with the specified configuration and persists it to state.
 	Create(name string, providerType storage.ProviderType, attrs map[string]interface{}) (*storage.Config, error)
 
+	// Update changes the configuration of the pool with name, merging
+	// the specified attributes into its existing attributes, and
+	// persists it to state. If validate is non-nil, it is called with
+	// the pool's existing configuration, and the update is abandoned
+	// if it returns an error. The update fails if the pool is changed
+	// concurrently.
+	Update(name string, attrs map[string]interface{}, validate ValidateUpdateFunc) (*storage.Config, error)
+
 	// Delete removes the pool with name from state.
 	Delete(name string) error
 
@@ -22,9 +30,16 @@ type PoolManager interface {
 	List() ([]*storage.Config, error)
 }
 
+// ValidateUpdateFunc validates an update of a pool, given the pool's
+// existing configuration and the attributes to be merged into it.
+type ValidateUpdateFunc func(existing *storage.Config, attrs map[string]interface{}) error
+
 type SettingsManager interface {
 	CreateSettings(key string, settings map[string]interface{}) error
 	ReadSettings(key string) (map[string]interface{}, error)
+	ReadSettingsWithVersion(key string) (map[string]interface{}, int64, error)
+	ReplaceSettings(key string, settings map[string]interface{}) error
+	ReplaceSettingsWithVersion(key string, version int64, settings map[string]interface{}) error
 	RemoveSettings(key string) error
 	ListSettings(keyPrefix string) (map[string]map[string]interface{}, error)
 }
@@ -69,6 +69,53 @@ func (pm *poolManager) Create(name string, providerType storage.ProviderType, at
 	return cfg, nil
 }
 
+// Update is defined on PoolManager interface.
+func (pm *poolManager) Update(name string, attrs map[string]interface{}, validate ValidateUpdateFunc) (*storage.Config, error) {
+	settings, version, err := pm.settings.ReadSettingsWithVersion(globalKey(name))
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, errors.NotFoundf("pool %q", name)
+		}
+		return nil, errors.Annotatef(err, "reading pool %q", name)
+	}
+	existing, err := configFromSettings(settings)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if validate != nil {
+		if err := validate(existing, attrs); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	newAttrs := existing.Attrs()
+	if newAttrs == nil {
+		newAttrs = make(map[string]interface{})
+	}
+	for k, v := range attrs {
+		newAttrs[k] = v
+	}
+
+	cfg, err := storage.NewConfig(name, existing.Provider(), newAttrs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	p, err := registry.StorageProvider(existing.Provider())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := provider.ValidateConfig(p, cfg); err != nil {
+		return nil, errors.Annotate(err, "validating storage provider config")
+	}
+
+	poolAttrs := cfg.Attrs()
+	poolAttrs[Name] = name
+	poolAttrs[Type] = string(existing.Provider())
+	if err := pm.settings.ReplaceSettingsWithVersion(globalKey(name), version, poolAttrs); err != nil {
+		return nil, errors.Annotatef(err, "updating pool %q", name)
+	}
+	return cfg, nil
+}
+
 // Delete is defined on PoolManager interface.
 func (pm *poolManager) Delete(name string) error {
 	err := pm.settings.RemoveSettings(globalKey(name))
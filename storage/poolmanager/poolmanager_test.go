@@ -126,6 +126,76 @@ func (s *poolSuite) TestCreateInvalidConfig(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "validating storage provider config: no good")
 }
 
+func (s *poolSuite) TestUpdate(c *gc.C) {
+	s.createSettings(c)
+	updated, err := s.poolManager.Update("testpool", map[string]interface{}{"baz": "qux"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	p, err := s.poolManager.Get("testpool")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(updated, gc.DeepEquals, p)
+	c.Assert(p.Attrs(), gc.DeepEquals, map[string]interface{}{"foo": "bar", "baz": "qux"})
+	c.Assert(p.Provider(), gc.Equals, storage.ProviderType("loop"))
+}
+
+func (s *poolSuite) TestUpdateValidate(c *gc.C) {
+	s.createSettings(c)
+	_, err := s.poolManager.Update("testpool", map[string]interface{}{"baz": "qux"},
+		func(existing *storage.Config, attrs map[string]interface{}) error {
+			c.Check(existing.Attrs(), gc.DeepEquals, map[string]interface{}{"foo": "bar"})
+			c.Check(attrs, gc.DeepEquals, map[string]interface{}{"baz": "qux"})
+			return errors.New("no good")
+		},
+	)
+	c.Assert(err, gc.ErrorMatches, "no good")
+
+	p, err := s.poolManager.Get("testpool")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(p.Attrs(), gc.DeepEquals, map[string]interface{}{"foo": "bar"})
+}
+
+func (s *poolSuite) TestUpdateConcurrentChange(c *gc.C) {
+	s.createSettings(c)
+	_, err := s.poolManager.Update("testpool", map[string]interface{}{"baz": "qux"},
+		func(*storage.Config, map[string]interface{}) error {
+			// Change the pool after it has been read.
+			return s.settings.ReplaceSettings("pool#testpool", map[string]interface{}{
+				"name": "testpool", "type": "loop", "foo": "baz",
+			})
+		},
+	)
+	c.Assert(err, gc.ErrorMatches, `updating pool "testpool": settings "pool#testpool" changed concurrently`)
+
+	p, err := s.poolManager.Get("testpool")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(p.Attrs(), gc.DeepEquals, map[string]interface{}{"foo": "baz"})
+}
+
+func (s *poolSuite) TestUpdateNotFound(c *gc.C) {
+	_, err := s.poolManager.Update("testpool", map[string]interface{}{"foo": "baz"}, nil)
+	c.Assert(err, gc.ErrorMatches, `pool "testpool" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *poolSuite) TestUpdateInvalidConfig(c *gc.C) {
+	registry.RegisterProvider("invalid", &dummy.StorageProvider{
+		ValidateConfigFunc: func(cfg *storage.Config) error {
+			if _, ok := cfg.ValueString("bad"); ok {
+				return errors.New("no good")
+			}
+			return nil
+		},
+	})
+	defer registry.RegisterProvider("invalid", nil)
+	_, err := s.poolManager.Create("testpool", "invalid", map[string]interface{}{"foo": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.poolManager.Update("testpool", map[string]interface{}{"bad": "value"}, nil)
+	c.Assert(err, gc.ErrorMatches, "validating storage provider config: no good")
+
+	p, err := s.poolManager.Get("testpool")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(p.Attrs(), gc.DeepEquals, map[string]interface{}{"foo": "bar"})
+}
+
 func (s *poolSuite) TestDelete(c *gc.C) {
 	s.createSettings(c)
 	err := s.poolManager.Delete("testpool")
@@ -14,9 +14,12 @@ var errNoMountPoint = errors.New("filesystem mount point not specified")
 // CommonProviders returns the storage providers used by all environments.
 func CommonProviders() map[storage.ProviderType]storage.Provider {
 	return map[storage.ProviderType]storage.Provider{
-		LoopProviderType:   &loopProvider{logAndExec},
-		RootfsProviderType: &rootfsProvider{logAndExec},
-		TmpfsProviderType:  &tmpfsProvider{logAndExec},
+		HostDirProviderType: &hostDirProvider{logAndExec},
+		LoopProviderType:    &loopProvider{logAndExec},
+		LXDProviderType:     &lxdProvider{logAndExec, connectLocalLXD},
+		LVMProviderType:     &lvmProvider{logAndExec},
+		RootfsProviderType:  &rootfsProvider{logAndExec},
+		TmpfsProviderType:   &tmpfsProvider{logAndExec},
 	}
 }
 
@@ -26,7 +26,10 @@ func (s *providerCommonSuite) TestCommonProvidersExported(c *gc.C) {
 		c.Check(ok, jc.IsTrue)
 	}
 	c.Assert(common, jc.SameContents, []storage.ProviderType{
+		provider.HostDirProviderType,
 		provider.LoopProviderType,
+		provider.LVMProviderType,
+		provider.LXDProviderType,
 		provider.RootfsProviderType,
 		provider.TmpfsProviderType,
 	})
@@ -11,7 +11,11 @@ import (
 	"github.com/juju/juju/storage"
 )
 
-var _ storage.Provider = (*StorageProvider)(nil)
+var (
+	_ storage.Provider               = (*StorageProvider)(nil)
+	_ storage.VolumeSnapshotProvider = (*StorageProvider)(nil)
+	_ storage.VolumeResizeProvider   = (*StorageProvider)(nil)
+)
 
 // StorageProvider is an implementation of storage.Provider, suitable for testing.
 // Each method's default behaviour may be overridden by setting the corresponding
@@ -41,6 +45,16 @@ type StorageProvider struct {
 	// SupportsFunc will be called by Supports, if non-nil; otherwise,
 	// Supports returns true.
 	SupportsFunc func(kind storage.StorageKind) bool
+
+	// VolumeSnapshots defines whether or not the provider reports
+	// that its volume sources can take volume snapshots. The
+	// VolumeSource's snapshot Func fields must be set for the
+	// snapshots to be taken.
+	VolumeSnapshots bool
+
+	// NoVolumeResize defines whether or not the provider reports
+	// that its volume sources cannot grow volumes.
+	NoVolumeResize bool
 }
 
 // VolumeSource is defined on storage.Provider.
@@ -90,3 +104,15 @@ func (p *StorageProvider) Dynamic() bool {
 	p.MethodCall(p, "Dynamic")
 	return p.IsDynamic
 }
+
+// SupportsVolumeSnapshots is defined on storage.VolumeSnapshotProvider.
+func (p *StorageProvider) SupportsVolumeSnapshots() bool {
+	p.MethodCall(p, "SupportsVolumeSnapshots")
+	return p.VolumeSnapshots
+}
+
+// SupportsVolumeResize is defined on storage.VolumeResizeProvider.
+func (p *StorageProvider) SupportsVolumeResize() bool {
+	p.MethodCall(p, "SupportsVolumeResize")
+	return !p.NoVolumeResize
+}
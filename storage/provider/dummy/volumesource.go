@@ -23,8 +23,24 @@ type VolumeSource struct {
 	ValidateVolumeParamsFunc func(storage.VolumeParams) error
 	AttachVolumesFunc        func([]storage.VolumeAttachmentParams) ([]storage.AttachVolumesResult, error)
 	DetachVolumesFunc        func([]storage.VolumeAttachmentParams) ([]error, error)
+
+	CreateVolumeSnapshotsFunc      func([]storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error)
+	ListVolumeSnapshotsFunc        func() ([]string, error)
+	DeleteVolumeSnapshotsFunc      func([]string) ([]error, error)
+	CreateVolumesFromSnapshotsFunc func([]storage.VolumeParams) ([]storage.CreateVolumesResult, error)
+
+	ResizeVolumesFunc func([]storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error)
+
+	ImportVolumeFunc func(string, map[string]string) (storage.VolumeInfo, error)
 }
 
+var (
+	_ storage.VolumeSource      = (*VolumeSource)(nil)
+	_ storage.VolumeSnapshotter = (*VolumeSource)(nil)
+	_ storage.VolumeResizer     = (*VolumeSource)(nil)
+	_ storage.VolumeImporter    = (*VolumeSource)(nil)
+)
+
 // CreateVolumes is defined on storage.VolumeSource.
 func (s *VolumeSource) CreateVolumes(params []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
 	s.MethodCall(s, "CreateVolumes", params)
@@ -87,3 +103,57 @@ func (s *VolumeSource) DetachVolumes(params []storage.VolumeAttachmentParams) ([
 	}
 	return nil, errors.NotImplementedf("DetachVolumes")
 }
+
+// CreateVolumeSnapshots is defined on storage.VolumeSnapshotter.
+func (s *VolumeSource) CreateVolumeSnapshots(params []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+	s.MethodCall(s, "CreateVolumeSnapshots", params)
+	if s.CreateVolumeSnapshotsFunc != nil {
+		return s.CreateVolumeSnapshotsFunc(params)
+	}
+	return nil, errors.NotImplementedf("CreateVolumeSnapshots")
+}
+
+// ListVolumeSnapshots is defined on storage.VolumeSnapshotter.
+func (s *VolumeSource) ListVolumeSnapshots() ([]string, error) {
+	s.MethodCall(s, "ListVolumeSnapshots")
+	if s.ListVolumeSnapshotsFunc != nil {
+		return s.ListVolumeSnapshotsFunc()
+	}
+	return nil, nil
+}
+
+// DeleteVolumeSnapshots is defined on storage.VolumeSnapshotter.
+func (s *VolumeSource) DeleteVolumeSnapshots(snapshotIds []string) ([]error, error) {
+	s.MethodCall(s, "DeleteVolumeSnapshots", snapshotIds)
+	if s.DeleteVolumeSnapshotsFunc != nil {
+		return s.DeleteVolumeSnapshotsFunc(snapshotIds)
+	}
+	return nil, errors.NotImplementedf("DeleteVolumeSnapshots")
+}
+
+// CreateVolumesFromSnapshots is defined on storage.VolumeSnapshotter.
+func (s *VolumeSource) CreateVolumesFromSnapshots(params []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
+	s.MethodCall(s, "CreateVolumesFromSnapshots", params)
+	if s.CreateVolumesFromSnapshotsFunc != nil {
+		return s.CreateVolumesFromSnapshotsFunc(params)
+	}
+	return nil, errors.NotImplementedf("CreateVolumesFromSnapshots")
+}
+
+// ResizeVolumes is defined on storage.VolumeResizer.
+func (s *VolumeSource) ResizeVolumes(params []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	s.MethodCall(s, "ResizeVolumes", params)
+	if s.ResizeVolumesFunc != nil {
+		return s.ResizeVolumesFunc(params)
+	}
+	return nil, errors.NotImplementedf("ResizeVolumes")
+}
+
+// ImportVolume is defined on storage.VolumeImporter.
+func (s *VolumeSource) ImportVolume(volumeId string, resourceTags map[string]string) (storage.VolumeInfo, error) {
+	s.MethodCall(s, "ImportVolume", volumeId, resourceTags)
+	if s.ImportVolumeFunc != nil {
+		return s.ImportVolumeFunc(volumeId, resourceTags)
+	}
+	return storage.VolumeInfo{}, errors.NotImplementedf("ImportVolume")
+}
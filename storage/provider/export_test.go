@@ -33,6 +33,19 @@ func LoopProvider(
 	return &loopProvider{run}
 }
 
+func LVMVolumeSource(
+	volumeGroup string,
+	run func(string, ...string) (string, error),
+) storage.VolumeSource {
+	return &lvmVolumeSource{run, volumeGroup}
+}
+
+func LVMProvider(
+	run func(string, ...string) (string, error),
+) storage.Provider {
+	return &lvmProvider{run}
+}
+
 func NewMockManagedFilesystemSource(
 	run func(string, ...string) (string, error),
 	volumeBlockDevices map[names.VolumeTag]storage.BlockDevice,
@@ -114,6 +127,62 @@ func RootfsProvider(run func(string, ...string) (string, error)) storage.Provide
 	return &rootfsProvider{run}
 }
 
+func HostDirFilesystemSource(
+	path, containerRoot string,
+	run func(string, ...string) (string, error),
+) (storage.FilesystemSource, *MockDirFuncs) {
+	d := &MockDirFuncs{
+		osDirFuncs{run},
+		set.NewStrings(),
+	}
+	return &hostDirFilesystemSource{d, run, path, containerRoot}, d
+}
+
+func HostDirProvider(run func(string, ...string) (string, error)) storage.Provider {
+	return &hostDirProvider{run}
+}
+
+type LXDDeviceClient lxdDeviceClient
+
+func LXDProvider(
+	run func(string, ...string) (string, error),
+	client LXDDeviceClient,
+) storage.Provider {
+	return &lxdProvider{run, func() (lxdDeviceClient, error) {
+		return client, nil
+	}}
+}
+
+func LXDVolumeSource(
+	storageDir string,
+	run func(string, ...string) (string, error),
+	client LXDDeviceClient,
+) storage.VolumeSource {
+	d := &MockDirFuncs{
+		osDirFuncs{run},
+		set.NewStrings(),
+	}
+	return &lxdVolumeSource{
+		&loopVolumeSource{d, run, storageDir},
+		&lxdDevices{client: client},
+	}
+}
+
+func LXDFilesystemSource(
+	storageDir string,
+	run func(string, ...string) (string, error),
+	client LXDDeviceClient,
+) storage.FilesystemSource {
+	d := &MockDirFuncs{
+		osDirFuncs{run},
+		set.NewStrings(),
+	}
+	return &lxdFilesystemSource{
+		&hostDirFilesystemSource{d, run, storageDir, ""},
+		&lxdDevices{client: client},
+	}
+}
+
 func TmpfsFilesystemSource(storageDir string, run func(string, ...string) (string, error)) storage.FilesystemSource {
 	return &tmpfsFilesystemSource{
 		&MockDirFuncs{
@@ -0,0 +1,271 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/storage"
+)
+
+const (
+	// HostDirProviderType is the storage provider type for directories
+	// on a host machine, which may be shared by the host and the
+	// containers on it.
+	HostDirProviderType = storage.ProviderType("hostdir")
+
+	// HostDirPath is the name of the pool configuration attribute
+	// identifying the directory on the host machine in which
+	// filesystems are created. If unspecified, filesystems are
+	// created in a "hostdir" directory in the machine agent's
+	// storage directory.
+	HostDirPath = "path"
+
+	// HostDirContainerRoot is the name of the pool configuration
+	// attribute identifying the directory on the host machine that
+	// contains the root filesystems of its containers, each of which
+	// is expected to be at <container-root>/<instance ID>/rootfs.
+	HostDirContainerRoot = "container-root"
+
+	// defaultHostDirContainerRoot is the default value of the
+	// HostDirContainerRoot pool configuration attribute, which
+	// is where LXD keeps its containers.
+	defaultHostDirContainerRoot = "/var/lib/lxd/containers"
+)
+
+// hostDirProvider creates filesystem sources which create filesystems
+// as directories on a host machine, and attach them by bind-mounting
+// the same directory for the host and each of its containers.
+type hostDirProvider struct {
+	// run is a function used for running commands on the local machine.
+	run runCommandFunc
+}
+
+var (
+	_ storage.Provider                 = (*hostDirProvider)(nil)
+	_ storage.ImmutableConfigProvider  = (*hostDirProvider)(nil)
+	_ storage.SharedFilesystemProvider = (*hostDirProvider)(nil)
+)
+
+// ValidateConfig is defined on the Provider interface.
+func (*hostDirProvider) ValidateConfig(cfg *storage.Config) error {
+	for _, attr := range []string{HostDirPath, HostDirContainerRoot} {
+		value, ok := cfg.ValueString(attr)
+		if ok && !filepath.IsAbs(value) {
+			return errors.NotValidf("%s %q (must be an absolute path)", attr, value)
+		}
+	}
+	return nil
+}
+
+// validateFullConfig validates a fully-constructed storage config,
+// combining the user-specified config and any internally specified
+// config.
+func (p *hostDirProvider) validateFullConfig(cfg *storage.Config) error {
+	if err := p.ValidateConfig(cfg); err != nil {
+		return err
+	}
+	storageDir, ok := cfg.ValueString(storage.ConfigStorageDir)
+	if !ok || storageDir == "" {
+		return errors.New("storage directory not specified")
+	}
+	return nil
+}
+
+// ImmutableConfigAttributes is defined on the ImmutableConfigProvider
+// interface.
+//
+// Existing filesystems are identified by their directory names alone,
+// so the directory containing them may not be changed while the pool
+// is in use.
+func (*hostDirProvider) ImmutableConfigAttributes() []string {
+	return []string{HostDirPath}
+}
+
+// SupportsSharedFilesystems is defined on the SharedFilesystemProvider
+// interface.
+func (*hostDirProvider) SupportsSharedFilesystems() bool {
+	return true
+}
+
+// VolumeSource is defined on the Provider interface.
+func (p *hostDirProvider) VolumeSource(environConfig *config.Config, providerConfig *storage.Config) (storage.VolumeSource, error) {
+	return nil, errors.NotSupportedf("volumes")
+}
+
+// FilesystemSource is defined on the Provider interface.
+func (p *hostDirProvider) FilesystemSource(environConfig *config.Config, sourceConfig *storage.Config) (storage.FilesystemSource, error) {
+	if err := p.validateFullConfig(sourceConfig); err != nil {
+		return nil, err
+	}
+	// storageDir is validated by validateFullConfig.
+	storageDir, _ := sourceConfig.ValueString(storage.ConfigStorageDir)
+	path, ok := sourceConfig.ValueString(HostDirPath)
+	if !ok {
+		path = filepath.Join(storageDir, string(HostDirProviderType))
+	}
+	containerRoot, ok := sourceConfig.ValueString(HostDirContainerRoot)
+	if !ok {
+		containerRoot = defaultHostDirContainerRoot
+	}
+	return &hostDirFilesystemSource{
+		&osDirFuncs{p.run},
+		p.run,
+		path,
+		containerRoot,
+	}, nil
+}
+
+// Supports is defined on the Provider interface.
+func (*hostDirProvider) Supports(k storage.StorageKind) bool {
+	return k == storage.StorageKindFilesystem
+}
+
+// Scope is defined on the Provider interface.
+func (*hostDirProvider) Scope() storage.Scope {
+	return storage.ScopeMachine
+}
+
+// Dynamic is defined on the Provider interface.
+func (*hostDirProvider) Dynamic() bool {
+	return true
+}
+
+// hostDirFilesystemSource creates filesystems as directories within a
+// directory on the host machine. The filesystems' IDs are the names of
+// their directories, which are the string forms of their tags.
+type hostDirFilesystemSource struct {
+	dirFuncs      dirFuncs
+	run           runCommandFunc
+	path          string
+	containerRoot string
+}
+
+var _ storage.FilesystemSource = (*hostDirFilesystemSource)(nil)
+
+// ValidateFilesystemParams is defined on the FilesystemSource interface.
+func (s *hostDirFilesystemSource) ValidateFilesystemParams(params storage.FilesystemParams) error {
+	// ValidateFilesystemParams may be called on a machine other than the
+	// machine where the filesystem will be created, so we cannot check
+	// available size until we get to CreateFilesystem.
+	return nil
+}
+
+// CreateFilesystems is defined on the FilesystemSource interface.
+func (s *hostDirFilesystemSource) CreateFilesystems(args []storage.FilesystemParams) ([]storage.CreateFilesystemsResult, error) {
+	results := make([]storage.CreateFilesystemsResult, len(args))
+	for i, arg := range args {
+		filesystem, err := s.createFilesystem(arg)
+		if err != nil {
+			results[i].Error = err
+			continue
+		}
+		results[i].Filesystem = filesystem
+	}
+	return results, nil
+}
+
+func (s *hostDirFilesystemSource) createFilesystem(params storage.FilesystemParams) (*storage.Filesystem, error) {
+	if err := s.ValidateFilesystemParams(params); err != nil {
+		return nil, errors.Trace(err)
+	}
+	// The directory is not required to be empty, as the
+	// filesystem may be shared and already in use by the
+	// time creation is retried.
+	filesystemId := params.Tag.String()
+	if err := ensureDir(s.dirFuncs, filepath.Join(s.path, filesystemId)); err != nil {
+		return nil, errors.Trace(err)
+	}
+	sizeInMiB, err := s.dirFuncs.calculateSize(s.path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if sizeInMiB < params.Size {
+		return nil, errors.Errorf("filesystem is not big enough (%dM < %dM)", sizeInMiB, params.Size)
+	}
+	return &storage.Filesystem{
+		params.Tag,
+		names.VolumeTag{},
+		storage.FilesystemInfo{
+			FilesystemId: filesystemId,
+			Size:         sizeInMiB,
+		},
+	}, nil
+}
+
+// DestroyFilesystems is defined on the FilesystemSource interface.
+func (s *hostDirFilesystemSource) DestroyFilesystems(filesystemIds []string) ([]error, error) {
+	// DestroyFilesystems is a no-op; like the rootfs provider, we
+	// leave the directories in tact for post-mortems and such.
+	return make([]error, len(filesystemIds)), nil
+}
+
+// AttachFilesystems is defined on the FilesystemSource interface.
+func (s *hostDirFilesystemSource) AttachFilesystems(args []storage.FilesystemAttachmentParams) ([]storage.AttachFilesystemsResult, error) {
+	results := make([]storage.AttachFilesystemsResult, len(args))
+	for i, arg := range args {
+		attachment, err := s.attachFilesystem(arg)
+		if err != nil {
+			results[i].Error = err
+			continue
+		}
+		results[i].FilesystemAttachment = attachment
+	}
+	return results, nil
+}
+
+func (s *hostDirFilesystemSource) attachFilesystem(arg storage.FilesystemAttachmentParams) (*storage.FilesystemAttachment, error) {
+	if arg.Path == "" {
+		return nil, errNoMountPoint
+	}
+	source := filepath.Join(s.path, arg.FilesystemId)
+	target := s.hostMountPoint(arg)
+	if err := ensureDir(s.dirFuncs, target); err != nil {
+		return nil, errors.Trace(err)
+	}
+	targetSource, err := s.dirFuncs.mountPointSource(target)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting target mount-point source")
+	}
+	if targetSource != source {
+		logger.Debugf("mounting filesystem %q at %q", source, target)
+		if err := s.dirFuncs.bindMount(source, target); err != nil {
+			return nil, errors.Annotatef(err, "bind-mounting %q at %q", source, target)
+		}
+	}
+	return &storage.FilesystemAttachment{
+		arg.Filesystem,
+		arg.Machine,
+		storage.FilesystemAttachmentInfo{
+			Path: arg.Path,
+		},
+	}, nil
+}
+
+// hostMountPoint returns the path on the host machine at which the
+// filesystem should be mounted for the specified attachment. For
+// attachments to containers, this is the attachment path within the
+// container's root filesystem.
+func (s *hostDirFilesystemSource) hostMountPoint(arg storage.FilesystemAttachmentParams) string {
+	if !strings.Contains(arg.Machine.Id(), "/") {
+		return arg.Path
+	}
+	return filepath.Join(s.containerRoot, string(arg.InstanceId), "rootfs", arg.Path)
+}
+
+// DetachFilesystems is defined on the FilesystemSource interface.
+func (s *hostDirFilesystemSource) DetachFilesystems(args []storage.FilesystemAttachmentParams) ([]error, error) {
+	results := make([]error, len(args))
+	for i, arg := range args {
+		if err := maybeUnmount(s.run, s.dirFuncs, s.hostMountPoint(arg)); err != nil {
+			results[i] = err
+		}
+	}
+	return results, nil
+}
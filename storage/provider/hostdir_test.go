@@ -0,0 +1,273 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"path/filepath"
+	"runtime"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider"
+	"github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&hostDirSuite{})
+
+type hostDirSuite struct {
+	testing.BaseSuite
+	path          string
+	containerRoot string
+	commands      *mockRunCommand
+	mockDirFuncs  *provider.MockDirFuncs
+}
+
+func (s *hostDirSuite) SetUpTest(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("Tests relevant only on *nix systems")
+	}
+	s.BaseSuite.SetUpTest(c)
+	s.path = c.MkDir()
+	s.containerRoot = c.MkDir()
+}
+
+func (s *hostDirSuite) TearDownTest(c *gc.C) {
+	if s.commands != nil {
+		s.commands.assertDrained()
+	}
+	s.BaseSuite.TearDownTest(c)
+}
+
+func (s *hostDirSuite) hostDirProvider(c *gc.C) storage.Provider {
+	s.commands = &mockRunCommand{c: c}
+	return provider.HostDirProvider(s.commands.run)
+}
+
+func (s *hostDirSuite) hostDirFilesystemSource(c *gc.C) storage.FilesystemSource {
+	s.commands = &mockRunCommand{c: c}
+	source, d := provider.HostDirFilesystemSource(s.path, s.containerRoot, s.commands.run)
+	s.mockDirFuncs = d
+	return source
+}
+
+func (s *hostDirSuite) TestFilesystemSource(c *gc.C) {
+	p := s.hostDirProvider(c)
+	cfg, err := storage.NewConfig("name", provider.HostDirProviderType, map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = p.FilesystemSource(nil, cfg)
+	c.Assert(err, gc.ErrorMatches, "storage directory not specified")
+	cfg, err = storage.NewConfig("name", provider.HostDirProviderType, map[string]interface{}{
+		"storage-dir": c.MkDir(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = p.FilesystemSource(nil, cfg)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *hostDirSuite) TestValidateConfig(c *gc.C) {
+	p := s.hostDirProvider(c)
+	cfg, err := storage.NewConfig("name", provider.HostDirProviderType, map[string]interface{}{
+		"path":           "/srv/shared",
+		"container-root": "/var/lib/lxc",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = p.ValidateConfig(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *hostDirSuite) TestValidateConfigRelativePath(c *gc.C) {
+	p := s.hostDirProvider(c)
+	cfg, err := storage.NewConfig("name", provider.HostDirProviderType, map[string]interface{}{
+		"path": "srv/shared",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = p.ValidateConfig(cfg)
+	c.Assert(err, gc.ErrorMatches, `path "srv/shared" \(must be an absolute path\) not valid`)
+}
+
+func (s *hostDirSuite) TestSupports(c *gc.C) {
+	p := s.hostDirProvider(c)
+	c.Assert(p.Supports(storage.StorageKindBlock), jc.IsFalse)
+	c.Assert(p.Supports(storage.StorageKindFilesystem), jc.IsTrue)
+}
+
+func (s *hostDirSuite) TestScope(c *gc.C) {
+	p := s.hostDirProvider(c)
+	c.Assert(p.Scope(), gc.Equals, storage.ScopeMachine)
+}
+
+func (s *hostDirSuite) TestSupportsSharedFilesystems(c *gc.C) {
+	p := s.hostDirProvider(c)
+	shared, ok := p.(storage.SharedFilesystemProvider)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(shared.SupportsSharedFilesystems(), jc.IsTrue)
+}
+
+func (s *hostDirSuite) TestImmutableConfigAttributes(c *gc.C) {
+	p := s.hostDirProvider(c)
+	immutable, ok := p.(storage.ImmutableConfigProvider)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(immutable.ImmutableConfigAttributes(), jc.DeepEquals, []string{"path"})
+}
+
+func (s *hostDirSuite) TestCreateFilesystems(c *gc.C) {
+	source := s.hostDirFilesystemSource(c)
+	cmd := s.commands.expect("df", "--output=size", s.path)
+	cmd.respond("1K-blocks\n4096", nil)
+
+	results, err := source.CreateFilesystems([]storage.FilesystemParams{{
+		Tag:  names.NewFilesystemTag("0/1"),
+		Size: 2,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.CreateFilesystemsResult{{
+		Filesystem: &storage.Filesystem{
+			Tag: names.NewFilesystemTag("0/1"),
+			FilesystemInfo: storage.FilesystemInfo{
+				FilesystemId: "filesystem-0-1",
+				Size:         4,
+			},
+		},
+	}})
+	c.Assert(s.mockDirFuncs.Dirs.Contains(filepath.Join(s.path, "filesystem-0-1")), jc.IsTrue)
+}
+
+func (s *hostDirSuite) TestCreateFilesystemsNotEnoughSpace(c *gc.C) {
+	source := s.hostDirFilesystemSource(c)
+	cmd := s.commands.expect("df", "--output=size", s.path)
+	cmd.respond("1K-blocks\n2048", nil)
+
+	results, err := source.CreateFilesystems([]storage.FilesystemParams{{
+		Tag:  names.NewFilesystemTag("0/1"),
+		Size: 4,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, gc.ErrorMatches, "filesystem is not big enough \\(2M < 4M\\)")
+}
+
+func (s *hostDirSuite) TestAttachFilesystemsNoPathSpecified(c *gc.C) {
+	source := s.hostDirFilesystemSource(c)
+	results, err := source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("0/1"),
+		FilesystemId: "filesystem-0-1",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, gc.ErrorMatches, "filesystem mount point not specified")
+}
+
+func (s *hostDirSuite) TestAttachFilesystemsHost(c *gc.C) {
+	source := s.hostDirFilesystemSource(c)
+
+	cmd := s.commands.expect("df", "--output=source", "/srv")
+	cmd.respond("headers\n/src/of/root", nil)
+	cmd = s.commands.expect("mount", "--bind", filepath.Join(s.path, "filesystem-0-1"), "/srv")
+	cmd.respond("", nil)
+
+	results, err := source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("0/1"),
+		FilesystemId: "filesystem-0-1",
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    names.NewMachineTag("0"),
+			InstanceId: "inst-0",
+		},
+		Path: "/srv",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.AttachFilesystemsResult{{
+		FilesystemAttachment: &storage.FilesystemAttachment{
+			Filesystem: names.NewFilesystemTag("0/1"),
+			Machine:    names.NewMachineTag("0"),
+			FilesystemAttachmentInfo: storage.FilesystemAttachmentInfo{
+				Path: "/srv",
+			},
+		},
+	}})
+}
+
+func (s *hostDirSuite) TestAttachFilesystemsContainer(c *gc.C) {
+	source := s.hostDirFilesystemSource(c)
+	target := filepath.Join(s.containerRoot, "juju-machine-0-lxd-0", "rootfs", "srv")
+
+	cmd := s.commands.expect("df", "--output=source", target)
+	cmd.respond("headers\n/src/of/root", nil)
+	cmd = s.commands.expect("mount", "--bind", filepath.Join(s.path, "filesystem-0-1"), target)
+	cmd.respond("", nil)
+
+	results, err := source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("0/1"),
+		FilesystemId: "filesystem-0-1",
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    names.NewMachineTag("0/lxd/0"),
+			InstanceId: "juju-machine-0-lxd-0",
+		},
+		Path: "/srv",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.AttachFilesystemsResult{{
+		FilesystemAttachment: &storage.FilesystemAttachment{
+			Filesystem: names.NewFilesystemTag("0/1"),
+			Machine:    names.NewMachineTag("0/lxd/0"),
+			FilesystemAttachmentInfo: storage.FilesystemAttachmentInfo{
+				Path: "/srv",
+			},
+		},
+	}})
+	c.Assert(s.mockDirFuncs.Dirs.Contains(target), jc.IsTrue)
+}
+
+func (s *hostDirSuite) TestAttachFilesystemsBound(c *gc.C) {
+	source := s.hostDirFilesystemSource(c)
+
+	// Already bind-mounted path/filesystem-0-1 to the target.
+	cmd := s.commands.expect("df", "--output=source", "/srv")
+	cmd.respond("headers\n"+filepath.Join(s.path, "filesystem-0-1"), nil)
+
+	results, err := source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("0/1"),
+		FilesystemId: "filesystem-0-1",
+		AttachmentParams: storage.AttachmentParams{
+			Machine: names.NewMachineTag("0"),
+		},
+		Path: "/srv",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+}
+
+func (s *hostDirSuite) TestDetachFilesystems(c *gc.C) {
+	source := s.hostDirFilesystemSource(c)
+	testDetachFilesystems(c, s.commands, source, true)
+}
+
+func (s *hostDirSuite) TestDetachFilesystemsUnattached(c *gc.C) {
+	source := s.hostDirFilesystemSource(c)
+	testDetachFilesystems(c, s.commands, source, false)
+}
+
+func (s *hostDirSuite) TestDetachFilesystemsContainer(c *gc.C) {
+	source := s.hostDirFilesystemSource(c)
+	target := filepath.Join(s.containerRoot, "juju-machine-0-lxd-0", "rootfs", "srv")
+
+	cmd := s.commands.expect("df", "--output=source", filepath.Dir(target))
+	cmd.respond("headers\n/same/as/rootfs", nil)
+	cmd = s.commands.expect("df", "--output=source", target)
+	cmd.respond("headers\n/different/to/rootfs", nil)
+	s.commands.expect("umount", target)
+
+	results, err := source.DetachFilesystems([]storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("0/1"),
+		FilesystemId: "filesystem-0-1",
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    names.NewMachineTag("0/lxd/0"),
+			InstanceId: "juju-machine-0-lxd-0",
+		},
+		Path: "/srv",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0], jc.ErrorIsNil)
+}
@@ -5,6 +5,7 @@ package provider
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path"
 	"path/filepath"
@@ -91,6 +92,18 @@ func (*loopProvider) Dynamic() bool {
 	return true
 }
 
+// SupportsVolumeSnapshots is defined on the VolumeSnapshotProvider
+// interface.
+func (*loopProvider) SupportsVolumeSnapshots() bool {
+	return true
+}
+
+// SupportsVolumeResize is defined on the VolumeResizeProvider
+// interface.
+func (*loopProvider) SupportsVolumeResize() bool {
+	return true
+}
+
 // loopVolumeSource provides common functionality to handle
 // loop devices for rootfs and host loop volume sources.
 type loopVolumeSource struct {
@@ -99,7 +112,11 @@ type loopVolumeSource struct {
 	storageDir string
 }
 
-var _ storage.VolumeSource = (*loopVolumeSource)(nil)
+var (
+	_ storage.VolumeSource      = (*loopVolumeSource)(nil)
+	_ storage.VolumeSnapshotter = (*loopVolumeSource)(nil)
+	_ storage.VolumeResizer     = (*loopVolumeSource)(nil)
+)
 
 // CreateVolumes is defined on the VolumeSource interface.
 func (lvs *loopVolumeSource) CreateVolumes(args []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
@@ -239,6 +256,195 @@ func (lvs *loopVolumeSource) detachVolume(tag names.VolumeTag) error {
 	return nil
 }
 
+// CreateVolumeSnapshots is defined on the VolumeSnapshotter interface.
+//
+// Loop volume snapshots are sparse copies of the volumes' backing files,
+// kept in a "snapshots" directory alongside them.
+func (lvs *loopVolumeSource) CreateVolumeSnapshots(args []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+	results := make([]storage.CreateVolumeSnapshotsResult, len(args))
+	for i, arg := range args {
+		snapshot, err := lvs.createVolumeSnapshot(arg)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "creating snapshot of volume %v", arg.Volume.Id())
+			continue
+		}
+		results[i].VolumeSnapshot = snapshot
+	}
+	return results, nil
+}
+
+func (lvs *loopVolumeSource) createVolumeSnapshot(arg storage.VolumeSnapshotParams) (*storage.VolumeSnapshot, error) {
+	snapshotId := loopSnapshotId(arg.Id)
+	snapshotFilePath := lvs.snapshotFilePath(snapshotId)
+	if err := ensureDir(lvs.dirFuncs, filepath.Dir(snapshotFilePath)); err != nil {
+		return nil, errors.Trace(err)
+	}
+	loopFilePath := lvs.volumeFilePath(arg.Volume)
+	info, err := os.Stat(loopFilePath)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading loop backing file")
+	}
+	if err := copyBlockFile(lvs.run, loopFilePath, snapshotFilePath); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &storage.VolumeSnapshot{
+		arg.Id,
+		arg.Volume,
+		storage.VolumeSnapshotInfo{
+			SnapshotId: snapshotId,
+			Size:       uint64(info.Size()) / (1024 * 1024),
+		},
+	}, nil
+}
+
+// loopSnapshotId returns the provider snapshot ID for the snapshot
+// with the given Juju snapshot ID. The snapshot ID is used as a file
+// name, so path separators must be removed.
+func loopSnapshotId(id string) string {
+	return "snapshot-" + strings.NewReplacer("/", "-", ":", "-").Replace(id)
+}
+
+func (lvs *loopVolumeSource) snapshotFilePath(snapshotId string) string {
+	return filepath.Join(lvs.storageDir, "snapshots", snapshotId)
+}
+
+// ListVolumeSnapshots is defined on the VolumeSnapshotter interface.
+func (lvs *loopVolumeSource) ListVolumeSnapshots() ([]string, error) {
+	infos, err := ioutil.ReadDir(filepath.Join(lvs.storageDir, "snapshots"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Annotate(err, "listing snapshots")
+	}
+	snapshotIds := make([]string, 0, len(infos))
+	for _, info := range infos {
+		if info.Mode().IsRegular() {
+			snapshotIds = append(snapshotIds, info.Name())
+		}
+	}
+	return snapshotIds, nil
+}
+
+// DeleteVolumeSnapshots is defined on the VolumeSnapshotter interface.
+func (lvs *loopVolumeSource) DeleteVolumeSnapshots(snapshotIds []string) ([]error, error) {
+	results := make([]error, len(snapshotIds))
+	for i, snapshotId := range snapshotIds {
+		if err := lvs.deleteVolumeSnapshot(snapshotId); err != nil {
+			results[i] = errors.Annotatef(err, "deleting %q", snapshotId)
+		}
+	}
+	return results, nil
+}
+
+func (lvs *loopVolumeSource) deleteVolumeSnapshot(snapshotId string) error {
+	if !strings.HasPrefix(snapshotId, "snapshot-") || strings.ContainsRune(snapshotId, os.PathSeparator) {
+		return errors.Errorf("invalid loop snapshot ID %q", snapshotId)
+	}
+	err := os.Remove(lvs.snapshotFilePath(snapshotId))
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Annotate(err, "removing snapshot file")
+	}
+	return nil
+}
+
+// CreateVolumesFromSnapshots is defined on the VolumeSnapshotter interface.
+func (lvs *loopVolumeSource) CreateVolumesFromSnapshots(args []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
+	results := make([]storage.CreateVolumesResult, len(args))
+	for i, arg := range args {
+		volume, err := lvs.createVolumeFromSnapshot(arg)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "creating volume from snapshot %q", arg.SnapshotId)
+			continue
+		}
+		results[i].Volume = &volume
+	}
+	return results, nil
+}
+
+func (lvs *loopVolumeSource) createVolumeFromSnapshot(params storage.VolumeParams) (storage.Volume, error) {
+	snapshotFilePath := lvs.snapshotFilePath(params.SnapshotId)
+	if _, err := os.Stat(snapshotFilePath); err != nil {
+		return storage.Volume{}, errors.Annotate(err, "reading snapshot file")
+	}
+	loopFilePath := lvs.volumeFilePath(params.Tag)
+	if err := ensureDir(lvs.dirFuncs, filepath.Dir(loopFilePath)); err != nil {
+		return storage.Volume{}, errors.Trace(err)
+	}
+	if err := copyBlockFile(lvs.run, snapshotFilePath, loopFilePath); err != nil {
+		return storage.Volume{}, errors.Trace(err)
+	}
+	// The requested size may be larger than the snapshot;
+	// fallocate will extend the copied file as necessary.
+	if err := createBlockFile(lvs.run, loopFilePath, params.Size); err != nil {
+		return storage.Volume{}, errors.Annotate(err, "could not extend block file")
+	}
+	return storage.Volume{
+		params.Tag,
+		storage.VolumeInfo{
+			VolumeId: params.Tag.String(),
+			Size:     params.Size,
+		},
+	}, nil
+}
+
+// ResizeVolumes is defined on the VolumeResizer interface.
+//
+// Loop volumes are resized by growing their backing files. Any loop
+// devices attached to the backing files are then told to pick up the
+// new size.
+func (lvs *loopVolumeSource) ResizeVolumes(args []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	results := make([]storage.ResizeVolumesResult, len(args))
+	for i, arg := range args {
+		info, err := lvs.resizeVolume(arg)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "resizing volume %v", arg.Tag.Id())
+			continue
+		}
+		results[i].VolumeInfo = info
+	}
+	return results, nil
+}
+
+func (lvs *loopVolumeSource) resizeVolume(arg storage.VolumeResizeParams) (*storage.VolumeInfo, error) {
+	loopFilePath := lvs.volumeFilePath(arg.Tag)
+	info, err := os.Stat(loopFilePath)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading loop backing file")
+	}
+	if uint64(info.Size()) > arg.Size*1024*1024 {
+		return nil, errors.Errorf(
+			"cannot shrink volume from %dMiB to %dMiB",
+			uint64(info.Size())/(1024*1024), arg.Size,
+		)
+	}
+	if err := createBlockFile(lvs.run, loopFilePath, arg.Size); err != nil {
+		return nil, errors.Annotate(err, "could not extend block file")
+	}
+	deviceNames, err := associatedLoopDevices(lvs.run, loopFilePath)
+	if err != nil {
+		return nil, errors.Annotate(err, "locating loop device")
+	}
+	for _, deviceName := range deviceNames {
+		if err := refreshLoopDeviceSize(lvs.run, deviceName); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return &storage.VolumeInfo{
+		VolumeId: arg.VolumeId,
+		Size:     arg.Size,
+	}, nil
+}
+
+// copyBlockFile makes a sparse copy of the block file at the source
+// path, at the destination path.
+func copyBlockFile(run runCommandFunc, src, dst string) error {
+	_, err := run("cp", "--sparse=always", src, dst)
+	if err != nil {
+		return errors.Annotatef(err, "copying %q to %q", src, dst)
+	}
+	return nil
+}
+
 // createBlockFile creates a file at the specified path, with the
 // given size in mebibytes.
 func createBlockFile(run runCommandFunc, filePath string, sizeInMiB uint64) error {
@@ -289,6 +495,16 @@ func detachLoopDevice(run runCommandFunc, deviceName string) error {
 	return err
 }
 
+// refreshLoopDeviceSize causes the loop device with the specified
+// name to re-read the size of its backing file.
+func refreshLoopDeviceSize(run runCommandFunc, deviceName string) error {
+	_, err := run("losetup", "-c", path.Join("/dev", deviceName))
+	if err != nil {
+		return errors.Annotatef(err, "refreshing size of loop device %q", deviceName)
+	}
+	return nil
+}
+
 // associatedLoopDevices returns the device names of the loop devices
 // associated with the specified file path.
 func associatedLoopDevices(run runCommandFunc, filePath string) ([]string, error) {
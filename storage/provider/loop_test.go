@@ -272,3 +272,165 @@ func (s *loopSuite) TestDetachVolumesDetachFails(c *gc.C) {
 	_, err = os.Stat(fileName)
 	c.Assert(err, jc.ErrorIsNil)
 }
+
+func (s *loopSuite) TestCreateVolumeSnapshots(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	fileName := filepath.Join(s.storageDir, "volume-0-1")
+	f, err := os.Create(fileName)
+	c.Assert(err, jc.ErrorIsNil)
+	err = f.Truncate(2 * 1024 * 1024)
+	f.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	s.commands.expect("cp", "--sparse=always", fileName, filepath.Join(s.storageDir, "snapshots", "snapshot-0-1-2"))
+
+	snapshotter := source.(storage.VolumeSnapshotter)
+	results, err := snapshotter.CreateVolumeSnapshots([]storage.VolumeSnapshotParams{{
+		Id:       "0/1:2",
+		Volume:   names.NewVolumeTag("0/1"),
+		VolumeId: "volume-0-1",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].VolumeSnapshot, jc.DeepEquals, &storage.VolumeSnapshot{
+		"0/1:2",
+		names.NewVolumeTag("0/1"),
+		storage.VolumeSnapshotInfo{
+			SnapshotId: "snapshot-0-1-2",
+			Size:       2,
+		},
+	})
+}
+
+func (s *loopSuite) TestCreateVolumeSnapshotsMissingVolume(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	snapshotter := source.(storage.VolumeSnapshotter)
+	results, err := snapshotter.CreateVolumeSnapshots([]storage.VolumeSnapshotParams{{
+		Id:     "0:1",
+		Volume: names.NewVolumeTag("0"),
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, "creating snapshot of volume 0: reading loop backing file: .*")
+}
+
+func (s *loopSuite) TestListVolumeSnapshots(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	snapshotter := source.(storage.VolumeSnapshotter)
+	snapshotIds, err := snapshotter.ListVolumeSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshotIds, gc.HasLen, 0)
+
+	snapshotDir := filepath.Join(s.storageDir, "snapshots")
+	err = os.Mkdir(snapshotDir, 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, name := range []string{"snapshot-0-1", "snapshot-0-2"} {
+		err := ioutil.WriteFile(filepath.Join(snapshotDir, name), nil, 0644)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	snapshotIds, err = snapshotter.ListVolumeSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshotIds, jc.SameContents, []string{"snapshot-0-1", "snapshot-0-2"})
+}
+
+func (s *loopSuite) TestDeleteVolumeSnapshots(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	snapshotDir := filepath.Join(s.storageDir, "snapshots")
+	err := os.Mkdir(snapshotDir, 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	fileName := filepath.Join(snapshotDir, "snapshot-0-1")
+	err = ioutil.WriteFile(fileName, nil, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	snapshotter := source.(storage.VolumeSnapshotter)
+	errs, err := snapshotter.DeleteVolumeSnapshots([]string{"snapshot-0-1", "../volume-0"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, gc.HasLen, 2)
+	c.Assert(errs[0], jc.ErrorIsNil)
+	c.Assert(errs[1], gc.ErrorMatches, `.* invalid loop snapshot ID "\.\./volume-0"`)
+
+	_, err = os.Stat(fileName)
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
+
+func (s *loopSuite) TestCreateVolumesFromSnapshots(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	snapshotDir := filepath.Join(s.storageDir, "snapshots")
+	err := os.Mkdir(snapshotDir, 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	snapshotFile := filepath.Join(snapshotDir, "snapshot-0-1")
+	err = ioutil.WriteFile(snapshotFile, nil, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	volumeFile := filepath.Join(s.storageDir, "volume-2")
+	s.commands.expect("cp", "--sparse=always", snapshotFile, volumeFile)
+	s.commands.expect("fallocate", "-l", "4MiB", volumeFile)
+
+	snapshotter := source.(storage.VolumeSnapshotter)
+	results, err := snapshotter.CreateVolumesFromSnapshots([]storage.VolumeParams{{
+		Tag:        names.NewVolumeTag("2"),
+		Size:       4,
+		SnapshotId: "snapshot-0-1",
+	}, {
+		Tag:        names.NewVolumeTag("3"),
+		Size:       4,
+		SnapshotId: "snapshot-0-2",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Volume, jc.DeepEquals, &storage.Volume{
+		names.NewVolumeTag("2"),
+		storage.VolumeInfo{
+			VolumeId: "volume-2",
+			Size:     4,
+		},
+	})
+	c.Assert(results[1].Error, gc.ErrorMatches, `creating volume from snapshot "snapshot-0-2": reading snapshot file: .*`)
+}
+
+func (s *loopSuite) TestResizeVolumes(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	fileName := filepath.Join(s.storageDir, "volume-0")
+	err := ioutil.WriteFile(fileName, nil, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	s.commands.expect("fallocate", "-l", "4MiB", fileName)
+	cmd := s.commands.expect("losetup", "-j", fileName)
+	cmd.respond("/dev/loop0: foo\n", nil)
+	s.commands.expect("losetup", "-c", "/dev/loop0")
+
+	resizer := source.(storage.VolumeResizer)
+	results, err := resizer.ResizeVolumes([]storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: "volume-0",
+		Size:     4,
+	}, {
+		Tag:      names.NewVolumeTag("1"),
+		VolumeId: "volume-1",
+		Size:     4,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].VolumeInfo, jc.DeepEquals, &storage.VolumeInfo{
+		VolumeId: "volume-0",
+		Size:     4,
+	})
+	c.Assert(results[1].Error, gc.ErrorMatches, `resizing volume 1: reading loop backing file: .*`)
+}
+
+func (s *loopSuite) TestResizeVolumesShrink(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	fileName := filepath.Join(s.storageDir, "volume-0")
+	err := ioutil.WriteFile(fileName, make([]byte, 2*1024*1024), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	resizer := source.(storage.VolumeResizer)
+	results, err := resizer.ResizeVolumes([]storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: "volume-0",
+		Size:     1,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, `resizing volume 0: cannot shrink volume from 2MiB to 1MiB`)
+}
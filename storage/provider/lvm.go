@@ -0,0 +1,521 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+	"math"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/storage"
+)
+
+const (
+	// LVMProviderType is the storage provider type for LVM
+	// logical volumes.
+	LVMProviderType = storage.ProviderType("lvm")
+
+	// LVMVolumeGroup is the name of the pool configuration attribute
+	// identifying the LVM volume group in which to create volumes.
+	LVMVolumeGroup = "volume-group"
+)
+
+// validLVMName matches valid LVM volume group and logical volume names.
+var validLVMName = regexp.MustCompile(`^[a-zA-Z0-9+_.][a-zA-Z0-9+_.-]*$`)
+
+// lvmProvider creates volume sources which use LVM logical volumes,
+// carved out of a volume group on the machine.
+type lvmProvider struct {
+	// run is a function used for running commands on the local machine.
+	run runCommandFunc
+}
+
+var (
+	_ storage.Provider                = (*lvmProvider)(nil)
+	_ storage.ImmutableConfigProvider = (*lvmProvider)(nil)
+)
+
+// ValidateConfig is defined on the Provider interface.
+func (*lvmProvider) ValidateConfig(cfg *storage.Config) error {
+	volumeGroup, ok := cfg.ValueString(LVMVolumeGroup)
+	if !ok || volumeGroup == "" {
+		return errors.New("volume group not specified")
+	}
+	if !validLVMName.MatchString(volumeGroup) {
+		return errors.NotValidf("volume group name %q", volumeGroup)
+	}
+	return nil
+}
+
+// ImmutableConfigAttributes is defined on the ImmutableConfigProvider
+// interface.
+//
+// Existing volumes are identified by their logical volume names alone,
+// so the volume group may not be changed while the pool is in use.
+func (*lvmProvider) ImmutableConfigAttributes() []string {
+	return []string{LVMVolumeGroup}
+}
+
+// VolumeSource is defined on the Provider interface.
+func (p *lvmProvider) VolumeSource(
+	environConfig *config.Config,
+	sourceConfig *storage.Config,
+) (storage.VolumeSource, error) {
+	if err := p.ValidateConfig(sourceConfig); err != nil {
+		return nil, err
+	}
+	// volumeGroup is validated by ValidateConfig.
+	volumeGroup, _ := sourceConfig.ValueString(LVMVolumeGroup)
+	return &lvmVolumeSource{p.run, volumeGroup}, nil
+}
+
+// FilesystemSource is defined on the Provider interface.
+func (p *lvmProvider) FilesystemSource(
+	environConfig *config.Config,
+	providerConfig *storage.Config,
+) (storage.FilesystemSource, error) {
+	return nil, errors.NotSupportedf("filesystems")
+}
+
+// Supports is defined on the Provider interface.
+func (*lvmProvider) Supports(k storage.StorageKind) bool {
+	return k == storage.StorageKindBlock
+}
+
+// Scope is defined on the Provider interface.
+func (*lvmProvider) Scope() storage.Scope {
+	return storage.ScopeMachine
+}
+
+// Dynamic is defined on the Provider interface.
+func (*lvmProvider) Dynamic() bool {
+	return true
+}
+
+// SupportsVolumeSnapshots is defined on the VolumeSnapshotProvider
+// interface.
+func (*lvmProvider) SupportsVolumeSnapshots() bool {
+	return true
+}
+
+// SupportsVolumeResize is defined on the VolumeResizeProvider
+// interface.
+func (*lvmProvider) SupportsVolumeResize() bool {
+	return true
+}
+
+// lvmVolumeSource creates, attaches, snapshots and resizes LVM
+// logical volumes in a volume group. The volumes' IDs are their
+// logical volume names, which are the string forms of their tags.
+type lvmVolumeSource struct {
+	run         runCommandFunc
+	volumeGroup string
+}
+
+var (
+	_ storage.VolumeSource      = (*lvmVolumeSource)(nil)
+	_ storage.VolumeSnapshotter = (*lvmVolumeSource)(nil)
+	_ storage.VolumeResizer     = (*lvmVolumeSource)(nil)
+)
+
+// CreateVolumes is defined on the VolumeSource interface.
+func (lvs *lvmVolumeSource) CreateVolumes(args []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
+	results := make([]storage.CreateVolumesResult, len(args))
+	for i, arg := range args {
+		volume, err := lvs.createVolume(arg)
+		if err != nil {
+			results[i].Error = errors.Annotate(err, "creating volume")
+			continue
+		}
+		results[i].Volume = &volume
+	}
+	return results, nil
+}
+
+func (lvs *lvmVolumeSource) createVolume(params storage.VolumeParams) (storage.Volume, error) {
+	volumeId := params.Tag.String()
+	if err := createLogicalVolume(lvs.run, lvs.volumeGroup, volumeId, params.Size); err != nil {
+		return storage.Volume{}, errors.Trace(err)
+	}
+	return storage.Volume{
+		params.Tag,
+		storage.VolumeInfo{
+			VolumeId: volumeId,
+			Size:     params.Size,
+		},
+	}, nil
+}
+
+// ListVolumes is defined on the VolumeSource interface.
+func (lvs *lvmVolumeSource) ListVolumes() ([]string, error) {
+	sizes, err := logicalVolumeSizes(lvs.run, lvs.volumeGroup)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var volumeIds []string
+	for name := range sizes {
+		if _, err := names.ParseVolumeTag(name); err == nil {
+			volumeIds = append(volumeIds, name)
+		}
+	}
+	return volumeIds, nil
+}
+
+// DescribeVolumes is defined on the VolumeSource interface.
+func (lvs *lvmVolumeSource) DescribeVolumes(volumeIds []string) ([]storage.DescribeVolumesResult, error) {
+	sizes, err := logicalVolumeSizes(lvs.run, lvs.volumeGroup)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	results := make([]storage.DescribeVolumesResult, len(volumeIds))
+	for i, volumeId := range volumeIds {
+		size, ok := sizes[volumeId]
+		if !ok {
+			results[i].Error = errors.NotFoundf("volume %q", volumeId)
+			continue
+		}
+		results[i].VolumeInfo = &storage.VolumeInfo{
+			VolumeId: volumeId,
+			Size:     size,
+		}
+	}
+	return results, nil
+}
+
+// DestroyVolumes is defined on the VolumeSource interface.
+func (lvs *lvmVolumeSource) DestroyVolumes(volumeIds []string) ([]error, error) {
+	results := make([]error, len(volumeIds))
+	for i, volumeId := range volumeIds {
+		if _, err := names.ParseVolumeTag(volumeId); err != nil {
+			results[i] = errors.Errorf("invalid lvm volume ID %q", volumeId)
+			continue
+		}
+		if err := removeLogicalVolume(lvs.run, lvs.volumeGroup, volumeId); err != nil {
+			results[i] = errors.Annotatef(err, "destroying %q", volumeId)
+		}
+	}
+	return results, nil
+}
+
+// ValidateVolumeParams is defined on the VolumeSource interface.
+func (lvs *lvmVolumeSource) ValidateVolumeParams(params storage.VolumeParams) error {
+	// ValidateVolumeParams may be called on a machine other than the
+	// machine where the logical volume will be created, so we cannot
+	// check the volume group's free space until we get to CreateVolumes.
+	return nil
+}
+
+// AttachVolumes is defined on the VolumeSource interface.
+//
+// Logical volumes are local to the machine, so attaching a volume
+// amounts to activating it with the requested permissions.
+func (lvs *lvmVolumeSource) AttachVolumes(args []storage.VolumeAttachmentParams) ([]storage.AttachVolumesResult, error) {
+	results := make([]storage.AttachVolumesResult, len(args))
+	for i, arg := range args {
+		attachment, err := lvs.attachVolume(arg)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "attaching volume %v", arg.Volume.Id())
+			continue
+		}
+		results[i].VolumeAttachment = attachment
+	}
+	return results, nil
+}
+
+func (lvs *lvmVolumeSource) attachVolume(arg storage.VolumeAttachmentParams) (*storage.VolumeAttachment, error) {
+	permission := "rw"
+	if arg.ReadOnly {
+		permission = "r"
+	}
+	lvPath := logicalVolumePath(lvs.volumeGroup, arg.VolumeId)
+	if _, err := lvs.run("lvchange", "--activate", "y", "--permission", permission, lvPath); err != nil {
+		return nil, errors.Annotatef(err, "activating logical volume %q", lvPath)
+	}
+	return &storage.VolumeAttachment{
+		arg.Volume,
+		arg.Machine,
+		storage.VolumeAttachmentInfo{
+			DeviceLink: path.Join("/dev", lvPath),
+			ReadOnly:   arg.ReadOnly,
+		},
+	}, nil
+}
+
+// DetachVolumes is defined on the VolumeSource interface.
+func (lvs *lvmVolumeSource) DetachVolumes(args []storage.VolumeAttachmentParams) ([]error, error) {
+	results := make([]error, len(args))
+	for i, arg := range args {
+		lvPath := logicalVolumePath(lvs.volumeGroup, arg.VolumeId)
+		if _, err := lvs.run("lvchange", "--activate", "n", lvPath); err != nil {
+			results[i] = errors.Annotatef(err, "detaching volume %s", arg.Volume.Id())
+		}
+	}
+	return results, nil
+}
+
+// CreateVolumeSnapshots is defined on the VolumeSnapshotter interface.
+//
+// LVM volume snapshots are snapshot logical volumes in the same volume
+// group, sized to hold a complete copy of the origin volume.
+func (lvs *lvmVolumeSource) CreateVolumeSnapshots(args []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+	results := make([]storage.CreateVolumeSnapshotsResult, len(args))
+	if len(args) == 0 {
+		return results, nil
+	}
+	sizes, err := logicalVolumeSizes(lvs.run, lvs.volumeGroup)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for i, arg := range args {
+		snapshot, err := lvs.createVolumeSnapshot(arg, sizes)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "creating snapshot of volume %v", arg.Volume.Id())
+			continue
+		}
+		results[i].VolumeSnapshot = snapshot
+	}
+	return results, nil
+}
+
+func (lvs *lvmVolumeSource) createVolumeSnapshot(
+	arg storage.VolumeSnapshotParams,
+	sizes map[string]uint64,
+) (*storage.VolumeSnapshot, error) {
+	size, ok := sizes[arg.VolumeId]
+	if !ok {
+		return nil, errors.NotFoundf("volume %q", arg.VolumeId)
+	}
+	snapshotId := lvmSnapshotId(arg.Id)
+	_, err := lvs.run(
+		"lvcreate", "--snapshot",
+		"--name", snapshotId,
+		"--size", fmt.Sprintf("%dm", size),
+		logicalVolumePath(lvs.volumeGroup, arg.VolumeId),
+	)
+	if err != nil {
+		return nil, errors.Annotatef(err, "creating snapshot logical volume %q", snapshotId)
+	}
+	return &storage.VolumeSnapshot{
+		arg.Id,
+		arg.Volume,
+		storage.VolumeSnapshotInfo{
+			SnapshotId: snapshotId,
+			Size:       size,
+		},
+	}, nil
+}
+
+// lvmSnapshotId returns the provider snapshot ID for the snapshot
+// with the given Juju snapshot ID. The snapshot ID is used as a
+// logical volume name, so path separators must be removed.
+func lvmSnapshotId(id string) string {
+	return "snapshot-" + strings.NewReplacer("/", "-", ":", "-").Replace(id)
+}
+
+// ListVolumeSnapshots is defined on the VolumeSnapshotter interface.
+func (lvs *lvmVolumeSource) ListVolumeSnapshots() ([]string, error) {
+	sizes, err := logicalVolumeSizes(lvs.run, lvs.volumeGroup)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var snapshotIds []string
+	for name := range sizes {
+		if strings.HasPrefix(name, "snapshot-") {
+			snapshotIds = append(snapshotIds, name)
+		}
+	}
+	return snapshotIds, nil
+}
+
+// DeleteVolumeSnapshots is defined on the VolumeSnapshotter interface.
+func (lvs *lvmVolumeSource) DeleteVolumeSnapshots(snapshotIds []string) ([]error, error) {
+	results := make([]error, len(snapshotIds))
+	for i, snapshotId := range snapshotIds {
+		if !strings.HasPrefix(snapshotId, "snapshot-") || !validLVMName.MatchString(snapshotId) {
+			results[i] = errors.Errorf("invalid lvm snapshot ID %q", snapshotId)
+			continue
+		}
+		if err := removeLogicalVolume(lvs.run, lvs.volumeGroup, snapshotId); err != nil {
+			results[i] = errors.Annotatef(err, "deleting %q", snapshotId)
+		}
+	}
+	return results, nil
+}
+
+// CreateVolumesFromSnapshots is defined on the VolumeSnapshotter interface.
+//
+// LVM snapshots cannot be detached from their origin volumes, so new
+// volumes are created and the snapshots' contents copied into them.
+func (lvs *lvmVolumeSource) CreateVolumesFromSnapshots(args []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
+	results := make([]storage.CreateVolumesResult, len(args))
+	if len(args) == 0 {
+		return results, nil
+	}
+	sizes, err := logicalVolumeSizes(lvs.run, lvs.volumeGroup)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for i, arg := range args {
+		volume, err := lvs.createVolumeFromSnapshot(arg, sizes)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "creating volume from snapshot %q", arg.SnapshotId)
+			continue
+		}
+		results[i].Volume = &volume
+	}
+	return results, nil
+}
+
+func (lvs *lvmVolumeSource) createVolumeFromSnapshot(
+	params storage.VolumeParams,
+	sizes map[string]uint64,
+) (storage.Volume, error) {
+	snapshotSize, ok := sizes[params.SnapshotId]
+	if !ok {
+		return storage.Volume{}, errors.NotFoundf("snapshot %q", params.SnapshotId)
+	}
+	if params.Size < snapshotSize {
+		return storage.Volume{}, errors.Errorf(
+			"volume size %dMiB smaller than snapshot size %dMiB",
+			params.Size, snapshotSize,
+		)
+	}
+	volume, err := lvs.createVolume(params)
+	if err != nil {
+		return storage.Volume{}, errors.Trace(err)
+	}
+	_, err = lvs.run(
+		"dd",
+		"if="+path.Join("/dev", logicalVolumePath(lvs.volumeGroup, params.SnapshotId)),
+		"of="+path.Join("/dev", logicalVolumePath(lvs.volumeGroup, volume.VolumeId)),
+		"bs=1M", "conv=sparse",
+	)
+	if err != nil {
+		if err := removeLogicalVolume(lvs.run, lvs.volumeGroup, volume.VolumeId); err != nil {
+			logger.Errorf("removing logical volume %q: %v", volume.VolumeId, err)
+		}
+		return storage.Volume{}, errors.Annotate(err, "copying snapshot")
+	}
+	return volume, nil
+}
+
+// ResizeVolumes is defined on the VolumeResizer interface.
+func (lvs *lvmVolumeSource) ResizeVolumes(args []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	results := make([]storage.ResizeVolumesResult, len(args))
+	if len(args) == 0 {
+		return results, nil
+	}
+	sizes, err := logicalVolumeSizes(lvs.run, lvs.volumeGroup)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for i, arg := range args {
+		info, err := lvs.resizeVolume(arg, sizes)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "resizing volume %v", arg.Tag.Id())
+			continue
+		}
+		results[i].VolumeInfo = info
+	}
+	return results, nil
+}
+
+func (lvs *lvmVolumeSource) resizeVolume(
+	arg storage.VolumeResizeParams,
+	sizes map[string]uint64,
+) (*storage.VolumeInfo, error) {
+	size, ok := sizes[arg.VolumeId]
+	if !ok {
+		return nil, errors.NotFoundf("volume %q", arg.VolumeId)
+	}
+	if size > arg.Size {
+		return nil, errors.Errorf("cannot shrink volume from %dMiB to %dMiB", size, arg.Size)
+	}
+	if size < arg.Size {
+		// lvextend fails if the size is unchanged.
+		_, err := lvs.run(
+			"lvextend",
+			"--size", fmt.Sprintf("%dm", arg.Size),
+			logicalVolumePath(lvs.volumeGroup, arg.VolumeId),
+		)
+		if err != nil {
+			return nil, errors.Annotate(err, "extending logical volume")
+		}
+	}
+	return &storage.VolumeInfo{
+		VolumeId: arg.VolumeId,
+		Size:     arg.Size,
+	}, nil
+}
+
+// logicalVolumePath returns the path of the logical volume with the
+// specified name in the specified volume group, relative to /dev.
+func logicalVolumePath(volumeGroup, name string) string {
+	return path.Join(volumeGroup, name)
+}
+
+// createLogicalVolume creates a logical volume with the specified
+// name and size in mebibytes, in the specified volume group.
+func createLogicalVolume(run runCommandFunc, volumeGroup, name string, sizeInMiB uint64) error {
+	_, err := run(
+		"lvcreate",
+		"--name", name,
+		"--size", fmt.Sprintf("%dm", sizeInMiB),
+		volumeGroup,
+	)
+	if err != nil {
+		return errors.Annotatef(err, "creating logical volume %q", name)
+	}
+	return nil
+}
+
+// removeLogicalVolume removes the logical volume with the specified
+// name from the specified volume group.
+func removeLogicalVolume(run runCommandFunc, volumeGroup, name string) error {
+	_, err := run("lvremove", "--force", logicalVolumePath(volumeGroup, name))
+	if err != nil {
+		return errors.Annotatef(err, "removing logical volume %q", name)
+	}
+	return nil
+}
+
+// logicalVolumeSizes returns the sizes, in mebibytes, of the logical
+// volumes in the specified volume group, keyed by their names.
+func logicalVolumeSizes(run runCommandFunc, volumeGroup string) (map[string]uint64, error) {
+	stdout, err := run(
+		"lvs", "--noheadings", "--nosuffix",
+		"--units", "m", "--separator", ":",
+		"--options", "lv_name,lv_size",
+		volumeGroup,
+	)
+	if err != nil {
+		return nil, errors.Annotatef(err, "listing logical volumes in %q", volumeGroup)
+	}
+	// The output will be zero or more lines with the format:
+	//    "  volume-0-0:1024.00"
+	sizes := make(map[string]uint64)
+	for _, line := range strings.Split(stdout, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		fields := strings.Split(line, ":")
+		if len(fields) != 2 {
+			return nil, errors.Errorf("unexpected output %q", line)
+		}
+		size, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			return nil, errors.Annotatef(err, "parsing size of logical volume %q", fields[0])
+		}
+		sizes[fields[0]] = uint64(math.Ceil(size))
+	}
+	return sizes, nil
+}
@@ -0,0 +1,390 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider"
+	"github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&lvmSuite{})
+
+type lvmSuite struct {
+	testing.BaseSuite
+	commands *mockRunCommand
+}
+
+func (s *lvmSuite) TearDownTest(c *gc.C) {
+	if s.commands != nil {
+		s.commands.assertDrained()
+	}
+	s.BaseSuite.TearDownTest(c)
+}
+
+func (s *lvmSuite) lvmProvider(c *gc.C) storage.Provider {
+	s.commands = &mockRunCommand{c: c}
+	return provider.LVMProvider(s.commands.run)
+}
+
+func (s *lvmSuite) lvmVolumeSource(c *gc.C) storage.VolumeSource {
+	s.commands = &mockRunCommand{c: c}
+	return provider.LVMVolumeSource("vg0", s.commands.run)
+}
+
+func (s *lvmSuite) expectListLogicalVolumes(output string) {
+	cmd := s.commands.expect(
+		"lvs", "--noheadings", "--nosuffix",
+		"--units", "m", "--separator", ":",
+		"--options", "lv_name,lv_size",
+		"vg0",
+	)
+	cmd.respond(output, nil)
+}
+
+func (s *lvmSuite) TestValidateConfig(c *gc.C) {
+	p := s.lvmProvider(c)
+	for i, test := range []struct {
+		attrs map[string]interface{}
+		err   string
+	}{{
+		attrs: map[string]interface{}{},
+		err:   "volume group not specified",
+	}, {
+		attrs: map[string]interface{}{"volume-group": "-vg0"},
+		err:   `volume group name "-vg0" not valid`,
+	}, {
+		attrs: map[string]interface{}{"volume-group": "vg/0"},
+		err:   `volume group name "vg/0" not valid`,
+	}, {
+		attrs: map[string]interface{}{"volume-group": "vg0"},
+	}} {
+		c.Logf("test %d: %v", i, test.attrs)
+		cfg, err := storage.NewConfig("name", provider.LVMProviderType, test.attrs)
+		c.Assert(err, jc.ErrorIsNil)
+		err = p.ValidateConfig(cfg)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (s *lvmSuite) TestImmutableConfigAttributes(c *gc.C) {
+	p := s.lvmProvider(c)
+	immutable, ok := p.(storage.ImmutableConfigProvider)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(immutable.ImmutableConfigAttributes(), jc.DeepEquals, []string{"volume-group"})
+}
+
+func (s *lvmSuite) TestVolumeSource(c *gc.C) {
+	p := s.lvmProvider(c)
+	cfg, err := storage.NewConfig("name", provider.LVMProviderType, map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = p.VolumeSource(nil, cfg)
+	c.Assert(err, gc.ErrorMatches, "volume group not specified")
+	cfg, err = storage.NewConfig("name", provider.LVMProviderType, map[string]interface{}{
+		"volume-group": "vg0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = p.VolumeSource(nil, cfg)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *lvmSuite) TestSupports(c *gc.C) {
+	p := s.lvmProvider(c)
+	c.Assert(p.Supports(storage.StorageKindBlock), jc.IsTrue)
+	c.Assert(p.Supports(storage.StorageKindFilesystem), jc.IsFalse)
+}
+
+func (s *lvmSuite) TestScope(c *gc.C) {
+	p := s.lvmProvider(c)
+	c.Assert(p.Scope(), gc.Equals, storage.ScopeMachine)
+	c.Assert(p.Dynamic(), jc.IsTrue)
+}
+
+func (s *lvmSuite) TestCreateVolumes(c *gc.C) {
+	source := s.lvmVolumeSource(c)
+	s.commands.expect("lvcreate", "--name", "volume-0-1", "--size", "2048m", "vg0")
+	cmd := s.commands.expect("lvcreate", "--name", "volume-0-2", "--size", "1024m", "vg0")
+	cmd.respond("", errors.New("insufficient free space"))
+
+	results, err := source.CreateVolumes([]storage.VolumeParams{{
+		Tag:  names.NewVolumeTag("0/1"),
+		Size: 2048,
+	}, {
+		Tag:  names.NewVolumeTag("0/2"),
+		Size: 1024,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Volume, jc.DeepEquals, &storage.Volume{
+		names.NewVolumeTag("0/1"),
+		storage.VolumeInfo{
+			VolumeId: "volume-0-1",
+			Size:     2048,
+		},
+	})
+	c.Assert(results[1].Error, gc.ErrorMatches, `creating volume: creating logical volume "volume-0-2": insufficient free space`)
+}
+
+func (s *lvmSuite) TestListVolumes(c *gc.C) {
+	source := s.lvmVolumeSource(c)
+	s.expectListLogicalVolumes("  volume-0-1:2048.00\n  snapshot-0-1-2:2048.00\n  other:4.00\n")
+	volumeIds, err := source.ListVolumes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volumeIds, jc.SameContents, []string{"volume-0-1"})
+}
+
+func (s *lvmSuite) TestDescribeVolumes(c *gc.C) {
+	source := s.lvmVolumeSource(c)
+	s.expectListLogicalVolumes("  volume-0-1:2047.50\n")
+	results, err := source.DescribeVolumes([]string{"volume-0-1", "volume-0-2"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].VolumeInfo, jc.DeepEquals, &storage.VolumeInfo{
+		VolumeId: "volume-0-1",
+		Size:     2048,
+	})
+	c.Assert(results[1].Error, gc.ErrorMatches, `volume "volume-0-2" not found`)
+}
+
+func (s *lvmSuite) TestDescribeVolumesUnexpectedOutput(c *gc.C) {
+	source := s.lvmVolumeSource(c)
+	s.expectListLogicalVolumes("  volume-0-1\n")
+	_, err := source.DescribeVolumes([]string{"volume-0-1"})
+	c.Assert(err, gc.ErrorMatches, `unexpected output "volume-0-1"`)
+}
+
+func (s *lvmSuite) TestDestroyVolumes(c *gc.C) {
+	source := s.lvmVolumeSource(c)
+	s.commands.expect("lvremove", "--force", "vg0/volume-0-1")
+	errs, err := source.DestroyVolumes([]string{"volume-0-1", "../super/important/stuff"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, gc.HasLen, 2)
+	c.Assert(errs[0], jc.ErrorIsNil)
+	c.Assert(errs[1], gc.ErrorMatches, `invalid lvm volume ID "\.\./super/important/stuff"`)
+}
+
+func (s *lvmSuite) TestAttachVolumes(c *gc.C) {
+	source := s.lvmVolumeSource(c)
+	s.commands.expect("lvchange", "--activate", "y", "--permission", "rw", "vg0/volume-0-1")
+	s.commands.expect("lvchange", "--activate", "y", "--permission", "r", "vg0/volume-0-2")
+
+	results, err := source.AttachVolumes([]storage.VolumeAttachmentParams{{
+		Volume:   names.NewVolumeTag("0/1"),
+		VolumeId: "volume-0-1",
+		AttachmentParams: storage.AttachmentParams{
+			Machine: names.NewMachineTag("0"),
+		},
+	}, {
+		Volume:   names.NewVolumeTag("0/2"),
+		VolumeId: "volume-0-2",
+		AttachmentParams: storage.AttachmentParams{
+			Machine:  names.NewMachineTag("0"),
+			ReadOnly: true,
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.AttachVolumesResult{{
+		VolumeAttachment: &storage.VolumeAttachment{
+			names.NewVolumeTag("0/1"),
+			names.NewMachineTag("0"),
+			storage.VolumeAttachmentInfo{
+				DeviceLink: "/dev/vg0/volume-0-1",
+			},
+		},
+	}, {
+		VolumeAttachment: &storage.VolumeAttachment{
+			names.NewVolumeTag("0/2"),
+			names.NewMachineTag("0"),
+			storage.VolumeAttachmentInfo{
+				DeviceLink: "/dev/vg0/volume-0-2",
+				ReadOnly:   true,
+			},
+		},
+	}})
+}
+
+func (s *lvmSuite) TestDetachVolumes(c *gc.C) {
+	source := s.lvmVolumeSource(c)
+	s.commands.expect("lvchange", "--activate", "n", "vg0/volume-0-1")
+	errs, err := source.DetachVolumes([]storage.VolumeAttachmentParams{{
+		Volume:   names.NewVolumeTag("0/1"),
+		VolumeId: "volume-0-1",
+		AttachmentParams: storage.AttachmentParams{
+			Machine: names.NewMachineTag("0"),
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, jc.DeepEquals, []error{nil})
+}
+
+func (s *lvmSuite) TestCreateVolumeSnapshots(c *gc.C) {
+	source := s.lvmVolumeSource(c)
+	s.expectListLogicalVolumes("  volume-0-1:2048.00\n")
+	s.commands.expect(
+		"lvcreate", "--snapshot",
+		"--name", "snapshot-0-1-2",
+		"--size", "2048m",
+		"vg0/volume-0-1",
+	)
+
+	snapshotter := source.(storage.VolumeSnapshotter)
+	results, err := snapshotter.CreateVolumeSnapshots([]storage.VolumeSnapshotParams{{
+		Id:       "0/1:2",
+		Volume:   names.NewVolumeTag("0/1"),
+		VolumeId: "volume-0-1",
+	}, {
+		Id:       "0/2:3",
+		Volume:   names.NewVolumeTag("0/2"),
+		VolumeId: "volume-0-2",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].VolumeSnapshot, jc.DeepEquals, &storage.VolumeSnapshot{
+		"0/1:2",
+		names.NewVolumeTag("0/1"),
+		storage.VolumeSnapshotInfo{
+			SnapshotId: "snapshot-0-1-2",
+			Size:       2048,
+		},
+	})
+	c.Assert(results[1].Error, gc.ErrorMatches, `creating snapshot of volume 0/2: volume "volume-0-2" not found`)
+}
+
+func (s *lvmSuite) TestListVolumeSnapshots(c *gc.C) {
+	source := s.lvmVolumeSource(c)
+	s.expectListLogicalVolumes("  volume-0-1:2048.00\n  snapshot-0-1-2:2048.00\n")
+	snapshotter := source.(storage.VolumeSnapshotter)
+	snapshotIds, err := snapshotter.ListVolumeSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshotIds, jc.SameContents, []string{"snapshot-0-1-2"})
+}
+
+func (s *lvmSuite) TestDeleteVolumeSnapshots(c *gc.C) {
+	source := s.lvmVolumeSource(c)
+	s.commands.expect("lvremove", "--force", "vg0/snapshot-0-1-2")
+	snapshotter := source.(storage.VolumeSnapshotter)
+	errs, err := snapshotter.DeleteVolumeSnapshots([]string{"snapshot-0-1-2", "volume-0-1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, gc.HasLen, 2)
+	c.Assert(errs[0], jc.ErrorIsNil)
+	c.Assert(errs[1], gc.ErrorMatches, `invalid lvm snapshot ID "volume-0-1"`)
+}
+
+func (s *lvmSuite) TestCreateVolumesFromSnapshots(c *gc.C) {
+	source := s.lvmVolumeSource(c)
+	s.expectListLogicalVolumes("  snapshot-0-1-2:2048.00\n")
+	s.commands.expect("lvcreate", "--name", "volume-0-3", "--size", "4096m", "vg0")
+	s.commands.expect(
+		"dd", "if=/dev/vg0/snapshot-0-1-2", "of=/dev/vg0/volume-0-3",
+		"bs=1M", "conv=sparse",
+	)
+
+	snapshotter := source.(storage.VolumeSnapshotter)
+	results, err := snapshotter.CreateVolumesFromSnapshots([]storage.VolumeParams{{
+		Tag:        names.NewVolumeTag("0/3"),
+		Size:       4096,
+		SnapshotId: "snapshot-0-1-2",
+	}, {
+		Tag:        names.NewVolumeTag("0/4"),
+		Size:       1024,
+		SnapshotId: "snapshot-0-1-2",
+	}, {
+		Tag:        names.NewVolumeTag("0/5"),
+		Size:       4096,
+		SnapshotId: "snapshot-0-2-3",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 3)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Volume, jc.DeepEquals, &storage.Volume{
+		names.NewVolumeTag("0/3"),
+		storage.VolumeInfo{
+			VolumeId: "volume-0-3",
+			Size:     4096,
+		},
+	})
+	c.Assert(results[1].Error, gc.ErrorMatches, `creating volume from snapshot "snapshot-0-1-2": volume size 1024MiB smaller than snapshot size 2048MiB`)
+	c.Assert(results[2].Error, gc.ErrorMatches, `creating volume from snapshot "snapshot-0-2-3": snapshot "snapshot-0-2-3" not found`)
+}
+
+func (s *lvmSuite) TestCreateVolumesFromSnapshotsCopyFails(c *gc.C) {
+	source := s.lvmVolumeSource(c)
+	s.expectListLogicalVolumes("  snapshot-0-1-2:2048.00\n")
+	s.commands.expect("lvcreate", "--name", "volume-0-3", "--size", "2048m", "vg0")
+	cmd := s.commands.expect(
+		"dd", "if=/dev/vg0/snapshot-0-1-2", "of=/dev/vg0/volume-0-3",
+		"bs=1M", "conv=sparse",
+	)
+	cmd.respond("", errors.New("I/O error"))
+	s.commands.expect("lvremove", "--force", "vg0/volume-0-3")
+
+	snapshotter := source.(storage.VolumeSnapshotter)
+	results, err := snapshotter.CreateVolumesFromSnapshots([]storage.VolumeParams{{
+		Tag:        names.NewVolumeTag("0/3"),
+		Size:       2048,
+		SnapshotId: "snapshot-0-1-2",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, `creating volume from snapshot "snapshot-0-1-2": copying snapshot: I/O error`)
+}
+
+func (s *lvmSuite) TestResizeVolumes(c *gc.C) {
+	source := s.lvmVolumeSource(c)
+	s.expectListLogicalVolumes("  volume-0-1:2048.00\n  volume-0-2:4096.00\n")
+	s.commands.expect("lvextend", "--size", "4096m", "vg0/volume-0-1")
+
+	resizer := source.(storage.VolumeResizer)
+	results, err := resizer.ResizeVolumes([]storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0/1"),
+		VolumeId: "volume-0-1",
+		Size:     4096,
+	}, {
+		// The volume is already the requested size,
+		// so lvextend is not run.
+		Tag:      names.NewVolumeTag("0/2"),
+		VolumeId: "volume-0-2",
+		Size:     4096,
+	}, {
+		Tag:      names.NewVolumeTag("0/3"),
+		VolumeId: "volume-0-3",
+		Size:     4096,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 3)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].VolumeInfo, jc.DeepEquals, &storage.VolumeInfo{
+		VolumeId: "volume-0-1",
+		Size:     4096,
+	})
+	c.Assert(results[1].Error, jc.ErrorIsNil)
+	c.Assert(results[2].Error, gc.ErrorMatches, `resizing volume 0/3: volume "volume-0-3" not found`)
+}
+
+func (s *lvmSuite) TestResizeVolumesShrink(c *gc.C) {
+	source := s.lvmVolumeSource(c)
+	s.expectListLogicalVolumes("  volume-0-1:2048.00\n")
+
+	resizer := source.(storage.VolumeResizer)
+	results, err := resizer.ResizeVolumes([]storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0/1"),
+		VolumeId: "volume-0-1",
+		Size:     1024,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, `resizing volume 0/1: cannot shrink volume from 2048MiB to 1024MiB`)
+}
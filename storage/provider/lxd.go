@@ -0,0 +1,332 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/tools/lxdclient"
+)
+
+const (
+	// LXDProviderType is the storage provider type for storage created
+	// on a host machine and passed through to the host's LXD containers
+	// as container devices.
+	LXDProviderType = storage.ProviderType("lxd")
+)
+
+// lxdDeviceClient is the interface to LXD required by the lxd storage
+// provider, for adding devices to and removing devices from containers.
+type lxdDeviceClient interface {
+	AddInstanceDevice(name, deviceName string, device lxdclient.Device) error
+	RemoveInstanceDevice(name, deviceName string) error
+}
+
+// connectLocalLXD connects to the LXD daemon on the local machine.
+func connectLocalLXD() (lxdDeviceClient, error) {
+	cfg, err := lxdclient.Config{Remote: lxdclient.Local}.WithDefaults()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	client, err := lxdclient.Connect(cfg)
+	if err != nil {
+		return nil, errors.Annotate(err, "connecting to local LXD")
+	}
+	return client, nil
+}
+
+// lxdProvider creates volume and filesystem sources that create storage
+// on a host machine. Storage attached to the host machine is attached as
+// it is by the loop and hostdir providers; storage attached to one of
+// the host's LXD containers is passed through to the container as an
+// LXD device.
+type lxdProvider struct {
+	// run is a function used for running commands on the local machine.
+	run runCommandFunc
+
+	// connect is a function used for connecting to LXD on the local
+	// machine.
+	connect func() (lxdDeviceClient, error)
+}
+
+var (
+	_ storage.Provider                     = (*lxdProvider)(nil)
+	_ storage.SharedFilesystemProvider     = (*lxdProvider)(nil)
+	_ storage.ContainerPassthroughProvider = (*lxdProvider)(nil)
+)
+
+// ValidateConfig is defined on the Provider interface.
+func (*lxdProvider) ValidateConfig(*storage.Config) error {
+	// The lxd provider has no configuration.
+	return nil
+}
+
+// validateFullConfig validates a fully-constructed storage config,
+// combining the user-specified config and any internally specified
+// config.
+func (p *lxdProvider) validateFullConfig(cfg *storage.Config) error {
+	if err := p.ValidateConfig(cfg); err != nil {
+		return err
+	}
+	storageDir, ok := cfg.ValueString(storage.ConfigStorageDir)
+	if !ok || storageDir == "" {
+		return errors.New("storage directory not specified")
+	}
+	return nil
+}
+
+// SupportsSharedFilesystems is defined on the SharedFilesystemProvider
+// interface.
+func (*lxdProvider) SupportsSharedFilesystems() bool {
+	return true
+}
+
+// SupportsContainerPassthrough is defined on the
+// ContainerPassthroughProvider interface.
+func (*lxdProvider) SupportsContainerPassthrough() bool {
+	return true
+}
+
+// VolumeSource is defined on the Provider interface.
+func (p *lxdProvider) VolumeSource(environConfig *config.Config, sourceConfig *storage.Config) (storage.VolumeSource, error) {
+	if err := p.validateFullConfig(sourceConfig); err != nil {
+		return nil, err
+	}
+	// storageDir is validated by validateFullConfig.
+	storageDir, _ := sourceConfig.ValueString(storage.ConfigStorageDir)
+	return &lxdVolumeSource{
+		&loopVolumeSource{
+			&osDirFuncs{p.run},
+			p.run,
+			filepath.Join(storageDir, string(LXDProviderType), "volumes"),
+		},
+		&lxdDevices{connect: p.connect},
+	}, nil
+}
+
+// FilesystemSource is defined on the Provider interface.
+func (p *lxdProvider) FilesystemSource(environConfig *config.Config, sourceConfig *storage.Config) (storage.FilesystemSource, error) {
+	if err := p.validateFullConfig(sourceConfig); err != nil {
+		return nil, err
+	}
+	// storageDir is validated by validateFullConfig.
+	storageDir, _ := sourceConfig.ValueString(storage.ConfigStorageDir)
+	return &lxdFilesystemSource{
+		&hostDirFilesystemSource{
+			&osDirFuncs{p.run},
+			p.run,
+			filepath.Join(storageDir, string(LXDProviderType), "filesystems"),
+			"",
+		},
+		&lxdDevices{connect: p.connect},
+	}, nil
+}
+
+// Supports is defined on the Provider interface.
+func (*lxdProvider) Supports(k storage.StorageKind) bool {
+	return k == storage.StorageKindBlock || k == storage.StorageKindFilesystem
+}
+
+// Scope is defined on the Provider interface.
+func (*lxdProvider) Scope() storage.Scope {
+	return storage.ScopeMachine
+}
+
+// Dynamic is defined on the Provider interface.
+func (*lxdProvider) Dynamic() bool {
+	return true
+}
+
+// isContainer reports whether or not the specified machine is a
+// container.
+func isContainer(machine names.MachineTag) bool {
+	return strings.Contains(machine.Id(), "/")
+}
+
+// lxdDevices adds devices to and removes devices from LXD containers
+// on the local machine, connecting to LXD when first required.
+type lxdDevices struct {
+	connect func() (lxdDeviceClient, error)
+	client  lxdDeviceClient
+}
+
+func (d *lxdDevices) getClient() (lxdDeviceClient, error) {
+	if d.client == nil {
+		client, err := d.connect()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		d.client = client
+	}
+	return d.client, nil
+}
+
+func (d *lxdDevices) add(instanceId instance.Id, deviceName string, device lxdclient.Device) error {
+	if instanceId == "" {
+		return errors.New("container instance ID not specified")
+	}
+	client, err := d.getClient()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	logger.Debugf("adding device %q to container %q", deviceName, instanceId)
+	if err := client.AddInstanceDevice(string(instanceId), deviceName, device); err != nil {
+		return errors.Annotatef(err, "adding device %q to container %q", deviceName, instanceId)
+	}
+	return nil
+}
+
+func (d *lxdDevices) remove(instanceId instance.Id, deviceName string) error {
+	if instanceId == "" {
+		return errors.New("container instance ID not specified")
+	}
+	client, err := d.getClient()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	logger.Debugf("removing device %q from container %q", deviceName, instanceId)
+	if err := client.RemoveInstanceDevice(string(instanceId), deviceName); err != nil {
+		return errors.Annotatef(err, "removing device %q from container %q", deviceName, instanceId)
+	}
+	return nil
+}
+
+// lxdVolumeSource creates volumes as loop devices on the host machine,
+// and passes them through to containers as LXD "unix-block" devices.
+type lxdVolumeSource struct {
+	*loopVolumeSource
+	devices *lxdDevices
+}
+
+var _ storage.VolumeSource = (*lxdVolumeSource)(nil)
+
+// AttachVolumes is defined on the VolumeSource interface.
+func (s *lxdVolumeSource) AttachVolumes(args []storage.VolumeAttachmentParams) ([]storage.AttachVolumesResult, error) {
+	results := make([]storage.AttachVolumesResult, len(args))
+	for i, arg := range args {
+		attachment, err := s.attachVolume(arg)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "attaching volume %v", arg.Volume.Id())
+			continue
+		}
+		results[i].VolumeAttachment = attachment
+	}
+	return results, nil
+}
+
+func (s *lxdVolumeSource) attachVolume(arg storage.VolumeAttachmentParams) (*storage.VolumeAttachment, error) {
+	attachment, err := s.loopVolumeSource.attachVolume(arg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !isContainer(arg.Machine) {
+		return attachment, nil
+	}
+	devicePath := path.Join("/dev", attachment.DeviceName)
+	device := lxdclient.Device{
+		"type":   "unix-block",
+		"source": devicePath,
+		"path":   devicePath,
+	}
+	if err := s.devices.add(arg.InstanceId, arg.Volume.String(), device); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return attachment, nil
+}
+
+// DetachVolumes is defined on the VolumeSource interface.
+func (s *lxdVolumeSource) DetachVolumes(args []storage.VolumeAttachmentParams) ([]error, error) {
+	results := make([]error, len(args))
+	for i, arg := range args {
+		if err := s.detachVolume(arg); err != nil {
+			results[i] = errors.Annotatef(err, "detaching volume %s", arg.Volume.Id())
+		}
+	}
+	return results, nil
+}
+
+func (s *lxdVolumeSource) detachVolume(arg storage.VolumeAttachmentParams) error {
+	if isContainer(arg.Machine) {
+		if err := s.devices.remove(arg.InstanceId, arg.Volume.String()); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return s.loopVolumeSource.detachVolume(arg.Volume)
+}
+
+// lxdFilesystemSource creates filesystems as directories on the host
+// machine, and passes them through to containers as LXD "disk" devices.
+type lxdFilesystemSource struct {
+	*hostDirFilesystemSource
+	devices *lxdDevices
+}
+
+var _ storage.FilesystemSource = (*lxdFilesystemSource)(nil)
+
+// AttachFilesystems is defined on the FilesystemSource interface.
+func (s *lxdFilesystemSource) AttachFilesystems(args []storage.FilesystemAttachmentParams) ([]storage.AttachFilesystemsResult, error) {
+	results := make([]storage.AttachFilesystemsResult, len(args))
+	for i, arg := range args {
+		attachment, err := s.attachFilesystem(arg)
+		if err != nil {
+			results[i].Error = err
+			continue
+		}
+		results[i].FilesystemAttachment = attachment
+	}
+	return results, nil
+}
+
+func (s *lxdFilesystemSource) attachFilesystem(arg storage.FilesystemAttachmentParams) (*storage.FilesystemAttachment, error) {
+	if !isContainer(arg.Machine) {
+		return s.hostDirFilesystemSource.attachFilesystem(arg)
+	}
+	if arg.Path == "" {
+		return nil, errNoMountPoint
+	}
+	device := lxdclient.Device{
+		"type":   "disk",
+		"source": filepath.Join(s.path, arg.FilesystemId),
+		"path":   arg.Path,
+	}
+	if arg.ReadOnly {
+		device["readonly"] = "true"
+	}
+	if err := s.devices.add(arg.InstanceId, arg.Filesystem.String(), device); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &storage.FilesystemAttachment{
+		arg.Filesystem,
+		arg.Machine,
+		storage.FilesystemAttachmentInfo{
+			Path:     arg.Path,
+			ReadOnly: arg.ReadOnly,
+		},
+	}, nil
+}
+
+// DetachFilesystems is defined on the FilesystemSource interface.
+func (s *lxdFilesystemSource) DetachFilesystems(args []storage.FilesystemAttachmentParams) ([]error, error) {
+	results := make([]error, len(args))
+	for i, arg := range args {
+		var err error
+		if isContainer(arg.Machine) {
+			err = s.devices.remove(arg.InstanceId, arg.Filesystem.String())
+		} else {
+			err = maybeUnmount(s.run, s.dirFuncs, arg.Path)
+		}
+		if err != nil {
+			results[i] = err
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,319 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"path/filepath"
+	"runtime"
+
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/tools/lxdclient"
+)
+
+var _ = gc.Suite(&lxdSuite{})
+
+type lxdSuite struct {
+	testing.BaseSuite
+	storageDir string
+	commands   *mockRunCommand
+	client     *mockLXDClient
+}
+
+func (s *lxdSuite) SetUpTest(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("Tests relevant only on *nix systems")
+	}
+	s.BaseSuite.SetUpTest(c)
+	s.storageDir = c.MkDir()
+	s.client = &mockLXDClient{}
+}
+
+func (s *lxdSuite) TearDownTest(c *gc.C) {
+	if s.commands != nil {
+		s.commands.assertDrained()
+	}
+	s.BaseSuite.TearDownTest(c)
+}
+
+func (s *lxdSuite) lxdProvider(c *gc.C) storage.Provider {
+	s.commands = &mockRunCommand{c: c}
+	return provider.LXDProvider(s.commands.run, s.client)
+}
+
+func (s *lxdSuite) lxdVolumeSource(c *gc.C) storage.VolumeSource {
+	s.commands = &mockRunCommand{c: c}
+	return provider.LXDVolumeSource(s.storageDir, s.commands.run, s.client)
+}
+
+func (s *lxdSuite) lxdFilesystemSource(c *gc.C) storage.FilesystemSource {
+	s.commands = &mockRunCommand{c: c}
+	return provider.LXDFilesystemSource(s.storageDir, s.commands.run, s.client)
+}
+
+type mockLXDClient struct {
+	gitjujutesting.Stub
+}
+
+func (m *mockLXDClient) AddInstanceDevice(name, deviceName string, device lxdclient.Device) error {
+	m.AddCall("AddInstanceDevice", name, deviceName, device)
+	return m.NextErr()
+}
+
+func (m *mockLXDClient) RemoveInstanceDevice(name, deviceName string) error {
+	m.AddCall("RemoveInstanceDevice", name, deviceName)
+	return m.NextErr()
+}
+
+func (s *lxdSuite) TestSources(c *gc.C) {
+	p := s.lxdProvider(c)
+	cfg, err := storage.NewConfig("name", provider.LXDProviderType, map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = p.VolumeSource(nil, cfg)
+	c.Assert(err, gc.ErrorMatches, "storage directory not specified")
+	_, err = p.FilesystemSource(nil, cfg)
+	c.Assert(err, gc.ErrorMatches, "storage directory not specified")
+
+	cfg, err = storage.NewConfig("name", provider.LXDProviderType, map[string]interface{}{
+		"storage-dir": c.MkDir(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = p.VolumeSource(nil, cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = p.FilesystemSource(nil, cfg)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *lxdSuite) TestSupports(c *gc.C) {
+	p := s.lxdProvider(c)
+	c.Assert(p.Supports(storage.StorageKindBlock), jc.IsTrue)
+	c.Assert(p.Supports(storage.StorageKindFilesystem), jc.IsTrue)
+}
+
+func (s *lxdSuite) TestScope(c *gc.C) {
+	p := s.lxdProvider(c)
+	c.Assert(p.Scope(), gc.Equals, storage.ScopeMachine)
+	c.Assert(p.Dynamic(), jc.IsTrue)
+}
+
+func (s *lxdSuite) TestSupportsContainerPassthrough(c *gc.C) {
+	p := s.lxdProvider(c)
+	passthrough, ok := p.(storage.ContainerPassthroughProvider)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(passthrough.SupportsContainerPassthrough(), jc.IsTrue)
+	shared, ok := p.(storage.SharedFilesystemProvider)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(shared.SupportsSharedFilesystems(), jc.IsTrue)
+}
+
+func (s *lxdSuite) TestAttachVolumesHost(c *gc.C) {
+	source := s.lxdVolumeSource(c)
+	cmd := s.commands.expect("losetup", "-j", filepath.Join(s.storageDir, "volume-0-0"))
+	cmd.respond("", nil)
+	cmd = s.commands.expect("losetup", "-f", "--show", filepath.Join(s.storageDir, "volume-0-0"))
+	cmd.respond("/dev/loop98", nil)
+
+	results, err := source.AttachVolumes([]storage.VolumeAttachmentParams{{
+		Volume:   names.NewVolumeTag("0/0"),
+		VolumeId: "volume-0-0",
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    names.NewMachineTag("0"),
+			InstanceId: "inst-0",
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].VolumeAttachment.DeviceName, gc.Equals, "loop98")
+	s.client.CheckNoCalls(c)
+}
+
+func (s *lxdSuite) TestAttachVolumesContainer(c *gc.C) {
+	source := s.lxdVolumeSource(c)
+	cmd := s.commands.expect("losetup", "-j", filepath.Join(s.storageDir, "volume-0-0"))
+	cmd.respond("", nil)
+	cmd = s.commands.expect("losetup", "-f", "--show", filepath.Join(s.storageDir, "volume-0-0"))
+	cmd.respond("/dev/loop98", nil)
+
+	results, err := source.AttachVolumes([]storage.VolumeAttachmentParams{{
+		Volume:   names.NewVolumeTag("0/0"),
+		VolumeId: "volume-0-0",
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    names.NewMachineTag("0/lxd/0"),
+			InstanceId: "juju-machine-0-lxd-0",
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.AttachVolumesResult{{
+		VolumeAttachment: &storage.VolumeAttachment{
+			names.NewVolumeTag("0/0"),
+			names.NewMachineTag("0/lxd/0"),
+			storage.VolumeAttachmentInfo{
+				DeviceName: "loop98",
+			},
+		},
+	}})
+	s.client.CheckCalls(c, []gitjujutesting.StubCall{{
+		"AddInstanceDevice", []interface{}{
+			"juju-machine-0-lxd-0", "volume-0-0", lxdclient.Device{
+				"type":   "unix-block",
+				"source": "/dev/loop98",
+				"path":   "/dev/loop98",
+			},
+		},
+	}})
+}
+
+func (s *lxdSuite) TestAttachVolumesContainerNotProvisioned(c *gc.C) {
+	source := s.lxdVolumeSource(c)
+	cmd := s.commands.expect("losetup", "-j", filepath.Join(s.storageDir, "volume-0-0"))
+	cmd.respond("/dev/loop98: foo\n", nil)
+
+	results, err := source.AttachVolumes([]storage.VolumeAttachmentParams{{
+		Volume:   names.NewVolumeTag("0/0"),
+		VolumeId: "volume-0-0",
+		AttachmentParams: storage.AttachmentParams{
+			Machine: names.NewMachineTag("0/lxd/0"),
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, gc.ErrorMatches, "attaching volume 0/0: container instance ID not specified")
+	s.client.CheckNoCalls(c)
+}
+
+func (s *lxdSuite) TestDetachVolumesContainer(c *gc.C) {
+	source := s.lxdVolumeSource(c)
+	cmd := s.commands.expect("losetup", "-j", filepath.Join(s.storageDir, "volume-0-0"))
+	cmd.respond("/dev/loop98: foo\n", nil)
+	s.commands.expect("losetup", "-d", "/dev/loop98")
+
+	errs, err := source.DetachVolumes([]storage.VolumeAttachmentParams{{
+		Volume:   names.NewVolumeTag("0/0"),
+		VolumeId: "volume-0-0",
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    names.NewMachineTag("0/lxd/0"),
+			InstanceId: "juju-machine-0-lxd-0",
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, jc.DeepEquals, []error{nil})
+	s.client.CheckCall(c, 0, "RemoveInstanceDevice", "juju-machine-0-lxd-0", "volume-0-0")
+}
+
+func (s *lxdSuite) TestDetachVolumesContainerRemoveDeviceFails(c *gc.C) {
+	source := s.lxdVolumeSource(c)
+	s.client.SetErrors(errors.New("boom"))
+
+	errs, err := source.DetachVolumes([]storage.VolumeAttachmentParams{{
+		Volume:   names.NewVolumeTag("0/0"),
+		VolumeId: "volume-0-0",
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    names.NewMachineTag("0/lxd/0"),
+			InstanceId: "juju-machine-0-lxd-0",
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs[0], gc.ErrorMatches,
+		`detaching volume 0/0: removing device "volume-0-0" from container "juju-machine-0-lxd-0": boom`)
+}
+
+func (s *lxdSuite) TestAttachFilesystemsContainer(c *gc.C) {
+	source := s.lxdFilesystemSource(c)
+	results, err := source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("0/1"),
+		FilesystemId: "filesystem-0-1",
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    names.NewMachineTag("0/lxd/0"),
+			InstanceId: "juju-machine-0-lxd-0",
+			ReadOnly:   true,
+		},
+		Path: "/srv",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.AttachFilesystemsResult{{
+		FilesystemAttachment: &storage.FilesystemAttachment{
+			Filesystem: names.NewFilesystemTag("0/1"),
+			Machine:    names.NewMachineTag("0/lxd/0"),
+			FilesystemAttachmentInfo: storage.FilesystemAttachmentInfo{
+				Path:     "/srv",
+				ReadOnly: true,
+			},
+		},
+	}})
+	s.client.CheckCalls(c, []gitjujutesting.StubCall{{
+		"AddInstanceDevice", []interface{}{
+			"juju-machine-0-lxd-0", "filesystem-0-1", lxdclient.Device{
+				"type":     "disk",
+				"source":   filepath.Join(s.storageDir, "filesystem-0-1"),
+				"path":     "/srv",
+				"readonly": "true",
+			},
+		},
+	}})
+}
+
+func (s *lxdSuite) TestAttachFilesystemsContainerNoPathSpecified(c *gc.C) {
+	source := s.lxdFilesystemSource(c)
+	results, err := source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("0/1"),
+		FilesystemId: "filesystem-0-1",
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    names.NewMachineTag("0/lxd/0"),
+			InstanceId: "juju-machine-0-lxd-0",
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, gc.ErrorMatches, "filesystem mount point not specified")
+	s.client.CheckNoCalls(c)
+}
+
+func (s *lxdSuite) TestAttachFilesystemsHost(c *gc.C) {
+	source := s.lxdFilesystemSource(c)
+	cmd := s.commands.expect("df", "--output=source", "/srv")
+	cmd.respond("headers\n/src/of/root", nil)
+	cmd = s.commands.expect("mount", "--bind", filepath.Join(s.storageDir, "filesystem-0-1"), "/srv")
+	cmd.respond("", nil)
+
+	results, err := source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("0/1"),
+		FilesystemId: "filesystem-0-1",
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    names.NewMachineTag("0"),
+			InstanceId: "inst-0",
+		},
+		Path: "/srv",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	s.client.CheckNoCalls(c)
+}
+
+func (s *lxdSuite) TestDetachFilesystemsContainer(c *gc.C) {
+	source := s.lxdFilesystemSource(c)
+	errs, err := source.DetachFilesystems([]storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("0/1"),
+		FilesystemId: "filesystem-0-1",
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    names.NewMachineTag("0/lxd/0"),
+			InstanceId: "juju-machine-0-lxd-0",
+		},
+		Path: "/srv",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, jc.DeepEquals, []error{nil})
+	s.client.CheckCall(c, 0, "RemoveInstanceDevice", "juju-machine-0-lxd-0", "filesystem-0-1")
+}
+
+func (s *lxdSuite) TestDetachFilesystemsHost(c *gc.C) {
+	source := s.lxdFilesystemSource(c)
+	testDetachFilesystems(c, s.commands, source, true)
+	s.client.CheckNoCalls(c)
+}
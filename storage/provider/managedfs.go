@@ -6,6 +6,7 @@ package provider
 import (
 	"path"
 	"path/filepath"
+	"strings"
 	"unicode"
 
 	"github.com/juju/errors"
@@ -31,6 +32,8 @@ type managedFilesystemSource struct {
 	filesystems        map[names.FilesystemTag]storage.Filesystem
 }
 
+var _ storage.FilesystemResizer = (*managedFilesystemSource)(nil)
+
 // NewManagedFilesystemSource returns a storage.FilesystemSource that manages
 // filesystems on block devices on the host machine.
 //
@@ -167,6 +170,50 @@ func (s *managedFilesystemSource) DetachFilesystems(args []storage.FilesystemAtt
 	return results, nil
 }
 
+// ResizeFilesystems is defined on storage.FilesystemResizer.
+//
+// Managed filesystems are grown to fill their backing volumes, so the
+// backing volumes must have been resized first.
+func (s *managedFilesystemSource) ResizeFilesystems(args []storage.FilesystemResizeParams) ([]storage.ResizeFilesystemsResult, error) {
+	results := make([]storage.ResizeFilesystemsResult, len(args))
+	for i, arg := range args {
+		info, err := s.resizeFilesystem(arg)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "resizing filesystem %v", arg.Tag.Id())
+			continue
+		}
+		results[i].FilesystemInfo = info
+	}
+	return results, nil
+}
+
+func (s *managedFilesystemSource) resizeFilesystem(arg storage.FilesystemResizeParams) (*storage.FilesystemInfo, error) {
+	blockDevice, err := s.backingVolumeBlockDevice(arg.Volume)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if blockDevice.Size < arg.Size {
+		return nil, errors.Errorf(
+			"backing-volume %s has not yet grown to %dMiB",
+			arg.Volume.Id(), arg.Size,
+		)
+	}
+	devicePath := devicePath(blockDevice)
+	if isDiskDevice(devicePath) {
+		if err := growPartition(s.run, devicePath); err != nil {
+			return nil, errors.Trace(err)
+		}
+		devicePath = partitionDevicePath(devicePath)
+	}
+	if err := growFilesystem(s.run, devicePath); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &storage.FilesystemInfo{
+		arg.FilesystemId,
+		blockDevice.Size,
+	}, nil
+}
+
 func destroyPartitions(run runCommandFunc, devicePath string) error {
 	logger.Debugf("destroying partitions on %q", devicePath)
 	if _, err := run("sgdisk", "--zap-all", devicePath); err != nil {
@@ -196,6 +243,32 @@ func createFilesystem(run runCommandFunc, devicePath string) error {
 	return nil
 }
 
+// growPartition grows the single partition (1) on the disk with the
+// specified device path to fill the disk.
+func growPartition(run runCommandFunc, devicePath string) error {
+	logger.Debugf("growing partition on %q", devicePath)
+	output, err := run("growpart", devicePath, "1")
+	if err != nil {
+		if strings.HasPrefix(output, "NOCHANGE") {
+			// The partition already fills the disk.
+			return nil
+		}
+		return errors.Annotate(err, "growpart failed")
+	}
+	return nil
+}
+
+// growFilesystem grows the filesystem on the device with the specified
+// path to fill the device. The filesystem may be mounted.
+func growFilesystem(run runCommandFunc, devicePath string) error {
+	logger.Debugf("attempting to grow filesystem on %q", devicePath)
+	if _, err := run("resize2fs", devicePath); err != nil {
+		return errors.Annotate(err, "resize2fs failed")
+	}
+	logger.Infof("grew filesystem on %q", devicePath)
+	return nil
+}
+
 func mountFilesystem(run runCommandFunc, dirFuncs dirFuncs, devicePath, mountPoint string, readOnly bool) error {
 	logger.Debugf("attempting to mount filesystem on %q at %q", devicePath, mountPoint)
 	if err := dirFuncs.mkDirAll(mountPoint, 0755); err != nil {
@@ -185,3 +185,57 @@ func (s *managedfsSuite) TestDetachFilesystemsUnattached(c *gc.C) {
 	source := s.initSource(c)
 	testDetachFilesystems(c, s.commands, source, false)
 }
+
+func (s *managedfsSuite) TestResizeFilesystems(c *gc.C) {
+	source := s.initSource(c)
+	// sda's partition is grown before the filesystem on it.
+	s.commands.expect("growpart", "/dev/sda", "1")
+	s.commands.expect("resize2fs", "/dev/sda1")
+	// The filesystem on xvdf1 is grown in place.
+	s.commands.expect("resize2fs", "/dev/xvdf1")
+
+	s.blockDevices[names.NewVolumeTag("0")] = storage.BlockDevice{
+		DeviceName: "sda",
+		Size:       4,
+	}
+	s.blockDevices[names.NewVolumeTag("1")] = storage.BlockDevice{
+		DeviceName: "xvdf1",
+		Size:       5,
+	}
+	s.blockDevices[names.NewVolumeTag("2")] = storage.BlockDevice{
+		DeviceName: "xvdg1",
+		Size:       2,
+	}
+	resizer := source.(storage.FilesystemResizer)
+	results, err := resizer.ResizeFilesystems([]storage.FilesystemResizeParams{{
+		Tag:          names.NewFilesystemTag("0/0"),
+		Volume:       names.NewVolumeTag("0"),
+		FilesystemId: "filesystem-0-0",
+		Size:         4,
+	}, {
+		Tag:          names.NewFilesystemTag("0/1"),
+		Volume:       names.NewVolumeTag("1"),
+		FilesystemId: "filesystem-0-1",
+		Size:         4,
+	}, {
+		Tag:          names.NewFilesystemTag("0/2"),
+		Volume:       names.NewVolumeTag("2"),
+		FilesystemId: "filesystem-0-2",
+		Size:         4,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.ResizeFilesystemsResult{{
+		FilesystemInfo: &storage.FilesystemInfo{
+			FilesystemId: "filesystem-0-0",
+			Size:         4,
+		},
+	}, {
+		FilesystemInfo: &storage.FilesystemInfo{
+			FilesystemId: "filesystem-0-1",
+			Size:         5,
+		},
+	}, {
+		Error: results[2].Error,
+	}})
+	c.Assert(results[2].Error, gc.ErrorMatches, "resizing filesystem 0/2: backing-volume 2 has not yet grown to 4MiB")
+}
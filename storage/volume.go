@@ -32,6 +32,29 @@ type VolumeInfo struct {
 	Persistent bool
 }
 
+// VolumeSnapshot identifies and describes a point-in-time copy of
+// a volume.
+type VolumeSnapshot struct {
+	// Id is the unique ID assigned by Juju to the snapshot.
+	Id string
+
+	// Volume is the unique tag assigned by Juju for the volume
+	// that the snapshot was taken from.
+	Volume names.VolumeTag
+
+	VolumeSnapshotInfo
+}
+
+// VolumeSnapshotInfo describes a volume snapshot.
+type VolumeSnapshotInfo struct {
+	// SnapshotId is a unique provider-supplied ID for the snapshot.
+	SnapshotId string
+
+	// Size is the size of the volume that the snapshot was taken
+	// from, in MiB.
+	Size uint64
+}
+
 // VolumeAttachment identifies and describes machine-specific volume
 // attachment information, including how the volume is exposed on the
 // machine.
@@ -96,6 +96,7 @@ type Client struct {
 	*profileClient
 	*instanceClient
 	*imageClient
+	*networkClient
 	baseURL string
 }
 
@@ -123,6 +124,7 @@ func Connect(cfg Config) (*Client, error) {
 		profileClient:      &profileClient{raw},
 		instanceClient:     &instanceClient{raw, remote},
 		imageClient:        &imageClient{raw, connectToRaw},
+		networkClient:      &networkClient{raw, remote},
 		baseURL:            raw.BaseURL,
 	}
 	return conn, nil
@@ -6,6 +6,8 @@
 package lxdclient
 
 import (
+	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/juju/errors"
@@ -28,6 +30,8 @@ type rawInstanceClient interface {
 	Init(name string, imgremote string, image string, profiles *[]string, config map[string]string, devices shared.Devices, ephem bool) (*lxd.Response, error)
 	Action(name string, action shared.ContainerAction, timeout int, force bool, stateful bool) (*lxd.Response, error)
 	Delete(name string) (*lxd.Response, error)
+	ContainerDeviceAdd(container, devname, devtype string, props []string) (*lxd.Response, error)
+	ContainerDeviceDelete(container, devname string) (*lxd.Response, error)
 
 	WaitForSuccess(waitURL string) error
 	ContainerState(name string) (*shared.ContainerState, error)
@@ -251,6 +255,78 @@ func checkInstanceName(name string, instances []Instance) bool {
 	return false
 }
 
+// AddInstanceDevice adds a device, such as a disk, with the given name
+// to the specified instance. The device's "type" entry identifies the
+// type of device; all other entries are passed to LXD as the device's
+// properties. If the instance already has an identical device with the
+// same name, AddInstanceDevice does nothing. The call blocks until the
+// device is added (or the request fails).
+func (client *instanceClient) AddInstanceDevice(name, deviceName string, device Device) error {
+	info, err := client.raw.ContainerInfo(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if existing, ok := info.Devices[deviceName]; ok {
+		if !sameDevice(existing, device) {
+			return errors.AlreadyExistsf("device %q on instance %q", deviceName, name)
+		}
+		return nil
+	}
+
+	var props []string
+	for key, value := range device {
+		if key == "type" {
+			continue
+		}
+		props = append(props, fmt.Sprintf("%s=%s", key, value))
+	}
+	sort.Strings(props)
+
+	resp, err := client.raw.ContainerDeviceAdd(name, deviceName, device["type"], props)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := client.raw.WaitForSuccess(resp.Operation); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// RemoveInstanceDevice removes the device with the given name from the
+// specified instance. If the instance has no such device, then
+// RemoveInstanceDevice does nothing. The call blocks until the device
+// is removed (or the request fails).
+func (client *instanceClient) RemoveInstanceDevice(name, deviceName string) error {
+	info, err := client.raw.ContainerInfo(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, ok := info.Devices[deviceName]; !ok {
+		return nil
+	}
+
+	resp, err := client.raw.ContainerDeviceDelete(name, deviceName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := client.raw.WaitForSuccess(resp.Operation); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+func sameDevice(existing shared.Device, device Device) bool {
+	if len(existing) != len(device) {
+		return false
+	}
+	for key, value := range device {
+		if existing[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
 // Addresses returns the list of network.Addresses for this instance. It
 // converts the information that LXD tracks into the Juju network model.
 func (client *instanceClient) Addresses(name string) ([]network.Address, error) {
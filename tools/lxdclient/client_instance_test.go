@@ -6,7 +6,10 @@
 package lxdclient_test
 
 import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
+	"github.com/lxc/lxd"
 	lxdshared "github.com/lxc/lxd/shared"
 	gc "gopkg.in/check.v1"
 
@@ -177,3 +180,113 @@ func (s *addressesSuite) TestAddresses(c *gc.C) {
 		},
 	})
 }
+
+type devicesSuite struct {
+	jujutesting.BaseSuite
+}
+
+var _ = gc.Suite(&devicesSuite{})
+
+type deviceTester struct {
+	lxdclient.RawInstanceClient
+
+	stub    testing.Stub
+	devices lxdshared.Devices
+}
+
+func (d *deviceTester) ContainerInfo(name string) (*lxdshared.ContainerInfo, error) {
+	d.stub.AddCall("ContainerInfo", name)
+	if err := d.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return &lxdshared.ContainerInfo{Name: name, Devices: d.devices}, nil
+}
+
+func (d *deviceTester) ContainerDeviceAdd(container, devname, devtype string, props []string) (*lxd.Response, error) {
+	d.stub.AddCall("ContainerDeviceAdd", container, devname, devtype, props)
+	if err := d.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return &lxd.Response{Operation: "/1.0/operations/add"}, nil
+}
+
+func (d *deviceTester) ContainerDeviceDelete(container, devname string) (*lxd.Response, error) {
+	d.stub.AddCall("ContainerDeviceDelete", container, devname)
+	if err := d.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return &lxd.Response{Operation: "/1.0/operations/delete"}, nil
+}
+
+func (d *deviceTester) WaitForSuccess(waitURL string) error {
+	d.stub.AddCall("WaitForSuccess", waitURL)
+	return d.stub.NextErr()
+}
+
+var _ lxdclient.RawInstanceClient = (*deviceTester)(nil)
+
+func (s *devicesSuite) TestAddInstanceDevice(c *gc.C) {
+	raw := &deviceTester{}
+	client := lxdclient.NewInstanceClient(raw)
+	err := client.AddInstanceDevice("juju-machine-0-lxd-0", "filesystem-0-1", lxdclient.Device{
+		"type":   "disk",
+		"source": "/var/lib/juju/storage/lxd/filesystem-0-1",
+		"path":   "/srv",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	raw.stub.CheckCalls(c, []testing.StubCall{
+		{"ContainerInfo", []interface{}{"juju-machine-0-lxd-0"}},
+		{"ContainerDeviceAdd", []interface{}{
+			"juju-machine-0-lxd-0", "filesystem-0-1", "disk",
+			[]string{"path=/srv", "source=/var/lib/juju/storage/lxd/filesystem-0-1"},
+		}},
+		{"WaitForSuccess", []interface{}{"/1.0/operations/add"}},
+	})
+}
+
+func (s *devicesSuite) TestAddInstanceDeviceExists(c *gc.C) {
+	device := lxdclient.Device{"type": "disk", "source": "/src", "path": "/srv"}
+	raw := &deviceTester{devices: lxdshared.Devices{
+		"filesystem-0-1": {"type": "disk", "source": "/src", "path": "/srv"},
+	}}
+	client := lxdclient.NewInstanceClient(raw)
+	err := client.AddInstanceDevice("juju-machine-0-lxd-0", "filesystem-0-1", device)
+	c.Assert(err, jc.ErrorIsNil)
+	raw.stub.CheckCallNames(c, "ContainerInfo")
+
+	device["path"] = "/var"
+	err = client.AddInstanceDevice("juju-machine-0-lxd-0", "filesystem-0-1", device)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+	c.Assert(err, gc.ErrorMatches, `device "filesystem-0-1" on instance "juju-machine-0-lxd-0" already exists`)
+}
+
+func (s *devicesSuite) TestAddInstanceDeviceError(c *gc.C) {
+	raw := &deviceTester{}
+	raw.stub.SetErrors(nil, errors.New("boom"))
+	client := lxdclient.NewInstanceClient(raw)
+	err := client.AddInstanceDevice("juju-machine-0-lxd-0", "volume-0-1", lxdclient.Device{"type": "unix-block"})
+	c.Assert(err, gc.ErrorMatches, "boom")
+	raw.stub.CheckCallNames(c, "ContainerInfo", "ContainerDeviceAdd")
+}
+
+func (s *devicesSuite) TestRemoveInstanceDevice(c *gc.C) {
+	raw := &deviceTester{devices: lxdshared.Devices{
+		"filesystem-0-1": {"type": "disk", "source": "/src", "path": "/srv"},
+	}}
+	client := lxdclient.NewInstanceClient(raw)
+	err := client.RemoveInstanceDevice("juju-machine-0-lxd-0", "filesystem-0-1")
+	c.Assert(err, jc.ErrorIsNil)
+	raw.stub.CheckCalls(c, []testing.StubCall{
+		{"ContainerInfo", []interface{}{"juju-machine-0-lxd-0"}},
+		{"ContainerDeviceDelete", []interface{}{"juju-machine-0-lxd-0", "filesystem-0-1"}},
+		{"WaitForSuccess", []interface{}{"/1.0/operations/delete"}},
+	})
+}
+
+func (s *devicesSuite) TestRemoveInstanceDeviceMissing(c *gc.C) {
+	raw := &deviceTester{}
+	client := lxdclient.NewInstanceClient(raw)
+	err := client.RemoveInstanceDevice("juju-machine-0-lxd-0", "filesystem-0-1")
+	c.Assert(err, jc.ErrorIsNil)
+	raw.stub.CheckCallNames(c, "ContainerInfo")
+}
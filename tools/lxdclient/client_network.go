@@ -0,0 +1,73 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build go1.3
+
+package lxdclient
+
+import (
+	"net"
+
+	"github.com/juju/errors"
+	"github.com/lxc/lxd/shared"
+)
+
+type rawNetworkClient interface {
+	ListNetworks() ([]shared.NetworkConfig, error)
+}
+
+type networkClient struct {
+	raw    rawNetworkClient
+	remote string
+}
+
+// interfaceAddrs returns the addresses assigned to the named host
+// network interface. It is a variable so it can be patched in tests.
+var interfaceAddrs = func(name string) ([]net.Addr, error) {
+	iface, err := net.InterfaceByName(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return iface.Addrs()
+}
+
+// BridgeSubnets returns the CIDRs of the subnets configured on each of
+// the LXD host's bridge networks, keyed by bridge name. The CIDRs are
+// only known when the LXD host is the local machine; for remote hosts
+// the bridges are returned with no subnets, and the subnets observed by
+// the machine agents are recorded instead. Link-local subnets are never
+// included.
+func (c networkClient) BridgeSubnets() (map[string][]string, error) {
+	networks, err := c.raw.ListNetworks()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	bridges := make(map[string][]string)
+	for _, nw := range networks {
+		if nw.Type != "bridge" {
+			continue
+		}
+		bridges[nw.Name] = nil
+		if c.remote != remoteIDForLocal {
+			continue
+		}
+
+		addrs, err := interfaceAddrs(nw.Name)
+		if err != nil {
+			return nil, errors.Annotatef(err, "getting addresses of bridge %q", nw.Name)
+		}
+		for _, addr := range addrs {
+			ipNet, ok := addr.(*net.IPNet)
+			if !ok || ipNet.IP.IsLinkLocalUnicast() {
+				continue
+			}
+			subnet := &net.IPNet{
+				IP:   ipNet.IP.Mask(ipNet.Mask),
+				Mask: ipNet.Mask,
+			}
+			bridges[nw.Name] = append(bridges[nw.Name], subnet.String())
+		}
+	}
+	return bridges, nil
+}
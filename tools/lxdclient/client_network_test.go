@@ -0,0 +1,90 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build go1.3
+
+package lxdclient_test
+
+import (
+	"net"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	lxdshared "github.com/lxc/lxd/shared"
+	gc "gopkg.in/check.v1"
+
+	jujutesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/tools/lxdclient"
+)
+
+type networkSuite struct {
+	jujutesting.BaseSuite
+}
+
+var _ = gc.Suite(&networkSuite{})
+
+type networkTester struct {
+	networks []lxdshared.NetworkConfig
+	err      error
+}
+
+func (n *networkTester) ListNetworks() ([]lxdshared.NetworkConfig, error) {
+	return n.networks, n.err
+}
+
+var _ lxdclient.RawNetworkClient = (*networkTester)(nil)
+
+func mustParseCIDR(c *gc.C, s string) net.Addr {
+	ip, ipNet, err := net.ParseCIDR(s)
+	c.Assert(err, jc.ErrorIsNil)
+	ipNet.IP = ip
+	return ipNet
+}
+
+func (s *networkSuite) TestBridgeSubnetsLocal(c *gc.C) {
+	lxdclient.PatchInterfaceAddrs(&s.CleanupSuite, map[string][]net.Addr{
+		"lxdbr0": {
+			mustParseCIDR(c, "10.0.8.1/24"),
+			mustParseCIDR(c, "fe80::1/64"),
+			mustParseCIDR(c, "fd00:1::1/64"),
+		},
+	})
+	raw := &networkTester{networks: []lxdshared.NetworkConfig{
+		{Name: "lxdbr0", Type: "bridge"},
+		{Name: "eth0", Type: "physical"},
+	}}
+	client := lxdclient.NewNetworkClient(raw, "local")
+
+	bridges, err := client.BridgeSubnets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(bridges, jc.DeepEquals, map[string][]string{
+		"lxdbr0": {"10.0.8.0/24", "fd00:1::/64"},
+	})
+}
+
+func (s *networkSuite) TestBridgeSubnetsRemote(c *gc.C) {
+	// The bridges of a remote LXD host are not the local machine's
+	// interfaces, so they must never be looked up.
+	s.PatchValue(lxdclient.InterfaceAddrs, func(name string) ([]net.Addr, error) {
+		c.Errorf("unexpected lookup of interface %q", name)
+		return nil, errors.New("unexpected")
+	})
+	raw := &networkTester{networks: []lxdshared.NetworkConfig{
+		{Name: "lxdbr0", Type: "bridge"},
+	}}
+	client := lxdclient.NewNetworkClient(raw, "some-remote")
+
+	bridges, err := client.BridgeSubnets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(bridges, jc.DeepEquals, map[string][]string{
+		"lxdbr0": nil,
+	})
+}
+
+func (s *networkSuite) TestBridgeSubnetsError(c *gc.C) {
+	raw := &networkTester{err: errors.New("boom")}
+	client := lxdclient.NewNetworkClient(raw, "local")
+
+	_, err := client.BridgeSubnets()
+	c.Check(err, gc.ErrorMatches, "boom")
+}
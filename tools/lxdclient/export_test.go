@@ -6,10 +6,15 @@
 package lxdclient
 
 import (
+	"net"
+
 	"github.com/juju/testing"
 )
 
-var NewInstanceSummary = newInstanceSummary
+var (
+	NewInstanceSummary = newInstanceSummary
+	InterfaceAddrs     = &interfaceAddrs
+)
 
 type RawInstanceClient rawInstanceClient
 
@@ -20,6 +25,21 @@ func NewInstanceClient(raw RawInstanceClient) *instanceClient {
 	}
 }
 
+type RawNetworkClient rawNetworkClient
+
+func NewNetworkClient(raw RawNetworkClient, remote string) *networkClient {
+	return &networkClient{
+		raw:    rawNetworkClient(raw),
+		remote: remote,
+	}
+}
+
+func PatchInterfaceAddrs(s *testing.CleanupSuite, addrs map[string][]net.Addr) {
+	s.PatchValue(&interfaceAddrs, func(name string) ([]net.Addr, error) {
+		return addrs[name], nil
+	})
+}
+
 func PatchGenerateCertificate(s *testing.CleanupSuite, cert, key string) {
 	s.PatchValue(&generateCertificate, func() ([]byte, []byte, error) {
 		return []byte(cert), []byte(key), nil
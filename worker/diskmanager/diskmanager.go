@@ -7,8 +7,10 @@ import (
 	"reflect"
 	"time"
 
+	"github.com/juju/errors"
 	"github.com/juju/loggo"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/worker"
 )
@@ -21,6 +23,10 @@ const (
 	// polling it is.
 	listBlockDevicesPeriod = time.Second * 30
 
+	// filesystemUsagePeriod is the minimum time period between reports
+	// of filesystem usage.
+	filesystemUsagePeriod = time.Minute * 5
+
 	// bytesInMiB is the number of bytes in a MiB.
 	bytesInMiB = 1024 * 1024
 )
@@ -31,6 +37,14 @@ type BlockDeviceSetter interface {
 	SetMachineBlockDevices([]storage.BlockDevice) error
 }
 
+// FilesystemUsageSetter is an interface that may be implemented by the
+// BlockDeviceSetter supplied to NewWorker, for reporting the usage of
+// filesystems mounted on the local host.
+type FilesystemUsageSetter interface {
+	MachineFilesystemAttachments() ([]storage.FilesystemAttachment, error)
+	SetFilesystemAttachmentUsage([]storage.FilesystemUsage) error
+}
+
 // ListBlockDevicesFunc is the type of a function that is supplied to
 // NewWorker for listing block devices available on the local host.
 type ListBlockDevicesFunc func() ([]storage.BlockDevice, error)
@@ -41,10 +55,26 @@ var DefaultListBlockDevices ListBlockDevicesFunc
 
 // NewWorker returns a worker that lists block devices
 // attached to the machine, and records them in state.
+//
+// If b also implements FilesystemUsageSetter, the worker
+// periodically records the usage of the machine's mounted
+// filesystems in state.
 var NewWorker = func(l ListBlockDevicesFunc, b BlockDeviceSetter) worker.Worker {
 	var old []storage.BlockDevice
+	var lastUsage time.Time
 	f := func(stop <-chan struct{}) error {
-		return doWork(l, b, &old)
+		if err := doWork(l, b, &old); err != nil {
+			return err
+		}
+		u, ok := b.(FilesystemUsageSetter)
+		if !ok || time.Since(lastUsage) < filesystemUsagePeriod {
+			return nil
+		}
+		if err := doFilesystemUsageWork(u); err != nil {
+			return err
+		}
+		lastUsage = time.Now()
+		return nil
 	}
 	return worker.NewPeriodicWorker(f, listBlockDevicesPeriod, worker.NewTimer)
 }
@@ -66,3 +96,33 @@ func doWork(listf ListBlockDevicesFunc, b BlockDeviceSetter, old *[]storage.Bloc
 	*old = blockDevices
 	return nil
 }
+
+func doFilesystemUsageWork(u FilesystemUsageSetter) error {
+	attachments, err := u.MachineFilesystemAttachments()
+	if params.IsCodeNotImplemented(err) {
+		// The controller predates filesystem usage reporting.
+		logger.Tracef("filesystem usage reporting not supported by controller")
+		return nil
+	} else if err != nil {
+		return errors.Annotate(err, "getting filesystem attachments")
+	}
+	var usage []storage.FilesystemUsage
+	for _, attachment := range attachments {
+		fsUsage, err := filesystemUsage(attachment.Path)
+		if errors.IsNotSupported(err) {
+			logger.Tracef("%v", err)
+			return nil
+		} else if err != nil {
+			logger.Warningf("cannot get usage of filesystem %s: %v", attachment.Filesystem.Id(), err)
+			continue
+		}
+		fsUsage.Filesystem = attachment.Filesystem
+		fsUsage.Machine = attachment.Machine
+		usage = append(usage, fsUsage)
+	}
+	if len(usage) == 0 {
+		return nil
+	}
+	logger.Debugf("filesystem usage: %v", usage)
+	return errors.Annotate(u.SetFilesystemAttachmentUsage(usage), "setting filesystem usage")
+}
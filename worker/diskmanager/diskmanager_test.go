@@ -6,9 +6,12 @@ package diskmanager_test
 import (
 	"time"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/storage"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/diskmanager"
@@ -112,6 +115,75 @@ func (s *DiskManagerWorkerSuite) TestBlockDevicesSorted(c *gc.C) {
 	}}})
 }
 
+func (s *DiskManagerWorkerSuite) TestFilesystemUsage(c *gc.C) {
+	s.PatchValue(diskmanager.FilesystemUsage, func(path string) (storage.FilesystemUsage, error) {
+		switch path {
+		case "/srv":
+			return storage.FilesystemUsage{UsedBytes: 1, FreeBytes: 2, UsedInodes: 3, FreeInodes: 4}, nil
+		}
+		return storage.FilesystemUsage{}, errors.New("boom")
+	})
+	setter := &mockFilesystemUsageSetter{
+		attachments: []storage.FilesystemAttachment{{
+			Filesystem:               names.NewFilesystemTag("0"),
+			Machine:                  names.NewMachineTag("0"),
+			FilesystemAttachmentInfo: storage.FilesystemAttachmentInfo{Path: "/srv"},
+		}, {
+			Filesystem:               names.NewFilesystemTag("1"),
+			Machine:                  names.NewMachineTag("0"),
+			FilesystemAttachmentInfo: storage.FilesystemAttachmentInfo{Path: "/var/lib/foo"},
+		}},
+	}
+	err := diskmanager.DoFilesystemUsageWork(setter)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Filesystems whose usage cannot be determined are skipped.
+	c.Assert(setter.usage, jc.DeepEquals, [][]storage.FilesystemUsage{{{
+		Filesystem: names.NewFilesystemTag("0"),
+		Machine:    names.NewMachineTag("0"),
+		UsedBytes:  1,
+		FreeBytes:  2,
+		UsedInodes: 3,
+		FreeInodes: 4,
+	}}})
+}
+
+func (s *DiskManagerWorkerSuite) TestFilesystemUsageNoAttachments(c *gc.C) {
+	setter := &mockFilesystemUsageSetter{}
+	err := diskmanager.DoFilesystemUsageWork(setter)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(setter.usage, gc.HasLen, 0)
+}
+
+func (s *DiskManagerWorkerSuite) TestFilesystemUsageNotImplemented(c *gc.C) {
+	setter := &mockFilesystemUsageSetter{
+		err: &params.Error{Code: params.CodeNotImplemented, Message: "not implemented"},
+	}
+	err := diskmanager.DoFilesystemUsageWork(setter)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *DiskManagerWorkerSuite) TestFilesystemUsageError(c *gc.C) {
+	setter := &mockFilesystemUsageSetter{err: errors.New("boom")}
+	err := diskmanager.DoFilesystemUsageWork(setter)
+	c.Assert(err, gc.ErrorMatches, "getting filesystem attachments: boom")
+}
+
+type mockFilesystemUsageSetter struct {
+	attachments []storage.FilesystemAttachment
+	usage       [][]storage.FilesystemUsage
+	err         error
+}
+
+func (m *mockFilesystemUsageSetter) MachineFilesystemAttachments() ([]storage.FilesystemAttachment, error) {
+	return m.attachments, m.err
+}
+
+func (m *mockFilesystemUsageSetter) SetFilesystemAttachmentUsage(usage []storage.FilesystemUsage) error {
+	m.usage = append(m.usage, usage)
+	return nil
+}
+
 type BlockDeviceSetterFunc func([]storage.BlockDevice) error
 
 func (f BlockDeviceSetterFunc) SetMachineBlockDevices(devices []storage.BlockDevice) error {
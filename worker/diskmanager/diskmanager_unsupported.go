@@ -8,6 +8,8 @@ package diskmanager
 import (
 	"runtime"
 
+	"github.com/juju/errors"
+
 	"github.com/juju/juju/storage"
 )
 
@@ -20,6 +22,10 @@ func listBlockDevices() ([]storage.BlockDevice, error) {
 	return nil, nil
 }
 
+var filesystemUsage = func(path string) (storage.FilesystemUsage, error) {
+	return storage.FilesystemUsage{}, errors.NotSupportedf("filesystem usage on %s", runtime.GOOS)
+}
+
 func init() {
 	logger.Infof(
 		"block device support has not been implemented for %s",
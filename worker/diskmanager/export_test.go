@@ -8,4 +8,7 @@ var (
 	BlockDeviceInUse = &blockDeviceInUse
 	DoWork           = doWork
 	NewWorkerFunc    = newWorker
+
+	DoFilesystemUsageWork = doFilesystemUsageWork
+	FilesystemUsage       = &filesystemUsage
 )
@@ -0,0 +1,30 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build linux
+
+package diskmanager
+
+import (
+	"syscall"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/storage"
+)
+
+// filesystemUsage returns the space and inode usage of the
+// filesystem mounted at the specified path.
+var filesystemUsage = func(path string) (storage.FilesystemUsage, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return storage.FilesystemUsage{}, errors.Annotatef(err, "statting %q", path)
+	}
+	blockSize := uint64(stat.Bsize)
+	return storage.FilesystemUsage{
+		UsedBytes:  (stat.Blocks - stat.Bfree) * blockSize,
+		FreeBytes:  stat.Bavail * blockSize,
+		UsedInodes: stat.Files - stat.Ffree,
+		FreeInodes: stat.Ffree,
+	}, nil
+}
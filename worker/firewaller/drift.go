@@ -0,0 +1,185 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package firewaller
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+)
+
+// checkDrift reads back the ingress rules applied by the provider and
+// compares them with those the firewaller expects to be applied. Any
+// difference is recorded against the affected machines, and repaired
+// if firewall-auto-repair is enabled. A failure to check one machine
+// is logged, and does not prevent the others from being checked.
+func (fw *Firewaller) checkDrift() error {
+	if fw.globalMode {
+		return fw.checkGlobalDrift()
+	}
+	for _, machined := range fw.machineds {
+		if err := fw.checkInstanceDrift(machined); err != nil {
+			logger.Errorf("cannot check firewall drift of %q: %v", machined.tag, err)
+		}
+	}
+	return nil
+}
+
+// checkInstanceDrift compares the ingress rules applied to the
+// machine's instance with those expected.
+func (fw *Firewaller) checkInstanceDrift(machined *machineData) error {
+	m, err := machined.machine()
+	if params.IsCodeNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	instanceId, err := m.InstanceId()
+	if params.IsCodeNotProvisioned(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	instances, err := fw.environ.Instances([]instance.Id{instanceId})
+	if err == environs.ErrNoInstances {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	inst := instances[0]
+	machineId := machined.tag.Id()
+	actual, err := instanceIngressRules(inst, machineId)
+	if err != nil {
+		return err
+	}
+	want := machined.ingressRules
+	if _, ok := inst.(instance.IngressRuleFirewaller); !ok {
+		want = openToAllRules(want)
+	}
+	missing := diffRules(want, actual)
+	unexpected := diffRules(actual, want)
+	if fw.autoRepair && (len(missing) > 0 || len(unexpected) > 0) {
+		logger.Warningf("repairing ingress rules on %q: missing %v, unexpected %v",
+			machined.tag, missing, unexpected)
+		if len(missing) > 0 {
+			if err := openInstanceIngressRules(inst, machineId, missing); err != nil {
+				return err
+			}
+		}
+		if len(unexpected) > 0 {
+			if err := closeInstanceIngressRules(inst, machineId, unexpected); err != nil {
+				return err
+			}
+		}
+		missing, unexpected = nil, nil
+	}
+	return fw.setFirewallDrift(machined, missing, unexpected)
+}
+
+// checkGlobalDrift compares the ingress rules opened for the whole
+// environment with those expected. Rules that are missing are recorded
+// against the machines that need them; unexpected rules affect every
+// machine, and so are recorded against all of them.
+func (fw *Firewaller) checkGlobalDrift() error {
+	actual, err := fw.globalIngressRules()
+	if err != nil {
+		return errors.Annotate(err, "cannot check global firewall drift")
+	}
+	_, canRestrict := fw.environ.(environs.IngressRuleFirewaller)
+	collector := make(map[string]network.IngressRule)
+	for _, machined := range fw.machineds {
+		for _, rule := range machined.ingressRules {
+			collector[rule.String()] = rule
+		}
+	}
+	want := []network.IngressRule{}
+	for _, rule := range collector {
+		want = append(want, rule)
+	}
+	if !canRestrict {
+		want = openToAllRules(want)
+	}
+	missing := diffRules(want, actual)
+	unexpected := diffRules(actual, want)
+	if fw.autoRepair && (len(missing) > 0 || len(unexpected) > 0) {
+		logger.Warningf("repairing global ingress rules: missing %v, unexpected %v",
+			missing, unexpected)
+		if len(missing) > 0 {
+			if err := fw.openGlobalIngressRules(missing); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		if len(unexpected) > 0 {
+			if err := fw.closeGlobalIngressRules(unexpected); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		missing, unexpected = nil, nil
+	}
+	missingKeys := make(map[string]bool)
+	for _, rule := range missing {
+		missingKeys[rule.String()] = true
+	}
+	for _, machined := range fw.machineds {
+		var machineMissing []network.IngressRule
+		for _, rule := range machined.ingressRules {
+			if missingKeys[rule.String()] {
+				machineMissing = append(machineMissing, rule)
+			}
+		}
+		if err := fw.setFirewallDrift(machined, machineMissing, unexpected); err != nil {
+			logger.Errorf("cannot record firewall drift of %q: %v", machined.tag, err)
+		}
+	}
+	return nil
+}
+
+// setFirewallDrift records the given drift against the machine, unless
+// it is the same as the drift last recorded by the firewaller.
+func (fw *Firewaller) setFirewallDrift(machined *machineData, missing, unexpected []network.IngressRule) error {
+	network.SortIngressRules(missing)
+	network.SortIngressRules(unexpected)
+	drift := fmt.Sprintf("%v %v", missing, unexpected)
+	if machined.firewallDriftSet && machined.firewallDrift == drift {
+		return nil
+	}
+	if len(missing) > 0 || len(unexpected) > 0 {
+		logger.Warningf("ingress rules on %q differ from expected: missing %v, unexpected %v",
+			machined.tag, missing, unexpected)
+	}
+	m, err := machined.machine()
+	if params.IsCodeNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := m.SetFirewallDrift(missing, unexpected); err != nil {
+		return err
+	}
+	machined.firewallDrift = drift
+	machined.firewallDriftSet = true
+	return nil
+}
+
+// openToAllRules returns those of the given ingress rules that permit
+// traffic from any address. Providers that cannot restrict the source
+// addresses of ingress traffic never have the other rules applied.
+func openToAllRules(rules []network.IngressRule) []network.IngressRule {
+	var result []network.IngressRule
+	for _, rule := range rules {
+		if rule.IsOpenToAll() {
+			result = append(result, rule)
+		}
+	}
+	return result
+}
@@ -5,8 +5,10 @@ package firewaller
 
 import (
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/firewaller"
@@ -23,6 +25,15 @@ import (
 
 type machineRanges map[network.PortRange]bool
 
+// egressRetryDelay is how long the firewaller waits before retrying
+// to set the egress rules of machines whose instances had not yet
+// been provisioned.
+var egressRetryDelay = 10 * time.Second
+
+// driftCheckAfter returns a channel which delivers when the firewaller
+// should next check the rules applied by the provider for drift.
+var driftCheckAfter = time.After
+
 // Firewaller watches the state for port ranges opened or closed on
 // machines and reflects those changes onto the backing environment.
 // Uses Firewaller API V1.
@@ -33,14 +44,19 @@ type Firewaller struct {
 	modelWatcher    watcher.NotifyWatcher
 	machinesWatcher watcher.StringsWatcher
 	portsWatcher    watcher.StringsWatcher
+	subnetsWatcher  watcher.NotifyWatcher
 	machineds       map[names.MachineTag]*machineData
 	unitsChange     chan *unitsChange
 	unitds          map[names.UnitTag]*unitData
 	applicationids  map[names.ApplicationTag]*serviceData
 	exposedChange   chan *exposedChange
 	globalMode      bool
-	globalPortRef   map[network.PortRange]int
+	globalRuleRef   map[string]int
 	machinePorts    map[names.MachineTag]machineRanges
+	egressRules     []network.EgressRule
+	egressPending   map[names.MachineTag]*machineData
+	checkInterval   time.Duration
+	autoRepair      bool
 }
 
 // NewFirewaller returns a new Firewaller or a new FirewallerV0,
@@ -54,6 +70,7 @@ func NewFirewaller(st *firewaller.State) (worker.Worker, error) {
 		applicationids: make(map[names.ApplicationTag]*serviceData),
 		exposedChange:  make(chan *exposedChange),
 		machinePorts:   make(map[names.MachineTag]machineRanges),
+		egressPending:  make(map[names.MachineTag]*machineData),
 	}
 	err := catacomb.Invoke(catacomb.Plan{
 		Site: &fw.catacomb,
@@ -85,11 +102,14 @@ func (fw *Firewaller) setUp() error {
 		}
 		return errors.Trace(err)
 	}
+	fw.egressRules = fw.environ.Config().EgressRules()
+	fw.checkInterval = fw.environ.Config().FirewallCheckInterval()
+	fw.autoRepair = fw.environ.Config().FirewallAutoRepair()
 	switch fw.environ.Config().FirewallMode() {
 	case config.FwInstance:
 	case config.FwGlobal:
 		fw.globalMode = true
-		fw.globalPortRef = make(map[network.PortRange]int)
+		fw.globalRuleRef = make(map[string]int)
 	case config.FwNone:
 		logger.Infof("stopping firewaller (not required)")
 		fw.Kill()
@@ -114,6 +134,14 @@ func (fw *Firewaller) setUp() error {
 		return errors.Trace(err)
 	}
 
+	fw.subnetsWatcher, err = fw.st.WatchSubnets()
+	if err != nil {
+		return errors.Annotatef(err, "failed to start subnets watcher")
+	}
+	if err := fw.catacomb.Add(fw.subnetsWatcher); err != nil {
+		return errors.Trace(err)
+	}
+
 	logger.Debugf("started watching opened port ranges for the environment")
 	return nil
 }
@@ -123,8 +151,15 @@ func (fw *Firewaller) loop() error {
 		return errors.Trace(err)
 	}
 	var reconciled bool
+	var egressRetry, driftCheck <-chan time.Time
 	portsChange := fw.portsWatcher.Changes()
 	for {
+		if egressRetry == nil && len(fw.egressPending) > 0 {
+			egressRetry = time.After(egressRetryDelay)
+		}
+		if driftCheck == nil && reconciled && fw.checkInterval > 0 {
+			driftCheck = driftCheckAfter(fw.checkInterval)
+		}
 		select {
 		case <-fw.catacomb.Dying():
 			return fw.catacomb.ErrDying()
@@ -142,6 +177,31 @@ func (fw *Firewaller) loop() error {
 				// hopefully be replaced with EnvironObserver.
 				logger.Errorf("loaded invalid environment configuration: %v", err)
 			}
+			fw.egressRules = config.EgressRules()
+			if interval := config.FirewallCheckInterval(); interval != fw.checkInterval {
+				fw.checkInterval = interval
+				driftCheck = nil
+			}
+			fw.autoRepair = config.FirewallAutoRepair()
+			for _, machined := range fw.machineds {
+				if err := fw.flushEgressRules(machined); err != nil {
+					return errors.Annotate(err, "cannot change egress rules")
+				}
+			}
+		case <-egressRetry:
+			egressRetry = nil
+			for _, machined := range fw.egressPending {
+				if err := fw.flushEgressRules(machined); err != nil {
+					return errors.Annotate(err, "cannot change egress rules")
+				}
+			}
+		case <-driftCheck:
+			driftCheck = nil
+			// The provider may be temporarily unable to report its
+			// rules; that is no reason to stop managing them.
+			if err := fw.checkDrift(); err != nil {
+				logger.Warningf("%v", err)
+			}
 		case change, ok := <-fw.machinesWatcher.Changes():
 			if !ok {
 				return errors.New("machines watcher closed")
@@ -176,12 +236,22 @@ func (fw *Firewaller) loop() error {
 					return errors.Trace(err)
 				}
 			}
+		case _, ok := <-fw.subnetsWatcher.Changes():
+			if !ok {
+				return errors.New("subnets watcher closed")
+			}
+			if err := fw.subnetsChanged(); err != nil {
+				return errors.Annotate(err, "cannot change firewall ports")
+			}
 		case change := <-fw.unitsChange:
 			if err := fw.unitsChanged(change); err != nil {
 				return errors.Trace(err)
 			}
 		case change := <-fw.exposedChange:
 			change.serviced.exposed = change.exposed
+			change.serviced.sourceCIDRs = change.sourceCIDRs
+			change.serviced.relationCIDRs = change.relationCIDRs
+			change.serviced.egressRules = change.egressRules
 			unitds := []*unitData{}
 			for _, unitd := range change.serviced.unitds {
 				unitds = append(unitds, unitd)
@@ -193,6 +263,37 @@ func (fw *Firewaller) loop() error {
 	}
 }
 
+// subnetsChanged updates the source address ranges of the exposed
+// services, as subnets may have been added to or removed from the
+// spaces to which they are exposed, and flushes the units of those
+// whose address ranges changed.
+func (fw *Firewaller) subnetsChanged() error {
+	for _, serviced := range fw.applicationids {
+		if !serviced.exposed {
+			continue
+		}
+		sourceCIDRs, err := serviced.application.ExposedSourceCIDRs()
+		if params.IsCodeNotFound(err) {
+			// The service's watcher will stop.
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if sameCIDRs(sourceCIDRs, serviced.sourceCIDRs) {
+			continue
+		}
+		serviced.sourceCIDRs = sourceCIDRs
+		unitds := []*unitData{}
+		for _, unitd := range serviced.unitds {
+			unitds = append(unitds, unitd)
+		}
+		if err := fw.flushUnits(unitds); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
 // startMachine creates a new data value for tracking details of the
 // machine and starts watching the machine for units added or removed.
 func (fw *Firewaller) startMachine(tag names.MachineTag) error {
@@ -200,7 +301,7 @@ func (fw *Firewaller) startMachine(tag names.MachineTag) error {
 		fw:           fw,
 		tag:          tag,
 		unitds:       make(map[names.UnitTag]*unitData),
-		openedPorts:  make([]network.PortRange, 0),
+		ingressRules: make([]network.IngressRule, 0),
 		definedPorts: make(map[network.PortRange]names.UnitTag),
 	}
 	m, err := machined.machine()
@@ -251,7 +352,10 @@ func (fw *Firewaller) startMachine(tag names.MachineTag) error {
 	}
 
 	// register the machined with the firewaller's catacomb.
-	return fw.catacomb.Add(machined)
+	if err := fw.catacomb.Add(machined); err != nil {
+		return errors.Trace(err)
+	}
+	return fw.flushEgressRules(machined)
 }
 
 // startUnit creates a new data value for tracking details of the unit
@@ -313,16 +417,31 @@ func (fw *Firewaller) startService(service *firewaller.Application) error {
 	if err != nil {
 		return err
 	}
+	sourceCIDRs, err := service.ExposedSourceCIDRs()
+	if err != nil {
+		return err
+	}
+	relationCIDRs, err := service.RelationIngressCIDRs()
+	if err != nil {
+		return err
+	}
+	egressRules, err := service.EgressRules()
+	if err != nil {
+		return err
+	}
 	serviced := &serviceData{
-		fw:          fw,
-		application: service,
-		exposed:     exposed,
-		unitds:      make(map[names.UnitTag]*unitData),
+		fw:            fw,
+		application:   service,
+		exposed:       exposed,
+		sourceCIDRs:   sourceCIDRs,
+		relationCIDRs: relationCIDRs,
+		egressRules:   egressRules,
+		unitds:        make(map[names.UnitTag]*unitData),
 	}
 	err = catacomb.Invoke(catacomb.Plan{
 		Site: &serviced.catacomb,
 		Work: func() error {
-			return serviced.watchLoop(exposed)
+			return serviced.watchLoop(exposed, sourceCIDRs, relationCIDRs, egressRules)
 		},
 	})
 	if err != nil {
@@ -336,14 +455,14 @@ func (fw *Firewaller) startService(service *firewaller.Application) error {
 }
 
 // reconcileGlobal compares the initially started watcher for machines,
-// units and services with the opened and closed ports globally and
-// opens and closes the appropriate ports for the whole environment.
+// units and services with the opened and closed ingress rules globally
+// and opens and closes the appropriate rules for the whole environment.
 func (fw *Firewaller) reconcileGlobal() error {
-	initialPortRanges, err := fw.environ.Ports()
+	initialRules, err := fw.globalIngressRules()
 	if err != nil {
 		return err
 	}
-	collector := make(map[network.PortRange]bool)
+	collector := make(map[string]network.IngressRule)
 	for _, machined := range fw.machineds {
 		for portRange, unitTag := range machined.definedPorts {
 			unitd, known := machined.unitds[unitTag]
@@ -351,38 +470,38 @@ func (fw *Firewaller) reconcileGlobal() error {
 				delete(machined.unitds, unitTag)
 				continue
 			}
-			if unitd.serviced.exposed {
-				collector[portRange] = true
+			if rule, ok := unitd.serviced.ingressRule(portRange); ok {
+				collector[rule.String()] = rule
 			}
 		}
 	}
-	wantedPorts := []network.PortRange{}
-	for port := range collector {
-		wantedPorts = append(wantedPorts, port)
+	wantedRules := []network.IngressRule{}
+	for _, rule := range collector {
+		wantedRules = append(wantedRules, rule)
 	}
-	// Check which ports to open or to close.
-	toOpen := diffRanges(wantedPorts, initialPortRanges)
-	toClose := diffRanges(initialPortRanges, wantedPorts)
+	// Check which rules to open or to close.
+	toOpen := diffRules(wantedRules, initialRules)
+	toClose := diffRules(initialRules, wantedRules)
 	if len(toOpen) > 0 {
-		logger.Infof("opening global ports %v", toOpen)
-		if err := fw.environ.OpenPorts(toOpen); err != nil {
+		logger.Infof("opening global ingress rules %v", toOpen)
+		if err := fw.openGlobalIngressRules(toOpen); err != nil {
 			return err
 		}
-		network.SortPortRanges(toOpen)
+		network.SortIngressRules(toOpen)
 	}
 	if len(toClose) > 0 {
-		logger.Infof("closing global ports %v", toClose)
-		if err := fw.environ.ClosePorts(toClose); err != nil {
+		logger.Infof("closing global ingress rules %v", toClose)
+		if err := fw.closeGlobalIngressRules(toClose); err != nil {
 			return err
 		}
-		network.SortPortRanges(toClose)
+		network.SortIngressRules(toClose)
 	}
 	return nil
 }
 
 // reconcileInstances compares the initially started watcher for machines,
-// units and services with the opened and closed ports of the instances and
-// opens and closes the appropriate ports for each instance.
+// units and services with the opened and closed ingress rules of the
+// instances and opens and closes the appropriate rules for each instance.
 func (fw *Firewaller) reconcileInstances() error {
 	for _, machined := range fw.machineds {
 		m, err := machined.machine()
@@ -411,31 +530,31 @@ func (fw *Firewaller) reconcileInstances() error {
 			return err
 		}
 		machineId := machined.tag.Id()
-		initialPortRanges, err := instances[0].Ports(machineId)
+		initialRules, err := instanceIngressRules(instances[0], machineId)
 		if err != nil {
 			return err
 		}
 
-		// Check which ports to open or to close.
-		toOpen := diffRanges(machined.openedPorts, initialPortRanges)
-		toClose := diffRanges(initialPortRanges, machined.openedPorts)
+		// Check which rules to open or to close.
+		toOpen := diffRules(machined.ingressRules, initialRules)
+		toClose := diffRules(initialRules, machined.ingressRules)
 		if len(toOpen) > 0 {
-			logger.Infof("opening instance port ranges %v for %q",
+			logger.Infof("opening instance ingress rules %v for %q",
 				toOpen, machined.tag)
-			if err := instances[0].OpenPorts(machineId, toOpen); err != nil {
+			if err := openInstanceIngressRules(instances[0], machineId, toOpen); err != nil {
 				// TODO(mue) Add local retry logic.
 				return err
 			}
-			network.SortPortRanges(toOpen)
+			network.SortIngressRules(toOpen)
 		}
 		if len(toClose) > 0 {
-			logger.Infof("closing instance port ranges %v for %q",
+			logger.Infof("closing instance ingress rules %v for %q",
 				toClose, machined.tag)
-			if err := instances[0].ClosePorts(machineId, toClose); err != nil {
+			if err := closeInstanceIngressRules(instances[0], machineId, toClose); err != nil {
 				// TODO(mue) Add local retry logic.
 				return err
 			}
-			network.SortPortRanges(toClose)
+			network.SortIngressRules(toClose)
 		}
 	}
 	return nil
@@ -550,74 +669,143 @@ func (fw *Firewaller) flushUnits(unitds []*unitData) error {
 		if err := fw.flushMachine(machined); err != nil {
 			return err
 		}
+		if err := fw.flushEgressRules(machined); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
-// flushMachine opens and closes ports for the passed machine.
+// flushMachine opens and closes ingress rules for the passed machine.
 func (fw *Firewaller) flushMachine(machined *machineData) error {
-	// Gather ports to open and close.
-	want := []network.PortRange{}
+	// Gather ingress rules to open and close.
+	want := []network.IngressRule{}
 	for portRange, unitTag := range machined.definedPorts {
 		unitd, known := machined.unitds[unitTag]
 		if !known {
 			delete(machined.unitds, unitTag)
 			continue
 		}
-		if unitd.serviced.exposed {
-			want = append(want, portRange)
+		if rule, ok := unitd.serviced.ingressRule(portRange); ok {
+			want = append(want, rule)
 		}
 	}
-	toOpen := diffRanges(want, machined.openedPorts)
-	toClose := diffRanges(machined.openedPorts, want)
-	machined.openedPorts = want
+	toOpen := diffRules(want, machined.ingressRules)
+	toClose := diffRules(machined.ingressRules, want)
+	machined.ingressRules = want
 	if fw.globalMode {
-		return fw.flushGlobalPorts(toOpen, toClose)
+		return fw.flushGlobalIngressRules(toOpen, toClose)
 	}
-	return fw.flushInstancePorts(machined, toOpen, toClose)
+	return fw.flushInstanceIngressRules(machined, toOpen, toClose)
 }
 
-// flushGlobalPorts opens and closes global ports in the environment.
-// It keeps a reference count for ports so that only 0-to-1 and 1-to-0 events
-// modify the environment.
-func (fw *Firewaller) flushGlobalPorts(rawOpen, rawClose []network.PortRange) error {
-	// Filter which ports are really to open or close.
-	var toOpen, toClose []network.PortRange
-	for _, portRange := range rawOpen {
-		if fw.globalPortRef[portRange] == 0 {
-			toOpen = append(toOpen, portRange)
+// flushEgressRules sets the egress rules of the passed machine's
+// instance to those of the model and of the services with units on
+// the machine. If the environment cannot restrict egress traffic,
+// an error is logged instead.
+func (fw *Firewaller) flushEgressRules(machined *machineData) error {
+	collector := make(map[string]network.EgressRule)
+	for _, rule := range fw.egressRules {
+		collector[rule.String()] = rule
+	}
+	for _, unitd := range machined.unitds {
+		for _, rule := range unitd.serviced.egressRules {
+			collector[rule.String()] = rule
 		}
-		fw.globalPortRef[portRange]++
 	}
-	for _, portRange := range rawClose {
-		fw.globalPortRef[portRange]--
-		if fw.globalPortRef[portRange] == 0 {
-			toClose = append(toClose, portRange)
-			delete(fw.globalPortRef, portRange)
+	want := []network.EgressRule{}
+	for _, rule := range collector {
+		want = append(want, rule)
+	}
+	network.SortEgressRules(want)
+	if machined.egressRulesSet && sameEgressRules(want, machined.egressRules) {
+		return nil
+	}
+
+	egressFirewaller, ok := fw.environ.(environs.EgressFirewaller)
+	if !ok {
+		if len(want) > 0 {
+			logger.Errorf(
+				"cannot set egress rules %v on %q: egress rules not supported by %q provider",
+				want, machined.tag, fw.environ.Config().Type(),
+			)
 		}
+		machined.egressRules = want
+		machined.egressRulesSet = true
+		return nil
+	}
+	m, err := machined.machine()
+	if params.IsCodeNotFound(err) {
+		delete(fw.egressPending, machined.tag)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	instanceId, err := m.InstanceId()
+	if params.IsCodeNotProvisioned(err) {
+		// The rules will be set once the machine's
+		// instance has been provisioned.
+		fw.egressPending[machined.tag] = machined
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	delete(fw.egressPending, machined.tag)
+	if err := egressFirewaller.SetEgressRules(machined.tag.Id(), instanceId, want); err != nil {
+		return err
 	}
-	// Open and close the ports.
+	machined.egressRules = want
+	machined.egressRulesSet = true
+	logger.Infof("set egress rules %v on %q", want, machined.tag)
+	return nil
+}
+
+// flushGlobalIngressRules opens and closes global ingress rules in the
+// environment. It keeps a reference count for rules so that only 0-to-1
+// and 1-to-0 events modify the environment.
+func (fw *Firewaller) flushGlobalIngressRules(rawOpen, rawClose []network.IngressRule) error {
+	// Filter which rules are really to open or close.
+	var toOpen, toClose []network.IngressRule
+	for _, rule := range rawOpen {
+		key := rule.String()
+		if fw.globalRuleRef[key] == 0 {
+			toOpen = append(toOpen, rule)
+		}
+		fw.globalRuleRef[key]++
+	}
+	for _, rule := range rawClose {
+		key := rule.String()
+		fw.globalRuleRef[key]--
+		if fw.globalRuleRef[key] == 0 {
+			toClose = append(toClose, rule)
+			delete(fw.globalRuleRef, key)
+		}
+	}
+	// Open and close the rules.
 	if len(toOpen) > 0 {
-		if err := fw.environ.OpenPorts(toOpen); err != nil {
+		if err := fw.openGlobalIngressRules(toOpen); err != nil {
 			// TODO(mue) Add local retry logic.
 			return err
 		}
-		network.SortPortRanges(toOpen)
-		logger.Infof("opened port ranges %v in environment", toOpen)
+		network.SortIngressRules(toOpen)
+		logger.Infof("opened ingress rules %v in environment", toOpen)
 	}
 	if len(toClose) > 0 {
-		if err := fw.environ.ClosePorts(toClose); err != nil {
+		if err := fw.closeGlobalIngressRules(toClose); err != nil {
 			// TODO(mue) Add local retry logic.
 			return err
 		}
-		network.SortPortRanges(toClose)
-		logger.Infof("closed port ranges %v in environment", toClose)
+		network.SortIngressRules(toClose)
+		logger.Infof("closed ingress rules %v in environment", toClose)
 	}
 	return nil
 }
 
-// flushInstancePorts opens and closes ports global on the machine.
-func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose []network.PortRange) error {
+// flushInstanceIngressRules opens and closes ingress rules on the
+// machine's instance.
+func (fw *Firewaller) flushInstanceIngressRules(machined *machineData, toOpen, toClose []network.IngressRule) error {
 	// If there's nothing to do, do nothing.
 	// This is important because when a machine is first created,
 	// it will have no instance id but also no open ports -
@@ -641,26 +829,126 @@ func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose
 	if err != nil {
 		return err
 	}
-	// Open and close the ports.
+	// Open and close the rules.
 	if len(toOpen) > 0 {
-		if err := instances[0].OpenPorts(machineId, toOpen); err != nil {
+		if err := openInstanceIngressRules(instances[0], machineId, toOpen); err != nil {
 			// TODO(mue) Add local retry logic.
 			return err
 		}
-		network.SortPortRanges(toOpen)
-		logger.Infof("opened port ranges %v on %q", toOpen, machined.tag)
+		network.SortIngressRules(toOpen)
+		logger.Infof("opened ingress rules %v on %q", toOpen, machined.tag)
 	}
 	if len(toClose) > 0 {
-		if err := instances[0].ClosePorts(machineId, toClose); err != nil {
+		if err := closeInstanceIngressRules(instances[0], machineId, toClose); err != nil {
 			// TODO(mue) Add local retry logic.
 			return err
 		}
-		network.SortPortRanges(toClose)
-		logger.Infof("closed port ranges %v on %q", toClose, machined.tag)
+		network.SortIngressRules(toClose)
+		logger.Infof("closed ingress rules %v on %q", toClose, machined.tag)
 	}
 	return nil
 }
 
+// globalIngressRules returns the ingress rules opened for the whole
+// environment. If the environment cannot restrict the source addresses
+// of ingress traffic, the rules permit traffic from any address.
+func (fw *Firewaller) globalIngressRules() ([]network.IngressRule, error) {
+	if env, ok := fw.environ.(environs.IngressRuleFirewaller); ok {
+		return env.IngressRules()
+	}
+	ports, err := fw.environ.Ports()
+	if err != nil {
+		return nil, err
+	}
+	return network.NewOpenIngressRules(ports), nil
+}
+
+// openGlobalIngressRules opens the given ingress rules for the whole
+// environment.
+func (fw *Firewaller) openGlobalIngressRules(rules []network.IngressRule) error {
+	if env, ok := fw.environ.(environs.IngressRuleFirewaller); ok {
+		return env.OpenIngressRules(rules)
+	}
+	ports := openToAllPortRanges(rules, true)
+	if len(ports) == 0 {
+		return nil
+	}
+	return fw.environ.OpenPorts(ports)
+}
+
+// closeGlobalIngressRules closes the given ingress rules for the whole
+// environment.
+func (fw *Firewaller) closeGlobalIngressRules(rules []network.IngressRule) error {
+	if env, ok := fw.environ.(environs.IngressRuleFirewaller); ok {
+		return env.CloseIngressRules(rules)
+	}
+	ports := openToAllPortRanges(rules, false)
+	if len(ports) == 0 {
+		return nil
+	}
+	return fw.environ.ClosePorts(ports)
+}
+
+// instanceIngressRules returns the ingress rules opened on the given
+// instance. If the instance cannot restrict the source addresses of
+// ingress traffic, the rules permit traffic from any address.
+func instanceIngressRules(inst instance.Instance, machineId string) ([]network.IngressRule, error) {
+	if fw, ok := inst.(instance.IngressRuleFirewaller); ok {
+		return fw.IngressRules(machineId)
+	}
+	ports, err := inst.Ports(machineId)
+	if err != nil {
+		return nil, err
+	}
+	return network.NewOpenIngressRules(ports), nil
+}
+
+// openInstanceIngressRules opens the given ingress rules on the given
+// instance.
+func openInstanceIngressRules(inst instance.Instance, machineId string, rules []network.IngressRule) error {
+	if fw, ok := inst.(instance.IngressRuleFirewaller); ok {
+		return fw.OpenIngressRules(machineId, rules)
+	}
+	ports := openToAllPortRanges(rules, true)
+	if len(ports) == 0 {
+		return nil
+	}
+	return inst.OpenPorts(machineId, ports)
+}
+
+// closeInstanceIngressRules closes the given ingress rules on the given
+// instance.
+func closeInstanceIngressRules(inst instance.Instance, machineId string, rules []network.IngressRule) error {
+	if fw, ok := inst.(instance.IngressRuleFirewaller); ok {
+		return fw.CloseIngressRules(machineId, rules)
+	}
+	ports := openToAllPortRanges(rules, false)
+	if len(ports) == 0 {
+		return nil
+	}
+	return inst.ClosePorts(machineId, ports)
+}
+
+// openToAllPortRanges returns the port ranges of those of the given
+// ingress rules that permit traffic from any address, for use with
+// providers that cannot restrict the source addresses of ingress
+// traffic. Such providers never have the other rules opened; if
+// warn is true, a warning is logged for each of them.
+func openToAllPortRanges(rules []network.IngressRule, warn bool) []network.PortRange {
+	var ports []network.PortRange
+	for _, rule := range rules {
+		if rule.IsOpenToAll() {
+			ports = append(ports, rule.PortRange)
+		} else if warn {
+			logger.Warningf(
+				"not opening ingress rule %v: provider cannot restrict ingress source addresses",
+				rule,
+			)
+		}
+	}
+	return ports
+}
+
 // machineLifeChanged starts watching new machines when the firewaller
 // is starting, or when new machines come to life, and stops watching
 // machines that are dying.
@@ -699,6 +987,7 @@ func (fw *Firewaller) forgetMachine(machined *machineData) error {
 	// watch loop has stopped before we nuke the last data and return.
 	worker.Stop(machined)
 	delete(fw.machineds, machined.tag)
+	delete(fw.egressPending, machined.tag)
 	logger.Debugf("stopped watching %q", machined.tag)
 	return nil
 }
@@ -751,13 +1040,21 @@ type unitsChange struct {
 
 // machineData holds machine details and watches units added or removed.
 type machineData struct {
-	catacomb    catacomb.Catacomb
-	fw          *Firewaller
-	tag         names.MachineTag
-	unitds      map[names.UnitTag]*unitData
-	openedPorts []network.PortRange
+	catacomb     catacomb.Catacomb
+	fw           *Firewaller
+	tag          names.MachineTag
+	unitds       map[names.UnitTag]*unitData
+	ingressRules []network.IngressRule
 	// ports defined by units on this machine
 	definedPorts map[network.PortRange]names.UnitTag
+	// egress rules last set on the machine's instance,
+	// if egressRulesSet is true
+	egressRules    []network.EgressRule
+	egressRulesSet bool
+	// firewall drift last recorded against the machine,
+	// if firewallDriftSet is true
+	firewallDrift    string
+	firewallDriftSet bool
 }
 
 func (md *machineData) machine() (*firewaller.Machine, error) {
@@ -805,23 +1102,56 @@ type unitData struct {
 	machined *machineData
 }
 
-// exposedChange contains the changed exposed flag for one specific service.
+// exposedChange contains the changed exposed flag, source address
+// ranges, relation ingress address ranges and egress rules for one
+// specific service.
 type exposedChange struct {
-	serviced *serviceData
-	exposed  bool
+	serviced      *serviceData
+	exposed       bool
+	sourceCIDRs   []string
+	relationCIDRs []string
+	egressRules   []network.EgressRule
 }
 
 // serviceData holds service details and watches exposure changes.
 type serviceData struct {
-	catacomb    catacomb.Catacomb
-	fw          *Firewaller
-	application *firewaller.Application
-	exposed     bool
-	unitds      map[names.UnitTag]*unitData
+	catacomb      catacomb.Catacomb
+	fw            *Firewaller
+	application   *firewaller.Application
+	exposed       bool
+	sourceCIDRs   []string
+	relationCIDRs []string
+	egressRules   []network.EgressRule
+	unitds        map[names.UnitTag]*unitData
 }
 
-// watchLoop watches the service's exposed flag for changes.
-func (sd *serviceData) watchLoop(exposed bool) error {
+// ingressRule returns the ingress rule for the given port range
+// opened by one of the service's units, and whether or not the
+// rule should be opened. Units of remote applications related
+// to the service may access the port whether or not the service
+// is exposed.
+func (sd *serviceData) ingressRule(portRange network.PortRange) (network.IngressRule, bool) {
+	cidrs := set.NewStrings(sd.relationCIDRs...)
+	if sd.exposed {
+		sources := set.NewStrings(sd.sourceCIDRs...)
+		if sources.Contains(network.OpenToAllCIDR) {
+			return network.NewIngressRule(portRange), true
+		}
+		cidrs = cidrs.Union(sources)
+	}
+	if cidrs.IsEmpty() {
+		// The service is not exposed, or is exposed only to
+		// spaces that have no subnets, and has no remote units
+		// related to it.
+		return network.IngressRule{}, false
+	}
+	return network.NewIngressRule(portRange, cidrs.SortedValues()...), true
+}
+
+// watchLoop watches the service's exposed flag, source address
+// ranges, relation ingress address ranges and egress rules for
+// changes.
+func (sd *serviceData) watchLoop(exposed bool, sourceCIDRs, relationCIDRs []string, egressRules []network.EgressRule) error {
 	serviceWatcher, err := sd.application.Watch()
 	if err != nil {
 		return errors.Trace(err)
@@ -847,13 +1177,27 @@ func (sd *serviceData) watchLoop(exposed bool) error {
 			if err != nil {
 				return errors.Trace(err)
 			}
-			if change == exposed {
+			changeCIDRs, err := sd.application.ExposedSourceCIDRs()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			changeRelationCIDRs, err := sd.application.RelationIngressCIDRs()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			changeEgressRules, err := sd.application.EgressRules()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if change == exposed && sameCIDRs(changeCIDRs, sourceCIDRs) &&
+				sameCIDRs(changeRelationCIDRs, relationCIDRs) &&
+				sameEgressRules(changeEgressRules, egressRules) {
 				continue
 			}
 
-			exposed = change
+			exposed, sourceCIDRs, relationCIDRs, egressRules = change, changeCIDRs, changeRelationCIDRs, changeEgressRules
 			select {
-			case sd.fw.exposedChange <- &exposedChange{sd, change}:
+			case sd.fw.exposedChange <- &exposedChange{sd, change, changeCIDRs, changeRelationCIDRs, changeEgressRules}:
 			case <-sd.catacomb.Dying():
 				return sd.catacomb.ErrDying()
 			}
@@ -871,12 +1215,40 @@ func (sd *serviceData) Wait() error {
 	return sd.catacomb.Wait()
 }
 
-// diffRanges returns all the port rangess that exist in A but not B.
-func diffRanges(A, B []network.PortRange) (missing []network.PortRange) {
+// sameCIDRs reports whether the two sorted lists of
+// CIDRs are the same.
+func sameCIDRs(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// sameEgressRules reports whether the two sorted lists of
+// egress rules are the same.
+func sameEgressRules(a, b []network.EgressRule) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].String() != b[i].String() {
+			return false
+		}
+	}
+	return true
+}
+
+// diffRules returns all the ingress rules that exist in A but not B.
+func diffRules(A, B []network.IngressRule) (missing []network.IngressRule) {
 next:
 	for _, a := range A {
 		for _, b := range B {
-			if a == b {
+			if a.String() == b.String() {
 				continue next
 			}
 		}
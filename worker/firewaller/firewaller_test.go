@@ -13,6 +13,7 @@ import (
 
 	"github.com/juju/juju/api"
 	apifirewaller "github.com/juju/juju/api/firewaller"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/juju"
@@ -114,6 +115,103 @@ func (s *firewallerBaseSuite) assertEnvironPorts(c *gc.C, expected []network.Por
 	}
 }
 
+// assertIngressRules retrieves the ingress rules of the instance and
+// compares them to the expected.
+func (s *firewallerBaseSuite) assertIngressRules(c *gc.C, inst instance.Instance, machineId string, expected []network.IngressRule) {
+	fwInst, ok := inst.(instance.IngressRuleFirewaller)
+	c.Assert(ok, jc.IsTrue)
+	s.waitForIngressRules(c, func() ([]network.IngressRule, error) {
+		return fwInst.IngressRules(machineId)
+	}, expected)
+}
+
+// assertEnvironIngressRules retrieves the ingress rules of the
+// environment and compares them to the expected.
+func (s *firewallerBaseSuite) assertEnvironIngressRules(c *gc.C, expected []network.IngressRule) {
+	fwEnv, ok := s.Environ.(environs.IngressRuleFirewaller)
+	c.Assert(ok, jc.IsTrue)
+	s.waitForIngressRules(c, fwEnv.IngressRules, expected)
+}
+
+// assertEgressRules retrieves the egress rules of the given
+// instance and compares them to the expected.
+func (s *firewallerBaseSuite) assertEgressRules(c *gc.C, inst instance.Instance, machineId string, expected []network.EgressRule) {
+	fwEnv, ok := s.Environ.(environs.EgressFirewaller)
+	c.Assert(ok, jc.IsTrue)
+	s.BackingState.StartSync()
+	start := time.Now()
+	for {
+		got, err := fwEnv.EgressRules(machineId, inst.Id())
+		if err != nil {
+			c.Fatal(err)
+			return
+		}
+		network.SortEgressRules(got)
+		network.SortEgressRules(expected)
+		if len(got) == 0 && len(expected) == 0 || reflect.DeepEqual(got, expected) {
+			c.Succeed()
+			return
+		}
+		if time.Since(start) > coretesting.LongWait {
+			c.Fatalf("timed out: expected %v; got %v", expected, got)
+			return
+		}
+		time.Sleep(coretesting.ShortWait)
+	}
+}
+
+func (s *firewallerBaseSuite) waitForIngressRules(c *gc.C, get func() ([]network.IngressRule, error), expected []network.IngressRule) {
+	s.BackingState.StartSync()
+	start := time.Now()
+	for {
+		got, err := get()
+		if err != nil {
+			c.Fatal(err)
+			return
+		}
+		network.SortIngressRules(got)
+		network.SortIngressRules(expected)
+		if reflect.DeepEqual(got, expected) {
+			c.Succeed()
+			return
+		}
+		if time.Since(start) > coretesting.LongWait {
+			c.Fatalf("timed out: expected %v; got %v", expected, got)
+			return
+		}
+		time.Sleep(coretesting.ShortWait)
+	}
+}
+
+// assertFirewallDrift waits for the firewall drift recorded against
+// the machine to match the expected.
+func (s *firewallerBaseSuite) assertFirewallDrift(c *gc.C, m *state.Machine, expected state.FirewallDrift) {
+	s.BackingState.StartSync()
+	start := time.Now()
+	for {
+		err := m.Refresh()
+		c.Assert(err, jc.ErrorIsNil)
+		got := m.FirewallDrift()
+		if reflect.DeepEqual(got, expected) {
+			c.Succeed()
+			return
+		}
+		if time.Since(start) > coretesting.LongWait {
+			c.Fatalf("timed out: expected %+v; got %+v", expected, got)
+			return
+		}
+		time.Sleep(coretesting.ShortWait)
+	}
+}
+
+// patchDriftCheck makes the firewaller check for firewall drift
+// without waiting for the configured interval.
+func (s *firewallerBaseSuite) patchDriftCheck() {
+	s.PatchValue(firewaller.DriftCheckAfter, func(time.Duration) <-chan time.Time {
+		return time.After(coretesting.ShortWait)
+	})
+}
+
 func (s *firewallerBaseSuite) addUnit(c *gc.C, svc *state.Application) (*state.Unit, *state.Machine) {
 	units, err := juju.AddUnits(s.State, svc, 1, nil)
 	c.Assert(err, jc.ErrorIsNil)
@@ -403,6 +501,252 @@ func (s *InstanceModeSuite) TestSetClearExposedService(c *gc.C) {
 	s.assertPorts(c, inst, m.Id(), nil)
 }
 
+func (s *InstanceModeSuite) TestExposedToCIDRs(c *gc.C) {
+	fw, err := firewaller.NewFirewaller(s.firewaller)
+	c.Assert(err, jc.ErrorIsNil)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	svc := s.AddTestingService(c, "wordpress", s.charm)
+	err = svc.SetExposedTo([]string{"10.0.0.0/8", "192.168.1.0/24"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	u, m := s.addUnit(c, svc)
+	inst := s.startInstance(c, m)
+	err = u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertIngressRules(c, inst, m.Id(), []network.IngressRule{
+		network.NewIngressRule(network.PortRange{80, 80, "tcp"}, "10.0.0.0/8", "192.168.1.0/24"),
+	})
+
+	// Changing the source ranges replaces the rules.
+	err = svc.SetExposedTo([]string{"10.0.0.0/8"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertIngressRules(c, inst, m.Id(), []network.IngressRule{
+		network.NewIngressRule(network.PortRange{80, 80, "tcp"}, "10.0.0.0/8"),
+	})
+
+	// Exposing without restrictions opens the ports to all.
+	err = svc.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertIngressRules(c, inst, m.Id(), []network.IngressRule{
+		network.NewIngressRule(network.PortRange{80, 80, "tcp"}),
+	})
+
+	err = svc.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertIngressRules(c, inst, m.Id(), nil)
+}
+
+func (s *InstanceModeSuite) TestExposedToSpaces(c *gc.C) {
+	_, err := s.State.AddSubnet(state.SubnetInfo{CIDR: "10.1.0.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSpace("internal", "", []string{"10.1.0.0/24"}, false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	fw, err := firewaller.NewFirewaller(s.firewaller)
+	c.Assert(err, jc.ErrorIsNil)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	svc := s.AddTestingService(c, "wordpress", s.charm)
+	err = svc.SetExposedTo(nil, []string{"internal"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	u, m := s.addUnit(c, svc)
+	inst := s.startInstance(c, m)
+	err = u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertIngressRules(c, inst, m.Id(), []network.IngressRule{
+		network.NewIngressRule(network.PortRange{80, 80, "tcp"}, "10.1.0.0/24"),
+	})
+
+	// Subnets added to the space are picked up without the
+	// application changing.
+	_, err = s.State.AddSubnet(state.SubnetInfo{CIDR: "10.2.0.0/24", SpaceName: "internal"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertIngressRules(c, inst, m.Id(), []network.IngressRule{
+		network.NewIngressRule(network.PortRange{80, 80, "tcp"}, "10.1.0.0/24", "10.2.0.0/24"),
+	})
+}
+
+func (s *InstanceModeSuite) TestRelationIngress(c *gc.C) {
+	fw, err := firewaller.NewFirewaller(s.firewaller)
+	c.Assert(err, jc.ErrorIsNil)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	svc := s.AddTestingService(c, "wordpress", s.charm)
+	u, m := s.addUnit(c, svc)
+	inst := s.startInstance(c, m)
+	err = u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertIngressRules(c, inst, m.Id(), nil)
+
+	// Units of related remote applications may access the
+	// ports of an unexposed service.
+	err = svc.SetRelationIngressCIDR("remote-mysql/0", "8.8.8.8/32")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertIngressRules(c, inst, m.Id(), []network.IngressRule{
+		network.NewIngressRule(network.PortRange{80, 80, "tcp"}, "8.8.8.8/32"),
+	})
+
+	// Exposing the service adds its source ranges.
+	err = svc.SetExposedTo([]string{"10.0.0.0/8"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertIngressRules(c, inst, m.Id(), []network.IngressRule{
+		network.NewIngressRule(network.PortRange{80, 80, "tcp"}, "10.0.0.0/8", "8.8.8.8/32"),
+	})
+
+	err = svc.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	err = svc.SetRelationIngressCIDR("remote-mysql/0", "")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertIngressRules(c, inst, m.Id(), nil)
+}
+
+func (s *InstanceModeSuite) TestEgressRules(c *gc.C) {
+	s.PatchValue(firewaller.EgressRetryDelay, coretesting.ShortWait)
+	fw, err := firewaller.NewFirewaller(s.firewaller)
+	c.Assert(err, jc.ErrorIsNil)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	svc := s.AddTestingService(c, "wordpress", s.charm)
+	err = svc.SetEgressRules([]network.EgressRule{
+		network.NewEgressRule(network.PortRange{3306, 3306, "tcp"}, "10.0.0.0/8"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The rules are set once the instance has been provisioned.
+	_, m := s.addUnit(c, svc)
+	inst := s.startInstance(c, m)
+	s.assertEgressRules(c, inst, m.Id(), []network.EgressRule{
+		network.NewEgressRule(network.PortRange{3306, 3306, "tcp"}, "10.0.0.0/8"),
+	})
+
+	// Model rules apply to all machines.
+	err = s.State.UpdateModelConfig(map[string]interface{}{
+		config.EgressRulesKey: "443/tcp",
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertEgressRules(c, inst, m.Id(), []network.EgressRule{
+		network.NewEgressRule(network.PortRange{443, 443, "tcp"}),
+		network.NewEgressRule(network.PortRange{3306, 3306, "tcp"}, "10.0.0.0/8"),
+	})
+
+	// Removing all rules lifts the restriction.
+	err = svc.SetEgressRules(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.UpdateModelConfig(nil, []string{config.EgressRulesKey}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertEgressRules(c, inst, m.Id(), nil)
+}
+
+func (s *InstanceModeSuite) TestFirewallDrift(c *gc.C) {
+	s.patchDriftCheck()
+	fw, err := firewaller.NewFirewaller(s.firewaller)
+	c.Assert(err, jc.ErrorIsNil)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	svc := s.AddTestingService(c, "wordpress", s.charm)
+	err = svc.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	u, m := s.addUnit(c, svc)
+	inst := s.startInstance(c, m)
+	err = u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	http := network.NewIngressRule(network.PortRange{80, 80, "tcp"})
+	ssh := network.NewIngressRule(network.PortRange{22, 22, "tcp"})
+	s.assertIngressRules(c, inst, m.Id(), []network.IngressRule{http})
+	s.assertFirewallDrift(c, m, state.FirewallDrift{})
+
+	// Rules changed behind the firewaller's back are reported,
+	// but left alone.
+	fwInst := inst.(instance.IngressRuleFirewaller)
+	err = fwInst.CloseIngressRules(m.Id(), []network.IngressRule{http})
+	c.Assert(err, jc.ErrorIsNil)
+	err = fwInst.OpenIngressRules(m.Id(), []network.IngressRule{ssh})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertFirewallDrift(c, m, state.FirewallDrift{
+		Missing:    []string{"80/tcp"},
+		Unexpected: []string{"22/tcp"},
+	})
+	s.assertIngressRules(c, inst, m.Id(), []network.IngressRule{ssh})
+
+	// Restoring the rules clears the drift.
+	err = fwInst.CloseIngressRules(m.Id(), []network.IngressRule{ssh})
+	c.Assert(err, jc.ErrorIsNil)
+	err = fwInst.OpenIngressRules(m.Id(), []network.IngressRule{http})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertFirewallDrift(c, m, state.FirewallDrift{})
+}
+
+func (s *InstanceModeSuite) TestFirewallDriftAutoRepair(c *gc.C) {
+	err := s.State.UpdateModelConfig(map[string]interface{}{
+		config.FirewallAutoRepairKey: true,
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.patchDriftCheck()
+	fw, err := firewaller.NewFirewaller(s.firewaller)
+	c.Assert(err, jc.ErrorIsNil)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	svc := s.AddTestingService(c, "wordpress", s.charm)
+	err = svc.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	u, m := s.addUnit(c, svc)
+	inst := s.startInstance(c, m)
+	err = u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	http := network.NewIngressRule(network.PortRange{80, 80, "tcp"})
+	ssh := network.NewIngressRule(network.PortRange{22, 22, "tcp"})
+	s.assertIngressRules(c, inst, m.Id(), []network.IngressRule{http})
+
+	// Rules changed behind the firewaller's back are put right,
+	// and no drift is recorded.
+	fwInst := inst.(instance.IngressRuleFirewaller)
+	err = fwInst.CloseIngressRules(m.Id(), []network.IngressRule{http})
+	c.Assert(err, jc.ErrorIsNil)
+	err = fwInst.OpenIngressRules(m.Id(), []network.IngressRule{ssh})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertIngressRules(c, inst, m.Id(), []network.IngressRule{http})
+	s.assertFirewallDrift(c, m, state.FirewallDrift{})
+}
+
+func (s *InstanceModeSuite) TestFirewallDriftContinuesPastBrokenInstance(c *gc.C) {
+	s.patchDriftCheck()
+	fw, err := firewaller.NewFirewaller(s.firewaller)
+	c.Assert(err, jc.ErrorIsNil)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	svc := s.AddTestingService(c, "wordpress", s.charm)
+	err = svc.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	u1, m1 := s.addUnit(c, svc)
+	inst1 := s.startInstance(c, m1)
+	err = u1.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+	u2, m2 := s.addUnit(c, svc)
+	inst2 := s.startInstance(c, m2)
+	err = u2.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	http := network.NewIngressRule(network.PortRange{80, 80, "tcp"})
+	ssh := network.NewIngressRule(network.PortRange{22, 22, "tcp"})
+	s.assertIngressRules(c, inst1, m1.Id(), []network.IngressRule{http})
+	s.assertIngressRules(c, inst2, m2.Id(), []network.IngressRule{http})
+
+	// Failing to read the rules of one instance does not stop the
+	// drift of the others being recorded.
+	dummy.SetInstanceBroken(inst1, "IngressRules")
+	err = inst2.(instance.IngressRuleFirewaller).OpenIngressRules(m2.Id(), []network.IngressRule{ssh})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertFirewallDrift(c, m2, state.FirewallDrift{
+		Unexpected: []string{"22/tcp"},
+	})
+}
+
 func (s *InstanceModeSuite) TestRemoveUnit(c *gc.C) {
 	fw, err := firewaller.NewFirewaller(s.firewaller)
 	c.Assert(err, jc.ErrorIsNil)
@@ -584,7 +928,7 @@ func (s *InstanceModeSuite) TestStartWithStateOpenPortsBroken(c *gc.C) {
 
 	// Nothing open without firewaller.
 	s.assertPorts(c, inst, m.Id(), nil)
-	dummy.SetInstanceBroken(inst, "OpenPorts")
+	dummy.SetInstanceBroken(inst, "OpenIngressRules")
 
 	// Starting the firewaller should attempt to open the ports,
 	// and fail due to the method being broken.
@@ -597,7 +941,7 @@ func (s *InstanceModeSuite) TestStartWithStateOpenPortsBroken(c *gc.C) {
 	select {
 	case err := <-errc:
 		c.Assert(err, gc.ErrorMatches,
-			`cannot respond to units changes for "machine-1": dummyInstance.OpenPorts is broken`)
+			`cannot respond to units changes for "machine-1": dummyInstance.OpenIngressRules is broken`)
 	case <-time.After(coretesting.LongWait):
 		fw.Kill()
 		fw.Wait()
@@ -670,6 +1014,81 @@ func (s *GlobalModeSuite) TestGlobalMode(c *gc.C) {
 	s.assertEnvironPorts(c, nil)
 }
 
+func (s *GlobalModeSuite) TestExposedToCIDRs(c *gc.C) {
+	fw, err := firewaller.NewFirewaller(s.firewaller)
+	c.Assert(err, jc.ErrorIsNil)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	svc1 := s.AddTestingService(c, "wordpress", s.charm)
+	err = svc1.SetExposedTo([]string{"10.0.0.0/8"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	u1, m1 := s.addUnit(c, svc1)
+	s.startInstance(c, m1)
+	err = u1.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	svc2 := s.AddTestingService(c, "moinmoin", s.charm)
+	err = svc2.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	u2, m2 := s.addUnit(c, svc2)
+	s.startInstance(c, m2)
+	err = u2.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertEnvironIngressRules(c, []network.IngressRule{
+		network.NewIngressRule(network.PortRange{80, 80, "tcp"}),
+		network.NewIngressRule(network.PortRange{80, 80, "tcp"}, "10.0.0.0/8"),
+	})
+
+	// Unexposing one service leaves the other's rule in place.
+	err = svc2.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertEnvironIngressRules(c, []network.IngressRule{
+		network.NewIngressRule(network.PortRange{80, 80, "tcp"}, "10.0.0.0/8"),
+	})
+}
+
+func (s *GlobalModeSuite) TestFirewallDrift(c *gc.C) {
+	s.patchDriftCheck()
+	fw, err := firewaller.NewFirewaller(s.firewaller)
+	c.Assert(err, jc.ErrorIsNil)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	svc := s.AddTestingService(c, "wordpress", s.charm)
+	err = svc.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	u, m := s.addUnit(c, svc)
+	s.startInstance(c, m)
+	err = u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	http := network.NewIngressRule(network.PortRange{80, 80, "tcp"})
+	ssh := network.NewIngressRule(network.PortRange{22, 22, "tcp"})
+	s.assertEnvironIngressRules(c, []network.IngressRule{http})
+	s.assertFirewallDrift(c, m, state.FirewallDrift{})
+
+	// Rules changed behind the firewaller's back are reported
+	// against the machines they affect.
+	fwEnv := s.Environ.(environs.IngressRuleFirewaller)
+	err = fwEnv.CloseIngressRules([]network.IngressRule{http})
+	c.Assert(err, jc.ErrorIsNil)
+	err = fwEnv.OpenIngressRules([]network.IngressRule{ssh})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertFirewallDrift(c, m, state.FirewallDrift{
+		Missing:    []string{"80/tcp"},
+		Unexpected: []string{"22/tcp"},
+	})
+
+	// Enabling auto-repair puts the rules right, and clears
+	// the drift.
+	err = s.State.UpdateModelConfig(map[string]interface{}{
+		config.FirewallAutoRepairKey: true,
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertEnvironIngressRules(c, []network.IngressRule{http})
+	s.assertFirewallDrift(c, m, state.FirewallDrift{})
+}
+
 func (s *GlobalModeSuite) TestStartWithUnexposedService(c *gc.C) {
 	m, err := s.State.AddMachine("quantal", state.JobHostUnits)
 	c.Assert(err, jc.ErrorIsNil)
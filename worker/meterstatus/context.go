@@ -80,6 +80,12 @@ func (ctx *limitedContext) HasExecutionSetUnitStatus() bool { return false }
 // ResetExecutionSetUnitStatus implements runner.Context.
 func (ctx *limitedContext) ResetExecutionSetUnitStatus() {}
 
+// RecordToolCall implements runner.Context.
+func (ctx *limitedContext) RecordToolCall(name string) {}
+
+// ToolCalls implements runner.Context.
+func (ctx *limitedContext) ToolCalls() []string { return nil }
+
 // Id implements runner.Context.
 func (ctx *limitedContext) Id() string { return ctx.id }
 
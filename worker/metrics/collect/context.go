@@ -82,6 +82,12 @@ func (ctx *hookContext) HasExecutionSetUnitStatus() bool { return false }
 // ResetExecutionSetUnitStatus implements runner.Context.
 func (ctx *hookContext) ResetExecutionSetUnitStatus() {}
 
+// RecordToolCall implements runner.Context.
+func (ctx *hookContext) RecordToolCall(name string) {}
+
+// ToolCalls implements runner.Context.
+func (ctx *hookContext) ToolCalls() []string { return nil }
+
 // Id implements runner.Context.
 func (ctx *hookContext) Id() string { return ctx.id }
 
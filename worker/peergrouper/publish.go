@@ -8,12 +8,14 @@ import (
 
 	"github.com/juju/errors"
 
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 )
 
 type apiHostPortsSetter interface {
 	SetAPIHostPorts([][]network.HostPort) error
+	ModelConfig() (*config.Config, error)
 }
 
 type publisher struct {
@@ -34,10 +36,26 @@ func (pub *publisher) publishAPIServers(apiServers [][]network.HostPort, instanc
 	pub.mu.Lock()
 	defer pub.mu.Unlock()
 
+	cfg, err := pub.st.ModelConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// The address policy both filters and orders the addresses of
+	// each API server. A change of policy is only applied here when
+	// the API servers' addresses next change.
+	policy := cfg.AddressPolicy()
 	sortedAPIServers := make([][]network.HostPort, len(apiServers))
 	for i, hostPorts := range apiServers {
-		sortedAPIServers[i] = append([]network.HostPort{}, hostPorts...)
-		network.SortHostPorts(sortedAPIServers[i])
+		sortedHostPorts := append([]network.HostPort{}, hostPorts...)
+		network.SortHostPorts(sortedHostPorts)
+		sortedAPIServers[i] = policy.ApplyToHostPorts(sortedHostPorts)
+		if len(sortedAPIServers[i]) == 0 {
+			// Rather than publishing an API server that cannot
+			// be reached, ignore a policy allowing none of its
+			// addresses.
+			logger.Warningf("address policy %q allows none of API server addresses %v", policy, sortedHostPorts)
+			sortedAPIServers[i] = sortedHostPorts
+		}
 	}
 	if apiServersEqual(sortedAPIServers, pub.lastAPIServers) {
 		logger.Debugf("API host ports have not changed")
@@ -45,7 +63,7 @@ func (pub *publisher) publishAPIServers(apiServers [][]network.HostPort, instanc
 	}
 
 	// TODO(rog) publish instanceIds in environment storage.
-	err := pub.st.SetAPIHostPorts(sortedAPIServers)
+	err = pub.st.SetAPIHostPorts(sortedAPIServers)
 	if err != nil {
 		return err
 	}
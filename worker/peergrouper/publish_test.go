@@ -7,6 +7,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/testing"
 )
@@ -20,6 +21,7 @@ var _ = gc.Suite(&publishSuite{})
 type mockAPIHostPortsSetter struct {
 	calls        int
 	apiHostPorts [][]network.HostPort
+	config       *config.Config
 }
 
 func (s *mockAPIHostPortsSetter) SetAPIHostPorts(apiHostPorts [][]network.HostPort) error {
@@ -28,8 +30,12 @@ func (s *mockAPIHostPortsSetter) SetAPIHostPorts(apiHostPorts [][]network.HostPo
 	return nil
 }
 
+func (s *mockAPIHostPortsSetter) ModelConfig() (*config.Config, error) {
+	return s.config, nil
+}
+
 func (s *publishSuite) TestPublisherSetsAPIHostPortsOnce(c *gc.C) {
-	var mock mockAPIHostPortsSetter
+	mock := mockAPIHostPortsSetter{config: testing.ModelConfig(c)}
 	statePublish := newPublisher(&mock)
 
 	hostPorts1 := network.NewHostPorts(1234, "testing1.invalid", "127.0.0.1")
@@ -59,7 +65,7 @@ func (s *publishSuite) TestPublisherSortsHostPorts(c *gc.C) {
 	ipV6First := network.NewHostPorts(1234, "testing1.invalid", "::1", "127.0.0.1")
 
 	check := func(publish, expect []network.HostPort) {
-		var mock mockAPIHostPortsSetter
+		mock := mockAPIHostPortsSetter{config: testing.ModelConfig(c)}
 		statePublish := newPublisher(&mock)
 		for i := 0; i < 2; i++ {
 			err := statePublish.publishAPIServers([][]network.HostPort{publish}, nil)
@@ -73,6 +79,46 @@ func (s *publishSuite) TestPublisherSortsHostPorts(c *gc.C) {
 	check(ipV4First, ipV4First)
 }
 
+func (s *publishSuite) TestPublisherAppliesAddressPolicy(c *gc.C) {
+	hostPorts := network.NewHostPorts(1234, "testing1.invalid", "10.0.0.1", "fd00::1")
+
+	check := func(policy string, expect []network.HostPort) {
+		cfg, err := testing.ModelConfig(c).Apply(map[string]interface{}{
+			"address-policy": policy,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		mock := mockAPIHostPortsSetter{config: cfg}
+		statePublish := newPublisher(&mock)
+		err = statePublish.publishAPIServers([][]network.HostPort{hostPorts}, nil)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(mock.apiHostPorts, gc.DeepEquals, [][]network.HostPort{expect})
+	}
+
+	check("prefer-ipv6", network.NewHostPorts(1234, "testing1.invalid", "fd00::1", "10.0.0.1"))
+	check("ipv6-only", network.NewHostPorts(1234, "testing1.invalid", "fd00::1"))
+	check("ipv4-only", network.NewHostPorts(1234, "testing1.invalid", "10.0.0.1"))
+}
+
+func (s *publishSuite) TestPublisherAddressPolicyAllowsNone(c *gc.C) {
+	cfg, err := testing.ModelConfig(c).Apply(map[string]interface{}{
+		"address-policy": "ipv6-only",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	mock := mockAPIHostPortsSetter{config: cfg}
+	statePublish := newPublisher(&mock)
+
+	// The addresses of an API server none of whose addresses are
+	// allowed are published unfiltered, but sorted.
+	ipv4Only := network.NewHostPorts(1234, "127.0.0.1", "10.0.0.1")
+	dualStack := network.NewHostPorts(1234, "10.0.0.2", "fd00::2")
+	err = statePublish.publishAPIServers([][]network.HostPort{ipv4Only, dualStack}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mock.apiHostPorts, gc.DeepEquals, [][]network.HostPort{
+		network.NewHostPorts(1234, "10.0.0.1", "127.0.0.1"),
+		network.NewHostPorts(1234, "fd00::2"),
+	})
+}
+
 func (s *publishSuite) TestPublisherRejectsNoServers(c *gc.C) {
 	var mock mockAPIHostPortsSetter
 	statePublish := newPublisher(&mock)
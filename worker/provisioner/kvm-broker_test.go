@@ -258,6 +258,23 @@ func (s *kvmBrokerSuite) TestStartInstancePopulatesFallbackNetworkInfo(c *gc.C)
 	}})
 }
 
+func (s *kvmBrokerSuite) TestStartInstanceStaticIPAddressPrepareError(c *gc.C) {
+	s.api.SetErrors(
+		nil, // ContainerConfig succeeds
+		errors.NotSupportedf("container address allocation"),
+	)
+	_, err := s.broker.StartInstance(environs.StartInstanceParams{
+		Tools: coretools.List{&coretools.Tools{
+			Version: version.MustParseBinary("2.3.4-quantal-amd64"),
+			URL:     "http://tools.testing.invalid/2.3.4-quantal-amd64.tgz",
+		}},
+		InstanceConfig:  s.instanceConfig(c, "1/kvm/2"),
+		StaticIPAddress: "192.168.122.5",
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot prepare static IP address "192.168.122.5" for container "1/kvm/2": container address allocation not supported`)
+	s.assertInstances(c)
+}
+
 type kvmProvisionerSuite struct {
 	CommonProvisionerSuite
 	kvmSuite
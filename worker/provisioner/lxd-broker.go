@@ -56,7 +56,13 @@ func (broker *lxdBroker) StartInstance(args environs.StartInstanceParams) (*envi
 		args.NetworkInfo,
 		lxdLogger,
 	)
-	if err != nil {
+	if err != nil && args.StaticIPAddress != "" {
+		// The static IP address is only configured in the container
+		// through the prepared info, which the container's network
+		// config is rendered from, so without it the container would
+		// silently get an address from DHCP instead.
+		return nil, errors.Annotatef(err, "cannot prepare static IP address %q for container %q", args.StaticIPAddress, machineId)
+	} else if err != nil {
 		// It's not fatal (yet) if we couldn't pre-allocate addresses for the
 		// container.
 		logger.Warningf("failed to prepare container %q network config: %v", machineId, err)
@@ -152,6 +152,20 @@ func (s *lxdBrokerSuite) TestStartInstancePopulatesFallbackNetworkInfo(c *gc.C)
 	}})
 }
 
+func (s *lxdBrokerSuite) TestStartInstanceStaticIPAddressPrepareError(c *gc.C) {
+	s.api.SetErrors(
+		nil, // ContainerConfig succeeds
+		errors.NotSupportedf("container address allocation"),
+	)
+	_, err := s.broker.StartInstance(environs.StartInstanceParams{
+		Tools:           s.possibleTools,
+		InstanceConfig:  s.instanceConfig(c, "1/lxd/0"),
+		StaticIPAddress: "10.0.8.5",
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot prepare static IP address "10.0.8.5" for container "1/lxd/0": container address allocation not supported`)
+	s.manager.CheckNoCalls(c)
+}
+
 func (s *lxdBrokerSuite) TestStartInstanceNoHostArchTools(c *gc.C) {
 	_, err := s.broker.StartInstance(environs.StartInstanceParams{
 		Tools: coretools.List{{
@@ -620,6 +620,7 @@ func constructStartInstanceParams(
 		EndpointBindings:  endpointBindings,
 		ImageMetadata:     possibleImageMetadata,
 		StatusCallback:    machine.SetInstanceStatus,
+		StaticIPAddress:   provisioningInfo.StaticIPAddress,
 	}, nil
 }
 
@@ -780,8 +781,8 @@ func volumesToApiserver(volumes []storage.Volume) []params.Volume {
 	result := make([]params.Volume, len(volumes))
 	for i, v := range volumes {
 		result[i] = params.Volume{
-			v.Tag.String(),
-			params.VolumeInfo{
+			VolumeTag: v.Tag.String(),
+			Info: params.VolumeInfo{
 				v.VolumeId,
 				v.HardwareId,
 				v.Size,
@@ -0,0 +1,165 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remoterelations_test
+
+import (
+	"sync"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
+)
+
+type mockWatcher struct {
+	mu      sync.Mutex
+	stopped chan struct{}
+}
+
+func newMockWatcher() *mockWatcher {
+	return &mockWatcher{
+		stopped: make(chan struct{}),
+	}
+}
+
+func (w *mockWatcher) Kill() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	select {
+	case <-w.stopped:
+	default:
+		close(w.stopped)
+	}
+}
+
+func (w *mockWatcher) Wait() error {
+	<-w.stopped
+	return nil
+}
+
+type mockStringsWatcher struct {
+	*mockWatcher
+	changes chan []string
+}
+
+func newMockStringsWatcher() *mockStringsWatcher {
+	return &mockStringsWatcher{
+		mockWatcher: newMockWatcher(),
+		changes:     make(chan []string, 1),
+	}
+}
+
+func (w *mockStringsWatcher) Changes() watcher.StringsChannel {
+	return w.changes
+}
+
+type mockRelationUnitsWatcher struct {
+	*mockWatcher
+	changes chan watcher.RelationUnitsChange
+}
+
+func newMockRelationUnitsWatcher() *mockRelationUnitsWatcher {
+	return &mockRelationUnitsWatcher{
+		mockWatcher: newMockWatcher(),
+		changes:     make(chan watcher.RelationUnitsChange, 1),
+	}
+}
+
+func (w *mockRelationUnitsWatcher) Changes() watcher.RelationUnitsChannel {
+	return w.changes
+}
+
+// mockFacade implements remoterelations.Facade, reporting the
+// published changes on a channel.
+type mockFacade struct {
+	mu                   sync.Mutex
+	applications         map[string]params.RemoteApplication
+	relations            map[string]params.RemoteRelation
+	applicationsWatcher  *mockStringsWatcher
+	relationsWatchers    map[string]*mockStringsWatcher
+	relationUnitsWatcher map[string]*mockRelationUnitsWatcher
+	published            chan params.RemoteRelationChange
+}
+
+func newMockFacade() *mockFacade {
+	return &mockFacade{
+		applications:         make(map[string]params.RemoteApplication),
+		relations:            make(map[string]params.RemoteRelation),
+		applicationsWatcher:  newMockStringsWatcher(),
+		relationsWatchers:    make(map[string]*mockStringsWatcher),
+		relationUnitsWatcher: make(map[string]*mockRelationUnitsWatcher),
+		published:            make(chan params.RemoteRelationChange, 10),
+	}
+}
+
+func (f *mockFacade) WatchRemoteApplications() (watcher.StringsWatcher, error) {
+	return f.applicationsWatcher, nil
+}
+
+func (f *mockFacade) RemoteApplications(applications []string) ([]params.RemoteApplicationResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	results := make([]params.RemoteApplicationResult, len(applications))
+	for i, name := range applications {
+		if application, ok := f.applications[name]; ok {
+			results[i].Result = &application
+		} else {
+			results[i].Error = &params.Error{
+				Code:    params.CodeNotFound,
+				Message: errors.NotFoundf("remote application %q", name).Error(),
+			}
+		}
+	}
+	return results, nil
+}
+
+func (f *mockFacade) WatchRemoteApplicationRelations(application string) (watcher.StringsWatcher, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w := newMockStringsWatcher()
+	f.relationsWatchers[application] = w
+	return w, nil
+}
+
+func (f *mockFacade) relationsWatcher(application string) *mockStringsWatcher {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.relationsWatchers[application]
+}
+
+func (f *mockFacade) Relations(keys []string) ([]params.RemoteRelationResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	results := make([]params.RemoteRelationResult, len(keys))
+	for i, key := range keys {
+		if relation, ok := f.relations[key]; ok {
+			results[i].Result = &relation
+		} else {
+			results[i].Error = &params.Error{
+				Code:    params.CodeNotFound,
+				Message: errors.NotFoundf("relation %q", key).Error(),
+			}
+		}
+	}
+	return results, nil
+}
+
+func (f *mockFacade) WatchLocalRelationUnits(relationKey string) (watcher.RelationUnitsWatcher, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w := newMockRelationUnitsWatcher()
+	f.relationUnitsWatcher[relationKey] = w
+	return w, nil
+}
+
+func (f *mockFacade) relationUnitsWatcherFor(relationKey string) *mockRelationUnitsWatcher {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.relationUnitsWatcher[relationKey]
+}
+
+func (f *mockFacade) PublishLocalRelationChange(change params.RemoteRelationChange) error {
+	f.published <- change
+	return nil
+}
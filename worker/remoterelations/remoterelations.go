@@ -0,0 +1,393 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package remoterelations defines a worker that publishes changes to
+// the local side of relations with remote applications to the models
+// hosting those applications.
+package remoterelations
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.remoterelations")
+
+// errApplicationRemoved is used internally to stop the worker of a
+// remote application that has been removed.
+var errApplicationRemoved = errors.New("remote application removed")
+
+// Facade exposes the capabilities required by the worker.
+type Facade interface {
+
+	// WatchRemoteApplications returns a watcher that notifies of
+	// the addition, removal, and lifecycle changes of remote
+	// applications in the model.
+	WatchRemoteApplications() (watcher.StringsWatcher, error)
+
+	// RemoteApplications returns the details of the named remote
+	// applications.
+	RemoteApplications(applications []string) ([]params.RemoteApplicationResult, error)
+
+	// WatchRemoteApplicationRelations returns a watcher that
+	// notifies of changes to the keys of the relations in which
+	// the named remote application takes part.
+	WatchRemoteApplicationRelations(application string) (watcher.StringsWatcher, error)
+
+	// Relations returns the details of the relations with the
+	// given keys.
+	Relations(keys []string) ([]params.RemoteRelationResult, error)
+
+	// WatchLocalRelationUnits returns a watcher that notifies of
+	// changes to the local units taking part in the relation with
+	// the given key.
+	WatchLocalRelationUnits(relationKey string) (watcher.RelationUnitsWatcher, error)
+
+	// PublishLocalRelationChange publishes a change made to the
+	// local side of a relation to the model hosting the remote
+	// application.
+	PublishLocalRelationChange(params.RemoteRelationChange) error
+}
+
+// Config defines the operation of a Worker.
+type Config struct {
+	Facade Facade
+}
+
+// Validate returns an error if config cannot drive a Worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	return nil
+}
+
+// Worker publishes changes to the local side of relations with remote
+// applications. It runs a worker for each remote application, which in
+// turn runs a worker for each of the application's relations.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+
+	applications map[string]*applicationWorker
+}
+
+// New returns a Worker backed by config, or an error.
+func New(config Config) (*Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{
+		config:       config,
+		applications: make(map[string]*applicationWorker),
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Kill is defined on worker.Worker.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is defined on worker.Worker.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	applicationsWatcher, err := w.config.Facade.WatchRemoteApplications()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(applicationsWatcher); err != nil {
+		return errors.Trace(err)
+	}
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case applications, ok := <-applicationsWatcher.Changes():
+			if !ok {
+				return errors.New("remote applications watcher closed")
+			}
+			if err := w.handleApplicationsChange(applications); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+// handleApplicationsChange starts a worker for each of the named remote
+// applications that is alive and not already being handled, and forgets
+// the workers of those that have been removed.
+func (w *Worker) handleApplicationsChange(applications []string) error {
+	logger.Debugf("remote applications changed: %v", applications)
+	results, err := w.config.Facade.RemoteApplications(applications)
+	if err != nil {
+		return errors.Annotate(err, "querying remote applications")
+	}
+	for i, result := range results {
+		name := applications[i]
+		if result.Error != nil && !params.IsCodeNotFound(result.Error) {
+			return errors.Annotatef(result.Error, "querying remote application %q", name)
+		}
+		if _, ok := w.applications[name]; ok {
+			if result.Error != nil {
+				// The application has been removed along with
+				// its last relation. Its worker stops by itself
+				// once it has published the relation's removal.
+				delete(w.applications, name)
+			}
+			continue
+		}
+		if result.Error != nil || result.Result.Life != params.Alive {
+			continue
+		}
+		aw, err := newApplicationWorker(w.config.Facade, *result.Result)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := w.catacomb.Add(aw); err != nil {
+			return errors.Trace(err)
+		}
+		w.applications[name] = aw
+	}
+	return nil
+}
+
+// applicationWorker runs a worker for each of the relations of a
+// remote application.
+type applicationWorker struct {
+	catacomb    catacomb.Catacomb
+	facade      Facade
+	application params.RemoteApplication
+
+	relations map[string]*relationWorker
+}
+
+func newApplicationWorker(facade Facade, application params.RemoteApplication) (*applicationWorker, error) {
+	w := &applicationWorker{
+		facade:      facade,
+		application: application,
+		relations:   make(map[string]*relationWorker),
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Kill is defined on worker.Worker.
+func (w *applicationWorker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is defined on worker.Worker.
+func (w *applicationWorker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *applicationWorker) loop() error {
+	relationsWatcher, err := w.facade.WatchRemoteApplicationRelations(w.application.Name)
+	if params.IsCodeNotFound(err) {
+		// The application has been removed since we saw it.
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(relationsWatcher); err != nil {
+		return errors.Trace(err)
+	}
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case keys, ok := <-relationsWatcher.Changes():
+			if !ok {
+				return errors.New("relations watcher closed")
+			}
+			err := w.handleRelationsChange(keys)
+			if err == errApplicationRemoved {
+				return nil
+			} else if err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+// handleRelationsChange starts a worker for each new relation with the
+// keys supplied, and publishes the lifecycle changes of known ones.
+func (w *applicationWorker) handleRelationsChange(keys []string) error {
+	logger.Debugf("relations of remote application %q changed: %v", w.application.Name, keys)
+	results, err := w.facade.Relations(keys)
+	if err != nil {
+		return errors.Annotate(err, "querying relations")
+	}
+	for i, result := range results {
+		key := keys[i]
+		if result.Error != nil && !params.IsCodeNotFound(result.Error) {
+			return errors.Annotatef(result.Error, "querying relation %q", key)
+		}
+		existing, known := w.relations[key]
+		if result.Error != nil {
+			// The relation has been removed: make sure the
+			// counterpart relation is removed too. The units
+			// still in scope there are departed by the API
+			// server.
+			if !known {
+				continue
+			}
+			delete(w.relations, key)
+			if err := worker.Stop(existing); err != nil {
+				return errors.Trace(err)
+			}
+			relation := existing.relation
+			relation.Life = params.Dead
+			if err := w.publish(relation, nil, nil); err != nil {
+				return errors.Trace(err)
+			}
+			if len(w.relations) == 0 {
+				if removed, err := w.applicationRemoved(); err != nil {
+					return errors.Trace(err)
+				} else if removed {
+					return errApplicationRemoved
+				}
+			}
+			continue
+		}
+		relation := *result.Result
+		if !known {
+			rw, err := newRelationWorker(w, relation)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if err := w.catacomb.Add(rw); err != nil {
+				return errors.Trace(err)
+			}
+			w.relations[key] = rw
+		}
+		if relation.Life != params.Alive {
+			if err := w.publish(relation, nil, nil); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+	return nil
+}
+
+// applicationRemoved returns whether the remote application has been
+// removed from the model.
+func (w *applicationWorker) applicationRemoved() (bool, error) {
+	results, err := w.facade.RemoteApplications([]string{w.application.Name})
+	if err != nil {
+		return false, errors.Annotate(err, "querying remote application")
+	}
+	if err := results[0].Error; err != nil {
+		if params.IsCodeNotFound(err) {
+			return true, nil
+		}
+		return false, errors.Annotate(err, "querying remote application")
+	}
+	return false, nil
+}
+
+// publish publishes a change to the local side of the relation to
+// the model hosting the remote application.
+func (w *applicationWorker) publish(relation params.RemoteRelation, changed, departed []string) error {
+	change := params.RemoteRelationChange{
+		Relation:          relation,
+		RemoteApplication: w.application,
+		ChangedUnits:      changed,
+		DepartedUnits:     departed,
+	}
+	if err := w.facade.PublishLocalRelationChange(change); err != nil {
+		return errors.Annotatef(err, "publishing change to relation %q", relation.Key)
+	}
+	return nil
+}
+
+// relationWorker publishes changes to the local units taking part in
+// a relation with a remote application.
+type relationWorker struct {
+	catacomb    catacomb.Catacomb
+	application *applicationWorker
+	relation    params.RemoteRelation
+}
+
+func newRelationWorker(application *applicationWorker, relation params.RemoteRelation) (*relationWorker, error) {
+	w := &relationWorker{
+		application: application,
+		relation:    relation,
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Kill is defined on worker.Worker.
+func (w *relationWorker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is defined on worker.Worker.
+func (w *relationWorker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *relationWorker) loop() error {
+	unitsWatcher, err := w.application.facade.WatchLocalRelationUnits(w.relation.Key)
+	if params.IsCodeNotFound(err) {
+		// The relation has been removed since we saw it.
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(unitsWatcher); err != nil {
+		return errors.Trace(err)
+	}
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case change, ok := <-unitsWatcher.Changes():
+			if !ok {
+				return errors.New("relation units watcher closed")
+			}
+			var changed []string
+			for unitName := range change.Changed {
+				changed = append(changed, unitName)
+			}
+			sort.Strings(changed)
+			if len(changed)+len(change.Departed) == 0 {
+				continue
+			}
+			if err := w.application.publish(w.relation, changed, change.Departed); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
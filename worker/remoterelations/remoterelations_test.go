@@ -0,0 +1,171 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remoterelations_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker/remoterelations"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type remoteRelationsSuite struct {
+	testing.IsolationSuite
+	facade      *mockFacade
+	application params.RemoteApplication
+	relation    params.RemoteRelation
+}
+
+var _ = gc.Suite(&remoteRelationsSuite{})
+
+func (s *remoteRelationsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.facade = newMockFacade()
+	s.application = params.RemoteApplication{
+		Name:                  "hosted-mysql",
+		SourceModelTag:        coretesting.ModelTag.String(),
+		SourceApplicationName: "mysql",
+		Life:                  params.Alive,
+	}
+	s.relation = params.RemoteRelation{
+		Id:                    1,
+		Key:                   "wordpress:db hosted-mysql:server",
+		Life:                  params.Alive,
+		ApplicationName:       "wordpress",
+		Endpoint:              "db",
+		RemoteApplicationName: "hosted-mysql",
+		RemoteEndpoint:        "server",
+	}
+	s.facade.applications["hosted-mysql"] = s.application
+	s.facade.relations[s.relation.Key] = s.relation
+}
+
+func (s *remoteRelationsSuite) TestValidate(c *gc.C) {
+	_, err := remoterelations.New(remoterelations.Config{})
+	c.Assert(err, gc.ErrorMatches, "nil Facade not valid")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *remoteRelationsSuite) TestPublishesRelationChanges(c *gc.C) {
+	w, err := remoterelations.New(remoterelations.Config{Facade: s.facade})
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.facade.applicationsWatcher.changes <- []string{"hosted-mysql"}
+	relationsWatcher := s.waitForRelationsWatcher(c, "hosted-mysql")
+	relationsWatcher.changes <- []string{s.relation.Key}
+	unitsWatcher := s.waitForRelationUnitsWatcher(c, s.relation.Key)
+
+	unitsWatcher.changes <- watcher.RelationUnitsChange{
+		Changed: map[string]watcher.UnitSettings{
+			"wordpress/1": {Version: 1},
+			"wordpress/0": {Version: 1},
+		},
+	}
+	s.assertPublished(c, params.RemoteRelationChange{
+		Relation:          s.relation,
+		RemoteApplication: s.application,
+		ChangedUnits:      []string{"wordpress/0", "wordpress/1"},
+	})
+
+	unitsWatcher.changes <- watcher.RelationUnitsChange{
+		Departed: []string{"wordpress/1"},
+	}
+	s.assertPublished(c, params.RemoteRelationChange{
+		Relation:          s.relation,
+		RemoteApplication: s.application,
+		DepartedUnits:     []string{"wordpress/1"},
+	})
+
+	// When the relation is removed, along with the remote application,
+	// the removal is published, and the remote application's worker
+	// stops. The API server departs the units remaining in scope.
+	s.facade.mu.Lock()
+	delete(s.facade.relations, s.relation.Key)
+	delete(s.facade.applications, "hosted-mysql")
+	s.facade.mu.Unlock()
+	relationsWatcher.changes <- []string{s.relation.Key}
+	dead := s.relation
+	dead.Life = params.Dead
+	s.assertPublished(c, params.RemoteRelationChange{
+		Relation:          dead,
+		RemoteApplication: s.application,
+	})
+	workertest.CheckKilled(c, unitsWatcher)
+	workertest.CheckKilled(c, relationsWatcher)
+}
+
+func (s *remoteRelationsSuite) TestPublishesDyingRelation(c *gc.C) {
+	w, err := remoterelations.New(remoterelations.Config{Facade: s.facade})
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.facade.applicationsWatcher.changes <- []string{"hosted-mysql"}
+	relationsWatcher := s.waitForRelationsWatcher(c, "hosted-mysql")
+	dying := s.relation
+	dying.Life = params.Dying
+	s.facade.mu.Lock()
+	s.facade.relations[s.relation.Key] = dying
+	s.facade.mu.Unlock()
+	relationsWatcher.changes <- []string{s.relation.Key}
+	s.assertPublished(c, params.RemoteRelationChange{
+		Relation:          dying,
+		RemoteApplication: s.application,
+	})
+}
+
+func (s *remoteRelationsSuite) TestIgnoresDeadApplications(c *gc.C) {
+	dead := s.application
+	dead.Life = params.Dead
+	s.facade.applications["hosted-mysql"] = dead
+	w, err := remoterelations.New(remoterelations.Config{Facade: s.facade})
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.facade.applicationsWatcher.changes <- []string{"hosted-mysql", "missing"}
+	select {
+	case s.facade.applicationsWatcher.changes <- nil:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for worker to handle change")
+	}
+	workertest.CheckAlive(c, w)
+	c.Assert(s.facade.relationsWatcher("hosted-mysql"), gc.IsNil)
+}
+
+func (s *remoteRelationsSuite) waitForRelationsWatcher(c *gc.C, application string) *mockStringsWatcher {
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if w := s.facade.relationsWatcher(application); w != nil {
+			return w
+		}
+	}
+	c.Fatalf("timed out waiting for relations watcher")
+	return nil
+}
+
+func (s *remoteRelationsSuite) waitForRelationUnitsWatcher(c *gc.C, key string) *mockRelationUnitsWatcher {
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if w := s.facade.relationUnitsWatcherFor(key); w != nil {
+			return w
+		}
+	}
+	c.Fatalf("timed out waiting for relation units watcher")
+	return nil
+}
+
+func (s *remoteRelationsSuite) assertPublished(c *gc.C, expect params.RemoteRelationChange) {
+	select {
+	case change := <-s.facade.published:
+		c.Assert(change, jc.DeepEquals, expect)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for change to be published")
+	}
+}
@@ -0,0 +1,28 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remoterelations
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/remoterelations"
+	"github.com/juju/juju/worker"
+)
+
+// NewFacade creates a Facade from a base.APICaller.
+// It's a sensible value for ManifoldConfig.NewFacade.
+func NewFacade(apiCaller base.APICaller) (Facade, error) {
+	return remoterelations.NewState(apiCaller), nil
+}
+
+// NewWorker creates a remoterelations worker.
+// It's a sensible value for ManifoldConfig.NewWorker.
+func NewWorker(config Config) (worker.Worker, error) {
+	w, err := New(config)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
@@ -86,6 +86,9 @@ func filesystemAttachmentsChanged(ctx *context, watcherIds []watcher.MachineStor
 	// Get filesystem information for alive and dying filesystem attachments, so
 	// we can attach/detach.
 	ids = append(alive, dying...)
+	if err := refreshAttachedFilesystems(ctx, ids); err != nil {
+		return errors.Trace(err)
+	}
 	filesystemAttachmentResults, err := ctx.config.Filesystems.FilesystemAttachments(ids)
 	if err != nil {
 		return errors.Annotatef(err, "getting filesystem attachment information")
@@ -106,6 +109,56 @@ func filesystemAttachmentsChanged(ctx *context, watcherIds []watcher.MachineStor
 	return nil
 }
 
+// refreshAttachedFilesystems obtains the details of the attached filesystems
+// that have not yet been seen by a machine-scoped provisioner. Filesystems
+// scoped to the model, but backed by volumes, are managed by the machine
+// that they are attached to; the machine's filesystem watcher does not
+// report them, so they are only learned of through their attachments.
+func refreshAttachedFilesystems(ctx *context, ids []params.MachineStorageId) error {
+	if !isMachineScoped(ctx) {
+		return nil
+	}
+	var tags []names.FilesystemTag
+	for _, id := range ids {
+		tag, err := names.ParseFilesystemTag(id.AttachmentTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if _, ok := names.FilesystemMachine(tag); ok {
+			// Machine-scoped filesystems are reported
+			// by the machine's filesystem watcher.
+			continue
+		}
+		if _, ok := ctx.filesystems[tag]; ok {
+			continue
+		}
+		tags = append(tags, tag)
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+	filesystemResults, err := ctx.config.Filesystems.Filesystems(tags)
+	if err != nil {
+		return errors.Annotatef(err, "getting filesystem information")
+	}
+	for i, result := range filesystemResults {
+		if result.Error != nil {
+			return errors.Annotatef(
+				result.Error, "getting filesystem information for filesystem %q", tags[i].Id(),
+			)
+		}
+		filesystem, err := filesystemFromParams(result.Result)
+		if err != nil {
+			return errors.Annotate(err, "getting filesystem info")
+		}
+		updateFilesystem(ctx, filesystem)
+		if filesystem.Volume != (names.VolumeTag{}) {
+			maybeAddPendingVolumeBlockDevice(ctx, filesystem.Volume)
+		}
+	}
+	return nil
+}
+
 // processDyingFilesystems processes the FilesystemResults for Dying filesystems,
 // removing them from provisioning-pending as necessary.
 func processDyingFilesystems(ctx *context, tags []names.FilesystemTag, filesystemResults []params.FilesystemResult) error {
@@ -206,6 +259,22 @@ func processDeadFilesystems(ctx *context, tags []names.FilesystemTag, filesystem
 	var remove []names.Tag
 	for i, result := range filesystemResults {
 		tag := tags[i]
+		if result.Error == nil && result.Result.Releasing {
+			// The filesystem is being released from the model,
+			// so we leave it intact in the cloud and just remove
+			// it from state.
+			logger.Debugf("filesystem %s is being released, queuing for removal", tag.Id())
+			remove = append(remove, tag)
+			continue
+		}
+		if result.Error == nil && result.Result.VolumeTag != "" && !isMachineScoped(ctx) {
+			// The filesystem is model-scoped and backed by a
+			// volume, which is destroyed separately; there is
+			// nothing to deprovision on the machine.
+			logger.Debugf("filesystem %s is volume-backed, queuing for removal", tag.Id())
+			remove = append(remove, tag)
+			continue
+		}
 		if result.Error == nil {
 			logger.Debugf("filesystem %s is provisioned, queuing for deprovisioning", tag.Id())
 			filesystem, err := filesystemFromParams(result.Result)
@@ -292,7 +361,7 @@ func processAliveFilesystems(ctx *context, tags []names.FilesystemTag, filesyste
 				return errors.Annotate(err, "getting filesystem info")
 			}
 			updateFilesystem(ctx, filesystem)
-			if filesystem.Volume != (names.VolumeTag{}) {
+			if filesystem.Volume != (names.VolumeTag{}) && isMachineScoped(ctx) {
 				// Ensure that volume-backed filesystems' block
 				// devices are present even after creating the
 				// filesystem, so that attachments can be made.
@@ -322,6 +391,12 @@ func processAliveFilesystems(ctx *context, tags []names.FilesystemTag, filesyste
 	return nil
 }
 
+// isMachineScoped reports whether the provisioner is scoped to a machine.
+func isMachineScoped(ctx *context) bool {
+	_, ok := ctx.config.Scope.(names.MachineTag)
+	return ok
+}
+
 func maybeAddPendingVolumeBlockDevice(ctx *context, v names.VolumeTag) {
 	if _, ok := ctx.volumeBlockDevices[v]; !ok {
 		ctx.pendingVolumeBlockDevices.Add(v)
@@ -461,9 +461,8 @@ func filesystemsFromStorage(in []storage.Filesystem) []params.Filesystem {
 	out := make([]params.Filesystem, len(in))
 	for i, f := range in {
 		paramsFilesystem := params.Filesystem{
-			f.Tag.String(),
-			"",
-			params.FilesystemInfo{
+			FilesystemTag: f.Tag.String(),
+			Info: params.FilesystemInfo{
 				f.FilesystemId,
 				f.Size,
 			},
@@ -483,8 +482,8 @@ func filesystemAttachmentsFromStorage(in []storage.FilesystemAttachment) []param
 			f.Filesystem.String(),
 			f.Machine.String(),
 			params.FilesystemAttachmentInfo{
-				f.Path,
-				f.ReadOnly,
+				MountPoint: f.Path,
+				ReadOnly:   f.ReadOnly,
 			},
 		}
 	}
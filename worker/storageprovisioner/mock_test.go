@@ -130,8 +130,17 @@ type mockVolumeAccessor struct {
 	provisionedAttachments map[params.MachineStorageId]params.VolumeAttachment
 	blockDevices           map[params.MachineStorageId]storage.BlockDevice
 
+	snapshotsWatcher  *mockStringsWatcher
+	snapshotParams    map[string]params.VolumeSnapshotParams
+	volumeSnapshotIds map[string]string
+
+	resizesWatcher *mockStringsWatcher
+	resizeParams   map[string]params.VolumeResizeParams
+
 	setVolumeInfo           func([]params.Volume) ([]params.ErrorResult, error)
 	setVolumeAttachmentInfo func([]params.VolumeAttachment) ([]params.ErrorResult, error)
+	setVolumeSnapshotInfo   func([]params.VolumeSnapshot) ([]params.ErrorResult, error)
+	removeVolumeSnapshots   func([]string) ([]params.ErrorResult, error)
 }
 
 func (m *mockVolumeAccessor) provisionVolume(tag names.VolumeTag) params.Volume {
@@ -214,6 +223,7 @@ func (v *mockVolumeAccessor) VolumeParams(volumes []names.VolumeTag) ([]params.V
 			Tags: map[string]string{
 				"very": "fancy",
 			},
+			SnapshotId: v.volumeSnapshotIds[tag.String()],
 		}
 		volumeParams.Attachment = &params.VolumeAttachmentParams{
 			VolumeTag:  tag.String(),
@@ -258,6 +268,52 @@ func (v *mockVolumeAccessor) SetVolumeAttachmentInfo(volumeAttachments []params.
 	return make([]params.ErrorResult, len(volumeAttachments)), nil
 }
 
+func (w *mockVolumeAccessor) WatchVolumeSnapshots() (watcher.StringsWatcher, error) {
+	return w.snapshotsWatcher, nil
+}
+
+func (v *mockVolumeAccessor) VolumeSnapshotParams(ids []string) ([]params.VolumeSnapshotParamsResult, error) {
+	result := make([]params.VolumeSnapshotParamsResult, len(ids))
+	for i, id := range ids {
+		if p, ok := v.snapshotParams[id]; ok {
+			result[i].Result = p
+		} else {
+			result[i].Error = common.ServerError(errors.NotFoundf("volume snapshot %q", id))
+		}
+	}
+	return result, nil
+}
+
+func (v *mockVolumeAccessor) SetVolumeSnapshotInfo(snapshots []params.VolumeSnapshot) ([]params.ErrorResult, error) {
+	if v.setVolumeSnapshotInfo != nil {
+		return v.setVolumeSnapshotInfo(snapshots)
+	}
+	return make([]params.ErrorResult, len(snapshots)), nil
+}
+
+func (v *mockVolumeAccessor) RemoveVolumeSnapshots(ids []string) ([]params.ErrorResult, error) {
+	if v.removeVolumeSnapshots != nil {
+		return v.removeVolumeSnapshots(ids)
+	}
+	return make([]params.ErrorResult, len(ids)), nil
+}
+
+func (w *mockVolumeAccessor) WatchVolumeResizes() (watcher.StringsWatcher, error) {
+	return w.resizesWatcher, nil
+}
+
+func (v *mockVolumeAccessor) VolumeResizeParams(tags []names.VolumeTag) ([]params.VolumeResizeParamsResult, error) {
+	result := make([]params.VolumeResizeParamsResult, len(tags))
+	for i, tag := range tags {
+		if p, ok := v.resizeParams[tag.String()]; ok {
+			result[i].Result = p
+		} else {
+			result[i].Error = common.ServerError(errors.NotProvisionedf("volume %q", tag.Id()))
+		}
+	}
+	return result, nil
+}
+
 func newMockVolumeAccessor() *mockVolumeAccessor {
 	return &mockVolumeAccessor{
 		volumesWatcher:         newMockStringsWatcher(),
@@ -267,6 +323,10 @@ func newMockVolumeAccessor() *mockVolumeAccessor {
 		provisionedVolumes:     make(map[string]params.Volume),
 		provisionedAttachments: make(map[params.MachineStorageId]params.VolumeAttachment),
 		blockDevices:           make(map[params.MachineStorageId]storage.BlockDevice),
+		snapshotsWatcher:       newMockStringsWatcher(),
+		snapshotParams:         make(map[string]params.VolumeSnapshotParams),
+		resizesWatcher:         newMockStringsWatcher(),
+		resizeParams:           make(map[string]params.VolumeResizeParams),
 	}
 }
 
@@ -277,6 +337,9 @@ type mockFilesystemAccessor struct {
 	provisionedFilesystems map[string]params.Filesystem
 	provisionedAttachments map[params.MachineStorageId]params.FilesystemAttachment
 
+	resizesWatcher *mockStringsWatcher
+	resizeParams   map[string]params.FilesystemResizeParams
+
 	setFilesystemInfo           func([]params.Filesystem) ([]params.ErrorResult, error)
 	setFilesystemAttachmentInfo func([]params.FilesystemAttachment) ([]params.ErrorResult, error)
 }
@@ -382,6 +445,22 @@ func (f *mockFilesystemAccessor) SetFilesystemAttachmentInfo(filesystemAttachmen
 	return make([]params.ErrorResult, len(filesystemAttachments)), nil
 }
 
+func (w *mockFilesystemAccessor) WatchFilesystemResizes() (watcher.StringsWatcher, error) {
+	return w.resizesWatcher, nil
+}
+
+func (f *mockFilesystemAccessor) FilesystemResizeParams(tags []names.FilesystemTag) ([]params.FilesystemResizeParamsResult, error) {
+	result := make([]params.FilesystemResizeParamsResult, len(tags))
+	for i, tag := range tags {
+		if p, ok := f.resizeParams[tag.String()]; ok {
+			result[i].Result = p
+		} else {
+			result[i].Error = common.ServerError(errors.NotProvisionedf("filesystem %q", tag.Id()))
+		}
+	}
+	return result, nil
+}
+
 func newMockFilesystemAccessor() *mockFilesystemAccessor {
 	return &mockFilesystemAccessor{
 		filesystemsWatcher:     newMockStringsWatcher(),
@@ -389,6 +468,8 @@ func newMockFilesystemAccessor() *mockFilesystemAccessor {
 		provisionedMachines:    make(map[string]instance.Id),
 		provisionedFilesystems: make(map[string]params.Filesystem),
 		provisionedAttachments: make(map[params.MachineStorageId]params.FilesystemAttachment),
+		resizesWatcher:         newMockStringsWatcher(),
+		resizeParams:           make(map[string]params.FilesystemResizeParams),
 	}
 }
 
@@ -464,6 +545,9 @@ type dummyProvider struct {
 	detachFilesystemsFunc        func([]storage.FilesystemAttachmentParams) ([]error, error)
 	destroyVolumesFunc           func([]string) ([]error, error)
 	destroyFilesystemsFunc       func([]string) ([]error, error)
+	createVolumeSnapshotsFunc    func([]storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error)
+	deleteVolumeSnapshotsFunc    func([]string) ([]error, error)
+	resizeVolumesFunc            func([]storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error)
 	validateVolumeParamsFunc     func(storage.VolumeParams) error
 	validateFilesystemParamsFunc func(storage.FilesystemParams) error
 }
@@ -573,6 +657,55 @@ func (s *dummyVolumeSource) DetachVolumes(params []storage.VolumeAttachmentParam
 	return make([]error, len(params)), nil
 }
 
+// CreateVolumeSnapshots takes snapshots of volumes.
+func (s *dummyVolumeSource) CreateVolumeSnapshots(params []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+	if s.provider.createVolumeSnapshotsFunc != nil {
+		return s.provider.createVolumeSnapshotsFunc(params)
+	}
+	results := make([]storage.CreateVolumeSnapshotsResult, len(params))
+	for i, p := range params {
+		results[i].VolumeSnapshot = &storage.VolumeSnapshot{
+			p.Id,
+			p.Volume,
+			storage.VolumeSnapshotInfo{SnapshotId: "snap-" + p.VolumeId},
+		}
+	}
+	return results, nil
+}
+
+// ListVolumeSnapshots lists volume snapshots.
+func (s *dummyVolumeSource) ListVolumeSnapshots() ([]string, error) {
+	return nil, nil
+}
+
+// DeleteVolumeSnapshots deletes volume snapshots.
+func (s *dummyVolumeSource) DeleteVolumeSnapshots(snapshotIds []string) ([]error, error) {
+	if s.provider.deleteVolumeSnapshotsFunc != nil {
+		return s.provider.deleteVolumeSnapshotsFunc(snapshotIds)
+	}
+	return make([]error, len(snapshotIds)), nil
+}
+
+// ResizeVolumes grows volumes.
+func (s *dummyVolumeSource) ResizeVolumes(params []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	if s.provider.resizeVolumesFunc != nil {
+		return s.provider.resizeVolumesFunc(params)
+	}
+	results := make([]storage.ResizeVolumesResult, len(params))
+	for i, p := range params {
+		results[i].VolumeInfo = &storage.VolumeInfo{
+			VolumeId: p.VolumeId,
+			Size:     p.Size,
+		}
+	}
+	return results, nil
+}
+
+// CreateVolumesFromSnapshots creates volumes from snapshots.
+func (s *dummyVolumeSource) CreateVolumesFromSnapshots(params []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
+	return s.CreateVolumes(params)
+}
+
 func (s *dummyFilesystemSource) ValidateFilesystemParams(params storage.FilesystemParams) error {
 	if s.provider != nil && s.provider.validateFilesystemParamsFunc != nil {
 		return s.provider.validateFilesystemParamsFunc(params)
@@ -711,6 +844,26 @@ func (s *mockManagedFilesystemSource) DetachFilesystems(params []storage.Filesys
 	return nil, errors.NotImplementedf("DetachFilesystems")
 }
 
+func (s *mockManagedFilesystemSource) ResizeFilesystems(args []storage.FilesystemResizeParams) ([]storage.ResizeFilesystemsResult, error) {
+	results := make([]storage.ResizeFilesystemsResult, len(args))
+	for i, arg := range args {
+		blockDevice, ok := s.blockDevices[arg.Volume]
+		if !ok {
+			results[i].Error = errors.Errorf("filesystem %v's backing-volume is not attached", arg.Tag.Id())
+			continue
+		}
+		if blockDevice.Size < arg.Size {
+			results[i].Error = errors.Errorf("backing-volume %s has not yet grown to %dMiB", arg.Volume.Id(), arg.Size)
+			continue
+		}
+		results[i].FilesystemInfo = &storage.FilesystemInfo{
+			FilesystemId: arg.FilesystemId,
+			Size:         blockDevice.Size,
+		}
+	}
+	return results, nil
+}
+
 type mockMachineAccessor struct {
 	instanceIds map[names.MachineTag]instance.Id
 	watcher     *mockNotifyWatcher
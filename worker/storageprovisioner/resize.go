@@ -0,0 +1,358 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storageprovisioner
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/storage"
+)
+
+// volumeResizesChanged is called when the volumes with the provided IDs
+// have been seen to have changed, so that outstanding requests to resize
+// them may be processed.
+func volumeResizesChanged(ctx *context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	tags := make([]names.VolumeTag, len(ids))
+	for i, id := range ids {
+		tags[i] = names.NewVolumeTag(id)
+	}
+	results, err := ctx.config.Volumes.VolumeResizeParams(tags)
+	if err != nil {
+		return errors.Annotate(err, "getting volume resize parameters")
+	}
+	var ops []scheduleOp
+	for i, result := range results {
+		if result.Error != nil {
+			if ignoreResizeError(result.Error) {
+				continue
+			}
+			return errors.Annotatef(
+				result.Error, "getting resize parameters for %s",
+				names.ReadableString(tags[i]),
+			)
+		}
+		if result.Result.Size == 0 {
+			// There is no outstanding resize request.
+			continue
+		}
+		ops = append(ops, &resizeVolumeOp{args: result.Result})
+	}
+	logger.Debugf("volume resize operations: %v", ops)
+	scheduleOperations(ctx, ops...)
+	return nil
+}
+
+// filesystemResizesChanged is called when the filesystems with the
+// provided IDs have been seen to have changed, so that outstanding
+// requests to resize them may be processed.
+func filesystemResizesChanged(ctx *context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	tags := make([]names.FilesystemTag, len(ids))
+	for i, id := range ids {
+		tags[i] = names.NewFilesystemTag(id)
+	}
+	results, err := ctx.config.Filesystems.FilesystemResizeParams(tags)
+	if err != nil {
+		return errors.Annotate(err, "getting filesystem resize parameters")
+	}
+	var ops []scheduleOp
+	for i, result := range results {
+		if result.Error != nil {
+			if ignoreResizeError(result.Error) {
+				continue
+			}
+			return errors.Annotatef(
+				result.Error, "getting resize parameters for %s",
+				names.ReadableString(tags[i]),
+			)
+		}
+		if result.Result.Size == 0 {
+			// There is no outstanding resize request.
+			continue
+		}
+		ops = append(ops, &resizeFilesystemOp{args: result.Result})
+	}
+	logger.Debugf("filesystem resize operations: %v", ops)
+	scheduleOperations(ctx, ops...)
+	return nil
+}
+
+// ignoreResizeError reports whether the error obtained when getting
+// resize parameters may be ignored: the storage has either been
+// removed, or is yet to be provisioned and so cannot be resized.
+func ignoreResizeError(err *params.Error) bool {
+	return params.IsCodeNotFound(err) ||
+		params.IsCodeUnauthorized(err) ||
+		params.IsCodeNotProvisioned(err)
+}
+
+// resizeVolumes grows volumes with the specified parameters, and records
+// the new sizes in state.
+func resizeVolumes(ctx *context, ops map[names.VolumeTag]*resizeVolumeOp) error {
+	resizers := make(map[string]storage.VolumeResizer)
+	paramsBySource := make(map[string][]storage.VolumeResizeParams)
+	var statuses []params.EntityStatusArgs
+	for tag, op := range ops {
+		sourceName := op.args.Provider
+		resizer, ok := resizers[sourceName]
+		if !ok {
+			volumeSource, err := volumeSource(
+				ctx.modelConfig, ctx.config.StorageDir,
+				sourceName, storage.ProviderType(op.args.Provider),
+			)
+			if err != nil && errors.Cause(err) != errNonDynamic {
+				return errors.Annotate(err, "getting volume source")
+			}
+			resizer, _ = volumeSource.(storage.VolumeResizer)
+			resizers[sourceName] = resizer
+		}
+		if resizer == nil {
+			// The request cannot be satisfied by retrying,
+			// so report it on the entity's status.
+			statuses = append(statuses, params.EntityStatusArgs{
+				Tag:    tag.String(),
+				Status: status.StatusError.String(),
+				Info: fmt.Sprintf(
+					"cannot resize: storage provider %q does not support resizing",
+					op.args.Provider,
+				),
+			})
+			continue
+		}
+		paramsBySource[sourceName] = append(paramsBySource[sourceName], storage.VolumeResizeParams{
+			Tag:      tag,
+			VolumeId: op.args.VolumeId,
+			Size:     op.args.Size,
+		})
+	}
+	setStatus(ctx, statuses)
+
+	var reschedule []scheduleOp
+	sizes := make(map[names.VolumeTag]uint64)
+	for sourceName, resizeParams := range paramsBySource {
+		logger.Debugf("resizing volumes: %v", resizeParams)
+		results, err := resizers[sourceName].ResizeVolumes(resizeParams)
+		if err != nil {
+			return errors.Annotatef(err, "resizing volumes from source %q", sourceName)
+		}
+		for i, result := range results {
+			tag := resizeParams[i].Tag
+			if result.Error != nil {
+				reschedule = append(reschedule, ops[tag])
+				logger.Errorf("failed to resize %s: %v", names.ReadableString(tag), result.Error)
+				continue
+			}
+			sizes[tag] = result.VolumeInfo.Size
+		}
+	}
+	scheduleOperations(ctx, reschedule...)
+	if len(sizes) == 0 {
+		return nil
+	}
+
+	// Record the new sizes in state, leaving the
+	// remainder of the volumes' info unchanged.
+	tags := make([]names.VolumeTag, 0, len(sizes))
+	for tag := range sizes {
+		tags = append(tags, tag)
+	}
+	volumeResults, err := ctx.config.Volumes.Volumes(tags)
+	if err != nil {
+		return errors.Annotate(err, "getting volumes")
+	}
+	volumes := make([]params.Volume, 0, len(tags))
+	for i, result := range volumeResults {
+		if result.Error != nil {
+			return errors.Annotatef(result.Error, "getting %s", names.ReadableString(tags[i]))
+		}
+		volume := result.Result
+		volume.Info.Size = sizes[tags[i]]
+		volumes = append(volumes, volume)
+		if info, ok := ctx.volumes[tags[i]]; ok {
+			info.Size = volume.Info.Size
+			ctx.volumes[tags[i]] = info
+		}
+	}
+	errorResults, err := ctx.config.Volumes.SetVolumeInfo(volumes)
+	if err != nil {
+		return errors.Annotate(err, "publishing resized volumes to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			return errors.Annotatef(
+				result.Error, "publishing resized %s to state",
+				names.ReadableString(tags[i]),
+			)
+		}
+	}
+	return nil
+}
+
+// resizeFilesystems grows filesystems with the specified parameters, and
+// records the new sizes in state.
+func resizeFilesystems(ctx *context, ops map[names.FilesystemTag]*resizeFilesystemOp) error {
+	var backingVolumes []names.VolumeTag
+	resizers := make(map[string]storage.FilesystemResizer)
+	paramsBySource := make(map[string][]storage.FilesystemResizeParams)
+	var statuses []params.EntityStatusArgs
+	for tag, op := range ops {
+		var volumeTag names.VolumeTag
+		if op.args.VolumeTag != "" {
+			var err error
+			volumeTag, err = names.ParseVolumeTag(op.args.VolumeTag)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			backingVolumes = append(backingVolumes, volumeTag)
+		}
+		sourceName := op.args.Provider
+		resizer, ok := resizers[sourceName]
+		if !ok {
+			var source storage.FilesystemSource
+			if volumeTag != (names.VolumeTag{}) {
+				source = ctx.managedFilesystemSource
+			} else {
+				var err error
+				source, err = filesystemSource(
+					ctx.modelConfig, ctx.config.StorageDir,
+					sourceName, storage.ProviderType(op.args.Provider),
+				)
+				if err != nil && errors.Cause(err) != errNonDynamic {
+					return errors.Annotate(err, "getting filesystem source")
+				}
+			}
+			resizer, _ = source.(storage.FilesystemResizer)
+			resizers[sourceName] = resizer
+		}
+		if resizer == nil {
+			// The request cannot be satisfied by retrying,
+			// so report it on the entity's status.
+			statuses = append(statuses, params.EntityStatusArgs{
+				Tag:    tag.String(),
+				Status: status.StatusError.String(),
+				Info: fmt.Sprintf(
+					"cannot resize: storage provider %q does not support resizing",
+					op.args.Provider,
+				),
+			})
+			continue
+		}
+		paramsBySource[sourceName] = append(paramsBySource[sourceName], storage.FilesystemResizeParams{
+			Tag:          tag,
+			Volume:       volumeTag,
+			FilesystemId: op.args.FilesystemId,
+			Size:         op.args.Size,
+		})
+	}
+	setStatus(ctx, statuses)
+
+	// Filesystems are grown to fill their backing volumes, so we must
+	// refresh the block devices to observe the volumes' new sizes.
+	if _, ok := ctx.config.Scope.(names.MachineTag); ok && len(backingVolumes) > 0 {
+		if err := refreshVolumeBlockDevices(ctx, backingVolumes); err != nil {
+			return errors.Annotate(err, "refreshing block devices")
+		}
+	}
+
+	var reschedule []scheduleOp
+	sizes := make(map[names.FilesystemTag]uint64)
+	for sourceName, resizeParams := range paramsBySource {
+		logger.Debugf("resizing filesystems: %v", resizeParams)
+		results, err := resizers[sourceName].ResizeFilesystems(resizeParams)
+		if err != nil {
+			return errors.Annotatef(err, "resizing filesystems from source %q", sourceName)
+		}
+		for i, result := range results {
+			tag := resizeParams[i].Tag
+			if result.Error != nil {
+				reschedule = append(reschedule, ops[tag])
+				logger.Errorf("failed to resize %s: %v", names.ReadableString(tag), result.Error)
+				continue
+			}
+			sizes[tag] = result.FilesystemInfo.Size
+		}
+	}
+	scheduleOperations(ctx, reschedule...)
+	if len(sizes) == 0 {
+		return nil
+	}
+
+	// Record the new sizes in state, leaving the
+	// remainder of the filesystems' info unchanged.
+	tags := make([]names.FilesystemTag, 0, len(sizes))
+	for tag := range sizes {
+		tags = append(tags, tag)
+	}
+	filesystemResults, err := ctx.config.Filesystems.Filesystems(tags)
+	if err != nil {
+		return errors.Annotate(err, "getting filesystems")
+	}
+	filesystems := make([]params.Filesystem, 0, len(tags))
+	for i, result := range filesystemResults {
+		if result.Error != nil {
+			return errors.Annotatef(result.Error, "getting %s", names.ReadableString(tags[i]))
+		}
+		filesystem := result.Result
+		filesystem.Info.Size = sizes[tags[i]]
+		filesystems = append(filesystems, filesystem)
+		if info, ok := ctx.filesystems[tags[i]]; ok {
+			info.Size = filesystem.Info.Size
+			ctx.filesystems[tags[i]] = info
+		}
+	}
+	errorResults, err := ctx.config.Filesystems.SetFilesystemInfo(filesystems)
+	if err != nil {
+		return errors.Annotate(err, "publishing resized filesystems to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			return errors.Annotatef(
+				result.Error, "publishing resized %s to state",
+				names.ReadableString(tags[i]),
+			)
+		}
+	}
+	return nil
+}
+
+// volumeResizeKey is the schedule key for volume resize operations.
+type volumeResizeKey struct {
+	tag names.VolumeTag
+}
+
+type resizeVolumeOp struct {
+	exponentialBackoff
+	args params.VolumeResizeParams
+}
+
+func (op *resizeVolumeOp) key() interface{} {
+	tag, _ := names.ParseVolumeTag(op.args.VolumeTag)
+	return volumeResizeKey{tag}
+}
+
+// filesystemResizeKey is the schedule key for filesystem resize
+// operations.
+type filesystemResizeKey struct {
+	tag names.FilesystemTag
+}
+
+type resizeFilesystemOp struct {
+	exponentialBackoff
+	args params.FilesystemResizeParams
+}
+
+func (op *resizeFilesystemOp) key() interface{} {
+	tag, _ := names.ParseFilesystemTag(op.args.FilesystemTag)
+	return filesystemResizeKey{tag}
+}
@@ -86,6 +86,30 @@ type VolumeAccessor interface {
 	// SetVolumeAttachmentInfo records the details of newly provisioned
 	// volume attachments.
 	SetVolumeAttachmentInfo([]params.VolumeAttachment) ([]params.ErrorResult, error)
+
+	// WatchVolumeSnapshots watches for changes to snapshots of volumes
+	// that this storage provisioner is responsible for.
+	WatchVolumeSnapshots() (watcher.StringsWatcher, error)
+
+	// VolumeSnapshotParams returns the parameters for creating or
+	// deleting the volume snapshots with the specified IDs.
+	VolumeSnapshotParams([]string) ([]params.VolumeSnapshotParamsResult, error)
+
+	// SetVolumeSnapshotInfo records the details of newly taken volume
+	// snapshots.
+	SetVolumeSnapshotInfo([]params.VolumeSnapshot) ([]params.ErrorResult, error)
+
+	// RemoveVolumeSnapshots removes the specified volume snapshots
+	// from state.
+	RemoveVolumeSnapshots([]string) ([]params.ErrorResult, error)
+
+	// WatchVolumeResizes watches for changes to the requested sizes of
+	// volumes that this storage provisioner is responsible for.
+	WatchVolumeResizes() (watcher.StringsWatcher, error)
+
+	// VolumeResizeParams returns the parameters for growing the volumes
+	// with the specified tags.
+	VolumeResizeParams([]names.VolumeTag) ([]params.VolumeResizeParamsResult, error)
 }
 
 // FilesystemAccessor defines an interface used to allow a storage provisioner
@@ -120,6 +144,14 @@ type FilesystemAccessor interface {
 	// SetFilesystemAttachmentInfo records the details of newly provisioned
 	// filesystem attachments.
 	SetFilesystemAttachmentInfo([]params.FilesystemAttachment) ([]params.ErrorResult, error)
+
+	// WatchFilesystemResizes watches for changes to the requested sizes
+	// of filesystems that this storage provisioner is responsible for.
+	WatchFilesystemResizes() (watcher.StringsWatcher, error)
+
+	// FilesystemResizeParams returns the parameters for growing the
+	// filesystems with the specified tags.
+	FilesystemResizeParams([]names.FilesystemTag) ([]params.FilesystemResizeParamsResult, error)
 }
 
 // MachineAccessor defines an interface used to allow a storage provisioner
@@ -213,6 +245,9 @@ func (w *storageProvisioner) loop() error {
 		volumesChanges               watcher.StringsChannel
 		filesystemsChanges           watcher.StringsChannel
 		volumeAttachmentsChanges     watcher.MachineStorageIdsChannel
+		volumeSnapshotsChanges       watcher.StringsChannel
+		volumeResizesChanges         watcher.StringsChannel
+		filesystemResizesChanges     watcher.StringsChannel
 		filesystemAttachmentsChanges watcher.MachineStorageIdsChannel
 		machineBlockDevicesChanges   <-chan struct{}
 	)
@@ -275,6 +310,33 @@ func (w *storageProvisioner) loop() error {
 			return errors.Trace(err)
 		}
 		filesystemAttachmentsChanges = filesystemAttachmentsWatcher.Changes()
+
+		volumeSnapshotsWatcher, err := w.config.Volumes.WatchVolumeSnapshots()
+		if err != nil {
+			return errors.Annotate(err, "watching volume snapshots")
+		}
+		if err := w.catacomb.Add(volumeSnapshotsWatcher); err != nil {
+			return errors.Trace(err)
+		}
+		volumeSnapshotsChanges = volumeSnapshotsWatcher.Changes()
+
+		volumeResizesWatcher, err := w.config.Volumes.WatchVolumeResizes()
+		if err != nil {
+			return errors.Annotate(err, "watching volume resizes")
+		}
+		if err := w.catacomb.Add(volumeResizesWatcher); err != nil {
+			return errors.Trace(err)
+		}
+		volumeResizesChanges = volumeResizesWatcher.Changes()
+
+		filesystemResizesWatcher, err := w.config.Filesystems.WatchFilesystemResizes()
+		if err != nil {
+			return errors.Annotate(err, "watching filesystem resizes")
+		}
+		if err := w.catacomb.Add(filesystemResizesWatcher); err != nil {
+			return errors.Trace(err)
+		}
+		filesystemResizesChanges = filesystemResizesWatcher.Changes()
 		return nil
 	}
 
@@ -339,6 +401,27 @@ func (w *storageProvisioner) loop() error {
 			if err := volumeAttachmentsChanged(&ctx, changes); err != nil {
 				return errors.Trace(err)
 			}
+		case changes, ok := <-volumeSnapshotsChanges:
+			if !ok {
+				return errors.New("volume snapshots watcher closed")
+			}
+			if err := volumeSnapshotsChanged(&ctx, changes); err != nil {
+				return errors.Trace(err)
+			}
+		case changes, ok := <-volumeResizesChanges:
+			if !ok {
+				return errors.New("volume resizes watcher closed")
+			}
+			if err := volumeResizesChanged(&ctx, changes); err != nil {
+				return errors.Trace(err)
+			}
+		case changes, ok := <-filesystemResizesChanges:
+			if !ok {
+				return errors.New("filesystem resizes watcher closed")
+			}
+			if err := filesystemResizesChanged(&ctx, changes); err != nil {
+				return errors.Trace(err)
+			}
 		case changes, ok := <-filesystemsChanges:
 			if !ok {
 				return errors.New("filesystems watcher closed")
@@ -384,6 +467,10 @@ func processSchedule(ctx *context) error {
 	destroyFilesystemOps := make(map[names.FilesystemTag]*destroyFilesystemOp)
 	attachFilesystemOps := make(map[params.MachineStorageId]*attachFilesystemOp)
 	detachFilesystemOps := make(map[params.MachineStorageId]*detachFilesystemOp)
+	createVolumeSnapshotOps := make(map[string]*createVolumeSnapshotOp)
+	destroyVolumeSnapshotOps := make(map[string]*destroyVolumeSnapshotOp)
+	resizeVolumeOps := make(map[names.VolumeTag]*resizeVolumeOp)
+	resizeFilesystemOps := make(map[names.FilesystemTag]*resizeFilesystemOp)
 	for _, item := range ready {
 		op := item.(scheduleOp)
 		key := op.key()
@@ -404,6 +491,14 @@ func processSchedule(ctx *context) error {
 			attachFilesystemOps[key.(params.MachineStorageId)] = op
 		case *detachFilesystemOp:
 			detachFilesystemOps[key.(params.MachineStorageId)] = op
+		case *createVolumeSnapshotOp:
+			createVolumeSnapshotOps[key.(volumeSnapshotKey).id] = op
+		case *destroyVolumeSnapshotOp:
+			destroyVolumeSnapshotOps[key.(volumeSnapshotKey).id] = op
+		case *resizeVolumeOp:
+			resizeVolumeOps[key.(volumeResizeKey).tag] = op
+		case *resizeFilesystemOp:
+			resizeFilesystemOps[key.(filesystemResizeKey).tag] = op
 		}
 	}
 	if len(destroyVolumeOps) > 0 {
@@ -446,6 +541,28 @@ func processSchedule(ctx *context) error {
 			return errors.Annotate(err, "attaching filesystems")
 		}
 	}
+	if len(destroyVolumeSnapshotOps) > 0 {
+		if err := destroyVolumeSnapshots(ctx, destroyVolumeSnapshotOps); err != nil {
+			return errors.Annotate(err, "destroying volume snapshots")
+		}
+	}
+	if len(createVolumeSnapshotOps) > 0 {
+		if err := createVolumeSnapshots(ctx, createVolumeSnapshotOps); err != nil {
+			return errors.Annotate(err, "creating volume snapshots")
+		}
+	}
+	// Volumes are resized before filesystems,
+	// as filesystems may be backed by them.
+	if len(resizeVolumeOps) > 0 {
+		if err := resizeVolumes(ctx, resizeVolumeOps); err != nil {
+			return errors.Annotate(err, "resizing volumes")
+		}
+	}
+	if len(resizeFilesystemOps) > 0 {
+		if err := resizeFilesystems(ctx, resizeFilesystemOps); err != nil {
+			return errors.Annotate(err, "resizing filesystems")
+		}
+	}
 	return nil
 }
 
@@ -1656,6 +1656,55 @@ func (s *storageProvisionerSuite) TestDestroyVolumesRetry(c *gc.C) {
 	})
 }
 
+func (s *storageProvisionerSuite) TestReleaseVolumes(c *gc.C) {
+	releasedVolume := names.NewVolumeTag("1")
+
+	volumeAccessor := newMockVolumeAccessor()
+	v := volumeAccessor.provisionVolume(releasedVolume)
+	v.Releasing = true
+	volumeAccessor.provisionedVolumes[releasedVolume.String()] = v
+
+	life := func(tags []names.Tag) ([]params.LifeResult, error) {
+		results := make([]params.LifeResult, len(tags))
+		for i := range results {
+			results[i].Life = params.Dead
+		}
+		return results, nil
+	}
+
+	destroyedChan := make(chan interface{}, 1)
+	s.provider.destroyVolumesFunc = func(volumeIds []string) ([]error, error) {
+		destroyedChan <- volumeIds
+		return make([]error, len(volumeIds)), nil
+	}
+
+	removedChan := make(chan interface{}, 1)
+	remove := func(tags []names.Tag) ([]params.ErrorResult, error) {
+		removedChan <- tags
+		return make([]params.ErrorResult, len(tags)), nil
+	}
+
+	args := &workerArgs{
+		volumes: volumeAccessor,
+		life: &mockLifecycleManager{
+			life:   life,
+			remove: remove,
+		},
+	}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.volumesWatcher.changes <- []string{releasedVolume.Id()}
+	args.environ.watcher.changes <- struct{}{}
+
+	// The released volume should be removed from state,
+	// without being deprovisioned.
+	removed := waitChannel(c, removedChan, "waiting for volume to be removed")
+	c.Assert(removed, jc.DeepEquals, []names.Tag{releasedVolume})
+	assertNoEvent(c, destroyedChan, "volumes deprovisioned")
+}
+
 func (s *storageProvisionerSuite) TestDestroyFilesystems(c *gc.C) {
 	provisionedFilesystem := names.NewFilesystemTag("1")
 	unprovisionedFilesystem := names.NewFilesystemTag("2")
@@ -1755,6 +1804,261 @@ func newStorageProvisioner(c *gc.C, args *workerArgs) worker.Worker {
 	return worker
 }
 
+func (s *storageProvisionerSuite) TestCreateVolumeSnapshot(c *gc.C) {
+	snapshotInfoSet := make(chan interface{})
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.snapshotParams["1:0"] = params.VolumeSnapshotParams{
+		Id:        "1:0",
+		VolumeTag: "volume-1",
+		VolumeId:  "vol-1",
+		Provider:  "dummy",
+		Life:      params.Alive,
+	}
+	volumeAccessor.setVolumeSnapshotInfo = func(snapshots []params.VolumeSnapshot) ([]params.ErrorResult, error) {
+		defer close(snapshotInfoSet)
+		c.Assert(snapshots, jc.DeepEquals, []params.VolumeSnapshot{{
+			Id:        "1:0",
+			VolumeTag: "volume-1",
+			Info:      params.VolumeSnapshotInfo{SnapshotId: "snap-vol-1"},
+		}})
+		return make([]params.ErrorResult, len(snapshots)), nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	args.environ.watcher.changes <- struct{}{}
+	volumeAccessor.snapshotsWatcher.changes <- []string{"1:0", "2:0"}
+	waitChannel(c, snapshotInfoSet, "waiting for volume snapshot info to be set")
+}
+
+func (s *storageProvisionerSuite) TestDestroyVolumeSnapshot(c *gc.C) {
+	deleted := make(chan interface{})
+	s.provider.deleteVolumeSnapshotsFunc = func(ids []string) ([]error, error) {
+		defer close(deleted)
+		c.Assert(ids, jc.DeepEquals, []string{"snap-vol-1"})
+		return make([]error, len(ids)), nil
+	}
+
+	removed := make(chan interface{})
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.snapshotParams["1:0"] = params.VolumeSnapshotParams{
+		Id:         "1:0",
+		VolumeTag:  "volume-1",
+		VolumeId:   "vol-1",
+		Provider:   "dummy",
+		Life:       params.Dying,
+		SnapshotId: "snap-vol-1",
+	}
+	volumeAccessor.removeVolumeSnapshots = func(ids []string) ([]params.ErrorResult, error) {
+		defer close(removed)
+		c.Assert(ids, jc.DeepEquals, []string{"1:0"})
+		return make([]params.ErrorResult, len(ids)), nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	args.environ.watcher.changes <- struct{}{}
+	volumeAccessor.snapshotsWatcher.changes <- []string{"1:0"}
+	waitChannel(c, deleted, "waiting for volume snapshot to be deleted")
+	waitChannel(c, removed, "waiting for volume snapshot to be removed")
+}
+
+func (s *storageProvisionerSuite) TestCreateVolumeFromSnapshot(c *gc.C) {
+	var createVolumesArgs []storage.VolumeParams
+	s.provider.createVolumesFunc = func(args []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
+		createVolumesArgs = append(createVolumesArgs, args...)
+		results := make([]storage.CreateVolumesResult, len(args))
+		for i, p := range args {
+			results[i].Volume = &storage.Volume{Tag: p.Tag, VolumeInfo: storage.VolumeInfo{VolumeId: "id-" + p.Tag.Id()}}
+		}
+		return results, nil
+	}
+
+	volumeInfoSet := make(chan interface{})
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.provisionedMachines["machine-1"] = instance.Id("already-provisioned-1")
+	volumeAccessor.volumeSnapshotIds = map[string]string{"volume-5": "snap-1"}
+	volumeAccessor.setVolumeInfo = func(volumes []params.Volume) ([]params.ErrorResult, error) {
+		defer close(volumeInfoSet)
+		return make([]params.ErrorResult, len(volumes)), nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.attachmentsWatcher.changes <- []watcher.MachineStorageId{{
+		MachineTag: "machine-1", AttachmentTag: "volume-5",
+	}}
+	volumeAccessor.volumesWatcher.changes <- []string{"5"}
+	args.environ.watcher.changes <- struct{}{}
+	waitChannel(c, volumeInfoSet, "waiting for volume info to be set")
+	c.Assert(createVolumesArgs, gc.HasLen, 1)
+	c.Assert(createVolumesArgs[0].SnapshotId, gc.Equals, "snap-1")
+}
+
+func (s *storageProvisionerSuite) TestResizeVolume(c *gc.C) {
+	var resizeVolumesArgs []storage.VolumeResizeParams
+	s.provider.resizeVolumesFunc = func(args []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+		resizeVolumesArgs = append(resizeVolumesArgs, args...)
+		results := make([]storage.ResizeVolumesResult, len(args))
+		for i, p := range args {
+			results[i].VolumeInfo = &storage.VolumeInfo{VolumeId: p.VolumeId, Size: p.Size}
+		}
+		return results, nil
+	}
+
+	volumeInfoSet := make(chan interface{})
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.provisionedVolumes["volume-1"] = params.Volume{
+		VolumeTag: "volume-1",
+		Info: params.VolumeInfo{
+			VolumeId:   "vol-1",
+			HardwareId: "abc",
+			Size:       1024,
+			Persistent: true,
+		},
+	}
+	volumeAccessor.resizeParams["volume-1"] = params.VolumeResizeParams{
+		VolumeTag: "volume-1",
+		VolumeId:  "vol-1",
+		Provider:  "dummy",
+		Size:      2048,
+	}
+	// Volume 2 has no outstanding resize request.
+	volumeAccessor.resizeParams["volume-2"] = params.VolumeResizeParams{
+		VolumeTag: "volume-2",
+		VolumeId:  "vol-2",
+		Provider:  "dummy",
+	}
+	volumeAccessor.setVolumeInfo = func(volumes []params.Volume) ([]params.ErrorResult, error) {
+		defer close(volumeInfoSet)
+		c.Assert(volumes, jc.DeepEquals, []params.Volume{{
+			VolumeTag: "volume-1",
+			Info: params.VolumeInfo{
+				VolumeId:   "vol-1",
+				HardwareId: "abc",
+				Size:       2048,
+				Persistent: true,
+			},
+		}})
+		return make([]params.ErrorResult, len(volumes)), nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	args.environ.watcher.changes <- struct{}{}
+	volumeAccessor.resizesWatcher.changes <- []string{"1", "2", "3"}
+	waitChannel(c, volumeInfoSet, "waiting for volume info to be set")
+	c.Assert(resizeVolumesArgs, jc.DeepEquals, []storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("1"),
+		VolumeId: "vol-1",
+		Size:     2048,
+	}})
+}
+
+func (s *storageProvisionerSuite) TestResizeVolumeNotSupported(c *gc.C) {
+	s.provider.volumeSourceFunc = func(envConfig *config.Config, sourceConfig *storage.Config) (storage.VolumeSource, error) {
+		// Hide the dummy volume source's ResizeVolumes method.
+		return struct{ storage.VolumeSource }{&dummyVolumeSource{provider: s.provider}}, nil
+	}
+
+	statusSet := make(chan interface{})
+	statusSetter := &mockStatusSetter{
+		setStatus: func(args []params.EntityStatusArgs) error {
+			statusSet <- args
+			return nil
+		},
+	}
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.resizeParams["volume-1"] = params.VolumeResizeParams{
+		VolumeTag: "volume-1",
+		VolumeId:  "vol-1",
+		Provider:  "dummy",
+		Size:      2048,
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, statusSetter: statusSetter}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	args.environ.watcher.changes <- struct{}{}
+	volumeAccessor.resizesWatcher.changes <- []string{"1"}
+	statuses := waitChannel(c, statusSet, "waiting for status to be set")
+	c.Assert(statuses, jc.DeepEquals, []params.EntityStatusArgs{{
+		Tag:    "volume-1",
+		Status: "error",
+		Info:   `cannot resize: storage provider "dummy" does not support resizing`,
+	}})
+}
+
+func (s *storageProvisionerSuite) TestResizeVolumeBackedFilesystem(c *gc.C) {
+	filesystemInfoSet := make(chan interface{})
+	filesystemAccessor := newMockFilesystemAccessor()
+	filesystemAccessor.provisionedFilesystems["filesystem-0-0"] = params.Filesystem{
+		FilesystemTag: "filesystem-0-0",
+		VolumeTag:     "volume-0-0",
+		Info: params.FilesystemInfo{
+			FilesystemId: "xvdf1",
+			Size:         1024,
+		},
+	}
+	filesystemAccessor.resizeParams["filesystem-0-0"] = params.FilesystemResizeParams{
+		FilesystemTag: "filesystem-0-0",
+		VolumeTag:     "volume-0-0",
+		FilesystemId:  "xvdf1",
+		Provider:      "dummy",
+		Size:          2048,
+	}
+	filesystemAccessor.setFilesystemInfo = func(filesystems []params.Filesystem) ([]params.ErrorResult, error) {
+		filesystemInfoSet <- filesystems
+		return make([]params.ErrorResult, len(filesystems)), nil
+	}
+
+	args := &workerArgs{
+		scope:       names.NewMachineTag("0"),
+		filesystems: filesystemAccessor,
+	}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	// The backing volume has already grown, so the
+	// filesystem can be grown to fill it.
+	args.volumes.blockDevices[params.MachineStorageId{
+		MachineTag:    "machine-0",
+		AttachmentTag: "volume-0-0",
+	}] = storage.BlockDevice{
+		DeviceName: "xvdf1",
+		Size:       2048,
+	}
+	args.environ.watcher.changes <- struct{}{}
+	filesystemAccessor.resizesWatcher.changes <- []string{"0/0"}
+	filesystemInfo := waitChannel(
+		c, filesystemInfoSet,
+		"waiting for filesystem info to be set",
+	).([]params.Filesystem)
+	c.Assert(filesystemInfo, jc.DeepEquals, []params.Filesystem{{
+		FilesystemTag: "filesystem-0-0",
+		VolumeTag:     "volume-0-0",
+		Info: params.FilesystemInfo{
+			FilesystemId: "xvdf1",
+			Size:         2048,
+		},
+	}})
+}
+
 type workerArgs struct {
 	scope        names.Tag
 	volumes      *mockVolumeAccessor
@@ -173,6 +173,14 @@ func processDeadVolumes(ctx *context, tags []names.VolumeTag, volumeResults []pa
 	var remove []names.Tag
 	for i, result := range volumeResults {
 		tag := tags[i]
+		if result.Error == nil && result.Result.Releasing {
+			// The volume is being released from the model, so
+			// we leave it intact in the cloud and just remove
+			// it from state.
+			logger.Debugf("volume %s is being released, queuing for removal", tag.Id())
+			remove = append(remove, tag)
+			continue
+		}
 		if result.Error == nil {
 			logger.Debugf("volume %s is provisioned, queuing for deprovisioning", tag.Id())
 			volume, err := volumeFromParams(result.Result)
@@ -386,8 +394,8 @@ func volumesFromStorage(in []storage.Volume) []params.Volume {
 	out := make([]params.Volume, len(in))
 	for i, v := range in {
 		out[i] = params.Volume{
-			v.Tag.String(),
-			params.VolumeInfo{
+			VolumeTag: v.Tag.String(),
+			Info: params.VolumeInfo{
 				v.VolumeId,
 				v.HardwareId,
 				v.Size,
@@ -475,6 +483,7 @@ func volumeParamsFromParams(in params.VolumeParams) (storage.VolumeParams, error
 		in.Attributes,
 		in.Tags,
 		attachment,
+		in.SnapshotId,
 	}, nil
 }
 
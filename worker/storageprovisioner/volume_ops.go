@@ -51,7 +51,7 @@ func createVolumes(ctx *context, ops map[names.VolumeTag]*createVolumeOp) error
 		if len(volumeParams) == 0 {
 			continue
 		}
-		results, err := volumeSource.CreateVolumes(volumeParams)
+		results, err := createVolumesFromSource(volumeSource, volumeParams)
 		if err != nil {
 			return errors.Annotatef(err, "creating volumes from source %q", sourceName)
 		}
@@ -122,6 +122,53 @@ func createVolumes(ctx *context, ops map[names.VolumeTag]*createVolumeOp) error
 	return nil
 }
 
+// createVolumesFromSource creates volumes with the specified parameters
+// using the given volume source. Volumes to be created from snapshots
+// are passed to the source's CreateVolumesFromSnapshots method; the
+// results are returned in the same order as the parameters.
+func createVolumesFromSource(
+	volumeSource storage.VolumeSource, volumeParams []storage.VolumeParams,
+) ([]storage.CreateVolumesResult, error) {
+	var plain, fromSnapshot []storage.VolumeParams
+	var plainIndices, fromSnapshotIndices []int
+	for i, p := range volumeParams {
+		if p.SnapshotId == "" {
+			plain = append(plain, p)
+			plainIndices = append(plainIndices, i)
+		} else {
+			fromSnapshot = append(fromSnapshot, p)
+			fromSnapshotIndices = append(fromSnapshotIndices, i)
+		}
+	}
+	results := make([]storage.CreateVolumesResult, len(volumeParams))
+	if len(plain) > 0 {
+		plainResults, err := volumeSource.CreateVolumes(plain)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for i, result := range plainResults {
+			results[plainIndices[i]] = result
+		}
+	}
+	if len(fromSnapshot) > 0 {
+		snapshotter, ok := volumeSource.(storage.VolumeSnapshotter)
+		if !ok {
+			for _, i := range fromSnapshotIndices {
+				results[i].Error = errors.NotSupportedf("creating volumes from snapshots")
+			}
+			return results, nil
+		}
+		snapshotResults, err := snapshotter.CreateVolumesFromSnapshots(fromSnapshot)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for i, result := range snapshotResults {
+			results[fromSnapshotIndices[i]] = result
+		}
+	}
+	return results, nil
+}
+
 // attachVolumes creates volume attachments with the specified parameters.
 func attachVolumes(ctx *context, ops map[params.MachineStorageId]*attachVolumeOp) error {
 	volumeAttachmentParams := make([]storage.VolumeAttachmentParams, 0, len(ops))
@@ -0,0 +1,235 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storageprovisioner
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/storage"
+)
+
+// volumeSnapshotsChanged is called when the lifecycle states of the
+// volume snapshots with the provided IDs have been seen to have changed.
+func volumeSnapshotsChanged(ctx *context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	results, err := ctx.config.Volumes.VolumeSnapshotParams(ids)
+	if err != nil {
+		return errors.Annotate(err, "getting volume snapshot parameters")
+	}
+	var ops []scheduleOp
+	for i, result := range results {
+		if result.Error != nil {
+			if params.IsCodeNotFound(result.Error) || params.IsCodeUnauthorized(result.Error) {
+				// The snapshot has already been removed.
+				continue
+			}
+			return errors.Annotatef(result.Error, "getting parameters for volume snapshot %q", ids[i])
+		}
+		p := result.Result
+		switch p.Life {
+		case params.Alive:
+			if p.SnapshotId != "" {
+				// The snapshot has already been taken.
+				continue
+			}
+			ops = append(ops, &createVolumeSnapshotOp{args: p})
+		case params.Dying:
+			ops = append(ops, &destroyVolumeSnapshotOp{args: p})
+		}
+	}
+	logger.Debugf("volume snapshot operations: %v", ops)
+	scheduleOperations(ctx, ops...)
+	return nil
+}
+
+// createVolumeSnapshots takes snapshots of volumes with the specified
+// parameters.
+func createVolumeSnapshots(ctx *context, ops map[string]*createVolumeSnapshotOp) error {
+	snapshotParams := make([]params.VolumeSnapshotParams, 0, len(ops))
+	for _, op := range ops {
+		snapshotParams = append(snapshotParams, op.args)
+	}
+	paramsBySource, snapshotters, err := volumeSnapshotParamsBySource(
+		ctx.modelConfig, ctx.config.StorageDir, snapshotParams,
+	)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var reschedule []scheduleOp
+	var snapshots []params.VolumeSnapshot
+	for sourceName, snapshotParams := range paramsBySource {
+		logger.Debugf("creating volume snapshots: %v", snapshotParams)
+		args := make([]storage.VolumeSnapshotParams, len(snapshotParams))
+		for i, p := range snapshotParams {
+			volumeTag, err := names.ParseVolumeTag(p.VolumeTag)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			args[i] = storage.VolumeSnapshotParams{
+				Id:       p.Id,
+				Volume:   volumeTag,
+				VolumeId: p.VolumeId,
+			}
+		}
+		results, err := snapshotters[sourceName].CreateVolumeSnapshots(args)
+		if err != nil {
+			return errors.Annotatef(err, "creating volume snapshots from source %q", sourceName)
+		}
+		for i, result := range results {
+			if result.Error != nil {
+				reschedule = append(reschedule, ops[snapshotParams[i].Id])
+				logger.Errorf(
+					"failed to create volume snapshot %q: %v",
+					snapshotParams[i].Id, result.Error,
+				)
+				continue
+			}
+			snapshots = append(snapshots, params.VolumeSnapshot{
+				Id:        result.VolumeSnapshot.Id,
+				VolumeTag: result.VolumeSnapshot.Volume.String(),
+				Info: params.VolumeSnapshotInfo{
+					SnapshotId: result.VolumeSnapshot.SnapshotId,
+					Size:       result.VolumeSnapshot.Size,
+				},
+			})
+		}
+	}
+	scheduleOperations(ctx, reschedule...)
+	if len(snapshots) == 0 {
+		return nil
+	}
+	errorResults, err := ctx.config.Volumes.SetVolumeSnapshotInfo(snapshots)
+	if err != nil {
+		return errors.Annotate(err, "publishing volume snapshots to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			logger.Errorf(
+				"publishing volume snapshot %q to state: %v",
+				snapshots[i].Id, result.Error,
+			)
+		}
+	}
+	return nil
+}
+
+// destroyVolumeSnapshots deletes volume snapshots with the specified
+// parameters, and removes them from state.
+func destroyVolumeSnapshots(ctx *context, ops map[string]*destroyVolumeSnapshotOp) error {
+	var remove []string
+	snapshotParams := make([]params.VolumeSnapshotParams, 0, len(ops))
+	for _, op := range ops {
+		if op.args.SnapshotId == "" {
+			// The snapshot was never taken, so there
+			// is nothing to delete from the provider.
+			remove = append(remove, op.args.Id)
+			continue
+		}
+		snapshotParams = append(snapshotParams, op.args)
+	}
+	paramsBySource, snapshotters, err := volumeSnapshotParamsBySource(
+		ctx.modelConfig, ctx.config.StorageDir, snapshotParams,
+	)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var reschedule []scheduleOp
+	for sourceName, snapshotParams := range paramsBySource {
+		logger.Debugf("deleting volume snapshots from %q: %v", sourceName, snapshotParams)
+		snapshotIds := make([]string, len(snapshotParams))
+		for i, p := range snapshotParams {
+			snapshotIds[i] = p.SnapshotId
+		}
+		errs, err := snapshotters[sourceName].DeleteVolumeSnapshots(snapshotIds)
+		if err != nil {
+			return errors.Annotatef(err, "deleting volume snapshots from source %q", sourceName)
+		}
+		for i, err := range errs {
+			id := snapshotParams[i].Id
+			if err == nil {
+				remove = append(remove, id)
+				continue
+			}
+			reschedule = append(reschedule, ops[id])
+			logger.Errorf("failed to delete volume snapshot %q: %v", id, err)
+		}
+	}
+	scheduleOperations(ctx, reschedule...)
+	if len(remove) == 0 {
+		return nil
+	}
+	errorResults, err := ctx.config.Volumes.RemoveVolumeSnapshots(remove)
+	if err != nil {
+		return errors.Annotate(err, "removing volume snapshots from state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil && !params.IsCodeNotFound(result.Error) {
+			return errors.Annotatef(result.Error, "removing volume snapshot %q from state", remove[i])
+		}
+	}
+	return nil
+}
+
+// volumeSnapshotParamsBySource separates the volume snapshot parameters
+// by volume source. Volume sources that do not support snapshots are
+// logged and skipped.
+func volumeSnapshotParamsBySource(
+	environConfig *config.Config,
+	baseStorageDir string,
+	snapshotParams []params.VolumeSnapshotParams,
+) (map[string][]params.VolumeSnapshotParams, map[string]storage.VolumeSnapshotter, error) {
+	snapshotters := make(map[string]storage.VolumeSnapshotter)
+	paramsBySource := make(map[string][]params.VolumeSnapshotParams)
+	for _, p := range snapshotParams {
+		sourceName := p.Provider
+		snapshotter, ok := snapshotters[sourceName]
+		if !ok {
+			volumeSource, err := volumeSource(
+				environConfig, baseStorageDir, sourceName, storage.ProviderType(p.Provider),
+			)
+			if err != nil && errors.Cause(err) != errNonDynamic {
+				return nil, nil, errors.Annotate(err, "getting volume source")
+			}
+			snapshotter, _ = volumeSource.(storage.VolumeSnapshotter)
+			snapshotters[sourceName] = snapshotter
+		}
+		if snapshotter == nil {
+			logger.Errorf(
+				"cannot process volume snapshot %q: storage provider %q does not support snapshots",
+				p.Id, p.Provider,
+			)
+			continue
+		}
+		paramsBySource[sourceName] = append(paramsBySource[sourceName], p)
+	}
+	return paramsBySource, snapshotters, nil
+}
+
+// volumeSnapshotKey is the schedule key for volume snapshot operations.
+type volumeSnapshotKey struct {
+	id string
+}
+
+type createVolumeSnapshotOp struct {
+	exponentialBackoff
+	args params.VolumeSnapshotParams
+}
+
+func (op *createVolumeSnapshotOp) key() interface{} {
+	return volumeSnapshotKey{op.args.Id}
+}
+
+type destroyVolumeSnapshotOp struct {
+	exponentialBackoff
+	args params.VolumeSnapshotParams
+}
+
+func (op *destroyVolumeSnapshotOp) key() interface{} {
+	return volumeSnapshotKey{op.args.Id}
+}
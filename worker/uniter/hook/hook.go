@@ -16,8 +16,19 @@ const (
 	LeaderElected         hooks.Kind = "leader-elected"
 	LeaderDeposed         hooks.Kind = "leader-deposed"
 	LeaderSettingsChanged hooks.Kind = "leader-settings-changed"
+
+	// StorageResized is run when the volume or filesystem backing
+	// storage attached to the unit has been grown.
+	StorageResized hooks.Kind = "storage-resized"
 )
 
+// IsStorage returns whether the specified hook kind is a storage hook.
+// It should be used in preference to hooks.Kind.IsStorage, which does
+// not know about StorageResized.
+func IsStorage(kind hooks.Kind) bool {
+	return kind.IsStorage() || kind == StorageResized
+}
+
 // Info holds details required to execute a hook. Not all fields are
 // relevant to all Kind values.
 type Info struct {
@@ -52,7 +63,7 @@ func (hi Info) Validate() error {
 		return nil
 	case hooks.Action:
 		return fmt.Errorf("hooks.Kind Action is deprecated")
-	case hooks.StorageAttached, hooks.StorageDetaching:
+	case hooks.StorageAttached, hooks.StorageDetaching, StorageResized:
 		if !names.IsValidStorage(hi.StorageId) {
 			return fmt.Errorf("invalid storage ID %q", hi.StorageId)
 		}
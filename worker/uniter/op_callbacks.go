@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/worker/uniter/charm"
 	"github.com/juju/juju/worker/uniter/hook"
+	"github.com/juju/juju/worker/uniter/operation"
 	"github.com/juju/juju/worker/uniter/runner"
 )
 
@@ -34,7 +35,7 @@ func (opc *operationCallbacks) PrepareHook(hi hook.Info) (string, error) {
 		if err != nil {
 			return "", err
 		}
-	case hi.Kind.IsStorage():
+	case hook.IsStorage(hi.Kind):
 		if err := opc.u.storage.ValidateHook(hi); err != nil {
 			return "", err
 		}
@@ -60,7 +61,7 @@ func (opc *operationCallbacks) CommitHook(hi hook.Info) error {
 	switch {
 	case hi.Kind.IsRelation():
 		return opc.u.relations.CommitHook(hi)
-	case hi.Kind.IsStorage():
+	case hook.IsStorage(hi.Kind):
 		return opc.u.storage.CommitHook(hi)
 	}
 	return nil
@@ -91,6 +92,28 @@ func (opc *operationCallbacks) NotifyHookFailed(hook string, ctx runner.Context)
 	}
 }
 
+// RecordHookExecution is part of the operation.Callbacks interface.
+func (opc *operationCallbacks) RecordHookExecution(execution operation.HookExecution) error {
+	relationId := -1
+	if execution.Info.Kind.IsRelation() {
+		relationId = execution.Info.RelationId
+	}
+	err := opc.u.unit.AddHookExecution(params.HookExecution{
+		Hook:       execution.Name,
+		RelationId: relationId,
+		RemoteUnit: execution.Info.RemoteUnit,
+		Started:    execution.Started,
+		Finished:   execution.Finished,
+		ExitCode:   execution.ExitCode,
+		ToolCalls:  execution.ToolCalls,
+	})
+	if errors.IsNotImplemented(err) || params.IsCodeNotImplemented(err) {
+		// Older controllers don't keep hook history.
+		err = nil
+	}
+	return err
+}
+
 // FailAction is part of the operation.Callbacks interface.
 func (opc *operationCallbacks) FailAction(actionId, message string) error {
 	if !names.IsValidAction(actionId) {
@@ -4,6 +4,8 @@
 package operation
 
 import (
+	"time"
+
 	"github.com/juju/loggo"
 	utilexec "github.com/juju/utils/exec"
 	corecharm "gopkg.in/juju/charm.v6-unstable"
@@ -138,6 +140,10 @@ type Callbacks interface {
 	NotifyHookCompleted(string, runner.Context)
 	NotifyHookFailed(string, runner.Context)
 
+	// RecordHookExecution records a run of a hook in the unit's hook
+	// history. It's only used by RunHook operations.
+	RecordHookExecution(HookExecution) error
+
 	// The following methods exist primarily to allow us to test operation code
 	// without using a live api connection.
 
@@ -156,6 +162,29 @@ type Callbacks interface {
 	SetCurrentCharm(charmURL *corecharm.URL) error
 }
 
+// HookExecution describes a single run of a hook.
+type HookExecution struct {
+	// Name is the name of the hook that was run.
+	Name string
+
+	// Info identifies the hook, and any relation or remote unit
+	// it was run for.
+	Info hook.Info
+
+	// Started and Finished record when the hook started and
+	// finished running.
+	Started  time.Time
+	Finished time.Time
+
+	// ExitCode is the exit code of the hook process, or -1
+	// if the hook could not be run to completion.
+	ExitCode int
+
+	// ToolCalls holds the names of the hook tools invoked by
+	// the hook, in the order they were called.
+	ToolCalls []string
+}
+
 // StorageUpdater is an interface used for updating local knowledge of storage
 // attachments.
 type StorageUpdater interface {
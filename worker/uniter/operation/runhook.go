@@ -5,6 +5,8 @@ package operation
 
 import (
 	"fmt"
+	"os/exec"
+	"time"
 
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6-unstable/hooks"
@@ -38,7 +40,7 @@ func (rh *runHook) String() string {
 		} else {
 			suffix = fmt.Sprintf(" (%d; %s)", rh.info.RelationId, rh.info.RemoteUnit)
 		}
-	case rh.info.Kind.IsStorage():
+	case hook.IsStorage(rh.info.Kind):
 		suffix = fmt.Sprintf(" (%s)", rh.info.StorageId)
 	}
 	return fmt.Sprintf("run %s%s hook", rh.info.Kind, suffix)
@@ -91,8 +93,13 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	ranHook := true
 	step := Done
 
+	started := time.Now()
 	err := rh.runner.RunHook(rh.name)
+	finished := time.Now()
 	cause := errors.Cause(err)
+	if !context.IsMissingHookError(cause) {
+		rh.recordExecution(started, finished, hookExitCode(cause))
+	}
 	switch {
 	case context.IsMissingHookError(cause):
 		ranHook = false
@@ -129,6 +136,39 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	}.apply(state), err
 }
 
+// recordExecution records the hook's execution in the unit's hook
+// history. Failure to do so is logged, but does not fail the hook.
+func (rh *runHook) recordExecution(started, finished time.Time, exitCode int) {
+	err := rh.callbacks.RecordHookExecution(HookExecution{
+		Name:      rh.name,
+		Info:      rh.info,
+		Started:   started,
+		Finished:  finished,
+		ExitCode:  exitCode,
+		ToolCalls: rh.runner.Context().ToolCalls(),
+	})
+	if err != nil {
+		logger.Warningf("cannot record execution of %q hook: %v", rh.name, err)
+	}
+}
+
+// hookExitCode returns the exit code of a hook process that finished
+// with the supplied error, or -1 if the hook did not run to completion.
+func hookExitCode(err error) int {
+	switch err {
+	case nil, context.ErrReboot, context.ErrRequeueAndReboot:
+		return 0
+	}
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		if status, ok := exitErr.Sys().(interface {
+			ExitStatus() int
+		}); ok {
+			return status.ExitStatus()
+		}
+	}
+	return -1
+}
+
 func (rh *runHook) beforeHook() error {
 	var err error
 	switch rh.info.Kind {
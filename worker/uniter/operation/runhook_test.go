@@ -184,6 +184,7 @@ func (s *RunHookSuite) getExecuteRunnerTest(c *gc.C, newHook newHook, kind hooks
 		PrepareHookCallbacks:    NewPrepareHookCallbacks(),
 		MockNotifyHookCompleted: &MockNotify{},
 		MockNotifyHookFailed:    &MockNotify{},
+		MockRecordHookExecution: &MockRecordHookExecution{},
 	}
 	factory := operation.NewFactory(operation.FactoryParams{
 		RunnerFactory: runnerFactory,
@@ -212,6 +213,7 @@ func (s *RunHookSuite) TestExecuteMissingHookError(c *gc.C) {
 		c.Assert(*runnerFactory.MockNewHookRunner.runner.MockRunHook.gotName, gc.Equals, "some-hook-name")
 		c.Assert(callbacks.MockNotifyHookCompleted.gotName, gc.IsNil)
 		c.Assert(callbacks.MockNotifyHookFailed.gotName, gc.IsNil)
+		c.Assert(callbacks.MockRecordHookExecution.gotExecution, gc.IsNil)
 
 		status, err := runnerFactory.MockNewHookRunner.runner.Context().UnitStatus()
 		c.Assert(err, jc.ErrorIsNil)
@@ -270,6 +272,37 @@ func (s *RunHookSuite) TestExecuteOtherError(c *gc.C) {
 	c.Assert(*callbacks.MockNotifyHookFailed.gotName, gc.Equals, "some-hook-name")
 	c.Assert(*callbacks.MockNotifyHookFailed.gotContext, gc.Equals, runnerFactory.MockNewHookRunner.runner.context)
 	c.Assert(callbacks.MockNotifyHookCompleted.gotName, gc.IsNil)
+	execution := callbacks.MockRecordHookExecution.gotExecution
+	c.Assert(execution, gc.NotNil)
+	c.Assert(execution.Name, gc.Equals, "some-hook-name")
+	c.Assert(execution.ExitCode, gc.Equals, -1)
+}
+
+func (s *RunHookSuite) TestExecuteRecordsExecution(c *gc.C) {
+	op, callbacks, _ := s.getExecuteRunnerTest(c, (operation.Factory).NewRunHook, hooks.ConfigChanged, nil)
+	_, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = op.Execute(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+	execution := callbacks.MockRecordHookExecution.gotExecution
+	c.Assert(execution, gc.NotNil)
+	c.Assert(execution.Name, gc.Equals, "some-hook-name")
+	c.Assert(execution.Info, gc.DeepEquals, hook.Info{Kind: hooks.ConfigChanged})
+	c.Assert(execution.ExitCode, gc.Equals, 0)
+	c.Assert(execution.ToolCalls, jc.DeepEquals, []string{"status-set"})
+	c.Assert(execution.Finished.Before(execution.Started), jc.IsFalse)
+}
+
+func (s *RunHookSuite) TestExecuteRecordFailureIgnored(c *gc.C) {
+	op, callbacks, _ := s.getExecuteRunnerTest(c, (operation.Factory).NewRunHook, hooks.ConfigChanged, nil)
+	callbacks.MockRecordHookExecution.err = errors.New("splat")
+	_, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	newState, err := op.Execute(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(newState.Step, gc.Equals, operation.Done)
 }
 
 func (s *RunHookSuite) testExecuteSuccess(
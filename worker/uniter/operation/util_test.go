@@ -181,10 +181,21 @@ func (mock *MockNotify) Call(hookName string, ctx runner.Context) {
 	mock.gotContext = &ctx
 }
 
+type MockRecordHookExecution struct {
+	gotExecution *operation.HookExecution
+	err          error
+}
+
+func (mock *MockRecordHookExecution) Call(execution operation.HookExecution) error {
+	mock.gotExecution = &execution
+	return mock.err
+}
+
 type ExecuteHookCallbacks struct {
 	*PrepareHookCallbacks
 	MockNotifyHookCompleted *MockNotify
 	MockNotifyHookFailed    *MockNotify
+	MockRecordHookExecution *MockRecordHookExecution
 }
 
 func (cb *ExecuteHookCallbacks) NotifyHookCompleted(hookName string, ctx runner.Context) {
@@ -195,6 +206,10 @@ func (cb *ExecuteHookCallbacks) NotifyHookFailed(hookName string, ctx runner.Con
 	cb.MockNotifyHookFailed.Call(hookName, ctx)
 }
 
+func (cb *ExecuteHookCallbacks) RecordHookExecution(execution operation.HookExecution) error {
+	return cb.MockRecordHookExecution.Call(execution)
+}
+
 type MockCommitHook struct {
 	gotHook *hook.Info
 	err     error
@@ -321,6 +336,10 @@ func (mock *MockContext) UnitStatus() (*jujuc.StatusInfo, error) {
 	return &mock.status, nil
 }
 
+func (mock *MockContext) ToolCalls() []string {
+	return []string{"status-set"}
+}
+
 func (mock *MockContext) Prepare() error {
 	mock.MethodCall(mock, "Prepare")
 	return mock.NextErr()
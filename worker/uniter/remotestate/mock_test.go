@@ -5,7 +5,9 @@ package remotestate_test
 
 import (
 	"sync"
+	"time"
 
+	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
 
@@ -97,11 +99,13 @@ func (w *mockRelationUnitsWatcher) Changes() watcher.RelationUnitsChannel {
 }
 
 type mockState struct {
-	unit                      mockUnit
-	relations                 map[names.RelationTag]*mockRelation
-	storageAttachment         map[params.StorageAttachmentId]params.StorageAttachment
-	relationUnitsWatchers     map[names.RelationTag]*mockRelationUnitsWatcher
-	storageAttachmentWatchers map[names.StorageTag]*mockNotifyWatcher
+	unit                        mockUnit
+	relations                   map[names.RelationTag]*mockRelation
+	storageAttachment           map[params.StorageAttachmentId]params.StorageAttachment
+	relationUnitsWatchers       map[names.RelationTag]*mockRelationUnitsWatcher
+	storageAttachmentWatchers   map[names.StorageTag]*mockNotifyWatcher
+	updateStatusInterval        time.Duration
+	updateStatusIntervalWatcher *mockNotifyWatcher
 }
 
 func (st *mockState) Relation(tag names.RelationTag) (remotestate.Relation, error) {
@@ -155,6 +159,10 @@ func (st *mockState) Unit(tag names.UnitTag) (remotestate.Unit, error) {
 	return &st.unit, nil
 }
 
+func (st *mockState) UpdateStatusHookInterval() (time.Duration, error) {
+	return st.updateStatusInterval, nil
+}
+
 func (st *mockState) WatchRelationUnits(
 	relationTag names.RelationTag, unitTag names.UnitTag,
 ) (watcher.RelationUnitsWatcher, error) {
@@ -181,6 +189,10 @@ func (st *mockState) WatchStorageAttachment(
 	return watcher, nil
 }
 
+func (st *mockState) WatchUpdateStatusHookInterval() (watcher.NotifyWatcher, error) {
+	return st.updateStatusIntervalWatcher, nil
+}
+
 type mockUnit struct {
 	tag                   names.UnitTag
 	life                  params.Life
@@ -240,6 +252,7 @@ type mockService struct {
 	charmModifiedVersion  int
 	forceUpgrade          bool
 	serviceWatcher        *mockNotifyWatcher
+	bindingsWatcher       *mockNotifyWatcher
 	leaderSettingsWatcher *mockNotifyWatcher
 	relationsWatcher      *mockStringsWatcher
 }
@@ -268,6 +281,13 @@ func (s *mockService) Watch() (watcher.NotifyWatcher, error) {
 	return s.serviceWatcher, nil
 }
 
+func (s *mockService) WatchEndpointBindings() (watcher.NotifyWatcher, error) {
+	if s.bindingsWatcher == nil {
+		return nil, errors.NotImplementedf("WatchEndpointBindings() (need V5+)")
+	}
+	return s.bindingsWatcher, nil
+}
+
 func (s *mockService) WatchLeadershipSettings() (watcher.NotifyWatcher, error) {
 	return s.leaderSettingsWatcher, nil
 }
@@ -4,6 +4,9 @@
 package remotestate
 
 import (
+	"time"
+
+	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
 
@@ -17,8 +20,10 @@ type State interface {
 	StorageAttachment(names.StorageTag, names.UnitTag) (params.StorageAttachment, error)
 	StorageAttachmentLife([]params.StorageAttachmentId) ([]params.LifeResult, error)
 	Unit(names.UnitTag) (Unit, error)
+	UpdateStatusHookInterval() (time.Duration, error)
 	WatchRelationUnits(names.RelationTag, names.UnitTag) (watcher.RelationUnitsWatcher, error)
 	WatchStorageAttachment(names.StorageTag, names.UnitTag) (watcher.NotifyWatcher, error)
+	WatchUpdateStatusHookInterval() (watcher.NotifyWatcher, error)
 }
 
 type Unit interface {
@@ -48,6 +53,9 @@ type Application interface {
 	Tag() names.ApplicationTag
 	// Watch returns a watcher that fires when this service changes.
 	Watch() (watcher.NotifyWatcher, error)
+	// WatchEndpointBindings returns a watcher that fires when the spaces
+	// this service's endpoints are bound to change.
+	WatchEndpointBindings() (watcher.NotifyWatcher, error)
 	// WatchLeadershipSettings returns a watcher that fires when the leadership
 	// settings for this service change.
 	WatchLeadershipSettings() (watcher.NotifyWatcher, error)
@@ -95,3 +103,20 @@ func (u apiUnit) Application() (Application, error) {
 	s, err := u.Unit.Application()
 	return apiService{s}, err
 }
+
+// UpdateStatusHookInterval returns the model's configured interval
+// between runs of the update-status hook.
+func (st apiState) UpdateStatusHookInterval() (time.Duration, error) {
+	cfg, err := st.State.ModelConfig()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return cfg.UpdateStatusHookInterval(), nil
+}
+
+// WatchUpdateStatusHookInterval returns a watcher that fires when the
+// model config, and thus possibly the update-status hook interval,
+// changes.
+func (st apiState) WatchUpdateStatusHookInterval() (watcher.NotifyWatcher, error) {
+	return st.State.WatchForModelConfigChanges()
+}
@@ -32,7 +32,7 @@ type RemoteStateWatcher struct {
 	storageAttachmentWatchers map[names.StorageTag]*storageAttachmentWatcher
 	storageAttachmentChanges  chan storageAttachmentChange
 	leadershipTracker         leadership.Tracker
-	updateStatusChannel       func() <-chan time.Time
+	updateStatusChannel       func(time.Duration) <-chan time.Time
 	commandChannel            <-chan string
 	retryHookChannel          <-chan struct{}
 
@@ -48,7 +48,7 @@ type RemoteStateWatcher struct {
 type WatcherConfig struct {
 	State               State
 	LeadershipTracker   leadership.Tracker
-	UpdateStatusChannel func() <-chan time.Time
+	UpdateStatusChannel func(time.Duration) <-chan time.Time
 	CommandChannel      <-chan string
 	RetryHookChannel    <-chan struct{}
 	UnitTag             names.UnitTag
@@ -226,6 +226,23 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 	}
 	requiredEvents++
 
+	var seenBindingsChange bool
+	var bindingsChanges watcher.NotifyChannel
+	bindingsw, err := w.service.WatchEndpointBindings()
+	if errors.IsNotImplemented(err) {
+		// Older controllers cannot watch endpoint bindings; changes
+		// to them are only seen when the uniter restarts.
+		logger.Debugf("not watching endpoint bindings: %v", err)
+	} else if err != nil {
+		return errors.Trace(err)
+	} else {
+		if err := w.catacomb.Add(bindingsw); err != nil {
+			return errors.Trace(err)
+		}
+		bindingsChanges = bindingsw.Changes()
+		requiredEvents++
+	}
+
 	var seenStorageChange bool
 	storagew, err := w.unit.WatchStorage()
 	if err != nil {
@@ -261,6 +278,20 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 	// returned by the leadership tracker.
 	requiredEvents++
 
+	// The update-status interval is not part of the snapshot, so
+	// we read it up front rather than waiting for an initial event.
+	updateStatusInterval, err := w.st.UpdateStatusHookInterval()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	updateStatusIntervalw, err := w.st.WatchUpdateStatusHookInterval()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(updateStatusIntervalw); err != nil {
+		return errors.Trace(err)
+	}
+
 	var eventsObserved int
 	observedEvent := func(flag *bool) {
 		if !*flag {
@@ -299,6 +330,7 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 		observedEvent(&seenLeadershipChange)
 	}
 
+	updateStatusTimer := w.updateStatusChannel(updateStatusInterval)
 	for {
 		select {
 		case <-w.catacomb.Dying():
@@ -344,6 +376,16 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 			}
 			observedEvent(&seenAddressesChange)
 
+		case _, ok := <-bindingsChanges:
+			logger.Debugf("got endpoint bindings change: ok=%t", ok)
+			if !ok {
+				return errors.New("endpoint bindings watcher closed")
+			}
+			if err := w.bindingsChanged(); err != nil {
+				return errors.Trace(err)
+			}
+			observedEvent(&seenBindingsChange)
+
 		case _, ok := <-leaderSettingsw.Changes():
 			logger.Debugf("got leader settings change: ok=%t", ok)
 			if !ok {
@@ -412,11 +454,29 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 				return errors.Trace(err)
 			}
 
-		case <-w.updateStatusChannel():
+		case <-updateStatusTimer:
 			logger.Debugf("update status timer triggered")
 			if err := w.updateStatusChanged(); err != nil {
 				return errors.Trace(err)
 			}
+			updateStatusTimer = w.updateStatusChannel(updateStatusInterval)
+
+		case _, ok := <-updateStatusIntervalw.Changes():
+			logger.Debugf("got update status interval change: ok=%t", ok)
+			if !ok {
+				return errors.New("update status interval watcher closed")
+			}
+			interval, err := w.st.UpdateStatusHookInterval()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if interval != updateStatusInterval {
+				logger.Debugf("update status interval changed to %v", interval)
+				updateStatusInterval = interval
+				updateStatusTimer = w.updateStatusChannel(updateStatusInterval)
+			}
+			// Nothing in the snapshot has changed.
+			continue
 
 		case id, ok := <-w.commandChannel:
 			if !ok {
@@ -517,6 +577,15 @@ func (w *RemoteStateWatcher) addressesChanged() error {
 	return nil
 }
 
+// bindingsChanged runs the config-changed hook, so the charm can
+// read the addresses of endpoints bound to different spaces.
+func (w *RemoteStateWatcher) bindingsChanged() error {
+	w.mu.Lock()
+	w.current.ConfigVersion++
+	w.mu.Unlock()
+	return nil
+}
+
 func (w *RemoteStateWatcher) leaderSettingsChanged() error {
 	w.mu.Lock()
 	w.current.LeaderSettingsVersion++
@@ -44,6 +44,7 @@ func (s *WatcherSuite) SetUpTest(c *gc.C) {
 				curl:                  charm.MustParseURL("cs:trusty/mysql"),
 				charmModifiedVersion:  5,
 				serviceWatcher:        newMockNotifyWatcher(),
+				bindingsWatcher:       newMockNotifyWatcher(),
 				leaderSettingsWatcher: newMockNotifyWatcher(),
 				relationsWatcher:      newMockStringsWatcher(),
 			},
@@ -53,10 +54,12 @@ func (s *WatcherSuite) SetUpTest(c *gc.C) {
 			storageWatcher:        newMockStringsWatcher(),
 			actionWatcher:         newMockStringsWatcher(),
 		},
-		relations:                 make(map[names.RelationTag]*mockRelation),
-		storageAttachment:         make(map[params.StorageAttachmentId]params.StorageAttachment),
-		relationUnitsWatchers:     make(map[names.RelationTag]*mockRelationUnitsWatcher),
-		storageAttachmentWatchers: make(map[names.StorageTag]*mockNotifyWatcher),
+		relations:                   make(map[names.RelationTag]*mockRelation),
+		storageAttachment:           make(map[params.StorageAttachmentId]params.StorageAttachment),
+		relationUnitsWatchers:       make(map[names.RelationTag]*mockRelationUnitsWatcher),
+		storageAttachmentWatchers:   make(map[names.StorageTag]*mockNotifyWatcher),
+		updateStatusInterval:        statusTickDuration,
+		updateStatusIntervalWatcher: newMockNotifyWatcher(),
 	}
 
 	s.leadership = &mockLeadershipTracker{
@@ -66,8 +69,8 @@ func (s *WatcherSuite) SetUpTest(c *gc.C) {
 	}
 
 	s.clock = testing.NewClock(time.Now())
-	statusTicker := func() <-chan time.Time {
-		return s.clock.After(statusTickDuration)
+	statusTicker := func(interval time.Duration) <-chan time.Time {
+		return s.clock.After(interval)
 	}
 
 	w, err := remotestate.NewWatcher(remotestate.WatcherConfig{
@@ -102,6 +105,37 @@ func (s *WatcherSuite) TestInitialSignal(c *gc.C) {
 	s.st.unit.unitWatcher.changes <- struct{}{}
 	assertNoNotifyEvent(c, s.watcher.RemoteStateChanged(), "remote state change")
 
+	s.st.unit.addressesWatcher.changes <- struct{}{}
+	s.st.unit.configSettingsWatcher.changes <- struct{}{}
+	s.st.unit.storageWatcher.changes <- []string{}
+	s.st.unit.actionWatcher.changes <- []string{}
+	s.st.unit.service.serviceWatcher.changes <- struct{}{}
+	s.st.unit.service.bindingsWatcher.changes <- struct{}{}
+	s.st.unit.service.leaderSettingsWatcher.changes <- struct{}{}
+	s.st.unit.service.relationsWatcher.changes <- []string{}
+	s.leadership.claimTicket.ch <- struct{}{}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+}
+
+func (s *WatcherSuite) TestInitialSignalEndpointBindingsNotImplemented(c *gc.C) {
+	s.watcher.Kill()
+	err := s.watcher.Wait()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Older controllers cannot watch endpoint bindings; the
+	// watcher carries on without them.
+	s.st.unit.service.bindingsWatcher = nil
+	s.watcher, err = remotestate.NewWatcher(remotestate.WatcherConfig{
+		State:             s.st,
+		LeadershipTracker: s.leadership,
+		UnitTag:           s.st.unit.tag,
+		UpdateStatusChannel: func(interval time.Duration) <-chan time.Time {
+			return s.clock.After(interval)
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.st.unit.unitWatcher.changes <- struct{}{}
 	s.st.unit.addressesWatcher.changes <- struct{}{}
 	s.st.unit.configSettingsWatcher.changes <- struct{}{}
 	s.st.unit.storageWatcher.changes <- []string{}
@@ -120,6 +154,7 @@ func signalAll(st *mockState, l *mockLeadershipTracker) {
 	st.unit.storageWatcher.changes <- []string{}
 	st.unit.actionWatcher.changes <- []string{}
 	st.unit.service.serviceWatcher.changes <- struct{}{}
+	st.unit.service.bindingsWatcher.changes <- struct{}{}
 	st.unit.service.leaderSettingsWatcher.changes <- struct{}{}
 	st.unit.service.relationsWatcher.changes <- []string{}
 	l.claimTicket.ch <- struct{}{}
@@ -138,7 +173,7 @@ func (s *WatcherSuite) TestSnapshot(c *gc.C) {
 		CharmURL:              s.st.unit.service.curl,
 		ForceCharmUpgrade:     s.st.unit.service.forceUpgrade,
 		ResolvedMode:          s.st.unit.resolved,
-		ConfigVersion:         2, // config settings and addresses
+		ConfigVersion:         3, // config settings, addresses and bindings
 		LeaderSettingsVersion: 1,
 		Leader:                true,
 	})
@@ -167,6 +202,10 @@ func (s *WatcherSuite) TestRemoteStateChanged(c *gc.C) {
 	assertOneChange()
 	c.Assert(s.watcher.Snapshot().ConfigVersion, gc.Equals, initial.ConfigVersion+2)
 
+	s.st.unit.service.bindingsWatcher.changes <- struct{}{}
+	assertOneChange()
+	c.Assert(s.watcher.Snapshot().ConfigVersion, gc.Equals, initial.ConfigVersion+3)
+
 	s.st.unit.storageWatcher.changes <- []string{}
 	assertOneChange()
 
@@ -544,3 +583,34 @@ func (s *WatcherSuite) TestUpdateStatusTicker(c *gc.C) {
 	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
 	c.Assert(s.watcher.Snapshot().UpdateStatusVersion, gc.Equals, initial.UpdateStatusVersion+2)
 }
+
+func (s *WatcherSuite) TestUpdateStatusIntervalChanged(c *gc.C) {
+	signalAll(s.st, s.leadership)
+	initial := s.watcher.Snapshot()
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	waitAlarm(c, s.clock)
+
+	// Lengthen the interval; the timer is restarted with the new interval.
+	s.st.updateStatusInterval = 2 * statusTickDuration
+	s.st.updateStatusIntervalWatcher.changes <- struct{}{}
+	waitAlarm(c, s.clock)
+	assertNoNotifyEvent(c, s.watcher.RemoteStateChanged(), "unexpected remote state change")
+
+	// Advance past the old trigger time, but not the new one.
+	s.clock.Advance(11 * time.Second)
+	assertNoNotifyEvent(c, s.watcher.RemoteStateChanged(), "unexpected remote state change")
+	c.Assert(s.watcher.Snapshot().UpdateStatusVersion, gc.Equals, initial.UpdateStatusVersion)
+
+	// And we hit the new trigger time.
+	s.clock.Advance(10 * time.Second)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().UpdateStatusVersion, gc.Equals, initial.UpdateStatusVersion+1)
+}
+
+func waitAlarm(c *gc.C, clock *testing.Clock) {
+	select {
+	case <-clock.Alarms():
+	case <-time.After(testing.LongWait):
+		c.Fatalf("timed out waiting for timer to be set")
+	}
+}
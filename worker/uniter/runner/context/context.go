@@ -6,7 +6,10 @@
 package context
 
 import (
+	"crypto/sha256"
 	"fmt"
+	"io"
+	"os"
 	"strings"
 	"sync"
 	"time"
@@ -196,6 +199,13 @@ type HookContext struct {
 	// a charm's workload status, or if the charm has already taken care of it.
 	hasRunStatusSet bool
 
+	// toolCalls holds the names of the hook tools invoked while
+	// running in this context, in the order they were called.
+	// Tools may be run concurrently, so access is guarded by
+	// toolCallsMu.
+	toolCalls   []string
+	toolCallsMu sync.Mutex
+
 	// storageAddConstraints is a collection of storage constraints
 	// keyed on storage name as specified in the charm.
 	// This collection will be added to the unit on successful
@@ -352,6 +362,12 @@ func (ctx *HookContext) SetUnitStatus(unitStatus jujuc.StatusInfo) error {
 	)
 }
 
+// SetUnitWorkloadVersion sets the version of the workload running
+// on the unit.
+func (ctx *HookContext) SetUnitWorkloadVersion(version string) error {
+	return ctx.unit.SetWorkloadVersion(version)
+}
+
 // SetApplicationStatus will set the given status to the service to which this
 // unit's belong, only if this unit is the leader.
 func (ctx *HookContext) SetApplicationStatus(serviceStatus jujuc.StatusInfo) error {
@@ -384,6 +400,27 @@ func (ctx *HookContext) ResetExecutionSetUnitStatus() {
 	ctx.hasRunStatusSet = false
 }
 
+// RecordToolCall records that the named hook tool was invoked
+// while running in this context.
+func (ctx *HookContext) RecordToolCall(name string) {
+	ctx.toolCallsMu.Lock()
+	defer ctx.toolCallsMu.Unlock()
+	ctx.toolCalls = append(ctx.toolCalls, name)
+}
+
+// ToolCalls returns the names of the hook tools invoked while
+// running in this context, in the order they were called.
+func (ctx *HookContext) ToolCalls() []string {
+	ctx.toolCallsMu.Lock()
+	defer ctx.toolCallsMu.Unlock()
+	if len(ctx.toolCalls) == 0 {
+		return nil
+	}
+	toolCalls := make([]string, len(ctx.toolCalls))
+	copy(toolCalls, ctx.toolCalls)
+	return toolCalls
+}
+
 func (ctx *HookContext) PublicAddress() (string, error) {
 	if ctx.publicAddress == "" {
 		return "", errors.NotFoundf("public address")
@@ -521,6 +558,39 @@ func (ctx *HookContext) UpdateActionResults(keys []string, value string) error {
 	return nil
 }
 
+// AttachActionFile uploads the file at the given path to the controller,
+// attaching it to the results of the running Action under the given name.
+// It returns an error if not called on an Action-containing HookContext.
+func (ctx *HookContext) AttachActionFile(name, path string) error {
+	if ctx.actionData == nil {
+		return errors.New("not running an action")
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if info.IsDir() {
+		return errors.Errorf("%q is a directory", path)
+	}
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, f); err != nil {
+		return errors.Annotatef(err, "cannot read %q", path)
+	}
+	if _, err := f.Seek(0, 0); err != nil {
+		return errors.Trace(err)
+	}
+	hash := fmt.Sprintf("%x", hasher.Sum(nil))
+	if err := ctx.state.ActionAttach(ctx.actionData.Tag, name, f, info.Size(), hash); err != nil {
+		return errors.Annotatef(err, "cannot attach %q", path)
+	}
+	return nil
+}
+
 func (ctx *HookContext) HookRelation() (jujuc.ContextRelation, error) {
 	return ctx.Relation(ctx.relationId)
 }
@@ -791,3 +861,8 @@ func (ctx *HookContext) killCharmHook() error {
 func (ctx *HookContext) NetworkConfig(bindingName string) ([]params.NetworkConfig, error) {
 	return ctx.unit.NetworkConfig(bindingName)
 }
+
+// NetworkInfo returns the network info for the given bindingNames.
+func (ctx *HookContext) NetworkInfo(bindingNames []string) (map[string]params.NetworkInfoResult, error) {
+	return ctx.unit.NetworkInfo(bindingNames)
+}
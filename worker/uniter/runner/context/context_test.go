@@ -5,6 +5,7 @@ package context_test
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/juju/testing"
@@ -135,6 +136,29 @@ func (s *InterfaceSuite) TestSetUnitStatusUpdatesFlag(c *gc.C) {
 	c.Assert(ctx.(runner.Context).HasExecutionSetUnitStatus(), jc.IsTrue)
 }
 
+func (s *InterfaceSuite) TestSetUnitWorkloadVersion(c *gc.C) {
+	ctx := s.GetContext(c, -1, "")
+	err := ctx.SetUnitWorkloadVersion("4.6.1")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.unit.WorkloadVersion(), gc.Equals, "4.6.1")
+}
+
+func (s *InterfaceSuite) TestRecordToolCall(c *gc.C) {
+	ctx := s.GetContext(c, -1, "").(runner.Context)
+	c.Assert(ctx.ToolCalls(), gc.IsNil)
+	ctx.RecordToolCall("status-set")
+	ctx.RecordToolCall("config-get")
+	toolCalls := ctx.ToolCalls()
+	c.Assert(toolCalls, jc.DeepEquals, []string{"status-set", "config-get"})
+
+	// The returned slice is a copy.
+	toolCalls[0] = "juju-log"
+	c.Assert(ctx.ToolCalls(), jc.DeepEquals, []string{"status-set", "config-get"})
+}
+
 func (s *InterfaceSuite) TestUnitStatusCaching(c *gc.C) {
 	ctx := s.GetContext(c, -1, "")
 	unitStatus, err := ctx.UnitStatus()
@@ -216,6 +240,8 @@ func (s *InterfaceSuite) TestNonActionCallsToActionMethodsFail(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "not running an action")
 	err = ctx.UpdateActionResults([]string{"1", "2", "3"}, "value")
 	c.Check(err, gc.ErrorMatches, "not running an action")
+	err = ctx.AttachActionFile("foo", "/tmp/foo")
+	c.Check(err, gc.ErrorMatches, "not running an action")
 }
 
 // TestUpdateActionResults demonstrates that UpdateActionResults functions
@@ -268,6 +294,15 @@ func (s *InterfaceSuite) TestUpdateActionResults(c *gc.C) {
 	}
 }
 
+// TestAttachActionFileDirectory ensures AttachActionFile refuses to
+// attach a directory.
+func (s *InterfaceSuite) TestAttachActionFileDirectory(c *gc.C) {
+	hctx := context.GetStubActionContext(nil)
+	dir := c.MkDir()
+	err := hctx.AttachActionFile("dir", dir)
+	c.Assert(err, gc.ErrorMatches, fmt.Sprintf("%q is a directory", dir))
+}
+
 // TestSetActionFailed ensures SetActionFailed works properly.
 func (s *InterfaceSuite) TestSetActionFailed(c *gc.C) {
 	hctx := context.GetStubActionContext(nil)
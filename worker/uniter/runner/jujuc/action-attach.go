@@ -0,0 +1,76 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/core/actions"
+)
+
+// ActionAttachCommand implements the action-attach command.
+type ActionAttachCommand struct {
+	cmd.CommandBase
+	ctx  Context
+	path string
+	name string
+}
+
+// NewActionAttachCommand returns a new ActionAttachCommand with the given context.
+func NewActionAttachCommand(ctx Context) (cmd.Command, error) {
+	return &ActionAttachCommand{ctx: ctx}, nil
+}
+
+// Info returns the content for --help.
+func (c *ActionAttachCommand) Info() *cmd.Info {
+	doc := `
+action-attach uploads the given file to the controller and attaches it to the
+results of the Action.  Attachments are listed alongside the Action's results
+and can be downloaded with "juju show-action-output --download <dir>".
+
+The attachment is named after the file unless --name is given.  Names must
+start with an alphanumeric character, and contain only alphanumerics, hyphens,
+underscores and periods.  Each name may only be attached once per Action.
+
+Example usage:
+ action-attach /tmp/dump.sql
+ action-attach --name report.txt $(mktemp)
+`
+	return &cmd.Info{
+		Name:    "action-attach",
+		Args:    "<path>",
+		Purpose: "attach a file to action results",
+		Doc:     doc,
+	}
+}
+
+// SetFlags handles known option flags.
+func (c *ActionAttachCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.name, "name", "", "name of the attachment (defaults to the file name)")
+}
+
+// Init checks that a single path was given, and that the attachment
+// name is valid.
+func (c *ActionAttachCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no path specified")
+	}
+	c.path = args[0]
+	if c.name == "" {
+		c.name = filepath.Base(c.path)
+	}
+	if !actions.IsValidAttachmentName(c.name) {
+		return errors.Errorf("attachment name %q must start with an alphanumeric, and contain only alphanumerics, hyphens, underscores and periods", c.name)
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run uploads the file and attaches it to the Action's results.
+func (c *ActionAttachCommand) Run(ctx *cmd.Context) error {
+	return c.ctx.AttachActionFile(c.name, ctx.AbsPath(c.path))
+}
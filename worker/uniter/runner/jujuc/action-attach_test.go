@@ -0,0 +1,110 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type ActionAttachSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&ActionAttachSuite{})
+
+type actionAttachContext struct {
+	jujuc.Context
+	attached map[string]string
+}
+
+func (ctx *actionAttachContext) AttachActionFile(name, path string) error {
+	if ctx.attached == nil {
+		ctx.attached = make(map[string]string)
+	}
+	ctx.attached[name] = path
+	return nil
+}
+
+type nonActionAttachContext struct {
+	jujuc.Context
+}
+
+func (ctx *nonActionAttachContext) AttachActionFile(name, path string) error {
+	return fmt.Errorf("not running an action")
+}
+
+func (s *ActionAttachSuite) TestActionAttach(c *gc.C) {
+	for i, t := range []struct {
+		summary  string
+		args     []string
+		attached map[string]string
+		errMsg   string
+		code     int
+	}{{
+		summary: "no arguments is an error",
+		errMsg:  "error: no path specified\n",
+		code:    2,
+	}, {
+		summary:  "relative path is resolved against the working directory",
+		args:     []string{"dump.sql"},
+		attached: map[string]string{"dump.sql": "dump.sql"},
+	}, {
+		summary:  "the name defaults to the file name",
+		args:     []string{"/var/log/app.log"},
+		attached: map[string]string{"app.log": "/var/log/app.log"},
+	}, {
+		summary:  "the name can be given explicitly",
+		args:     []string{"--name", "report.txt", "/tmp/tmp.xyz"},
+		attached: map[string]string{"report.txt": "/tmp/tmp.xyz"},
+	}, {
+		summary: "invalid names are rejected",
+		args:    []string{"--name", "../report", "/tmp/tmp.xyz"},
+		errMsg:  `error: attachment name "../report" must start with an alphanumeric, and contain only alphanumerics, hyphens, underscores and periods` + "\n",
+		code:    2,
+	}, {
+		summary: "extra arguments are an error",
+		args:    []string{"/tmp/a", "/tmp/b"},
+		errMsg:  "error: unrecognized args: [\"/tmp/b\"]\n",
+		code:    2,
+	}} {
+		c.Logf("test %d: %s", i, t.summary)
+		hctx := &actionAttachContext{}
+		com, err := jujuc.NewCommand(hctx, cmdString("action-attach"))
+		c.Assert(err, jc.ErrorIsNil)
+		ctx := testing.Context(c)
+		code := cmd.Main(com, ctx, t.args)
+		c.Check(code, gc.Equals, t.code)
+		c.Check(bufferString(ctx.Stderr), gc.Equals, t.errMsg)
+		expected := make(map[string]string)
+		for name, path := range t.attached {
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(ctx.Dir, path)
+			}
+			expected[name] = path
+		}
+		if len(expected) == 0 {
+			expected = nil
+		}
+		c.Check(hctx.attached, jc.DeepEquals, expected)
+	}
+}
+
+func (s *ActionAttachSuite) TestNonActionAttachFails(c *gc.C) {
+	hctx := &nonActionAttachContext{}
+	com, err := jujuc.NewCommand(hctx, cmdString("action-attach"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, []string{"/tmp/dump.sql"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "error: not running an action\n")
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "")
+}
@@ -0,0 +1,55 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type applicationVersionSetSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&applicationVersionSetSuite{})
+
+func (s *applicationVersionSetSuite) createCommand(c *gc.C) (*Context, cmd.Command) {
+	hctx := s.GetHookContext(c, -1, "")
+	com, err := jujuc.NewCommand(hctx, cmdString("application-version-set"))
+	c.Assert(err, jc.ErrorIsNil)
+	return hctx, com
+}
+
+func (s *applicationVersionSetSuite) TestInitErrors(c *gc.C) {
+	_, com := s.createCommand(c)
+	testing.TestInit(c, com, nil, "no version specified")
+
+	_, com = s.createCommand(c)
+	testing.TestInit(c, com, []string{"1.2", "extra"}, `unrecognized args: \["extra"\]`)
+}
+
+func (s *applicationVersionSetSuite) TestSetVersion(c *gc.C) {
+	hctx, com := s.createCommand(c)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, []string{"2.5.1-ubuntu1"})
+	c.Assert(code, gc.Equals, 0)
+	c.Assert(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Assert(bufferString(ctx.Stdout), gc.Equals, "")
+	c.Assert(hctx.info.WorkloadVersion, gc.Equals, "2.5.1-ubuntu1")
+	s.Stub.CheckCall(c, 0, "SetUnitWorkloadVersion", "2.5.1-ubuntu1")
+}
+
+func (s *applicationVersionSetSuite) TestSetVersionError(c *gc.C) {
+	s.Stub.SetErrors(errors.New("uh oh"))
+	_, com := s.createCommand(c)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, []string{"2.5.1"})
+	c.Assert(code, gc.Equals, 1)
+	c.Assert(bufferString(ctx.Stderr), gc.Equals, "error: uh oh\n")
+}
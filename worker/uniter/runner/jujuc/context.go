@@ -96,6 +96,11 @@ type actionHookContext interface {
 
 	// SetActionFailed sets a failure state for the Action.
 	SetActionFailed() error
+
+	// AttachActionFile uploads the file at the given path to the
+	// controller, attaching it to the results of the Action under
+	// the given name.
+	AttachActionFile(name, path string) error
 }
 
 // ContextUnit is the part of a hook context related to the unit.
@@ -105,6 +110,10 @@ type ContextUnit interface {
 
 	// Config returns the current service configuration of the executing unit.
 	ConfigSettings() (charm.Settings, error)
+
+	// SetUnitWorkloadVersion sets the version of the workload
+	// running on the executing unit.
+	SetUnitWorkloadVersion(version string) error
 }
 
 // ContextStatus is the part of a hook context related to the unit's status.
@@ -166,6 +175,10 @@ type ContextNetworking interface {
 	//
 	// LKK Card: https://canonical.leankit.com/Boards/View/101652562/119258804
 	NetworkConfig(bindingName string) ([]params.NetworkConfig, error)
+
+	// NetworkInfo returns the network info for the unit and each of the
+	// given bindingNames, keyed by binding name.
+	NetworkInfo(bindingNames []string) (map[string]params.NetworkInfoResult, error)
 }
 
 // ContextLeadership is the part of a hook context related to the
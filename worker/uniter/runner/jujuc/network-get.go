@@ -9,6 +9,8 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
 )
 
 // NetworkGetCommand implements the network-get command.
@@ -29,11 +31,16 @@ func NewNetworkGetCommand(ctx Context) (cmd.Command, error) {
 
 // Info is part of the cmd.Command interface.
 func (c *NetworkGetCommand) Info() *cmd.Info {
-	args := "<binding-name> --primary-address"
+	args := "<binding-name> [--primary-address]"
 	doc := `
-network-get returns the network config for a given binding name. The only
-supported flag for now is --primary-address, which is required and returns
-the IP address the local unit should advertise as its endpoint to its peers.
+network-get returns the network config for a given binding name. By default
+it returns the devices and addresses of the unit's machine bound to the
+endpoint's space (bind-addresses), the addresses other units should use to
+reach the unit (ingress-addresses), and the subnets the unit's traffic
+originates from (egress-subnets).
+
+If --primary-address is given, only the IP address the local unit should
+advertise as its endpoint to its peers is returned.
 `
 	return &cmd.Info{
 		Name:    "network-get",
@@ -59,26 +66,74 @@ func (c *NetworkGetCommand) Init(args []string) error {
 	if c.bindingName == "" {
 		return fmt.Errorf("no binding name specified")
 	}
+	return cmd.CheckEmpty(args[1:])
+}
 
-	if !c.primaryAddress {
-		return fmt.Errorf("--primary-address is currently required")
-	}
+// interfaceAddress describes a single address of a device.
+type interfaceAddress struct {
+	Address string `json:"address" yaml:"address"`
+	CIDR    string `json:"cidr" yaml:"cidr"`
+}
 
-	return cmd.CheckEmpty(args[1:])
+// bindAddress describes a device and its addresses bound to the
+// endpoint's space.
+type bindAddress struct {
+	MACAddress    string             `json:"mac-address" yaml:"mac-address"`
+	InterfaceName string             `json:"interface-name" yaml:"interface-name"`
+	Addresses     []interfaceAddress `json:"addresses" yaml:"addresses"`
 }
 
-func (c *NetworkGetCommand) Run(ctx *cmd.Context) error {
-	netConfig, err := c.ctx.NetworkConfig(c.bindingName)
-	if err != nil {
-		return errors.Trace(err)
+// networkInfo is the output of network-get without --primary-address.
+type networkInfo struct {
+	BindAddresses    []bindAddress `json:"bind-addresses" yaml:"bind-addresses"`
+	IngressAddresses []string      `json:"ingress-addresses" yaml:"ingress-addresses"`
+	EgressSubnets    []string      `json:"egress-subnets" yaml:"egress-subnets"`
+}
+
+func formatNetworkInfo(result params.NetworkInfoResult) networkInfo {
+	info := networkInfo{
+		BindAddresses:    []bindAddress{},
+		IngressAddresses: result.IngressAddresses,
+		EgressSubnets:    result.EgressSubnets,
 	}
-	if len(netConfig) < 1 {
-		return fmt.Errorf("no network config found for binding %q", c.bindingName)
+	for _, device := range result.Info {
+		bind := bindAddress{
+			MACAddress:    device.MACAddress,
+			InterfaceName: device.InterfaceName,
+		}
+		for _, addr := range device.Addresses {
+			bind.Addresses = append(bind.Addresses, interfaceAddress{
+				Address: addr.Address,
+				CIDR:    addr.CIDR,
+			})
+		}
+		info.BindAddresses = append(info.BindAddresses, bind)
 	}
+	return info
+}
 
+func (c *NetworkGetCommand) Run(ctx *cmd.Context) error {
 	if c.primaryAddress {
+		netConfig, err := c.ctx.NetworkConfig(c.bindingName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if len(netConfig) < 1 {
+			return fmt.Errorf("no network config found for binding %q", c.bindingName)
+		}
 		return c.out.Write(ctx, netConfig[0].Address)
 	}
 
-	return nil // never reached as --primary-address is required.
+	results, err := c.ctx.NetworkInfo([]string{c.bindingName})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	result, ok := results[c.bindingName]
+	if !ok {
+		return fmt.Errorf("no network info found for binding %q", c.bindingName)
+	}
+	if result.Error != nil {
+		return errors.Trace(result.Error)
+	}
+	return c.out.Write(ctx, formatNetworkInfo(result))
 }
@@ -40,6 +40,25 @@ func (s *NetworkGetSuite) createCommand(c *gc.C) cmd.Command {
 	}
 	hctx.info.NetworkInterface.BindingsToNetworkConfigs = presetBindings
 
+	presetInfo := make(map[string]params.NetworkInfoResult)
+	presetInfo["known-relation"] = params.NetworkInfoResult{
+		Info: []params.NetworkInfo{{
+			MACAddress:    "00:11:22:33:44:00",
+			InterfaceName: "eth0",
+			Addresses: []params.InterfaceAddress{
+				{Address: "10.10.0.23", CIDR: "10.10.0.0/24"},
+				{Address: "192.168.1.111", CIDR: "192.168.1.0/24"},
+			},
+		}},
+		IngressAddresses: []string{"10.10.0.23", "192.168.1.111"},
+		EgressSubnets:    []string{"10.10.0.23/32", "192.168.1.111/32"},
+	}
+	presetInfo["known-unbound"] = params.NetworkInfoResult{
+		IngressAddresses: []string{"10.33.1.8"},
+		EgressSubnets:    []string{"10.33.1.8/32"},
+	}
+	hctx.info.NetworkInterface.BindingsToNetworkInfo = presetInfo
+
 	com, err := jujuc.NewCommand(hctx, cmdString("network-get"))
 	c.Assert(err, jc.ErrorIsNil)
 	return com
@@ -62,10 +81,50 @@ func (s *NetworkGetSuite) TestNetworkGet(c *gc.C) {
 		args:    []string{""},
 		out:     `no binding name specified`,
 	}, {
-		summary: "binding name given, no --primary-address given",
+		summary: "too many arguments",
 		code:    2,
-		args:    []string{"foo"},
-		out:     `--primary-address is currently required`,
+		args:    []string{"known-relation", "extra"},
+		out:     `unrecognized args: \["extra"\]`,
+	}, {
+		summary: "unknown binding given",
+		args:    []string{"unknown"},
+		code:    1,
+		out:     "insert server error for unknown binding here",
+	}, {
+		summary: "explicitly bound relation name given",
+		args:    []string{"known-relation"},
+		out: `
+bind-addresses:
+- mac-address: "00:11:22:33:44:00"
+  interface-name: eth0
+  addresses:
+  - address: 10.10.0.23
+    cidr: 10.10.0.0/24
+  - address: 192.168.1.111
+    cidr: 192.168.1.0/24
+ingress-addresses:
+- 10.10.0.23
+- 192.168.1.111
+egress-subnets:
+- 10.10.0.23/32
+- 192.168.1.111/32`[1:],
+	}, {
+		summary: "explicitly bound relation name given, json format",
+		args:    []string{"known-relation", "--format", "json"},
+		out: `{"bind-addresses":[{"mac-address":"00:11:22:33:44:00","interface-name":"eth0",` +
+			`"addresses":[{"address":"10.10.0.23","cidr":"10.10.0.0/24"},` +
+			`{"address":"192.168.1.111","cidr":"192.168.1.0/24"}]}],` +
+			`"ingress-addresses":["10.10.0.23","192.168.1.111"],` +
+			`"egress-subnets":["10.10.0.23/32","192.168.1.111/32"]}`,
+	}, {
+		summary: "implicitly bound binding name given",
+		args:    []string{"known-unbound"},
+		out: `
+bind-addresses: []
+ingress-addresses:
+- 10.33.1.8
+egress-subnets:
+- 10.33.1.8/32`[1:],
 	}, {
 		summary: "unknown binding given, with --primary-address",
 		args:    []string{"unknown", "--primary-address"},
@@ -112,7 +171,7 @@ func (s *NetworkGetSuite) TestNetworkGet(c *gc.C) {
 func (s *NetworkGetSuite) TestHelp(c *gc.C) {
 
 	var helpTemplate = `
-Usage: network-get [options] <binding-name> --primary-address
+Usage: network-get [options] <binding-name> [--primary-address]
 
 Summary:
 get network config
@@ -126,9 +185,14 @@ Options:
     get the primary address for the binding
 
 Details:
-network-get returns the network config for a given binding name. The only
-supported flag for now is --primary-address, which is required and returns
-the IP address the local unit should advertise as its endpoint to its peers.
+network-get returns the network config for a given binding name. By default
+it returns the devices and addresses of the unit's machine bound to the
+endpoint's space (bind-addresses), the addresses other units should use to
+reach the unit (ingress-addresses), and the subnets the unit's traffic
+originates from (egress-subnets).
+
+If --primary-address is given, only the IP address the local unit should
+advertise as its endpoint to its peers is returned.
 `[1:]
 
 	com := s.createCommand(c)
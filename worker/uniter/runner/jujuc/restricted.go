@@ -25,6 +25,9 @@ type RestrictedContext struct{}
 // ConfigSettings implements jujuc.Context.
 func (*RestrictedContext) ConfigSettings() (charm.Settings, error) { return nil, ErrRestrictedContext }
 
+// SetUnitWorkloadVersion implements jujuc.Context.
+func (*RestrictedContext) SetUnitWorkloadVersion(string) error { return ErrRestrictedContext }
+
 // UnitStatus implements jujuc.Context.
 func (*RestrictedContext) UnitStatus() (*StatusInfo, error) { return nil, ErrRestrictedContext }
 
@@ -69,6 +72,11 @@ func (*RestrictedContext) NetworkConfig(bindingName string) ([]params.NetworkCon
 	return nil, ErrRestrictedContext
 }
 
+// NetworkInfo implements jujuc.Context.
+func (*RestrictedContext) NetworkInfo(bindingNames []string) (map[string]params.NetworkInfoResult, error) {
+	return nil, ErrRestrictedContext
+}
+
 // IsLeader implements jujuc.Context.
 func (*RestrictedContext) IsLeader() (bool, error) { return false, ErrRestrictedContext }
 
@@ -133,6 +141,9 @@ func (*RestrictedContext) SetActionMessage(string) error { return ErrRestrictedC
 // SetActionFailed implements jujuc.Context.
 func (*RestrictedContext) SetActionFailed() error { return ErrRestrictedContext }
 
+// AttachActionFile implements jujuc.Context.
+func (*RestrictedContext) AttachActionFile(name, path string) error { return ErrRestrictedContext }
+
 // Component implements jujc.Context.
 func (*RestrictedContext) Component(string) (ContextComponent, error) {
 	return nil, ErrRestrictedContext
@@ -265,6 +265,7 @@ var newCommandTests = []struct {
 	{"storage-get", ""},
 	{"status-get", ""},
 	{"status-set", ""},
+	{"application-version-set", ""},
 	// The error message contains .exe on Windows
 	{"random", "unknown command: random(.exe)?"},
 }
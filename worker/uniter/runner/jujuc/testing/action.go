@@ -69,3 +69,16 @@ func (c *ContextActionHook) SetActionFailed() error {
 	}
 	return nil
 }
+
+// AttachActionFile implements jujuc.ActionHookContext.
+func (c *ContextActionHook) AttachActionFile(name, path string) error {
+	c.stub.AddCall("AttachActionFile", name, path)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	if c.info.ActionParams == nil {
+		return errors.Errorf("not running an action")
+	}
+	return nil
+}
@@ -18,6 +18,7 @@ type NetworkInterface struct {
 	PrivateAddress           string
 	Ports                    []network.PortRange
 	BindingsToNetworkConfigs map[string][]params.NetworkConfig
+	BindingsToNetworkInfo    map[string]params.NetworkInfoResult
 }
 
 // CheckPorts checks the current ports.
@@ -116,3 +117,23 @@ func (c *ContextNetworking) NetworkConfig(bindingName string) ([]params.NetworkC
 	}
 	return netConfig, nil
 }
+
+// NetworkInfo implements jujuc.ContextNetworking.
+func (c *ContextNetworking) NetworkInfo(bindingNames []string) (map[string]params.NetworkInfoResult, error) {
+	c.stub.AddCall("NetworkInfo", bindingNames)
+	if err := c.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	results := make(map[string]params.NetworkInfoResult)
+	for _, bindingName := range bindingNames {
+		info, isBindingKnown := c.info.BindingsToNetworkInfo[bindingName]
+		if !isBindingKnown {
+			info = params.NetworkInfoResult{
+				Error: &params.Error{Message: "insert server error for unknown binding here"},
+			}
+		}
+		results[bindingName] = info
+	}
+	return results, nil
+}
@@ -10,8 +10,9 @@ import (
 
 // Unit holds the values for the hook context.
 type Unit struct {
-	Name           string
-	ConfigSettings charm.Settings
+	Name            string
+	ConfigSettings  charm.Settings
+	WorkloadVersion string
 }
 
 // ContextUnit is a test double for jujuc.ContextUnit.
@@ -37,3 +38,14 @@ func (c *ContextUnit) ConfigSettings() (charm.Settings, error) {
 
 	return c.info.ConfigSettings, nil
 }
+
+// SetUnitWorkloadVersion implements jujuc.ContextUnit.
+func (c *ContextUnit) SetUnitWorkloadVersion(version string) error {
+	c.stub.AddCall("SetUnitWorkloadVersion", version)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	c.info.WorkloadVersion = version
+	return nil
+}
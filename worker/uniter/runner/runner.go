@@ -52,6 +52,8 @@ type Context interface {
 	SetProcess(process context.HookProcess)
 	HasExecutionSetUnitStatus() bool
 	ResetExecutionSetUnitStatus()
+	RecordToolCall(name string)
+	ToolCalls() []string
 
 	Prepare() error
 	Flush(badge string, failure error) error
@@ -271,7 +273,12 @@ func (runner *runner) startJujucServer() (*jujuc.Server, error) {
 		if ctxId != runner.context.Id() {
 			return nil, errors.Errorf("expected context id %q, got %q", runner.context.Id(), ctxId)
 		}
-		return jujuc.NewCommand(runner.context, cmdName)
+		c, err := jujuc.NewCommand(runner.context, cmdName)
+		if err != nil {
+			return nil, err
+		}
+		runner.context.RecordToolCall(cmdName)
+		return c, nil
 	}
 	srv, err := jujuc.NewServer(getCmd, runner.paths.GetJujucSocket())
 	if err != nil {
@@ -48,6 +48,10 @@ type StorageAccessor interface {
 type storageAttachment struct {
 	*stateFile
 	jujuc.ContextStorageAttachment
+
+	// hookSize is the size of the storage, in MiB, as
+	// reported to the hook being run.
+	hookSize uint64
 }
 
 // Attachments generates storage hooks in response to changes to
@@ -131,6 +135,12 @@ func (a *Attachments) init() error {
 				storageTag.Id(),
 			)
 		}
+		if stateFile.size == 0 {
+			// The state file was written before sizes were
+			// recorded; take the current size as the baseline
+			// against which future resizes are compared.
+			stateFile.size = attachment.Size
+		}
 		a.storageAttachments[storageTag] = storageAttachment{
 			stateFile,
 			&contextStorage{
@@ -138,6 +148,7 @@ func (a *Attachments) init() error {
 				kind:     storage.StorageKind(attachment.Kind),
 				location: attachment.Location,
 			},
+			stateFile.size,
 		}
 	}
 	for storageTag := range attachmentsByTag {
@@ -210,7 +221,8 @@ func (a *Attachments) CommitHook(hi hook.Info) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
-	if err := storageState.CommitHook(hi); err != nil {
+	storageAttachment := a.storageAttachments[names.NewStorageTag(hi.StorageId)]
+	if err := storageState.commitHook(hi, storageAttachment.hookSize); err != nil {
 		return err
 	}
 	storageTag := names.NewStorageTag(hi.StorageId)
@@ -235,7 +247,7 @@ func (a *Attachments) removeStorageAttachment(tag names.StorageTag) error {
 }
 
 func (a *Attachments) storageStateForHook(hi hook.Info) (*stateFile, error) {
-	if !hi.Kind.IsStorage() {
+	if !hook.IsStorage(hi.Kind) {
 		return nil, errors.Errorf("not a storage hook: %#v", hi)
 	}
 	storageAttachment, ok := a.storageAttachments[names.NewStorageTag(hi.StorageId)]
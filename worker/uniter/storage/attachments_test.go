@@ -313,6 +313,73 @@ func (s *attachmentsSuite) TestAttachmentsCommitHook(c *gc.C) {
 	c.Assert(removed, jc.IsTrue)
 }
 
+func (s *attachmentsSuite) TestAttachmentsResized(c *gc.C) {
+	stateDir := c.MkDir()
+	unitTag := names.NewUnitTag("mysql/0")
+	abort := make(chan struct{})
+
+	storageTag := names.NewStorageTag("data/0")
+	st := &mockStorageAccessor{
+		unitStorageAttachments: func(u names.UnitTag) ([]params.StorageAttachmentId, error) {
+			return nil, nil
+		},
+	}
+
+	att, err := storage.NewAttachments(st, unitTag, stateDir, abort)
+	c.Assert(err, jc.ErrorIsNil)
+	r := storage.NewResolver(att)
+
+	localState := resolver.LocalState{State: operation.State{
+		Kind: operation.Continue,
+	}}
+	nextOp := func(size uint64) (operation.Operation, error) {
+		return r.NextOp(localState, remotestate.Snapshot{
+			Life: params.Alive,
+			Storage: map[names.StorageTag]remotestate.StorageSnapshot{
+				storageTag: {
+					Kind:     params.StorageKindBlock,
+					Life:     params.Alive,
+					Location: "/dev/sdb",
+					Attached: true,
+					Size:     size,
+				},
+			},
+		}, &mockOperations{})
+	}
+
+	op, err := nextOp(1024)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run hook storage-attached")
+	err = att.CommitHook(hook.Info{
+		Kind:      hooks.StorageAttached,
+		StorageId: storageTag.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	stateFile := filepath.Join(stateDir, "data-0")
+	data, err := ioutil.ReadFile(stateFile)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "attached: true\nsize: 1024\n")
+
+	// No hook is run until the storage grows.
+	_, err = nextOp(1024)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+
+	op, err = nextOp(2048)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run hook storage-resized")
+	err = att.CommitHook(hook.Info{
+		Kind:      hook.StorageResized,
+		StorageId: storageTag.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	data, err = ioutil.ReadFile(stateFile)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "attached: true\nsize: 2048\n")
+
+	_, err = nextOp(2048)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+}
+
 func (s *attachmentsSuite) TestAttachmentsSetDying(c *gc.C) {
 	stateDir := c.MkDir()
 	unitTag := names.NewUnitTag("mysql/0")
@@ -136,8 +136,12 @@ func (s *storageResolver) nextHookOp(
 		storageAttachment, ok := s.storage.storageAttachments[tag]
 		if ok && storageAttachment.attached {
 			// Once the storage is attached, we only care about
-			// lifecycle state changes.
-			return nil, resolver.ErrNoOperation
+			// lifecycle state changes, and the storage growing.
+			if snap.Size <= storageAttachment.stateFile.size {
+				return nil, resolver.ErrNoOperation
+			}
+			hookInfo.Kind = hook.StorageResized
+			break
 		}
 		// The storage-attached hook has not been committed, so add the
 		// storage to the pending set.
@@ -176,6 +180,7 @@ func (s *storageResolver) nextHookOp(
 			kind:     storage.StorageKind(snap.Kind),
 			location: snap.Location,
 		},
+		snap.Size,
 	}
 
 	return opFactory.NewRunHook(hookInfo)
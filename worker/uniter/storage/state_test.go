@@ -197,6 +197,8 @@ func (s *stateSuite) TestValidateHook(c *gc.C) {
 
 	assertValidates(false, hooks.StorageAttached)
 	assertValidates(true, hooks.StorageDetaching)
+	assertValidates(true, hook.StorageResized)
+	assertValidateFails(false, hook.StorageResized, `inappropriate "storage-resized" hook for storage "data/0": storage not attached`)
 	assertValidateFails(false, hooks.StorageDetaching, `inappropriate "storage-detaching" hook for storage "data/0": storage not attached`)
 	assertValidateFails(true, hooks.StorageAttached, `inappropriate "storage-attached" hook for storage "data/0": storage already attached`)
 }
@@ -4,21 +4,28 @@
 package uniter
 
 import (
+	"math/rand"
 	"time"
 )
 
 const (
-	// interval at which the unit's status should be polled
-	statusPollInterval = 5 * time.Minute
+	// maxUpdateStatusJitter is the largest fraction of the update-status
+	// interval by which each signal is randomly brought forward or
+	// delayed, so that units sharing a model don't all run their
+	// update-status hooks at the same moment.
+	maxUpdateStatusJitter = 0.2
 )
 
-// updateStatusSignal returns a time channel that fires after a given interval.
-func updateStatusSignal() <-chan time.Time {
-	// TODO(fwereade): 2016-03-17 lp:1558657
-	return time.After(statusPollInterval)
+// updateStatusSignal returns a function that, given an interval, returns
+// a time channel that fires after that interval adjusted by a random jitter.
+func updateStatusSignal(r *rand.Rand) func(time.Duration) <-chan time.Time {
+	return func(interval time.Duration) <-chan time.Time {
+		jitter := (r.Float64()*2 - 1) * maxUpdateStatusJitter * float64(interval)
+		return time.After(interval + time.Duration(jitter))
+	}
 }
 
 // NewUpdateStatusTimer returns a timed signal suitable for update-status hook.
-func NewUpdateStatusTimer() func() <-chan time.Time {
-	return updateStatusSignal
+func NewUpdateStatusTimer() func(time.Duration) <-chan time.Time {
+	return updateStatusSignal(rand.New(rand.NewSource(time.Now().UnixNano())))
 }
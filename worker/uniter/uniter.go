@@ -92,8 +92,8 @@ type Uniter struct {
 	observer UniterExecutionObserver
 
 	// updateStatusAt defines a function that will be used to generate signals for
-	// the update-status hook
-	updateStatusAt func() <-chan time.Time
+	// the update-status hook at the model's configured interval
+	updateStatusAt func(time.Duration) <-chan time.Time
 
 	// hookRetryStrategy represents configuration for hook retries
 	hookRetryStrategy params.RetryStrategy
@@ -112,7 +112,7 @@ type UniterParams struct {
 	Downloader           charm.Downloader
 	MachineLockName      string
 	CharmDirGuard        fortress.Guard
-	UpdateStatusSignal   func() <-chan time.Time
+	UpdateStatusSignal   func(time.Duration) <-chan time.Time
 	HookRetryStrategy    params.RetryStrategy
 	NewOperationExecutor NewExecutorFunc
 	Clock                clock.Clock
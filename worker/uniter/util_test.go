@@ -1881,7 +1881,7 @@ func (t *manualTicker) Tick() error {
 }
 
 // ReturnTimer can be used to replace the update status signal generator.
-func (t *manualTicker) ReturnTimer() <-chan time.Time {
+func (t *manualTicker) ReturnTimer(time.Duration) <-chan time.Time {
 	return t.c
 }
 
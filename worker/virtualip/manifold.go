@@ -0,0 +1,96 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package virtualip
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/dependency"
+)
+
+// ManifoldConfig defines the names of the manifolds on which a
+// virtual IP worker depends, and the functions used to create it.
+type ManifoldConfig struct {
+	AgentName             string
+	APICallerName         string
+	LeadershipTrackerName string
+
+	NewFacade func(base.APICaller, names.UnitTag) (Facade, error)
+	NewWorker func(Config) (worker.Worker, error)
+}
+
+// validate is called by start to check for bad configuration.
+func (config ManifoldConfig) validate() error {
+	if config.AgentName == "" {
+		return errors.NotValidf("empty AgentName")
+	}
+	if config.APICallerName == "" {
+		return errors.NotValidf("empty APICallerName")
+	}
+	if config.LeadershipTrackerName == "" {
+		return errors.NotValidf("empty LeadershipTrackerName")
+	}
+	if config.NewFacade == nil {
+		return errors.NotValidf("nil NewFacade")
+	}
+	if config.NewWorker == nil {
+		return errors.NotValidf("nil NewWorker")
+	}
+	return nil
+}
+
+// start is a StartFunc for a Worker manifold.
+func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, error) {
+	if err := config.validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var agent agent.Agent
+	if err := context.Get(config.AgentName, &agent); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var apiCaller base.APICaller
+	if err := context.Get(config.APICallerName, &apiCaller); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var tracker leadership.Tracker
+	if err := context.Get(config.LeadershipTrackerName, &tracker); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	tag := agent.CurrentConfig().Tag()
+	unitTag, ok := tag.(names.UnitTag)
+	if !ok {
+		return nil, errors.Errorf("expected a unit tag, got %v", tag)
+	}
+	facade, err := config.NewFacade(apiCaller, unitTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	worker, err := config.NewWorker(Config{
+		Facade:  facade,
+		Tracker: tracker,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return worker, nil
+}
+
+// Manifold returns a dependency manifold that runs a virtual IP
+// worker, using the resource names defined in the supplied config.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.AgentName,
+			config.APICallerName,
+			config.LeadershipTrackerName,
+		},
+		Start: config.start,
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package virtualip
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/uniter"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker"
+)
+
+// NewFacade returns a Facade backed by the uniter API for the
+// given unit.
+func NewFacade(apiCaller base.APICaller, unitTag names.UnitTag) (Facade, error) {
+	unit, err := uniter.NewState(apiCaller, unitTag).Unit(unitTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &facade{unit}, nil
+}
+
+// NewWorker returns a worker.Worker that runs a virtual IP worker
+// with the given config.
+func NewWorker(config Config) (worker.Worker, error) {
+	worker, err := New(config)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return worker, nil
+}
+
+type facade struct {
+	unit *uniter.Unit
+}
+
+// WatchApplication is part of the Facade interface.
+func (f *facade) WatchApplication() (watcher.NotifyWatcher, error) {
+	application, err := f.unit.Application()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return application.Watch()
+}
+
+// ClaimVirtualIPAddress is part of the Facade interface.
+func (f *facade) ClaimVirtualIPAddress() (string, error) {
+	return f.unit.ClaimVirtualIPAddress()
+}
@@ -0,0 +1,110 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package virtualip_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+
+	"github.com/juju/juju/core/leadership"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type mockFacade struct {
+	stub    *testing.Stub
+	changes chan struct{}
+	claims  chan string
+	address string
+}
+
+func newMockFacade(stub *testing.Stub, address string) *mockFacade {
+	return &mockFacade{
+		stub:    stub,
+		changes: make(chan struct{}),
+		claims:  make(chan string, 10),
+		address: address,
+	}
+}
+
+func (mock *mockFacade) WatchApplication() (watcher.NotifyWatcher, error) {
+	mock.stub.AddCall("WatchApplication")
+	if err := mock.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return &mockWatcher{
+		Worker:  workertest.NewErrorWorker(nil),
+		changes: mock.changes,
+	}, nil
+}
+
+func (mock *mockFacade) ClaimVirtualIPAddress() (string, error) {
+	mock.stub.AddCall("ClaimVirtualIPAddress")
+	if err := mock.stub.NextErr(); err != nil {
+		return "", err
+	}
+	mock.claims <- mock.address
+	return mock.address, nil
+}
+
+type mockWatcher struct {
+	worker.Worker
+	changes chan struct{}
+}
+
+func (mock *mockWatcher) Changes() watcher.NotifyChannel {
+	return mock.changes
+}
+
+// mockTracker is a leadership.Tracker whose leadership is granted
+// and revoked by the test via its leader and minion channels.
+type mockTracker struct {
+	leadership.Tracker
+	leader chan bool
+	minion chan bool
+}
+
+func newMockTracker() *mockTracker {
+	return &mockTracker{
+		leader: make(chan bool),
+		minion: make(chan bool),
+	}
+}
+
+func (mock *mockTracker) WaitLeader() leadership.Ticket {
+	return newMockTicket(mock.leader)
+}
+
+func (mock *mockTracker) WaitMinion() leadership.Ticket {
+	return newMockTicket(mock.minion)
+}
+
+type mockTicket struct {
+	ready  chan struct{}
+	result bool
+}
+
+func newMockTicket(results <-chan bool) *mockTicket {
+	ticket := &mockTicket{ready: make(chan struct{})}
+	go func() {
+		select {
+		case ticket.result = <-results:
+			close(ticket.ready)
+		case <-time.After(coretesting.LongWait):
+		}
+	}()
+	return ticket
+}
+
+func (ticket *mockTicket) Wait() bool {
+	<-ticket.ready
+	return ticket.result
+}
+
+func (ticket *mockTicket) Ready() <-chan struct{} {
+	return ticket.ready
+}
@@ -0,0 +1,147 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package virtualip provides a worker that keeps the virtual IP
+// address of a unit's application assigned to the unit's machine
+// while the unit is the application's leader.
+package virtualip
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.virtualip")
+
+// Facade exposes capabilities required by the worker.
+type Facade interface {
+	WatchApplication() (watcher.NotifyWatcher, error)
+	ClaimVirtualIPAddress() (string, error)
+}
+
+// Config holds the configuration and dependencies for a worker.
+type Config struct {
+	Facade  Facade
+	Tracker leadership.Tracker
+}
+
+// Validate returns an error if the config cannot be expected
+// to drive a functional worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Tracker == nil {
+		return errors.NotValidf("nil Tracker")
+	}
+	return nil
+}
+
+// New returns a worker that claims the virtual IP address of the
+// unit's application whenever the unit becomes the application's
+// leader, and again whenever the application changes while the unit
+// remains leader.
+func New(config Config) (*Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Worker claims the application's virtual IP address for the unit
+// while the unit is leader.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+	address  string
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	watcher, err := w.config.Facade.WatchApplication()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(watcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	var leader bool
+	leaderTicket := w.config.Tracker.WaitLeader()
+	leaderReady := leaderTicket.Ready()
+	var minionTicket leadership.Ticket
+	var minionReady <-chan struct{}
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-leaderReady:
+			leaderReady = nil
+			if !leaderTicket.Wait() {
+				return errors.New("leadership tracker stopped")
+			}
+			leader = true
+			if err := w.claim(); err != nil {
+				return errors.Trace(err)
+			}
+			minionTicket = w.config.Tracker.WaitMinion()
+			minionReady = minionTicket.Ready()
+		case <-minionReady:
+			minionReady = nil
+			if !minionTicket.Wait() {
+				return errors.New("leadership tracker stopped")
+			}
+			// The controller moves the address to the new leader's
+			// machine when that leader claims it.
+			leader = false
+			w.address = ""
+			leaderTicket = w.config.Tracker.WaitLeader()
+			leaderReady = leaderTicket.Ready()
+		case _, ok := <-watcher.Changes():
+			if !ok {
+				return errors.New("application watcher closed")
+			}
+			if !leader {
+				continue
+			}
+			if err := w.claim(); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+// claim assigns the application's virtual IP address, if it has one,
+// to the unit's machine.
+func (w *Worker) claim() error {
+	address, err := w.config.Facade.ClaimVirtualIPAddress()
+	if err != nil {
+		return errors.Annotate(err, "cannot claim virtual IP address")
+	}
+	if address != w.address && address != "" {
+		logger.Infof("claimed virtual IP address %s", address)
+	}
+	w.address = address
+	return nil
+}
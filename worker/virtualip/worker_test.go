@@ -0,0 +1,128 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package virtualip_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/virtualip"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+	stub    *testing.Stub
+	facade  *mockFacade
+	tracker *mockTracker
+	config  virtualip.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.stub = &testing.Stub{}
+	s.facade = newMockFacade(s.stub, "10.20.0.254")
+	s.tracker = newMockTracker()
+	s.config = virtualip.Config{
+		Facade:  s.facade,
+		Tracker: s.tracker,
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	config := s.config
+	config.Facade = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil Facade not valid")
+
+	config = s.config
+	config.Tracker = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil Tracker not valid")
+}
+
+func (s *WorkerSuite) TestWatchError(c *gc.C) {
+	s.stub.SetErrors(errors.New("boom"))
+	w, err := virtualip.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "boom")
+}
+
+func (s *WorkerSuite) TestClaimsWhenLeader(c *gc.C) {
+	w, err := virtualip.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.sendLeadership(c, s.tracker.leader)
+	s.assertClaim(c)
+	s.sendChange(c)
+	s.assertClaim(c)
+}
+
+func (s *WorkerSuite) TestNoClaimAsMinion(c *gc.C) {
+	w, err := virtualip.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.sendChange(c)
+	s.assertNoClaim(c)
+
+	s.sendLeadership(c, s.tracker.leader)
+	s.assertClaim(c)
+	s.sendLeadership(c, s.tracker.minion)
+	s.sendChange(c)
+	s.assertNoClaim(c)
+
+	s.sendLeadership(c, s.tracker.leader)
+	s.assertClaim(c)
+}
+
+func (s *WorkerSuite) TestClaimError(c *gc.C) {
+	s.stub.SetErrors(nil, errors.New("boom"))
+	w, err := virtualip.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.sendLeadership(c, s.tracker.leader)
+	err = workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "cannot claim virtual IP address: boom")
+}
+
+func (s *WorkerSuite) sendLeadership(c *gc.C, ch chan bool) {
+	select {
+	case ch <- true:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out sending leadership change")
+	}
+}
+
+func (s *WorkerSuite) sendChange(c *gc.C) {
+	select {
+	case s.facade.changes <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out sending application change")
+	}
+}
+
+func (s *WorkerSuite) assertClaim(c *gc.C) {
+	select {
+	case address := <-s.facade.claims:
+		c.Check(address, gc.Equals, "10.20.0.254")
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for claim")
+	}
+}
+
+func (s *WorkerSuite) assertNoClaim(c *gc.C) {
+	select {
+	case <-s.facade.claims:
+		c.Fatalf("unexpected claim")
+	case <-time.After(coretesting.ShortWait):
+	}
+}